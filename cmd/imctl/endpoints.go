@@ -2,9 +2,15 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
 
+	"inetmock.icb4dc0.de/inetmock/internal/format"
 	rpcv1 "inetmock.icb4dc0.de/inetmock/pkg/rpc/v1"
 )
 
@@ -22,10 +28,90 @@ var (
 			return runRestartEndpoints()
 		},
 	}
+
+	reloadEndpointsCmd = &cobra.Command{
+		Use:          "reload",
+		Short:        "Reload the listener configuration and restart only changed listener groups",
+		SilenceUsage: true,
+		RunE: func(*cobra.Command, []string) error {
+			return runReloadEndpoints()
+		},
+	}
+)
+
+var (
+	createListenerArgs struct {
+		Protocol        string
+		ListenAddresses []string
+		Port            uint16
+		EndpointName    string
+		Handler         string
+		TLS             bool
+		SNI             []string
+		ClientAuth      string
+		ClientCAFiles   []string
+		OptionsFile     string
+		Start           bool
+	}
+	updateOptionsFile string
+
+	createEndpointCmd = &cobra.Command{
+		Use:          "create [group name]",
+		Short:        "Create a listener group with a single endpoint",
+		Long:         `If no port or port 0 is given, the OS chooses a random port that is printed after the group was created.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCreateEndpoint(args[0])
+		},
+	}
+
+	deleteEndpointCmd = &cobra.Command{
+		Use:          "delete [group name]",
+		Aliases:      []string{"rm", "del"},
+		Short:        "Stop and remove a listener group",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runDeleteEndpoint(args[0])
+		},
+	}
+
+	updateEndpointOptionsCmd = &cobra.Command{
+		Use:          "update-options [group name] [endpoint name]",
+		Short:        "Replace the options of an endpoint and restart its listener group",
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runUpdateEndpointOptions(args[0], args[1])
+		},
+	}
 )
 
 func init() {
-	endpointsCmd.AddCommand(restartEndpointsCmd)
+	createEndpointCmd.Flags().StringVar(&createListenerArgs.Protocol, "protocol", "tcp", "Protocol of the listener - tcp, tcp4, tcp6, udp, udp4 or udp6")
+	createEndpointCmd.Flags().StringSliceVar(&createListenerArgs.ListenAddresses, "listen-address", nil, "IP addresses the listener should bind to - might be passed multiple times")
+	createEndpointCmd.Flags().Uint16Var(&createListenerArgs.Port, "port", 0, "Port the listener should bind to - 0 means random port")
+	createEndpointCmd.Flags().StringVar(&createListenerArgs.EndpointName, "endpoint", "", "Name of the endpoint - defaults to the handler name")
+	createEndpointCmd.Flags().StringVar(&createListenerArgs.Handler, "handler", "", "Name of the protocol handler e.g. http_mock")
+	createEndpointCmd.Flags().BoolVar(&createListenerArgs.TLS, "tls", false, "Terminate TLS for the endpoint")
+	createEndpointCmd.Flags().StringSliceVar(&createListenerArgs.SNI, "sni", nil, "Server name patterns TLS connections are routed to the endpoint by - might be passed multiple times")
+	createEndpointCmd.Flags().StringVar(&createListenerArgs.ClientAuth, "client-auth", "", "Client certificate auth of the TLS endpoint - none, request or require")
+	createEndpointCmd.Flags().StringSliceVar(&createListenerArgs.ClientCAFiles, "client-ca", nil, "PEM file of a CA client certificates are verified against - might be passed multiple times")
+	createEndpointCmd.Flags().StringVar(&createListenerArgs.OptionsFile, "options", "", "Path to a YAML or JSON file containing the handler options")
+	createEndpointCmd.Flags().BoolVar(&createListenerArgs.Start, "start", true, "Start the listener group right away")
+	_ = createEndpointCmd.MarkFlagRequired("handler")
+
+	updateEndpointOptionsCmd.Flags().StringVar(&updateOptionsFile, "options", "", "Path to a YAML or JSON file containing the handler options")
+	_ = updateEndpointOptionsCmd.MarkFlagRequired("options")
+
+	endpointsCmd.AddCommand(
+		restartEndpointsCmd,
+		reloadEndpointsCmd,
+		createEndpointCmd,
+		deleteEndpointCmd,
+		updateEndpointOptionsCmd,
+	)
 }
 
 func runRestartEndpoints() error {
@@ -36,3 +122,153 @@ func runRestartEndpoints() error {
 	_, err := endpointsClient.RestartAllGroups(ctx, new(rpcv1.RestartAllGroupsRequest))
 	return err
 }
+
+func runReloadEndpoints() error {
+	type printableResult struct {
+		Group   string
+		Action  string
+		Success bool
+		Error   string
+	}
+
+	endpointsClient := rpcv1.NewEndpointOrchestratorServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	resp, err := endpointsClient.ReloadConfig(ctx, new(rpcv1.ReloadConfigRequest))
+	if err != nil {
+		return err
+	}
+
+	out := make([]printableResult, 0, len(resp.Results))
+	for idx := range resp.Results {
+		result := resp.Results[idx]
+		out = append(out, printableResult{
+			Group:   result.GroupName,
+			Action:  strings.ToLower(strings.TrimPrefix(result.Action.String(), "RECONCILE_ACTION_")),
+			Success: result.Success,
+			Error:   result.Error,
+		})
+	}
+
+	return format.Writer(cfg.Format, os.Stdout).Write(out)
+}
+
+func runCreateEndpoint(groupName string) error {
+	options, err := readOptionsFile(createListenerArgs.OptionsFile)
+	if err != nil {
+		return err
+	}
+
+	clientAuthMode, err := parseClientAuthMode(createListenerArgs.ClientAuth)
+	if err != nil {
+		return err
+	}
+
+	endpointName := createListenerArgs.EndpointName
+	if endpointName == "" {
+		endpointName = createListenerArgs.Handler
+	}
+
+	endpointsClient := rpcv1.NewEndpointOrchestratorServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	resp, err := endpointsClient.CreateListenerGroup(ctx, &rpcv1.CreateListenerGroupRequest{
+		Spec: &rpcv1.ListenerSpec{
+			Name:            groupName,
+			Protocol:        createListenerArgs.Protocol,
+			ListenAddresses: createListenerArgs.ListenAddresses,
+			Port:            uint32(createListenerArgs.Port),
+			Endpoints: map[string]*rpcv1.EndpointSpec{
+				endpointName: {
+					Handler: createListenerArgs.Handler,
+					Tls:     createListenerArgs.TLS,
+					Sni:     createListenerArgs.SNI,
+					ClientAuth: &rpcv1.ClientAuthSpec{
+						Mode:    clientAuthMode,
+						CaFiles: createListenerArgs.ClientCAFiles,
+					},
+					Options: options,
+				},
+			},
+		},
+		Start: createListenerArgs.Start,
+	})
+	if err != nil {
+		return err
+	}
+
+	type printableGroup struct {
+		Name      string
+		Address   string
+		Endpoints string
+	}
+
+	return format.Writer(cfg.Format, os.Stdout).Write([]printableGroup{
+		{
+			Name:      resp.Group.Name,
+			Address:   strings.Join(resp.Group.Addresses, ", "),
+			Endpoints: strings.Join(resp.Group.Endpoints, ", "),
+		},
+	})
+}
+
+func parseClientAuthMode(mode string) (rpcv1.ClientAuthMode, error) {
+	if mode == "" {
+		return rpcv1.ClientAuthMode_CLIENT_AUTH_MODE_UNSPECIFIED, nil
+	}
+
+	if value, ok := rpcv1.ClientAuthMode_value["CLIENT_AUTH_MODE_"+strings.ToUpper(mode)]; ok {
+		return rpcv1.ClientAuthMode(value), nil
+	}
+
+	return rpcv1.ClientAuthMode_CLIENT_AUTH_MODE_UNSPECIFIED, fmt.Errorf("unknown client auth mode %s", mode)
+}
+
+func runDeleteEndpoint(groupName string) error {
+	endpointsClient := rpcv1.NewEndpointOrchestratorServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	_, err := endpointsClient.DeleteListenerGroup(ctx, &rpcv1.DeleteListenerGroupRequest{GroupName: groupName})
+	return err
+}
+
+func runUpdateEndpointOptions(groupName, endpointName string) error {
+	options, err := readOptionsFile(updateOptionsFile)
+	if err != nil {
+		return err
+	}
+
+	endpointsClient := rpcv1.NewEndpointOrchestratorServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	_, err = endpointsClient.UpdateEndpointOptions(ctx, &rpcv1.UpdateEndpointOptionsRequest{
+		GroupName:    groupName,
+		EndpointName: endpointName,
+		Options:      options,
+	})
+
+	return err
+}
+
+func readOptionsFile(path string) (*structpb.Struct, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is a superset of JSON hence the YAML decoder handles both
+	options := make(map[string]any)
+	if err = yaml.Unmarshal(raw, &options); err != nil {
+		return nil, err
+	}
+
+	return structpb.NewStruct(options)
+}
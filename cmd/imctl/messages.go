@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"inetmock.icb4dc0.de/inetmock/internal/format"
+	rpcv1 "inetmock.icb4dc0.de/inetmock/pkg/rpc/v1"
+)
+
+var (
+	injectMessageSender string
+
+	messagesCmd = &cobra.Command{
+		Use:   "messages",
+		Short: "Interact with clients of running messaging endpoints e.g. irc_mock or mqtt_mock",
+	}
+
+	injectMessageCmd = &cobra.Command{
+		Use:   "inject [group name] [endpoint name] [target] [message]",
+		Short: "Send a message to a channel or client of an endpoint",
+		Long: `The target is a channel like #botnet or the nick name of a client for IRC endpoints
+and the topic the message is published to for MQTT endpoints.
+If no sender is given, the default sender of the endpoint is used.`,
+		Args:         cobra.ExactArgs(4),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runInjectMessage(args[0], args[1], args[2], args[3])
+		},
+	}
+)
+
+type printableInjectionResult struct {
+	Target     string
+	Recipients int32
+}
+
+func init() {
+	injectMessageCmd.Flags().StringVar(&injectMessageSender, "sender", "", "Nick name the message is sent from")
+
+	messagesCmd.AddCommand(injectMessageCmd)
+}
+
+func runInjectMessage(groupName, endpointName, target, message string) error {
+	messagingClient := rpcv1.NewMessagingServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	resp, err := messagingClient.InjectMessage(ctx, &rpcv1.InjectMessageRequest{
+		GroupName:    groupName,
+		EndpointName: endpointName,
+		Target:       target,
+		Sender:       injectMessageSender,
+		Message:      message,
+	})
+	if err != nil {
+		return err
+	}
+
+	return format.Writer(cfg.Format, os.Stdout).Write([]printableInjectionResult{
+		{Target: target, Recipients: resp.Recipients},
+	})
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"inetmock.icb4dc0.de/inetmock/internal/format"
+	rpcv1 "inetmock.icb4dc0.de/inetmock/pkg/rpc/v1"
+)
+
+var (
+	insertRuleIndex int32
+
+	rulesCmd = &cobra.Command{
+		Use:   "rules",
+		Short: "Manage the rules of running endpoints",
+	}
+
+	listRulesCmd = &cobra.Command{
+		Use:          "list [group name] [endpoint name]",
+		Aliases:      []string{"ls"},
+		Short:        "List the rules of an endpoint in evaluation order",
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runListRules(args[0], args[1])
+		},
+	}
+
+	insertRuleCmd = &cobra.Command{
+		Use:          "insert [group name] [endpoint name] [rule]",
+		Short:        "Insert a rule at the given position",
+		Long:         `If no index or a negative index is given, the rule is appended to the end of the rule set.`,
+		Args:         cobra.ExactArgs(3),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runInsertRule(args[0], args[1], args[2])
+		},
+	}
+
+	replaceRuleCmd = &cobra.Command{
+		Use:          "replace [group name] [endpoint name] [index] [rule]",
+		Short:        "Replace the rule at the given position",
+		Args:         cobra.ExactArgs(4),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			index, err := parseRuleIndex(args[2])
+			if err != nil {
+				return err
+			}
+			return runReplaceRule(args[0], args[1], index, args[3])
+		},
+	}
+
+	deleteRuleCmd = &cobra.Command{
+		Use:          "delete [group name] [endpoint name] [index]",
+		Aliases:      []string{"rm", "del"},
+		Short:        "Delete the rule at the given position",
+		Args:         cobra.ExactArgs(3),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			index, err := parseRuleIndex(args[2])
+			if err != nil {
+				return err
+			}
+			return runDeleteRule(args[0], args[1], index)
+		},
+	}
+
+	validateRuleCmd = &cobra.Command{
+		Use:          "validate [group name] [endpoint name] [rule]",
+		Short:        "Check whether a rule is valid for the given endpoint without applying it",
+		Args:         cobra.ExactArgs(3),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runValidateRule(args[0], args[1], args[2])
+		},
+	}
+)
+
+type printableRule struct {
+	Index      int32
+	ID         string
+	Hits       uint64
+	LastMatch  string
+	LastClient string
+	Rule       string
+}
+
+func init() {
+	insertRuleCmd.Flags().Int32Var(&insertRuleIndex, "index", -1, "Position the rule should be inserted at - 0 means the rule is evaluated first")
+
+	rulesCmd.AddCommand(
+		listRulesCmd,
+		insertRuleCmd,
+		replaceRuleCmd,
+		deleteRuleCmd,
+		validateRuleCmd,
+	)
+}
+
+func runListRules(groupName, endpointName string) error {
+	rulesClient := rpcv1.NewRulesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	resp, err := rulesClient.ListRules(ctx, &rpcv1.ListRulesRequest{
+		GroupName:    groupName,
+		EndpointName: endpointName,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeRules(resp.Rules)
+}
+
+func runInsertRule(groupName, endpointName, rule string) error {
+	rulesClient := rpcv1.NewRulesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	resp, err := rulesClient.InsertRule(ctx, &rpcv1.InsertRuleRequest{
+		GroupName:    groupName,
+		EndpointName: endpointName,
+		Index:        insertRuleIndex,
+		Rule:         rule,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeRules(resp.Rules)
+}
+
+func runReplaceRule(groupName, endpointName string, index int32, rule string) error {
+	rulesClient := rpcv1.NewRulesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	resp, err := rulesClient.ReplaceRule(ctx, &rpcv1.ReplaceRuleRequest{
+		GroupName:    groupName,
+		EndpointName: endpointName,
+		Index:        index,
+		Rule:         rule,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeRules(resp.Rules)
+}
+
+func runDeleteRule(groupName, endpointName string, index int32) error {
+	rulesClient := rpcv1.NewRulesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	resp, err := rulesClient.DeleteRule(ctx, &rpcv1.DeleteRuleRequest{
+		GroupName:    groupName,
+		EndpointName: endpointName,
+		Index:        index,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeRules(resp.Rules)
+}
+
+func runValidateRule(groupName, endpointName, rule string) error {
+	rulesClient := rpcv1.NewRulesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(cliApp.Context(), cfg.GRPCTimeout)
+	defer cancel()
+
+	resp, err := rulesClient.ValidateRule(ctx, &rpcv1.ValidateRuleRequest{
+		GroupName:    groupName,
+		EndpointName: endpointName,
+		Rule:         rule,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !resp.Valid {
+		return errors.New(resp.Error)
+	}
+
+	return nil
+}
+
+func writeRules(rules []*rpcv1.Rule) error {
+	out := make([]printableRule, 0, len(rules))
+	for idx := range rules {
+		rule := printableRule{
+			Index:      rules[idx].Index,
+			ID:         rules[idx].Id,
+			Hits:       rules[idx].Hits,
+			LastClient: rules[idx].LastClient,
+			Rule:       rules[idx].Raw,
+		}
+		if rules[idx].LastMatch != nil {
+			rule.LastMatch = rules[idx].LastMatch.AsTime().Local().Format(time.RFC3339)
+		}
+		out = append(out, rule)
+	}
+
+	return format.Writer(cfg.Format, os.Stdout).Write(out)
+}
+
+func parseRuleIndex(raw string) (int32, error) {
+	index, err := strconv.ParseInt(raw, 10, 32)
+	return int32(index), err
+}
@@ -15,6 +15,7 @@ import (
 	"inetmock.icb4dc0.de/inetmock/netflow"
 	"inetmock.icb4dc0.de/inetmock/pkg/cert"
 	"inetmock.icb4dc0.de/inetmock/pkg/health"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
 var (
@@ -23,10 +24,13 @@ var (
 )
 
 type Data struct {
-	PCAP      string
-	Audit     string
-	FakeFiles string
-	State     string
+	PCAP       string
+	Audit      string
+	FakeFiles  string
+	State      string
+	Mail       string
+	Quarantine string
+	Syslog     string
 }
 
 func (d *Data) setup() (err error) {
@@ -36,6 +40,15 @@ func (d *Data) setup() (err error) {
 	if d.Audit, err = ensureDataDir(d.Audit); err != nil {
 		return
 	}
+	if d.Mail, err = ensureDataDir(d.Mail); err != nil {
+		return
+	}
+	if d.Quarantine, err = ensureDataDir(d.Quarantine); err != nil {
+		return
+	}
+	if d.Syslog, err = ensureDataDir(d.Syslog); err != nil {
+		return
+	}
 	var stateDir string
 	if stateDir, err = ensureDataDir(filepath.Dir(d.State)); err != nil {
 		return
@@ -83,6 +96,8 @@ type appConfig struct {
 		Firewall map[string]netflow.FirewallInterfaceConfig
 		NAT      map[string]netflow.NATTableSpec
 	}
+	// WPAD configures the PAC file served by http_mock, the WPAD names answered by dns_mock and DHCP option 252
+	WPAD wpad.Config
 	Data Data
 }
 
@@ -127,6 +142,9 @@ func main() {
 				"data.pcap":                             "/var/lib/inetmock/data/pcap",
 				"data.audit":                            "/var/lib/inetmock/data/audit",
 				"data.state":                            "/var/lib/inetmock/data/state/inetmock.db",
+				"data.mail":                             "/var/lib/inetmock/data/mail",
+				"data.quarantine":                       "/var/lib/inetmock/data/quarantine",
+				"data.syslog":                           "/var/lib/inetmock/data/syslog",
 				"caches.dns.ttl":                        30 * time.Second,
 				"caches.dns.initialCapacity":            500,
 				"tls.curve":                             cert.CurveTypeP256,
@@ -7,7 +7,9 @@ import (
 	"io/fs"
 	"net"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/soheilhy/cmux"
@@ -27,10 +29,29 @@ import (
 	"inetmock.icb4dc0.de/inetmock/protocols/dns"
 	"inetmock.icb4dc0.de/inetmock/protocols/dns/doh"
 	dnsmock "inetmock.icb4dc0.de/inetmock/protocols/dns/mock"
+	"inetmock.icb4dc0.de/inetmock/protocols/dns/multicast"
+	"inetmock.icb4dc0.de/inetmock/protocols/ftp"
+	grpcmock "inetmock.icb4dc0.de/inetmock/protocols/grpc/mock"
 	"inetmock.icb4dc0.de/inetmock/protocols/http/mock"
 	"inetmock.icb4dc0.de/inetmock/protocols/http/proxy"
+	"inetmock.icb4dc0.de/inetmock/protocols/irc"
+	"inetmock.icb4dc0.de/inetmock/protocols/ldap"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail/imap"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail/pop3"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail/smtp"
 	"inetmock.icb4dc0.de/inetmock/protocols/metrics"
+	"inetmock.icb4dc0.de/inetmock/protocols/mqtt"
+	"inetmock.icb4dc0.de/inetmock/protocols/ntp"
+	"inetmock.icb4dc0.de/inetmock/protocols/passthrough"
 	"inetmock.icb4dc0.de/inetmock/protocols/pprof"
+	"inetmock.icb4dc0.de/inetmock/protocols/raw"
+	"inetmock.icb4dc0.de/inetmock/protocols/shell/ssh"
+	"inetmock.icb4dc0.de/inetmock/protocols/shell/telnet"
+	"inetmock.icb4dc0.de/inetmock/protocols/smallservices"
+	"inetmock.icb4dc0.de/inetmock/protocols/snmp"
+	"inetmock.icb4dc0.de/inetmock/protocols/syslog"
+	"inetmock.icb4dc0.de/inetmock/protocols/tftp"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
 const (
@@ -107,7 +128,27 @@ func startINetMock(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	setupEndpointHandlers(registry, appLogger, eventStream, certStore, stateStore, fakeFileFS, checker)
+	var wpadService *wpad.Service
+	if cfg.WPAD.Enabled() {
+		if wpadService, err = wpad.New(cfg.WPAD, cfg.Listeners); err != nil {
+			appLogger.Error("Failed to setup WPAD", zap.Error(err))
+			return err
+		}
+	}
+
+	setupEndpointHandlers(
+		registry,
+		appLogger,
+		eventStream,
+		certStore,
+		stateStore,
+		fakeFileFS,
+		cfg.Data.Mail,
+		cfg.Data.Quarantine,
+		cfg.Data.Syslog,
+		checker,
+		wpadService,
+	)
 
 	serverBuilder := endpoint.NewServerBuilder(certStore.TLSConfig(), registry, appLogger.Named("orchestrator"))
 	srv := serverBuilder.Server()
@@ -122,6 +163,7 @@ func startINetMock(_ *cobra.Command, _ []string) error {
 	nat := netflow.NewNAT(sinkOption)
 
 	toClose = append(toClose, firewall, nat)
+	reloader := configReloader(appLogger.Named("reloader"), serverBuilder)
 	rpcAPI := rpc.NewINetMockAPI(
 		cfg.APIURL(),
 		appLogger,
@@ -130,14 +172,13 @@ func startINetMock(_ *cobra.Command, _ []string) error {
 		firewall,
 		nat,
 		srv,
+		serverBuilder,
+		reloader,
 		cfg.Data.Audit,
 		cfg.Data.PCAP,
 	)
 
-	for name, spec := range cfg.Listeners {
-		if spec.Name == "" {
-			spec.Name = name
-		}
+	for _, spec := range listenerSpecs(cfg.Listeners) {
 		if err := serverBuilder.ConfigureGroup(spec); err != nil {
 			appLogger.Error("Failed to register listener", zap.Error(err))
 			return err
@@ -153,6 +194,7 @@ func startINetMock(_ *cobra.Command, _ []string) error {
 	startGroupsCancel()
 
 	srv.ShutdownOnCancel(serverApp.Context())
+	reloadOnSignal(serverApp.Context(), appLogger, reloader)
 
 	if err := rpcAPI.StartServer(); err != nil {
 		serverApp.Shutdown()
@@ -179,6 +221,71 @@ func startINetMock(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+func listenerSpecs(listeners map[string]endpoint.ListenerSpec) []endpoint.ListenerSpec {
+	specs := make([]endpoint.ListenerSpec, 0, len(listeners))
+	for name, spec := range listeners {
+		if spec.Name == "" {
+			spec.Name = name
+		}
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
+func configReloader(logger logging.Logger, builder *endpoint.ServerBuilder) endpoint.ConfigReloader {
+	return endpoint.ConfigReloaderFunc(func(ctx context.Context) ([]endpoint.GroupReconcileResult, error) {
+		logger.Info("Reloading listener configuration")
+
+		var reloadedCfg appConfig
+		if err := serverApp.ReadConfig(&reloadedCfg); err != nil {
+			logger.Error("Failed to read config", zap.Error(err))
+			return nil, err
+		}
+
+		results := builder.Reconcile(ctx, listenerSpecs(reloadedCfg.Listeners))
+		for idx := range results {
+			if results[idx].Err != nil {
+				logger.Error(
+					"Failed to reconcile listener group",
+					zap.String("group_name", results[idx].GroupName),
+					zap.Stringer("action", results[idx].Action),
+					zap.Error(results[idx].Err),
+				)
+			} else {
+				logger.Info(
+					"Reconciled listener group",
+					zap.String("group_name", results[idx].GroupName),
+					zap.Stringer("action", results[idx].Action),
+				)
+			}
+		}
+
+		return results, nil
+	})
+}
+
+func reloadOnSignal(ctx context.Context, logger logging.Logger, reloader endpoint.ConfigReloader) {
+	hangUp := make(chan os.Signal, 1)
+	signal.Notify(hangUp, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(hangUp)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hangUp:
+				reloadCtx, cancel := context.WithTimeout(ctx, startGroupsTimeout)
+				if _, err := reloader.ReloadConfig(reloadCtx); err != nil {
+					logger.Error("Failed to reload config on SIGHUP", zap.Error(err))
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
 func setupEventStream(appLogger logging.Logger) (audit.EventStream, error) {
 	var evenStream audit.EventStream
 	var err error
@@ -218,14 +325,36 @@ func setupEndpointHandlers(
 	certStore cert.Store,
 	stateStore state.KVStore,
 	fakeFileFS fs.FS,
+	mailDir string,
+	quarantineDir string,
+	syslogDir string,
 	checker health.Checker,
+	wpadService *wpad.Service,
 ) {
-	mock.AddHTTPMock(registry, logger.Named("http_mock"), emitter, fakeFileFS)
-	dnsmock.AddDNSMock(registry, logger.Named("dns_mock"), emitter)
-	dhcpmock.AddDHCPMock(registry, logger.Named("dhcp_mock"), emitter, stateStore.WithSuffixes("dhcp_mock"))
+	mock.AddHTTPMock(registry, logger.Named("http_mock"), emitter, fakeFileFS, wpadService)
+	grpcmock.AddGRPCMock(registry, logger.Named("grpc_mock"), emitter)
+	dnsmock.AddDNSMock(registry, logger.Named("dns_mock"), emitter, wpadService)
+	dhcpmock.AddDHCPMock(registry, logger.Named("dhcp_mock"), emitter, stateStore.WithSuffixes("dhcp_mock"), wpadService)
 	doh.AddDoH(registry, logger.Named("doh_mock"), emitter)
+	multicast.AddResponders(registry, logger, emitter)
 	pprof.AddPprof(registry, logger.Named("pprof"), emitter)
 	proxy.AddHTTPProxy(registry, logger.Named("http_proxy"), emitter, certStore)
+	smtp.AddSMTPMock(registry, logger.Named("smtp_mock"), emitter, certStore, mailDir)
+	pop3.AddPOP3Mock(registry, logger.Named("pop3_mock"), emitter, certStore, fakeFileFS, mailDir)
+	imap.AddIMAPMock(registry, logger.Named("imap_mock"), emitter, certStore, fakeFileFS, mailDir)
+	ftp.AddFTPMock(registry, logger.Named("ftp_mock"), emitter, certStore, fakeFileFS, quarantineDir)
+	tftp.AddTFTPMock(registry, logger.Named("tftp_mock"), emitter, fakeFileFS, quarantineDir)
+	ntp.AddNTPMock(registry, logger.Named("ntp_mock"), emitter)
+	raw.AddRawMock(registry, logger.Named("raw_mock"), emitter, fakeFileFS)
+	smallservices.AddSmallServices(registry, logger, emitter)
+	irc.AddIRCMock(registry, logger.Named("irc_mock"), emitter)
+	mqtt.AddMQTTMock(registry, logger.Named("mqtt_mock"), emitter)
+	syslog.AddSyslogMock(registry, logger.Named("syslog_mock"), emitter, syslogDir)
+	ldap.AddLDAPMock(registry, logger.Named("ldap_mock"), emitter, certStore, fakeFileFS)
+	snmp.AddSNMPMock(registry, logger.Named("snmp_mock"), emitter)
+	passthrough.AddTLSPassthrough(registry, logger.Named("tls_passthrough"), emitter)
+	ssh.AddSSHMock(registry, logger.Named("ssh_mock"), emitter, stateStore.WithSuffixes("ssh_mock"))
+	telnet.AddTelnetMock(registry, logger.Named("telnet_mock"), emitter)
 	metrics.AddMetricsExporter(registry, logger.Named("metrics_exporter"), checker)
 }
 
@@ -5,19 +5,30 @@ go 1.20
 require (
 	github.com/DataDog/ebpf-manager v0.2.4
 	github.com/alecthomas/participle/v2 v2.0.0
+	github.com/beevik/ntp v0.3.0
 	github.com/bwmarrin/snowflake v0.3.0
 	github.com/cilium/ebpf v0.10.0
 	github.com/dgraph-io/badger/v4 v4.0.1
 	github.com/docker/go-connections v0.4.0
+	github.com/eclipse/paho.mqtt.golang v1.4.3
 	github.com/elazarl/goproxy v0.0.0-20221015165544-a0805db90819
+	github.com/emersion/go-imap v1.2.1
+	github.com/emersion/go-message v0.18.2
+	github.com/emersion/go-sasl v0.0.0-20200509203442-7bfe0ed36a21
+	github.com/emersion/go-smtp v0.15.0
+	github.com/go-asn1-ber/asn1-ber v1.5.4
+	github.com/go-ldap/ldap/v3 v3.4.4
 	github.com/golang/mock v1.6.0
 	github.com/google/uuid v1.3.0
 	github.com/gopacket/gopacket v1.0.1-0.20230225095122-6457da64b08f
+	github.com/gorilla/websocket v1.5.0
+	github.com/gosnmp/gosnmp v1.38.0
 	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0
 	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0
 	github.com/imdario/mergo v0.3.15
 	github.com/insomniacslk/dhcp v0.0.0-20230227183519-86f5cd589e46
 	github.com/jinzhu/copier v0.3.5
+	github.com/jlaffaye/ftp v0.2.0
 	github.com/maxatome/go-testdeep v1.12.0
 	github.com/miekg/dns v1.1.51
 	github.com/mitchellh/mapstructure v1.5.0
@@ -32,9 +43,13 @@ require (
 	github.com/valyala/tcplisten v1.0.0
 	github.com/vmihailenco/msgpack/v5 v5.3.5
 	go.uber.org/zap v1.24.0
+	golang.org/x/crypto v0.13.0
 	golang.org/x/exp v0.0.0-20230303215020-44a13b063f3e
-	golang.org/x/net v0.8.0
+	golang.org/x/net v0.15.0
 	golang.org/x/sync v0.1.0
+	golang.org/x/sys v0.13.0
+	golang.org/x/term v0.12.0
+	golang.org/x/time v0.3.0
 	google.golang.org/grpc v1.53.0
 	google.golang.org/protobuf v1.28.1
 	gopkg.in/yaml.v3 v3.0.1
@@ -44,6 +59,7 @@ replace github.com/DataDog/ebpf-manager => github.com/DataDog/ebpf-manager v0.2.
 
 require (
 	github.com/Azure/go-ansiterm v0.0.0-20230124172434-306776ec8161 // indirect
+	github.com/Azure/go-ntlmssp v0.0.0-20220621081337-cb9428e4ac1e // indirect
 	github.com/DataDog/gopsutil v1.2.2 // indirect
 	github.com/Microsoft/go-winio v0.6.0 // indirect
 	github.com/StackExchange/wmi v1.2.1 // indirect
@@ -108,9 +124,7 @@ require (
 	go.uber.org/goleak v1.2.0 // indirect
 	go.uber.org/multierr v1.9.0 // indirect
 	golang.org/x/mod v0.8.0 // indirect
-	golang.org/x/sys v0.6.0 // indirect
-	golang.org/x/text v0.8.0 // indirect
-	golang.org/x/time v0.3.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
 	golang.org/x/tools v0.6.0 // indirect
 	google.golang.org/genproto v0.0.0-20230216225411-c8e22ba71e44 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
@@ -53,11 +53,13 @@ type App interface {
 	Logger() logging.Logger
 	Context() context.Context
 	RootCommand() *cobra.Command
+	ReadConfig(target any) error
 	MustRun()
 	Shutdown()
 }
 
 type app struct {
+	spec    Spec
 	rootCmd *cobra.Command
 	ctx     context.Context
 	cancel  context.CancelFunc
@@ -90,6 +92,12 @@ func (a *app) RootCommand() *cobra.Command {
 	return a.rootCmd
 }
 
+// ReadConfig reads the configuration again from the same sources as on startup
+// and decodes it into the given target instead of the initially configured one.
+func (a *app) ReadConfig(target any) error {
+	return a.spec.readConfig(a.rootCmd, target)
+}
+
 func (a *app) Shutdown() {
 	a.logger.Info("Shutdown initiated")
 	a.cancel()
@@ -101,6 +109,7 @@ func NewApp(spec Spec) App {
 	}
 
 	a := &app{
+		spec: spec,
 		rootCmd: &cobra.Command{
 			Use:          spec.Name,
 			Short:        spec.Short,
@@ -112,7 +121,7 @@ func NewApp(spec Spec) App {
 
 	lateInitTasks := []func(cmd *cobra.Command, args []string) (err error){
 		func(*cobra.Command, []string) (err error) {
-			return spec.readConfig(a.rootCmd)
+			return spec.readConfig(a.rootCmd, spec.Config)
 		},
 		func(cmd *cobra.Command, args []string) (err error) {
 			var cwd string
@@ -170,7 +179,7 @@ func NewApp(spec Spec) App {
 	return a
 }
 
-func (s Spec) readConfig(rootCmd *cobra.Command) error {
+func (s Spec) readConfig(rootCmd *cobra.Command, target any) error {
 	viperCfg := viper.NewWithOptions()
 	viperCfg.SetConfigName("config")
 	viperCfg.SetConfigType("yaml")
@@ -204,7 +213,7 @@ func (s Spec) readConfig(rootCmd *cobra.Command) error {
 		}
 	}
 
-	if err := viperCfg.Unmarshal(s.Config, s.ConfigDecodingOptions...); err != nil {
+	if err := viperCfg.Unmarshal(target, s.ConfigDecodingOptions...); err != nil {
 		return err
 	}
 
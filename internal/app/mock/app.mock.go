@@ -76,6 +76,20 @@ func (mr *MockAppMockRecorder) MustRun() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MustRun", reflect.TypeOf((*MockApp)(nil).MustRun))
 }
 
+// ReadConfig mocks base method.
+func (m *MockApp) ReadConfig(target any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadConfig", target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadConfig indicates an expected call of ReadConfig.
+func (mr *MockAppMockRecorder) ReadConfig(target interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadConfig", reflect.TypeOf((*MockApp)(nil).ReadConfig), target)
+}
+
 // RootCommand mocks base method.
 func (m *MockApp) RootCommand() *cobra.Command {
 	m.ctrl.T.Helper()
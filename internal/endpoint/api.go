@@ -4,12 +4,19 @@ package endpoint
 
 import (
 	"context"
+	"errors"
+	"net"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/soheilhy/cmux"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
 )
 
 var (
+	ErrNotServing           = errors.New("endpoint is not serving")
+	ErrUnknownMessageTarget = errors.New("unknown message target")
+
 	WithDecodeHook = func(decodeHook mapstructure.DecodeHookFunc) UnmarshalOption {
 		return func(cfg *mapstructure.DecoderConfig) {
 			cfg.DecodeHook = decodeHook
@@ -75,10 +82,44 @@ type (
 		Matchers() []cmux.Matcher
 	}
 
+	// FallbackHandler is implemented by multiplexed handlers accepting any connection e.g. by matching with cmux.Any().
+	// Their matchers are registered after those of all other handlers of a group, hence they only receive the
+	// connections no other handler claimed.
+	FallbackHandler interface {
+		MultiplexHandler
+		Fallback() bool
+	}
+
+	// MatchWritingHandler is implemented by multiplexed handlers whose clients wait for the server before they send
+	// enough data to be matched e.g. gRPC clients waiting for the HTTP/2 SETTINGS frame of the server.
+	// Their match writers are used instead of their matchers and they are registered before all other handlers of a
+	// group, otherwise other matchers would block until the read timeout expired.
+	// Because the data written while matching also reaches connections that are not matched in the end, a match
+	// writing handler can only share its multiplexer with endpoints routed by SNI.
+	MatchWritingHandler interface {
+		MultiplexHandler
+		MatchWriters() []cmux.MatchWriter
+	}
+
 	StoppableHandler interface {
 		ProtocolHandler
 		Stop(ctx context.Context) error
 	}
+
+	// RuleManagingHandler is implemented by handlers whose rules can be modified while they are running.
+	// RuleManager returns nil as long as the handler is not started.
+	RuleManagingHandler interface {
+		ProtocolHandler
+		RuleManager() rules.Manager
+	}
+
+	// MessageInjectingHandler is implemented by handlers of chat like protocols e.g. IRC which can deliver messages
+	// of a fake sender to connected clients. InjectMessage returns the number of clients the message was delivered to,
+	// ErrNotServing if the handler is not started and ErrUnknownMessageTarget if the target does not exist.
+	MessageInjectingHandler interface {
+		ProtocolHandler
+		InjectMessage(target, sender, message string) (recipients int, err error)
+	}
 )
 
 type (
@@ -88,16 +129,33 @@ type (
 		ServeGroups(ctx context.Context) error
 		Shutdown(ctx context.Context) error
 		ShutdownGroup(ctx context.Context, groupName string) error
+		RemoveGroup(ctx context.Context, groupName string) error
+		EndpointHandler(groupName, endpointName string) (ProtocolHandler, error)
 	}
 
 	HostBuilder interface {
 		ConfigureGroup(spec ListenerSpec) (err error)
+		CreateGroup(spec ListenerSpec) (err error)
+		CreateAndServeGroup(ctx context.Context, spec ListenerSpec) (err error)
 		ConfiguredGroups() []GroupInfo
+		UpdateEndpointOptions(ctx context.Context, groupName, endpointName string, opts map[string]any) error
+	}
+
+	ConfigReloader interface {
+		ReloadConfig(ctx context.Context) ([]GroupReconcileResult, error)
 	}
 
+	ConfigReloaderFunc func(ctx context.Context) ([]GroupReconcileResult, error)
+
 	GroupInfo struct {
 		Name      string
+		Addr      net.Addr
+		Addrs     []net.Addr
 		Endpoints []string
 		Serving   bool
 	}
 )
+
+func (f ConfigReloaderFunc) ReloadConfig(ctx context.Context) ([]GroupReconcileResult, error) {
+	return f(ctx)
+}
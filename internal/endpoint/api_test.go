@@ -17,6 +17,23 @@ func (h MultiplexHandlerMock) Matchers() []cmux.Matcher {
 	return h.MultiplexMatchers
 }
 
+type FallbackHandlerMock struct {
+	MultiplexHandlerMock
+}
+
+func (FallbackHandlerMock) Fallback() bool {
+	return true
+}
+
+type MatchWritingHandlerMock struct {
+	MultiplexHandlerMock
+	MultiplexMatchWriters []cmux.MatchWriter
+}
+
+func (h MatchWritingHandlerMock) MatchWriters() []cmux.MatchWriter {
+	return h.MultiplexMatchWriters
+}
+
 type ProtocolHandlerFunc func(ctx context.Context, startupSpec *endpoint.StartupSpec) error
 
 func (p ProtocolHandlerFunc) Start(ctx context.Context, startupSpec *endpoint.StartupSpec) error {
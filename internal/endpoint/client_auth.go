@@ -0,0 +1,111 @@
+package endpoint
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	ClientAuthModeNone    = "none"
+	ClientAuthModeRequest = "request"
+	ClientAuthModeRequire = "require"
+)
+
+var (
+	ErrInvalidClientAuth     = errors.New("invalid client auth")
+	ErrConflictingClientAuth = errors.New("TLS endpoints sharing a listener have to use the same client auth")
+)
+
+// ClientAuthSpec configures whether clients of a TLS endpoint have to present a certificate.
+// Mode is one of none (default), request or require.
+// The certificates are verified against the CAs in the PEM files of CAFiles, if no CA is configured every
+// certificate is accepted blindly.
+type ClientAuthSpec struct {
+	Mode    string
+	CAFiles []string `mapstructure:"caFiles"`
+}
+
+// Load parses the mode and reads the configured CAs.
+// It returns nil if clients are not asked for a certificate.
+func (s ClientAuthSpec) Load() (*ClientAuth, error) {
+	var (
+		mode     = strings.ToLower(s.Mode)
+		verify   = len(s.CAFiles) > 0
+		authType tls.ClientAuthType
+	)
+
+	switch {
+	case mode == "" || mode == ClientAuthModeNone:
+		if verify {
+			return nil, fmt.Errorf("%w: caFiles require mode %s or %s", ErrInvalidClientAuth, ClientAuthModeRequest, ClientAuthModeRequire)
+		}
+		return nil, nil
+	case mode == ClientAuthModeRequest && verify:
+		authType = tls.VerifyClientCertIfGiven
+	case mode == ClientAuthModeRequest:
+		authType = tls.RequestClientCert
+	case mode == ClientAuthModeRequire && verify:
+		authType = tls.RequireAndVerifyClientCert
+	case mode == ClientAuthModeRequire:
+		authType = tls.RequireAnyClientCert
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidClientAuth, s.Mode)
+	}
+
+	auth := &ClientAuth{Type: authType}
+	if !verify {
+		return auth, nil
+	}
+
+	auth.CAs = x509.NewCertPool()
+	for _, caFile := range s.CAFiles {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidClientAuth, err)
+		}
+
+		if !auth.CAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificate found in %s", ErrInvalidClientAuth, caFile)
+		}
+	}
+
+	return auth, nil
+}
+
+// ClientAuth is the loaded client certificate policy of an endpoint.
+// CAs is nil if certificates are accepted without verification.
+type ClientAuth struct {
+	Type tls.ClientAuthType
+	CAs  *x509.CertPool
+}
+
+// TLSConfig returns a copy of base applying the client certificate policy.
+// A nil ClientAuth returns base as is.
+func (a *ClientAuth) TLSConfig(base *tls.Config) *tls.Config {
+	if a == nil {
+		return base
+	}
+
+	cfg := base.Clone()
+	cfg.ClientAuth = a.Type
+	cfg.ClientCAs = a.CAs
+
+	return cfg
+}
+
+// Equal checks whether both policies request the same certificates and verify them against the same CAs
+func (a *ClientAuth) Equal(other *ClientAuth) bool {
+	if a == nil || other == nil {
+		return a == other
+	}
+
+	if a.CAs == nil || other.CAs == nil {
+		return a.Type == other.Type && a.CAs == other.CAs
+	}
+
+	return a.Type == other.Type && a.CAs.Equal(other.CAs)
+}
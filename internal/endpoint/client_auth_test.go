@@ -0,0 +1,173 @@
+package endpoint_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+)
+
+func TestClientAuthSpec_Load(t *testing.T) {
+	t.Parallel()
+	var (
+		caFile      = test.NewClientCA(t).CAFile
+		invalidFile = filepath.Join(t.TempDir(), "invalid.pem")
+	)
+
+	if err := os.WriteFile(invalidFile, []byte("no certificate"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		spec    endpoint.ClientAuthSpec
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "Default mode",
+			spec: endpoint.ClientAuthSpec{},
+			want: td.Nil(),
+		},
+		{
+			name: "Explicit none mode",
+			spec: endpoint.ClientAuthSpec{Mode: "None"},
+			want: td.Nil(),
+		},
+		{
+			name: "Request any certificate",
+			spec: endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequest},
+			want: &endpoint.ClientAuth{Type: tls.RequestClientCert},
+		},
+		{
+			name: "Require any certificate",
+			spec: endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire},
+			want: &endpoint.ClientAuth{Type: tls.RequireAnyClientCert},
+		},
+		{
+			name: "Request verified certificate",
+			spec: endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequest, CAFiles: []string{caFile}},
+			want: td.Struct(&endpoint.ClientAuth{Type: tls.VerifyClientCertIfGiven}, td.StructFields{"CAs": td.NotNil()}),
+		},
+		{
+			name: "Require verified certificate",
+			spec: endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire, CAFiles: []string{caFile}},
+			want: td.Struct(&endpoint.ClientAuth{Type: tls.RequireAndVerifyClientCert}, td.StructFields{"CAs": td.NotNil()}),
+		},
+		{
+			name:    "CA files without mode",
+			spec:    endpoint.ClientAuthSpec{CAFiles: []string{caFile}},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown mode",
+			spec:    endpoint.ClientAuthSpec{Mode: "demand"},
+			wantErr: true,
+		},
+		{
+			name:    "Missing CA file",
+			spec:    endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire, CAFiles: []string{filepath.Join(t.TempDir(), "ca.pem")}},
+			wantErr: true,
+		},
+		{
+			name:    "CA file without certificate",
+			spec:    endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire, CAFiles: []string{invalidFile}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.spec.Load()
+			if tt.wantErr {
+				td.Cmp(t, errors.Is(err, endpoint.ErrInvalidClientAuth), true)
+				return
+			}
+
+			if td.CmpNoError(t, err) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientAuth_TLSConfig(t *testing.T) {
+	t.Parallel()
+	base := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	var noClientAuth *endpoint.ClientAuth
+	td.CmpShallow(t, noClientAuth.TLSConfig(base), base)
+
+	cfg := (&endpoint.ClientAuth{Type: tls.RequireAnyClientCert}).TLSConfig(base)
+	td.Cmp(t, cfg.ClientAuth, tls.RequireAnyClientCert)
+	td.Cmp(t, cfg.MinVersion, uint16(tls.VersionTLS12))
+	td.Cmp(t, base.ClientAuth, tls.NoClientCert)
+}
+
+func TestClientAuth_Equal(t *testing.T) {
+	t.Parallel()
+	loadClientAuth := func(spec endpoint.ClientAuthSpec) *endpoint.ClientAuth {
+		t.Helper()
+		clientAuth, err := spec.Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		return clientAuth
+	}
+
+	caFile := test.NewClientCA(t).CAFile
+	tests := []struct {
+		name  string
+		a, b  *endpoint.ClientAuth
+		equal bool
+	}{
+		{
+			name:  "Both without client auth",
+			equal: true,
+		},
+		{
+			name:  "Only one with client auth",
+			a:     &endpoint.ClientAuth{Type: tls.RequestClientCert},
+			equal: false,
+		},
+		{
+			name:  "Different modes",
+			a:     &endpoint.ClientAuth{Type: tls.RequestClientCert},
+			b:     &endpoint.ClientAuth{Type: tls.RequireAnyClientCert},
+			equal: false,
+		},
+		{
+			name:  "Same CA files",
+			a:     loadClientAuth(endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire, CAFiles: []string{caFile}}),
+			b:     loadClientAuth(endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire, CAFiles: []string{caFile}}),
+			equal: true,
+		},
+		{
+			name:  "Different CA files",
+			a:     loadClientAuth(endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire, CAFiles: []string{caFile}}),
+			b:     loadClientAuth(endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire, CAFiles: []string{test.NewClientCA(t).CAFile}}),
+			equal: false,
+		},
+		{
+			name:  "Verified and unverified",
+			a:     loadClientAuth(endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire, CAFiles: []string{caFile}}),
+			b:     &endpoint.ClientAuth{Type: tls.RequireAndVerifyClientCert},
+			equal: false,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			td.Cmp(t, tt.a.Equal(tt.b), tt.equal)
+			td.Cmp(t, tt.b.Equal(tt.a), tt.equal)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package endpoint
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/soheilhy/cmux"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+const (
+	recordHeaderLen          = 5
+	handshakeHeaderLen       = 4
+	clientRandomLen          = 32
+	recordTypeHandshake      = 0x16
+	handshakeTypeClientHello = 0x01
+	serverNameTypeHostName   = 0x00
+
+	extensionServerName          uint16 = 0x0000
+	extensionSupportedGroups     uint16 = 0x000a
+	extensionECPointFormats      uint16 = 0x000b
+	extensionSignatureAlgorithms uint16 = 0x000d
+	extensionALPN                uint16 = 0x0010
+	extensionSupportedVersions   uint16 = 0x002b
+	// maxClientHelloLen limits the memory spent on a single ClientHello, real ones are usually smaller than 2 KiB
+	maxClientHelloLen = 1 << 16
+)
+
+var (
+	ErrNoClientHello        = errors.New("no TLS ClientHello")
+	ErrMalformedClientHello = errors.New("malformed TLS ClientHello")
+	ErrInvalidSNIPattern    = errors.New("invalid SNI pattern")
+)
+
+// ClientHello contains the fields of a TLS ClientHello relevant for routing connections before the handshake and
+// for fingerprinting clients. Version is the legacy version field, Extensions are the extension types in the order
+// they were sent. ServerName is empty if the client did not send the server_name extension.
+type ClientHello struct {
+	Version             uint16
+	CipherSuites        []uint16
+	Extensions          []uint16
+	ServerName          string
+	SupportedGroups     []uint16
+	ECPointFormats      []uint8
+	SignatureAlgorithms []uint16
+	ALPN                []string
+	SupportedVersions   []uint16
+}
+
+// ReadClientHello reads the TLS records containing the ClientHello from r and parses it.
+// The ClientHello may be fragmented across multiple records.
+func ReadClientHello(r io.Reader) (*ClientHello, error) {
+	msg, err := readHandshakeMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseClientHello(msg)
+}
+
+// MatchSNI matches TLS connections whose ClientHello contains a server name matching one of the given patterns.
+// Patterns use the syntax of path.Match and are compared case-insensitive, e.g. *.example.com.
+// Connections without server name are only matched by the pattern *.
+func MatchSNI(patterns ...string) cmux.Matcher {
+	return func(r io.Reader) bool {
+		hello, err := ReadClientHello(r)
+		return err == nil && matchServerName(patterns, hello.ServerName)
+	}
+}
+
+// ValidateSNIPatterns checks whether all patterns are valid path.Match patterns
+func ValidateSNIPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			return fmt.Errorf("%w: empty pattern", ErrInvalidSNIPattern)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidSNIPattern, pattern)
+		}
+	}
+
+	return nil
+}
+
+func matchServerName(patterns []string, serverName string) bool {
+	serverName = strings.ToLower(strings.TrimSuffix(serverName, "."))
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), serverName); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readHandshakeMessage reads handshake records until the first handshake message is complete
+// and returns its body without the handshake header
+func readHandshakeMessage(r io.Reader) ([]byte, error) {
+	var (
+		header = make([]byte, recordHeaderLen)
+		msg    []byte
+	)
+
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, err
+		}
+
+		// the record version is 0x0301 for compatibility reasons by most clients but at least 0x0300 by all of them
+		fragmentLen := binary.BigEndian.Uint16(header[3:])
+		if header[0] != recordTypeHandshake || header[1] != 0x03 || fragmentLen == 0 {
+			return nil, ErrNoClientHello
+		}
+
+		fragment := make([]byte, fragmentLen)
+		if _, err := io.ReadFull(r, fragment); err != nil {
+			return nil, err
+		}
+		msg = append(msg, fragment...)
+
+		if len(msg) < handshakeHeaderLen {
+			continue
+		}
+
+		if msg[0] != handshakeTypeClientHello {
+			return nil, ErrNoClientHello
+		}
+
+		msgLen := int(msg[1])<<16 | int(msg[2])<<8 | int(msg[3])
+		if msgLen > maxClientHelloLen {
+			return nil, fmt.Errorf("%w: exceeds %d bytes", ErrMalformedClientHello, maxClientHelloLen)
+		}
+
+		if len(msg) >= handshakeHeaderLen+msgLen {
+			return msg[handshakeHeaderLen : handshakeHeaderLen+msgLen], nil
+		}
+	}
+}
+
+func parseClientHello(msg []byte) (*ClientHello, error) {
+	var (
+		s                             = cryptobyte.String(msg)
+		hello                         = new(ClientHello)
+		sessionID, ciphers, comp, ext cryptobyte.String
+	)
+
+	if !s.ReadUint16(&hello.Version) ||
+		!s.Skip(clientRandomLen) ||
+		!s.ReadUint8LengthPrefixed(&sessionID) ||
+		!s.ReadUint16LengthPrefixed(&ciphers) ||
+		!s.ReadUint8LengthPrefixed(&comp) {
+		return nil, ErrMalformedClientHello
+	}
+
+	var ok bool
+	if hello.CipherSuites, ok = readUint16List(ciphers); !ok {
+		return nil, ErrMalformedClientHello
+	}
+
+	// extensions are optional
+	if s.Empty() {
+		return hello, nil
+	}
+
+	if !s.ReadUint16LengthPrefixed(&ext) || !s.Empty() {
+		return nil, ErrMalformedClientHello
+	}
+
+	for !ext.Empty() {
+		var (
+			extType uint16
+			extData cryptobyte.String
+		)
+		if !ext.ReadUint16(&extType) || !ext.ReadUint16LengthPrefixed(&extData) {
+			return nil, ErrMalformedClientHello
+		}
+
+		hello.Extensions = append(hello.Extensions, extType)
+		if !hello.parseExtension(extType, extData) {
+			return nil, fmt.Errorf("%w: extension %d", ErrMalformedClientHello, extType)
+		}
+	}
+
+	return hello, nil
+}
+
+func (h *ClientHello) parseExtension(extType uint16, data cryptobyte.String) (ok bool) {
+	var list cryptobyte.String
+
+	switch extType {
+	case extensionServerName:
+		if !data.ReadUint16LengthPrefixed(&list) {
+			return false
+		}
+		for !list.Empty() {
+			var (
+				nameType uint8
+				name     cryptobyte.String
+			)
+			if !list.ReadUint8(&nameType) || !list.ReadUint16LengthPrefixed(&name) {
+				return false
+			}
+			if nameType == serverNameTypeHostName {
+				h.ServerName = string(name)
+			}
+		}
+	case extensionSupportedGroups:
+		if !data.ReadUint16LengthPrefixed(&list) {
+			return false
+		}
+		h.SupportedGroups, ok = readUint16List(list)
+		return ok
+	case extensionECPointFormats:
+		if !data.ReadUint8LengthPrefixed(&list) {
+			return false
+		}
+		h.ECPointFormats = append([]uint8(nil), list...)
+	case extensionSignatureAlgorithms:
+		if !data.ReadUint16LengthPrefixed(&list) {
+			return false
+		}
+		h.SignatureAlgorithms, ok = readUint16List(list)
+		return ok
+	case extensionALPN:
+		if !data.ReadUint16LengthPrefixed(&list) {
+			return false
+		}
+		for !list.Empty() {
+			var proto cryptobyte.String
+			if !list.ReadUint8LengthPrefixed(&proto) {
+				return false
+			}
+			h.ALPN = append(h.ALPN, string(proto))
+		}
+	case extensionSupportedVersions:
+		if !data.ReadUint8LengthPrefixed(&list) {
+			return false
+		}
+		h.SupportedVersions, ok = readUint16List(list)
+		return ok
+	}
+
+	return true
+}
+
+func readUint16List(s cryptobyte.String) (values []uint16, ok bool) {
+	for !s.Empty() {
+		var value uint16
+		if !s.ReadUint16(&value) {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+
+	return values, true
+}
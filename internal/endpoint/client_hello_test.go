@@ -0,0 +1,202 @@
+package endpoint_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+func TestReadClientHello(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		data    []byte
+		want    any
+		wantErr error
+	}{
+		{
+			name: "ClientHello with server name",
+			data: captureClientHello(t, &tls.Config{ServerName: "www.example.com"}),
+			want: td.Struct(new(endpoint.ClientHello), td.StructFields{"ServerName": "www.example.com"}),
+		},
+		{
+			name: "ClientHello without server name",
+			data: captureClientHello(t, &tls.Config{ServerName: "192.0.2.10", InsecureSkipVerify: true}),
+			want: td.Struct(new(endpoint.ClientHello), td.StructFields{"ServerName": ""}),
+		},
+		{
+			name: "ClientHello fragmented across records",
+			data: fragment(captureClientHello(t, &tls.Config{ServerName: "mail.example.org"}), 64),
+			want: td.Struct(new(endpoint.ClientHello), td.StructFields{"ServerName": "mail.example.org"}),
+		},
+		{
+			name:    "Plain text HTTP request",
+			data:    []byte("GET / HTTP/1.1\r\nHost: www.example.com\r\n\r\n"),
+			wantErr: endpoint.ErrNoClientHello,
+		},
+		{
+			name:    "Truncated ClientHello",
+			data:    captureClientHello(t, &tls.Config{ServerName: "www.example.com"})[:100],
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "Malformed ClientHello",
+			data:    []byte{0x16, 0x03, 0x01, 0x00, 0x08, 0x01, 0x00, 0x00, 0x04, 0x03, 0x03, 0x00, 0x00},
+			wantErr: endpoint.ErrMalformedClientHello,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := endpoint.ReadClientHello(bytes.NewReader(tt.data))
+			if tt.wantErr != nil {
+				td.Cmp(t, errors.Is(err, tt.wantErr), true)
+				return
+			}
+
+			if td.CmpNoError(t, err) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchSNI(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		patterns []string
+		config   *tls.Config
+		want     bool
+	}{
+		{
+			name:     "Exact match",
+			patterns: []string{"www.example.com"},
+			config:   &tls.Config{ServerName: "www.example.com"},
+			want:     true,
+		},
+		{
+			name:     "Wildcard match ignoring case",
+			patterns: []string{"*.EXAMPLE.com"},
+			config:   &tls.Config{ServerName: "api.example.com"},
+			want:     true,
+		},
+		{
+			name:     "Second pattern matches",
+			patterns: []string{"*.example.com", "update.vendor.?o"},
+			config:   &tls.Config{ServerName: "update.vendor.io"},
+			want:     true,
+		},
+		{
+			name:     "No match",
+			patterns: []string{"*.example.com"},
+			config:   &tls.Config{ServerName: "example.com"},
+			want:     false,
+		},
+		{
+			name:     "Catch all matches missing server name",
+			patterns: []string{"*"},
+			config:   &tls.Config{ServerName: "192.0.2.10", InsecureSkipVerify: true},
+			want:     true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			matcher := endpoint.MatchSNI(tt.patterns...)
+			td.Cmp(t, matcher(bytes.NewReader(captureClientHello(t, tt.config))), tt.want)
+		})
+	}
+}
+
+func TestValidateSNIPatterns(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  bool
+	}{
+		{
+			name:     "Valid patterns",
+			patterns: []string{"*.example.com", "www.example.[a-z][a-z]"},
+		},
+		{
+			name:     "Empty pattern",
+			patterns: []string{""},
+			wantErr:  true,
+		},
+		{
+			name:     "Malformed pattern",
+			patterns: []string{"www.example.[com"},
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := endpoint.ValidateSNIPatterns(tt.patterns); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSNIPatterns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// captureClientHello returns the records a TLS client sends to initiate the handshake
+func captureClientHello(tb testing.TB, cfg *tls.Config) []byte {
+	tb.Helper()
+	client, server := net.Pipe()
+	tb.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+
+	go func() {
+		_ = client.SetDeadline(time.Now().Add(time.Second))
+		_ = tls.Client(client, cfg).Handshake()
+	}()
+
+	_ = server.SetReadDeadline(time.Now().Add(time.Second))
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(server, header); err != nil {
+		tb.Fatalf("io.ReadFull() error = %v", err)
+	}
+
+	record := make([]byte, int(header[3])<<8|int(header[4]))
+	if _, err := io.ReadFull(server, record); err != nil {
+		tb.Fatalf("io.ReadFull() error = %v", err)
+	}
+
+	return append(header, record...)
+}
+
+// fragment splits the handshake message of a single record into multiple records of the given size
+func fragment(record []byte, size int) []byte {
+	var (
+		header = record[:5]
+		msg    = record[5:]
+		out    []byte
+	)
+
+	for len(msg) > 0 {
+		n := size
+		if len(msg) < n {
+			n = len(msg)
+		}
+		out = append(out, header[0], header[1], header[2], byte(n>>8), byte(n))
+		out = append(out, msg[:n]...)
+		msg = msg[n:]
+	}
+
+	return out
+}
@@ -0,0 +1,65 @@
+package endpoint
+
+import (
+	"net"
+	"sync"
+)
+
+// ConnTracker keeps track of the connections a handler accepted from its listener.
+// Closing the listener doesn't affect connections that are already accepted, hence stoppable handlers close them
+// explicitly with CloseAll.
+// The zero value is closed i.e. it rejects all connections until Open is called when the handler is started.
+type ConnTracker struct {
+	lock  sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+// Open starts accepting connections e.g. when the handler is (re-)started
+func (t *ConnTracker) Open() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.conns = make(map[net.Conn]struct{})
+}
+
+// IsOpen reports whether the tracker accepts connections i.e. whether the handler is serving
+func (t *ConnTracker) IsOpen() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.conns != nil
+}
+
+// Track registers the given connection.
+// It returns false if the tracker is closed already, the connection has to be closed by the caller in this case.
+func (t *ConnTracker) Track(conn net.Conn) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.conns == nil {
+		return false
+	}
+
+	t.conns[conn] = struct{}{}
+	return true
+}
+
+// Untrack removes a connection that was closed by the handler itself
+func (t *ConnTracker) Untrack(conn net.Conn) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.conns, conn)
+}
+
+// CloseAll closes all tracked connections and rejects any further connections until the tracker is opened again
+func (t *ConnTracker) CloseAll() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for conn := range t.conns {
+		_ = conn.Close()
+	}
+
+	t.conns = nil
+}
@@ -0,0 +1,44 @@
+package endpoint_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+func TestConnTracker(t *testing.T) {
+	t.Parallel()
+	var (
+		tracker   endpoint.ConnTracker
+		tracked   = new(closeRecordingConn)
+		untracked = new(closeRecordingConn)
+	)
+
+	td.Cmp(t, tracker.IsOpen(), false)
+	td.Cmp(t, tracker.Track(tracked), false, "tracker has to be opened first")
+
+	tracker.Open()
+	td.Cmp(t, tracker.IsOpen(), true)
+	td.Cmp(t, tracker.Track(tracked), true)
+	td.Cmp(t, tracker.Track(untracked), true)
+	tracker.Untrack(untracked)
+
+	tracker.CloseAll()
+	td.Cmp(t, tracker.IsOpen(), false)
+	td.Cmp(t, tracked.closed, true)
+	td.Cmp(t, untracked.closed, false)
+	td.Cmp(t, tracker.Track(untracked), false, "tracker rejects connections after CloseAll")
+}
+
+type closeRecordingConn struct {
+	net.Conn
+	closed bool
+}
+
+func (c *closeRecordingConn) Close() error {
+	c.closed = true
+	return nil
+}
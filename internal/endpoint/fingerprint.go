@@ -0,0 +1,186 @@
+package endpoint
+
+import (
+	"crypto/md5" //nolint:gosec // JA3 is defined as MD5 hash
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+)
+
+const emptyJA4Hash = "000000000000"
+
+// JA3 returns the JA3 string of the ClientHello: the decimal version, cipher suites, extensions, supported groups
+// and EC point formats, GREASE values are ignored.
+func (h *ClientHello) JA3() string {
+	fields := []string{
+		strconv.Itoa(int(h.Version)),
+		joinUint16(withoutGREASE(h.CipherSuites), "-", decimal),
+		joinUint16(withoutGREASE(h.Extensions), "-", decimal),
+		joinUint16(withoutGREASE(h.SupportedGroups), "-", decimal),
+	}
+
+	formats := make([]string, 0, len(h.ECPointFormats))
+	for _, f := range h.ECPointFormats {
+		formats = append(formats, strconv.Itoa(int(f)))
+	}
+
+	return strings.Join(append(fields, strings.Join(formats, "-")), ",")
+}
+
+// JA3Hash returns the hex encoded MD5 hash of the JA3 string
+func (h *ClientHello) JA3Hash() string {
+	//nolint:gosec // JA3 is defined as MD5 hash
+	sum := md5.Sum([]byte(h.JA3()))
+	return hex.EncodeToString(sum[:])
+}
+
+// JA4 returns the JA4 fingerprint of the ClientHello.
+// Connections are always considered to be TCP based, QUIC is not supported.
+func (h *ClientHello) JA4() string {
+	var (
+		ciphers    = withoutGREASE(h.CipherSuites)
+		extensions = withoutGREASE(h.Extensions)
+		sni        = "i"
+	)
+
+	if h.ServerName != "" {
+		sni = "d"
+	}
+
+	prefix := fmt.Sprintf("t%s%s%02d%02d%s", h.ja4Version(), sni, min99(len(ciphers)), min99(len(extensions)), h.ja4ALPN())
+
+	sortedCiphers := append([]uint16(nil), ciphers...)
+	sort.Slice(sortedCiphers, func(i, j int) bool { return sortedCiphers[i] < sortedCiphers[j] })
+
+	sortedExtensions := make([]uint16, 0, len(extensions))
+	for _, ext := range extensions {
+		if ext != extensionServerName && ext != extensionALPN {
+			sortedExtensions = append(sortedExtensions, ext)
+		}
+	}
+	sort.Slice(sortedExtensions, func(i, j int) bool { return sortedExtensions[i] < sortedExtensions[j] })
+
+	extensionsPart := joinUint16(sortedExtensions, ",", hex4)
+	if len(sortedExtensions) > 0 && len(h.SignatureAlgorithms) > 0 {
+		extensionsPart += "_" + joinUint16(withoutGREASE(h.SignatureAlgorithms), ",", hex4)
+	}
+
+	return strings.Join([]string{
+		prefix,
+		truncatedHash(joinUint16(sortedCiphers, ",", hex4)),
+		truncatedHash(extensionsPart),
+	}, "_")
+}
+
+// Fingerprint converts the ClientHello to the fingerprint recorded in the audit TLS details
+func (h *ClientHello) Fingerprint() *audit.TLSFingerprint {
+	return &audit.TLSFingerprint{
+		JA3:             h.JA3(),
+		JA3Hash:         h.JA3Hash(),
+		JA4:             h.JA4(),
+		ALPN:            h.ALPN,
+		SupportedGroups: withoutGREASE(h.SupportedGroups),
+	}
+}
+
+func (h *ClientHello) ja4Version() string {
+	// the supported_versions extension supersedes the legacy version field
+	version := h.Version
+	if supported := withoutGREASE(h.SupportedVersions); len(supported) > 0 {
+		version = 0
+		for _, v := range supported {
+			if v > version {
+				version = v
+			}
+		}
+	}
+
+	switch version {
+	case 0x0304:
+		return "13"
+	case 0x0303:
+		return "12"
+	case 0x0302:
+		return "11"
+	case 0x0301:
+		return "10"
+	case 0x0300:
+		return "s3"
+	default:
+		return "00"
+	}
+}
+
+func (h *ClientHello) ja4ALPN() string {
+	if len(h.ALPN) == 0 || h.ALPN[0] == "" {
+		return "00"
+	}
+
+	proto := h.ALPN[0]
+	first, last := proto[0], proto[len(proto)-1]
+	if isAlphanumeric(first) && isAlphanumeric(last) {
+		return string([]byte{first, last})
+	}
+
+	encoded := hex.EncodeToString([]byte(proto))
+	return string([]byte{encoded[0], encoded[len(encoded)-1]})
+}
+
+// isGREASE checks whether v is one of the reserved values of RFC 8701 clients send to prevent ossification
+func isGREASE(v uint16) bool {
+	return v&0x0f0f == 0x0a0a && v>>8 == v&0xff
+}
+
+func withoutGREASE(values []uint16) []uint16 {
+	filtered := make([]uint16, 0, len(values))
+	for _, v := range values {
+		if !isGREASE(v) {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered
+}
+
+func decimal(v uint16) string {
+	return strconv.Itoa(int(v))
+}
+
+func hex4(v uint16) string {
+	return fmt.Sprintf("%04x", v)
+}
+
+func joinUint16(values []uint16, sep string, format func(uint16) string) string {
+	formatted := make([]string, 0, len(values))
+	for _, v := range values {
+		formatted = append(formatted, format(v))
+	}
+
+	return strings.Join(formatted, sep)
+}
+
+func truncatedHash(s string) string {
+	if s == "" {
+		return emptyJA4Hash
+	}
+
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:len(emptyJA4Hash)]
+}
+
+func min99(n int) int {
+	if n > 99 {
+		return 99
+	}
+
+	return n
+}
+
+func isAlphanumeric(b byte) bool {
+	return b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
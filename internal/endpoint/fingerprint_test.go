@@ -0,0 +1,154 @@
+package endpoint_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+)
+
+func TestClientHello_Fingerprint(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		hello *endpoint.ClientHello
+		want  *audit.TLSFingerprint
+	}{
+		{
+			name: "TLS 1.3 ClientHello with GREASE values",
+			hello: &endpoint.ClientHello{
+				Version:             0x0303,
+				CipherSuites:        []uint16{0x0a0a, 0x1301, 0x1302},
+				Extensions:          []uint16{0x1a1a, 0x0000, 0x000a, 0x000b, 0x000d, 0x0010, 0x002b},
+				ServerName:          "www.example.com",
+				SupportedGroups:     []uint16{0x2a2a, 29, 23},
+				ECPointFormats:      []uint8{0},
+				SignatureAlgorithms: []uint16{0x0403, 0x0804},
+				ALPN:                []string{"h2", "http/1.1"},
+				SupportedVersions:   []uint16{0x3a3a, 0x0304, 0x0303},
+			},
+			want: &audit.TLSFingerprint{
+				JA3:             "771,4865-4866,0-10-11-13-16-43,29-23,0",
+				JA3Hash:         "8b85ec5fe3da506907f3cac65cd06803",
+				JA4:             "t13d0206h2_62ed6f6ca7ad_fb71836bce29",
+				ALPN:            []string{"h2", "http/1.1"},
+				SupportedGroups: []uint16{29, 23},
+			},
+		},
+		{
+			name: "Legacy ClientHello without extensions",
+			hello: &endpoint.ClientHello{
+				Version:      0x0301,
+				CipherSuites: []uint16{0x002f},
+			},
+			want: &audit.TLSFingerprint{
+				JA3:             "769,47,,,",
+				JA3Hash:         "b02be259814e870a469a20ce9b2a7900",
+				JA4:             "t10i010000_ba72b8082249_000000000000",
+				SupportedGroups: []uint16{},
+			},
+		},
+		{
+			name: "Non alphanumeric ALPN",
+			hello: &endpoint.ClientHello{
+				Version: 0x0303,
+				ALPN:    []string{"\xabx"},
+			},
+			want: &audit.TLSFingerprint{
+				JA3:             "771,,,,",
+				JA3Hash:         "bddda940f9963577c41d7c28b1a5f65f",
+				JA4:             "t12i0000a8_000000000000_000000000000",
+				ALPN:            []string{"\xabx"},
+				SupportedGroups: []uint16{},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			td.Cmp(t, tt.hello.Fingerprint(), tt.want)
+		})
+	}
+}
+
+func TestReadClientHello_Fingerprint(t *testing.T) {
+	t.Parallel()
+	hello, err := endpoint.ReadClientHello(bytes.NewReader(captureClientHello(t, &tls.Config{
+		ServerName: "www.example.com",
+		NextProtos: []string{"h2", "http/1.1"},
+	})))
+	if !td.CmpNoError(t, err) {
+		return
+	}
+
+	td.Cmp(t, hello.Fingerprint(), td.Struct(new(audit.TLSFingerprint), td.StructFields{
+		"JA3":             td.Re(`^771,[0-9-]+,0-[0-9-]+,[0-9-]+,0$`),
+		"JA3Hash":         td.Re(`^[0-9a-f]{32}$`),
+		"JA4":             td.Re(`^t13d\d{4}h2_[0-9a-f]{12}_[0-9a-f]{12}$`),
+		"ALPN":            []string{"h2", "http/1.1"},
+		"SupportedGroups": td.NotEmpty(),
+	}))
+}
+
+func TestNewTLSListener(t *testing.T) {
+	t.Parallel()
+	var (
+		certStore = test.NewSelfSignedCertStore(t)
+		listener  = endpoint.NewTLSListener(test.NewTCPListener(t, "127.0.0.1:0"), certStore.TLSConfig())
+		accepted  = make(chan net.Conn, 1)
+	)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(accepted)
+			return
+		}
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		_ = conn.(*tls.Conn).Handshake()
+		accepted <- conn
+	}()
+
+	//nolint:gosec // the listener uses a self-signed certificate
+	client, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", listener.Addr().String(), &tls.Config{
+		ServerName:         "localhost",
+		NextProtos:         []string{"http/1.1"},
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("tls.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	conn, ok := <-accepted
+	if !ok {
+		t.Fatal("Failed to accept connection")
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	details, ok := audit.NewTLSDetailsFromConn(conn)
+	td.Cmp(t, ok, true)
+	td.Cmp(t, details, td.Struct(new(audit.TLSDetails), td.StructFields{
+		"ServerName": "localhost",
+		"TLSFingerprint": td.Struct(audit.TLSFingerprint{}, td.StructFields{
+			"JA4":  td.Re(`^t13d\d{4}h1_`),
+			"ALPN": []string{"http/1.1"},
+		}),
+	}))
+}
@@ -2,6 +2,7 @@ package endpoint
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"strings"
 )
@@ -9,7 +10,11 @@ import (
 var (
 	ErrUDPMultiplexer           = errors.New("UDP listeners don't support multiplexing")
 	ErrMultiplexingNotSupported = errors.New("not all handlers do support multiplexing")
+	ErrMatchWriterNotExclusive  = errors.New("handlers writing while matching can't share a listener with other handlers")
 	ErrUnsupportedProtocol      = errors.New("protocol not supported")
+	ErrInvalidListenAddress     = errors.New("invalid listen address")
+	ErrAddressFamilyMismatch    = errors.New("address family mismatch")
+	ErrUnmanagedMultipleAddrs   = errors.New("unmanaged listeners support only a single listen address")
 )
 
 type HandlerReference string
@@ -21,37 +26,144 @@ func (h HandlerReference) ToLower() HandlerReference {
 type ListenerSpec struct {
 	Name      string
 	Protocol  string
-	Address   string `mapstructure:"listenAddress"`
+	Address   string   `mapstructure:"listenAddress"`
+	Addresses []string `mapstructure:"listenAddresses"`
 	Port      uint16
 	Endpoints map[string]Spec
 	Unmanaged bool
 }
 
+// Addr returns the first address the listener binds to.
 func (l ListenerSpec) Addr() (net.Addr, error) {
-	ip := net.IPv4(0, 0, 0, 0)
+	addrs, err := l.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	return addrs[0], nil
+}
+
+// Addrs returns all addresses the listener binds to.
+// If no address is configured, the listener binds to the wildcard address of its family:
+// [::] for tcp6 and udp6, 0.0.0.0 otherwise.
+func (l ListenerSpec) Addrs() ([]net.Addr, error) {
+	transport, family := l.transport()
+	if family != "" && family != "4" && family != "6" {
+		return nil, ErrUnsupportedProtocol
+	}
+
+	rawAddresses := l.Addresses
 	if l.Address != "" {
-		ip = net.ParseIP(l.Address)
-	}
-
-	switch strings.ToLower(strings.TrimRight(l.Protocol, "46")) {
-	case "tcp":
-		return &net.TCPAddr{
-			IP:   ip,
-			Port: int(l.Port),
-		}, nil
-	case "udp":
-		return &net.UDPAddr{
-			IP:   ip,
-			Port: int(l.Port),
-		}, nil
+		rawAddresses = append([]string{l.Address}, rawAddresses...)
+	}
+
+	if len(rawAddresses) == 0 {
+		if family == "6" {
+			rawAddresses = []string{net.IPv6unspecified.String()}
+		} else {
+			rawAddresses = []string{net.IPv4zero.String()}
+		}
+	}
+
+	addrs := make([]net.Addr, 0, len(rawAddresses))
+	for _, raw := range rawAddresses {
+		ip := net.ParseIP(raw)
+		if ip == nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidListenAddress, raw)
+		}
+
+		if isIPv4 := ip.To4() != nil; (family == "4" && !isIPv4) || (family == "6" && isIPv4) {
+			return nil, fmt.Errorf("%w: %s is not a valid %s address", ErrAddressFamilyMismatch, raw, l.Protocol)
+		}
+
+		switch transport {
+		case "tcp":
+			addrs = append(addrs, &net.TCPAddr{IP: ip, Port: int(l.Port)})
+		case "udp":
+			addrs = append(addrs, &net.UDPAddr{IP: ip, Port: int(l.Port)})
+		default:
+			return nil, ErrUnsupportedProtocol
+		}
+	}
+
+	return addrs, nil
+}
+
+// Network returns the network the given address should be bound with.
+// tcp4/udp4 and tcp6/udp6 restrict the listener to the respective address family.
+// For tcp/udp the family is determined by the address, IPv6 addresses are bound dual-stack
+// i.e. the IPv6 wildcard address [::] also accepts IPv4 clients.
+func (l ListenerSpec) Network(addr net.Addr) string {
+	transport, family := l.transport()
+	if family != "" {
+		return transport + family
+	}
+
+	if ip := addrIP(addr); ip.To4() != nil {
+		return transport + "4"
+	}
+
+	return transport
+}
+
+// familySuffix is appended to the transport in derived group names
+// to keep them unique if the same port is bound for different address families.
+func (l ListenerSpec) familySuffix(addrs []net.Addr) string {
+	var ipv4, ipv6 bool
+	for idx := range addrs {
+		switch network := l.Network(addrs[idx]); {
+		case strings.HasSuffix(network, "4"):
+			ipv4 = true
+		case strings.HasSuffix(network, "6"):
+			ipv6 = true
+		case isUnspecified(addrs[idx]):
+			ipv4, ipv6 = true, true
+		default:
+			ipv6 = true
+		}
+	}
+
+	switch {
+	case ipv4 && ipv6:
+		return "46"
+	case ipv6:
+		return "6"
 	default:
-		return nil, ErrUnsupportedProtocol
+		return ""
 	}
 }
 
+func (l ListenerSpec) transport() (transport, family string) {
+	protocol := strings.ToLower(l.Protocol)
+	transport = strings.TrimRight(protocol, "46")
+	return transport, strings.TrimPrefix(protocol, transport)
+}
+
+func addrIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		return a.IP
+	case *net.UDPAddr:
+		return a.IP
+	default:
+		return nil
+	}
+}
+
+func isUnspecified(addr net.Addr) bool {
+	return addrIP(addr).IsUnspecified()
+}
+
+// Spec configures a single endpoint of a listener.
+// If SNI patterns are configured, the endpoint only receives TLS connections whose ClientHello contains a matching
+// server name. The connections are terminated with the inetmock CA if TLS is set, otherwise the handler receives
+// the TLS stream untouched e.g. to pass it through to another server.
+// ClientAuth is only supported for TLS endpoints.
 type Spec struct {
 	HandlerRef HandlerReference `mapstructure:"handler"`
 	TLS        bool
+	SNI        []string
+	ClientAuth ClientAuthSpec  `mapstructure:"clientAuth"`
 	Handler    ProtocolHandler `mapstructure:"-"`
 	Options    map[string]any
 }
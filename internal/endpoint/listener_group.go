@@ -2,10 +2,12 @@ package endpoint
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +19,13 @@ const (
 	defaultReadTimeout    = 100 * time.Millisecond
 )
 
+const (
+	matchOrderWriting = iota
+	matchOrderSNI
+	matchOrderDefault
+	matchOrderFallback
+)
+
 type (
 	ErrorHandler interface {
 		OnError(err error)
@@ -39,18 +48,25 @@ func NewListenerGroup(spec ListenerSpec) (grp *ListenerGroup, err error) {
 		Name:      spec.Name,
 		endpoints: make(map[string]*ListenerEndpoint),
 		Unmanaged: spec.Unmanaged,
+		Spec:      spec,
 	}
 
-	if grp.Addr, err = spec.Addr(); err != nil {
+	if grp.Addrs, err = spec.Addrs(); err != nil {
 		return nil, err
 	}
 
+	if spec.Unmanaged && len(grp.Addrs) > 1 {
+		return nil, ErrUnmanagedMultipleAddrs
+	}
+
+	grp.Addr = grp.Addrs[0]
+
 	if grp.Name == "" {
 		switch a := grp.Addr.(type) {
 		case *net.TCPAddr:
-			grp.Name = fmt.Sprintf("%d/tcp", a.Port)
+			grp.Name = fmt.Sprintf("%d/tcp%s", a.Port, spec.familySuffix(grp.Addrs))
 		case *net.UDPAddr:
-			grp.Name = fmt.Sprintf("%d/udp", a.Port)
+			grp.Name = fmt.Sprintf("%d/udp%s", a.Port, spec.familySuffix(grp.Addrs))
 		}
 	}
 
@@ -60,6 +76,7 @@ func NewListenerGroup(spec ListenerSpec) (grp *ListenerGroup, err error) {
 func NewListenerEndpoint(spec Spec, handler ProtocolHandler) *ListenerEndpoint {
 	return &ListenerEndpoint{
 		TLS:     spec.TLS,
+		SNI:     spec.SNI,
 		Handler: handler,
 		Options: spec.Options,
 	}
@@ -80,10 +97,14 @@ type ListenerGroup struct {
 	errorHandlers []ErrorHandler
 	endpoints     map[string]*ListenerEndpoint
 	isServing     bool
-	CloseTimeout  time.Duration
-	Name          string
-	Unmanaged     bool
-	Addr          net.Addr
+	// createdAtRuntime marks groups created through the API, they are not touched when the configuration is reconciled
+	createdAtRuntime bool
+	CloseTimeout     time.Duration
+	Name             string
+	Unmanaged        bool
+	Addr             net.Addr
+	Addrs            []net.Addr
+	Spec             ListenerSpec
 }
 
 func (lg *ListenerGroup) AddErrorHandler(eh ErrorHandler) {
@@ -101,9 +122,21 @@ func (lg *ListenerGroup) ConfigureEndpoint(name string, le *ListenerEndpoint) {
 	lg.lock.Lock()
 	defer lg.lock.Unlock()
 
+	if le.Name == "" {
+		le.Name = fmt.Sprintf("%s:%s", lg.Name, name)
+	}
+
 	lg.endpoints[name] = le
 }
 
+func (lg *ListenerGroup) Endpoint(name string) (le *ListenerEndpoint, exists bool) {
+	lg.lock.Lock()
+	defer lg.lock.Unlock()
+
+	le, exists = lg.endpoints[name]
+	return le, exists
+}
+
 func (lg *ListenerGroup) ConfiguredEndpoints() (eps []string) {
 	lg.lock.Lock()
 	defer lg.lock.Unlock()
@@ -116,6 +149,13 @@ func (lg *ListenerGroup) ConfiguredEndpoints() (eps []string) {
 	return eps
 }
 
+func (lg *ListenerGroup) IsServing() bool {
+	lg.lock.Lock()
+	defer lg.lock.Unlock()
+
+	return lg.isServing
+}
+
 func (lg *ListenerGroup) Serve(ctx context.Context) error {
 	lg.lock.Lock()
 	defer lg.lock.Unlock()
@@ -146,7 +186,9 @@ func (lg *ListenerGroup) Serve(ctx context.Context) error {
 	return nil
 }
 
-func (lg *ListenerGroup) SetupMux(mux cmux.CMux, grp *Group) {
+// SetupMux assigns the listeners of the given multiplexer to the endpoints of the group.
+// Connections of TLS endpoints routed by SNI are terminated with the given TLS config and their own client auth.
+func (lg *ListenerGroup) SetupMux(mux cmux.CMux, grp *Group, tlsConfig *tls.Config) {
 	lg.lock.Lock()
 	defer lg.lock.Unlock()
 
@@ -156,25 +198,57 @@ func (lg *ListenerGroup) SetupMux(mux cmux.CMux, grp *Group) {
 		ep := lg.endpoints[name]
 		ep.Name = fmt.Sprintf("%s:%s", lg.Name, name)
 		ep.Uplink.Addr = lg.Addr
-		ep.Uplink.Listener = mux.Match(grp.Handlers[name].Matchers()...)
+		switch handler := grp.Handlers[name].(type) {
+		case MatchWritingHandler:
+			ep.Uplink.Listener = mux.MatchWithWriters(handler.MatchWriters()...)
+		case sniHandler:
+			ep.Uplink.Listener = mux.Match(handler.Matchers()...)
+			if ep.TLS {
+				ep.Uplink.Listener = NewTLSListener(ep.Uplink.Listener, ep.ClientAuth.TLSConfig(tlsConfig))
+			}
+		default:
+			ep.Uplink.Listener = mux.Match(handler.Matchers()...)
+		}
 	}
 }
 
+// GroupByTLS splits the endpoints into those which are matched on the plain connection and those which are matched
+// after the TLS handshake with the inetmock CA.
+// Endpoints routed by SNI are always part of the plain group because they are matched by the ClientHello.
 func (lg *ListenerGroup) GroupByTLS() (plainGrp, tlsGrp *Group, err error) {
 	lg.lock.Lock()
 	defer lg.lock.Unlock()
 
-	if plainGrp, err = groupEndpoints(lg.endpoints, func(s *ListenerEndpoint) bool { return !s.TLS }); err != nil {
+	if plainGrp, err = groupEndpoints(lg.endpoints, func(s *ListenerEndpoint) bool { return !s.TLS || len(s.SNI) > 0 }); err != nil {
 		return nil, nil, err
 	}
 
-	if tlsGrp, err = groupEndpoints(lg.endpoints, func(s *ListenerEndpoint) bool { return s.TLS }); err != nil {
+	if tlsGrp, err = groupEndpoints(lg.endpoints, func(s *ListenerEndpoint) bool { return s.TLS && len(s.SNI) == 0 }); err != nil {
 		return nil, nil, err
 	}
 
 	return
 }
 
+// TLSClientAuth returns the client auth of the endpoints in the given group.
+// All endpoints have to share the same client auth because it is negotiated before the connection is matched.
+func (lg *ListenerGroup) TLSClientAuth(grp *Group) (*ClientAuth, error) {
+	lg.lock.Lock()
+	defer lg.lock.Unlock()
+
+	var clientAuth *ClientAuth
+	for idx, name := range grp.Names {
+		ep := lg.endpoints[name]
+		if idx == 0 {
+			clientAuth = ep.ClientAuth
+		} else if !clientAuth.Equal(ep.ClientAuth) {
+			return nil, fmt.Errorf("%w: %s", ErrConflictingClientAuth, name)
+		}
+	}
+
+	return clientAuth, nil
+}
+
 func (lg *ListenerGroup) Shutdown(ctx context.Context) (err error) {
 	lg.lock.Lock()
 	defer lg.lock.Unlock()
@@ -212,11 +286,13 @@ func (lg *ListenerGroup) notifyErrorHandlers(err error) {
 }
 
 type ListenerEndpoint struct {
-	Name    string
-	TLS     bool
-	Handler ProtocolHandler
-	Uplink  Uplink
-	Options map[string]any
+	Name       string
+	TLS        bool
+	SNI        []string
+	ClientAuth *ClientAuth
+	Handler    ProtocolHandler
+	Uplink     Uplink
+	Options    map[string]any
 }
 
 func (le ListenerEndpoint) Close(ctx context.Context) (err error) {
@@ -235,7 +311,10 @@ func groupEndpoints(endpoints map[string]*ListenerEndpoint, predicate func(s *Li
 
 	for name, spec := range endpoints {
 		var e MultiplexHandler
-		if ep, ok := spec.Handler.(MultiplexHandler); !ok {
+		if len(spec.SNI) > 0 {
+			// the matchers of the handler are ignored in favor of the SNI patterns
+			e = sniHandler{ProtocolHandler: spec.Handler, patterns: spec.SNI}
+		} else if ep, ok := spec.Handler.(MultiplexHandler); !ok {
 			return nil, fmt.Errorf("handler %s %w", spec.Name, ErrMultiplexingNotSupported)
 		} else {
 			e = ep
@@ -247,5 +326,67 @@ func groupEndpoints(endpoints map[string]*ListenerEndpoint, predicate func(s *Li
 		}
 	}
 	sort.Strings(grp.Names)
+
+	if err := checkMatchWriterExclusive(grp); err != nil {
+		return nil, err
+	}
+
+	// match writers have to be registered first, matchers of fallback handlers accept any connection and have to be
+	// registered last
+	sort.SliceStable(grp.Names, func(i, j int) bool {
+		return matchOrder(grp.Handlers[grp.Names[i]]) < matchOrder(grp.Handlers[grp.Names[j]])
+	})
+
 	return grp, nil
 }
+
+// checkMatchWriterExclusive ensures match writing handlers are not multiplexed with other plain text handlers.
+// The data written by a match writer e.g. the HTTP/2 SETTINGS frame of the gRPC matcher would otherwise confuse clients
+// of the other handlers whose connections are handed over after the match writer did not match.
+func checkMatchWriterExclusive(grp *Group) error {
+	var writers, others []string
+	for _, name := range grp.Names {
+		switch grp.Handlers[name].(type) {
+		case MatchWritingHandler:
+			writers = append(writers, name)
+		case sniHandler:
+			// SNI routed endpoints only receive TLS connections which are never matched by match writers
+		default:
+			others = append(others, name)
+		}
+	}
+
+	if len(writers) > 0 && len(writers)+len(others) > 1 {
+		return fmt.Errorf("%w: %s", ErrMatchWriterNotExclusive, strings.Join(append(writers, others...), ", "))
+	}
+
+	return nil
+}
+
+func matchOrder(handler MultiplexHandler) int {
+	switch handler.(type) {
+	case MatchWritingHandler:
+		return matchOrderWriting
+	case sniHandler:
+		// SNI matchers only match TLS connections and are registered before the matchers of plain text handlers
+		// to prevent fallback handlers from claiming them
+		return matchOrderSNI
+	}
+
+	if fallback, ok := handler.(FallbackHandler); ok && fallback.Fallback() {
+		return matchOrderFallback
+	}
+
+	return matchOrderDefault
+}
+
+// sniHandler routes connections to an endpoint by the server name of their ClientHello
+// instead of the matchers of the actual handler
+type sniHandler struct {
+	ProtocolHandler
+	patterns []string
+}
+
+func (h sniHandler) Matchers() []cmux.Matcher {
+	return []cmux.Matcher{MatchSNI(h.patterns...)}
+}
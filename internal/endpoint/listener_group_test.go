@@ -2,6 +2,7 @@ package endpoint_test
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"net"
 	"testing"
@@ -171,6 +172,192 @@ func TestListenerGroup_GroupByTLS(t *testing.T) {
 			}),
 			wantErr: false,
 		},
+		{
+			name: "Fallback handler is ordered last",
+			spec: defaultListenerSpec,
+			registrations: []registration{
+				{
+					name: "a_raw",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     false,
+						Handler: FallbackHandlerMock{},
+					},
+				},
+				{
+					name: "plain_http",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     false,
+						Handler: MultiplexHandlerMock{},
+					},
+				},
+				{
+					name: "smtp",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     false,
+						Handler: MultiplexHandlerMock{},
+					},
+				},
+			},
+			wantPlainGrp: td.Struct(new(endpoint.Group), td.StructFields{
+				"Handlers": td.Len(3),
+				"Names":    []string{"plain_http", "smtp", "a_raw"},
+			}),
+			wantTLSGrp: td.Struct(new(endpoint.Group), td.StructFields{
+				"Handlers": td.Empty(),
+			}),
+			wantErr: false,
+		},
+		{
+			name: "Match writing handler is ordered before SNI routed endpoints",
+			spec: defaultListenerSpec,
+			registrations: []registration{
+				{
+					name: "https",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     true,
+						Handler: MultiplexHandlerMock{},
+					},
+				},
+				{
+					name: "a_update",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     true,
+						SNI:     []string{"update.example.com"},
+						Handler: MultiplexHandlerMock{},
+					},
+				},
+				{
+					name: "plain_grpc",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     false,
+						Handler: MatchWritingHandlerMock{},
+					},
+				},
+			},
+			wantPlainGrp: td.Struct(new(endpoint.Group), td.StructFields{
+				"Handlers": td.Len(2),
+				"Names":    []string{"plain_grpc", "a_update"},
+			}),
+			wantTLSGrp: td.Struct(new(endpoint.Group), td.StructFields{
+				"Handlers": td.Len(1),
+				"Names":    []string{"https"},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "Error because match writing handler shares the plain group with other handlers",
+			spec: defaultListenerSpec,
+			registrations: []registration{
+				{
+					name: "plain_http",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     false,
+						Handler: MultiplexHandlerMock{},
+					},
+				},
+				{
+					name: "plain_grpc",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     false,
+						Handler: MatchWritingHandlerMock{},
+					},
+				},
+			},
+			wantPlainGrp: td.Nil(),
+			wantTLSGrp:   td.Nil(),
+			wantErr:      true,
+		},
+		{
+			name: "Error because match writing handler shares the TLS group with other handlers",
+			spec: defaultListenerSpec,
+			registrations: []registration{
+				{
+					name: "https",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     true,
+						Handler: MultiplexHandlerMock{},
+					},
+				},
+				{
+					name: "grpc",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     true,
+						Handler: MatchWritingHandlerMock{},
+					},
+				},
+			},
+			wantPlainGrp: td.Nil(),
+			wantTLSGrp:   td.Nil(),
+			wantErr:      true,
+		},
+		{
+			name: "SNI routed endpoints are part of the plain group",
+			spec: defaultListenerSpec,
+			registrations: []registration{
+				{
+					name: "a_raw",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     false,
+						Handler: FallbackHandlerMock{},
+					},
+				},
+				{
+					name: "https",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     true,
+						Handler: MultiplexHandlerMock{},
+					},
+				},
+				{
+					name: "https_update",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     true,
+						SNI:     []string{"update.example.com"},
+						Handler: MultiplexHandlerMock{},
+					},
+				},
+				{
+					name: "passthrough",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     false,
+						SNI:     []string{"*.example.org"},
+						Handler: StoppableProtocolHandlerMock{},
+					},
+				},
+			},
+			wantPlainGrp: td.Struct(new(endpoint.Group), td.StructFields{
+				"Handlers": td.Len(3),
+				"Names":    []string{"https_update", "passthrough", "a_raw"},
+			}),
+			wantTLSGrp: td.Struct(new(endpoint.Group), td.StructFields{
+				"Handlers": td.Len(1),
+				"Names":    []string{"https"},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "Error because handler without SNI does not support multiplexing",
+			spec: defaultListenerSpec,
+			registrations: []registration{
+				{
+					name: "passthrough",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     false,
+						Handler: StoppableProtocolHandlerMock{},
+					},
+				},
+				{
+					name: "https",
+					le: &endpoint.ListenerEndpoint{
+						TLS:     true,
+						Handler: MultiplexHandlerMock{},
+					},
+				},
+			},
+			wantPlainGrp: td.Nil(),
+			wantTLSGrp:   td.Nil(),
+			wantErr:      true,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -198,6 +385,84 @@ func TestListenerGroup_GroupByTLS(t *testing.T) {
 	}
 }
 
+func TestListenerGroup_TLSClientAuth(t *testing.T) {
+	t.Parallel()
+	var (
+		request = &endpoint.ClientAuth{Type: tls.RequestClientCert}
+		require = &endpoint.ClientAuth{Type: tls.RequireAnyClientCert}
+	)
+
+	tests := []struct {
+		name      string
+		endpoints map[string]*endpoint.ListenerEndpoint
+		want      any
+		wantErr   bool
+	}{
+		{
+			name: "Without client auth",
+			endpoints: map[string]*endpoint.ListenerEndpoint{
+				"https": {TLS: true, Handler: MultiplexHandlerMock{}},
+				"grpc":  {TLS: true, Handler: MultiplexHandlerMock{}},
+			},
+			want: td.Nil(),
+		},
+		{
+			name: "Same client auth",
+			endpoints: map[string]*endpoint.ListenerEndpoint{
+				"https": {TLS: true, ClientAuth: require, Handler: MultiplexHandlerMock{}},
+				"grpc":  {TLS: true, ClientAuth: &endpoint.ClientAuth{Type: tls.RequireAnyClientCert}, Handler: MultiplexHandlerMock{}},
+			},
+			want: require,
+		},
+		{
+			name: "Client auth of plain and SNI endpoints is ignored",
+			endpoints: map[string]*endpoint.ListenerEndpoint{
+				"https":  {TLS: true, ClientAuth: request, Handler: MultiplexHandlerMock{}},
+				"plain":  {Handler: MultiplexHandlerMock{}},
+				"device": {TLS: true, SNI: []string{"device.example.com"}, ClientAuth: require, Handler: MultiplexHandlerMock{}},
+			},
+			want: request,
+		},
+		{
+			name: "Conflicting client auth",
+			endpoints: map[string]*endpoint.ListenerEndpoint{
+				"https": {TLS: true, ClientAuth: request, Handler: MultiplexHandlerMock{}},
+				"grpc":  {TLS: true, ClientAuth: require, Handler: MultiplexHandlerMock{}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			lg, err := endpoint.NewListenerGroup(defaultListenerSpec)
+			if err != nil {
+				t.Fatalf("endpoint.NewListenerGroup() error = %v", err)
+			}
+
+			for name, le := range tt.endpoints {
+				lg.ConfigureEndpoint(name, le)
+			}
+
+			_, tlsGrp, err := lg.GroupByTLS()
+			if err != nil {
+				t.Fatalf("GroupByTLS() error = %v", err)
+			}
+
+			got, err := lg.TLSClientAuth(tlsGrp)
+			if tt.wantErr {
+				td.Cmp(t, errors.Is(err, endpoint.ErrConflictingClientAuth), true)
+				return
+			}
+
+			if td.CmpNoError(t, err) {
+				td.Cmp(t, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewListenerGroup(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -275,6 +540,55 @@ func TestNewListenerGroup(t *testing.T) {
 			}),
 			wantErr: false,
 		},
+		{
+			name: "TCP6 group - empty name",
+			args: args{
+				spec: endpoint.ListenerSpec{
+					Protocol: "tcp6",
+					Port:     80,
+				},
+			},
+			wantGrp: td.Struct(&endpoint.ListenerGroup{
+				Name: "80/tcp6",
+			}, td.StructFields{
+				"Addr":  td.Struct(&net.TCPAddr{IP: net.IPv6unspecified, Port: 80}, td.StructFields{}),
+				"Addrs": td.Len(1),
+			}),
+			wantErr: false,
+		},
+		{
+			name: "TCP group - multiple addresses of both families",
+			args: args{
+				spec: endpoint.ListenerSpec{
+					Protocol:  "tcp",
+					Port:      80,
+					Addresses: []string{"127.0.0.1", "::1"},
+				},
+			},
+			wantGrp: td.Struct(&endpoint.ListenerGroup{
+				Name: "80/tcp46",
+			}, td.StructFields{
+				"Addr": td.Struct(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 80}, td.StructFields{}),
+				"Addrs": []net.Addr{
+					&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 80},
+					&net.TCPAddr{IP: net.IPv6loopback, Port: 80},
+				},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "Unmanaged group - multiple addresses",
+			args: args{
+				spec: endpoint.ListenerSpec{
+					Protocol:  "tcp",
+					Port:      80,
+					Addresses: []string{"127.0.0.1", "::1"},
+					Unmanaged: true,
+				},
+			},
+			wantGrp: td.Nil(),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
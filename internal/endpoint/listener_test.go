@@ -36,7 +36,51 @@ func TestListenerSpec_Addr(t *testing.T) {
 				Protocol: "tcp6",
 				Port:     1234,
 			},
-			want: &net.TCPAddr{IP: net.IPv4(0, 0, 0, 0), Port: 1234},
+			want: &net.TCPAddr{IP: net.IPv6unspecified, Port: 1234},
+		},
+		{
+			name: "TCP address with IPv6 address",
+			fields: fields{
+				Protocol: "tcp",
+				Address:  "::1",
+				Port:     1234,
+			},
+			want: &net.TCPAddr{IP: net.IPv6loopback, Port: 1234},
+		},
+		{
+			name: "TCP4 address with IPv6 address",
+			fields: fields{
+				Protocol: "tcp4",
+				Address:  "::1",
+				Port:     1234,
+			},
+			wantErr: true,
+		},
+		{
+			name: "TCP6 address with IPv4 address",
+			fields: fields{
+				Protocol: "tcp6",
+				Address:  "127.0.0.1",
+				Port:     1234,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid address",
+			fields: fields{
+				Protocol: "tcp",
+				Address:  "localhost",
+				Port:     1234,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported protocol",
+			fields: fields{
+				Protocol: "sctp",
+				Port:     1234,
+			},
+			wantErr: true,
 		},
 		{
 			name: "TCP address",
@@ -60,7 +104,7 @@ func TestListenerSpec_Addr(t *testing.T) {
 				Protocol: "udp6",
 				Port:     1234,
 			},
-			want: &net.UDPAddr{IP: net.IPv4(0, 0, 0, 0), Port: 1234},
+			want: &net.UDPAddr{IP: net.IPv6unspecified, Port: 1234},
 		},
 		{
 			name: "UDP address",
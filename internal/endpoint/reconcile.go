@@ -0,0 +1,97 @@
+package endpoint
+
+import (
+	"context"
+	"sort"
+)
+
+type ReconcileAction uint8
+
+const (
+	ReconcileActionUnchanged ReconcileAction = iota
+	ReconcileActionAdded
+	ReconcileActionUpdated
+	ReconcileActionRemoved
+)
+
+func (a ReconcileAction) String() string {
+	switch a {
+	case ReconcileActionAdded:
+		return "added"
+	case ReconcileActionUpdated:
+		return "updated"
+	case ReconcileActionRemoved:
+		return "removed"
+	default:
+		return "unchanged"
+	}
+}
+
+type GroupReconcileResult struct {
+	GroupName string
+	Action    ReconcileAction
+	Err       error
+}
+
+// Reconcile compares the given listener specs with the currently configured groups.
+// Groups configured with ConfigureGroup that are not part of the given specs anymore are shut down and removed,
+// groups created at runtime with CreateGroup are kept unless the given specs contain a group with the same name.
+// new groups are configured and served and groups whose spec changed are restarted with fresh handler instances.
+// Groups that did not change are left untouched such that their in-memory state survives.
+// Errors are reported per group and do not abort the reconciliation of the remaining groups.
+func (e *ServerBuilder) Reconcile(ctx context.Context, specs []ListenerSpec) []GroupReconcileResult {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	var (
+		results = make([]GroupReconcileResult, 0, len(specs))
+		desired = make(map[string]*ListenerGroup, len(specs))
+	)
+
+	for idx := range specs {
+		grp, err := e.buildGroup(specs[idx])
+		if err != nil {
+			results = append(results, GroupReconcileResult{
+				GroupName: specs[idx].Name,
+				Action:    ReconcileActionUnchanged,
+				Err:       err,
+			})
+			// prevent removal of a running group just because its new spec is invalid
+			desired[specs[idx].Name] = nil
+			continue
+		}
+		desired[grp.Name] = grp
+	}
+
+	// remove groups first to release their ports before new groups are started
+	for _, name := range e.server.reconciledGroupNames() {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+
+		results = append(results, GroupReconcileResult{
+			GroupName: name,
+			Action:    ReconcileActionRemoved,
+			Err:       e.server.RemoveGroup(ctx, name),
+		})
+	}
+
+	for name, grp := range desired {
+		if grp == nil {
+			continue
+		}
+
+		action, err := e.server.replaceGroup(ctx, grp)
+		results = append(results, GroupReconcileResult{
+			GroupName: name,
+			Action:    action,
+			Err:       err,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].GroupName < results[j].GroupName
+	})
+
+	return results
+}
@@ -0,0 +1,166 @@
+package endpoint_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+func TestServerBuilder_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	unmanagedSpec := func(name string, opts map[string]any) endpoint.ListenerSpec {
+		return endpoint.ListenerSpec{
+			Name:      name,
+			Protocol:  "tcp",
+			Port:      1234,
+			Unmanaged: true,
+			Endpoints: map[string]endpoint.Spec{
+				"plain": {
+					HandlerRef: "counting",
+					Options:    opts,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		initial    []endpoint.ListenerSpec
+		created    []endpoint.ListenerSpec
+		reconcile  []endpoint.ListenerSpec
+		want       any
+		wantStarts int64
+		wantGroups any
+	}{
+		{
+			name:      "Add new group",
+			reconcile: []endpoint.ListenerSpec{unmanagedSpec("http", nil)},
+			want: td.Bag(
+				td.Struct(endpoint.GroupReconcileResult{GroupName: "http", Action: endpoint.ReconcileActionAdded}, td.StructFields{"Err": nil}),
+			),
+			wantStarts: 1,
+			wantGroups: td.Bag(td.Struct(endpoint.GroupInfo{Name: "http", Serving: true}, td.StructFields{})),
+		},
+		{
+			name:      "Keep unchanged group",
+			initial:   []endpoint.ListenerSpec{unmanagedSpec("http", map[string]any{"rules": []string{"=> Status(204)"}})},
+			reconcile: []endpoint.ListenerSpec{unmanagedSpec("http", map[string]any{"rules": []string{"=> Status(204)"}})},
+			want: td.Bag(
+				td.Struct(endpoint.GroupReconcileResult{GroupName: "http", Action: endpoint.ReconcileActionUnchanged}, td.StructFields{"Err": nil}),
+			),
+			wantStarts: 1,
+			wantGroups: td.Bag(td.Struct(endpoint.GroupInfo{Name: "http", Serving: true}, td.StructFields{})),
+		},
+		{
+			name:      "Restart changed group",
+			initial:   []endpoint.ListenerSpec{unmanagedSpec("http", map[string]any{"rules": []string{"=> Status(204)"}})},
+			reconcile: []endpoint.ListenerSpec{unmanagedSpec("http", map[string]any{"rules": []string{"=> Status(404)"}})},
+			want: td.Bag(
+				td.Struct(endpoint.GroupReconcileResult{GroupName: "http", Action: endpoint.ReconcileActionUpdated}, td.StructFields{"Err": nil}),
+			),
+			wantStarts: 2,
+			wantGroups: td.Bag(td.Struct(endpoint.GroupInfo{Name: "http", Serving: true}, td.StructFields{})),
+		},
+		{
+			name:      "Remove deleted group",
+			initial:   []endpoint.ListenerSpec{unmanagedSpec("http", nil), unmanagedSpec("https", nil)},
+			reconcile: []endpoint.ListenerSpec{unmanagedSpec("http", nil)},
+			want: td.Bag(
+				td.Struct(endpoint.GroupReconcileResult{GroupName: "http", Action: endpoint.ReconcileActionUnchanged}, td.StructFields{"Err": nil}),
+				td.Struct(endpoint.GroupReconcileResult{GroupName: "https", Action: endpoint.ReconcileActionRemoved}, td.StructFields{"Err": nil}),
+			),
+			wantStarts: 2,
+			wantGroups: td.Bag(td.Struct(endpoint.GroupInfo{Name: "http", Serving: true}, td.StructFields{})),
+		},
+		{
+			name:      "Keep group created at runtime",
+			initial:   []endpoint.ListenerSpec{unmanagedSpec("http", nil)},
+			created:   []endpoint.ListenerSpec{unmanagedSpec("sinkhole", nil)},
+			reconcile: []endpoint.ListenerSpec{unmanagedSpec("http", nil)},
+			want: td.Bag(
+				td.Struct(endpoint.GroupReconcileResult{GroupName: "http", Action: endpoint.ReconcileActionUnchanged}, td.StructFields{"Err": nil}),
+			),
+			wantStarts: 2,
+			wantGroups: td.Bag(
+				td.Struct(endpoint.GroupInfo{Name: "http", Serving: true}, td.StructFields{}),
+				td.Struct(endpoint.GroupInfo{Name: "sinkhole", Serving: true}, td.StructFields{}),
+			),
+		},
+		{
+			name:      "Replace group created at runtime by configured group",
+			created:   []endpoint.ListenerSpec{unmanagedSpec("http", nil)},
+			reconcile: []endpoint.ListenerSpec{unmanagedSpec("http", map[string]any{"rules": []string{"=> Status(204)"}})},
+			want: td.Bag(
+				td.Struct(endpoint.GroupReconcileResult{GroupName: "http", Action: endpoint.ReconcileActionUpdated}, td.StructFields{"Err": nil}),
+			),
+			wantStarts: 2,
+			wantGroups: td.Bag(td.Struct(endpoint.GroupInfo{Name: "http", Serving: true}, td.StructFields{})),
+		},
+		{
+			name:    "Keep running group if new spec is invalid",
+			initial: []endpoint.ListenerSpec{unmanagedSpec("http", nil)},
+			reconcile: []endpoint.ListenerSpec{
+				{
+					Name:      "http",
+					Protocol:  "tcp",
+					Port:      1234,
+					Unmanaged: true,
+					Endpoints: map[string]endpoint.Spec{
+						"plain": {
+							HandlerRef: "unknown",
+						},
+					},
+				},
+			},
+			want: td.Bag(
+				td.Struct(endpoint.GroupReconcileResult{GroupName: "http"}, td.StructFields{"Err": td.Code(func(err error) bool { return errors.Is(err, endpoint.ErrUnknownHandlerRef) })}),
+			),
+			wantStarts: 1,
+			wantGroups: td.Bag(td.Struct(endpoint.GroupInfo{Name: "http", Serving: true}, td.StructFields{})),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var starts int64
+			registry := endpoint.NewHandlerRegistry()
+			registry.RegisterHandler("counting", func() endpoint.ProtocolHandler {
+				return ProtocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error {
+					atomic.AddInt64(&starts, 1)
+					return nil
+				})
+			})
+
+			builder := endpoint.NewServerBuilder(nil, registry, logging.CreateTestLogger(t))
+			for idx := range tt.initial {
+				if err := builder.ConfigureGroup(tt.initial[idx]); err != nil {
+					t.Fatalf("ConfigureGroup() error = %v", err)
+				}
+			}
+
+			for idx := range tt.created {
+				if err := builder.CreateGroup(tt.created[idx]); err != nil {
+					t.Fatalf("CreateGroup() error = %v", err)
+				}
+			}
+
+			if err := builder.Server().ServeGroups(context.Background()); err != nil {
+				t.Fatalf("ServeGroups() error = %v", err)
+			}
+
+			td.Cmp(t, builder.Reconcile(context.Background(), tt.reconcile), tt.want)
+			td.Cmp(t, atomic.LoadInt64(&starts), tt.wantStarts)
+			td.Cmp(t, builder.ConfiguredGroups(), tt.wantGroups)
+		})
+	}
+}
@@ -35,7 +35,7 @@ func Test_handlerRegistry_AvailableHandlers(t *testing.T) {
 				registry := endpoint.NewHandlerRegistry()
 				logger := logging.CreateTestLogger(tb)
 				emitter := audit_mock.NewMockEmitter(ctrl)
-				httpmock.AddHTTPMock(registry, logger, emitter, new(fstest.MapFS))
+				httpmock.AddHTTPMock(registry, logger, emitter, new(fstest.MapFS), nil)
 				return registry
 			},
 			wantAvailableHandlers: td.Set(endpoint.HandlerReference("http_mock")),
@@ -47,8 +47,8 @@ func Test_handlerRegistry_AvailableHandlers(t *testing.T) {
 				registry := endpoint.NewHandlerRegistry()
 				logger := logging.CreateTestLogger(tb)
 				emitter := audit_mock.NewMockEmitter(ctrl)
-				httpmock.AddHTTPMock(registry, logger, emitter, new(fstest.MapFS))
-				dnsmock.AddDNSMock(registry, logger, emitter)
+				httpmock.AddHTTPMock(registry, logger, emitter, new(fstest.MapFS), nil)
+				dnsmock.AddDNSMock(registry, logger, emitter, nil)
 				return registry
 			},
 			wantAvailableHandlers: td.Set(
@@ -94,7 +94,7 @@ func Test_handlerRegistry_HandlerForName(t *testing.T) {
 				registry := endpoint.NewHandlerRegistry()
 				logger := logging.CreateTestLogger(tb)
 				emitter := audit_mock.NewMockEmitter(ctrl)
-				httpmock.AddHTTPMock(registry, logger, emitter, new(fstest.MapFS))
+				httpmock.AddHTTPMock(registry, logger, emitter, new(fstest.MapFS), nil)
 				return registry
 			},
 			handlerRef:   "http_mock",
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/soheilhy/cmux"
@@ -16,6 +17,8 @@ import (
 
 var (
 	ErrNoSuchGroup      = errors.New("no group with given name configured")
+	ErrGroupExists      = errors.New("group with given name is already configured")
+	ErrServeGroup       = errors.New("failed to serve group")
 	_              Host = (*Server)(nil)
 )
 
@@ -40,11 +43,42 @@ type Server struct {
 	Logger       logging.Logger
 }
 
-func (s *Server) ConfigureGroup(grp *ListenerGroup) {
+// ConfigureGroup adds the group to the server without starting it.
+// Configured groups are not replaced, ErrGroupExists is returned if a group with the same name is already known.
+func (s *Server) ConfigureGroup(grp *ListenerGroup) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	if _, exists := s.groups[grp.Name]; exists {
+		return fmt.Errorf("%w: %s", ErrGroupExists, grp.Name)
+	}
+
 	s.groups[grp.Name] = grp
+
+	return nil
+}
+
+// configureAndServeGroup adds the group to the server and serves it while holding the lock,
+// hence no other operation e.g. RemoveGroup can interfere before the group is served.
+// A group that can't be served is shut down again and not added at all.
+func (s *Server) configureAndServeGroup(ctx context.Context, grp *ListenerGroup) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, exists := s.groups[grp.Name]; exists {
+		return fmt.Errorf("%w: %s", ErrGroupExists, grp.Name)
+	}
+
+	if err := s.serveGroup(ctx, grp); err != nil {
+		if shutdownErr := grp.Shutdown(ctx); shutdownErr != nil {
+			s.Logger.Error("Failed to shut down group that could not be served", zap.String("group_name", grp.Name), zap.Error(shutdownErr))
+		}
+		return fmt.Errorf("%w: %s: %w", ErrServeGroup, grp.Name, err)
+	}
+
+	s.groups[grp.Name] = grp
+
+	return nil
 }
 
 func (s *Server) ConfiguredGroups() []GroupInfo {
@@ -56,8 +90,10 @@ func (s *Server) ConfiguredGroups() []GroupInfo {
 	for name, grp := range s.groups {
 		info := GroupInfo{
 			Name:      name,
+			Addr:      grp.Addr,
+			Addrs:     grp.Addrs,
 			Endpoints: grp.ConfiguredEndpoints(),
-			Serving:   grp.isServing,
+			Serving:   grp.IsServing(),
 		}
 
 		infos = append(infos, info)
@@ -101,6 +137,37 @@ func (s *Server) ShutdownGroup(ctx context.Context, groupName string) error {
 	}
 }
 
+func (s *Server) RemoveGroup(ctx context.Context, groupName string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if grpToRemove, exists := s.groups[groupName]; !exists {
+		return fmt.Errorf("%w: %s", ErrNoSuchGroup, groupName)
+	} else if err := grpToRemove.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	delete(s.groups, groupName)
+
+	return nil
+}
+
+func (s *Server) EndpointHandler(groupName, endpointName string) (ProtocolHandler, error) {
+	s.lock.Lock()
+	grp, exists := s.groups[groupName]
+	s.lock.Unlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNoSuchGroup, groupName)
+	}
+
+	if ep, exists := grp.Endpoint(endpointName); exists {
+		return ep.Handler, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNoSuchEndpoint, endpointName)
+}
+
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -128,6 +195,69 @@ func (s *Server) ShutdownOnCancel(ctx context.Context) {
 	}()
 }
 
+// replaceGroup configures the given group and takes care of the lifecycle of a previously configured group with the same name.
+// If the spec of the already configured group equals the spec of the new group, nothing happens at all.
+// Otherwise, the old group is shut down and the new group is served if the old one was serving - newly added groups are always served.
+func (s *Server) replaceGroup(ctx context.Context, grp *ListenerGroup) (ReconcileAction, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, exists := s.groups[grp.Name]
+	if !exists {
+		s.groups[grp.Name] = grp
+		return ReconcileActionAdded, s.serveGroup(ctx, grp)
+	}
+
+	if reflect.DeepEqual(existing.Spec, grp.Spec) {
+		return ReconcileActionUnchanged, nil
+	}
+
+	if sameListenAddress(existing.Spec, grp.Spec) {
+		// keep the addresses an OS chosen port was resolved to
+		grp.Addr, grp.Addrs = existing.Addr, existing.Addrs
+	}
+
+	wasServing := existing.IsServing()
+	if wasServing {
+		if err := existing.Shutdown(ctx); err != nil {
+			return ReconcileActionUpdated, err
+		}
+	}
+
+	s.groups[grp.Name] = grp
+
+	if !wasServing {
+		return ReconcileActionUpdated, nil
+	}
+
+	return ReconcileActionUpdated, s.serveGroup(ctx, grp)
+}
+
+func (s *Server) group(groupName string) (grp *ListenerGroup, exists bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	grp, exists = s.groups[groupName]
+
+	return grp, exists
+}
+
+// reconciledGroupNames returns the names of all groups managed by the configuration i.e. not created at runtime
+func (s *Server) reconciledGroupNames() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	names := make([]string, 0, len(s.groups))
+	for name, grp := range s.groups {
+		if grp.createdAtRuntime {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
 func (s *Server) serveGroup(ctx context.Context, grpToStart *ListenerGroup) error {
 	grpToStart.errorHandlers = make([]ErrorHandler, len(s.ErrorHandler))
 	copy(grpToStart.errorHandlers, s.ErrorHandler)
@@ -153,14 +283,14 @@ func (s *Server) setupGroup(grp *ListenerGroup, tlsConfig *tls.Config) error {
 
 	setupLogger := s.Logger.With(zap.String("group_name", grp.Name))
 
-	if len(grp.endpoints) <= 1 {
+	if !multiplexed(grp) {
 		for name, le := range grp.endpoints {
 			setupLogger.Debug("Preparing single handler group",
 				zap.String("handler_name", name),
 				zap.Bool("tls", le.TLS),
 			)
 			if le.TLS {
-				uplink.Listener = tls.NewListener(uplink.Listener, tlsConfig)
+				uplink.Listener = NewTLSListener(uplink.Listener, le.ClientAuth.TLSConfig(tlsConfig))
 			}
 			le.Name = fmt.Sprintf("%s:%s", grp.Name, name)
 			le.Uplink = *uplink
@@ -184,14 +314,20 @@ func (s *Server) setupGroup(grp *ListenerGroup, tlsConfig *tls.Config) error {
 	if !plainGrp.IsEmpty() {
 		setupLogger.Debug("Configuring plain text endpoints")
 		plainMux := cmux.New(lis)
-		grp.SetupMux(plainMux, plainGrp)
-		lis = plainMux.Match(cmux.Any())
+		grp.SetupMux(plainMux, plainGrp, tlsConfig)
+		if !tlsGrp.IsEmpty() {
+			lis = plainMux.Match(cmux.Any())
+		}
 	}
 
 	if !tlsGrp.IsEmpty() {
 		setupLogger.Debug("Configuring TLS endpoints")
-		tlsMux := cmux.New(tls.NewListener(lis, tlsConfig))
-		grp.SetupMux(tlsMux, tlsGrp)
+		clientAuth, err := grp.TLSClientAuth(tlsGrp)
+		if err != nil {
+			return err
+		}
+		tlsMux := cmux.New(NewTLSListener(lis, clientAuth.TLSConfig(tlsConfig)))
+		grp.SetupMux(tlsMux, tlsGrp, tlsConfig)
 	}
 
 	return nil
@@ -203,16 +339,143 @@ func (s *Server) setupUplink(grp *ListenerGroup) (u *Uplink, err error) {
 		return
 	}
 
-	switch a := grp.Addr.(type) {
+	switch grp.Addr.(type) {
 	case *net.UDPAddr:
-		u.PacketConn, err = net.ListenUDP("udp", a)
+		var conns []net.PacketConn
+		if conns, err = listenUDP(grp); err == nil {
+			u.PacketConn = net.MultiPacketConn(conns...)
+		}
 	case *net.TCPAddr:
-		u.Listener, err = net.ListenTCP(
-			a,
+		var listeners []net.Listener
+		if listeners, err = listenTCP(grp); err == nil {
+			u.Listener = net.MultiListener(listeners...)
+		}
+	}
+
+	if err == nil {
+		u.Addr = grp.Addr
+	}
+
+	return
+}
+
+func listenUDP(grp *ListenerGroup) (conns []net.PacketConn, err error) {
+	conns = make([]net.PacketConn, 0, len(grp.Addrs))
+	for idx := range grp.Addrs {
+		addr := grp.Addrs[idx].(*net.UDPAddr)
+		var conn *net.UDPConn
+		if conn, err = net.ListenUDP(grp.Spec.Network(addr), addr); err != nil {
+			break
+		}
+		conns = append(conns, conn)
+		resolveRandomPort(grp, conn.LocalAddr())
+	}
+
+	if err != nil {
+		for idx := range conns {
+			_ = conns[idx].Close()
+		}
+		return nil, err
+	}
+
+	return conns, nil
+}
+
+func listenTCP(grp *ListenerGroup) (listeners []net.Listener, err error) {
+	listeners = make([]net.Listener, 0, len(grp.Addrs))
+	for idx := range grp.Addrs {
+		addr := grp.Addrs[idx].(*net.TCPAddr)
+		var listener net.Listener
+		listener, err = net.ListenTCP(
+			grp.Spec.Network(addr),
+			addr,
 			net.WithReusePort(true),
 			net.WithFastOpen(true),
-			net.WithDeferAccept(true),
+			net.WithDeferAccept(deferAccept(grp)),
 		)
+		if err != nil {
+			break
+		}
+		listeners = append(listeners, listener)
+		resolveRandomPort(grp, listener.Addr())
 	}
-	return
+
+	if err != nil {
+		for idx := range listeners {
+			_ = listeners[idx].Close()
+		}
+		return nil, err
+	}
+
+	return listeners, nil
+}
+
+// multiplexed reports whether connections have to be matched to one of the endpoints of the group.
+// A single endpoint is served directly unless it is routed by SNI.
+func multiplexed(grp *ListenerGroup) bool {
+	if len(grp.endpoints) > 1 {
+		return true
+	}
+
+	for _, le := range grp.endpoints {
+		return len(le.SNI) > 0
+	}
+
+	return false
+}
+
+// deferAccept reports whether the kernel may hold back connections until the client sent data.
+// Multiplexed groups wait for the client anyway but single endpoints might greet the client first e.g. SMTP, FTP or SSH
+// and so do fallback handlers which receive the connections of clients that did not send anything.
+func deferAccept(grp *ListenerGroup) bool {
+	if !multiplexed(grp) {
+		return false
+	}
+
+	for _, le := range grp.endpoints {
+		if fallback, ok := le.Handler.(FallbackHandler); ok && fallback.Fallback() && len(le.SNI) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameListenAddress(a, b ListenerSpec) bool {
+	return a.Protocol == b.Protocol &&
+		a.Address == b.Address &&
+		reflect.DeepEqual(a.Addresses, b.Addresses) &&
+		a.Port == b.Port &&
+		a.Unmanaged == b.Unmanaged
+}
+
+// resolveRandomPort updates all addresses of the group without a port to the port the OS has chosen for the bound address.
+// This way all addresses of a group share the same port and subsequent restarts of the group re-use it.
+func resolveRandomPort(grp *ListenerGroup, bound net.Addr) {
+	var boundPort int
+	switch a := bound.(type) {
+	case *net.UDPAddr:
+		boundPort = a.Port
+	case *net.TCPAddr:
+		boundPort = a.Port
+	}
+
+	addrs := make([]net.Addr, 0, len(grp.Addrs))
+	for i := range grp.Addrs {
+		switch a := grp.Addrs[i].(type) {
+		case *net.UDPAddr:
+			if a.Port == 0 {
+				a = &net.UDPAddr{IP: a.IP, Port: boundPort, Zone: a.Zone}
+			}
+			addrs = append(addrs, a)
+		case *net.TCPAddr:
+			if a.Port == 0 {
+				a = &net.TCPAddr{IP: a.IP, Port: boundPort, Zone: a.Zone}
+			}
+			addrs = append(addrs, a)
+		}
+	}
+
+	grp.Addrs = addrs
+	grp.Addr = addrs[0]
 }
@@ -1,8 +1,10 @@
 package endpoint
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"sync"
 
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
@@ -11,6 +13,7 @@ import (
 var (
 	ErrUnknownHandlerRef = errors.New("no handler for given key registered")
 	ErrNoEndpoints       = errors.New("no endpoints configured in ListenerGroup")
+	ErrNoSuchEndpoint    = errors.New("no endpoint with given name configured")
 )
 
 func NewServerBuilder(
@@ -38,25 +41,43 @@ func (e *ServerBuilder) ConfigureGroup(spec ListenerSpec) (err error) {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
-	if len(spec.Endpoints) < 1 {
-		return ErrNoEndpoints
+	var grp *ListenerGroup
+	if grp, err = e.buildGroup(spec); err != nil {
+		return err
 	}
 
+	return e.server.ConfigureGroup(grp)
+}
+
+// CreateGroup configures a group at runtime e.g. through the API.
+// In contrast to groups configured with ConfigureGroup these groups are not removed by Reconcile if they are missing
+// in the configuration.
+func (e *ServerBuilder) CreateGroup(spec ListenerSpec) (err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
 	var grp *ListenerGroup
-	if grp, err = NewListenerGroup(spec); err != nil {
+	if grp, err = e.buildGroup(spec); err != nil {
 		return err
 	}
 
-	for name, s := range spec.Endpoints {
-		if handler, registered := e.registry.HandlerForName(s.HandlerRef); registered {
-			grp.ConfigureEndpoint(name, NewListenerEndpoint(s, handler))
-		} else {
-			return ErrUnknownHandlerRef
-		}
+	grp.createdAtRuntime = true
+	return e.server.ConfigureGroup(grp)
+}
+
+// CreateAndServeGroup is like CreateGroup but also serves the created group.
+// Creating and serving happens atomically, either the group is served afterwards or it is not configured at all.
+func (e *ServerBuilder) CreateAndServeGroup(ctx context.Context, spec ListenerSpec) (err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	var grp *ListenerGroup
+	if grp, err = e.buildGroup(spec); err != nil {
+		return err
 	}
 
-	e.server.ConfigureGroup(grp)
-	return nil
+	grp.createdAtRuntime = true
+	return e.server.configureAndServeGroup(ctx, grp)
 }
 
 func (e *ServerBuilder) ConfiguredGroups() []GroupInfo {
@@ -65,3 +86,80 @@ func (e *ServerBuilder) ConfiguredGroups() []GroupInfo {
 
 	return e.server.ConfiguredGroups()
 }
+
+// UpdateEndpointOptions replaces the options of a single endpoint within a configured group.
+// The group is rebuilt with fresh handler instances and restarted if it was serving before.
+func (e *ServerBuilder) UpdateEndpointOptions(ctx context.Context, groupName, endpointName string, opts map[string]any) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	existing, exists := e.server.group(groupName)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrNoSuchGroup, groupName)
+	}
+
+	spec := existing.Spec
+
+	epSpec, exists := spec.Endpoints[endpointName]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrNoSuchEndpoint, endpointName)
+	}
+
+	endpoints := make(map[string]Spec, len(spec.Endpoints))
+	for name := range spec.Endpoints {
+		endpoints[name] = spec.Endpoints[name]
+	}
+
+	epSpec.Options = opts
+	endpoints[endpointName] = epSpec
+	spec.Endpoints = endpoints
+
+	grp, err := e.buildGroup(spec)
+	if err != nil {
+		return err
+	}
+
+	grp.createdAtRuntime = existing.createdAtRuntime
+	_, err = e.server.replaceGroup(ctx, grp)
+	return err
+}
+
+func (e *ServerBuilder) buildGroup(spec ListenerSpec) (grp *ListenerGroup, err error) {
+	if len(spec.Endpoints) < 1 {
+		return nil, ErrNoEndpoints
+	}
+
+	if grp, err = NewListenerGroup(spec); err != nil {
+		return nil, err
+	}
+
+	for name, s := range spec.Endpoints {
+		if err = ValidateSNIPatterns(s.SNI); err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", name, err)
+		}
+
+		var clientAuth *ClientAuth
+		if clientAuth, err = s.ClientAuth.Load(); err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", name, err)
+		} else if clientAuth != nil && !s.TLS {
+			return nil, fmt.Errorf("endpoint %s: %w: requires TLS", name, ErrInvalidClientAuth)
+		}
+
+		if handler, registered := e.registry.HandlerForName(s.HandlerRef); registered {
+			le := NewListenerEndpoint(s, handler)
+			le.ClientAuth = clientAuth
+			grp.ConfigureEndpoint(name, le)
+		} else {
+			return nil, ErrUnknownHandlerRef
+		}
+	}
+
+	if len(spec.Endpoints) > 1 {
+		// reject endpoints which can't be multiplexed together before the group replaces a running one
+		if _, _, err = grp.GroupByTLS(); err != nil {
+			return nil, err
+		}
+	}
+
+	return grp, nil
+}
@@ -3,11 +3,15 @@ package endpoint_test
 import (
 	"context"
 	"testing"
+	"testing/fstest"
 
 	"github.com/maxatome/go-testdeep/td"
 
 	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	grpcmock "inetmock.icb4dc0.de/inetmock/protocols/grpc/mock"
+	httpmock "inetmock.icb4dc0.de/inetmock/protocols/http/mock"
 )
 
 func TestServerBuilder_ConfigureGroup(t *testing.T) {
@@ -80,6 +84,138 @@ func TestServerBuilder_ConfigureGroup(t *testing.T) {
 			wantErr:       false,
 			wantEndpoints: td.Len(1),
 		},
+		{
+			name: "Invalid SNI pattern",
+			registrySetup: func(tb testing.TB) endpoint.HandlerRegistry {
+				tb.Helper()
+				registry := endpoint.NewHandlerRegistry()
+				registry.RegisterHandler("http_mock", func() endpoint.ProtocolHandler {
+					return ProtocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error {
+						tb.Error("should not start at all")
+						return nil
+					})
+				})
+
+				return registry
+			},
+			args: args{
+				spec: endpoint.ListenerSpec{
+					Protocol: "tcp",
+					Port:     1234,
+					Endpoints: map[string]endpoint.Spec{
+						"https": {
+							HandlerRef: "http_mock",
+							TLS:        true,
+							SNI:        []string{"[a-z.example.com"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Client auth without TLS",
+			registrySetup: func(tb testing.TB) endpoint.HandlerRegistry {
+				tb.Helper()
+				registry := endpoint.NewHandlerRegistry()
+				registry.RegisterHandler("http_mock", func() endpoint.ProtocolHandler {
+					return ProtocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error {
+						tb.Error("should not start at all")
+						return nil
+					})
+				})
+
+				return registry
+			},
+			args: args{
+				spec: endpoint.ListenerSpec{
+					Protocol: "tcp",
+					Port:     1234,
+					Endpoints: map[string]endpoint.Spec{
+						"http": {
+							HandlerRef: "http_mock",
+							ClientAuth: endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown client auth mode",
+			registrySetup: func(tb testing.TB) endpoint.HandlerRegistry {
+				tb.Helper()
+				registry := endpoint.NewHandlerRegistry()
+				registry.RegisterHandler("http_mock", func() endpoint.ProtocolHandler {
+					return ProtocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error {
+						tb.Error("should not start at all")
+						return nil
+					})
+				})
+
+				return registry
+			},
+			args: args{
+				spec: endpoint.ListenerSpec{
+					Protocol: "tcp",
+					Port:     1234,
+					Endpoints: map[string]endpoint.Spec{
+						"https": {
+							HandlerRef: "http_mock",
+							TLS:        true,
+							ClientAuth: endpoint.ClientAuthSpec{Mode: "demand"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:          "HTTP mock via h2c and gRPC mock sharing the plain group",
+			registrySetup: httpAndGRPCRegistry,
+			args: args{
+				spec: endpoint.ListenerSpec{
+					Protocol: "tcp",
+					Port:     1234,
+					Endpoints: map[string]endpoint.Spec{
+						"http": {HandlerRef: "http_mock"},
+						"grpc": {HandlerRef: "grpc_mock"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:          "HTTP mock via h2 and gRPC mock sharing the TLS group",
+			registrySetup: httpAndGRPCRegistry,
+			args: args{
+				spec: endpoint.ListenerSpec{
+					Protocol: "tcp",
+					Port:     1234,
+					Endpoints: map[string]endpoint.Spec{
+						"https": {HandlerRef: "http_mock", TLS: true},
+						"grpcs": {HandlerRef: "grpc_mock", TLS: true},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:          "Plain HTTP mock and gRPC mock via TLS",
+			registrySetup: httpAndGRPCRegistry,
+			args: args{
+				spec: endpoint.ListenerSpec{
+					Protocol: "tcp",
+					Port:     1234,
+					Endpoints: map[string]endpoint.Spec{
+						"http":  {HandlerRef: "http_mock"},
+						"grpcs": {HandlerRef: "grpc_mock", TLS: true},
+					},
+				},
+			},
+			wantErr:       false,
+			wantEndpoints: td.Len(1),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -91,9 +227,26 @@ func TestServerBuilder_ConfigureGroup(t *testing.T) {
 					t.Errorf("ConfigureGroup() error = %v, wantErr %v", err, tt.wantErr)
 				}
 				return
+			} else if tt.wantErr {
+				t.Errorf("ConfigureGroup() error = nil, wantErr %v", tt.wantErr)
+				return
 			}
 
 			td.Cmp(t, e.ConfiguredGroups(), tt.wantEndpoints)
 		})
 	}
 }
+
+func httpAndGRPCRegistry(tb testing.TB) endpoint.HandlerRegistry {
+	tb.Helper()
+	var (
+		registry = endpoint.NewHandlerRegistry()
+		logger   = logging.CreateTestLogger(tb)
+		emitter  = new(audit_mock.EmitterMock)
+	)
+
+	httpmock.AddHTTPMock(registry, logger, emitter, fstest.MapFS{}, nil)
+	grpcmock.AddGRPCMock(registry, logger, emitter)
+
+	return registry
+}
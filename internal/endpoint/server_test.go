@@ -2,6 +2,8 @@ package endpoint_test
 
 import (
 	"context"
+	"crypto/tls"
+	"io"
 	"net"
 	"net/http"
 	"testing"
@@ -57,7 +59,7 @@ func TestServer_ServeGroups_Success(t *testing.T) {
 func prepareServer(tb testing.TB, emitter audit.Emitter, logger logging.Logger) (*net.TCPAddr, *endpoint.Server) {
 	tb.Helper()
 	defaultRegistry := endpoint.NewHandlerRegistry()
-	mock.AddHTTPMock(defaultRegistry, logger, emitter, fstest.MapFS{})
+	mock.AddHTTPMock(defaultRegistry, logger, emitter, fstest.MapFS{}, nil)
 	builder := endpoint.NewServerBuilder(nil, defaultRegistry, logger)
 
 	var port int
@@ -104,3 +106,416 @@ func prepareServer(tb testing.TB, emitter audit.Emitter, logger logging.Logger)
 
 	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port}, srv
 }
+
+func TestServer_ServeGroups_MultipleAddresses(t *testing.T) {
+	t.Parallel()
+
+	mockEmitter := new(audit_mock.EmitterMock)
+	logger := logging.CreateTestLogger(t)
+
+	defaultRegistry := endpoint.NewHandlerRegistry()
+	mock.AddHTTPMock(defaultRegistry, logger, mockEmitter, fstest.MapFS{}, nil)
+	builder := endpoint.NewServerBuilder(nil, defaultRegistry, logger)
+
+	port, err := netutils.RandomPort()
+	if err != nil {
+		t.Fatalf("netutils.RandomPort() error = %v", err)
+	}
+
+	spec := endpoint.ListenerSpec{
+		Protocol:  "tcp",
+		Addresses: []string{"127.0.0.1", "::1"},
+		Port:      uint16(port),
+		Endpoints: map[string]endpoint.Spec{
+			"plain": {
+				HandlerRef: "http_mock",
+				Options: map[string]any{
+					"rules": []string{`=> Status(204)`},
+				},
+			},
+		},
+	}
+
+	if err := builder.ConfigureGroup(spec); err != nil {
+		t.Fatalf("builder.ConfigureGroup() error = %v", err)
+	}
+
+	srv := builder.Server()
+
+	startupCtx, startupCancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	t.Cleanup(startupCancel)
+	if err := srv.ServeGroups(startupCtx); err != nil {
+		t.Fatalf("srv.ServeGroups() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := srv.Shutdown(context.Background()); err != nil {
+			t.Errorf("srv.Shutdown() error = %v", err)
+		}
+	})
+
+	for _, ip := range []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback} {
+		httpClient := test.HTTPClientForAddr(t, &net.TCPAddr{IP: ip, Port: port})
+		resp, err := ctxhttp.Get(context.Background(), httpClient, "http://www.stackoverflow.com/")
+		if err != nil {
+			t.Errorf("httpClient.Get() via %s error = %v", ip, err)
+			continue
+		}
+		_ = resp.Body.Close()
+		td.Cmp(t, resp.StatusCode, http.StatusNoContent)
+	}
+}
+
+func TestServer_ServeGroups_SNIRouting(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		endpoints  map[string]endpoint.Spec
+		url        string
+		wantStatus any
+		wantErr    bool
+	}{
+		{
+			name:       "Plain text request",
+			endpoints:  sniRoutingEndpoints(true),
+			url:        "http://www.example.com/",
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "TLS without matching SNI route",
+			endpoints:  sniRoutingEndpoints(true),
+			url:        "https://www.example.com/",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "TLS with matching SNI route",
+			endpoints:  sniRoutingEndpoints(true),
+			url:        "https://update.example.com/",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "TLS with matching wildcard SNI route",
+			endpoints:  sniRoutingEndpoints(true),
+			url:        "https://cdn.example.org/",
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:      "TLS without matching SNI route and default endpoint",
+			endpoints: sniRoutingEndpoints(false),
+			url:       "https://www.example.com/",
+			wantErr:   true,
+		},
+		{
+			name: "Single endpoint with SNI route",
+			endpoints: map[string]endpoint.Spec{
+				"update": sniRoutingEndpoints(false)["update"],
+			},
+			url:        "https://update.example.com/",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "Single endpoint without matching SNI route",
+			endpoints: map[string]endpoint.Spec{
+				"update": sniRoutingEndpoints(false)["update"],
+			},
+			url:     "https://www.example.com/",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			logger := logging.CreateTestLogger(t)
+			registry := endpoint.NewHandlerRegistry()
+			mock.AddHTTPMock(registry, logger, new(audit_mock.EmitterMock), fstest.MapFS{}, nil)
+			builder := endpoint.NewServerBuilder(test.NewSelfSignedCertStore(t).TLSConfig(), registry, logger)
+
+			spec := endpoint.ListenerSpec{
+				Name:      "sni",
+				Protocol:  "tcp",
+				Address:   "127.0.0.1",
+				Endpoints: tt.endpoints,
+			}
+
+			if err := builder.ConfigureGroup(spec); err != nil {
+				t.Fatalf("builder.ConfigureGroup() error = %v", err)
+			}
+
+			srv := builder.Server()
+			if err := srv.ServeGroups(test.Context(t)); err != nil {
+				t.Fatalf("srv.ServeGroups() error = %v", err)
+			}
+
+			t.Cleanup(func() {
+				if err := srv.Shutdown(context.Background()); err != nil {
+					t.Errorf("srv.Shutdown() error = %v", err)
+				}
+			})
+
+			dialer := new(net.Dialer)
+			httpClient := &http.Client{
+				Transport: &http.Transport{
+					DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+						return dialer.DialContext(ctx, "tcp", srv.ConfiguredGroups()[0].Addr.String())
+					},
+					//nolint:gosec // the server uses a self-signed certificate
+					TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+				},
+				Timeout: time.Second,
+			}
+
+			resp, err := ctxhttp.Get(test.Context(t), httpClient, tt.url)
+			if tt.wantErr {
+				td.CmpError(t, err)
+				return
+			}
+
+			if td.CmpNoError(t, err) {
+				_ = resp.Body.Close()
+				td.Cmp(t, resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func sniRoutingEndpoints(withDefaults bool) map[string]endpoint.Spec {
+	endpoints := map[string]endpoint.Spec{
+		"update": {
+			HandlerRef: "http_mock",
+			TLS:        true,
+			SNI:        []string{"update.example.com"},
+			Options:    map[string]any{"rules": []string{`=> Status(204)`}},
+		},
+		"cdn": {
+			HandlerRef: "http_mock",
+			TLS:        true,
+			SNI:        []string{"*.example.org"},
+			Options:    map[string]any{"rules": []string{`=> Status(201)`}},
+		},
+	}
+
+	if withDefaults {
+		endpoints["plain"] = endpoint.Spec{
+			HandlerRef: "http_mock",
+			Options:    map[string]any{"rules": []string{`=> Status(202)`}},
+		}
+		endpoints["https"] = endpoint.Spec{
+			HandlerRef: "http_mock",
+			TLS:        true,
+			Options:    map[string]any{"rules": []string{`=> Status(200)`}},
+		}
+	}
+
+	return endpoints
+}
+
+func TestServer_ServeGroups_ClientAuth(t *testing.T) {
+	t.Parallel()
+	var (
+		clientCA  = test.NewClientCA(t)
+		trusted   = clientCA.ClientCert(t, "device-42")
+		untrusted = test.NewClientCA(t).ClientCert(t, "device-42")
+		rules     = map[string]any{"rules": []string{`ClientCert("cn", "^device-42$") => Status(204)`, `=> Status(200)`}}
+		require   = endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequire, CAFiles: []string{clientCA.CAFile}}
+		request   = endpoint.ClientAuthSpec{Mode: endpoint.ClientAuthModeRequest}
+	)
+
+	tests := []struct {
+		name        string
+		endpoints   map[string]endpoint.Spec
+		url         string
+		certificate *tls.Certificate
+		wantStatus  any
+		wantErr     bool
+	}{
+		{
+			name: "Required certificate issued by trusted CA",
+			endpoints: map[string]endpoint.Spec{
+				"https": {HandlerRef: "http_mock", TLS: true, ClientAuth: require, Options: rules},
+			},
+			url:         "https://www.example.com/",
+			certificate: &trusted,
+			wantStatus:  http.StatusNoContent,
+		},
+		{
+			name: "Required certificate missing",
+			endpoints: map[string]endpoint.Spec{
+				"https": {HandlerRef: "http_mock", TLS: true, ClientAuth: require, Options: rules},
+			},
+			url:     "https://www.example.com/",
+			wantErr: true,
+		},
+		{
+			name: "Required certificate issued by untrusted CA",
+			endpoints: map[string]endpoint.Spec{
+				"https": {HandlerRef: "http_mock", TLS: true, ClientAuth: require, Options: rules},
+			},
+			url:         "https://www.example.com/",
+			certificate: &untrusted,
+			wantErr:     true,
+		},
+		{
+			name: "Requested certificate is accepted blindly",
+			endpoints: map[string]endpoint.Spec{
+				"https": {HandlerRef: "http_mock", TLS: true, ClientAuth: request, Options: rules},
+			},
+			url:         "https://www.example.com/",
+			certificate: &untrusted,
+			wantStatus:  http.StatusNoContent,
+		},
+		{
+			name: "Requested certificate missing",
+			endpoints: map[string]endpoint.Spec{
+				"https": {HandlerRef: "http_mock", TLS: true, ClientAuth: request, Options: rules},
+			},
+			url:        "https://www.example.com/",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Multiplexed TLS endpoint",
+			endpoints: map[string]endpoint.Spec{
+				"plain": {HandlerRef: "http_mock", Options: map[string]any{"rules": []string{`=> Status(202)`}}},
+				"https": {HandlerRef: "http_mock", TLS: true, ClientAuth: require, Options: rules},
+			},
+			url:         "https://www.example.com/",
+			certificate: &trusted,
+			wantStatus:  http.StatusNoContent,
+		},
+		{
+			name: "SNI routed endpoint",
+			endpoints: map[string]endpoint.Spec{
+				"https":  {HandlerRef: "http_mock", TLS: true, Options: map[string]any{"rules": []string{`=> Status(202)`}}},
+				"device": {HandlerRef: "http_mock", TLS: true, SNI: []string{"device.example.com"}, ClientAuth: require, Options: rules},
+			},
+			url:         "https://device.example.com/",
+			certificate: &trusted,
+			wantStatus:  http.StatusNoContent,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			logger := logging.CreateTestLogger(t)
+			registry := endpoint.NewHandlerRegistry()
+			mock.AddHTTPMock(registry, logger, new(audit_mock.EmitterMock), fstest.MapFS{}, nil)
+			builder := endpoint.NewServerBuilder(test.NewSelfSignedCertStore(t).TLSConfig(), registry, logger)
+
+			spec := endpoint.ListenerSpec{
+				Name:      "mtls",
+				Protocol:  "tcp",
+				Address:   "127.0.0.1",
+				Endpoints: tt.endpoints,
+			}
+
+			if err := builder.ConfigureGroup(spec); err != nil {
+				t.Fatalf("builder.ConfigureGroup() error = %v", err)
+			}
+
+			srv := builder.Server()
+			if err := srv.ServeGroups(test.Context(t)); err != nil {
+				t.Fatalf("srv.ServeGroups() error = %v", err)
+			}
+
+			t.Cleanup(func() {
+				if err := srv.Shutdown(context.Background()); err != nil {
+					t.Errorf("srv.Shutdown() error = %v", err)
+				}
+			})
+
+			//nolint:gosec // the server uses a self-signed certificate
+			tlsConfig := &tls.Config{InsecureSkipVerify: true}
+			if tt.certificate != nil {
+				tlsConfig.Certificates = []tls.Certificate{*tt.certificate}
+			}
+
+			dialer := new(net.Dialer)
+			httpClient := &http.Client{
+				Transport: &http.Transport{
+					DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+						return dialer.DialContext(ctx, "tcp", srv.ConfiguredGroups()[0].Addr.String())
+					},
+					TLSClientConfig: tlsConfig,
+				},
+				Timeout: time.Second,
+			}
+
+			resp, err := ctxhttp.Get(test.Context(t), httpClient, tt.url)
+			if tt.wantErr {
+				td.CmpError(t, err)
+				return
+			}
+
+			if td.CmpNoError(t, err) {
+				_ = resp.Body.Close()
+				td.Cmp(t, resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestServer_ServeGroups_ServerSpeaksFirst(t *testing.T) {
+	t.Parallel()
+	const greeting = "220 inetmock ready\r\n"
+
+	registry := endpoint.NewHandlerRegistry()
+	registry.RegisterHandler("greeting", func() endpoint.ProtocolHandler {
+		return ProtocolHandlerFunc(func(_ context.Context, spec *endpoint.StartupSpec) error {
+			go func() {
+				for {
+					conn, err := spec.Listener.Accept()
+					if err != nil {
+						return
+					}
+					_, _ = conn.Write([]byte(greeting))
+					_ = conn.Close()
+				}
+			}()
+			return nil
+		})
+	})
+
+	builder := endpoint.NewServerBuilder(nil, registry, logging.CreateTestLogger(t))
+	spec := endpoint.ListenerSpec{
+		Name:      "greeting",
+		Protocol:  "tcp",
+		Address:   "127.0.0.1",
+		Endpoints: map[string]endpoint.Spec{"plain": {HandlerRef: "greeting"}},
+	}
+
+	if err := builder.ConfigureGroup(spec); err != nil {
+		t.Fatalf("builder.ConfigureGroup() error = %v", err)
+	}
+
+	srv := builder.Server()
+	if err := srv.ServeGroups(test.Context(t)); err != nil {
+		t.Fatalf("srv.ServeGroups() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := srv.Shutdown(context.Background()); err != nil {
+			t.Errorf("srv.Shutdown() error = %v", err)
+		}
+	})
+
+	conn, err := net.Dial("tcp", srv.ConfiguredGroups()[0].Addr.String())
+	if err != nil {
+		t.Fatalf("net.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	// with TCP_DEFER_ACCEPT the connection would only be accepted after the client sent data or after about a second
+	if err = conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond)); err != nil {
+		t.Fatalf("conn.SetReadDeadline() error = %v", err)
+	}
+
+	got := make([]byte, len(greeting))
+	if _, err = io.ReadFull(conn, got); err != nil {
+		t.Fatalf("io.ReadFull() error = %v", err)
+	}
+
+	td.Cmp(t, string(got), greeting)
+}
@@ -0,0 +1,86 @@
+package endpoint
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+)
+
+var _ audit.TLSFingerprinter = (*helloRecordingConn)(nil)
+
+// NewTLSListener is a drop-in replacement for tls.NewListener.
+// It records the ClientHello of every accepted connection to make the fingerprint of the client available
+// via audit.ConnTLSFingerprint after the handshake.
+func NewTLSListener(inner net.Listener, cfg *tls.Config) net.Listener {
+	return tls.NewListener(&helloRecordingListener{Listener: inner}, cfg)
+}
+
+type helloRecordingListener struct {
+	net.Listener
+}
+
+func (l *helloRecordingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return &helloRecordingConn{Conn: conn}, nil
+}
+
+// helloRecordingConn buffers everything read from the connection until the first handshake message is complete
+type helloRecordingConn struct {
+	net.Conn
+	lock        sync.Mutex
+	done        bool
+	buf         []byte
+	fingerprint *audit.TLSFingerprint
+}
+
+func (c *helloRecordingConn) Read(b []byte) (n int, err error) {
+	n, err = c.Conn.Read(b)
+	if n > 0 {
+		c.record(b[:n])
+	}
+
+	return n, err
+}
+
+func (c *helloRecordingConn) TLSFingerprint() *audit.TLSFingerprint {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.fingerprint
+}
+
+func (c *helloRecordingConn) record(data []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.done {
+		return
+	}
+
+	c.buf = append(c.buf, data...)
+	msg, err := readHandshakeMessage(bytes.NewReader(c.buf))
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// wait for the remaining records
+		return
+	}
+
+	c.done = true
+	c.buf = nil
+
+	if err != nil {
+		return
+	}
+
+	if hello, err := parseClientHello(msg); err == nil {
+		c.fingerprint = hello.Fingerprint()
+	}
+}
@@ -45,10 +45,15 @@ type CacheMockReverseLookupCall struct {
 	Results   CacheMockReverseLookupCallResults
 }
 
+type CacheMockFlushCall struct {
+	Timestamp time.Time
+}
+
 type CacheMockCalls struct {
 	PutRecord     []CacheMockPutRecordCall
 	ForwardLookup []CacheMockForwardLookupCall
 	ReverseLookup []CacheMockReverseLookupCall
+	Flush         []CacheMockFlushCall
 }
 
 type CacheMockCallsContext struct {
@@ -62,6 +67,7 @@ type CacheMock struct {
 	OnPutRecord     func(state CacheMockCallsContext, host string, address net.IP)
 	OnForwardLookup func(state CacheMockCallsContext, host string) net.IP
 	OnReverseLookup func(state CacheMockCallsContext, address net.IP) (host string, miss bool)
+	OnFlush         func(state CacheMockCallsContext)
 }
 
 func (m *CacheMock) PutRecord(host string, address net.IP) {
@@ -127,3 +133,17 @@ func (m *CacheMock) ReverseLookup(address net.IP) (host string, miss bool) {
 
 	return
 }
+
+func (m *CacheMock) Flush() {
+	if m.OnFlush != nil {
+		ctx := CacheMockCallsContext{
+			CacheMockCalls: m.Calls,
+			TB:             m.TB,
+		}
+		m.OnFlush(ctx)
+	}
+
+	m.Calls.Flush = append(m.Calls.Flush, CacheMockFlushCall{
+		Timestamp: time.Now(),
+	})
+}
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	cmux "github.com/soheilhy/cmux"
 	endpoint "inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	rules "inetmock.icb4dc0.de/inetmock/internal/rules"
 )
 
 // MockProtocolHandler is a mock of ProtocolHandler interface.
@@ -101,6 +102,136 @@ func (mr *MockMultiplexHandlerMockRecorder) Start(ctx, ss interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockMultiplexHandler)(nil).Start), ctx, ss)
 }
 
+// MockFallbackHandler is a mock of FallbackHandler interface.
+type MockFallbackHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockFallbackHandlerMockRecorder
+}
+
+// MockFallbackHandlerMockRecorder is the mock recorder for MockFallbackHandler.
+type MockFallbackHandlerMockRecorder struct {
+	mock *MockFallbackHandler
+}
+
+// NewMockFallbackHandler creates a new mock instance.
+func NewMockFallbackHandler(ctrl *gomock.Controller) *MockFallbackHandler {
+	mock := &MockFallbackHandler{ctrl: ctrl}
+	mock.recorder = &MockFallbackHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFallbackHandler) EXPECT() *MockFallbackHandlerMockRecorder {
+	return m.recorder
+}
+
+// Fallback mocks base method.
+func (m *MockFallbackHandler) Fallback() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fallback")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Fallback indicates an expected call of Fallback.
+func (mr *MockFallbackHandlerMockRecorder) Fallback() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fallback", reflect.TypeOf((*MockFallbackHandler)(nil).Fallback))
+}
+
+// Matchers mocks base method.
+func (m *MockFallbackHandler) Matchers() []cmux.Matcher {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Matchers")
+	ret0, _ := ret[0].([]cmux.Matcher)
+	return ret0
+}
+
+// Matchers indicates an expected call of Matchers.
+func (mr *MockFallbackHandlerMockRecorder) Matchers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Matchers", reflect.TypeOf((*MockFallbackHandler)(nil).Matchers))
+}
+
+// Start mocks base method.
+func (m *MockFallbackHandler) Start(ctx context.Context, ss *endpoint.StartupSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, ss)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockFallbackHandlerMockRecorder) Start(ctx, ss interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockFallbackHandler)(nil).Start), ctx, ss)
+}
+
+// MockMatchWritingHandler is a mock of MatchWritingHandler interface.
+type MockMatchWritingHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockMatchWritingHandlerMockRecorder
+}
+
+// MockMatchWritingHandlerMockRecorder is the mock recorder for MockMatchWritingHandler.
+type MockMatchWritingHandlerMockRecorder struct {
+	mock *MockMatchWritingHandler
+}
+
+// NewMockMatchWritingHandler creates a new mock instance.
+func NewMockMatchWritingHandler(ctrl *gomock.Controller) *MockMatchWritingHandler {
+	mock := &MockMatchWritingHandler{ctrl: ctrl}
+	mock.recorder = &MockMatchWritingHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMatchWritingHandler) EXPECT() *MockMatchWritingHandlerMockRecorder {
+	return m.recorder
+}
+
+// MatchWriters mocks base method.
+func (m *MockMatchWritingHandler) MatchWriters() []cmux.MatchWriter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchWriters")
+	ret0, _ := ret[0].([]cmux.MatchWriter)
+	return ret0
+}
+
+// MatchWriters indicates an expected call of MatchWriters.
+func (mr *MockMatchWritingHandlerMockRecorder) MatchWriters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchWriters", reflect.TypeOf((*MockMatchWritingHandler)(nil).MatchWriters))
+}
+
+// Matchers mocks base method.
+func (m *MockMatchWritingHandler) Matchers() []cmux.Matcher {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Matchers")
+	ret0, _ := ret[0].([]cmux.Matcher)
+	return ret0
+}
+
+// Matchers indicates an expected call of Matchers.
+func (mr *MockMatchWritingHandlerMockRecorder) Matchers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Matchers", reflect.TypeOf((*MockMatchWritingHandler)(nil).Matchers))
+}
+
+// Start mocks base method.
+func (m *MockMatchWritingHandler) Start(ctx context.Context, ss *endpoint.StartupSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, ss)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockMatchWritingHandlerMockRecorder) Start(ctx, ss interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockMatchWritingHandler)(nil).Start), ctx, ss)
+}
+
 // MockStoppableHandler is a mock of StoppableHandler interface.
 type MockStoppableHandler struct {
 	ctrl     *gomock.Controller
@@ -152,6 +283,109 @@ func (mr *MockStoppableHandlerMockRecorder) Stop(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockStoppableHandler)(nil).Stop), ctx)
 }
 
+// MockRuleManagingHandler is a mock of RuleManagingHandler interface.
+type MockRuleManagingHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuleManagingHandlerMockRecorder
+}
+
+// MockRuleManagingHandlerMockRecorder is the mock recorder for MockRuleManagingHandler.
+type MockRuleManagingHandlerMockRecorder struct {
+	mock *MockRuleManagingHandler
+}
+
+// NewMockRuleManagingHandler creates a new mock instance.
+func NewMockRuleManagingHandler(ctrl *gomock.Controller) *MockRuleManagingHandler {
+	mock := &MockRuleManagingHandler{ctrl: ctrl}
+	mock.recorder = &MockRuleManagingHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRuleManagingHandler) EXPECT() *MockRuleManagingHandlerMockRecorder {
+	return m.recorder
+}
+
+// RuleManager mocks base method.
+func (m *MockRuleManagingHandler) RuleManager() rules.Manager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RuleManager")
+	ret0, _ := ret[0].(rules.Manager)
+	return ret0
+}
+
+// RuleManager indicates an expected call of RuleManager.
+func (mr *MockRuleManagingHandlerMockRecorder) RuleManager() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RuleManager", reflect.TypeOf((*MockRuleManagingHandler)(nil).RuleManager))
+}
+
+// Start mocks base method.
+func (m *MockRuleManagingHandler) Start(ctx context.Context, ss *endpoint.StartupSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, ss)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockRuleManagingHandlerMockRecorder) Start(ctx, ss interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockRuleManagingHandler)(nil).Start), ctx, ss)
+}
+
+// MockMessageInjectingHandler is a mock of MessageInjectingHandler interface.
+type MockMessageInjectingHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageInjectingHandlerMockRecorder
+}
+
+// MockMessageInjectingHandlerMockRecorder is the mock recorder for MockMessageInjectingHandler.
+type MockMessageInjectingHandlerMockRecorder struct {
+	mock *MockMessageInjectingHandler
+}
+
+// NewMockMessageInjectingHandler creates a new mock instance.
+func NewMockMessageInjectingHandler(ctrl *gomock.Controller) *MockMessageInjectingHandler {
+	mock := &MockMessageInjectingHandler{ctrl: ctrl}
+	mock.recorder = &MockMessageInjectingHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageInjectingHandler) EXPECT() *MockMessageInjectingHandlerMockRecorder {
+	return m.recorder
+}
+
+// InjectMessage mocks base method.
+func (m *MockMessageInjectingHandler) InjectMessage(target, sender, message string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectMessage", target, sender, message)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectMessage indicates an expected call of InjectMessage.
+func (mr *MockMessageInjectingHandlerMockRecorder) InjectMessage(target, sender, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectMessage", reflect.TypeOf((*MockMessageInjectingHandler)(nil).InjectMessage), target, sender, message)
+}
+
+// Start mocks base method.
+func (m *MockMessageInjectingHandler) Start(ctx context.Context, ss *endpoint.StartupSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, ss)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockMessageInjectingHandlerMockRecorder) Start(ctx, ss interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockMessageInjectingHandler)(nil).Start), ctx, ss)
+}
+
 // MockHost is a mock of Host interface.
 type MockHost struct {
 	ctrl     *gomock.Controller
@@ -189,6 +423,35 @@ func (mr *MockHostMockRecorder) ConfiguredGroups() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfiguredGroups", reflect.TypeOf((*MockHost)(nil).ConfiguredGroups))
 }
 
+// EndpointHandler mocks base method.
+func (m *MockHost) EndpointHandler(groupName, endpointName string) (endpoint.ProtocolHandler, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndpointHandler", groupName, endpointName)
+	ret0, _ := ret[0].(endpoint.ProtocolHandler)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndpointHandler indicates an expected call of EndpointHandler.
+func (mr *MockHostMockRecorder) EndpointHandler(groupName, endpointName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndpointHandler", reflect.TypeOf((*MockHost)(nil).EndpointHandler), groupName, endpointName)
+}
+
+// RemoveGroup mocks base method.
+func (m *MockHost) RemoveGroup(ctx context.Context, groupName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveGroup", ctx, groupName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveGroup indicates an expected call of RemoveGroup.
+func (mr *MockHostMockRecorder) RemoveGroup(ctx, groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGroup", reflect.TypeOf((*MockHost)(nil).RemoveGroup), ctx, groupName)
+}
+
 // ServeGroup mocks base method.
 func (m *MockHost) ServeGroup(ctx context.Context, groupName string) error {
 	m.ctrl.T.Helper()
@@ -295,3 +558,83 @@ func (mr *MockHostBuilderMockRecorder) ConfiguredGroups() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfiguredGroups", reflect.TypeOf((*MockHostBuilder)(nil).ConfiguredGroups))
 }
+
+// CreateAndServeGroup mocks base method.
+func (m *MockHostBuilder) CreateAndServeGroup(ctx context.Context, spec endpoint.ListenerSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAndServeGroup", ctx, spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAndServeGroup indicates an expected call of CreateAndServeGroup.
+func (mr *MockHostBuilderMockRecorder) CreateAndServeGroup(ctx, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAndServeGroup", reflect.TypeOf((*MockHostBuilder)(nil).CreateAndServeGroup), ctx, spec)
+}
+
+// CreateGroup mocks base method.
+func (m *MockHostBuilder) CreateGroup(spec endpoint.ListenerSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockHostBuilderMockRecorder) CreateGroup(spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockHostBuilder)(nil).CreateGroup), spec)
+}
+
+// UpdateEndpointOptions mocks base method.
+func (m *MockHostBuilder) UpdateEndpointOptions(ctx context.Context, groupName, endpointName string, opts map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEndpointOptions", ctx, groupName, endpointName, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEndpointOptions indicates an expected call of UpdateEndpointOptions.
+func (mr *MockHostBuilderMockRecorder) UpdateEndpointOptions(ctx, groupName, endpointName, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEndpointOptions", reflect.TypeOf((*MockHostBuilder)(nil).UpdateEndpointOptions), ctx, groupName, endpointName, opts)
+}
+
+// MockConfigReloader is a mock of ConfigReloader interface.
+type MockConfigReloader struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigReloaderMockRecorder
+}
+
+// MockConfigReloaderMockRecorder is the mock recorder for MockConfigReloader.
+type MockConfigReloaderMockRecorder struct {
+	mock *MockConfigReloader
+}
+
+// NewMockConfigReloader creates a new mock instance.
+func NewMockConfigReloader(ctrl *gomock.Controller) *MockConfigReloader {
+	mock := &MockConfigReloader{ctrl: ctrl}
+	mock.recorder = &MockConfigReloaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigReloader) EXPECT() *MockConfigReloaderMockRecorder {
+	return m.recorder
+}
+
+// ReloadConfig mocks base method.
+func (m *MockConfigReloader) ReloadConfig(ctx context.Context) ([]endpoint.GroupReconcileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadConfig", ctx)
+	ret0, _ := ret[0].([]endpoint.GroupReconcileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadConfig indicates an expected call of ReloadConfig.
+func (mr *MockConfigReloaderMockRecorder) ReloadConfig(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadConfig", reflect.TypeOf((*MockConfigReloader)(nil).ReloadConfig), ctx)
+}
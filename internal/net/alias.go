@@ -3,7 +3,9 @@ package net
 import "net"
 
 type (
+	Addr       = net.Addr
 	UDPAddr    = net.UDPAddr
+	UDPConn    = net.UDPConn
 	TCPAddr    = net.TCPAddr
 	Listener   = net.Listener
 	PacketConn = net.PacketConn
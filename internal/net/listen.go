@@ -3,50 +3,126 @@
 package net
 
 import (
+	"context"
+	"fmt"
 	"net"
+	"syscall"
 
-	"github.com/valyala/tcplisten"
+	"golang.org/x/sys/unix"
 )
 
+const fastOpenQlen = 16 * 1024
+
 type (
+	listenConfig struct {
+		DeferAccept bool
+		ReuseAddr   bool
+		ReusePort   bool
+		FastOpen    bool
+	}
 	TCPListenOption interface {
-		apply(cfg *tcplisten.Config)
+		apply(cfg *listenConfig)
 	}
-	TCPListenOptionFunc func(cfg *tcplisten.Config)
+	TCPListenOptionFunc func(cfg *listenConfig)
 )
 
-func (f TCPListenOptionFunc) apply(cfg *tcplisten.Config) {
+func (f TCPListenOptionFunc) apply(cfg *listenConfig) {
 	f(cfg)
 }
 
 var (
 	WithDeferAccept = func(deferAccept bool) TCPListenOption {
-		return TCPListenOptionFunc(func(cfg *tcplisten.Config) {
+		return TCPListenOptionFunc(func(cfg *listenConfig) {
 			cfg.DeferAccept = deferAccept
 		})
 	}
 
 	WithReusePort = func(reusePort bool) TCPListenOption {
-		return TCPListenOptionFunc(func(cfg *tcplisten.Config) {
+		return TCPListenOptionFunc(func(cfg *listenConfig) {
 			cfg.ReusePort = reusePort
 		})
 	}
 
 	WithFastOpen = func(fastOpen bool) TCPListenOption {
-		return TCPListenOptionFunc(func(cfg *tcplisten.Config) {
+		return TCPListenOptionFunc(func(cfg *listenConfig) {
 			cfg.FastOpen = fastOpen
 		})
 	}
 )
 
-func ListenTCP(addr *net.TCPAddr, opts ...TCPListenOption) (listener net.Listener, err error) {
-	const network = "tcp4"
-
-	listenerCfg := new(tcplisten.Config)
+// ListenTCP binds a TCP listener to the given address.
+// The network determines the address family:
+// tcp4 and tcp6 only accept connections of the respective family,
+// tcp accepts connections of both families if addr is the IPv6 wildcard address.
+func ListenTCP(network string, addr *net.TCPAddr, opts ...TCPListenOption) (listener net.Listener, err error) {
+	listenerCfg := new(listenConfig)
 
 	for i := range opts {
 		opts[i].apply(listenerCfg)
 	}
 
-	return listenerCfg.NewListener(network, addr.String())
+	lc := net.ListenConfig{
+		Control: listenerCfg.control,
+	}
+
+	return lc.Listen(context.Background(), network, addr.String())
+}
+
+// ListenReusableUDP binds a UDP socket with SO_REUSEADDR and SO_REUSEPORT enabled
+// to share well-known ports like 5353 (mDNS) with other responders running on the same host.
+func ListenReusableUDP(network string, addr *net.UDPAddr) (*net.UDPConn, error) {
+	listenerCfg := &listenConfig{
+		ReuseAddr: true,
+		ReusePort: true,
+	}
+
+	lc := net.ListenConfig{
+		Control: listenerCfg.control,
+	}
+
+	conn, err := lc.ListenPacket(context.Background(), network, addr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.(*net.UDPConn), nil
+}
+
+func (cfg *listenConfig) control(_, _ string, rawConn syscall.RawConn) error {
+	var sockOptErr error
+	if err := rawConn.Control(func(fd uintptr) {
+		sockOptErr = cfg.setSockOpts(int(fd))
+	}); err != nil {
+		return err
+	}
+
+	return sockOptErr
+}
+
+func (cfg *listenConfig) setSockOpts(fd int) error {
+	if cfg.ReuseAddr {
+		if err := unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_REUSEADDR, 1); err != nil {
+			return fmt.Errorf("cannot enable SO_REUSEADDR: %w", err)
+		}
+	}
+
+	if cfg.ReusePort {
+		if err := unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_REUSEPORT, 1); err != nil {
+			return fmt.Errorf("cannot enable SO_REUSEPORT: %w", err)
+		}
+	}
+
+	if cfg.DeferAccept {
+		if err := unix.SetsockoptInt(fd, unix.IPPROTO_TCP, unix.TCP_DEFER_ACCEPT, 1); err != nil {
+			return fmt.Errorf("cannot enable TCP_DEFER_ACCEPT: %w", err)
+		}
+	}
+
+	if cfg.FastOpen {
+		if err := unix.SetsockoptInt(fd, unix.SOL_TCP, unix.TCP_FASTOPEN, fastOpenQlen); err != nil {
+			return fmt.Errorf("cannot enable TCP_FASTOPEN: %w", err)
+		}
+	}
+
+	return nil
 }
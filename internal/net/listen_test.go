@@ -11,6 +11,7 @@ func TestManagedListener(t *testing.T) {
 	t.Parallel()
 	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
 	listener, err := net2.ListenTCP(
+		"tcp4",
 		addr,
 		net2.WithFastOpen(true),
 		net2.WithReusePort(true),
@@ -45,3 +46,27 @@ func TestManagedListener(t *testing.T) {
 		t.Logf("listener.Close() error = %v", err)
 	}
 }
+
+func TestListenReusableUDP(t *testing.T) {
+	t.Parallel()
+	first, err := net2.ListenReusableUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Errorf("ListenReusableUDP() error = %v", err)
+		return
+	}
+
+	t.Cleanup(func() {
+		_ = first.Close()
+	})
+
+	// the second socket binds the same port as the first one
+	second, err := net2.ListenReusableUDP("udp4", first.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		t.Errorf("ListenReusableUDP() error = %v", err)
+		return
+	}
+
+	if err = second.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	maxUDPPacketSize = 65535
+	// upper bound of remembered clients before the mapping to the receiving connections is reset
+	maxTrackedPeers = 4096
+)
+
+// MultiListener combines the given listeners into a single one accepting connections from all of them.
+// Addr returns the address of the first listener, closing the returned listener closes all listeners.
+func MultiListener(listeners ...net.Listener) net.Listener {
+	if len(listeners) == 1 {
+		return listeners[0]
+	}
+
+	ml := &multiListener{
+		listeners: listeners,
+		results:   make(chan acceptResult),
+		done:      make(chan struct{}),
+	}
+
+	for idx := range listeners {
+		go ml.acceptLoop(listeners[idx])
+	}
+
+	return ml
+}
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+type multiListener struct {
+	listeners []net.Listener
+	results   chan acceptResult
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (m *multiListener) Accept() (net.Conn, error) {
+	select {
+	case res := <-m.results:
+		return res.conn, res.err
+	case <-m.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (m *multiListener) Close() (err error) {
+	m.closeOnce.Do(func() {
+		close(m.done)
+		for idx := range m.listeners {
+			err = errors.Join(err, m.listeners[idx].Close())
+		}
+	})
+	return err
+}
+
+func (m *multiListener) Addr() net.Addr {
+	return m.listeners[0].Addr()
+}
+
+func (m *multiListener) acceptLoop(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		select {
+		case m.results <- acceptResult{conn: conn, err: err}:
+		case <-m.done:
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return
+		}
+
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+	}
+}
+
+// MultiPacketConn combines the given connections into a single one receiving packets from all of them.
+// Responses are sent through the connection that received the last packet of the peer,
+// LocalAddr returns the address of the first connection and closing the returned connection closes all connections.
+func MultiPacketConn(conns ...net.PacketConn) net.PacketConn {
+	if len(conns) == 1 {
+		return conns[0]
+	}
+
+	mpc := &multiPacketConn{
+		conns:           conns,
+		packets:         make(chan packet),
+		done:            make(chan struct{}),
+		deadlineChanged: make(chan struct{}),
+		peers:           make(map[string]net.PacketConn),
+	}
+
+	for idx := range conns {
+		go mpc.readLoop(conns[idx])
+	}
+
+	return mpc
+}
+
+// IsMultiPacketConn reports whether the given connection combines the sockets of multiple addresses
+func IsMultiPacketConn(conn net.PacketConn) bool {
+	_, ok := conn.(*multiPacketConn)
+	return ok
+}
+
+type packet struct {
+	data []byte
+	addr net.Addr
+	err  error
+}
+
+type multiPacketConn struct {
+	conns     []net.PacketConn
+	packets   chan packet
+	done      chan struct{}
+	closeOnce sync.Once
+
+	lock            sync.Mutex
+	readDeadline    time.Time
+	deadlineChanged chan struct{}
+	peers           map[string]net.PacketConn
+}
+
+func (m *multiPacketConn) ReadFrom(p []byte) (n int, addr net.Addr, err error) {
+	for {
+		var deadlineChanged bool
+		if n, addr, deadlineChanged, err = m.waitForPacket(p); !deadlineChanged {
+			return n, addr, err
+		}
+	}
+}
+
+// waitForPacket blocks until a packet is received, the read deadline is exceeded or modified or the connection is closed.
+func (m *multiPacketConn) waitForPacket(p []byte) (n int, addr net.Addr, deadlineChanged bool, err error) {
+	m.lock.Lock()
+	deadline, changed := m.readDeadline, m.deadlineChanged
+	m.lock.Unlock()
+
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return 0, nil, false, os.ErrDeadlineExceeded
+		}
+		timer := time.NewTimer(remaining)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case pkt := <-m.packets:
+		return copy(p, pkt.data), pkt.addr, false, pkt.err
+	case <-timeout:
+		return 0, nil, false, os.ErrDeadlineExceeded
+	case <-m.done:
+		return 0, nil, false, net.ErrClosed
+	case <-changed:
+		return 0, nil, true, nil
+	}
+}
+
+func (m *multiPacketConn) WriteTo(p []byte, addr net.Addr) (n int, err error) {
+	m.lock.Lock()
+	conn, ok := m.peers[addr.String()]
+	m.lock.Unlock()
+
+	if !ok {
+		conn = m.conns[0]
+	}
+
+	return conn.WriteTo(p, addr)
+}
+
+func (m *multiPacketConn) Close() (err error) {
+	m.closeOnce.Do(func() {
+		close(m.done)
+		for idx := range m.conns {
+			err = errors.Join(err, m.conns[idx].Close())
+		}
+	})
+	return err
+}
+
+func (m *multiPacketConn) LocalAddr() net.Addr {
+	return m.conns[0].LocalAddr()
+}
+
+func (m *multiPacketConn) SetDeadline(t time.Time) error {
+	if err := m.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return m.SetWriteDeadline(t)
+}
+
+func (m *multiPacketConn) SetReadDeadline(t time.Time) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.readDeadline = t
+	close(m.deadlineChanged)
+	m.deadlineChanged = make(chan struct{})
+
+	return nil
+}
+
+func (m *multiPacketConn) SetWriteDeadline(t time.Time) (err error) {
+	for idx := range m.conns {
+		err = errors.Join(err, m.conns[idx].SetWriteDeadline(t))
+	}
+	return err
+}
+
+func (m *multiPacketConn) readLoop(conn net.PacketConn) {
+	for {
+		buf := make([]byte, maxUDPPacketSize)
+		n, addr, err := conn.ReadFrom(buf)
+
+		if addr != nil {
+			m.lock.Lock()
+			if len(m.peers) >= maxTrackedPeers {
+				m.peers = make(map[string]net.PacketConn)
+			}
+			m.peers[addr.String()] = conn
+			m.lock.Unlock()
+		}
+
+		select {
+		case m.packets <- packet{data: buf[:n], addr: addr, err: err}:
+		case <-m.done:
+			return
+		}
+
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+	}
+}
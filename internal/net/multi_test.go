@@ -0,0 +1,104 @@
+package net_test
+
+import (
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	net2 "inetmock.icb4dc0.de/inetmock/internal/net"
+)
+
+func TestMultiListener(t *testing.T) {
+	t.Parallel()
+	var listeners []net.Listener
+	for _, addr := range []string{"127.0.0.1:0", "[::1]:0"} {
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Fatalf("net.Listen() error = %v", err)
+		}
+		listeners = append(listeners, l)
+	}
+
+	listener := net2.MultiListener(listeners...)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	for idx := range listeners {
+		conn, err := net.Dial("tcp", listeners[idx].Addr().String())
+		if err != nil {
+			t.Fatalf("net.Dial() error = %v", err)
+		}
+
+		accepted, err := listener.Accept()
+		if err != nil {
+			t.Fatalf("listener.Accept() error = %v", err)
+		}
+		td.Cmp(t, accepted.LocalAddr().String(), listeners[idx].Addr().String())
+		_ = accepted.Close()
+		_ = conn.Close()
+	}
+
+	if err := listener.Close(); err != nil {
+		t.Errorf("listener.Close() error = %v", err)
+	}
+
+	if _, err := listener.Accept(); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("listener.Accept() error = %v, want %v", err, net.ErrClosed)
+	}
+}
+
+func TestMultiPacketConn(t *testing.T) {
+	t.Parallel()
+	var conns []net.PacketConn
+	for _, addr := range []string{"127.0.0.1:0", "[::1]:0"} {
+		c, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			t.Fatalf("net.ListenPacket() error = %v", err)
+		}
+		conns = append(conns, c)
+	}
+
+	multiConn := net2.MultiPacketConn(conns...)
+	t.Cleanup(func() {
+		_ = multiConn.Close()
+	})
+
+	buf := make([]byte, 1024)
+	for idx := range conns {
+		client, err := net.Dial("udp", conns[idx].LocalAddr().String())
+		if err != nil {
+			t.Fatalf("net.Dial() error = %v", err)
+		}
+
+		if _, err = client.Write([]byte("ping")); err != nil {
+			t.Fatalf("client.Write() error = %v", err)
+		}
+
+		n, addr, err := multiConn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("multiConn.ReadFrom() error = %v", err)
+		}
+		td.Cmp(t, string(buf[:n]), "ping")
+
+		if _, err = multiConn.WriteTo([]byte("pong"), addr); err != nil {
+			t.Fatalf("multiConn.WriteTo() error = %v", err)
+		}
+
+		_ = client.SetReadDeadline(time.Now().Add(time.Second))
+		if n, err = client.Read(buf); err != nil {
+			t.Fatalf("client.Read() error = %v", err)
+		}
+		td.Cmp(t, string(buf[:n]), "pong")
+		_ = client.Close()
+	}
+
+	_ = multiConn.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	if _, _, err := multiConn.ReadFrom(buf); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("multiConn.ReadFrom() error = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+}
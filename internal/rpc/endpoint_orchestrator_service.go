@@ -2,6 +2,9 @@ package rpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -12,23 +15,37 @@ import (
 	rpcv1 "inetmock.icb4dc0.de/inetmock/pkg/rpc/v1"
 )
 
-var _ rpcv1.EndpointOrchestratorServiceServer = (*endpointOrchestratorServer)(nil)
+var (
+	_                     rpcv1.EndpointOrchestratorServiceServer = (*endpointOrchestratorServer)(nil)
+	reconcileActionToWire                                         = map[endpoint.ReconcileAction]rpcv1.ReconcileAction{
+		endpoint.ReconcileActionUnchanged: rpcv1.ReconcileAction_RECONCILE_ACTION_UNCHANGED,
+		endpoint.ReconcileActionAdded:     rpcv1.ReconcileAction_RECONCILE_ACTION_ADDED,
+		endpoint.ReconcileActionUpdated:   rpcv1.ReconcileAction_RECONCILE_ACTION_UPDATED,
+		endpoint.ReconcileActionRemoved:   rpcv1.ReconcileAction_RECONCILE_ACTION_REMOVED,
+	}
+)
 
 func NewEndpointOrchestratorServer(
 	logger logging.Logger,
 	epHost endpoint.Host,
+	hostBuilder endpoint.HostBuilder,
+	reloader endpoint.ConfigReloader,
 ) rpcv1.EndpointOrchestratorServiceServer {
 	return &endpointOrchestratorServer{
 		UnimplementedEndpointOrchestratorServiceServer: rpcv1.UnimplementedEndpointOrchestratorServiceServer{},
-		logger: logger,
-		epHost: epHost,
+		logger:      logger,
+		epHost:      epHost,
+		hostBuilder: hostBuilder,
+		reloader:    reloader,
 	}
 }
 
 type endpointOrchestratorServer struct {
 	rpcv1.UnimplementedEndpointOrchestratorServiceServer
-	logger logging.Logger
-	epHost endpoint.Host
+	logger      logging.Logger
+	epHost      endpoint.Host
+	hostBuilder endpoint.HostBuilder
+	reloader    endpoint.ConfigReloader
 }
 
 func (s *endpointOrchestratorServer) ListAllServingGroups(
@@ -45,10 +62,7 @@ func (s *endpointOrchestratorServer) ListAllServingGroups(
 			continue
 		}
 
-		resp.Groups = append(resp.Groups, &rpcv1.ListenerGroup{
-			Name:      groups[idx].Name,
-			Endpoints: groups[idx].Endpoints,
-		})
+		resp.Groups = append(resp.Groups, groupInfoToProto(groups[idx]))
 	}
 
 	return resp, nil
@@ -64,10 +78,7 @@ func (s *endpointOrchestratorServer) ListAllConfiguredGroups(
 	}
 
 	for idx := range groups {
-		resp.Groups = append(resp.Groups, &rpcv1.ListenerGroup{
-			Name:      groups[idx].Name,
-			Endpoints: groups[idx].Endpoints,
-		})
+		resp.Groups = append(resp.Groups, groupInfoToProto(groups[idx]))
 	}
 
 	return resp, nil
@@ -145,3 +156,206 @@ func (s *endpointOrchestratorServer) RestartAllGroups(
 	}
 	return new(rpcv1.RestartAllGroupsResponse), nil
 }
+
+func (s *endpointOrchestratorServer) CreateListenerGroup(
+	ctx context.Context,
+	req *rpcv1.CreateListenerGroupRequest,
+) (*rpcv1.CreateListenerGroupResponse, error) {
+	if s.hostBuilder == nil {
+		return nil, status.Error(codes.Unimplemented, "creating listener groups is not supported")
+	}
+
+	spec, err := listenerSpecFromProto(req.GetSpec())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.Start {
+		err = s.hostBuilder.CreateAndServeGroup(ctx, spec)
+	} else {
+		err = s.hostBuilder.CreateGroup(spec)
+	}
+
+	if err != nil {
+		switch {
+		case errors.Is(err, endpoint.ErrGroupExists):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, endpoint.ErrServeGroup):
+			s.logger.Error("Failed to start created group", zap.String("group_name", spec.Name), zap.Error(err))
+			return nil, status.Error(codes.Unknown, err.Error())
+		default:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	info, _ := s.findGroup(spec.Name)
+
+	return &rpcv1.CreateListenerGroupResponse{
+		Group: groupInfoToProto(info),
+	}, nil
+}
+
+func (s *endpointOrchestratorServer) DeleteListenerGroup(
+	ctx context.Context,
+	req *rpcv1.DeleteListenerGroupRequest,
+) (*rpcv1.DeleteListenerGroupResponse, error) {
+	if err := s.epHost.RemoveGroup(ctx, req.GroupName); err != nil {
+		if errors.Is(err, endpoint.ErrNoSuchGroup) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	return new(rpcv1.DeleteListenerGroupResponse), nil
+}
+
+func (s *endpointOrchestratorServer) UpdateEndpointOptions(
+	ctx context.Context,
+	req *rpcv1.UpdateEndpointOptionsRequest,
+) (*rpcv1.UpdateEndpointOptionsResponse, error) {
+	if s.hostBuilder == nil {
+		return nil, status.Error(codes.Unimplemented, "updating endpoint options is not supported")
+	}
+
+	err := s.hostBuilder.UpdateEndpointOptions(ctx, req.GroupName, req.EndpointName, req.GetOptions().AsMap())
+	switch {
+	case err == nil:
+		return new(rpcv1.UpdateEndpointOptionsResponse), nil
+	case errors.Is(err, endpoint.ErrNoSuchGroup), errors.Is(err, endpoint.ErrNoSuchEndpoint):
+		return nil, status.Error(codes.NotFound, err.Error())
+	default:
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+}
+
+func (s *endpointOrchestratorServer) ReloadConfig(
+	ctx context.Context,
+	_ *rpcv1.ReloadConfigRequest,
+) (*rpcv1.ReloadConfigResponse, error) {
+	if s.reloader == nil {
+		return nil, status.Error(codes.Unimplemented, "config reloading is not supported")
+	}
+
+	results, err := s.reloader.ReloadConfig(ctx)
+	if err != nil {
+		s.logger.Error("Failed to reload config", zap.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	resp := &rpcv1.ReloadConfigResponse{
+		Results: make([]*rpcv1.ListenerGroupReconcileResult, 0, len(results)),
+	}
+
+	for idx := range results {
+		result := &rpcv1.ListenerGroupReconcileResult{
+			GroupName: results[idx].GroupName,
+			Action:    reconcileActionToWire[results[idx].Action],
+			Success:   results[idx].Err == nil,
+		}
+
+		if results[idx].Err != nil {
+			result.Error = results[idx].Err.Error()
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+func (s *endpointOrchestratorServer) findGroup(groupName string) (endpoint.GroupInfo, bool) {
+	groups := s.epHost.ConfiguredGroups()
+	for idx := range groups {
+		if groups[idx].Name == groupName {
+			return groups[idx], true
+		}
+	}
+
+	return endpoint.GroupInfo{}, false
+}
+
+func groupInfoToProto(info endpoint.GroupInfo) *rpcv1.ListenerGroup {
+	grp := &rpcv1.ListenerGroup{
+		Name:      info.Name,
+		Endpoints: info.Endpoints,
+	}
+
+	if info.Addr != nil {
+		grp.Address = info.Addr.String()
+	}
+
+	for idx := range info.Addrs {
+		grp.Addresses = append(grp.Addresses, info.Addrs[idx].String())
+	}
+
+	return grp
+}
+
+func listenerSpecFromProto(spec *rpcv1.ListenerSpec) (endpoint.ListenerSpec, error) {
+	if spec == nil {
+		return endpoint.ListenerSpec{}, errors.New("listener spec is required")
+	}
+
+	if spec.Port > math.MaxUint16 {
+		return endpoint.ListenerSpec{}, fmt.Errorf("port %d out of range", spec.Port)
+	}
+
+	listenerSpec := endpoint.ListenerSpec{
+		Name:      spec.Name,
+		Protocol:  spec.Protocol,
+		Address:   spec.ListenAddress,
+		Addresses: spec.ListenAddresses,
+		Port:      uint16(spec.Port),
+		Unmanaged: spec.Unmanaged,
+		Endpoints: make(map[string]endpoint.Spec, len(spec.Endpoints)),
+	}
+
+	if listenerSpec.Name == "" {
+		if listenerSpec.Port == 0 {
+			return endpoint.ListenerSpec{}, errors.New("name is required if the port is chosen randomly")
+		}
+
+		if grp, err := endpoint.NewListenerGroup(listenerSpec); err != nil {
+			return endpoint.ListenerSpec{}, err
+		} else {
+			listenerSpec.Name = grp.Name
+		}
+	}
+
+	for name, ep := range spec.Endpoints {
+		clientAuth, err := clientAuthSpecFromProto(ep.GetClientAuth())
+		if err != nil {
+			return endpoint.ListenerSpec{}, fmt.Errorf("endpoint %s: %w", name, err)
+		}
+
+		listenerSpec.Endpoints[name] = endpoint.Spec{
+			HandlerRef: endpoint.HandlerReference(ep.Handler),
+			TLS:        ep.Tls,
+			SNI:        ep.Sni,
+			ClientAuth: clientAuth,
+			Options:    ep.GetOptions().AsMap(),
+		}
+	}
+
+	return listenerSpec, nil
+}
+
+func clientAuthSpecFromProto(spec *rpcv1.ClientAuthSpec) (endpoint.ClientAuthSpec, error) {
+	clientAuth := endpoint.ClientAuthSpec{
+		CAFiles: spec.GetCaFiles(),
+	}
+
+	switch spec.GetMode() {
+	case rpcv1.ClientAuthMode_CLIENT_AUTH_MODE_UNSPECIFIED:
+	case rpcv1.ClientAuthMode_CLIENT_AUTH_MODE_NONE:
+		clientAuth.Mode = endpoint.ClientAuthModeNone
+	case rpcv1.ClientAuthMode_CLIENT_AUTH_MODE_REQUEST:
+		clientAuth.Mode = endpoint.ClientAuthModeRequest
+	case rpcv1.ClientAuthMode_CLIENT_AUTH_MODE_REQUIRE:
+		clientAuth.Mode = endpoint.ClientAuthModeRequire
+	default:
+		return endpoint.ClientAuthSpec{}, fmt.Errorf("unknown client auth mode %d", spec.GetMode())
+	}
+
+	return clientAuth, nil
+}
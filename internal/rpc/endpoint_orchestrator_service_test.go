@@ -2,15 +2,28 @@ package rpc_test
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"net"
+	"net/http"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/maxatome/go-testdeep/td"
+	"golang.org/x/net/context/ctxhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
 	"inetmock.icb4dc0.de/inetmock/internal/rpc"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
 	rpcv1 "inetmock.icb4dc0.de/inetmock/pkg/rpc/v1"
+	httpmock "inetmock.icb4dc0.de/inetmock/protocols/http/mock"
 )
 
 func Test_endpointOrchestratorServer_ListAllServingGroups(t *testing.T) {
@@ -116,7 +129,7 @@ func Test_endpointOrchestratorServer_ListAllServingGroups(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), tt.hostSetup(t))
+			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), tt.hostSetup(t), nil, nil)
 			got, err := s.ListAllServingGroups(context.Background(), nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ListAllServingGroups() error = %v, wantErr %v", err, tt.wantErr)
@@ -244,7 +257,7 @@ func Test_endpointOrchestratorServer_ListAllConfiguredGroups(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), tt.hostSetup(t))
+			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), tt.hostSetup(t), nil, nil)
 			got, err := s.ListAllConfiguredGroups(context.Background(), nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ListAllConfiguredGroups() error = %v, wantErr %v", err, tt.wantErr)
@@ -296,7 +309,7 @@ func Test_endpointOrchestratorServer_StartListenerGroup(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), tt.hostSetup(t))
+			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), tt.hostSetup(t), nil, nil)
 			got, err := s.StartListenerGroup(context.Background(), tt.req)
 			if err != nil {
 				if !tt.wantErr {
@@ -353,7 +366,7 @@ func Test_endpointOrchestratorServer_StartAllGroups(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), tt.hostSetup(t))
+			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), tt.hostSetup(t), nil, nil)
 			got, err := s.StartAllGroups(context.Background(), nil)
 			if err != nil {
 				if !tt.wantErr {
@@ -366,12 +379,423 @@ func Test_endpointOrchestratorServer_StartAllGroups(t *testing.T) {
 	}
 }
 
+func Test_endpointOrchestratorServer_ReloadConfig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		reloader endpoint.ConfigReloader
+		want     any
+		wantErr  bool
+	}{
+		{
+			name:    "No reloader configured",
+			wantErr: true,
+		},
+		{
+			name: "Return error",
+			reloader: endpoint.ConfigReloaderFunc(func(context.Context) ([]endpoint.GroupReconcileResult, error) {
+				return nil, errors.New("nope")
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Report per group results",
+			reloader: endpoint.ConfigReloaderFunc(func(context.Context) ([]endpoint.GroupReconcileResult, error) {
+				return []endpoint.GroupReconcileResult{
+					{
+						GroupName: "80/tcp",
+						Action:    endpoint.ReconcileActionUpdated,
+					},
+					{
+						GroupName: "443/tcp",
+						Action:    endpoint.ReconcileActionAdded,
+						Err:       errors.New("address already in use"),
+					},
+				}, nil
+			}),
+			want: td.Struct(new(rpcv1.ReloadConfigResponse), td.StructFields{
+				"Results": td.Bag(
+					td.Struct(&rpcv1.ListenerGroupReconcileResult{
+						GroupName: "80/tcp",
+						Action:    rpcv1.ReconcileAction_RECONCILE_ACTION_UPDATED,
+						Success:   true,
+					}, td.StructFields{}),
+					td.Struct(&rpcv1.ListenerGroupReconcileResult{
+						GroupName: "443/tcp",
+						Action:    rpcv1.ReconcileAction_RECONCILE_ACTION_ADDED,
+						Success:   false,
+						Error:     "address already in use",
+					}, td.StructFields{}),
+				),
+			}),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), hostMock{}, nil, tt.reloader)
+			got, err := s.ReloadConfig(context.Background(), new(rpcv1.ReloadConfigRequest))
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("ReloadConfig() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			td.Cmp(t, got, tt.want)
+		})
+	}
+}
+
+func Test_endpointOrchestratorServer_DynamicGroups(t *testing.T) {
+	t.Parallel()
+
+	var startedOptions []map[string]any
+	registry := endpoint.NewHandlerRegistry()
+	registry.RegisterHandler("recording", func() endpoint.ProtocolHandler {
+		return protocolHandlerFunc(func(_ context.Context, spec *endpoint.StartupSpec) error {
+			startedOptions = append(startedOptions, spec.Options)
+			return nil
+		})
+	})
+
+	builder := endpoint.NewServerBuilder(nil, registry, logging.CreateTestLogger(t))
+	srv := builder.Server()
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), srv, builder, nil)
+
+	opts, err := structpb.NewStruct(map[string]any{"rules": []any{"=> Status(204)"}})
+	if err != nil {
+		t.Fatalf("structpb.NewStruct() error = %v", err)
+	}
+
+	createReq := &rpcv1.CreateListenerGroupRequest{
+		Spec: &rpcv1.ListenerSpec{
+			Name:          "throwaway",
+			Protocol:      "tcp",
+			ListenAddress: "127.0.0.1",
+			Endpoints: map[string]*rpcv1.EndpointSpec{
+				"plain": {
+					Handler: "recording",
+					Options: opts,
+				},
+			},
+		},
+		Start: true,
+	}
+
+	created, err := s.CreateListenerGroup(context.Background(), createReq)
+	if err != nil {
+		t.Fatalf("CreateListenerGroup() error = %v", err)
+	}
+
+	td.Cmp(t, created.Group, td.Struct(&rpcv1.ListenerGroup{Name: "throwaway"}, td.StructFields{
+		"Address":   td.All(td.HasPrefix("127.0.0.1:"), td.Not("127.0.0.1:0")),
+		"Endpoints": td.Bag("throwaway:plain"),
+	}))
+
+	if _, err = s.CreateListenerGroup(context.Background(), createReq); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateListenerGroup() error = %v, want AlreadyExists", err)
+	}
+
+	updatedOpts, err := structpb.NewStruct(map[string]any{"rules": []any{"=> Status(404)"}})
+	if err != nil {
+		t.Fatalf("structpb.NewStruct() error = %v", err)
+	}
+
+	_, err = s.UpdateEndpointOptions(context.Background(), &rpcv1.UpdateEndpointOptionsRequest{
+		GroupName:    "throwaway",
+		EndpointName: "plain",
+		Options:      updatedOpts,
+	})
+	if err != nil {
+		t.Fatalf("UpdateEndpointOptions() error = %v", err)
+	}
+
+	td.Cmp(t, startedOptions, []map[string]any{
+		{"rules": []any{"=> Status(204)"}},
+		{"rules": []any{"=> Status(404)"}},
+	})
+
+	if _, err = s.UpdateEndpointOptions(context.Background(), &rpcv1.UpdateEndpointOptionsRequest{
+		GroupName:    "throwaway",
+		EndpointName: "unknown",
+	}); status.Code(err) != codes.NotFound {
+		t.Errorf("UpdateEndpointOptions() error = %v, want NotFound", err)
+	}
+
+	if _, err = s.DeleteListenerGroup(context.Background(), &rpcv1.DeleteListenerGroupRequest{GroupName: "throwaway"}); err != nil {
+		t.Fatalf("DeleteListenerGroup() error = %v", err)
+	}
+
+	td.Cmp(t, srv.ConfiguredGroups(), td.Empty())
+
+	if _, err = s.DeleteListenerGroup(context.Background(), &rpcv1.DeleteListenerGroupRequest{GroupName: "throwaway"}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteListenerGroup() error = %v, want NotFound", err)
+	}
+}
+
+func Test_endpointOrchestratorServer_CreateListenerGroup_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	registry := endpoint.NewHandlerRegistry()
+	registry.RegisterHandler("noop", func() endpoint.ProtocolHandler {
+		return protocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error {
+			return nil
+		})
+	})
+
+	builder := endpoint.NewServerBuilder(nil, registry, logging.CreateTestLogger(t))
+	s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), builder.Server(), builder, nil)
+
+	const callers = 10
+	var (
+		wg      sync.WaitGroup
+		results = make(chan codes.Code, callers)
+	)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.CreateListenerGroup(context.Background(), &rpcv1.CreateListenerGroupRequest{
+				Spec: &rpcv1.ListenerSpec{
+					Name:          "contested",
+					Protocol:      "tcp",
+					ListenAddress: "127.0.0.1",
+					Endpoints: map[string]*rpcv1.EndpointSpec{
+						"plain": {Handler: "noop"},
+					},
+				},
+			})
+			results <- status.Code(err)
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	got := make(map[string]int)
+	for code := range results {
+		got[code.String()]++
+	}
+
+	td.Cmp(t, got, map[string]int{"OK": 1, "AlreadyExists": callers - 1})
+}
+
+func Test_endpointOrchestratorServer_CreateListenerGroup_StartFailure(t *testing.T) {
+	t.Parallel()
+
+	registry := endpoint.NewHandlerRegistry()
+	registry.RegisterHandler("failing", func() endpoint.ProtocolHandler {
+		return protocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error {
+			return errors.New("startup failed")
+		})
+	})
+
+	builder := endpoint.NewServerBuilder(nil, registry, logging.CreateTestLogger(t))
+	srv := builder.Server()
+	s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), srv, builder, nil)
+
+	_, err := s.CreateListenerGroup(context.Background(), &rpcv1.CreateListenerGroupRequest{
+		Spec: &rpcv1.ListenerSpec{
+			Name:          "broken",
+			Protocol:      "tcp",
+			ListenAddress: "127.0.0.1",
+			Endpoints: map[string]*rpcv1.EndpointSpec{
+				"plain": {Handler: "failing"},
+			},
+		},
+		Start: true,
+	})
+
+	td.Cmp(t, status.Code(err), codes.Unknown)
+	td.Cmp(t, srv.ConfiguredGroups(), td.Empty())
+}
+
+func Test_endpointOrchestratorServer_CreateListenerGroup_TLSRouting(t *testing.T) {
+	t.Parallel()
+	var (
+		logger   = logging.CreateTestLogger(t)
+		registry = endpoint.NewHandlerRegistry()
+		clientCA = test.NewClientCA(t)
+		trusted  = clientCA.ClientCert(t, "device-42")
+	)
+
+	httpmock.AddHTTPMock(registry, logger, new(audit_mock.EmitterMock), fstest.MapFS{}, nil)
+	builder := endpoint.NewServerBuilder(test.NewSelfSignedCertStore(t).TLSConfig(), registry, logger)
+	srv := builder.Server()
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	s := rpc.NewEndpointOrchestratorServer(logger, srv, builder, nil)
+
+	rulesOptions := func(rule string) *structpb.Struct {
+		opts, err := structpb.NewStruct(map[string]any{"rules": []any{rule}})
+		if err != nil {
+			t.Fatalf("structpb.NewStruct() error = %v", err)
+		}
+		return opts
+	}
+
+	created, err := s.CreateListenerGroup(context.Background(), &rpcv1.CreateListenerGroupRequest{
+		Spec: &rpcv1.ListenerSpec{
+			Name:          "mtls",
+			Protocol:      "tcp",
+			ListenAddress: "127.0.0.1",
+			Endpoints: map[string]*rpcv1.EndpointSpec{
+				"https": {Handler: "http_mock", Tls: true, Options: rulesOptions("=> Status(202)")},
+				"device": {
+					Handler: "http_mock",
+					Tls:     true,
+					Sni:     []string{"device.example.com"},
+					ClientAuth: &rpcv1.ClientAuthSpec{
+						Mode:    rpcv1.ClientAuthMode_CLIENT_AUTH_MODE_REQUIRE,
+						CaFiles: []string{clientCA.CAFile},
+					},
+					Options: rulesOptions("=> Status(204)"),
+				},
+			},
+		},
+		Start: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateListenerGroup() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		url         string
+		certificate *tls.Certificate
+		wantStatus  any
+		wantErr     bool
+	}{
+		{
+			name:        "SNI routed endpoint with trusted client certificate",
+			url:         "https://device.example.com/",
+			certificate: &trusted,
+			wantStatus:  http.StatusNoContent,
+		},
+		{
+			name:    "SNI routed endpoint without client certificate",
+			url:     "https://device.example.com/",
+			wantErr: true,
+		},
+		{
+			name:       "Other server names are not routed to the SNI endpoint",
+			url:        "https://www.example.com/",
+			wantStatus: http.StatusAccepted,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			//nolint:gosec // the server uses a self-signed certificate
+			tlsConfig := &tls.Config{InsecureSkipVerify: true}
+			if tt.certificate != nil {
+				tlsConfig.Certificates = []tls.Certificate{*tt.certificate}
+			}
+
+			dialer := new(net.Dialer)
+			httpClient := &http.Client{
+				Transport: &http.Transport{
+					DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+						return dialer.DialContext(ctx, "tcp", created.Group.Address)
+					},
+					TLSClientConfig: tlsConfig,
+				},
+				Timeout: time.Second,
+			}
+
+			resp, err := ctxhttp.Get(test.Context(t), httpClient, tt.url)
+			if tt.wantErr {
+				td.CmpError(t, err)
+				return
+			}
+
+			if td.CmpNoError(t, err) {
+				_ = resp.Body.Close()
+				td.Cmp(t, resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_endpointOrchestratorServer_CreateListenerGroup_InvalidTLSSpec(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		spec *rpcv1.EndpointSpec
+	}{
+		{
+			name: "Invalid SNI pattern",
+			spec: &rpcv1.EndpointSpec{Handler: "noop", Tls: true, Sni: []string{"[a-z.example.com"}},
+		},
+		{
+			name: "Client auth without TLS",
+			spec: &rpcv1.EndpointSpec{
+				Handler:    "noop",
+				ClientAuth: &rpcv1.ClientAuthSpec{Mode: rpcv1.ClientAuthMode_CLIENT_AUTH_MODE_REQUIRE},
+			},
+		},
+		{
+			name: "Unknown client auth mode",
+			spec: &rpcv1.EndpointSpec{
+				Handler:    "noop",
+				Tls:        true,
+				ClientAuth: &rpcv1.ClientAuthSpec{Mode: rpcv1.ClientAuthMode(42)},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			registry := endpoint.NewHandlerRegistry()
+			registry.RegisterHandler("noop", func() endpoint.ProtocolHandler {
+				return protocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error {
+					return nil
+				})
+			})
+
+			builder := endpoint.NewServerBuilder(nil, registry, logging.CreateTestLogger(t))
+			srv := builder.Server()
+			s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), srv, builder, nil)
+
+			_, err := s.CreateListenerGroup(context.Background(), &rpcv1.CreateListenerGroupRequest{
+				Spec: &rpcv1.ListenerSpec{
+					Name:          "invalid",
+					Protocol:      "tcp",
+					ListenAddress: "127.0.0.1",
+					Endpoints:     map[string]*rpcv1.EndpointSpec{"tls": tt.spec},
+				},
+			})
+
+			td.Cmp(t, status.Code(err), codes.InvalidArgument)
+			td.Cmp(t, srv.ConfiguredGroups(), td.Empty())
+		})
+	}
+}
+
+type protocolHandlerFunc func(ctx context.Context, startupSpec *endpoint.StartupSpec) error
+
+func (f protocolHandlerFunc) Start(ctx context.Context, startupSpec *endpoint.StartupSpec) error {
+	return f(ctx, startupSpec)
+}
+
 type hostMock struct {
 	OnConfiguredGroups func() []endpoint.GroupInfo
 	OnServeGroup       func(ctx context.Context, groupName string) error
 	OnServeGroups      func(ctx context.Context) error
 	OnShutdown         func(ctx context.Context) error
 	OnShutdownGroup    func(ctx context.Context, groupName string) error
+	OnRemoveGroup      func(ctx context.Context, groupName string) error
+	OnEndpointHandler  func(groupName, endpointName string) (endpoint.ProtocolHandler, error)
 }
 
 func (m hostMock) ConfiguredGroups() []endpoint.GroupInfo {
@@ -408,3 +832,108 @@ func (m hostMock) ShutdownGroup(ctx context.Context, groupName string) error {
 	}
 	return nil
 }
+
+func (m hostMock) RemoveGroup(ctx context.Context, groupName string) error {
+	if m.OnRemoveGroup != nil {
+		return m.OnRemoveGroup(ctx, groupName)
+	}
+	return nil
+}
+
+func (m hostMock) EndpointHandler(groupName, endpointName string) (endpoint.ProtocolHandler, error) {
+	if m.OnEndpointHandler != nil {
+		return m.OnEndpointHandler(groupName, endpointName)
+	}
+	return nil, endpoint.ErrNoSuchGroup
+}
+
+func Test_endpointOrchestratorServer_CreateListenerGroup_ConcurrentDelete(t *testing.T) {
+	t.Parallel()
+
+	registry := endpoint.NewHandlerRegistry()
+	registry.RegisterHandler("noop", func() endpoint.ProtocolHandler {
+		return protocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error {
+			return nil
+		})
+	})
+
+	builder := endpoint.NewServerBuilder(nil, registry, logging.CreateTestLogger(t))
+	srv := builder.Server()
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	s := rpc.NewEndpointOrchestratorServer(logging.CreateTestLogger(t), srv, builder, nil)
+
+	const (
+		workers            = 4
+		creationsPerWorker = 50
+	)
+
+	var (
+		creators, deleters sync.WaitGroup
+		done               = make(chan struct{})
+		createResults      = make(chan codes.Code, workers*creationsPerWorker)
+		deleteResults      = make(map[codes.Code]bool)
+		deleteResultsLock  sync.Mutex
+	)
+
+	for i := 0; i < workers; i++ {
+		creators.Add(1)
+		go func() {
+			defer creators.Done()
+			for j := 0; j < creationsPerWorker; j++ {
+				_, err := s.CreateListenerGroup(context.Background(), &rpcv1.CreateListenerGroupRequest{
+					Spec: &rpcv1.ListenerSpec{
+						Name:          "contested",
+						Protocol:      "tcp",
+						ListenAddress: "127.0.0.1",
+						Endpoints: map[string]*rpcv1.EndpointSpec{
+							"plain": {Handler: "noop"},
+						},
+					},
+					Start: true,
+				})
+				createResults <- status.Code(err)
+			}
+		}()
+
+		// delete the group over and over again while it is created to hit the gap between creating and serving it
+		deleters.Add(1)
+		go func() {
+			defer deleters.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					_, err := s.DeleteListenerGroup(context.Background(), &rpcv1.DeleteListenerGroupRequest{GroupName: "contested"})
+					deleteResultsLock.Lock()
+					deleteResults[status.Code(err)] = true
+					deleteResultsLock.Unlock()
+				}
+			}
+		}()
+	}
+
+	creators.Wait()
+	close(done)
+	deleters.Wait()
+	close(createResults)
+
+	for code := range createResults {
+		td.Cmp(t, code, td.Any(codes.OK, codes.AlreadyExists))
+	}
+
+	td.Cmp(t, deleteResults, td.SubMapOf(map[codes.Code]bool{codes.OK: true, codes.NotFound: true}, nil))
+
+	// a group that survived the deletions has to be served, a created but not served group must not be left behind
+	td.Cmp(t, srv.ConfiguredGroups(), td.Any(
+		td.Empty(),
+		td.Bag(td.SStruct(endpoint.GroupInfo{Name: "contested", Serving: true}, td.StructFields{
+			"Addr":      td.NotNil(),
+			"Addrs":     td.Ignore(),
+			"Endpoints": td.Ignore(),
+		})),
+	))
+}
@@ -45,6 +45,8 @@ type inetmockAPI struct {
 	fw            *netflow.Firewall
 	nat           *netflow.NAT
 	epHost        endpoint.Host
+	hostBuilder   endpoint.HostBuilder
+	reloader      endpoint.ConfigReloader
 	auditDataDir  string
 	pcapDataDir   string
 	serverRunning chan struct{}
@@ -58,6 +60,8 @@ func NewINetMockAPI(
 	fw *netflow.Firewall,
 	nat *netflow.NAT,
 	epHost endpoint.Host,
+	hostBuilder endpoint.HostBuilder,
+	reloader endpoint.ConfigReloader,
 	auditDataDir, pcapDataDir string,
 ) INetMockAPI {
 	return &inetmockAPI{
@@ -68,6 +72,8 @@ func NewINetMockAPI(
 		fw:           fw,
 		nat:          nat,
 		epHost:       epHost,
+		hostBuilder:  hostBuilder,
+		reloader:     reloader,
 		auditDataDir: auditDataDir,
 		pcapDataDir:  pcapDataDir,
 	}
@@ -96,8 +102,10 @@ func (i *inetmockAPI) StartServer() (err error) {
 	rpcv1.RegisterAuditServiceServer(i.server, NewAuditServiceServer(i.logger, i.eventStream, i.auditDataDir))
 	rpcv1.RegisterPCAPServiceServer(i.server, NewPCAPServer(i.pcapDataDir, pcap.NewRecorder()))
 	rpcv1.RegisterProfilingServiceServer(i.server, NewProfilingServer())
-	rpcv1.RegisterEndpointOrchestratorServiceServer(i.server, NewEndpointOrchestratorServer(i.logger, i.epHost))
+	rpcv1.RegisterEndpointOrchestratorServiceServer(i.server, NewEndpointOrchestratorServer(i.logger, i.epHost, i.hostBuilder, i.reloader))
 	rpcv1.RegisterNetFlowControlServiceServer(i.server, NewNetFlowControlServiceServer(i.fw, i.nat))
+	rpcv1.RegisterRulesServiceServer(i.server, NewRulesServer(i.epHost))
+	rpcv1.RegisterMessagingServiceServer(i.server, NewMessagingServer(i.epHost))
 
 	reflection.Register(i.server)
 
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	rpcv1 "inetmock.icb4dc0.de/inetmock/pkg/rpc/v1"
+)
+
+var _ rpcv1.MessagingServiceServer = (*messagingServer)(nil)
+
+func NewMessagingServer(epHost endpoint.Host) rpcv1.MessagingServiceServer {
+	return &messagingServer{
+		epHost: epHost,
+	}
+}
+
+type messagingServer struct {
+	rpcv1.UnimplementedMessagingServiceServer
+	epHost endpoint.Host
+}
+
+func (s *messagingServer) InjectMessage(_ context.Context, req *rpcv1.InjectMessageRequest) (*rpcv1.InjectMessageResponse, error) {
+	handler, err := s.epHost.EndpointHandler(req.GroupName, req.EndpointName)
+	if err != nil {
+		if errors.Is(err, endpoint.ErrNoSuchGroup) || errors.Is(err, endpoint.ErrNoSuchEndpoint) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	injector, ok := handler.(endpoint.MessageInjectingHandler)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "handler of endpoint %s does not support message injection", req.EndpointName)
+	}
+
+	recipients, err := injector.InjectMessage(req.Target, req.Sender, req.Message)
+	switch {
+	case errors.Is(err, endpoint.ErrNotServing):
+		return nil, status.Errorf(codes.FailedPrecondition, "endpoint %s is not serving", req.EndpointName)
+	case errors.Is(err, endpoint.ErrUnknownMessageTarget):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &rpcv1.InjectMessageResponse{Recipients: int32(recipients)}, nil
+}
@@ -0,0 +1,100 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rpc"
+	rpcv1 "inetmock.icb4dc0.de/inetmock/pkg/rpc/v1"
+)
+
+func Test_messagingServer_InjectMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		handler  endpoint.ProtocolHandler
+		req      *rpcv1.InjectMessageRequest
+		want     any
+		wantCode codes.Code
+	}{
+		{
+			name: "Inject message",
+			handler: injectorFunc(func(target, sender, message string) (int, error) {
+				if target != "#botnet" || sender != "admin" || message != "!ddos" {
+					return 0, endpoint.ErrUnknownMessageTarget
+				}
+				return 3, nil
+			}),
+			req: &rpcv1.InjectMessageRequest{GroupName: "grp", EndpointName: "ep", Target: "#botnet", Sender: "admin", Message: "!ddos"},
+			want: td.Struct(new(rpcv1.InjectMessageResponse), td.StructFields{
+				"Recipients": int32(3),
+			}),
+		},
+		{
+			name:     "Unknown endpoint",
+			handler:  injectorFunc(func(string, string, string) (int, error) { return 0, nil }),
+			req:      &rpcv1.InjectMessageRequest{GroupName: "grp", EndpointName: "unknown", Target: "#botnet"},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Unknown target",
+			handler:  injectorFunc(func(string, string, string) (int, error) { return 0, endpoint.ErrUnknownMessageTarget }),
+			req:      &rpcv1.InjectMessageRequest{GroupName: "grp", EndpointName: "ep", Target: "#unknown"},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Handler not started",
+			handler:  injectorFunc(func(string, string, string) (int, error) { return 0, endpoint.ErrNotServing }),
+			req:      &rpcv1.InjectMessageRequest{GroupName: "grp", EndpointName: "ep", Target: "#botnet"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "Handler without message support",
+			handler:  protocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error { return nil }),
+			req:      &rpcv1.InjectMessageRequest{GroupName: "grp", EndpointName: "ep", Target: "#botnet"},
+			wantCode: codes.Unimplemented,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := rpc.NewMessagingServer(hostMock{
+				OnEndpointHandler: func(groupName, endpointName string) (endpoint.ProtocolHandler, error) {
+					if groupName != "grp" || endpointName != "ep" {
+						return nil, endpoint.ErrNoSuchEndpoint
+					}
+					return tt.handler, nil
+				},
+			})
+
+			got, err := s.InjectMessage(context.Background(), tt.req)
+			if tt.wantCode != codes.OK {
+				td.Cmp(t, status.Code(err), tt.wantCode)
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error = %v", err)
+				return
+			}
+
+			td.Cmp(t, got, tt.want)
+		})
+	}
+}
+
+type injectorFunc func(target, sender, message string) (int, error)
+
+func (injectorFunc) Start(context.Context, *endpoint.StartupSpec) error {
+	return nil
+}
+
+func (f injectorFunc) InjectMessage(target, sender, message string) (int, error) {
+	return f(target, sender, message)
+}
@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	rpcv1 "inetmock.icb4dc0.de/inetmock/pkg/rpc/v1"
+)
+
+var _ rpcv1.RulesServiceServer = (*rulesServer)(nil)
+
+func NewRulesServer(epHost endpoint.Host) rpcv1.RulesServiceServer {
+	return &rulesServer{
+		epHost: epHost,
+	}
+}
+
+type rulesServer struct {
+	rpcv1.UnimplementedRulesServiceServer
+	epHost endpoint.Host
+}
+
+func (s *rulesServer) ListRules(_ context.Context, req *rpcv1.ListRulesRequest) (*rpcv1.ListRulesResponse, error) {
+	manager, err := s.ruleManager(req.GroupName, req.EndpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpcv1.ListRulesResponse{Rules: rulesToProto(manager.Rules())}, nil
+}
+
+func (s *rulesServer) InsertRule(_ context.Context, req *rpcv1.InsertRuleRequest) (*rpcv1.InsertRuleResponse, error) {
+	manager, err := s.ruleManager(req.GroupName, req.EndpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	index := int(req.Index)
+	if index < 0 {
+		index = len(manager.Rules())
+	}
+
+	if err := manager.InsertRule(index, req.Rule); err != nil {
+		return nil, ruleErrorToGRPC(err)
+	}
+
+	return &rpcv1.InsertRuleResponse{Rules: rulesToProto(manager.Rules())}, nil
+}
+
+func (s *rulesServer) ReplaceRule(_ context.Context, req *rpcv1.ReplaceRuleRequest) (*rpcv1.ReplaceRuleResponse, error) {
+	manager, err := s.ruleManager(req.GroupName, req.EndpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := manager.ReplaceRule(int(req.Index), req.Rule); err != nil {
+		return nil, ruleErrorToGRPC(err)
+	}
+
+	return &rpcv1.ReplaceRuleResponse{Rules: rulesToProto(manager.Rules())}, nil
+}
+
+func (s *rulesServer) DeleteRule(_ context.Context, req *rpcv1.DeleteRuleRequest) (*rpcv1.DeleteRuleResponse, error) {
+	manager, err := s.ruleManager(req.GroupName, req.EndpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := manager.DeleteRule(int(req.Index)); err != nil {
+		return nil, ruleErrorToGRPC(err)
+	}
+
+	return &rpcv1.DeleteRuleResponse{Rules: rulesToProto(manager.Rules())}, nil
+}
+
+func (s *rulesServer) ValidateRule(_ context.Context, req *rpcv1.ValidateRuleRequest) (*rpcv1.ValidateRuleResponse, error) {
+	manager, err := s.ruleManager(req.GroupName, req.EndpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := manager.ValidateRule(req.Rule); err != nil {
+		return &rpcv1.ValidateRuleResponse{Error: err.Error()}, nil
+	}
+
+	return &rpcv1.ValidateRuleResponse{Valid: true}, nil
+}
+
+func (s *rulesServer) ruleManager(groupName, endpointName string) (rules.Manager, error) {
+	handler, err := s.epHost.EndpointHandler(groupName, endpointName)
+	if err != nil {
+		if errors.Is(err, endpoint.ErrNoSuchGroup) || errors.Is(err, endpoint.ErrNoSuchEndpoint) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	ruleHandler, ok := handler.(endpoint.RuleManagingHandler)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "handler of endpoint %s does not support rule management", endpointName)
+	}
+
+	manager := ruleHandler.RuleManager()
+	if manager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "endpoint %s is not serving", endpointName)
+	}
+
+	return manager, nil
+}
+
+func ruleErrorToGRPC(err error) error {
+	if errors.Is(err, rules.ErrRuleIndexOutOfRange) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func rulesToProto(infos []rules.Info) []*rpcv1.Rule {
+	protoRules := make([]*rpcv1.Rule, 0, len(infos))
+	for idx := range infos {
+		rule := &rpcv1.Rule{
+			Index:      int32(infos[idx].Index),
+			Id:         infos[idx].ID,
+			Raw:        infos[idx].Raw,
+			Hits:       infos[idx].Hits,
+			LastClient: infos[idx].LastClient,
+		}
+		if !infos[idx].LastMatch.IsZero() {
+			rule.LastMatch = timestamppb.New(infos[idx].LastMatch)
+		}
+		protoRules = append(protoRules, rule)
+	}
+	return protoRules
+}
@@ -0,0 +1,185 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rpc"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	rpcv1 "inetmock.icb4dc0.de/inetmock/pkg/rpc/v1"
+)
+
+func Test_rulesServer(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		handler  endpoint.ProtocolHandler
+		call     func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error)
+		want     any
+		wantCode codes.Code
+	}{
+		{
+			name:    "List rules",
+			handler: newIntRuleHandler("1", "2"),
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.ListRules(ctx, &rpcv1.ListRulesRequest{GroupName: "grp", EndpointName: "ep"})
+			},
+			want: td.Struct(new(rpcv1.ListRulesResponse), td.StructFields{
+				"Rules": td.Smuggle(rulesToRaw, []string{"1", "2"}),
+			}),
+		},
+		{
+			name:    "Unknown endpoint",
+			handler: newIntRuleHandler(),
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.ListRules(ctx, &rpcv1.ListRulesRequest{GroupName: "grp", EndpointName: "unknown"})
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:    "Handler without rule support",
+			handler: protocolHandlerFunc(func(context.Context, *endpoint.StartupSpec) error { return nil }),
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.ListRules(ctx, &rpcv1.ListRulesRequest{GroupName: "grp", EndpointName: "ep"})
+			},
+			wantCode: codes.Unimplemented,
+		},
+		{
+			name:    "Handler not started",
+			handler: intRuleHandler{},
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.ListRules(ctx, &rpcv1.ListRulesRequest{GroupName: "grp", EndpointName: "ep"})
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:    "Append rule with negative index",
+			handler: newIntRuleHandler("1"),
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.InsertRule(ctx, &rpcv1.InsertRuleRequest{GroupName: "grp", EndpointName: "ep", Index: -1, Rule: "2"})
+			},
+			want: td.Struct(new(rpcv1.InsertRuleResponse), td.StructFields{
+				"Rules": td.Smuggle(rulesToRaw, []string{"1", "2"}),
+			}),
+		},
+		{
+			name:    "Insert rule at the beginning",
+			handler: newIntRuleHandler("1"),
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.InsertRule(ctx, &rpcv1.InsertRuleRequest{GroupName: "grp", EndpointName: "ep", Index: 0, Rule: "0"})
+			},
+			want: td.Struct(new(rpcv1.InsertRuleResponse), td.StructFields{
+				"Rules": td.Smuggle(rulesToRaw, []string{"0", "1"}),
+			}),
+		},
+		{
+			name:    "Insert invalid rule",
+			handler: newIntRuleHandler("1"),
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.InsertRule(ctx, &rpcv1.InsertRuleRequest{GroupName: "grp", EndpointName: "ep", Index: 0, Rule: "a"})
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:    "Replace rule",
+			handler: newIntRuleHandler("1", "2"),
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.ReplaceRule(ctx, &rpcv1.ReplaceRuleRequest{GroupName: "grp", EndpointName: "ep", Index: 1, Rule: "3"})
+			},
+			want: td.Struct(new(rpcv1.ReplaceRuleResponse), td.StructFields{
+				"Rules": td.Smuggle(rulesToRaw, []string{"1", "3"}),
+			}),
+		},
+		{
+			name:    "Delete rule out of range",
+			handler: newIntRuleHandler("1"),
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.DeleteRule(ctx, &rpcv1.DeleteRuleRequest{GroupName: "grp", EndpointName: "ep", Index: 1})
+			},
+			wantCode: codes.OutOfRange,
+		},
+		{
+			name:    "Validate invalid rule",
+			handler: newIntRuleHandler("1"),
+			call: func(ctx context.Context, s rpcv1.RulesServiceServer) (any, error) {
+				return s.ValidateRule(ctx, &rpcv1.ValidateRuleRequest{GroupName: "grp", EndpointName: "ep", Rule: "a"})
+			},
+			want: td.Struct(new(rpcv1.ValidateRuleResponse), td.StructFields{
+				"Valid": false,
+				"Error": td.Not(""),
+			}),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := rpc.NewRulesServer(hostMock{
+				OnEndpointHandler: func(groupName, endpointName string) (endpoint.ProtocolHandler, error) {
+					if groupName != "grp" || endpointName != "ep" {
+						return nil, endpoint.ErrNoSuchEndpoint
+					}
+					return tt.handler, nil
+				},
+			})
+
+			got, err := tt.call(context.Background(), s)
+			if tt.wantCode != codes.OK {
+				td.Cmp(t, status.Code(err), tt.wantCode)
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error = %v", err)
+				return
+			}
+
+			td.Cmp(t, got, tt.want)
+		})
+	}
+}
+
+type intRuleHandler struct {
+	protocolHandlerFunc
+	manager rules.Manager
+}
+
+func newIntRuleHandler(initial ...string) intRuleHandler {
+	set := new(rules.Set[int])
+	for _, raw := range initial {
+		val, _ := strconv.Atoi(raw)
+		set.Append(raw, val)
+	}
+
+	return intRuleHandler{
+		manager: rules.CompilingManager[int]{
+			Set: set,
+			Compile: func(rawRule string) (int, error) {
+				if val, err := strconv.Atoi(rawRule); err != nil {
+					return 0, errors.New("not a number")
+				} else {
+					return val, nil
+				}
+			},
+		},
+	}
+}
+
+func (h intRuleHandler) RuleManager() rules.Manager {
+	return h.manager
+}
+
+func rulesToRaw(protoRules []*rpcv1.Rule) []string {
+	raw := make([]string, 0, len(protoRules))
+	for idx := range protoRules {
+		raw = append(raw, protoRules[idx].Raw)
+	}
+	return raw
+}
@@ -8,4 +8,5 @@ var (
 	ErrUnknownFilterMethod = errors.New("no filter with the given name is known")
 	ErrNoInitiatorDefined  = errors.New("no initiator defined")
 	ErrUnknownInitiator    = errors.New("no initiator with the given name is known")
+	ErrRuleIndexOutOfRange = errors.New("rule index out of range")
 )
@@ -0,0 +1,251 @@
+package rules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// Entry is a single compiled rule within a Set.
+	// The raw rule is kept to be able to list the rules of a running handler.
+	Entry[T any] struct {
+		Raw        string
+		Value      T
+		id         string
+		hits       uint64
+		lastMatch  int64
+		lastClient atomic.Pointer[string]
+	}
+
+	// Set holds an ordered list of compiled rules.
+	// Readers always work on an immutable snapshot of the rules,
+	// modifications are copy-on-write and swapped atomically
+	// such that in-flight requests are not affected.
+	// The zero value is ready to use.
+	Set[T any] struct {
+		lock    sync.Mutex
+		entries atomic.Pointer[[]*Entry[T]]
+	}
+
+	// Info describes a rule as seen from the outside e.g. for listing rules via the API.
+	Info struct {
+		Index      int
+		ID         string
+		Raw        string
+		Hits       uint64
+		LastMatch  time.Time
+		LastClient string
+	}
+
+	// Manager allows to modify the rules of a running handler.
+	// Indices are zero based and refer to the evaluation order of the rules.
+	Manager interface {
+		Rules() []Info
+		InsertRule(index int, rawRule string) error
+		ReplaceRule(index int, rawRule string) error
+		DeleteRule(index int) error
+		ValidateRule(rawRule string) error
+	}
+
+	// CompilingManager implements Manager for any Set
+	// by compiling raw rules with the given Compile function before they are added to the Set.
+	CompilingManager[T any] struct {
+		Set     *Set[T]
+		Compile func(rawRule string) (T, error)
+	}
+
+	// NotifyingManager calls OnChange after the rules of the wrapped Manager were modified successfully
+	// e.g. to invalidate answers a handler cached based on the previous rules.
+	NotifyingManager struct {
+		Manager
+		OnChange func()
+	}
+)
+
+// ID identifies the rule independent of its position, it is derived from the raw rule when the rule is added to the Set.
+// Hence, the ID stays the same when other rules are inserted or deleted and when the handler is restarted.
+// Identical rules within the same Set are told apart by a suffix e.g. 1a2b3c4d5e6f7a8b-2 for the second occurrence.
+func (e *Entry[T]) ID() string {
+	return e.id
+}
+
+// RuleID returns the ID of the given raw rule i.e. the first 8 bytes of its SHA-256 hash hex encoded
+func RuleID(rawRule string) string {
+	const idLength = 8
+	hash := sha256.Sum256([]byte(rawRule))
+	return hex.EncodeToString(hash[:idLength])
+}
+
+// Hit records a match of the rule for the given client e.g. its IP or MAC address.
+func (e *Entry[T]) Hit(client string) {
+	atomic.AddUint64(&e.hits, 1)
+	atomic.StoreInt64(&e.lastMatch, time.Now().UnixNano())
+	e.lastClient.Store(&client)
+}
+
+func (e *Entry[T]) Hits() uint64 {
+	return atomic.LoadUint64(&e.hits)
+}
+
+// LastMatch returns the time of the last match or the zero time if the rule never matched.
+func (e *Entry[T]) LastMatch() time.Time {
+	if lastMatch := atomic.LoadInt64(&e.lastMatch); lastMatch != 0 {
+		return time.Unix(0, lastMatch)
+	}
+	return time.Time{}
+}
+
+func (e *Entry[T]) LastClient() string {
+	if lastClient := e.lastClient.Load(); lastClient != nil {
+		return *lastClient
+	}
+	return ""
+}
+
+// Entries returns the current snapshot of rules - the returned slice must not be modified.
+func (s *Set[T]) Entries() []*Entry[T] {
+	if entries := s.entries.Load(); entries != nil {
+		return *entries
+	}
+	return nil
+}
+
+func (s *Set[T]) Len() int {
+	return len(s.Entries())
+}
+
+func (s *Set[T]) Append(raw string, value T) {
+	_ = s.modify(func(current []*Entry[T]) ([]*Entry[T], error) {
+		return append(current, newEntry(current, raw, value)), nil
+	})
+}
+
+// Insert adds a rule at the given index, an index equal to the number of rules appends the rule.
+func (s *Set[T]) Insert(index int, raw string, value T) error {
+	return s.modify(func(current []*Entry[T]) ([]*Entry[T], error) {
+		if index < 0 || index > len(current) {
+			return nil, fmt.Errorf("%w: %d", ErrRuleIndexOutOfRange, index)
+		}
+
+		updated := make([]*Entry[T], 0, len(current)+1)
+		updated = append(updated, current[:index]...)
+		updated = append(updated, newEntry(current, raw, value))
+		return append(updated, current[index:]...), nil
+	})
+}
+
+func (s *Set[T]) Replace(index int, raw string, value T) error {
+	return s.modify(func(current []*Entry[T]) ([]*Entry[T], error) {
+		if index < 0 || index >= len(current) {
+			return nil, fmt.Errorf("%w: %d", ErrRuleIndexOutOfRange, index)
+		}
+
+		others := append(append(make([]*Entry[T], 0, len(current)-1), current[:index]...), current[index+1:]...)
+		current[index] = newEntry(others, raw, value)
+		return current, nil
+	})
+}
+
+func (s *Set[T]) Delete(index int) error {
+	return s.modify(func(current []*Entry[T]) ([]*Entry[T], error) {
+		if index < 0 || index >= len(current) {
+			return nil, fmt.Errorf("%w: %d", ErrRuleIndexOutOfRange, index)
+		}
+
+		return append(current[:index], current[index+1:]...), nil
+	})
+}
+
+// newEntry creates the entry for the given rule with an ID that is not used by any of the existing entries
+func newEntry[T any](existing []*Entry[T], raw string, value T) *Entry[T] {
+	taken := make(map[string]bool, len(existing))
+	for idx := range existing {
+		taken[existing[idx].id] = true
+	}
+
+	id := RuleID(raw)
+	for occurrence := 2; taken[id]; occurrence++ {
+		id = fmt.Sprintf("%s-%d", RuleID(raw), occurrence)
+	}
+
+	return &Entry[T]{Raw: raw, Value: value, id: id}
+}
+
+// modify passes a copy of the current rules to the given function and stores the result as new snapshot.
+func (s *Set[T]) modify(mod func(current []*Entry[T]) ([]*Entry[T], error)) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	current := s.Entries()
+	updated, err := mod(append(make([]*Entry[T], 0, len(current)), current...))
+	if err != nil {
+		return err
+	}
+
+	s.entries.Store(&updated)
+	return nil
+}
+
+func (m CompilingManager[T]) Rules() []Info {
+	entries := m.Set.Entries()
+	infos := make([]Info, 0, len(entries))
+	for idx := range entries {
+		infos = append(infos, Info{
+			Index:      idx,
+			ID:         entries[idx].ID(),
+			Raw:        entries[idx].Raw,
+			Hits:       entries[idx].Hits(),
+			LastMatch:  entries[idx].LastMatch(),
+			LastClient: entries[idx].LastClient(),
+		})
+	}
+	return infos
+}
+
+func (m CompilingManager[T]) InsertRule(index int, rawRule string) error {
+	compiled, err := m.Compile(rawRule)
+	if err != nil {
+		return err
+	}
+	return m.Set.Insert(index, rawRule, compiled)
+}
+
+func (m CompilingManager[T]) ReplaceRule(index int, rawRule string) error {
+	compiled, err := m.Compile(rawRule)
+	if err != nil {
+		return err
+	}
+	return m.Set.Replace(index, rawRule, compiled)
+}
+
+func (m CompilingManager[T]) DeleteRule(index int) error {
+	return m.Set.Delete(index)
+}
+
+func (m CompilingManager[T]) ValidateRule(rawRule string) error {
+	_, err := m.Compile(rawRule)
+	return err
+}
+
+func (m NotifyingManager) InsertRule(index int, rawRule string) error {
+	return m.notify(m.Manager.InsertRule(index, rawRule))
+}
+
+func (m NotifyingManager) ReplaceRule(index int, rawRule string) error {
+	return m.notify(m.Manager.ReplaceRule(index, rawRule))
+}
+
+func (m NotifyingManager) DeleteRule(index int) error {
+	return m.notify(m.Manager.DeleteRule(index))
+}
+
+func (m NotifyingManager) notify(err error) error {
+	if err == nil && m.OnChange != nil {
+		m.OnChange()
+	}
+	return err
+}
@@ -0,0 +1,258 @@
+package rules_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+)
+
+func TestCompilingManager(t *testing.T) {
+	t.Parallel()
+	errInvalidRule := errors.New("invalid rule")
+	tests := []struct {
+		name    string
+		initial []string
+		modify  func(m rules.Manager) error
+		want    any
+		wantErr bool
+	}{
+		{
+			name:    "List initial rules",
+			initial: []string{"1", "2"},
+			modify: func(rules.Manager) error {
+				return nil
+			},
+			want: []rules.Info{
+				{Index: 0, ID: rules.RuleID("1"), Raw: "1"},
+				{Index: 1, ID: rules.RuleID("2"), Raw: "2"},
+			},
+		},
+		{
+			name:    "Insert rule at the beginning",
+			initial: []string{"1", "2"},
+			modify: func(m rules.Manager) error {
+				return m.InsertRule(0, "0")
+			},
+			want: []rules.Info{
+				{Index: 0, ID: rules.RuleID("0"), Raw: "0"},
+				{Index: 1, ID: rules.RuleID("1"), Raw: "1"},
+				{Index: 2, ID: rules.RuleID("2"), Raw: "2"},
+			},
+		},
+		{
+			name:    "Insert rule at the end",
+			initial: []string{"1", "2"},
+			modify: func(m rules.Manager) error {
+				return m.InsertRule(2, "3")
+			},
+			want: []rules.Info{
+				{Index: 0, ID: rules.RuleID("1"), Raw: "1"},
+				{Index: 1, ID: rules.RuleID("2"), Raw: "2"},
+				{Index: 2, ID: rules.RuleID("3"), Raw: "3"},
+			},
+		},
+		{
+			name:    "Insert rule out of range",
+			initial: []string{"1"},
+			modify: func(m rules.Manager) error {
+				return m.InsertRule(2, "3")
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Insert invalid rule",
+			initial: []string{"1"},
+			modify: func(m rules.Manager) error {
+				return m.InsertRule(0, "invalid")
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Replace rule",
+			initial: []string{"1", "2"},
+			modify: func(m rules.Manager) error {
+				return m.ReplaceRule(1, "3")
+			},
+			want: []rules.Info{
+				{Index: 0, ID: rules.RuleID("1"), Raw: "1"},
+				{Index: 1, ID: rules.RuleID("3"), Raw: "3"},
+			},
+		},
+		{
+			name:    "Replace rule out of range",
+			initial: []string{"1", "2"},
+			modify: func(m rules.Manager) error {
+				return m.ReplaceRule(2, "3")
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Delete rule",
+			initial: []string{"1", "2", "3"},
+			modify: func(m rules.Manager) error {
+				return m.DeleteRule(1)
+			},
+			want: []rules.Info{
+				{Index: 0, ID: rules.RuleID("1"), Raw: "1"},
+				{Index: 1, ID: rules.RuleID("3"), Raw: "3"},
+			},
+		},
+		{
+			name:    "Delete rule out of range",
+			initial: []string{"1"},
+			modify: func(m rules.Manager) error {
+				return m.DeleteRule(-1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Identical rules get distinct IDs",
+			initial: []string{"1", "1"},
+			modify: func(m rules.Manager) error {
+				return m.InsertRule(0, "1")
+			},
+			want: []rules.Info{
+				{Index: 0, ID: rules.RuleID("1") + "-3", Raw: "1"},
+				{Index: 1, ID: rules.RuleID("1"), Raw: "1"},
+				{Index: 2, ID: rules.RuleID("1") + "-2", Raw: "1"},
+			},
+		},
+		{
+			name:    "Deleting an identical rule keeps the IDs of the others",
+			initial: []string{"1", "1", "1"},
+			modify: func(m rules.Manager) error {
+				return m.DeleteRule(0)
+			},
+			want: []rules.Info{
+				{Index: 0, ID: rules.RuleID("1") + "-2", Raw: "1"},
+				{Index: 1, ID: rules.RuleID("1") + "-3", Raw: "1"},
+			},
+		},
+		{
+			name:    "Replacing a rule with an identical one",
+			initial: []string{"1", "2"},
+			modify: func(m rules.Manager) error {
+				if err := m.ReplaceRule(1, "1"); err != nil {
+					return err
+				}
+				return m.ReplaceRule(0, "1")
+			},
+			want: []rules.Info{
+				{Index: 0, ID: rules.RuleID("1"), Raw: "1"},
+				{Index: 1, ID: rules.RuleID("1") + "-2", Raw: "1"},
+			},
+		},
+		{
+			name:    "Validate does not modify rules",
+			initial: []string{"1"},
+			modify: func(m rules.Manager) error {
+				return m.ValidateRule("2")
+			},
+			want: []rules.Info{
+				{Index: 0, ID: rules.RuleID("1"), Raw: "1"},
+			},
+		},
+		{
+			name:    "Validate invalid rule",
+			initial: []string{"1"},
+			modify: func(m rules.Manager) error {
+				return m.ValidateRule("invalid")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			set := new(rules.Set[int])
+			for _, raw := range tt.initial {
+				val, _ := strconv.Atoi(raw)
+				set.Append(raw, val)
+			}
+
+			manager := rules.CompilingManager[int]{
+				Set: set,
+				Compile: func(rawRule string) (int, error) {
+					if val, err := strconv.Atoi(rawRule); err != nil {
+						return 0, errInvalidRule
+					} else {
+						return val, nil
+					}
+				},
+			}
+
+			if err := tt.modify(manager); err != nil {
+				if !tt.wantErr {
+					t.Errorf("modify() error = %v", err)
+				}
+				return
+			} else if tt.wantErr {
+				t.Error("modify() expected error but got none")
+				return
+			}
+
+			td.Cmp(t, manager.Rules(), tt.want)
+		})
+	}
+}
+
+func TestNotifyingManager(t *testing.T) {
+	t.Parallel()
+	set := new(rules.Set[int])
+	set.Append("1", 1)
+
+	var changes int
+	manager := rules.NotifyingManager{
+		Manager: rules.CompilingManager[int]{
+			Set:     set,
+			Compile: strconv.Atoi,
+		},
+		OnChange: func() {
+			changes++
+		},
+	}
+
+	td.CmpNoError(t, manager.InsertRule(0, "0"))
+	td.CmpNoError(t, manager.ReplaceRule(1, "2"))
+	td.CmpError(t, manager.ReplaceRule(1, "invalid"))
+	td.CmpError(t, manager.DeleteRule(2))
+	td.CmpNoError(t, manager.ValidateRule("3"))
+	td.CmpNoError(t, manager.DeleteRule(0))
+
+	td.Cmp(t, changes, 3)
+	td.Cmp(t, manager.Rules(), td.Len(1))
+}
+
+func TestSet_SnapshotUnaffectedByModifications(t *testing.T) {
+	t.Parallel()
+	set := new(rules.Set[int])
+	set.Append("1", 1)
+	set.Append("2", 2)
+
+	snapshot := set.Entries()
+	snapshot[0].Hit("192.168.0.10")
+
+	if err := set.Delete(0); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	td.Cmp(t, len(snapshot), 2)
+	td.Cmp(t, snapshot[0].Raw, "1")
+	td.Cmp(t, set.Len(), 1)
+	td.Cmp(t, set.Entries()[0].Raw, "2")
+	td.Cmp(t, snapshot[0].Hits(), uint64(1))
+	td.Cmp(t, snapshot[0].LastClient(), "192.168.0.10")
+	td.CmpNot(t, snapshot[0].LastMatch(), td.Zero())
+}
+
+func TestRuleID(t *testing.T) {
+	t.Parallel()
+	td.Cmp(t, rules.RuleID(`=> Status(204)`), td.Re(`^[0-9a-f]{16}$`))
+	td.Cmp(t, rules.RuleID(`=> Status(204)`), rules.RuleID(`=> Status(204)`))
+	td.Cmp(t, rules.RuleID(`=> Status(204)`), td.Not(rules.RuleID(`=> Status(404)`)))
+}
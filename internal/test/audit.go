@@ -0,0 +1,73 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const (
+	awaitEventsTimeout      = 5 * time.Second
+	awaitEventsPollInterval = 10 * time.Millisecond
+)
+
+// AwaitEvents polls the events emitted to the mock until they match want or the timeout elapsed.
+// Handlers emit their events asynchronously, hence the events are compared eventually and the test fails
+// only if they still don't match after the timeout.
+func AwaitEvents(tb testing.TB, emitterMock *audit_mock.EmitterMock, want any) bool {
+	tb.Helper()
+	deadline := time.Now().Add(awaitEventsTimeout)
+	events := emittedEvents(emitterMock)
+	for !td.EqDeeply(events, want) && time.Now().Before(deadline) {
+		time.Sleep(awaitEventsPollInterval)
+		events = emittedEvents(emitterMock)
+	}
+
+	return td.Cmp(tb, events, want)
+}
+
+// AwaitEvent is like AwaitEvents but compares only the first emitted event
+func AwaitEvent(tb testing.TB, emitterMock *audit_mock.EmitterMock, want any) bool {
+	tb.Helper()
+	return AwaitEvents(tb, emitterMock, td.Smuggle(func(events []*audit.Event) (*audit.Event, error) {
+		if len(events) == 0 {
+			return nil, errors.New("no audit event emitted")
+		}
+		return events[0], nil
+	}, want))
+}
+
+// AwaitEventDetails is like AwaitEvents but compares the protocol details of the events in the order they were emitted,
+// additionally all events have to be emitted by the given application protocol
+func AwaitEventDetails(tb testing.TB, emitterMock *audit_mock.EmitterMock, app auditv1.AppProtocol, want any) bool {
+	tb.Helper()
+	return AwaitEvents(tb, emitterMock, td.All(
+		td.ArrayEach(td.Smuggle("Application", app)),
+		EventDetails(want),
+	))
+}
+
+// EventDetails compares the protocol details of a slice of events against want
+func EventDetails(want any) td.TestDeep {
+	return td.Smuggle(func(events []*audit.Event) (details []any) {
+		for idx := range events {
+			details = append(details, events[idx].ProtocolDetails)
+		}
+		return details
+	}, want)
+}
+
+func emittedEvents(emitterMock *audit_mock.EmitterMock) (events []*audit.Event) {
+	emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+		for _, call := range calls.Emit() {
+			events = append(events, call.Params.Ev)
+		}
+	})
+	return events
+}
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+)
+
+var _ cert.Store = (*selfSignedStore)(nil)
+
+// NewSelfSignedCertStore creates a cert.Store that serves a single self-signed certificate
+// - clients have to skip the verification of the server certificate.
+func NewSelfSignedCertStore(tb testing.TB) cert.Store {
+	tb.Helper()
+	const validity = 24 * time.Hour
+	generator := cert.NewDefaultGenerator(cert.Options{
+		Validity: cert.ValidityByPurpose{
+			CA: cert.ValidityDuration{
+				NotBeforeRelative: validity,
+				NotAfterRelative:  validity,
+			},
+		},
+	})
+
+	crt, err := generator.CACert(cert.GenerationOptions{CommonName: "localhost"})
+	if err != nil {
+		tb.Fatalf("generator.CACert() error = %v", err)
+	}
+
+	return &selfSignedStore{crt: crt}
+}
+
+type selfSignedStore struct {
+	crt *tls.Certificate
+}
+
+func (s *selfSignedStore) CACert() *tls.Certificate {
+	return s.crt
+}
+
+func (s *selfSignedStore) Certificate(string, net.IP) (*tls.Certificate, error) {
+	return s.crt, nil
+}
+
+func (s *selfSignedStore) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*s.crt},
+	}
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+)
+
+// ClientCA issues client certificates for mutual TLS tests.
+// The CA certificate is written as PEM to CAFile to be configured as trusted CA of endpoints.
+type ClientCA struct {
+	CAFile    string
+	generator cert.Generator
+	ca        *tls.Certificate
+}
+
+func NewClientCA(tb testing.TB) *ClientCA {
+	tb.Helper()
+	const validity = 24 * time.Hour
+	generator := cert.NewDefaultGenerator(cert.Options{
+		Validity: cert.ValidityByPurpose{
+			CA: cert.ValidityDuration{
+				NotBeforeRelative: validity,
+				NotAfterRelative:  validity,
+			},
+			Server: cert.ValidityDuration{
+				NotBeforeRelative: validity,
+				NotAfterRelative:  validity,
+			},
+		},
+	})
+
+	ca, err := generator.CACert(cert.GenerationOptions{CommonName: "INetMock Test Client CA"})
+	if err != nil {
+		tb.Fatalf("generator.CACert() error = %v", err)
+	}
+
+	caFile := filepath.Join(tb.TempDir(), "client-ca.pem")
+	if err = os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Certificate[0]}), 0o600); err != nil {
+		tb.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	return &ClientCA{
+		CAFile:    caFile,
+		generator: generator,
+		ca:        ca,
+	}
+}
+
+// ClientCert issues a certificate for the given common name signed by the CA
+func (c *ClientCA) ClientCert(tb testing.TB, commonName string) tls.Certificate {
+	tb.Helper()
+	crt, err := c.generator.ServerCert(cert.GenerationOptions{CommonName: commonName}, c.ca)
+	if err != nil {
+		tb.Fatalf("generator.ServerCert() error = %v", err)
+	}
+
+	return *crt
+}
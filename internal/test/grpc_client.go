@@ -0,0 +1,29 @@
+package test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func GRPCClientConnForInMemListener(ctx context.Context, tb testing.TB, lis InMemListener) *grpc.ClientConn {
+	tb.Helper()
+	conn, err := grpc.DialContext(
+		ctx,
+		"inetmock.icb4dc0.de:443",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return lis.DialContext(ctx, "tcp", addr)
+		}),
+	)
+	if err != nil {
+		tb.Fatalf("failed to connect to gRPC server - error = %v", err)
+	}
+	tb.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
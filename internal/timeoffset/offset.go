@@ -0,0 +1,53 @@
+package timeoffset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+var ErrInvalid = errors.New("invalid time offset")
+
+// Parse parses signed durations like time.ParseDuration but additionally accepts whole days as leading component
+// e.g. "+365d", "-1d12h" or "90m"
+func Parse(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+
+	negative := strings.HasPrefix(raw, "-")
+	unsigned := strings.TrimLeft(raw, "+-")
+	if len(raw)-len(unsigned) > 1 || unsigned == "" {
+		return 0, fmt.Errorf("%w %q", ErrInvalid, raw)
+	}
+
+	var offset time.Duration
+	if rawDays, rest, hasDays := strings.Cut(unsigned, "d"); hasDays {
+		days, err := strconv.ParseInt(rawDays, 10, 64)
+		if err != nil || days > int64(math.MaxInt64/day) {
+			return 0, fmt.Errorf("%w %q", ErrInvalid, raw)
+		}
+		offset = time.Duration(days) * day
+		unsigned = rest
+	}
+
+	if unsigned != "" {
+		d, err := time.ParseDuration(unsigned)
+		if err != nil || d < 0 || offset > math.MaxInt64-d {
+			return 0, fmt.Errorf("%w %q", ErrInvalid, raw)
+		}
+		offset += d
+	}
+
+	if negative {
+		return -offset, nil
+	}
+
+	return offset, nil
+}
@@ -0,0 +1,82 @@
+package timeoffset_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/timeoffset"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name: "Empty offset",
+			raw:  "",
+			want: 0,
+		},
+		{
+			name: "Days",
+			raw:  "+365d",
+			want: 365 * 24 * time.Hour,
+		},
+		{
+			name: "Negative days and hours",
+			raw:  "-1d12h",
+			want: -36 * time.Hour,
+		},
+		{
+			name: "Duration without days",
+			raw:  "90m",
+			want: 90 * time.Minute,
+		},
+		{
+			name:    "Multiple signs",
+			raw:     "+-1h",
+			wantErr: true,
+		},
+		{
+			name:    "Sign within duration",
+			raw:     "1d-2h",
+			wantErr: true,
+		},
+		{
+			name:    "Fractional days",
+			raw:     "1.5d",
+			wantErr: true,
+		},
+		{
+			name:    "Overflow",
+			raw:     "+200000d",
+			wantErr: true,
+		},
+		{
+			name:    "Sign only",
+			raw:     "-",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := timeoffset.Parse(tt.raw)
+			if tt.wantErr {
+				if !errors.Is(err, timeoffset.ErrInvalid) {
+					t.Errorf("Parse() error = %v, want %v", err, timeoffset.ErrInvalid)
+				}
+				return
+			}
+			td.CmpNoError(t, err)
+			td.Cmp(t, got, tt.want)
+		})
+	}
+}
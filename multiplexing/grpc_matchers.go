@@ -0,0 +1,19 @@
+package multiplexing
+
+import (
+	"strings"
+
+	"github.com/soheilhy/cmux"
+)
+
+const grpcContentType = "application/grpc"
+
+// GRPC matches HTTP/2 requests with a gRPC content type e.g. application/grpc or application/grpc+proto.
+// gRPC clients wait for the SETTINGS frame of the server hence the matcher has to write it.
+func GRPC() cmux.MatchWriter {
+	return HTTPMatchAndWithWriter(func(req *RequestPreface) bool {
+		return req != nil &&
+			req.Version == HTTPVersion2 &&
+			strings.HasPrefix(req.Header.Get("Content-Type"), grpcContentType)
+	})
+}
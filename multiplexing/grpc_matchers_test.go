@@ -0,0 +1,82 @@
+package multiplexing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/soheilhy/cmux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/multiplexing"
+)
+
+func TestGRPC(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		call    func(ctx context.Context, t *testing.T, lis test.InMemListener) error
+		wantErr bool
+	}{
+		{
+			name: "Match a gRPC call",
+			call: func(ctx context.Context, t *testing.T, lis test.InMemListener) error {
+				conn := test.GRPCClientConnForInMemListener(ctx, t, lis)
+				_, err := healthv1.NewHealthClient(conn).Check(ctx, new(healthv1.HealthCheckRequest))
+				return err
+			},
+		},
+		{
+			name: "Don't match a plain HTTP 2 request",
+			call: func(ctx context.Context, _ *testing.T, lis test.InMemListener) error {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://inetmock.icb4dc0.de/", nil)
+				if err != nil {
+					return err
+				}
+				resp, err := test.HTTP2ClientForInMemListener(lis).Do(req)
+				if err != nil {
+					return err
+				}
+				return resp.Body.Close()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			inMemListener := test.NewInMemoryListener(t)
+			c := cmux.New(inMemListener)
+			c.SetReadTimeout(100 * time.Millisecond)
+			multiplexerListener := c.MatchWithWriters(multiplexing.GRPC())
+			go func() {
+				if err := c.Serve(); !errors.Is(err, test.ErrListenerClosed) {
+					t.Logf("Serve() error = %v", err)
+				}
+			}()
+
+			srv := grpc.NewServer()
+			healthv1.RegisterHealthServer(srv, health.NewServer())
+			go func() {
+				_ = srv.Serve(multiplexerListener)
+			}()
+			t.Cleanup(func() {
+				srv.Stop()
+				c.Close()
+			})
+
+			ctx, cancel := context.WithTimeout(test.Context(t), time.Second)
+			defer cancel()
+
+			err := tt.call(ctx, t, inMemListener)
+			td.Cmp(t, err != nil, tt.wantErr)
+		})
+	}
+}
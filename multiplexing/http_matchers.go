@@ -59,19 +59,35 @@ func PathPrefixMatcher(prefix string) RequestMatcher {
 }
 
 func HTTPMatchOr(reqMatchers ...RequestMatcher) cmux.Matcher {
-	return httpMatch(reqMatchers, false, func(b1, b2 bool) bool {
-		return b1 || b2
-	})
+	return discardingMatcher(httpMatch(reqMatchers, false, or))
 }
 
 func HTTPMatchAnd(reqMatchers ...RequestMatcher) cmux.Matcher {
-	return httpMatch(reqMatchers, true, func(b1, b2 bool) bool {
-		return b1 && b2
-	})
+	return discardingMatcher(httpMatch(reqMatchers, true, and))
+}
+
+// HTTPMatchAndWithWriter behaves like HTTPMatchAnd but answers the SETTINGS frame of HTTP/2 clients.
+// This is required for clients like gRPC waiting for the server SETTINGS before sending their request headers.
+func HTTPMatchAndWithWriter(reqMatchers ...RequestMatcher) cmux.MatchWriter {
+	return httpMatch(reqMatchers, true, and)
+}
+
+func and(b1, b2 bool) bool {
+	return b1 && b2
 }
 
-func httpMatch(reqMatchers []RequestMatcher, init bool, fold func(b1, b2 bool) bool) cmux.Matcher {
+func or(b1, b2 bool) bool {
+	return b1 || b2
+}
+
+func discardingMatcher(matchWriter cmux.MatchWriter) cmux.Matcher {
 	return func(reader io.Reader) bool {
+		return matchWriter(io.Discard, reader)
+	}
+}
+
+func httpMatch(reqMatchers []RequestMatcher, init bool, fold func(b1, b2 bool) bool) cmux.MatchWriter {
+	return func(writer io.Writer, reader io.Reader) bool {
 		var (
 			req *RequestPreface
 			err error
@@ -88,7 +104,7 @@ func httpMatch(reqMatchers []RequestMatcher, init bool, fold func(b1, b2 bool) b
 			}
 			req.Version = v
 		case HTTPVersion2:
-			if req, err = parseHTTP2Request(buffered); err != nil {
+			if req, err = parseHTTP2Request(writer, buffered); err != nil {
 				return false
 			}
 			req.Version = v
@@ -0,0 +1,38 @@
+package multiplexing
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/soheilhy/cmux"
+)
+
+const ircCommandLength = 5
+
+var ircRegistrationCommands = [][]byte{
+	[]byte("CAP L"),
+	[]byte("CAP R"),
+	[]byte("NICK "),
+	[]byte("PASS "),
+	[]byte("USER "),
+}
+
+// IRC matches connections of clients starting with an IRC registration command.
+// USER is also sent by POP3 and FTP clients but only after they received the server's banner,
+// hence they are not matched as long as the client has to wait for the banner.
+func IRC() cmux.Matcher {
+	return func(reader io.Reader) bool {
+		cmd := make([]byte, ircCommandLength)
+		if _, err := io.ReadFull(reader, cmd); err != nil {
+			return false
+		}
+
+		for idx := range ircRegistrationCommands {
+			if bytes.EqualFold(cmd, ircRegistrationCommands[idx]) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,77 @@
+package multiplexing
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/soheilhy/cmux"
+)
+
+const (
+	berSequence          = 0x30
+	berInteger           = 0x02
+	berLongFormLength    = 0x80
+	berMaxLengthOctets   = 4
+	ldapMaxMessageIDSize = 4
+
+	ldapBindRequest     = 0x60
+	ldapSearchRequest   = 0x63
+	ldapExtendedRequest = 0x77
+)
+
+// LDAP matches connections of clients starting with an LDAP bind, search or extended request e.g. StartTLS.
+// The BER encoded message envelope is checked up to the tag of the protocol operation.
+func LDAP() cmux.Matcher {
+	return func(reader io.Reader) bool {
+		buffered := bufio.NewReader(reader)
+		if tag, err := buffered.ReadByte(); err != nil || tag != berSequence {
+			return false
+		}
+
+		if !skipBERLength(buffered) {
+			return false
+		}
+
+		if tag, err := buffered.ReadByte(); err != nil || tag != berInteger {
+			return false
+		}
+
+		idLength, err := buffered.ReadByte()
+		if err != nil || idLength == 0 || idLength > ldapMaxMessageIDSize {
+			return false
+		}
+
+		if _, err = buffered.Discard(int(idLength)); err != nil {
+			return false
+		}
+
+		switch op, err := buffered.ReadByte(); {
+		case err != nil:
+			return false
+		case op == ldapBindRequest, op == ldapSearchRequest, op == ldapExtendedRequest:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// skipBERLength skips a definite length in short or long form
+func skipBERLength(reader *bufio.Reader) bool {
+	first, err := reader.ReadByte()
+	if err != nil {
+		return false
+	}
+
+	if first&berLongFormLength == 0 {
+		return true
+	}
+
+	octets := int(first &^ berLongFormLength)
+	if octets == 0 || octets > berMaxLengthOctets {
+		return false
+	}
+
+	_, err = reader.Discard(octets)
+	return err == nil
+}
@@ -0,0 +1,67 @@
+package multiplexing_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/multiplexing"
+)
+
+func TestLDAP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input []byte
+		want  bool
+	}{
+		{
+			name:  "Match anonymous bind request",
+			input: []byte{0x30, 0x0c, 0x02, 0x01, 0x01, 0x60, 0x07, 0x02, 0x01, 0x03, 0x04, 0x00, 0x80, 0x00},
+			want:  true,
+		},
+		{
+			name:  "Match search request with long form length",
+			input: []byte{0x30, 0x84, 0x00, 0x00, 0x00, 0x2d, 0x02, 0x02, 0x01, 0x00, 0x63, 0x84, 0x00, 0x00, 0x00, 0x24},
+			want:  true,
+		},
+		{
+			name:  "Match StartTLS request",
+			input: append([]byte{0x30, 0x1d, 0x02, 0x01, 0x01, 0x77, 0x18, 0x80, 0x16}, "1.3.6.1.4.1.1466.20037"...),
+			want:  true,
+		},
+		{
+			name:  "No match for unbind request",
+			input: []byte{0x30, 0x05, 0x02, 0x01, 0x02, 0x42, 0x00},
+			want:  false,
+		},
+		{
+			name:  "No match for too large message ID",
+			input: []byte{0x30, 0x0c, 0x02, 0x05, 0x01, 0x01, 0x01, 0x01, 0x01, 0x60, 0x00},
+			want:  false,
+		},
+		{
+			name:  "No match for indefinite length",
+			input: []byte{0x30, 0x80, 0x02, 0x01, 0x01, 0x60, 0x00},
+			want:  false,
+		},
+		{
+			name:  "No match for truncated message",
+			input: []byte{0x30, 0x0c, 0x02, 0x01},
+			want:  false,
+		},
+		{
+			name:  "No match for HTTP request",
+			input: []byte("GET / HTTP/1.1\r\n"),
+			want:  false,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			td.Cmp(t, multiplexing.LDAP()(bytes.NewReader(tt.input)), tt.want)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package multiplexing
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/soheilhy/cmux"
+)
+
+const (
+	mqttConnectPacketType  = 0x10
+	mqttMaxRemainingLength = 4
+	mqttContinuationBit    = 0x80
+)
+
+var mqttProtocolNames = [][]byte{
+	// MQTT 3.1.1 and 5
+	{0x00, 0x04, 'M', 'Q', 'T', 'T'},
+	// MQTT 3.1
+	{0x00, 0x06, 'M', 'Q', 'I', 's', 'd', 'p'},
+}
+
+// MQTT matches connections of clients starting with an MQTT CONNECT packet.
+// The fixed header is followed by the protocol name which is checked for all known protocol versions.
+func MQTT() cmux.Matcher {
+	return func(reader io.Reader) bool {
+		buffered := bufio.NewReader(reader)
+		if packetType, err := buffered.ReadByte(); err != nil || packetType != mqttConnectPacketType {
+			return false
+		}
+
+		if !skipRemainingLength(buffered) {
+			return false
+		}
+
+		for idx := range mqttProtocolNames {
+			if name, err := buffered.Peek(len(mqttProtocolNames[idx])); err == nil && bytes.Equal(name, mqttProtocolNames[idx]) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// skipRemainingLength skips the variable length encoded remaining length of the fixed header
+func skipRemainingLength(reader io.ByteReader) bool {
+	for i := 0; i < mqttMaxRemainingLength; i++ {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return false
+		}
+		if b&mqttContinuationBit == 0 {
+			return true
+		}
+	}
+	return false
+}
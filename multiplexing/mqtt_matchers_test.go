@@ -0,0 +1,62 @@
+package multiplexing_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/multiplexing"
+)
+
+func TestMQTT(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input []byte
+		want  bool
+	}{
+		{
+			name:  "Match MQTT 3.1.1 CONNECT",
+			input: []byte{0x10, 0x10, 0x00, 0x04, 'M', 'Q', 'T', 'T', 0x04, 0x02, 0x00, 0x3c},
+			want:  true,
+		},
+		{
+			name:  "Match MQTT 3.1 CONNECT",
+			input: []byte{0x10, 0x12, 0x00, 0x06, 'M', 'Q', 'I', 's', 'd', 'p', 0x03, 0x02},
+			want:  true,
+		},
+		{
+			name:  "Match CONNECT with multi byte remaining length",
+			input: []byte{0x10, 0x80, 0x01, 0x00, 0x04, 'M', 'Q', 'T', 'T', 0x05},
+			want:  true,
+		},
+		{
+			name:  "No match for PUBLISH",
+			input: []byte{0x30, 0x10, 0x00, 0x04, 'M', 'Q', 'T', 'T'},
+			want:  false,
+		},
+		{
+			name:  "No match for invalid remaining length",
+			input: []byte{0x10, 0x80, 0x80, 0x80, 0x80, 0x01, 0x00, 0x04, 'M', 'Q', 'T', 'T'},
+			want:  false,
+		},
+		{
+			name:  "No match for HTTP request",
+			input: []byte("GET / HTTP/1.1\r\n"),
+			want:  false,
+		},
+		{
+			name:  "No match for truncated CONNECT",
+			input: []byte{0x10, 0x10, 0x00, 0x04, 'M'},
+			want:  false,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			td.Cmp(t, multiplexing.MQTT()(bytes.NewReader(tt.input)), tt.want)
+		})
+	}
+}
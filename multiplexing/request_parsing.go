@@ -87,10 +87,10 @@ func parseHTTP1Request(bufReader *bufio.Reader) (*RequestPreface, error) {
 	}
 }
 
-func parseHTTP2Request(reader *bufio.Reader) (*RequestPreface, error) {
+func parseHTTP2Request(writer io.Writer, reader *bufio.Reader) (*RequestPreface, error) {
 	var (
 		req    = new(RequestPreface)
-		framer = http2.NewFramer(io.Discard, reader)
+		framer = http2.NewFramer(writer, reader)
 		done   bool
 	)
 
@@ -0,0 +1,76 @@
+package multiplexing
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/soheilhy/cmux"
+)
+
+const (
+	syslogMaxOctetCountDigits = 10
+	syslogMaxPriDigits        = 3
+	syslogMaxPri              = 191
+)
+
+// Syslog matches connections of clients sending syslog messages with a PRI part e.g. <34>,
+// either with non-transparent framing or prefixed with the octet count of the message as defined in RFC 6587.
+func Syslog() cmux.Matcher {
+	return func(reader io.Reader) bool {
+		buffered := bufio.NewReader(reader)
+		first, err := buffered.ReadByte()
+		if err != nil {
+			return false
+		}
+
+		if isDigit(first) {
+			if first == '0' || !skipDigits(buffered, syslogMaxOctetCountDigits-1, ' ') {
+				return false
+			}
+			if first, err = buffered.ReadByte(); err != nil {
+				return false
+			}
+		}
+
+		if first != '<' {
+			return false
+		}
+
+		pri := 0
+		for idx := 0; idx <= syslogMaxPriDigits; idx++ {
+			b, err := buffered.ReadByte()
+			switch {
+			case err != nil:
+				return false
+			case b == '>':
+				return idx > 0 && pri <= syslogMaxPri
+			case !isDigit(b):
+				return false
+			default:
+				pri = pri*10 + int(b-'0')
+			}
+		}
+
+		return false
+	}
+}
+
+// skipDigits consumes up to maxDigits digits followed by the given delimiter
+func skipDigits(reader *bufio.Reader, maxDigits int, delimiter byte) bool {
+	for idx := 0; idx <= maxDigits; idx++ {
+		b, err := reader.ReadByte()
+		switch {
+		case err != nil:
+			return false
+		case b == delimiter:
+			return true
+		case !isDigit(b):
+			return false
+		}
+	}
+	return false
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
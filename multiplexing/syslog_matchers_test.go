@@ -0,0 +1,67 @@
+package multiplexing_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/multiplexing"
+)
+
+func TestSyslog(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input []byte
+		want  bool
+	}{
+		{
+			name:  "Match RFC 3164 message",
+			input: []byte("<34>Oct 11 22:14:15 mymachine su: 'su root' failed\n"),
+			want:  true,
+		},
+		{
+			name:  "Match RFC 5424 message",
+			input: []byte("<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 - message\n"),
+			want:  true,
+		},
+		{
+			name:  "Match octet counted message",
+			input: []byte("52 <0>1 2003-10-11T22:14:15.003Z host app - - - message"),
+			want:  true,
+		},
+		{
+			name:  "No match for PRI out of range",
+			input: []byte("<192>Oct 11 22:14:15 mymachine su: failed\n"),
+			want:  false,
+		},
+		{
+			name:  "No match for empty PRI",
+			input: []byte("<>Oct 11 22:14:15 mymachine su: failed\n"),
+			want:  false,
+		},
+		{
+			name:  "No match for octet count with leading zero",
+			input: []byte("052 <0>1 - - - - - -"),
+			want:  false,
+		},
+		{
+			name:  "No match for HTTP request",
+			input: []byte("GET / HTTP/1.1\r\n"),
+			want:  false,
+		},
+		{
+			name:  "No match for truncated PRI",
+			input: []byte("<34"),
+			want:  false,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			td.Cmp(t, multiplexing.Syslog()(bytes.NewReader(tt.input)), tt.want)
+		})
+	}
+}
@@ -11,26 +11,53 @@ import (
 type httpContextKey string
 
 const (
-	remoteAddrKey httpContextKey = "inetmock.icb4dc0.de/inetmock/pkg/audit/context/remoteAddr"
-	localAddrKey  httpContextKey = "inetmock.icb4dc0.de/inetmock/pkg/audit/context/localAddr"
-	tlsStateKey   httpContextKey = "inetmock.icb4dc0.de/inetmock/pkg/audit/context/tlsState"
+	remoteAddrKey       httpContextKey = "inetmock.icb4dc0.de/inetmock/pkg/audit/context/remoteAddr"
+	localAddrKey        httpContextKey = "inetmock.icb4dc0.de/inetmock/pkg/audit/context/localAddr"
+	tlsStateKey         httpContextKey = "inetmock.icb4dc0.de/inetmock/pkg/audit/context/tlsState"
+	tlsFingerprinterKey httpContextKey = "inetmock.icb4dc0.de/inetmock/pkg/audit/context/tlsFingerprinter"
 )
 
 func StoreConnPropertiesInContext(ctx context.Context, c net.Conn) context.Context {
-	ctx = context.WithValue(ctx, remoteAddrKey, c.RemoteAddr())
-	ctx = context.WithValue(ctx, localAddrKey, c.LocalAddr())
+	ctx = StoreAddrsInContext(ctx, c.LocalAddr(), c.RemoteAddr())
 	ctx = addTLSConnectionStateToContext(ctx, c)
+	// the fingerprinter is stored instead of the fingerprint because the handshake might not be done yet
+	if fingerprinter := connTLSFingerprinter(c); fingerprinter != nil {
+		ctx = context.WithValue(ctx, tlsFingerprinterKey, fingerprinter)
+	}
 	return ctx
 }
 
+// StoreAddrsInContext stores the local and remote address e.g. of a packet based connection
+// such that they can be retrieved with LocalAddr and RemoteAddr.
+func StoreAddrsInContext(ctx context.Context, localAddr, remoteAddr net.Addr) context.Context {
+	ctx = context.WithValue(ctx, remoteAddrKey, remoteAddr)
+	ctx = context.WithValue(ctx, localAddrKey, localAddr)
+	return ctx
+}
+
+// tlsStateFunc resolves the TLS connection state when it is requested
+// because the handshake might not be done yet when the connection is stored in the context
+type tlsStateFunc func() tls.ConnectionState
+
 func addTLSConnectionStateToContext(ctx context.Context, c net.Conn) context.Context {
+	if _, ok := ConnTLSState(c); ok {
+		return context.WithValue(ctx, tlsStateKey, tlsStateFunc(func() tls.ConnectionState {
+			state, _ := ConnTLSState(c)
+			return state
+		}))
+	}
+	return ctx
+}
+
+// ConnTLSState returns the TLS connection state of c if it is a TLS connection, also if it was multiplexed
+func ConnTLSState(c net.Conn) (tls.ConnectionState, bool) {
 	switch subConn := c.(type) {
 	case *tls.Conn:
-		return context.WithValue(ctx, tlsStateKey, subConn.ConnectionState())
+		return subConn.ConnectionState(), true
 	case *cmux.MuxConn:
-		return addTLSConnectionStateToContext(ctx, subConn.Conn)
+		return ConnTLSState(subConn.Conn)
 	default:
-		return ctx
+		return tls.ConnectionState{}, false
 	}
 }
 
@@ -39,7 +66,31 @@ func TLSConnectionState(ctx context.Context) (tls.ConnectionState, bool) {
 	if val == nil {
 		return tls.ConnectionState{}, false
 	}
-	return val.(tls.ConnectionState), true
+	return val.(tlsStateFunc)(), true
+}
+
+// TLSFingerprintFromContext returns the fingerprint of the TLS client of the connection stored in the context,
+// nil if the connection wasn't terminated by an endpoint
+func TLSFingerprintFromContext(ctx context.Context) *TLSFingerprint {
+	if fingerprinter, ok := ctx.Value(tlsFingerprinterKey).(TLSFingerprinter); ok {
+		return fingerprinter.TLSFingerprint()
+	}
+	return nil
+}
+
+// NewTLSDetailsFromContext collects the TLS details of the connection stored in the context
+func NewTLSDetailsFromContext(ctx context.Context) (*TLSDetails, bool) {
+	state, ok := TLSConnectionState(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	details := NewTLSDetailsFromState(state)
+	if fingerprint := TLSFingerprintFromContext(ctx); fingerprint != nil {
+		details.TLSFingerprint = *fingerprint
+	}
+
+	return details, true
 }
 
 func LocalAddr(ctx context.Context) net.Addr {
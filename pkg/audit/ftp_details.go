@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*FTP)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Ftp)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.FTPDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Ftp); !ok {
+			return nil
+		} else {
+			entity = e.Ftp
+		}
+
+		return &FTP{
+			Command:  entity.Command,
+			User:     entity.User,
+			Password: entity.Password,
+			Path:     entity.Path,
+			Bytes:    entity.Bytes,
+			Passive:  entity.Passive,
+		}
+	})
+}
+
+// FTP describes a command of an FTP client.
+// Bytes and Passive are only set for data transfers, the password only for login attempts.
+type FTP struct {
+	Command  string
+	User     string
+	Password string
+	Path     string
+	Bytes    int64
+	Passive  bool
+}
+
+func (d FTP) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_Ftp{
+		Ftp: &auditv1.FTPDetailsEntity{
+			Command:  d.Command,
+			User:     d.User,
+			Password: d.Password,
+			Path:     d.Path,
+			Bytes:    d.Bytes,
+			Passive:  d.Passive,
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"reflect"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*GRPC)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Grpc)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.GRPCDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Grpc); !ok {
+			return nil
+		} else {
+			entity = e.Grpc
+		}
+
+		md := metadata.MD{}
+		for key, value := range entity.Metadata {
+			md.Append(key, value.Values...)
+		}
+
+		return &GRPC{
+			FullMethod:    entity.FullMethod,
+			Authority:     entity.Authority,
+			Metadata:      md,
+			Requests:      entity.Requests,
+			StatusCode:    codes.Code(entity.StatusCode),
+			StatusMessage: entity.StatusMessage,
+		}
+	})
+}
+
+// GRPC describes a single call handled by the gRPC mock.
+// Requests contains all received request messages encoded as JSON.
+type GRPC struct {
+	FullMethod    string
+	Authority     string
+	Metadata      metadata.MD
+	Requests      []string
+	StatusCode    codes.Code
+	StatusMessage string
+}
+
+func (d GRPC) AddToMsg(msg *auditv1.EventEntity) {
+	md := make(map[string]*auditv1.GRPCMetadataValue, len(d.Metadata))
+	for key, values := range d.Metadata {
+		md[key] = &auditv1.GRPCMetadataValue{Values: values}
+	}
+
+	msg.ProtocolDetails = &auditv1.EventEntity_Grpc{
+		Grpc: &auditv1.GRPCDetailsEntity{
+			FullMethod:    d.FullMethod,
+			Authority:     d.Authority,
+			Metadata:      md,
+			Requests:      d.Requests,
+			StatusCode:    uint32(d.StatusCode),
+			StatusMessage: d.StatusMessage,
+		},
+	}
+}
@@ -1,7 +1,6 @@
 package audit
 
 import (
-	"crypto/tls"
 	"net/http"
 
 	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
@@ -22,12 +21,8 @@ func EmittingHandler(emitter Emitter, app auditv1.AppProtocol, delegate http.Han
 			WithApplication(app).
 			WithProtocolDetails(httpDetails)
 
-		if state, ok := TLSConnectionState(req.Context()); ok {
-			builder = builder.WithTLSDetails(&TLSDetails{
-				Version:     TLSVersionToEntity(state.Version).String(),
-				CipherSuite: tls.CipherSuiteName(state.CipherSuite),
-				ServerName:  state.ServerName,
-			})
+		if details, ok := NewTLSDetailsFromContext(req.Context()); ok {
+			builder = builder.WithTLSDetails(details)
 		}
 
 		// it's considered to be okay if these details are missing
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*IRC)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Irc)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.IRCDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Irc); !ok {
+			return nil
+		} else {
+			entity = e.Irc
+		}
+
+		return &IRC{
+			Nick:     entity.Nick,
+			User:     entity.User,
+			RealName: entity.RealName,
+			Command:  entity.Command,
+			Params:   entity.Params,
+		}
+	})
+}
+
+// IRC describes a single message sent by an IRC client.
+// Nick, User and RealName are empty as long as the client did not register them.
+type IRC struct {
+	Nick     string
+	User     string
+	RealName string
+	Command  string
+	Params   []string
+}
+
+func (d IRC) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_Irc{
+		Irc: &auditv1.IRCDetailsEntity{
+			Nick:     d.Nick,
+			User:     d.User,
+			RealName: d.RealName,
+			Command:  d.Command,
+			Params:   d.Params,
+		},
+	}
+}
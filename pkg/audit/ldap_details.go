@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*LDAP)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Ldap)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.LDAPDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Ldap); !ok {
+			return nil
+		} else {
+			entity = e.Ldap
+		}
+
+		return &LDAP{
+			Operation:     entity.Operation,
+			DN:            entity.Dn,
+			Password:      entity.Password,
+			SASLMechanism: entity.SaslMechanism,
+			Scope:         entity.Scope,
+			Filter:        entity.Filter,
+			Attributes:    entity.Attributes,
+			RequestName:   entity.RequestName,
+			ResultCode:    entity.ResultCode,
+			Entries:       entity.Entries,
+		}
+	})
+}
+
+// LDAP describes a single operation requested from the LDAP mock.
+// DN is the bind DN, the search base or the DN of the entry the operation refers to,
+// Scope, Filter, Attributes and Entries are only set for searches.
+type LDAP struct {
+	Operation     auditv1.LDAPOperation
+	DN            string
+	Password      string
+	SASLMechanism string
+	Scope         auditv1.LDAPSearchScope
+	Filter        string
+	Attributes    []string
+	RequestName   string
+	ResultCode    uint32
+	Entries       uint32
+}
+
+func (d LDAP) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_Ldap{
+		Ldap: &auditv1.LDAPDetailsEntity{
+			Operation:     d.Operation,
+			Dn:            d.DN,
+			Password:      d.Password,
+			SaslMechanism: d.SASLMechanism,
+			Scope:         d.Scope,
+			Filter:        d.Filter,
+			Attributes:    d.Attributes,
+			RequestName:   d.RequestName,
+			ResultCode:    d.ResultCode,
+			Entries:       d.Entries,
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*Mailbox)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Mailbox)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.MailboxDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Mailbox); !ok {
+			return nil
+		} else {
+			entity = e.Mailbox
+		}
+
+		return &Mailbox{
+			Command:   entity.Command,
+			Arguments: entity.Arguments,
+			User:      entity.User,
+			Password:  entity.Password,
+			Mailbox:   entity.Mailbox,
+		}
+	})
+}
+
+// Mailbox describes a command of a client accessing a mailbox e.g. via POP3 or IMAP.
+// The password is only set for login attempts.
+type Mailbox struct {
+	Command   string
+	Arguments []string
+	User      string
+	Password  string
+	Mailbox   string
+}
+
+func (d Mailbox) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_Mailbox{
+		Mailbox: &auditv1.MailboxDetailsEntity{
+			Command:   d.Command,
+			Arguments: d.Arguments,
+			User:      d.User,
+			Password:  d.Password,
+			Mailbox:   d.Mailbox,
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*MQTT)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Mqtt)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.MQTTDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Mqtt); !ok {
+			return nil
+		} else {
+			entity = e.Mqtt
+		}
+
+		return &MQTT{
+			PacketType:      entity.PacketType,
+			ProtocolVersion: entity.ProtocolVersion,
+			ClientID:        entity.ClientId,
+			Username:        entity.Username,
+			Password:        entity.Password,
+			Topics:          entity.Topics,
+			QoS:             entity.Qos,
+			Retain:          entity.Retain,
+			PayloadSize:     entity.PayloadSize,
+		}
+	})
+}
+
+// MQTT describes a CONNECT, PUBLISH, SUBSCRIBE or UNSUBSCRIBE packet sent by an MQTT client.
+// Topics contains the topic name of a PUBLISH packet or the topic filters of a (UN)SUBSCRIBE packet.
+type MQTT struct {
+	PacketType      auditv1.MQTTPacketType
+	ProtocolVersion uint32
+	ClientID        string
+	Username        string
+	Password        string
+	Topics          []string
+	QoS             uint32
+	Retain          bool
+	PayloadSize     int64
+}
+
+func (d MQTT) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_Mqtt{
+		Mqtt: &auditv1.MQTTDetailsEntity{
+			PacketType:      d.PacketType,
+			ProtocolVersion: d.ProtocolVersion,
+			ClientId:        d.ClientID,
+			Username:        d.Username,
+			Password:        d.Password,
+			Topics:          d.Topics,
+			Qos:             d.QoS,
+			Retain:          d.Retain,
+			PayloadSize:     d.PayloadSize,
+		},
+	}
+}
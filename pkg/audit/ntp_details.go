@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"reflect"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*NTP)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Ntp)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.NTPDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Ntp); !ok {
+			return nil
+		} else {
+			entity = e.Ntp
+		}
+
+		details := &NTP{
+			Version: uint8(entity.Version),
+			Mode:    uint8(entity.Mode),
+			Offset:  entity.Offset.AsDuration(),
+		}
+
+		if entity.ClientTransmitTime != nil {
+			details.ClientTransmitTime = entity.ClientTransmitTime.AsTime()
+		}
+
+		return details
+	})
+}
+
+// NTP describes a request of an NTP client.
+// ClientTransmitTime is the client's clock when it sent the request, many clients send a random value instead for privacy
+// reasons. Offset is the difference between the time sent to the client and the actual time.
+type NTP struct {
+	Version            uint8
+	Mode               uint8
+	ClientTransmitTime time.Time
+	Offset             time.Duration
+}
+
+func (d NTP) AddToMsg(msg *auditv1.EventEntity) {
+	entity := &auditv1.NTPDetailsEntity{
+		Version: uint32(d.Version),
+		Mode:    uint32(d.Mode),
+		Offset:  durationpb.New(d.Offset),
+	}
+
+	if !d.ClientTransmitTime.IsZero() {
+		entity.ClientTransmitTime = timestamppb.New(d.ClientTransmitTime)
+	}
+
+	msg.ProtocolDetails = &auditv1.EventEntity_Ntp{
+		Ntp: entity,
+	}
+}
@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"reflect"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*Passthrough)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Passthrough)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.PassthroughDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Passthrough); !ok {
+			return nil
+		} else {
+			entity = e.Passthrough
+		}
+
+		return &Passthrough{
+			Backend:       entity.Backend,
+			ServerName:    entity.ServerName,
+			BytesSent:     entity.BytesSent,
+			BytesReceived: entity.BytesReceived,
+			Duration:      entity.Duration.AsDuration(),
+			Error:         entity.Error,
+		}
+	})
+}
+
+// Passthrough describes a connection passed through to a backend without terminating it.
+// BytesSent is the number of bytes forwarded from the client to the backend, BytesReceived vice versa.
+// Error is set if the backend could not be reached.
+type Passthrough struct {
+	Backend       string
+	ServerName    string
+	BytesSent     uint64
+	BytesReceived uint64
+	Duration      time.Duration
+	Error         string
+}
+
+func (d Passthrough) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_Passthrough{
+		Passthrough: &auditv1.PassthroughDetailsEntity{
+			Backend:       d.Backend,
+			ServerName:    d.ServerName,
+			BytesSent:     d.BytesSent,
+			BytesReceived: d.BytesReceived,
+			Duration:      durationpb.New(d.Duration),
+			Error:         d.Error,
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*Raw)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Raw)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.RawDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Raw); !ok {
+			return nil
+		} else {
+			entity = e.Raw
+		}
+
+		details := &Raw{
+			Truncated:     entity.Truncated,
+			BytesReceived: entity.BytesReceived,
+			BytesSent:     entity.BytesSent,
+		}
+
+		if len(entity.Transcript) > 0 {
+			details.Transcript = make([]RawTranscriptEntry, 0, len(entity.Transcript))
+			for _, e := range entity.Transcript {
+				details.Transcript = append(details.Transcript, RawTranscriptEntry{
+					Direction: e.Direction,
+					Data:      e.Data,
+				})
+			}
+		}
+
+		return details
+	})
+}
+
+// RawTranscriptEntry is a chunk of data received from or sent to the client
+type RawTranscriptEntry struct {
+	Direction auditv1.RawDirection
+	Data      []byte
+}
+
+// Raw describes a session of a client with a generic mock like a TCP connection or a single UDP datagram.
+// The transcript is truncated if it exceeds the configured size, the byte counters always contain the full amount.
+type Raw struct {
+	Transcript    []RawTranscriptEntry
+	Truncated     bool
+	BytesReceived int64
+	BytesSent     int64
+}
+
+func (d Raw) AddToMsg(msg *auditv1.EventEntity) {
+	entity := &auditv1.RawDetailsEntity{
+		Truncated:     d.Truncated,
+		BytesReceived: d.BytesReceived,
+		BytesSent:     d.BytesSent,
+	}
+
+	if len(d.Transcript) > 0 {
+		entity.Transcript = make([]*auditv1.RawTranscriptEntry, 0, len(d.Transcript))
+		for idx := range d.Transcript {
+			entity.Transcript = append(entity.Transcript, &auditv1.RawTranscriptEntry{
+				Direction: d.Transcript[idx].Direction,
+				Data:      d.Transcript[idx].Data,
+			})
+		}
+	}
+
+	msg.ProtocolDetails = &auditv1.EventEntity_Raw{
+		Raw: entity,
+	}
+}
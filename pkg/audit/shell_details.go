@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*Shell)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Shell)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.ShellDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Shell); !ok {
+			return nil
+		} else {
+			entity = e.Shell
+		}
+
+		return &Shell{
+			EventType:            entity.EventType,
+			ClientVersion:        entity.ClientVersion,
+			Username:             entity.Username,
+			AuthMethod:           entity.AuthMethod,
+			Password:             entity.Password,
+			PublicKeyType:        entity.PublicKeyType,
+			PublicKeyFingerprint: entity.PublicKeyFingerprint,
+			Accepted:             entity.Accepted,
+			Command:              entity.Command,
+		}
+	})
+}
+
+// Shell describes a login attempt or a command typed into the fake shell of a remote shell mock like SSH or Telnet.
+// Accepted is only set for login attempts, Command only for commands.
+type Shell struct {
+	EventType            auditv1.ShellEventType
+	ClientVersion        string
+	Username             string
+	AuthMethod           auditv1.ShellAuthMethod
+	Password             string
+	PublicKeyType        string
+	PublicKeyFingerprint string
+	Accepted             bool
+	Command              string
+}
+
+func (d Shell) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_Shell{
+		Shell: &auditv1.ShellDetailsEntity{
+			EventType:            d.EventType,
+			ClientVersion:        d.ClientVersion,
+			Username:             d.Username,
+			AuthMethod:           d.AuthMethod,
+			Password:             d.Password,
+			PublicKeyType:        d.PublicKeyType,
+			PublicKeyFingerprint: d.PublicKeyFingerprint,
+			Accepted:             d.Accepted,
+			Command:              d.Command,
+		},
+	}
+}
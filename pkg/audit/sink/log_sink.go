@@ -34,6 +34,13 @@ func (l logSink) OnEvent(ev *audit.Event) {
 			zap.String("tls_cipher_suite", ev.TLS.CipherSuite),
 			zap.String("tls_version", ev.TLS.Version),
 		)
+
+		if len(ev.TLS.ClientCertificates) > 0 {
+			eventLogger = eventLogger.With(
+				zap.String("tls_client_subject", ev.TLS.ClientCertificates[0].Subject),
+				zap.Bool("tls_client_verified", ev.TLS.ClientCertificateVerified),
+			)
+		}
 	}
 
 	eventLogger.Info(
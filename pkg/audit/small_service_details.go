@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*SmallService)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_SmallService)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.SmallServiceDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_SmallService); !ok {
+			return nil
+		} else {
+			entity = e.SmallService
+		}
+
+		return &SmallService{
+			Queries:          entity.Queries,
+			BytesReceived:    entity.BytesReceived,
+			BytesSent:        entity.BytesSent,
+			RateLimited:      entity.RateLimited,
+			DroppedDatagrams: entity.DroppedDatagrams,
+		}
+	})
+}
+
+// SmallService describes a TCP connection to or a UDP datagram sent to one of the simple services like echo or finger.
+// Queries contains the requests of services expecting one e.g. the user name of a finger request.
+// RateLimited indicates that the response was dropped or cut short because of the configured limits.
+// Dropped UDP datagrams are not reported one by one but summarized in a single event, DroppedDatagrams is the number of
+// datagrams summarized and BytesReceived their total size.
+type SmallService struct {
+	Queries          []string
+	BytesReceived    int64
+	BytesSent        int64
+	RateLimited      bool
+	DroppedDatagrams int64
+}
+
+func (d SmallService) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_SmallService{
+		SmallService: &auditv1.SmallServiceDetailsEntity{
+			Queries:          d.Queries,
+			BytesReceived:    d.BytesReceived,
+			BytesSent:        d.BytesSent,
+			RateLimited:      d.RateLimited,
+			DroppedDatagrams: d.DroppedDatagrams,
+		},
+	}
+}
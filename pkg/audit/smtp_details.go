@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*SMTP)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Smtp)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.SMTPDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Smtp); !ok {
+			return nil
+		} else {
+			entity = e.Smtp
+		}
+
+		return &SMTP{
+			Helo:        entity.Helo,
+			MailFrom:    entity.MailFrom,
+			RcptTo:      entity.RcptTo,
+			Subject:     entity.Subject,
+			Size:        entity.Size,
+			Attachments: entity.Attachments,
+			AuthUser:    entity.AuthUser,
+		}
+	})
+}
+
+type SMTP struct {
+	Helo        string
+	MailFrom    string
+	RcptTo      []string
+	Subject     string
+	Size        int64
+	Attachments []string
+	AuthUser    string
+}
+
+func (d SMTP) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_Smtp{
+		Smtp: &auditv1.SMTPDetailsEntity{
+			Helo:        d.Helo,
+			MailFrom:    d.MailFrom,
+			RcptTo:      d.RcptTo,
+			Subject:     d.Subject,
+			Size:        d.Size,
+			Attachments: d.Attachments,
+			AuthUser:    d.AuthUser,
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*SNMP)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Snmp)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.SNMPDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Snmp); !ok {
+			return nil
+		} else {
+			entity = e.Snmp
+		}
+
+		snmp := &SNMP{
+			Version:       entity.Version,
+			Operation:     entity.Operation,
+			Community:     entity.Community,
+			UserName:      entity.UserName,
+			SecurityLevel: entity.SecurityLevel,
+			ContextName:   entity.ContextName,
+			RequestID:     entity.RequestId,
+			Variables:     make([]SNMPVariable, 0, len(entity.Variables)),
+			ErrorStatus:   entity.ErrorStatus,
+			Report:        entity.Report,
+		}
+
+		for idx := range entity.Variables {
+			snmp.Variables = append(snmp.Variables, SNMPVariable{
+				OID:   entity.Variables[idx].Oid,
+				Value: entity.Variables[idx].Value,
+			})
+		}
+
+		return snmp
+	})
+}
+
+// SNMPVariable is a single variable binding of a request, Value is only set for set requests
+type SNMPVariable struct {
+	OID   string
+	Value string
+}
+
+// SNMP describes a single request received by the SNMP mock.
+// Community is only set for SNMPv1 and SNMPv2c requests, UserName, SecurityLevel and ContextName only for SNMPv3
+// requests. Report is the name of the USM statistics counter if the request was answered with a report.
+type SNMP struct {
+	Version       auditv1.SNMPVersion
+	Operation     auditv1.SNMPOperation
+	Community     string
+	UserName      string
+	SecurityLevel auditv1.SNMPSecurityLevel
+	ContextName   string
+	RequestID     uint32
+	Variables     []SNMPVariable
+	ErrorStatus   uint32
+	Report        string
+}
+
+func (d SNMP) AddToMsg(msg *auditv1.EventEntity) {
+	details := &auditv1.SNMPDetailsEntity{
+		Version:       d.Version,
+		Operation:     d.Operation,
+		Community:     d.Community,
+		UserName:      d.UserName,
+		SecurityLevel: d.SecurityLevel,
+		ContextName:   d.ContextName,
+		RequestId:     d.RequestID,
+		Variables:     make([]*auditv1.SNMPVariable, 0, len(d.Variables)),
+		ErrorStatus:   d.ErrorStatus,
+		Report:        d.Report,
+	}
+
+	for idx := range d.Variables {
+		details.Variables = append(details.Variables, &auditv1.SNMPVariable{
+			Oid:   d.Variables[idx].OID,
+			Value: d.Variables[idx].Value,
+		})
+	}
+
+	msg.ProtocolDetails = &auditv1.EventEntity_Snmp{
+		Snmp: details,
+	}
+}
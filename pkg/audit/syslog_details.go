@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"reflect"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*Syslog)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Syslog)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.SyslogDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Syslog); !ok {
+			return nil
+		} else {
+			entity = e.Syslog
+		}
+
+		var timestamp time.Time
+		if entity.Timestamp != nil {
+			timestamp = entity.Timestamp.AsTime()
+		}
+
+		return &Syslog{
+			Format:         entity.Format,
+			Facility:       entity.Facility,
+			Severity:       entity.Severity,
+			Timestamp:      timestamp,
+			Hostname:       entity.Hostname,
+			AppName:        entity.AppName,
+			ProcID:         entity.ProcId,
+			MsgID:          entity.MsgId,
+			StructuredData: entity.StructuredData,
+			Message:        entity.Message,
+		}
+	})
+}
+
+// Syslog describes a single message received by the syslog mock.
+// Timestamp is the time reported by the sender, it is zero if the message did not contain a valid timestamp.
+type Syslog struct {
+	Format         auditv1.SyslogFormat
+	Facility       uint32
+	Severity       uint32
+	Timestamp      time.Time
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData string
+	Message        string
+}
+
+func (d Syslog) AddToMsg(msg *auditv1.EventEntity) {
+	var timestamp *timestamppb.Timestamp
+	if !d.Timestamp.IsZero() {
+		timestamp = timestamppb.New(d.Timestamp)
+	}
+
+	msg.ProtocolDetails = &auditv1.EventEntity_Syslog{
+		Syslog: &auditv1.SyslogDetailsEntity{
+			Format:         d.Format,
+			Facility:       d.Facility,
+			Severity:       d.Severity,
+			Timestamp:      timestamp,
+			Hostname:       d.Hostname,
+			AppName:        d.AppName,
+			ProcId:         d.ProcID,
+			MsgId:          d.MsgID,
+			StructuredData: d.StructuredData,
+			Message:        d.Message,
+		},
+	}
+}
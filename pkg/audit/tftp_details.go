@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*TFTP)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_Tftp)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.TFTPDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_Tftp); !ok {
+			return nil
+		} else {
+			entity = e.Tftp
+		}
+
+		return &TFTP{
+			Operation: entity.Operation,
+			Filename:  entity.Filename,
+			Mode:      entity.Mode,
+			BlockSize: entity.BlockSize,
+			Bytes:     entity.Bytes,
+			ErrorCode: entity.ErrorCode,
+			Completed: entity.Completed,
+		}
+	})
+}
+
+// TFTP describes a read or write request of a TFTP client.
+// Bytes is the number of bytes transferred before the transfer completed or was aborted,
+// ErrorCode is the TFTP error sent to or received from the client if the transfer failed with an error packet.
+type TFTP struct {
+	Operation auditv1.TFTPOperation
+	Filename  string
+	Mode      string
+	BlockSize uint32
+	Bytes     int64
+	ErrorCode uint32
+	Completed bool
+}
+
+func (d TFTP) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_Tftp{
+		Tftp: &auditv1.TFTPDetailsEntity{
+			Operation: d.Operation,
+			Filename:  d.Filename,
+			Mode:      d.Mode,
+			BlockSize: d.BlockSize,
+			Bytes:     d.Bytes,
+			ErrorCode: d.ErrorCode,
+			Completed: d.Completed,
+		},
+	}
+}
@@ -1,7 +1,15 @@
 package audit
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+	"time"
+
+	"github.com/soheilhy/cmux"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
 )
@@ -13,10 +21,85 @@ var tlsToEntity = map[uint16]auditv1.TLSVersion{
 	tls.VersionTLS13: auditv1.TLSVersion_TLS_VERSION_TLS13,
 }
 
+// TLSDetails describes the TLS session of a connection.
+// ClientCertificates is the chain presented by the client starting with its leaf certificate,
+// ClientCertificateVerified is only set if the chain was verified against the CA pool of the endpoint.
 type TLSDetails struct {
-	Version     string
-	CipherSuite string
-	ServerName  string
+	Version                   string
+	CipherSuite               string
+	ServerName                string
+	ClientCertificates        []Certificate
+	ClientCertificateVerified bool
+	TLSFingerprint
+}
+
+// Certificate contains the audit relevant fields of an X.509 certificate.
+// FingerprintSHA256 is the hex encoded SHA-256 hash of the DER encoded certificate.
+type Certificate struct {
+	Subject           string
+	Issuer            string
+	SerialNumber      string
+	FingerprintSHA256 string
+	NotBefore         time.Time
+	NotAfter          time.Time
+	DNSNames          []string
+	EmailAddresses    []string
+}
+
+func NewCertificate(cert *x509.Certificate) Certificate {
+	fingerprint := sha256.Sum256(cert.Raw)
+	return Certificate{
+		Subject:           cert.Subject.String(),
+		Issuer:            cert.Issuer.String(),
+		SerialNumber:      cert.SerialNumber.String(),
+		FingerprintSHA256: hex.EncodeToString(fingerprint[:]),
+		NotBefore:         cert.NotBefore.UTC(),
+		NotAfter:          cert.NotAfter.UTC(),
+		DNSNames:          cert.DNSNames,
+		EmailAddresses:    cert.EmailAddresses,
+	}
+}
+
+func NewCertificateFromProto(entity *auditv1.CertificateEntity) Certificate {
+	return Certificate{
+		Subject:           entity.GetSubject(),
+		Issuer:            entity.GetIssuer(),
+		SerialNumber:      entity.GetSerialNumber(),
+		FingerprintSHA256: entity.GetSha256Fingerprint(),
+		NotBefore:         entity.GetNotBefore().AsTime(),
+		NotAfter:          entity.GetNotAfter().AsTime(),
+		DNSNames:          entity.GetDnsNames(),
+		EmailAddresses:    entity.GetEmailAddresses(),
+	}
+}
+
+func (c Certificate) ProtoMessage() *auditv1.CertificateEntity {
+	return &auditv1.CertificateEntity{
+		Subject:           c.Subject,
+		Issuer:            c.Issuer,
+		SerialNumber:      c.SerialNumber,
+		Sha256Fingerprint: c.FingerprintSHA256,
+		NotBefore:         timestamppb.New(c.NotBefore),
+		NotAfter:          timestamppb.New(c.NotAfter),
+		DnsNames:          c.DNSNames,
+		EmailAddresses:    c.EmailAddresses,
+	}
+}
+
+// TLSFingerprint identifies the TLS implementation of a client by its ClientHello.
+// JA3Hash is the MD5 hash of JA3, SupportedGroups are the IDs of the named groups without GREASE values.
+type TLSFingerprint struct {
+	JA3             string
+	JA3Hash         string
+	JA4             string
+	ALPN            []string
+	SupportedGroups []uint16
+}
+
+// TLSFingerprinter is implemented by connections recording the ClientHello of the client.
+// TLSFingerprint returns nil as long as the ClientHello was not received completely or if it was malformed.
+type TLSFingerprinter interface {
+	TLSFingerprint() *TLSFingerprint
 }
 
 func TLSVersionToEntity(version uint16) auditv1.TLSVersion {
@@ -26,22 +109,111 @@ func TLSVersionToEntity(version uint16) auditv1.TLSVersion {
 	return auditv1.TLSVersion_TLS_VERSION_UNSPECIFIED
 }
 
+// NewTLSDetailsFromState collects the audit relevant details of an established TLS connection.
+// On the server side the peer certificates are those of the client.
+func NewTLSDetailsFromState(state tls.ConnectionState) *TLSDetails {
+	details := &TLSDetails{
+		Version:                   TLSVersionToEntity(state.Version).String(),
+		CipherSuite:               tls.CipherSuiteName(state.CipherSuite),
+		ServerName:                state.ServerName,
+		ClientCertificateVerified: len(state.VerifiedChains) > 0,
+	}
+
+	for _, cert := range state.PeerCertificates {
+		details.ClientCertificates = append(details.ClientCertificates, NewCertificate(cert))
+	}
+
+	return details
+}
+
+// NewTLSDetailsFromConn collects the audit relevant details of c if it is a TLS connection with completed handshake,
+// also if it was multiplexed. The fingerprint of the client is only available if the connection was terminated by an
+// endpoint.
+func NewTLSDetailsFromConn(c net.Conn) (*TLSDetails, bool) {
+	state, ok := ConnTLSState(c)
+	if !ok || !state.HandshakeComplete {
+		return nil, false
+	}
+
+	details := NewTLSDetailsFromState(state)
+	if fingerprint := ConnTLSFingerprint(c); fingerprint != nil {
+		details.TLSFingerprint = *fingerprint
+	}
+
+	return details, true
+}
+
+// ConnTLSFingerprint returns the fingerprint of the client if c is a TLS connection whose ClientHello was recorded
+func ConnTLSFingerprint(c net.Conn) *TLSFingerprint {
+	if fingerprinter := connTLSFingerprinter(c); fingerprinter != nil {
+		return fingerprinter.TLSFingerprint()
+	}
+
+	return nil
+}
+
+func connTLSFingerprinter(c net.Conn) TLSFingerprinter {
+	switch subConn := c.(type) {
+	case *tls.Conn:
+		if fingerprinter, ok := subConn.NetConn().(TLSFingerprinter); ok {
+			return fingerprinter
+		}
+		return nil
+	case *cmux.MuxConn:
+		return connTLSFingerprinter(subConn.Conn)
+	default:
+		return nil
+	}
+}
+
 func NewTLSDetailsFromProto(entity *auditv1.TLSDetailsEntity) *TLSDetails {
 	if entity == nil {
 		return nil
 	}
 
-	return &TLSDetails{
-		Version:     entity.GetVersion().String(),
-		CipherSuite: entity.GetCipherSuite(),
-		ServerName:  entity.GetServerName(),
+	details := &TLSDetails{
+		Version:                   entity.GetVersion().String(),
+		CipherSuite:               entity.GetCipherSuite(),
+		ServerName:                entity.GetServerName(),
+		ClientCertificateVerified: entity.GetClientCertificateVerified(),
+		TLSFingerprint: TLSFingerprint{
+			JA3:     entity.GetJa3(),
+			JA3Hash: entity.GetJa3Hash(),
+			JA4:     entity.GetJa4(),
+			ALPN:    entity.GetAlpn(),
+		},
+	}
+
+	for _, group := range entity.GetSupportedGroups() {
+		details.SupportedGroups = append(details.SupportedGroups, uint16(group))
 	}
+
+	for _, cert := range entity.GetClientCertificates() {
+		details.ClientCertificates = append(details.ClientCertificates, NewCertificateFromProto(cert))
+	}
+
+	return details
 }
 
 func (d TLSDetails) ProtoMessage() *auditv1.TLSDetailsEntity {
-	return &auditv1.TLSDetailsEntity{
-		Version:     auditv1.TLSVersion(auditv1.TLSVersion_value[d.Version]),
-		CipherSuite: d.CipherSuite,
-		ServerName:  d.ServerName,
+	entity := &auditv1.TLSDetailsEntity{
+		Version:                   auditv1.TLSVersion(auditv1.TLSVersion_value[d.Version]),
+		CipherSuite:               d.CipherSuite,
+		ServerName:                d.ServerName,
+		Ja3:                       d.JA3,
+		Ja3Hash:                   d.JA3Hash,
+		Ja4:                       d.JA4,
+		Alpn:                      d.ALPN,
+		ClientCertificateVerified: d.ClientCertificateVerified,
 	}
+
+	for _, group := range d.SupportedGroups {
+		entity.SupportedGroups = append(entity.SupportedGroups, uint32(group))
+	}
+
+	for _, cert := range d.ClientCertificates {
+		entity.ClientCertificates = append(entity.ClientCertificates, cert.ProtoMessage())
+	}
+
+	return entity
 }
@@ -0,0 +1,81 @@
+package audit_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+)
+
+func TestNewTLSDetailsFromState(t *testing.T) {
+	t.Parallel()
+	clientCert := test.NewClientCA(t).ClientCert(t, "device-42")
+	leaf, err := x509.ParseCertificate(clientCert.Certificate[0])
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		state tls.ConnectionState
+		want  any
+	}{
+		{
+			name: "Without client certificate",
+			state: tls.ConnectionState{
+				Version:     tls.VersionTLS13,
+				CipherSuite: tls.TLS_AES_128_GCM_SHA256,
+				ServerName:  "www.example.com",
+			},
+			want: &audit.TLSDetails{
+				Version:     "TLS_VERSION_TLS13",
+				CipherSuite: "TLS_AES_128_GCM_SHA256",
+				ServerName:  "www.example.com",
+			},
+		},
+		{
+			name: "Unverified client certificate",
+			state: tls.ConnectionState{
+				Version:          tls.VersionTLS13,
+				PeerCertificates: []*x509.Certificate{leaf},
+			},
+			want: td.Struct(new(audit.TLSDetails), td.StructFields{
+				"ClientCertificates": td.Bag(td.Struct(audit.Certificate{
+					SerialNumber: leaf.SerialNumber.String(),
+				}, td.StructFields{
+					"Subject":           td.HasPrefix("CN=device-42,"),
+					"Issuer":            td.HasPrefix("CN=INetMock Test Client CA,"),
+					"FingerprintSHA256": td.Re(`^[0-9a-f]{64}$`),
+					"NotBefore":         leaf.NotBefore.UTC(),
+					"NotAfter":          leaf.NotAfter.UTC(),
+				})),
+				"ClientCertificateVerified": false,
+			}),
+		},
+		{
+			name: "Verified client certificate",
+			state: tls.ConnectionState{
+				Version:          tls.VersionTLS13,
+				PeerCertificates: []*x509.Certificate{leaf},
+				VerifiedChains:   [][]*x509.Certificate{{leaf}},
+			},
+			want: td.Struct(new(audit.TLSDetails), td.StructFields{
+				"ClientCertificates":        td.Len(1),
+				"ClientCertificateVerified": true,
+			}),
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := audit.NewTLSDetailsFromState(tt.state)
+			td.Cmp(t, got, tt.want)
+			td.Cmp(t, audit.NewTLSDetailsFromProto(got.ProtoMessage()), got)
+		})
+	}
+}
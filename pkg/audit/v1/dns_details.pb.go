@@ -89,8 +89,10 @@ const (
 	ResourceRecordType_RESOURCE_RECORD_TYPE_TXT         ResourceRecordType = 16
 	ResourceRecordType_RESOURCE_RECORD_TYPE_RP          ResourceRecordType = 17
 	ResourceRecordType_RESOURCE_RECORD_TYPE_AAAA        ResourceRecordType = 28
-	ResourceRecordType_RESOURCE_RECORD_TYPE_SRV         ResourceRecordType = 33
-	ResourceRecordType_RESOURCE_RECORD_TYPE_NAPTR       ResourceRecordType = 35
+	// NetBIOS general name service resource record, only used by NBNS
+	ResourceRecordType_RESOURCE_RECORD_TYPE_NB    ResourceRecordType = 32
+	ResourceRecordType_RESOURCE_RECORD_TYPE_SRV   ResourceRecordType = 33
+	ResourceRecordType_RESOURCE_RECORD_TYPE_NAPTR ResourceRecordType = 35
 )
 
 // Enum value maps for ResourceRecordType.
@@ -108,6 +110,7 @@ var (
 		16: "RESOURCE_RECORD_TYPE_TXT",
 		17: "RESOURCE_RECORD_TYPE_RP",
 		28: "RESOURCE_RECORD_TYPE_AAAA",
+		32: "RESOURCE_RECORD_TYPE_NB",
 		33: "RESOURCE_RECORD_TYPE_SRV",
 		35: "RESOURCE_RECORD_TYPE_NAPTR",
 	}
@@ -124,6 +127,7 @@ var (
 		"RESOURCE_RECORD_TYPE_TXT":         16,
 		"RESOURCE_RECORD_TYPE_RP":          17,
 		"RESOURCE_RECORD_TYPE_AAAA":        28,
+		"RESOURCE_RECORD_TYPE_NB":          32,
 		"RESOURCE_RECORD_TYPE_SRV":         33,
 		"RESOURCE_RECORD_TYPE_NAPTR":       35,
 	}
@@ -293,7 +297,7 @@ var file_audit_v1_dns_details_proto_rawDesc = []byte{
 	0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x02, 0x12, 0x16,
 	0x0a, 0x12, 0x44, 0x4e, 0x53, 0x5f, 0x4f, 0x50, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f,
 	0x54, 0x49, 0x46, 0x59, 0x10, 0x04, 0x12, 0x16, 0x0a, 0x12, 0x44, 0x4e, 0x53, 0x5f, 0x4f, 0x50,
-	0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x05, 0x2a, 0xc4,
+	0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x05, 0x2a, 0xe1,
 	0x03, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72,
 	0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43,
 	0x45, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e,
@@ -318,24 +322,25 @@ var file_audit_v1_dns_details_proto_rawDesc = []byte{
 	0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x54,
 	0x59, 0x50, 0x45, 0x5f, 0x52, 0x50, 0x10, 0x11, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x53, 0x4f,
 	0x55, 0x52, 0x43, 0x45, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x41, 0x41, 0x41, 0x41, 0x10, 0x1c, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x53, 0x4f, 0x55,
+	0x5f, 0x41, 0x41, 0x41, 0x41, 0x10, 0x1c, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x53, 0x4f, 0x55,
 	0x52, 0x43, 0x45, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x53, 0x52, 0x56, 0x10, 0x21, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43,
-	0x45, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x41,
-	0x50, 0x54, 0x52, 0x10, 0x23, 0x42, 0xc3, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e,
-	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42,
-	0x0f, 0x44, 0x6e, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x48, 0x02, 0x50, 0x01, 0x5a, 0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x69,
-	0x63, 0x62, 0x34, 0x64, 0x63, 0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f,
-	0x63, 0x6b, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x3b,
-	0x61, 0x75, 0x64, 0x69, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41, 0x58, 0xaa, 0x02, 0x11,
-	0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x56,
-	0x31, 0xca, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64,
-	0x69, 0x74, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
-	0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
-	0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x4e, 0x42, 0x10, 0x20, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45,
+	0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x52, 0x56,
+	0x10, 0x21, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x52,
+	0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x41, 0x50, 0x54, 0x52,
+	0x10, 0x23, 0x42, 0xc3, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42, 0x0f, 0x44, 0x6e,
+	0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x02, 0x50,
+	0x01, 0x5a, 0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x69, 0x63, 0x62, 0x34,
+	0x64, 0x63, 0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41, 0x58, 0xaa, 0x02, 0x11, 0x49, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x31, 0xca, 0x02,
+	0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c,
+	0x56, 0x31, 0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x3a, 0x3a, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
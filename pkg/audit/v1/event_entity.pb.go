@@ -74,34 +74,118 @@ func (TransportProtocol) EnumDescriptor() ([]byte, []int) {
 type AppProtocol int32
 
 const (
-	AppProtocol_APP_PROTOCOL_UNSPECIFIED    AppProtocol = 0
-	AppProtocol_APP_PROTOCOL_DNS            AppProtocol = 1
-	AppProtocol_APP_PROTOCOL_HTTP           AppProtocol = 2
-	AppProtocol_APP_PROTOCOL_HTTP_PROXY     AppProtocol = 3
-	AppProtocol_APP_PROTOCOL_PPROF          AppProtocol = 4
-	AppProtocol_APP_PROTOCOL_DNS_OVER_HTTPS AppProtocol = 5
-	AppProtocol_APP_PROTOCOL_DHCP           AppProtocol = 6
+	AppProtocol_APP_PROTOCOL_UNSPECIFIED     AppProtocol = 0
+	AppProtocol_APP_PROTOCOL_DNS             AppProtocol = 1
+	AppProtocol_APP_PROTOCOL_HTTP            AppProtocol = 2
+	AppProtocol_APP_PROTOCOL_HTTP_PROXY      AppProtocol = 3
+	AppProtocol_APP_PROTOCOL_PPROF           AppProtocol = 4
+	AppProtocol_APP_PROTOCOL_DNS_OVER_HTTPS  AppProtocol = 5
+	AppProtocol_APP_PROTOCOL_DHCP            AppProtocol = 6
+	AppProtocol_APP_PROTOCOL_SMTP            AppProtocol = 7
+	AppProtocol_APP_PROTOCOL_POP3            AppProtocol = 8
+	AppProtocol_APP_PROTOCOL_IMAP            AppProtocol = 9
+	AppProtocol_APP_PROTOCOL_FTP             AppProtocol = 10
+	AppProtocol_APP_PROTOCOL_TFTP            AppProtocol = 11
+	AppProtocol_APP_PROTOCOL_NTP             AppProtocol = 12
+	AppProtocol_APP_PROTOCOL_RAW             AppProtocol = 13
+	AppProtocol_APP_PROTOCOL_ECHO            AppProtocol = 14
+	AppProtocol_APP_PROTOCOL_DISCARD         AppProtocol = 15
+	AppProtocol_APP_PROTOCOL_DAYTIME         AppProtocol = 16
+	AppProtocol_APP_PROTOCOL_QUOTD           AppProtocol = 17
+	AppProtocol_APP_PROTOCOL_CHARGEN         AppProtocol = 18
+	AppProtocol_APP_PROTOCOL_TIME            AppProtocol = 19
+	AppProtocol_APP_PROTOCOL_FINGER          AppProtocol = 20
+	AppProtocol_APP_PROTOCOL_IDENT           AppProtocol = 21
+	AppProtocol_APP_PROTOCOL_IRC             AppProtocol = 22
+	AppProtocol_APP_PROTOCOL_MQTT            AppProtocol = 23
+	AppProtocol_APP_PROTOCOL_SSH             AppProtocol = 24
+	AppProtocol_APP_PROTOCOL_TELNET          AppProtocol = 25
+	AppProtocol_APP_PROTOCOL_LLMNR           AppProtocol = 26
+	AppProtocol_APP_PROTOCOL_MDNS            AppProtocol = 27
+	AppProtocol_APP_PROTOCOL_NBNS            AppProtocol = 28
+	AppProtocol_APP_PROTOCOL_WEBSOCKET       AppProtocol = 29
+	AppProtocol_APP_PROTOCOL_GRPC            AppProtocol = 30
+	AppProtocol_APP_PROTOCOL_SYSLOG          AppProtocol = 31
+	AppProtocol_APP_PROTOCOL_LDAP            AppProtocol = 32
+	AppProtocol_APP_PROTOCOL_SNMP            AppProtocol = 33
+	AppProtocol_APP_PROTOCOL_TLS_PASSTHROUGH AppProtocol = 34
 )
 
 // Enum value maps for AppProtocol.
 var (
 	AppProtocol_name = map[int32]string{
-		0: "APP_PROTOCOL_UNSPECIFIED",
-		1: "APP_PROTOCOL_DNS",
-		2: "APP_PROTOCOL_HTTP",
-		3: "APP_PROTOCOL_HTTP_PROXY",
-		4: "APP_PROTOCOL_PPROF",
-		5: "APP_PROTOCOL_DNS_OVER_HTTPS",
-		6: "APP_PROTOCOL_DHCP",
+		0:  "APP_PROTOCOL_UNSPECIFIED",
+		1:  "APP_PROTOCOL_DNS",
+		2:  "APP_PROTOCOL_HTTP",
+		3:  "APP_PROTOCOL_HTTP_PROXY",
+		4:  "APP_PROTOCOL_PPROF",
+		5:  "APP_PROTOCOL_DNS_OVER_HTTPS",
+		6:  "APP_PROTOCOL_DHCP",
+		7:  "APP_PROTOCOL_SMTP",
+		8:  "APP_PROTOCOL_POP3",
+		9:  "APP_PROTOCOL_IMAP",
+		10: "APP_PROTOCOL_FTP",
+		11: "APP_PROTOCOL_TFTP",
+		12: "APP_PROTOCOL_NTP",
+		13: "APP_PROTOCOL_RAW",
+		14: "APP_PROTOCOL_ECHO",
+		15: "APP_PROTOCOL_DISCARD",
+		16: "APP_PROTOCOL_DAYTIME",
+		17: "APP_PROTOCOL_QUOTD",
+		18: "APP_PROTOCOL_CHARGEN",
+		19: "APP_PROTOCOL_TIME",
+		20: "APP_PROTOCOL_FINGER",
+		21: "APP_PROTOCOL_IDENT",
+		22: "APP_PROTOCOL_IRC",
+		23: "APP_PROTOCOL_MQTT",
+		24: "APP_PROTOCOL_SSH",
+		25: "APP_PROTOCOL_TELNET",
+		26: "APP_PROTOCOL_LLMNR",
+		27: "APP_PROTOCOL_MDNS",
+		28: "APP_PROTOCOL_NBNS",
+		29: "APP_PROTOCOL_WEBSOCKET",
+		30: "APP_PROTOCOL_GRPC",
+		31: "APP_PROTOCOL_SYSLOG",
+		32: "APP_PROTOCOL_LDAP",
+		33: "APP_PROTOCOL_SNMP",
+		34: "APP_PROTOCOL_TLS_PASSTHROUGH",
 	}
 	AppProtocol_value = map[string]int32{
-		"APP_PROTOCOL_UNSPECIFIED":    0,
-		"APP_PROTOCOL_DNS":            1,
-		"APP_PROTOCOL_HTTP":           2,
-		"APP_PROTOCOL_HTTP_PROXY":     3,
-		"APP_PROTOCOL_PPROF":          4,
-		"APP_PROTOCOL_DNS_OVER_HTTPS": 5,
-		"APP_PROTOCOL_DHCP":           6,
+		"APP_PROTOCOL_UNSPECIFIED":     0,
+		"APP_PROTOCOL_DNS":             1,
+		"APP_PROTOCOL_HTTP":            2,
+		"APP_PROTOCOL_HTTP_PROXY":      3,
+		"APP_PROTOCOL_PPROF":           4,
+		"APP_PROTOCOL_DNS_OVER_HTTPS":  5,
+		"APP_PROTOCOL_DHCP":            6,
+		"APP_PROTOCOL_SMTP":            7,
+		"APP_PROTOCOL_POP3":            8,
+		"APP_PROTOCOL_IMAP":            9,
+		"APP_PROTOCOL_FTP":             10,
+		"APP_PROTOCOL_TFTP":            11,
+		"APP_PROTOCOL_NTP":             12,
+		"APP_PROTOCOL_RAW":             13,
+		"APP_PROTOCOL_ECHO":            14,
+		"APP_PROTOCOL_DISCARD":         15,
+		"APP_PROTOCOL_DAYTIME":         16,
+		"APP_PROTOCOL_QUOTD":           17,
+		"APP_PROTOCOL_CHARGEN":         18,
+		"APP_PROTOCOL_TIME":            19,
+		"APP_PROTOCOL_FINGER":          20,
+		"APP_PROTOCOL_IDENT":           21,
+		"APP_PROTOCOL_IRC":             22,
+		"APP_PROTOCOL_MQTT":            23,
+		"APP_PROTOCOL_SSH":             24,
+		"APP_PROTOCOL_TELNET":          25,
+		"APP_PROTOCOL_LLMNR":           26,
+		"APP_PROTOCOL_MDNS":            27,
+		"APP_PROTOCOL_NBNS":            28,
+		"APP_PROTOCOL_WEBSOCKET":       29,
+		"APP_PROTOCOL_GRPC":            30,
+		"APP_PROTOCOL_SYSLOG":          31,
+		"APP_PROTOCOL_LDAP":            32,
+		"APP_PROTOCOL_SNMP":            33,
+		"APP_PROTOCOL_TLS_PASSTHROUGH": 34,
 	}
 )
 
@@ -195,6 +279,19 @@ type TLSDetailsEntity struct {
 	Version     TLSVersion `protobuf:"varint,1,opt,name=version,proto3,enum=inetmock.audit.v1.TLSVersion" json:"version,omitempty"`
 	CipherSuite string     `protobuf:"bytes,2,opt,name=cipher_suite,json=cipherSuite,proto3" json:"cipher_suite,omitempty"`
 	ServerName  string     `protobuf:"bytes,3,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	// JA3 fingerprint of the ClientHello and its MD5 hash, only set if the connection was terminated by an endpoint
+	Ja3     string `protobuf:"bytes,4,opt,name=ja3,proto3" json:"ja3,omitempty"`
+	Ja3Hash string `protobuf:"bytes,5,opt,name=ja3_hash,json=ja3Hash,proto3" json:"ja3_hash,omitempty"`
+	// JA4 fingerprint of the ClientHello e.g. t13d1516h2_8daaf6152771_e5627efa2ab1
+	Ja4 string `protobuf:"bytes,6,opt,name=ja4,proto3" json:"ja4,omitempty"`
+	// protocols offered by the client via ALPN in the order of preference
+	Alpn []string `protobuf:"bytes,7,rep,name=alpn,proto3" json:"alpn,omitempty"`
+	// named groups (elliptic curves) supported by the client, GREASE values are omitted
+	SupportedGroups []uint32 `protobuf:"varint,8,rep,packed,name=supported_groups,json=supportedGroups,proto3" json:"supported_groups,omitempty"`
+	// certificate chain presented by the client, the leaf certificate comes first
+	ClientCertificates []*CertificateEntity `protobuf:"bytes,9,rep,name=client_certificates,json=clientCertificates,proto3" json:"client_certificates,omitempty"`
+	// whether the client certificate was verified against the CA pool configured for the endpoint
+	ClientCertificateVerified bool `protobuf:"varint,10,opt,name=client_certificate_verified,json=clientCertificateVerified,proto3" json:"client_certificate_verified,omitempty"`
 }
 
 func (x *TLSDetailsEntity) Reset() {
@@ -250,6 +347,159 @@ func (x *TLSDetailsEntity) GetServerName() string {
 	return ""
 }
 
+func (x *TLSDetailsEntity) GetJa3() string {
+	if x != nil {
+		return x.Ja3
+	}
+	return ""
+}
+
+func (x *TLSDetailsEntity) GetJa3Hash() string {
+	if x != nil {
+		return x.Ja3Hash
+	}
+	return ""
+}
+
+func (x *TLSDetailsEntity) GetJa4() string {
+	if x != nil {
+		return x.Ja4
+	}
+	return ""
+}
+
+func (x *TLSDetailsEntity) GetAlpn() []string {
+	if x != nil {
+		return x.Alpn
+	}
+	return nil
+}
+
+func (x *TLSDetailsEntity) GetSupportedGroups() []uint32 {
+	if x != nil {
+		return x.SupportedGroups
+	}
+	return nil
+}
+
+func (x *TLSDetailsEntity) GetClientCertificates() []*CertificateEntity {
+	if x != nil {
+		return x.ClientCertificates
+	}
+	return nil
+}
+
+func (x *TLSDetailsEntity) GetClientCertificateVerified() bool {
+	if x != nil {
+		return x.ClientCertificateVerified
+	}
+	return false
+}
+
+type CertificateEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subject      string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Issuer       string `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	SerialNumber string `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// hex encoded SHA-256 hash of the DER encoded certificate
+	Sha256Fingerprint string                 `protobuf:"bytes,4,opt,name=sha256_fingerprint,json=sha256Fingerprint,proto3" json:"sha256_fingerprint,omitempty"`
+	NotBefore         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	DnsNames          []string               `protobuf:"bytes,7,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	EmailAddresses    []string               `protobuf:"bytes,8,rep,name=email_addresses,json=emailAddresses,proto3" json:"email_addresses,omitempty"`
+}
+
+func (x *CertificateEntity) Reset() {
+	*x = CertificateEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_event_entity_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CertificateEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CertificateEntity) ProtoMessage() {}
+
+func (x *CertificateEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_event_entity_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CertificateEntity.ProtoReflect.Descriptor instead.
+func (*CertificateEntity) Descriptor() ([]byte, []int) {
+	return file_audit_v1_event_entity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CertificateEntity) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *CertificateEntity) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *CertificateEntity) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *CertificateEntity) GetSha256Fingerprint() string {
+	if x != nil {
+		return x.Sha256Fingerprint
+	}
+	return ""
+}
+
+func (x *CertificateEntity) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *CertificateEntity) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+func (x *CertificateEntity) GetDnsNames() []string {
+	if x != nil {
+		return x.DnsNames
+	}
+	return nil
+}
+
+func (x *CertificateEntity) GetEmailAddresses() []string {
+	if x != nil {
+		return x.EmailAddresses
+	}
+	return nil
+}
+
 type EventEntity struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -269,13 +519,29 @@ type EventEntity struct {
 	//	*EventEntity_Dns
 	//	*EventEntity_Dhcp
 	//	*EventEntity_NetMon
+	//	*EventEntity_Smtp
+	//	*EventEntity_Mailbox
+	//	*EventEntity_Ftp
+	//	*EventEntity_Tftp
+	//	*EventEntity_Ntp
+	//	*EventEntity_Raw
+	//	*EventEntity_SmallService
+	//	*EventEntity_Irc
+	//	*EventEntity_Mqtt
+	//	*EventEntity_Shell
+	//	*EventEntity_WebSocket
+	//	*EventEntity_Grpc
+	//	*EventEntity_Syslog
+	//	*EventEntity_Ldap
+	//	*EventEntity_Snmp
+	//	*EventEntity_Passthrough
 	ProtocolDetails isEventEntity_ProtocolDetails `protobuf_oneof:"protocol_details"`
 }
 
 func (x *EventEntity) Reset() {
 	*x = EventEntity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_audit_v1_event_entity_proto_msgTypes[1]
+		mi := &file_audit_v1_event_entity_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -288,7 +554,7 @@ func (x *EventEntity) String() string {
 func (*EventEntity) ProtoMessage() {}
 
 func (x *EventEntity) ProtoReflect() protoreflect.Message {
-	mi := &file_audit_v1_event_entity_proto_msgTypes[1]
+	mi := &file_audit_v1_event_entity_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +567,7 @@ func (x *EventEntity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventEntity.ProtoReflect.Descriptor instead.
 func (*EventEntity) Descriptor() ([]byte, []int) {
-	return file_audit_v1_event_entity_proto_rawDescGZIP(), []int{1}
+	return file_audit_v1_event_entity_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *EventEntity) GetId() int64 {
@@ -402,6 +668,118 @@ func (x *EventEntity) GetNetMon() *NetMonDetailsEntity {
 	return nil
 }
 
+func (x *EventEntity) GetSmtp() *SMTPDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Smtp); ok {
+		return x.Smtp
+	}
+	return nil
+}
+
+func (x *EventEntity) GetMailbox() *MailboxDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Mailbox); ok {
+		return x.Mailbox
+	}
+	return nil
+}
+
+func (x *EventEntity) GetFtp() *FTPDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Ftp); ok {
+		return x.Ftp
+	}
+	return nil
+}
+
+func (x *EventEntity) GetTftp() *TFTPDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Tftp); ok {
+		return x.Tftp
+	}
+	return nil
+}
+
+func (x *EventEntity) GetNtp() *NTPDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Ntp); ok {
+		return x.Ntp
+	}
+	return nil
+}
+
+func (x *EventEntity) GetRaw() *RawDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Raw); ok {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *EventEntity) GetSmallService() *SmallServiceDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_SmallService); ok {
+		return x.SmallService
+	}
+	return nil
+}
+
+func (x *EventEntity) GetIrc() *IRCDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Irc); ok {
+		return x.Irc
+	}
+	return nil
+}
+
+func (x *EventEntity) GetMqtt() *MQTTDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Mqtt); ok {
+		return x.Mqtt
+	}
+	return nil
+}
+
+func (x *EventEntity) GetShell() *ShellDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Shell); ok {
+		return x.Shell
+	}
+	return nil
+}
+
+func (x *EventEntity) GetWebSocket() *WebSocketDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_WebSocket); ok {
+		return x.WebSocket
+	}
+	return nil
+}
+
+func (x *EventEntity) GetGrpc() *GRPCDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Grpc); ok {
+		return x.Grpc
+	}
+	return nil
+}
+
+func (x *EventEntity) GetSyslog() *SyslogDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Syslog); ok {
+		return x.Syslog
+	}
+	return nil
+}
+
+func (x *EventEntity) GetLdap() *LDAPDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Ldap); ok {
+		return x.Ldap
+	}
+	return nil
+}
+
+func (x *EventEntity) GetSnmp() *SNMPDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Snmp); ok {
+		return x.Snmp
+	}
+	return nil
+}
+
+func (x *EventEntity) GetPassthrough() *PassthroughDetailsEntity {
+	if x, ok := x.GetProtocolDetails().(*EventEntity_Passthrough); ok {
+		return x.Passthrough
+	}
+	return nil
+}
+
 type isEventEntity_ProtocolDetails interface {
 	isEventEntity_ProtocolDetails()
 }
@@ -422,6 +800,70 @@ type EventEntity_NetMon struct {
 	NetMon *NetMonDetailsEntity `protobuf:"bytes,23,opt,name=net_mon,json=netMon,proto3,oneof"`
 }
 
+type EventEntity_Smtp struct {
+	Smtp *SMTPDetailsEntity `protobuf:"bytes,24,opt,name=smtp,proto3,oneof"`
+}
+
+type EventEntity_Mailbox struct {
+	Mailbox *MailboxDetailsEntity `protobuf:"bytes,25,opt,name=mailbox,proto3,oneof"`
+}
+
+type EventEntity_Ftp struct {
+	Ftp *FTPDetailsEntity `protobuf:"bytes,26,opt,name=ftp,proto3,oneof"`
+}
+
+type EventEntity_Tftp struct {
+	Tftp *TFTPDetailsEntity `protobuf:"bytes,27,opt,name=tftp,proto3,oneof"`
+}
+
+type EventEntity_Ntp struct {
+	Ntp *NTPDetailsEntity `protobuf:"bytes,28,opt,name=ntp,proto3,oneof"`
+}
+
+type EventEntity_Raw struct {
+	Raw *RawDetailsEntity `protobuf:"bytes,29,opt,name=raw,proto3,oneof"`
+}
+
+type EventEntity_SmallService struct {
+	SmallService *SmallServiceDetailsEntity `protobuf:"bytes,30,opt,name=small_service,json=smallService,proto3,oneof"`
+}
+
+type EventEntity_Irc struct {
+	Irc *IRCDetailsEntity `protobuf:"bytes,31,opt,name=irc,proto3,oneof"`
+}
+
+type EventEntity_Mqtt struct {
+	Mqtt *MQTTDetailsEntity `protobuf:"bytes,32,opt,name=mqtt,proto3,oneof"`
+}
+
+type EventEntity_Shell struct {
+	Shell *ShellDetailsEntity `protobuf:"bytes,33,opt,name=shell,proto3,oneof"`
+}
+
+type EventEntity_WebSocket struct {
+	WebSocket *WebSocketDetailsEntity `protobuf:"bytes,34,opt,name=web_socket,json=webSocket,proto3,oneof"`
+}
+
+type EventEntity_Grpc struct {
+	Grpc *GRPCDetailsEntity `protobuf:"bytes,35,opt,name=grpc,proto3,oneof"`
+}
+
+type EventEntity_Syslog struct {
+	Syslog *SyslogDetailsEntity `protobuf:"bytes,36,opt,name=syslog,proto3,oneof"`
+}
+
+type EventEntity_Ldap struct {
+	Ldap *LDAPDetailsEntity `protobuf:"bytes,37,opt,name=ldap,proto3,oneof"`
+}
+
+type EventEntity_Snmp struct {
+	Snmp *SNMPDetailsEntity `protobuf:"bytes,38,opt,name=snmp,proto3,oneof"`
+}
+
+type EventEntity_Passthrough struct {
+	Passthrough *PassthroughDetailsEntity `protobuf:"bytes,39,opt,name=passthrough,proto3,oneof"`
+}
+
 func (*EventEntity_Http) isEventEntity_ProtocolDetails() {}
 
 func (*EventEntity_Dns) isEventEntity_ProtocolDetails() {}
@@ -430,6 +872,38 @@ func (*EventEntity_Dhcp) isEventEntity_ProtocolDetails() {}
 
 func (*EventEntity_NetMon) isEventEntity_ProtocolDetails() {}
 
+func (*EventEntity_Smtp) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Mailbox) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Ftp) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Tftp) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Ntp) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Raw) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_SmallService) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Irc) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Mqtt) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Shell) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_WebSocket) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Grpc) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Syslog) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Ldap) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Snmp) isEventEntity_ProtocolDetails() {}
+
+func (*EventEntity_Passthrough) isEventEntity_ProtocolDetails() {}
+
 var File_audit_v1_event_entity_proto protoreflect.FileDescriptor
 
 var file_audit_v1_event_entity_proto_rawDesc = []byte{
@@ -445,100 +919,275 @@ var file_audit_v1_event_entity_proto_rawDesc = []byte{
 	0x74, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x68, 0x63, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
 	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1d, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76,
 	0x31, 0x2f, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8f, 0x01, 0x0a, 0x10, 0x54, 0x4c, 0x53, 0x44, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x37, 0x0a, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x69,
-	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x54, 0x4c, 0x53, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x5f, 0x73,
-	0x75, 0x69, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x69, 0x70, 0x68,
-	0x65, 0x72, 0x53, 0x75, 0x69, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xac, 0x05, 0x0a, 0x0b, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x12, 0x42, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
-	0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70,
-	0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x09, 0x74, 0x72, 0x61,
-	0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x40, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x69, 0x6e,
-	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e,
-	0x41, 0x70, 0x70, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x0b, 0x61, 0x70, 0x70,
-	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x49, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x64,
-	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x70, 0x12, 0x1f, 0x0a, 0x0b,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x29, 0x0a,
-	0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x72,
-	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x35, 0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
-	0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x4c, 0x53, 0x44, 0x65, 0x74,
-	0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x12,
-	0x3a, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31,
+	0x2f, 0x73, 0x6d, 0x74, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x61,
+	0x69, 0x6c, 0x62, 0x6f, 0x78, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x74,
+	0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x66, 0x74, 0x70, 0x5f, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x74, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f,
+	0x76, 0x31, 0x2f, 0x72, 0x61, 0x77, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x24, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x73,
+	0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x72, 0x63, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31,
+	0x2f, 0x6d, 0x71, 0x74, 0x74, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x68,
+	0x65, 0x6c, 0x6c, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x20, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x77, 0x65, 0x62, 0x73,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72,
+	0x70, 0x63, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1d, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x79, 0x73, 0x6c, 0x6f,
+	0x67, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x64, 0x61, 0x70, 0x5f, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x6e, 0x6d, 0x70, 0x5f, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x22, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x2f, 0x76, 0x31, 0x2f, 0x70, 0x61, 0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x5f,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa4, 0x03,
+	0x0a, 0x10, 0x54, 0x4c, 0x53, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x37, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x4c, 0x53, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x63,
+	0x69, 0x70, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x69, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x53, 0x75, 0x69, 0x74, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x6a, 0x61, 0x33, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6a, 0x61,
+	0x33, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x61, 0x33, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x61, 0x33, 0x48, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03,
+	0x6a, 0x61, 0x34, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6a, 0x61, 0x34, 0x12, 0x12,
+	0x0a, 0x04, 0x61, 0x6c, 0x70, 0x6e, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x6c,
+	0x70, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0f, 0x73, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x55, 0x0a,
+	0x13, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x52, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x1b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x22, 0xd3, 0x02, 0x0a, 0x11, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x5f, 0x66, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73,
+	0x68, 0x61, 0x32, 0x35, 0x36, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x12, 0x39, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x6e,
+	0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x41,
+	0x66, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x6e, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6e, 0x73, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0xb0, 0x0d, 0x0a, 0x0b, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x42, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f,
+	0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x09, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x40, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e,
+	0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x70, 0x70, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x0b, 0x61,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x70, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x12,
+	0x29, 0x0a, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x35, 0x0a, 0x03, 0x74, 0x6c,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f,
+	0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x4c, 0x53, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x03, 0x74, 0x6c,
+	0x73, 0x12, 0x3a, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x37, 0x0a,
+	0x03, 0x64, 0x6e, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x4e, 0x53, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48,
+	0x00, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x04, 0x64, 0x68, 0x63, 0x70, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x48, 0x43, 0x50, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x64, 0x68,
+	0x63, 0x70, 0x12, 0x41, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x5f, 0x6d, 0x6f, 0x6e, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x06, 0x6e,
+	0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x04, 0x73, 0x6d, 0x74, 0x70, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x4d, 0x54, 0x50, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x73, 0x6d, 0x74,
+	0x70, 0x12, 0x43, 0x0a, 0x07, 0x6d, 0x61, 0x69, 0x6c, 0x62, 0x6f, 0x78, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x62, 0x6f, 0x78, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x07, 0x6d,
+	0x61, 0x69, 0x6c, 0x62, 0x6f, 0x78, 0x12, 0x37, 0x0a, 0x03, 0x66, 0x74, 0x70, 0x18, 0x1a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x54, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x03, 0x66, 0x74, 0x70, 0x12,
+	0x3a, 0x0a, 0x04, 0x74, 0x66, 0x74, 0x70, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
 	0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76,
-	0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x37, 0x0a, 0x03, 0x64,
-	0x6e, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d,
-	0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x4e, 0x53,
+	0x31, 0x2e, 0x54, 0x46, 0x54, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x74, 0x66, 0x74, 0x70, 0x12, 0x37, 0x0a, 0x03, 0x6e,
+	0x74, 0x70, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x54, 0x50,
 	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52,
-	0x03, 0x64, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x04, 0x64, 0x68, 0x63, 0x70, 0x18, 0x16, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75,
-	0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x48, 0x43, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69,
-	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x64, 0x68, 0x63, 0x70,
-	0x12, 0x41, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x5f, 0x6d, 0x6f, 0x6e, 0x18, 0x17, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x26, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64,
-	0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x74, 0x4d, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61,
-	0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x65, 0x74,
-	0x4d, 0x6f, 0x6e, 0x42, 0x12, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f,
-	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2a, 0x6f, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x22, 0x0a, 0x1e,
-	0x54, 0x52, 0x41, 0x4e, 0x53, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43,
-	0x4f, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
-	0x12, 0x1a, 0x0a, 0x16, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x50, 0x52,
-	0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x54, 0x43, 0x50, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16,
-	0x54, 0x52, 0x41, 0x4e, 0x53, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43,
-	0x4f, 0x4c, 0x5f, 0x55, 0x44, 0x50, 0x10, 0x02, 0x2a, 0xc5, 0x01, 0x0a, 0x0b, 0x41, 0x70, 0x70,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x50, 0x50, 0x5f,
-	0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
-	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52,
-	0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x44, 0x4e, 0x53, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11,
-	0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x48, 0x54, 0x54,
-	0x50, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f,
-	0x43, 0x4f, 0x4c, 0x5f, 0x48, 0x54, 0x54, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x58, 0x59, 0x10, 0x03,
-	0x12, 0x16, 0x0a, 0x12, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c,
-	0x5f, 0x50, 0x50, 0x52, 0x4f, 0x46, 0x10, 0x04, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x50, 0x50, 0x5f,
-	0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x44, 0x4e, 0x53, 0x5f, 0x4f, 0x56, 0x45,
-	0x52, 0x5f, 0x48, 0x54, 0x54, 0x50, 0x53, 0x10, 0x05, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50,
-	0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x44, 0x48, 0x43, 0x50, 0x10, 0x06,
-	0x2a, 0x85, 0x01, 0x0a, 0x0a, 0x54, 0x4c, 0x53, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x1b, 0x0a, 0x17, 0x54, 0x4c, 0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x55,
-	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11,
-	0x54, 0x4c, 0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4c, 0x53, 0x31,
-	0x30, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4c, 0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49,
-	0x4f, 0x4e, 0x5f, 0x54, 0x4c, 0x53, 0x31, 0x31, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4c,
-	0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4c, 0x53, 0x31, 0x32, 0x10,
-	0x03, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4c, 0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e,
-	0x5f, 0x54, 0x4c, 0x53, 0x31, 0x33, 0x10, 0x04, 0x42, 0xc4, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d,
+	0x03, 0x6e, 0x74, 0x70, 0x12, 0x37, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x1d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x53, 0x0a,
+	0x0d, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x1e,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6d, 0x61, 0x6c, 0x6c, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x37, 0x0a, 0x03, 0x69, 0x72, 0x63, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x52, 0x43, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x03, 0x69, 0x72, 0x63, 0x12, 0x3a, 0x0a, 0x04, 0x6d,
+	0x71, 0x74, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x65, 0x74,
+	0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x51,
+	0x54, 0x54, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48,
+	0x00, 0x52, 0x04, 0x6d, 0x71, 0x74, 0x74, 0x12, 0x3d, 0x0a, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x6c,
+	0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
+	0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52,
+	0x05, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x12, 0x4a, 0x0a, 0x0a, 0x77, 0x65, 0x62, 0x5f, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x57,
+	0x65, 0x62, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x09, 0x77, 0x65, 0x62, 0x53, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x3a, 0x0a, 0x04, 0x67, 0x72, 0x70, 0x63, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x52, 0x50, 0x43, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x67, 0x72, 0x70, 0x63, 0x12, 0x40,
+	0x0a, 0x06, 0x73, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x06, 0x73, 0x79, 0x73, 0x6c, 0x6f, 0x67,
+	0x12, 0x3a, 0x0a, 0x04, 0x6c, 0x64, 0x61, 0x70, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
 	0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e,
-	0x76, 0x31, 0x42, 0x10, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x48, 0x02, 0x50, 0x01, 0x5a, 0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f,
-	0x63, 0x6b, 0x2e, 0x69, 0x63, 0x62, 0x34, 0x64, 0x63, 0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e,
-	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74,
-	0x2f, 0x76, 0x31, 0x3b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41,
-	0x58, 0xaa, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64,
-	0x69, 0x74, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
-	0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74,
-	0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50,
-	0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74,
-	0x6d, 0x6f, 0x63, 0x6b, 0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x76, 0x31, 0x2e, 0x4c, 0x44, 0x41, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x6c, 0x64, 0x61, 0x70, 0x12, 0x3a, 0x0a, 0x04,
+	0x73, 0x6e, 0x6d, 0x70, 0x18, 0x26, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x4e, 0x4d, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x48, 0x00, 0x52, 0x04, 0x73, 0x6e, 0x6d, 0x70, 0x12, 0x4f, 0x0a, 0x0b, 0x70, 0x61, 0x73, 0x73,
+	0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x61,
+	0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x42, 0x12, 0x0a, 0x10, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2a, 0x6f, 0x0a,
+	0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x12, 0x22, 0x0a, 0x1e, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x50, 0x4f, 0x52, 0x54, 0x5f,
+	0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x50,
+	0x4f, 0x52, 0x54, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x54, 0x43, 0x50,
+	0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x50, 0x4f, 0x52, 0x54, 0x5f,
+	0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x55, 0x44, 0x50, 0x10, 0x02, 0x2a, 0xe6,
+	0x06, 0x0a, 0x0b, 0x41, 0x70, 0x70, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1c,
+	0x0a, 0x18, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10,
+	0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x44, 0x4e, 0x53,
+	0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43,
+	0x4f, 0x4c, 0x5f, 0x48, 0x54, 0x54, 0x50, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x50, 0x50,
+	0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x48, 0x54, 0x54, 0x50, 0x5f, 0x50,
+	0x52, 0x4f, 0x58, 0x59, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52,
+	0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x50, 0x50, 0x52, 0x4f, 0x46, 0x10, 0x04, 0x12, 0x1f,
+	0x0a, 0x1b, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x44,
+	0x4e, 0x53, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x5f, 0x48, 0x54, 0x54, 0x50, 0x53, 0x10, 0x05, 0x12,
+	0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f,
+	0x44, 0x48, 0x43, 0x50, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52,
+	0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x53, 0x4d, 0x54, 0x50, 0x10, 0x07, 0x12, 0x15, 0x0a,
+	0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x50, 0x4f,
+	0x50, 0x33, 0x10, 0x08, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54,
+	0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x49, 0x4d, 0x41, 0x50, 0x10, 0x09, 0x12, 0x14, 0x0a, 0x10, 0x41,
+	0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x46, 0x54, 0x50, 0x10,
+	0x0a, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f,
+	0x4c, 0x5f, 0x54, 0x46, 0x54, 0x50, 0x10, 0x0b, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x50, 0x50, 0x5f,
+	0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x4e, 0x54, 0x50, 0x10, 0x0c, 0x12, 0x14,
+	0x0a, 0x10, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x52,
+	0x41, 0x57, 0x10, 0x0d, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54,
+	0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x45, 0x43, 0x48, 0x4f, 0x10, 0x0e, 0x12, 0x18, 0x0a, 0x14, 0x41,
+	0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x44, 0x49, 0x53, 0x43,
+	0x41, 0x52, 0x44, 0x10, 0x0f, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f,
+	0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x44, 0x41, 0x59, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x10, 0x12,
+	0x16, 0x0a, 0x12, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f,
+	0x51, 0x55, 0x4f, 0x54, 0x44, 0x10, 0x11, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x50, 0x50, 0x5f, 0x50,
+	0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x43, 0x48, 0x41, 0x52, 0x47, 0x45, 0x4e, 0x10,
+	0x12, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f,
+	0x4c, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x13, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x50, 0x50, 0x5f,
+	0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x46, 0x49, 0x4e, 0x47, 0x45, 0x52, 0x10,
+	0x14, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f,
+	0x4c, 0x5f, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x10, 0x15, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x50, 0x50,
+	0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x49, 0x52, 0x43, 0x10, 0x16, 0x12,
+	0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f,
+	0x4d, 0x51, 0x54, 0x54, 0x10, 0x17, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52,
+	0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x53, 0x53, 0x48, 0x10, 0x18, 0x12, 0x17, 0x0a, 0x13,
+	0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x54, 0x45, 0x4c,
+	0x4e, 0x45, 0x54, 0x10, 0x19, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f,
+	0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x4c, 0x4c, 0x4d, 0x4e, 0x52, 0x10, 0x1a, 0x12, 0x15, 0x0a,
+	0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x4d, 0x44,
+	0x4e, 0x53, 0x10, 0x1b, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54,
+	0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x4e, 0x42, 0x4e, 0x53, 0x10, 0x1c, 0x12, 0x1a, 0x0a, 0x16, 0x41,
+	0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x57, 0x45, 0x42, 0x53,
+	0x4f, 0x43, 0x4b, 0x45, 0x54, 0x10, 0x1d, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50,
+	0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x47, 0x52, 0x50, 0x43, 0x10, 0x1e, 0x12, 0x17,
+	0x0a, 0x13, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x53,
+	0x59, 0x53, 0x4c, 0x4f, 0x47, 0x10, 0x1f, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50,
+	0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x4c, 0x44, 0x41, 0x50, 0x10, 0x20, 0x12, 0x15,
+	0x0a, 0x11, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x53,
+	0x4e, 0x4d, 0x50, 0x10, 0x21, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x50, 0x50, 0x5f, 0x50, 0x52, 0x4f,
+	0x54, 0x4f, 0x43, 0x4f, 0x4c, 0x5f, 0x54, 0x4c, 0x53, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x54, 0x48,
+	0x52, 0x4f, 0x55, 0x47, 0x48, 0x10, 0x22, 0x2a, 0x85, 0x01, 0x0a, 0x0a, 0x54, 0x4c, 0x53, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x4c, 0x53, 0x5f, 0x56, 0x45,
+	0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4c, 0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49,
+	0x4f, 0x4e, 0x5f, 0x54, 0x4c, 0x53, 0x31, 0x30, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4c,
+	0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4c, 0x53, 0x31, 0x31, 0x10,
+	0x02, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4c, 0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e,
+	0x5f, 0x54, 0x4c, 0x53, 0x31, 0x32, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4c, 0x53, 0x5f,
+	0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4c, 0x53, 0x31, 0x33, 0x10, 0x04, 0x42,
+	0xc4, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x02, 0x50, 0x01, 0x5a,
+	0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x69, 0x63, 0x62, 0x34, 0x64, 0x63,
+	0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41, 0x58, 0xaa, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x11, 0x49,
+	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31,
+	0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x3a, 0x3a, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -554,34 +1203,70 @@ func file_audit_v1_event_entity_proto_rawDescGZIP() []byte {
 }
 
 var file_audit_v1_event_entity_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_audit_v1_event_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_audit_v1_event_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_audit_v1_event_entity_proto_goTypes = []interface{}{
-	(TransportProtocol)(0),        // 0: inetmock.audit.v1.TransportProtocol
-	(AppProtocol)(0),              // 1: inetmock.audit.v1.AppProtocol
-	(TLSVersion)(0),               // 2: inetmock.audit.v1.TLSVersion
-	(*TLSDetailsEntity)(nil),      // 3: inetmock.audit.v1.TLSDetailsEntity
-	(*EventEntity)(nil),           // 4: inetmock.audit.v1.EventEntity
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
-	(*HTTPDetailsEntity)(nil),     // 6: inetmock.audit.v1.HTTPDetailsEntity
-	(*DNSDetailsEntity)(nil),      // 7: inetmock.audit.v1.DNSDetailsEntity
-	(*DHCPDetailsEntity)(nil),     // 8: inetmock.audit.v1.DHCPDetailsEntity
-	(*NetMonDetailsEntity)(nil),   // 9: inetmock.audit.v1.NetMonDetailsEntity
+	(TransportProtocol)(0),            // 0: inetmock.audit.v1.TransportProtocol
+	(AppProtocol)(0),                  // 1: inetmock.audit.v1.AppProtocol
+	(TLSVersion)(0),                   // 2: inetmock.audit.v1.TLSVersion
+	(*TLSDetailsEntity)(nil),          // 3: inetmock.audit.v1.TLSDetailsEntity
+	(*CertificateEntity)(nil),         // 4: inetmock.audit.v1.CertificateEntity
+	(*EventEntity)(nil),               // 5: inetmock.audit.v1.EventEntity
+	(*timestamppb.Timestamp)(nil),     // 6: google.protobuf.Timestamp
+	(*HTTPDetailsEntity)(nil),         // 7: inetmock.audit.v1.HTTPDetailsEntity
+	(*DNSDetailsEntity)(nil),          // 8: inetmock.audit.v1.DNSDetailsEntity
+	(*DHCPDetailsEntity)(nil),         // 9: inetmock.audit.v1.DHCPDetailsEntity
+	(*NetMonDetailsEntity)(nil),       // 10: inetmock.audit.v1.NetMonDetailsEntity
+	(*SMTPDetailsEntity)(nil),         // 11: inetmock.audit.v1.SMTPDetailsEntity
+	(*MailboxDetailsEntity)(nil),      // 12: inetmock.audit.v1.MailboxDetailsEntity
+	(*FTPDetailsEntity)(nil),          // 13: inetmock.audit.v1.FTPDetailsEntity
+	(*TFTPDetailsEntity)(nil),         // 14: inetmock.audit.v1.TFTPDetailsEntity
+	(*NTPDetailsEntity)(nil),          // 15: inetmock.audit.v1.NTPDetailsEntity
+	(*RawDetailsEntity)(nil),          // 16: inetmock.audit.v1.RawDetailsEntity
+	(*SmallServiceDetailsEntity)(nil), // 17: inetmock.audit.v1.SmallServiceDetailsEntity
+	(*IRCDetailsEntity)(nil),          // 18: inetmock.audit.v1.IRCDetailsEntity
+	(*MQTTDetailsEntity)(nil),         // 19: inetmock.audit.v1.MQTTDetailsEntity
+	(*ShellDetailsEntity)(nil),        // 20: inetmock.audit.v1.ShellDetailsEntity
+	(*WebSocketDetailsEntity)(nil),    // 21: inetmock.audit.v1.WebSocketDetailsEntity
+	(*GRPCDetailsEntity)(nil),         // 22: inetmock.audit.v1.GRPCDetailsEntity
+	(*SyslogDetailsEntity)(nil),       // 23: inetmock.audit.v1.SyslogDetailsEntity
+	(*LDAPDetailsEntity)(nil),         // 24: inetmock.audit.v1.LDAPDetailsEntity
+	(*SNMPDetailsEntity)(nil),         // 25: inetmock.audit.v1.SNMPDetailsEntity
+	(*PassthroughDetailsEntity)(nil),  // 26: inetmock.audit.v1.PassthroughDetailsEntity
 }
 var file_audit_v1_event_entity_proto_depIdxs = []int32{
-	2, // 0: inetmock.audit.v1.TLSDetailsEntity.version:type_name -> inetmock.audit.v1.TLSVersion
-	5, // 1: inetmock.audit.v1.EventEntity.timestamp:type_name -> google.protobuf.Timestamp
-	0, // 2: inetmock.audit.v1.EventEntity.transport:type_name -> inetmock.audit.v1.TransportProtocol
-	1, // 3: inetmock.audit.v1.EventEntity.application:type_name -> inetmock.audit.v1.AppProtocol
-	3, // 4: inetmock.audit.v1.EventEntity.tls:type_name -> inetmock.audit.v1.TLSDetailsEntity
-	6, // 5: inetmock.audit.v1.EventEntity.http:type_name -> inetmock.audit.v1.HTTPDetailsEntity
-	7, // 6: inetmock.audit.v1.EventEntity.dns:type_name -> inetmock.audit.v1.DNSDetailsEntity
-	8, // 7: inetmock.audit.v1.EventEntity.dhcp:type_name -> inetmock.audit.v1.DHCPDetailsEntity
-	9, // 8: inetmock.audit.v1.EventEntity.net_mon:type_name -> inetmock.audit.v1.NetMonDetailsEntity
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	2,  // 0: inetmock.audit.v1.TLSDetailsEntity.version:type_name -> inetmock.audit.v1.TLSVersion
+	4,  // 1: inetmock.audit.v1.TLSDetailsEntity.client_certificates:type_name -> inetmock.audit.v1.CertificateEntity
+	6,  // 2: inetmock.audit.v1.CertificateEntity.not_before:type_name -> google.protobuf.Timestamp
+	6,  // 3: inetmock.audit.v1.CertificateEntity.not_after:type_name -> google.protobuf.Timestamp
+	6,  // 4: inetmock.audit.v1.EventEntity.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 5: inetmock.audit.v1.EventEntity.transport:type_name -> inetmock.audit.v1.TransportProtocol
+	1,  // 6: inetmock.audit.v1.EventEntity.application:type_name -> inetmock.audit.v1.AppProtocol
+	3,  // 7: inetmock.audit.v1.EventEntity.tls:type_name -> inetmock.audit.v1.TLSDetailsEntity
+	7,  // 8: inetmock.audit.v1.EventEntity.http:type_name -> inetmock.audit.v1.HTTPDetailsEntity
+	8,  // 9: inetmock.audit.v1.EventEntity.dns:type_name -> inetmock.audit.v1.DNSDetailsEntity
+	9,  // 10: inetmock.audit.v1.EventEntity.dhcp:type_name -> inetmock.audit.v1.DHCPDetailsEntity
+	10, // 11: inetmock.audit.v1.EventEntity.net_mon:type_name -> inetmock.audit.v1.NetMonDetailsEntity
+	11, // 12: inetmock.audit.v1.EventEntity.smtp:type_name -> inetmock.audit.v1.SMTPDetailsEntity
+	12, // 13: inetmock.audit.v1.EventEntity.mailbox:type_name -> inetmock.audit.v1.MailboxDetailsEntity
+	13, // 14: inetmock.audit.v1.EventEntity.ftp:type_name -> inetmock.audit.v1.FTPDetailsEntity
+	14, // 15: inetmock.audit.v1.EventEntity.tftp:type_name -> inetmock.audit.v1.TFTPDetailsEntity
+	15, // 16: inetmock.audit.v1.EventEntity.ntp:type_name -> inetmock.audit.v1.NTPDetailsEntity
+	16, // 17: inetmock.audit.v1.EventEntity.raw:type_name -> inetmock.audit.v1.RawDetailsEntity
+	17, // 18: inetmock.audit.v1.EventEntity.small_service:type_name -> inetmock.audit.v1.SmallServiceDetailsEntity
+	18, // 19: inetmock.audit.v1.EventEntity.irc:type_name -> inetmock.audit.v1.IRCDetailsEntity
+	19, // 20: inetmock.audit.v1.EventEntity.mqtt:type_name -> inetmock.audit.v1.MQTTDetailsEntity
+	20, // 21: inetmock.audit.v1.EventEntity.shell:type_name -> inetmock.audit.v1.ShellDetailsEntity
+	21, // 22: inetmock.audit.v1.EventEntity.web_socket:type_name -> inetmock.audit.v1.WebSocketDetailsEntity
+	22, // 23: inetmock.audit.v1.EventEntity.grpc:type_name -> inetmock.audit.v1.GRPCDetailsEntity
+	23, // 24: inetmock.audit.v1.EventEntity.syslog:type_name -> inetmock.audit.v1.SyslogDetailsEntity
+	24, // 25: inetmock.audit.v1.EventEntity.ldap:type_name -> inetmock.audit.v1.LDAPDetailsEntity
+	25, // 26: inetmock.audit.v1.EventEntity.snmp:type_name -> inetmock.audit.v1.SNMPDetailsEntity
+	26, // 27: inetmock.audit.v1.EventEntity.passthrough:type_name -> inetmock.audit.v1.PassthroughDetailsEntity
+	28, // [28:28] is the sub-list for method output_type
+	28, // [28:28] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_audit_v1_event_entity_proto_init() }
@@ -593,6 +1278,22 @@ func file_audit_v1_event_entity_proto_init() {
 	file_audit_v1_dns_details_proto_init()
 	file_audit_v1_dhcp_details_proto_init()
 	file_audit_v1_netmon_details_proto_init()
+	file_audit_v1_smtp_details_proto_init()
+	file_audit_v1_mailbox_details_proto_init()
+	file_audit_v1_ftp_details_proto_init()
+	file_audit_v1_tftp_details_proto_init()
+	file_audit_v1_ntp_details_proto_init()
+	file_audit_v1_raw_details_proto_init()
+	file_audit_v1_small_service_details_proto_init()
+	file_audit_v1_irc_details_proto_init()
+	file_audit_v1_mqtt_details_proto_init()
+	file_audit_v1_shell_details_proto_init()
+	file_audit_v1_websocket_details_proto_init()
+	file_audit_v1_grpc_details_proto_init()
+	file_audit_v1_syslog_details_proto_init()
+	file_audit_v1_ldap_details_proto_init()
+	file_audit_v1_snmp_details_proto_init()
+	file_audit_v1_passthrough_details_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_audit_v1_event_entity_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TLSDetailsEntity); i {
@@ -607,6 +1308,18 @@ func file_audit_v1_event_entity_proto_init() {
 			}
 		}
 		file_audit_v1_event_entity_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CertificateEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_audit_v1_event_entity_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EventEntity); i {
 			case 0:
 				return &v.state
@@ -619,11 +1332,27 @@ func file_audit_v1_event_entity_proto_init() {
 			}
 		}
 	}
-	file_audit_v1_event_entity_proto_msgTypes[1].OneofWrappers = []interface{}{
+	file_audit_v1_event_entity_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*EventEntity_Http)(nil),
 		(*EventEntity_Dns)(nil),
 		(*EventEntity_Dhcp)(nil),
 		(*EventEntity_NetMon)(nil),
+		(*EventEntity_Smtp)(nil),
+		(*EventEntity_Mailbox)(nil),
+		(*EventEntity_Ftp)(nil),
+		(*EventEntity_Tftp)(nil),
+		(*EventEntity_Ntp)(nil),
+		(*EventEntity_Raw)(nil),
+		(*EventEntity_SmallService)(nil),
+		(*EventEntity_Irc)(nil),
+		(*EventEntity_Mqtt)(nil),
+		(*EventEntity_Shell)(nil),
+		(*EventEntity_WebSocket)(nil),
+		(*EventEntity_Grpc)(nil),
+		(*EventEntity_Syslog)(nil),
+		(*EventEntity_Ldap)(nil),
+		(*EventEntity_Snmp)(nil),
+		(*EventEntity_Passthrough)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -631,7 +1360,7 @@ func file_audit_v1_event_entity_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_audit_v1_event_entity_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,407 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: audit/v1/ldap_details.proto
+
+package auditv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LDAPOperation int32
+
+const (
+	LDAPOperation_LDAP_OPERATION_UNSPECIFIED LDAPOperation = 0
+	LDAPOperation_LDAP_OPERATION_BIND        LDAPOperation = 1
+	LDAPOperation_LDAP_OPERATION_SEARCH      LDAPOperation = 2
+	LDAPOperation_LDAP_OPERATION_COMPARE     LDAPOperation = 3
+	LDAPOperation_LDAP_OPERATION_EXTENDED    LDAPOperation = 4
+	LDAPOperation_LDAP_OPERATION_MODIFY      LDAPOperation = 5
+	LDAPOperation_LDAP_OPERATION_ADD         LDAPOperation = 6
+	LDAPOperation_LDAP_OPERATION_DELETE      LDAPOperation = 7
+	LDAPOperation_LDAP_OPERATION_MODIFY_DN   LDAPOperation = 8
+)
+
+// Enum value maps for LDAPOperation.
+var (
+	LDAPOperation_name = map[int32]string{
+		0: "LDAP_OPERATION_UNSPECIFIED",
+		1: "LDAP_OPERATION_BIND",
+		2: "LDAP_OPERATION_SEARCH",
+		3: "LDAP_OPERATION_COMPARE",
+		4: "LDAP_OPERATION_EXTENDED",
+		5: "LDAP_OPERATION_MODIFY",
+		6: "LDAP_OPERATION_ADD",
+		7: "LDAP_OPERATION_DELETE",
+		8: "LDAP_OPERATION_MODIFY_DN",
+	}
+	LDAPOperation_value = map[string]int32{
+		"LDAP_OPERATION_UNSPECIFIED": 0,
+		"LDAP_OPERATION_BIND":        1,
+		"LDAP_OPERATION_SEARCH":      2,
+		"LDAP_OPERATION_COMPARE":     3,
+		"LDAP_OPERATION_EXTENDED":    4,
+		"LDAP_OPERATION_MODIFY":      5,
+		"LDAP_OPERATION_ADD":         6,
+		"LDAP_OPERATION_DELETE":      7,
+		"LDAP_OPERATION_MODIFY_DN":   8,
+	}
+)
+
+func (x LDAPOperation) Enum() *LDAPOperation {
+	p := new(LDAPOperation)
+	*p = x
+	return p
+}
+
+func (x LDAPOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LDAPOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_ldap_details_proto_enumTypes[0].Descriptor()
+}
+
+func (LDAPOperation) Type() protoreflect.EnumType {
+	return &file_audit_v1_ldap_details_proto_enumTypes[0]
+}
+
+func (x LDAPOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LDAPOperation.Descriptor instead.
+func (LDAPOperation) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_ldap_details_proto_rawDescGZIP(), []int{0}
+}
+
+type LDAPSearchScope int32
+
+const (
+	LDAPSearchScope_LDAP_SEARCH_SCOPE_UNSPECIFIED   LDAPSearchScope = 0
+	LDAPSearchScope_LDAP_SEARCH_SCOPE_BASE_OBJECT   LDAPSearchScope = 1
+	LDAPSearchScope_LDAP_SEARCH_SCOPE_SINGLE_LEVEL  LDAPSearchScope = 2
+	LDAPSearchScope_LDAP_SEARCH_SCOPE_WHOLE_SUBTREE LDAPSearchScope = 3
+)
+
+// Enum value maps for LDAPSearchScope.
+var (
+	LDAPSearchScope_name = map[int32]string{
+		0: "LDAP_SEARCH_SCOPE_UNSPECIFIED",
+		1: "LDAP_SEARCH_SCOPE_BASE_OBJECT",
+		2: "LDAP_SEARCH_SCOPE_SINGLE_LEVEL",
+		3: "LDAP_SEARCH_SCOPE_WHOLE_SUBTREE",
+	}
+	LDAPSearchScope_value = map[string]int32{
+		"LDAP_SEARCH_SCOPE_UNSPECIFIED":   0,
+		"LDAP_SEARCH_SCOPE_BASE_OBJECT":   1,
+		"LDAP_SEARCH_SCOPE_SINGLE_LEVEL":  2,
+		"LDAP_SEARCH_SCOPE_WHOLE_SUBTREE": 3,
+	}
+)
+
+func (x LDAPSearchScope) Enum() *LDAPSearchScope {
+	p := new(LDAPSearchScope)
+	*p = x
+	return p
+}
+
+func (x LDAPSearchScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LDAPSearchScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_ldap_details_proto_enumTypes[1].Descriptor()
+}
+
+func (LDAPSearchScope) Type() protoreflect.EnumType {
+	return &file_audit_v1_ldap_details_proto_enumTypes[1]
+}
+
+func (x LDAPSearchScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LDAPSearchScope.Descriptor instead.
+func (LDAPSearchScope) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_ldap_details_proto_rawDescGZIP(), []int{1}
+}
+
+type LDAPDetailsEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation LDAPOperation `protobuf:"varint,1,opt,name=operation,proto3,enum=inetmock.audit.v1.LDAPOperation" json:"operation,omitempty"`
+	// bind DN, search base or DN of the entry the operation refers to
+	Dn string `protobuf:"bytes,2,opt,name=dn,proto3" json:"dn,omitempty"`
+	// password of simple binds
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// mechanism of SASL binds, empty for simple binds
+	SaslMechanism string          `protobuf:"bytes,4,opt,name=sasl_mechanism,json=saslMechanism,proto3" json:"sasl_mechanism,omitempty"`
+	Scope         LDAPSearchScope `protobuf:"varint,5,opt,name=scope,proto3,enum=inetmock.audit.v1.LDAPSearchScope" json:"scope,omitempty"`
+	// search filter in string representation e.g. (&(objectClass=user)(sAMAccountName=jdoe))
+	Filter     string   `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"`
+	Attributes []string `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// OID of extended operations e.g. 1.3.6.1.4.1.1466.20037 for StartTLS
+	RequestName string `protobuf:"bytes,8,opt,name=request_name,json=requestName,proto3" json:"request_name,omitempty"`
+	ResultCode  uint32 `protobuf:"varint,9,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	// number of entries returned by a search
+	Entries uint32 `protobuf:"varint,10,opt,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *LDAPDetailsEntity) Reset() {
+	*x = LDAPDetailsEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_ldap_details_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LDAPDetailsEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LDAPDetailsEntity) ProtoMessage() {}
+
+func (x *LDAPDetailsEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_ldap_details_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LDAPDetailsEntity.ProtoReflect.Descriptor instead.
+func (*LDAPDetailsEntity) Descriptor() ([]byte, []int) {
+	return file_audit_v1_ldap_details_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LDAPDetailsEntity) GetOperation() LDAPOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return LDAPOperation_LDAP_OPERATION_UNSPECIFIED
+}
+
+func (x *LDAPDetailsEntity) GetDn() string {
+	if x != nil {
+		return x.Dn
+	}
+	return ""
+}
+
+func (x *LDAPDetailsEntity) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *LDAPDetailsEntity) GetSaslMechanism() string {
+	if x != nil {
+		return x.SaslMechanism
+	}
+	return ""
+}
+
+func (x *LDAPDetailsEntity) GetScope() LDAPSearchScope {
+	if x != nil {
+		return x.Scope
+	}
+	return LDAPSearchScope_LDAP_SEARCH_SCOPE_UNSPECIFIED
+}
+
+func (x *LDAPDetailsEntity) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *LDAPDetailsEntity) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *LDAPDetailsEntity) GetRequestName() string {
+	if x != nil {
+		return x.RequestName
+	}
+	return ""
+}
+
+func (x *LDAPDetailsEntity) GetResultCode() uint32 {
+	if x != nil {
+		return x.ResultCode
+	}
+	return 0
+}
+
+func (x *LDAPDetailsEntity) GetEntries() uint32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+var File_audit_v1_ldap_details_proto protoreflect.FileDescriptor
+
+var file_audit_v1_ldap_details_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x64, 0x61, 0x70, 0x5f,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x69,
+	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31,
+	0x22, 0xf6, 0x02, 0x0a, 0x11, 0x4c, 0x44, 0x41, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x69, 0x6e, 0x65, 0x74,
+	0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x44,
+	0x41, 0x50, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x64, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x61, 0x73, 0x6c, 0x5f, 0x6d, 0x65, 0x63, 0x68, 0x61,
+	0x6e, 0x69, 0x73, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x61, 0x73, 0x6c,
+	0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x12, 0x38, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x44, 0x41,
+	0x50, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x61,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x2a, 0x88, 0x02, 0x0a, 0x0d, 0x4c, 0x44,
+	0x41, 0x50, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x4c,
+	0x44, 0x41, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x4c,
+	0x44, 0x41, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x42, 0x49,
+	0x4e, 0x44, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x44, 0x41, 0x50, 0x5f, 0x4f, 0x50, 0x45,
+	0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x10, 0x02, 0x12,
+	0x1a, 0x0a, 0x16, 0x4c, 0x44, 0x41, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x52, 0x45, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x4c,
+	0x44, 0x41, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x45, 0x58,
+	0x54, 0x45, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x04, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x44, 0x41, 0x50,
+	0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x49, 0x46,
+	0x59, 0x10, 0x05, 0x12, 0x16, 0x0a, 0x12, 0x4c, 0x44, 0x41, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x44, 0x44, 0x10, 0x06, 0x12, 0x19, 0x0a, 0x15, 0x4c,
+	0x44, 0x41, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45,
+	0x4c, 0x45, 0x54, 0x45, 0x10, 0x07, 0x12, 0x1c, 0x0a, 0x18, 0x4c, 0x44, 0x41, 0x50, 0x5f, 0x4f,
+	0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x49, 0x46, 0x59, 0x5f,
+	0x44, 0x4e, 0x10, 0x08, 0x2a, 0xa0, 0x01, 0x0a, 0x0f, 0x4c, 0x44, 0x41, 0x50, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x44, 0x41, 0x50,
+	0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x21, 0x0a, 0x1d, 0x4c,
+	0x44, 0x41, 0x50, 0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x53, 0x43, 0x4f, 0x50, 0x45,
+	0x5f, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x4f, 0x42, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x22,
+	0x0a, 0x1e, 0x4c, 0x44, 0x41, 0x50, 0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x53, 0x43,
+	0x4f, 0x50, 0x45, 0x5f, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c,
+	0x10, 0x02, 0x12, 0x23, 0x0a, 0x1f, 0x4c, 0x44, 0x41, 0x50, 0x5f, 0x53, 0x45, 0x41, 0x52, 0x43,
+	0x48, 0x5f, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x57, 0x48, 0x4f, 0x4c, 0x45, 0x5f, 0x53, 0x55,
+	0x42, 0x54, 0x52, 0x45, 0x45, 0x10, 0x03, 0x42, 0xc4, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e,
+	0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76,
+	0x31, 0x42, 0x10, 0x4c, 0x64, 0x61, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x48, 0x02, 0x50, 0x01, 0x5a, 0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
+	0x6b, 0x2e, 0x69, 0x63, 0x62, 0x34, 0x64, 0x63, 0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f,
+	0x76, 0x31, 0x3b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41, 0x58,
+	0xaa, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_audit_v1_ldap_details_proto_rawDescOnce sync.Once
+	file_audit_v1_ldap_details_proto_rawDescData = file_audit_v1_ldap_details_proto_rawDesc
+)
+
+func file_audit_v1_ldap_details_proto_rawDescGZIP() []byte {
+	file_audit_v1_ldap_details_proto_rawDescOnce.Do(func() {
+		file_audit_v1_ldap_details_proto_rawDescData = protoimpl.X.CompressGZIP(file_audit_v1_ldap_details_proto_rawDescData)
+	})
+	return file_audit_v1_ldap_details_proto_rawDescData
+}
+
+var file_audit_v1_ldap_details_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_audit_v1_ldap_details_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_audit_v1_ldap_details_proto_goTypes = []interface{}{
+	(LDAPOperation)(0),        // 0: inetmock.audit.v1.LDAPOperation
+	(LDAPSearchScope)(0),      // 1: inetmock.audit.v1.LDAPSearchScope
+	(*LDAPDetailsEntity)(nil), // 2: inetmock.audit.v1.LDAPDetailsEntity
+}
+var file_audit_v1_ldap_details_proto_depIdxs = []int32{
+	0, // 0: inetmock.audit.v1.LDAPDetailsEntity.operation:type_name -> inetmock.audit.v1.LDAPOperation
+	1, // 1: inetmock.audit.v1.LDAPDetailsEntity.scope:type_name -> inetmock.audit.v1.LDAPSearchScope
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_audit_v1_ldap_details_proto_init() }
+func file_audit_v1_ldap_details_proto_init() {
+	if File_audit_v1_ldap_details_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_audit_v1_ldap_details_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LDAPDetailsEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_audit_v1_ldap_details_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_v1_ldap_details_proto_goTypes,
+		DependencyIndexes: file_audit_v1_ldap_details_proto_depIdxs,
+		EnumInfos:         file_audit_v1_ldap_details_proto_enumTypes,
+		MessageInfos:      file_audit_v1_ldap_details_proto_msgTypes,
+	}.Build()
+	File_audit_v1_ldap_details_proto = out.File
+	file_audit_v1_ldap_details_proto_rawDesc = nil
+	file_audit_v1_ldap_details_proto_goTypes = nil
+	file_audit_v1_ldap_details_proto_depIdxs = nil
+}
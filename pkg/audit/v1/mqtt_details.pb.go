@@ -0,0 +1,308 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: audit/v1/mqtt_details.proto
+
+package auditv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MQTTPacketType int32
+
+const (
+	MQTTPacketType_MQTT_PACKET_TYPE_UNSPECIFIED MQTTPacketType = 0
+	MQTTPacketType_MQTT_PACKET_TYPE_CONNECT     MQTTPacketType = 1
+	MQTTPacketType_MQTT_PACKET_TYPE_PUBLISH     MQTTPacketType = 2
+	MQTTPacketType_MQTT_PACKET_TYPE_SUBSCRIBE   MQTTPacketType = 3
+	MQTTPacketType_MQTT_PACKET_TYPE_UNSUBSCRIBE MQTTPacketType = 4
+)
+
+// Enum value maps for MQTTPacketType.
+var (
+	MQTTPacketType_name = map[int32]string{
+		0: "MQTT_PACKET_TYPE_UNSPECIFIED",
+		1: "MQTT_PACKET_TYPE_CONNECT",
+		2: "MQTT_PACKET_TYPE_PUBLISH",
+		3: "MQTT_PACKET_TYPE_SUBSCRIBE",
+		4: "MQTT_PACKET_TYPE_UNSUBSCRIBE",
+	}
+	MQTTPacketType_value = map[string]int32{
+		"MQTT_PACKET_TYPE_UNSPECIFIED": 0,
+		"MQTT_PACKET_TYPE_CONNECT":     1,
+		"MQTT_PACKET_TYPE_PUBLISH":     2,
+		"MQTT_PACKET_TYPE_SUBSCRIBE":   3,
+		"MQTT_PACKET_TYPE_UNSUBSCRIBE": 4,
+	}
+)
+
+func (x MQTTPacketType) Enum() *MQTTPacketType {
+	p := new(MQTTPacketType)
+	*p = x
+	return p
+}
+
+func (x MQTTPacketType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MQTTPacketType) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_mqtt_details_proto_enumTypes[0].Descriptor()
+}
+
+func (MQTTPacketType) Type() protoreflect.EnumType {
+	return &file_audit_v1_mqtt_details_proto_enumTypes[0]
+}
+
+func (x MQTTPacketType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MQTTPacketType.Descriptor instead.
+func (MQTTPacketType) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_mqtt_details_proto_rawDescGZIP(), []int{0}
+}
+
+type MQTTDetailsEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PacketType      MQTTPacketType `protobuf:"varint,1,opt,name=packet_type,json=packetType,proto3,enum=inetmock.audit.v1.MQTTPacketType" json:"packet_type,omitempty"`
+	ProtocolVersion uint32         `protobuf:"varint,2,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	ClientId        string         `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Username        string         `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Password        string         `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	Topics          []string       `protobuf:"bytes,6,rep,name=topics,proto3" json:"topics,omitempty"`
+	Qos             uint32         `protobuf:"varint,7,opt,name=qos,proto3" json:"qos,omitempty"`
+	Retain          bool           `protobuf:"varint,8,opt,name=retain,proto3" json:"retain,omitempty"`
+	PayloadSize     int64          `protobuf:"varint,9,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
+}
+
+func (x *MQTTDetailsEntity) Reset() {
+	*x = MQTTDetailsEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_mqtt_details_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MQTTDetailsEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MQTTDetailsEntity) ProtoMessage() {}
+
+func (x *MQTTDetailsEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_mqtt_details_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MQTTDetailsEntity.ProtoReflect.Descriptor instead.
+func (*MQTTDetailsEntity) Descriptor() ([]byte, []int) {
+	return file_audit_v1_mqtt_details_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MQTTDetailsEntity) GetPacketType() MQTTPacketType {
+	if x != nil {
+		return x.PacketType
+	}
+	return MQTTPacketType_MQTT_PACKET_TYPE_UNSPECIFIED
+}
+
+func (x *MQTTDetailsEntity) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *MQTTDetailsEntity) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *MQTTDetailsEntity) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *MQTTDetailsEntity) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *MQTTDetailsEntity) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *MQTTDetailsEntity) GetQos() uint32 {
+	if x != nil {
+		return x.Qos
+	}
+	return 0
+}
+
+func (x *MQTTDetailsEntity) GetRetain() bool {
+	if x != nil {
+		return x.Retain
+	}
+	return false
+}
+
+func (x *MQTTDetailsEntity) GetPayloadSize() int64 {
+	if x != nil {
+		return x.PayloadSize
+	}
+	return 0
+}
+
+var File_audit_v1_mqtt_details_proto protoreflect.FileDescriptor
+
+var file_audit_v1_mqtt_details_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x71, 0x74, 0x74, 0x5f,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x69,
+	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31,
+	0x22, 0xbc, 0x02, 0x0a, 0x11, 0x4d, 0x51, 0x54, 0x54, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x51, 0x54, 0x54, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x6f, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x03, 0x71, 0x6f, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x2a,
+	0xb0, 0x01, 0x0a, 0x0e, 0x4d, 0x51, 0x54, 0x54, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x4d, 0x51, 0x54, 0x54, 0x5f, 0x50, 0x41, 0x43, 0x4b, 0x45,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x51, 0x54, 0x54, 0x5f, 0x50, 0x41, 0x43,
+	0x4b, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54,
+	0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x51, 0x54, 0x54, 0x5f, 0x50, 0x41, 0x43, 0x4b, 0x45,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x10, 0x02,
+	0x12, 0x1e, 0x0a, 0x1a, 0x4d, 0x51, 0x54, 0x54, 0x5f, 0x50, 0x41, 0x43, 0x4b, 0x45, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x10, 0x03,
+	0x12, 0x20, 0x0a, 0x1c, 0x4d, 0x51, 0x54, 0x54, 0x5f, 0x50, 0x41, 0x43, 0x4b, 0x45, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45,
+	0x10, 0x04, 0x42, 0xc4, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x4d, 0x71,
+	0x74, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x02,
+	0x50, 0x01, 0x5a, 0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x69, 0x63, 0x62,
+	0x34, 0x64, 0x63, 0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41, 0x58, 0xaa, 0x02, 0x11, 0x49, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x31, 0xca,
+	0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x3a, 0x3a,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_audit_v1_mqtt_details_proto_rawDescOnce sync.Once
+	file_audit_v1_mqtt_details_proto_rawDescData = file_audit_v1_mqtt_details_proto_rawDesc
+)
+
+func file_audit_v1_mqtt_details_proto_rawDescGZIP() []byte {
+	file_audit_v1_mqtt_details_proto_rawDescOnce.Do(func() {
+		file_audit_v1_mqtt_details_proto_rawDescData = protoimpl.X.CompressGZIP(file_audit_v1_mqtt_details_proto_rawDescData)
+	})
+	return file_audit_v1_mqtt_details_proto_rawDescData
+}
+
+var file_audit_v1_mqtt_details_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_audit_v1_mqtt_details_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_audit_v1_mqtt_details_proto_goTypes = []interface{}{
+	(MQTTPacketType)(0),       // 0: inetmock.audit.v1.MQTTPacketType
+	(*MQTTDetailsEntity)(nil), // 1: inetmock.audit.v1.MQTTDetailsEntity
+}
+var file_audit_v1_mqtt_details_proto_depIdxs = []int32{
+	0, // 0: inetmock.audit.v1.MQTTDetailsEntity.packet_type:type_name -> inetmock.audit.v1.MQTTPacketType
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_audit_v1_mqtt_details_proto_init() }
+func file_audit_v1_mqtt_details_proto_init() {
+	if File_audit_v1_mqtt_details_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_audit_v1_mqtt_details_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MQTTDetailsEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_audit_v1_mqtt_details_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_v1_mqtt_details_proto_goTypes,
+		DependencyIndexes: file_audit_v1_mqtt_details_proto_depIdxs,
+		EnumInfos:         file_audit_v1_mqtt_details_proto_enumTypes,
+		MessageInfos:      file_audit_v1_mqtt_details_proto_msgTypes,
+	}.Build()
+	File_audit_v1_mqtt_details_proto = out.File
+	file_audit_v1_mqtt_details_proto_rawDesc = nil
+	file_audit_v1_mqtt_details_proto_goTypes = nil
+	file_audit_v1_mqtt_details_proto_depIdxs = nil
+}
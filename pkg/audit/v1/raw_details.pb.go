@@ -0,0 +1,324 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: audit/v1/raw_details.proto
+
+package auditv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RawDirection int32
+
+const (
+	RawDirection_RAW_DIRECTION_UNSPECIFIED RawDirection = 0
+	RawDirection_RAW_DIRECTION_INBOUND     RawDirection = 1
+	RawDirection_RAW_DIRECTION_OUTBOUND    RawDirection = 2
+)
+
+// Enum value maps for RawDirection.
+var (
+	RawDirection_name = map[int32]string{
+		0: "RAW_DIRECTION_UNSPECIFIED",
+		1: "RAW_DIRECTION_INBOUND",
+		2: "RAW_DIRECTION_OUTBOUND",
+	}
+	RawDirection_value = map[string]int32{
+		"RAW_DIRECTION_UNSPECIFIED": 0,
+		"RAW_DIRECTION_INBOUND":     1,
+		"RAW_DIRECTION_OUTBOUND":    2,
+	}
+)
+
+func (x RawDirection) Enum() *RawDirection {
+	p := new(RawDirection)
+	*p = x
+	return p
+}
+
+func (x RawDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RawDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_raw_details_proto_enumTypes[0].Descriptor()
+}
+
+func (RawDirection) Type() protoreflect.EnumType {
+	return &file_audit_v1_raw_details_proto_enumTypes[0]
+}
+
+func (x RawDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RawDirection.Descriptor instead.
+func (RawDirection) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_raw_details_proto_rawDescGZIP(), []int{0}
+}
+
+type RawTranscriptEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Direction RawDirection `protobuf:"varint,1,opt,name=direction,proto3,enum=inetmock.audit.v1.RawDirection" json:"direction,omitempty"`
+	Data      []byte       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *RawTranscriptEntry) Reset() {
+	*x = RawTranscriptEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_raw_details_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawTranscriptEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawTranscriptEntry) ProtoMessage() {}
+
+func (x *RawTranscriptEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_raw_details_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawTranscriptEntry.ProtoReflect.Descriptor instead.
+func (*RawTranscriptEntry) Descriptor() ([]byte, []int) {
+	return file_audit_v1_raw_details_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RawTranscriptEntry) GetDirection() RawDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return RawDirection_RAW_DIRECTION_UNSPECIFIED
+}
+
+func (x *RawTranscriptEntry) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RawDetailsEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transcript    []*RawTranscriptEntry `protobuf:"bytes,1,rep,name=transcript,proto3" json:"transcript,omitempty"`
+	Truncated     bool                  `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	BytesReceived int64                 `protobuf:"varint,3,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	BytesSent     int64                 `protobuf:"varint,4,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+}
+
+func (x *RawDetailsEntity) Reset() {
+	*x = RawDetailsEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_raw_details_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawDetailsEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawDetailsEntity) ProtoMessage() {}
+
+func (x *RawDetailsEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_raw_details_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawDetailsEntity.ProtoReflect.Descriptor instead.
+func (*RawDetailsEntity) Descriptor() ([]byte, []int) {
+	return file_audit_v1_raw_details_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RawDetailsEntity) GetTranscript() []*RawTranscriptEntry {
+	if x != nil {
+		return x.Transcript
+	}
+	return nil
+}
+
+func (x *RawDetailsEntity) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *RawDetailsEntity) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *RawDetailsEntity) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+var File_audit_v1_raw_details_proto protoreflect.FileDescriptor
+
+var file_audit_v1_raw_details_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x61, 0x77, 0x5f, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x22,
+	0x67, 0x0a, 0x12, 0x52, 0x61, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x3d, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xbd, 0x01, 0x0a, 0x10, 0x52, 0x61, 0x77,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x45, 0x0a,
+	0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x2a, 0x64, 0x0a, 0x0c, 0x52, 0x61, 0x77, 0x44,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x41, 0x57, 0x5f,
+	0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x41, 0x57, 0x5f, 0x44,
+	0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x42, 0x4f, 0x55, 0x4e, 0x44,
+	0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x41, 0x57, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x55, 0x54, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x42, 0xc3,
+	0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42, 0x0f, 0x52, 0x61, 0x77, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x02, 0x50, 0x01, 0x5a, 0x31, 0x69,
+	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x69, 0x63, 0x62, 0x34, 0x64, 0x63, 0x30, 0x2e,
+	0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x76, 0x31,
+	0xa2, 0x02, 0x03, 0x49, 0x41, 0x58, 0xaa, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
+	0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x11, 0x49, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0xe2, 0x02,
+	0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c,
+	0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02,
+	0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_audit_v1_raw_details_proto_rawDescOnce sync.Once
+	file_audit_v1_raw_details_proto_rawDescData = file_audit_v1_raw_details_proto_rawDesc
+)
+
+func file_audit_v1_raw_details_proto_rawDescGZIP() []byte {
+	file_audit_v1_raw_details_proto_rawDescOnce.Do(func() {
+		file_audit_v1_raw_details_proto_rawDescData = protoimpl.X.CompressGZIP(file_audit_v1_raw_details_proto_rawDescData)
+	})
+	return file_audit_v1_raw_details_proto_rawDescData
+}
+
+var file_audit_v1_raw_details_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_audit_v1_raw_details_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_audit_v1_raw_details_proto_goTypes = []interface{}{
+	(RawDirection)(0),          // 0: inetmock.audit.v1.RawDirection
+	(*RawTranscriptEntry)(nil), // 1: inetmock.audit.v1.RawTranscriptEntry
+	(*RawDetailsEntity)(nil),   // 2: inetmock.audit.v1.RawDetailsEntity
+}
+var file_audit_v1_raw_details_proto_depIdxs = []int32{
+	0, // 0: inetmock.audit.v1.RawTranscriptEntry.direction:type_name -> inetmock.audit.v1.RawDirection
+	1, // 1: inetmock.audit.v1.RawDetailsEntity.transcript:type_name -> inetmock.audit.v1.RawTranscriptEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_audit_v1_raw_details_proto_init() }
+func file_audit_v1_raw_details_proto_init() {
+	if File_audit_v1_raw_details_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_audit_v1_raw_details_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawTranscriptEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_audit_v1_raw_details_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawDetailsEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_audit_v1_raw_details_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_v1_raw_details_proto_goTypes,
+		DependencyIndexes: file_audit_v1_raw_details_proto_depIdxs,
+		EnumInfos:         file_audit_v1_raw_details_proto_enumTypes,
+		MessageInfos:      file_audit_v1_raw_details_proto_msgTypes,
+	}.Build()
+	File_audit_v1_raw_details_proto = out.File
+	file_audit_v1_raw_details_proto_rawDesc = nil
+	file_audit_v1_raw_details_proto_goTypes = nil
+	file_audit_v1_raw_details_proto_depIdxs = nil
+}
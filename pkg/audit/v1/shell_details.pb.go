@@ -0,0 +1,367 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: audit/v1/shell_details.proto
+
+package auditv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ShellEventType int32
+
+const (
+	ShellEventType_SHELL_EVENT_TYPE_UNSPECIFIED ShellEventType = 0
+	ShellEventType_SHELL_EVENT_TYPE_LOGIN       ShellEventType = 1
+	ShellEventType_SHELL_EVENT_TYPE_COMMAND     ShellEventType = 2
+)
+
+// Enum value maps for ShellEventType.
+var (
+	ShellEventType_name = map[int32]string{
+		0: "SHELL_EVENT_TYPE_UNSPECIFIED",
+		1: "SHELL_EVENT_TYPE_LOGIN",
+		2: "SHELL_EVENT_TYPE_COMMAND",
+	}
+	ShellEventType_value = map[string]int32{
+		"SHELL_EVENT_TYPE_UNSPECIFIED": 0,
+		"SHELL_EVENT_TYPE_LOGIN":       1,
+		"SHELL_EVENT_TYPE_COMMAND":     2,
+	}
+)
+
+func (x ShellEventType) Enum() *ShellEventType {
+	p := new(ShellEventType)
+	*p = x
+	return p
+}
+
+func (x ShellEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShellEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_shell_details_proto_enumTypes[0].Descriptor()
+}
+
+func (ShellEventType) Type() protoreflect.EnumType {
+	return &file_audit_v1_shell_details_proto_enumTypes[0]
+}
+
+func (x ShellEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShellEventType.Descriptor instead.
+func (ShellEventType) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_shell_details_proto_rawDescGZIP(), []int{0}
+}
+
+type ShellAuthMethod int32
+
+const (
+	ShellAuthMethod_SHELL_AUTH_METHOD_UNSPECIFIED          ShellAuthMethod = 0
+	ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD             ShellAuthMethod = 1
+	ShellAuthMethod_SHELL_AUTH_METHOD_PUBLIC_KEY           ShellAuthMethod = 2
+	ShellAuthMethod_SHELL_AUTH_METHOD_KEYBOARD_INTERACTIVE ShellAuthMethod = 3
+)
+
+// Enum value maps for ShellAuthMethod.
+var (
+	ShellAuthMethod_name = map[int32]string{
+		0: "SHELL_AUTH_METHOD_UNSPECIFIED",
+		1: "SHELL_AUTH_METHOD_PASSWORD",
+		2: "SHELL_AUTH_METHOD_PUBLIC_KEY",
+		3: "SHELL_AUTH_METHOD_KEYBOARD_INTERACTIVE",
+	}
+	ShellAuthMethod_value = map[string]int32{
+		"SHELL_AUTH_METHOD_UNSPECIFIED":          0,
+		"SHELL_AUTH_METHOD_PASSWORD":             1,
+		"SHELL_AUTH_METHOD_PUBLIC_KEY":           2,
+		"SHELL_AUTH_METHOD_KEYBOARD_INTERACTIVE": 3,
+	}
+)
+
+func (x ShellAuthMethod) Enum() *ShellAuthMethod {
+	p := new(ShellAuthMethod)
+	*p = x
+	return p
+}
+
+func (x ShellAuthMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShellAuthMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_shell_details_proto_enumTypes[1].Descriptor()
+}
+
+func (ShellAuthMethod) Type() protoreflect.EnumType {
+	return &file_audit_v1_shell_details_proto_enumTypes[1]
+}
+
+func (x ShellAuthMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShellAuthMethod.Descriptor instead.
+func (ShellAuthMethod) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_shell_details_proto_rawDescGZIP(), []int{1}
+}
+
+type ShellDetailsEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventType            ShellEventType  `protobuf:"varint,1,opt,name=event_type,json=eventType,proto3,enum=inetmock.audit.v1.ShellEventType" json:"event_type,omitempty"`
+	ClientVersion        string          `protobuf:"bytes,2,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	Username             string          `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	AuthMethod           ShellAuthMethod `protobuf:"varint,4,opt,name=auth_method,json=authMethod,proto3,enum=inetmock.audit.v1.ShellAuthMethod" json:"auth_method,omitempty"`
+	Password             string          `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	PublicKeyType        string          `protobuf:"bytes,6,opt,name=public_key_type,json=publicKeyType,proto3" json:"public_key_type,omitempty"`
+	PublicKeyFingerprint string          `protobuf:"bytes,7,opt,name=public_key_fingerprint,json=publicKeyFingerprint,proto3" json:"public_key_fingerprint,omitempty"`
+	Accepted             bool            `protobuf:"varint,8,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Command              string          `protobuf:"bytes,9,opt,name=command,proto3" json:"command,omitempty"`
+}
+
+func (x *ShellDetailsEntity) Reset() {
+	*x = ShellDetailsEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_shell_details_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShellDetailsEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellDetailsEntity) ProtoMessage() {}
+
+func (x *ShellDetailsEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_shell_details_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellDetailsEntity.ProtoReflect.Descriptor instead.
+func (*ShellDetailsEntity) Descriptor() ([]byte, []int) {
+	return file_audit_v1_shell_details_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ShellDetailsEntity) GetEventType() ShellEventType {
+	if x != nil {
+		return x.EventType
+	}
+	return ShellEventType_SHELL_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ShellDetailsEntity) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *ShellDetailsEntity) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ShellDetailsEntity) GetAuthMethod() ShellAuthMethod {
+	if x != nil {
+		return x.AuthMethod
+	}
+	return ShellAuthMethod_SHELL_AUTH_METHOD_UNSPECIFIED
+}
+
+func (x *ShellDetailsEntity) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ShellDetailsEntity) GetPublicKeyType() string {
+	if x != nil {
+		return x.PublicKeyType
+	}
+	return ""
+}
+
+func (x *ShellDetailsEntity) GetPublicKeyFingerprint() string {
+	if x != nil {
+		return x.PublicKeyFingerprint
+	}
+	return ""
+}
+
+func (x *ShellDetailsEntity) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *ShellDetailsEntity) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+var File_audit_v1_shell_details_proto protoreflect.FileDescriptor
+
+var file_audit_v1_shell_details_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x68, 0x65, 0x6c, 0x6c,
+	0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11,
+	0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76,
+	0x31, 0x22, 0x8e, 0x03, 0x0a, 0x12, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x40, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x69,
+	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x43, 0x0a,
+	0x0b, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x41, 0x75, 0x74, 0x68,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x4d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x26,
+	0x0a, 0x0f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65,
+	0x79, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x2a, 0x6c, 0x0a, 0x0e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x53, 0x48, 0x45, 0x4c, 0x4c, 0x5f, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x48, 0x45, 0x4c, 0x4c, 0x5f,
+	0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x47, 0x49, 0x4e,
+	0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x48, 0x45, 0x4c, 0x4c, 0x5f, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x10, 0x02,
+	0x2a, 0xa2, 0x01, 0x0a, 0x0f, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x48, 0x45, 0x4c, 0x4c, 0x5f, 0x41, 0x55,
+	0x54, 0x48, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x48, 0x45, 0x4c, 0x4c,
+	0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x50, 0x41, 0x53,
+	0x53, 0x57, 0x4f, 0x52, 0x44, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x53, 0x48, 0x45, 0x4c, 0x4c,
+	0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x50, 0x55, 0x42,
+	0x4c, 0x49, 0x43, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x02, 0x12, 0x2a, 0x0a, 0x26, 0x53, 0x48, 0x45,
+	0x4c, 0x4c, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x4b,
+	0x45, 0x59, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x41, 0x43, 0x54,
+	0x49, 0x56, 0x45, 0x10, 0x03, 0x42, 0xc5, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42,
+	0x11, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x48, 0x02, 0x50, 0x01, 0x5a, 0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2e, 0x69, 0x63, 0x62, 0x34, 0x64, 0x63, 0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65, 0x74,
+	0x6d, 0x6f, 0x63, 0x6b, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76,
+	0x31, 0x3b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41, 0x58, 0xaa,
+	0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x2e, 0x56, 0x31, 0xca, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f,
+	0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f,
+	0x63, 0x6b, 0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_audit_v1_shell_details_proto_rawDescOnce sync.Once
+	file_audit_v1_shell_details_proto_rawDescData = file_audit_v1_shell_details_proto_rawDesc
+)
+
+func file_audit_v1_shell_details_proto_rawDescGZIP() []byte {
+	file_audit_v1_shell_details_proto_rawDescOnce.Do(func() {
+		file_audit_v1_shell_details_proto_rawDescData = protoimpl.X.CompressGZIP(file_audit_v1_shell_details_proto_rawDescData)
+	})
+	return file_audit_v1_shell_details_proto_rawDescData
+}
+
+var file_audit_v1_shell_details_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_audit_v1_shell_details_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_audit_v1_shell_details_proto_goTypes = []interface{}{
+	(ShellEventType)(0),        // 0: inetmock.audit.v1.ShellEventType
+	(ShellAuthMethod)(0),       // 1: inetmock.audit.v1.ShellAuthMethod
+	(*ShellDetailsEntity)(nil), // 2: inetmock.audit.v1.ShellDetailsEntity
+}
+var file_audit_v1_shell_details_proto_depIdxs = []int32{
+	0, // 0: inetmock.audit.v1.ShellDetailsEntity.event_type:type_name -> inetmock.audit.v1.ShellEventType
+	1, // 1: inetmock.audit.v1.ShellDetailsEntity.auth_method:type_name -> inetmock.audit.v1.ShellAuthMethod
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_audit_v1_shell_details_proto_init() }
+func file_audit_v1_shell_details_proto_init() {
+	if File_audit_v1_shell_details_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_audit_v1_shell_details_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShellDetailsEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_audit_v1_shell_details_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_v1_shell_details_proto_goTypes,
+		DependencyIndexes: file_audit_v1_shell_details_proto_depIdxs,
+		EnumInfos:         file_audit_v1_shell_details_proto_enumTypes,
+		MessageInfos:      file_audit_v1_shell_details_proto_msgTypes,
+	}.Build()
+	File_audit_v1_shell_details_proto = out.File
+	file_audit_v1_shell_details_proto_rawDesc = nil
+	file_audit_v1_shell_details_proto_goTypes = nil
+	file_audit_v1_shell_details_proto_depIdxs = nil
+}
@@ -0,0 +1,529 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: audit/v1/snmp_details.proto
+
+package auditv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SNMPVersion int32
+
+const (
+	SNMPVersion_SNMP_VERSION_UNSPECIFIED SNMPVersion = 0
+	SNMPVersion_SNMP_VERSION_V1          SNMPVersion = 1
+	SNMPVersion_SNMP_VERSION_V2C         SNMPVersion = 2
+	SNMPVersion_SNMP_VERSION_V3          SNMPVersion = 3
+)
+
+// Enum value maps for SNMPVersion.
+var (
+	SNMPVersion_name = map[int32]string{
+		0: "SNMP_VERSION_UNSPECIFIED",
+		1: "SNMP_VERSION_V1",
+		2: "SNMP_VERSION_V2C",
+		3: "SNMP_VERSION_V3",
+	}
+	SNMPVersion_value = map[string]int32{
+		"SNMP_VERSION_UNSPECIFIED": 0,
+		"SNMP_VERSION_V1":          1,
+		"SNMP_VERSION_V2C":         2,
+		"SNMP_VERSION_V3":          3,
+	}
+)
+
+func (x SNMPVersion) Enum() *SNMPVersion {
+	p := new(SNMPVersion)
+	*p = x
+	return p
+}
+
+func (x SNMPVersion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SNMPVersion) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_snmp_details_proto_enumTypes[0].Descriptor()
+}
+
+func (SNMPVersion) Type() protoreflect.EnumType {
+	return &file_audit_v1_snmp_details_proto_enumTypes[0]
+}
+
+func (x SNMPVersion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SNMPVersion.Descriptor instead.
+func (SNMPVersion) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_snmp_details_proto_rawDescGZIP(), []int{0}
+}
+
+type SNMPOperation int32
+
+const (
+	SNMPOperation_SNMP_OPERATION_UNSPECIFIED SNMPOperation = 0
+	SNMPOperation_SNMP_OPERATION_GET         SNMPOperation = 1
+	SNMPOperation_SNMP_OPERATION_GET_NEXT    SNMPOperation = 2
+	SNMPOperation_SNMP_OPERATION_GET_BULK    SNMPOperation = 3
+	SNMPOperation_SNMP_OPERATION_SET         SNMPOperation = 4
+)
+
+// Enum value maps for SNMPOperation.
+var (
+	SNMPOperation_name = map[int32]string{
+		0: "SNMP_OPERATION_UNSPECIFIED",
+		1: "SNMP_OPERATION_GET",
+		2: "SNMP_OPERATION_GET_NEXT",
+		3: "SNMP_OPERATION_GET_BULK",
+		4: "SNMP_OPERATION_SET",
+	}
+	SNMPOperation_value = map[string]int32{
+		"SNMP_OPERATION_UNSPECIFIED": 0,
+		"SNMP_OPERATION_GET":         1,
+		"SNMP_OPERATION_GET_NEXT":    2,
+		"SNMP_OPERATION_GET_BULK":    3,
+		"SNMP_OPERATION_SET":         4,
+	}
+)
+
+func (x SNMPOperation) Enum() *SNMPOperation {
+	p := new(SNMPOperation)
+	*p = x
+	return p
+}
+
+func (x SNMPOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SNMPOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_snmp_details_proto_enumTypes[1].Descriptor()
+}
+
+func (SNMPOperation) Type() protoreflect.EnumType {
+	return &file_audit_v1_snmp_details_proto_enumTypes[1]
+}
+
+func (x SNMPOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SNMPOperation.Descriptor instead.
+func (SNMPOperation) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_snmp_details_proto_rawDescGZIP(), []int{1}
+}
+
+type SNMPSecurityLevel int32
+
+const (
+	SNMPSecurityLevel_SNMP_SECURITY_LEVEL_UNSPECIFIED     SNMPSecurityLevel = 0
+	SNMPSecurityLevel_SNMP_SECURITY_LEVEL_NO_AUTH_NO_PRIV SNMPSecurityLevel = 1
+	SNMPSecurityLevel_SNMP_SECURITY_LEVEL_AUTH_NO_PRIV    SNMPSecurityLevel = 2
+	SNMPSecurityLevel_SNMP_SECURITY_LEVEL_AUTH_PRIV       SNMPSecurityLevel = 3
+)
+
+// Enum value maps for SNMPSecurityLevel.
+var (
+	SNMPSecurityLevel_name = map[int32]string{
+		0: "SNMP_SECURITY_LEVEL_UNSPECIFIED",
+		1: "SNMP_SECURITY_LEVEL_NO_AUTH_NO_PRIV",
+		2: "SNMP_SECURITY_LEVEL_AUTH_NO_PRIV",
+		3: "SNMP_SECURITY_LEVEL_AUTH_PRIV",
+	}
+	SNMPSecurityLevel_value = map[string]int32{
+		"SNMP_SECURITY_LEVEL_UNSPECIFIED":     0,
+		"SNMP_SECURITY_LEVEL_NO_AUTH_NO_PRIV": 1,
+		"SNMP_SECURITY_LEVEL_AUTH_NO_PRIV":    2,
+		"SNMP_SECURITY_LEVEL_AUTH_PRIV":       3,
+	}
+)
+
+func (x SNMPSecurityLevel) Enum() *SNMPSecurityLevel {
+	p := new(SNMPSecurityLevel)
+	*p = x
+	return p
+}
+
+func (x SNMPSecurityLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SNMPSecurityLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_snmp_details_proto_enumTypes[2].Descriptor()
+}
+
+func (SNMPSecurityLevel) Type() protoreflect.EnumType {
+	return &file_audit_v1_snmp_details_proto_enumTypes[2]
+}
+
+func (x SNMPSecurityLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SNMPSecurityLevel.Descriptor instead.
+func (SNMPSecurityLevel) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_snmp_details_proto_rawDescGZIP(), []int{2}
+}
+
+type SNMPVariable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// OID in dotted notation e.g. .1.3.6.1.2.1.1.5.0
+	Oid string `protobuf:"bytes,1,opt,name=oid,proto3" json:"oid,omitempty"`
+	// value of set requests in string representation
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *SNMPVariable) Reset() {
+	*x = SNMPVariable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_snmp_details_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SNMPVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SNMPVariable) ProtoMessage() {}
+
+func (x *SNMPVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_snmp_details_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SNMPVariable.ProtoReflect.Descriptor instead.
+func (*SNMPVariable) Descriptor() ([]byte, []int) {
+	return file_audit_v1_snmp_details_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SNMPVariable) GetOid() string {
+	if x != nil {
+		return x.Oid
+	}
+	return ""
+}
+
+func (x *SNMPVariable) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SNMPDetailsEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version   SNMPVersion   `protobuf:"varint,1,opt,name=version,proto3,enum=inetmock.audit.v1.SNMPVersion" json:"version,omitempty"`
+	Operation SNMPOperation `protobuf:"varint,2,opt,name=operation,proto3,enum=inetmock.audit.v1.SNMPOperation" json:"operation,omitempty"`
+	// community string of SNMPv1 and SNMPv2c requests
+	Community string `protobuf:"bytes,3,opt,name=community,proto3" json:"community,omitempty"`
+	// user name and security level of SNMPv3 requests
+	UserName      string            `protobuf:"bytes,4,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	SecurityLevel SNMPSecurityLevel `protobuf:"varint,5,opt,name=security_level,json=securityLevel,proto3,enum=inetmock.audit.v1.SNMPSecurityLevel" json:"security_level,omitempty"`
+	ContextName   string            `protobuf:"bytes,6,opt,name=context_name,json=contextName,proto3" json:"context_name,omitempty"`
+	RequestId     uint32            `protobuf:"varint,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Variables     []*SNMPVariable   `protobuf:"bytes,8,rep,name=variables,proto3" json:"variables,omitempty"`
+	// error status of the response e.g. 6 (noAccess), 0 if the request was processed successfully
+	ErrorStatus uint32 `protobuf:"varint,9,opt,name=error_status,json=errorStatus,proto3" json:"error_status,omitempty"`
+	// reason why the request was discarded, either the USM statistics counter sent as report e.g. usmStatsUnknownUserNames
+	// or snmpInBadCommunityNames if the community is unknown
+	Report string `protobuf:"bytes,10,opt,name=report,proto3" json:"report,omitempty"`
+}
+
+func (x *SNMPDetailsEntity) Reset() {
+	*x = SNMPDetailsEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_snmp_details_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SNMPDetailsEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SNMPDetailsEntity) ProtoMessage() {}
+
+func (x *SNMPDetailsEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_snmp_details_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SNMPDetailsEntity.ProtoReflect.Descriptor instead.
+func (*SNMPDetailsEntity) Descriptor() ([]byte, []int) {
+	return file_audit_v1_snmp_details_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SNMPDetailsEntity) GetVersion() SNMPVersion {
+	if x != nil {
+		return x.Version
+	}
+	return SNMPVersion_SNMP_VERSION_UNSPECIFIED
+}
+
+func (x *SNMPDetailsEntity) GetOperation() SNMPOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return SNMPOperation_SNMP_OPERATION_UNSPECIFIED
+}
+
+func (x *SNMPDetailsEntity) GetCommunity() string {
+	if x != nil {
+		return x.Community
+	}
+	return ""
+}
+
+func (x *SNMPDetailsEntity) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *SNMPDetailsEntity) GetSecurityLevel() SNMPSecurityLevel {
+	if x != nil {
+		return x.SecurityLevel
+	}
+	return SNMPSecurityLevel_SNMP_SECURITY_LEVEL_UNSPECIFIED
+}
+
+func (x *SNMPDetailsEntity) GetContextName() string {
+	if x != nil {
+		return x.ContextName
+	}
+	return ""
+}
+
+func (x *SNMPDetailsEntity) GetRequestId() uint32 {
+	if x != nil {
+		return x.RequestId
+	}
+	return 0
+}
+
+func (x *SNMPDetailsEntity) GetVariables() []*SNMPVariable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *SNMPDetailsEntity) GetErrorStatus() uint32 {
+	if x != nil {
+		return x.ErrorStatus
+	}
+	return 0
+}
+
+func (x *SNMPDetailsEntity) GetReport() string {
+	if x != nil {
+		return x.Report
+	}
+	return ""
+}
+
+var File_audit_v1_snmp_details_proto protoreflect.FileDescriptor
+
+var file_audit_v1_snmp_details_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x6e, 0x6d, 0x70, 0x5f,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x69,
+	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31,
+	0x22, 0x36, 0x0a, 0x0c, 0x53, 0x4e, 0x4d, 0x50, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x6f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xd1, 0x03, 0x0a, 0x11, 0x53, 0x4e, 0x4d,
+	0x50, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x38,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1e, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x4e, 0x4d, 0x50, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x4e, 0x4d, 0x50, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d,
+	0x75, 0x6e, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d,
+	0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x4b, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x4e, 0x4d, 0x50, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x64, 0x12, 0x3d, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x4e, 0x4d, 0x50, 0x56, 0x61,
+	0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2a, 0x6b, 0x0a, 0x0b,
+	0x53, 0x4e, 0x4d, 0x50, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x18, 0x53,
+	0x4e, 0x4d, 0x50, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4e, 0x4d,
+	0x50, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x56, 0x31, 0x10, 0x01, 0x12, 0x14,
+	0x0a, 0x10, 0x53, 0x4e, 0x4d, 0x50, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x56,
+	0x32, 0x43, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4e, 0x4d, 0x50, 0x5f, 0x56, 0x45, 0x52,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x56, 0x33, 0x10, 0x03, 0x2a, 0x99, 0x01, 0x0a, 0x0d, 0x53, 0x4e,
+	0x4d, 0x50, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x53,
+	0x4e, 0x4d, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x53,
+	0x4e, 0x4d, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x47, 0x45,
+	0x54, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4e, 0x4d, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x47, 0x45, 0x54, 0x5f, 0x4e, 0x45, 0x58, 0x54, 0x10, 0x02,
+	0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4e, 0x4d, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x47, 0x45, 0x54, 0x5f, 0x42, 0x55, 0x4c, 0x4b, 0x10, 0x03, 0x12, 0x16, 0x0a,
+	0x12, 0x53, 0x4e, 0x4d, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x53, 0x45, 0x54, 0x10, 0x04, 0x2a, 0xaa, 0x01, 0x0a, 0x11, 0x53, 0x4e, 0x4d, 0x50, 0x53, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x1f, 0x53,
+	0x4e, 0x4d, 0x50, 0x5f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x45, 0x56,
+	0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x27, 0x0a, 0x23, 0x53, 0x4e, 0x4d, 0x50, 0x5f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54,
+	0x59, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x4e, 0x4f, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f,
+	0x4e, 0x4f, 0x5f, 0x50, 0x52, 0x49, 0x56, 0x10, 0x01, 0x12, 0x24, 0x0a, 0x20, 0x53, 0x4e, 0x4d,
+	0x50, 0x5f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c,
+	0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4e, 0x4f, 0x5f, 0x50, 0x52, 0x49, 0x56, 0x10, 0x02, 0x12,
+	0x21, 0x0a, 0x1d, 0x53, 0x4e, 0x4d, 0x50, 0x5f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59,
+	0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x50, 0x52, 0x49, 0x56,
+	0x10, 0x03, 0x42, 0xc4, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x53, 0x6e,
+	0x6d, 0x70, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x02,
+	0x50, 0x01, 0x5a, 0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x69, 0x63, 0x62,
+	0x34, 0x64, 0x63, 0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41, 0x58, 0xaa, 0x02, 0x11, 0x49, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x31, 0xca,
+	0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x3a, 0x3a,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_audit_v1_snmp_details_proto_rawDescOnce sync.Once
+	file_audit_v1_snmp_details_proto_rawDescData = file_audit_v1_snmp_details_proto_rawDesc
+)
+
+func file_audit_v1_snmp_details_proto_rawDescGZIP() []byte {
+	file_audit_v1_snmp_details_proto_rawDescOnce.Do(func() {
+		file_audit_v1_snmp_details_proto_rawDescData = protoimpl.X.CompressGZIP(file_audit_v1_snmp_details_proto_rawDescData)
+	})
+	return file_audit_v1_snmp_details_proto_rawDescData
+}
+
+var file_audit_v1_snmp_details_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_audit_v1_snmp_details_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_audit_v1_snmp_details_proto_goTypes = []interface{}{
+	(SNMPVersion)(0),          // 0: inetmock.audit.v1.SNMPVersion
+	(SNMPOperation)(0),        // 1: inetmock.audit.v1.SNMPOperation
+	(SNMPSecurityLevel)(0),    // 2: inetmock.audit.v1.SNMPSecurityLevel
+	(*SNMPVariable)(nil),      // 3: inetmock.audit.v1.SNMPVariable
+	(*SNMPDetailsEntity)(nil), // 4: inetmock.audit.v1.SNMPDetailsEntity
+}
+var file_audit_v1_snmp_details_proto_depIdxs = []int32{
+	0, // 0: inetmock.audit.v1.SNMPDetailsEntity.version:type_name -> inetmock.audit.v1.SNMPVersion
+	1, // 1: inetmock.audit.v1.SNMPDetailsEntity.operation:type_name -> inetmock.audit.v1.SNMPOperation
+	2, // 2: inetmock.audit.v1.SNMPDetailsEntity.security_level:type_name -> inetmock.audit.v1.SNMPSecurityLevel
+	3, // 3: inetmock.audit.v1.SNMPDetailsEntity.variables:type_name -> inetmock.audit.v1.SNMPVariable
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_audit_v1_snmp_details_proto_init() }
+func file_audit_v1_snmp_details_proto_init() {
+	if File_audit_v1_snmp_details_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_audit_v1_snmp_details_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SNMPVariable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_audit_v1_snmp_details_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SNMPDetailsEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_audit_v1_snmp_details_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_v1_snmp_details_proto_goTypes,
+		DependencyIndexes: file_audit_v1_snmp_details_proto_depIdxs,
+		EnumInfos:         file_audit_v1_snmp_details_proto_enumTypes,
+		MessageInfos:      file_audit_v1_snmp_details_proto_msgTypes,
+	}.Build()
+	File_audit_v1_snmp_details_proto = out.File
+	file_audit_v1_snmp_details_proto_rawDesc = nil
+	file_audit_v1_snmp_details_proto_goTypes = nil
+	file_audit_v1_snmp_details_proto_depIdxs = nil
+}
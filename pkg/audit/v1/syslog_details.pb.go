@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: audit/v1/syslog_details.proto
+
+package auditv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SyslogFormat int32
+
+const (
+	SyslogFormat_SYSLOG_FORMAT_UNSPECIFIED SyslogFormat = 0
+	SyslogFormat_SYSLOG_FORMAT_RFC3164     SyslogFormat = 1
+	SyslogFormat_SYSLOG_FORMAT_RFC5424     SyslogFormat = 2
+)
+
+// Enum value maps for SyslogFormat.
+var (
+	SyslogFormat_name = map[int32]string{
+		0: "SYSLOG_FORMAT_UNSPECIFIED",
+		1: "SYSLOG_FORMAT_RFC3164",
+		2: "SYSLOG_FORMAT_RFC5424",
+	}
+	SyslogFormat_value = map[string]int32{
+		"SYSLOG_FORMAT_UNSPECIFIED": 0,
+		"SYSLOG_FORMAT_RFC3164":     1,
+		"SYSLOG_FORMAT_RFC5424":     2,
+	}
+)
+
+func (x SyslogFormat) Enum() *SyslogFormat {
+	p := new(SyslogFormat)
+	*p = x
+	return p
+}
+
+func (x SyslogFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SyslogFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_syslog_details_proto_enumTypes[0].Descriptor()
+}
+
+func (SyslogFormat) Type() protoreflect.EnumType {
+	return &file_audit_v1_syslog_details_proto_enumTypes[0]
+}
+
+func (x SyslogFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SyslogFormat.Descriptor instead.
+func (SyslogFormat) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_syslog_details_proto_rawDescGZIP(), []int{0}
+}
+
+type SyslogDetailsEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Format SyslogFormat `protobuf:"varint,1,opt,name=format,proto3,enum=inetmock.audit.v1.SyslogFormat" json:"format,omitempty"`
+	// facility and severity as encoded in the PRI part e.g. 4 (auth) and 2 (critical)
+	Facility uint32 `protobuf:"varint,2,opt,name=facility,proto3" json:"facility,omitempty"`
+	Severity uint32 `protobuf:"varint,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	// timestamp as reported by the sender, unset if it was missing or invalid
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Hostname       string                 `protobuf:"bytes,5,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	AppName        string                 `protobuf:"bytes,6,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	ProcId         string                 `protobuf:"bytes,7,opt,name=proc_id,json=procId,proto3" json:"proc_id,omitempty"`
+	MsgId          string                 `protobuf:"bytes,8,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`
+	StructuredData string                 `protobuf:"bytes,9,opt,name=structured_data,json=structuredData,proto3" json:"structured_data,omitempty"`
+	Message        string                 `protobuf:"bytes,10,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *SyslogDetailsEntity) Reset() {
+	*x = SyslogDetailsEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_syslog_details_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyslogDetailsEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyslogDetailsEntity) ProtoMessage() {}
+
+func (x *SyslogDetailsEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_syslog_details_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyslogDetailsEntity.ProtoReflect.Descriptor instead.
+func (*SyslogDetailsEntity) Descriptor() ([]byte, []int) {
+	return file_audit_v1_syslog_details_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SyslogDetailsEntity) GetFormat() SyslogFormat {
+	if x != nil {
+		return x.Format
+	}
+	return SyslogFormat_SYSLOG_FORMAT_UNSPECIFIED
+}
+
+func (x *SyslogDetailsEntity) GetFacility() uint32 {
+	if x != nil {
+		return x.Facility
+	}
+	return 0
+}
+
+func (x *SyslogDetailsEntity) GetSeverity() uint32 {
+	if x != nil {
+		return x.Severity
+	}
+	return 0
+}
+
+func (x *SyslogDetailsEntity) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SyslogDetailsEntity) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *SyslogDetailsEntity) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *SyslogDetailsEntity) GetProcId() string {
+	if x != nil {
+		return x.ProcId
+	}
+	return ""
+}
+
+func (x *SyslogDetailsEntity) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *SyslogDetailsEntity) GetStructuredData() string {
+	if x != nil {
+		return x.StructuredData
+	}
+	return ""
+}
+
+func (x *SyslogDetailsEntity) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_audit_v1_syslog_details_proto protoreflect.FileDescriptor
+
+var file_audit_v1_syslog_details_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x79, 0x73, 0x6c, 0x6f,
+	0x67, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x11, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e,
+	0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xea, 0x02, 0x0a, 0x13, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x37, 0x0a, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x66, 0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x70, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x70, 0x72, 0x6f, 0x63, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x27, 0x0a,
+	0x0f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2a, 0x63, 0x0a, 0x0c, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x1d, 0x0a, 0x19, 0x53, 0x59, 0x53, 0x4c, 0x4f, 0x47, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41,
+	0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x19, 0x0a, 0x15, 0x53, 0x59, 0x53, 0x4c, 0x4f, 0x47, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54,
+	0x5f, 0x52, 0x46, 0x43, 0x33, 0x31, 0x36, 0x34, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x59,
+	0x53, 0x4c, 0x4f, 0x47, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x52, 0x46, 0x43, 0x35,
+	0x34, 0x32, 0x34, 0x10, 0x02, 0x42, 0xc6, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42,
+	0x12, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x48, 0x02, 0x50, 0x01, 0x5a, 0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
+	0x6b, 0x2e, 0x69, 0x63, 0x62, 0x34, 0x64, 0x63, 0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f,
+	0x76, 0x31, 0x3b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41, 0x58,
+	0xaa, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_audit_v1_syslog_details_proto_rawDescOnce sync.Once
+	file_audit_v1_syslog_details_proto_rawDescData = file_audit_v1_syslog_details_proto_rawDesc
+)
+
+func file_audit_v1_syslog_details_proto_rawDescGZIP() []byte {
+	file_audit_v1_syslog_details_proto_rawDescOnce.Do(func() {
+		file_audit_v1_syslog_details_proto_rawDescData = protoimpl.X.CompressGZIP(file_audit_v1_syslog_details_proto_rawDescData)
+	})
+	return file_audit_v1_syslog_details_proto_rawDescData
+}
+
+var file_audit_v1_syslog_details_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_audit_v1_syslog_details_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_audit_v1_syslog_details_proto_goTypes = []interface{}{
+	(SyslogFormat)(0),             // 0: inetmock.audit.v1.SyslogFormat
+	(*SyslogDetailsEntity)(nil),   // 1: inetmock.audit.v1.SyslogDetailsEntity
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_audit_v1_syslog_details_proto_depIdxs = []int32{
+	0, // 0: inetmock.audit.v1.SyslogDetailsEntity.format:type_name -> inetmock.audit.v1.SyslogFormat
+	2, // 1: inetmock.audit.v1.SyslogDetailsEntity.timestamp:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_audit_v1_syslog_details_proto_init() }
+func file_audit_v1_syslog_details_proto_init() {
+	if File_audit_v1_syslog_details_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_audit_v1_syslog_details_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyslogDetailsEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_audit_v1_syslog_details_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_v1_syslog_details_proto_goTypes,
+		DependencyIndexes: file_audit_v1_syslog_details_proto_depIdxs,
+		EnumInfos:         file_audit_v1_syslog_details_proto_enumTypes,
+		MessageInfos:      file_audit_v1_syslog_details_proto_msgTypes,
+	}.Build()
+	File_audit_v1_syslog_details_proto = out.File
+	file_audit_v1_syslog_details_proto_rawDesc = nil
+	file_audit_v1_syslog_details_proto_goTypes = nil
+	file_audit_v1_syslog_details_proto_depIdxs = nil
+}
@@ -0,0 +1,276 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: audit/v1/tftp_details.proto
+
+package auditv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TFTPOperation int32
+
+const (
+	TFTPOperation_TFTP_OPERATION_UNSPECIFIED TFTPOperation = 0
+	TFTPOperation_TFTP_OPERATION_READ        TFTPOperation = 1
+	TFTPOperation_TFTP_OPERATION_WRITE       TFTPOperation = 2
+)
+
+// Enum value maps for TFTPOperation.
+var (
+	TFTPOperation_name = map[int32]string{
+		0: "TFTP_OPERATION_UNSPECIFIED",
+		1: "TFTP_OPERATION_READ",
+		2: "TFTP_OPERATION_WRITE",
+	}
+	TFTPOperation_value = map[string]int32{
+		"TFTP_OPERATION_UNSPECIFIED": 0,
+		"TFTP_OPERATION_READ":        1,
+		"TFTP_OPERATION_WRITE":       2,
+	}
+)
+
+func (x TFTPOperation) Enum() *TFTPOperation {
+	p := new(TFTPOperation)
+	*p = x
+	return p
+}
+
+func (x TFTPOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TFTPOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_tftp_details_proto_enumTypes[0].Descriptor()
+}
+
+func (TFTPOperation) Type() protoreflect.EnumType {
+	return &file_audit_v1_tftp_details_proto_enumTypes[0]
+}
+
+func (x TFTPOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TFTPOperation.Descriptor instead.
+func (TFTPOperation) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_tftp_details_proto_rawDescGZIP(), []int{0}
+}
+
+type TFTPDetailsEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation TFTPOperation `protobuf:"varint,1,opt,name=operation,proto3,enum=inetmock.audit.v1.TFTPOperation" json:"operation,omitempty"`
+	Filename  string        `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Mode      string        `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	BlockSize uint32        `protobuf:"varint,4,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	Bytes     int64         `protobuf:"varint,5,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	ErrorCode uint32        `protobuf:"varint,6,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Completed bool          `protobuf:"varint,7,opt,name=completed,proto3" json:"completed,omitempty"`
+}
+
+func (x *TFTPDetailsEntity) Reset() {
+	*x = TFTPDetailsEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_tftp_details_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TFTPDetailsEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFTPDetailsEntity) ProtoMessage() {}
+
+func (x *TFTPDetailsEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_tftp_details_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFTPDetailsEntity.ProtoReflect.Descriptor instead.
+func (*TFTPDetailsEntity) Descriptor() ([]byte, []int) {
+	return file_audit_v1_tftp_details_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TFTPDetailsEntity) GetOperation() TFTPOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return TFTPOperation_TFTP_OPERATION_UNSPECIFIED
+}
+
+func (x *TFTPDetailsEntity) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *TFTPDetailsEntity) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *TFTPDetailsEntity) GetBlockSize() uint32 {
+	if x != nil {
+		return x.BlockSize
+	}
+	return 0
+}
+
+func (x *TFTPDetailsEntity) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *TFTPDetailsEntity) GetErrorCode() uint32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *TFTPDetailsEntity) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+var File_audit_v1_tftp_details_proto protoreflect.FileDescriptor
+
+var file_audit_v1_tftp_details_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x66, 0x74, 0x70, 0x5f,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x69,
+	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31,
+	0x22, 0xf5, 0x01, 0x0a, 0x11, 0x54, 0x46, 0x54, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x69, 0x6e, 0x65, 0x74,
+	0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x46,
+	0x54, 0x50, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x2a, 0x62, 0x0a, 0x0d, 0x54, 0x46, 0x54, 0x50,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x46, 0x54,
+	0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x46, 0x54,
+	0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x41, 0x44,
+	0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x54, 0x46, 0x54, 0x50, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x57, 0x52, 0x49, 0x54, 0x45, 0x10, 0x02, 0x42, 0xc4, 0x01, 0x0a,
+	0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x54, 0x66, 0x74, 0x70, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x02, 0x50, 0x01, 0x5a, 0x31, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x69, 0x63, 0x62, 0x34, 0x64, 0x63, 0x30, 0x2e, 0x64,
+	0x65, 0x2f, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x76, 0x31, 0xa2,
+	0x02, 0x03, 0x49, 0x41, 0x58, 0xaa, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x11, 0x49, 0x6e, 0x65, 0x74,
+	0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d,
+	0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x5c, 0x56,
+	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x13,
+	0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x3a,
+	0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_audit_v1_tftp_details_proto_rawDescOnce sync.Once
+	file_audit_v1_tftp_details_proto_rawDescData = file_audit_v1_tftp_details_proto_rawDesc
+)
+
+func file_audit_v1_tftp_details_proto_rawDescGZIP() []byte {
+	file_audit_v1_tftp_details_proto_rawDescOnce.Do(func() {
+		file_audit_v1_tftp_details_proto_rawDescData = protoimpl.X.CompressGZIP(file_audit_v1_tftp_details_proto_rawDescData)
+	})
+	return file_audit_v1_tftp_details_proto_rawDescData
+}
+
+var file_audit_v1_tftp_details_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_audit_v1_tftp_details_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_audit_v1_tftp_details_proto_goTypes = []interface{}{
+	(TFTPOperation)(0),        // 0: inetmock.audit.v1.TFTPOperation
+	(*TFTPDetailsEntity)(nil), // 1: inetmock.audit.v1.TFTPDetailsEntity
+}
+var file_audit_v1_tftp_details_proto_depIdxs = []int32{
+	0, // 0: inetmock.audit.v1.TFTPDetailsEntity.operation:type_name -> inetmock.audit.v1.TFTPOperation
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_audit_v1_tftp_details_proto_init() }
+func file_audit_v1_tftp_details_proto_init() {
+	if File_audit_v1_tftp_details_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_audit_v1_tftp_details_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TFTPDetailsEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_audit_v1_tftp_details_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_v1_tftp_details_proto_goTypes,
+		DependencyIndexes: file_audit_v1_tftp_details_proto_depIdxs,
+		EnumInfos:         file_audit_v1_tftp_details_proto_enumTypes,
+		MessageInfos:      file_audit_v1_tftp_details_proto_msgTypes,
+	}.Build()
+	File_audit_v1_tftp_details_proto = out.File
+	file_audit_v1_tftp_details_proto_rawDesc = nil
+	file_audit_v1_tftp_details_proto_goTypes = nil
+	file_audit_v1_tftp_details_proto_depIdxs = nil
+}
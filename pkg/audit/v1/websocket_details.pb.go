@@ -0,0 +1,326 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: audit/v1/websocket_details.proto
+
+package auditv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WebSocketDirection int32
+
+const (
+	WebSocketDirection_WEB_SOCKET_DIRECTION_UNSPECIFIED WebSocketDirection = 0
+	WebSocketDirection_WEB_SOCKET_DIRECTION_INBOUND     WebSocketDirection = 1
+	WebSocketDirection_WEB_SOCKET_DIRECTION_OUTBOUND    WebSocketDirection = 2
+)
+
+// Enum value maps for WebSocketDirection.
+var (
+	WebSocketDirection_name = map[int32]string{
+		0: "WEB_SOCKET_DIRECTION_UNSPECIFIED",
+		1: "WEB_SOCKET_DIRECTION_INBOUND",
+		2: "WEB_SOCKET_DIRECTION_OUTBOUND",
+	}
+	WebSocketDirection_value = map[string]int32{
+		"WEB_SOCKET_DIRECTION_UNSPECIFIED": 0,
+		"WEB_SOCKET_DIRECTION_INBOUND":     1,
+		"WEB_SOCKET_DIRECTION_OUTBOUND":    2,
+	}
+)
+
+func (x WebSocketDirection) Enum() *WebSocketDirection {
+	p := new(WebSocketDirection)
+	*p = x
+	return p
+}
+
+func (x WebSocketDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebSocketDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_websocket_details_proto_enumTypes[0].Descriptor()
+}
+
+func (WebSocketDirection) Type() protoreflect.EnumType {
+	return &file_audit_v1_websocket_details_proto_enumTypes[0]
+}
+
+func (x WebSocketDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebSocketDirection.Descriptor instead.
+func (WebSocketDirection) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_websocket_details_proto_rawDescGZIP(), []int{0}
+}
+
+type WebSocketOpcode int32
+
+const (
+	WebSocketOpcode_WEB_SOCKET_OPCODE_UNSPECIFIED WebSocketOpcode = 0
+	WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT        WebSocketOpcode = 1
+	WebSocketOpcode_WEB_SOCKET_OPCODE_BINARY      WebSocketOpcode = 2
+	WebSocketOpcode_WEB_SOCKET_OPCODE_CLOSE       WebSocketOpcode = 3
+	WebSocketOpcode_WEB_SOCKET_OPCODE_PING        WebSocketOpcode = 4
+	WebSocketOpcode_WEB_SOCKET_OPCODE_PONG        WebSocketOpcode = 5
+)
+
+// Enum value maps for WebSocketOpcode.
+var (
+	WebSocketOpcode_name = map[int32]string{
+		0: "WEB_SOCKET_OPCODE_UNSPECIFIED",
+		1: "WEB_SOCKET_OPCODE_TEXT",
+		2: "WEB_SOCKET_OPCODE_BINARY",
+		3: "WEB_SOCKET_OPCODE_CLOSE",
+		4: "WEB_SOCKET_OPCODE_PING",
+		5: "WEB_SOCKET_OPCODE_PONG",
+	}
+	WebSocketOpcode_value = map[string]int32{
+		"WEB_SOCKET_OPCODE_UNSPECIFIED": 0,
+		"WEB_SOCKET_OPCODE_TEXT":        1,
+		"WEB_SOCKET_OPCODE_BINARY":      2,
+		"WEB_SOCKET_OPCODE_CLOSE":       3,
+		"WEB_SOCKET_OPCODE_PING":        4,
+		"WEB_SOCKET_OPCODE_PONG":        5,
+	}
+)
+
+func (x WebSocketOpcode) Enum() *WebSocketOpcode {
+	p := new(WebSocketOpcode)
+	*p = x
+	return p
+}
+
+func (x WebSocketOpcode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebSocketOpcode) Descriptor() protoreflect.EnumDescriptor {
+	return file_audit_v1_websocket_details_proto_enumTypes[1].Descriptor()
+}
+
+func (WebSocketOpcode) Type() protoreflect.EnumType {
+	return &file_audit_v1_websocket_details_proto_enumTypes[1]
+}
+
+func (x WebSocketOpcode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebSocketOpcode.Descriptor instead.
+func (WebSocketOpcode) EnumDescriptor() ([]byte, []int) {
+	return file_audit_v1_websocket_details_proto_rawDescGZIP(), []int{1}
+}
+
+type WebSocketDetailsEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Direction   WebSocketDirection `protobuf:"varint,1,opt,name=direction,proto3,enum=inetmock.audit.v1.WebSocketDirection" json:"direction,omitempty"`
+	Opcode      WebSocketOpcode    `protobuf:"varint,2,opt,name=opcode,proto3,enum=inetmock.audit.v1.WebSocketOpcode" json:"opcode,omitempty"`
+	PayloadSize int64              `protobuf:"varint,3,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
+	// URI of the upgraded request e.g. /ws
+	Uri string `protobuf:"bytes,4,opt,name=uri,proto3" json:"uri,omitempty"`
+}
+
+func (x *WebSocketDetailsEntity) Reset() {
+	*x = WebSocketDetailsEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_audit_v1_websocket_details_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebSocketDetailsEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebSocketDetailsEntity) ProtoMessage() {}
+
+func (x *WebSocketDetailsEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_v1_websocket_details_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebSocketDetailsEntity.ProtoReflect.Descriptor instead.
+func (*WebSocketDetailsEntity) Descriptor() ([]byte, []int) {
+	return file_audit_v1_websocket_details_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WebSocketDetailsEntity) GetDirection() WebSocketDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return WebSocketDirection_WEB_SOCKET_DIRECTION_UNSPECIFIED
+}
+
+func (x *WebSocketDetailsEntity) GetOpcode() WebSocketOpcode {
+	if x != nil {
+		return x.Opcode
+	}
+	return WebSocketOpcode_WEB_SOCKET_OPCODE_UNSPECIFIED
+}
+
+func (x *WebSocketDetailsEntity) GetPayloadSize() int64 {
+	if x != nil {
+		return x.PayloadSize
+	}
+	return 0
+}
+
+func (x *WebSocketDetailsEntity) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+var File_audit_v1_websocket_details_proto protoreflect.FileDescriptor
+
+var file_audit_v1_websocket_details_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x77, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x11, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x2e, 0x76, 0x31, 0x22, 0xce, 0x01, 0x0a, 0x16, 0x57, 0x65, 0x62, 0x53, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x12, 0x43, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x53, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x06, 0x6f, 0x70, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x53, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x4f, 0x70, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x6f, 0x70, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x2a, 0x7f, 0x0a, 0x12, 0x57, 0x65, 0x62, 0x53, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x20,
+	0x57, 0x45, 0x42, 0x5f, 0x53, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x57, 0x45, 0x42, 0x5f, 0x53, 0x4f, 0x43, 0x4b, 0x45, 0x54,
+	0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x42, 0x4f, 0x55,
+	0x4e, 0x44, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x57, 0x45, 0x42, 0x5f, 0x53, 0x4f, 0x43, 0x4b,
+	0x45, 0x54, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4f, 0x55, 0x54,
+	0x42, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x2a, 0xc3, 0x01, 0x0a, 0x0f, 0x57, 0x65, 0x62, 0x53,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x70, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x57,
+	0x45, 0x42, 0x5f, 0x53, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a,
+	0x0a, 0x16, 0x57, 0x45, 0x42, 0x5f, 0x53, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x4f, 0x50, 0x43,
+	0x4f, 0x44, 0x45, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x57, 0x45,
+	0x42, 0x5f, 0x53, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x57, 0x45, 0x42, 0x5f,
+	0x53, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x4c,
+	0x4f, 0x53, 0x45, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x57, 0x45, 0x42, 0x5f, 0x53, 0x4f, 0x43,
+	0x4b, 0x45, 0x54, 0x5f, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x50, 0x49, 0x4e, 0x47, 0x10,
+	0x04, 0x12, 0x1a, 0x0a, 0x16, 0x57, 0x45, 0x42, 0x5f, 0x53, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f,
+	0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x50, 0x4f, 0x4e, 0x47, 0x10, 0x05, 0x42, 0xc9, 0x01,
+	0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x42, 0x15, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x02,
+	0x50, 0x01, 0x5a, 0x31, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x69, 0x63, 0x62,
+	0x34, 0x64, 0x63, 0x30, 0x2e, 0x64, 0x65, 0x2f, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x49, 0x41, 0x58, 0xaa, 0x02, 0x11, 0x49, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x31, 0xca,
+	0x02, 0x11, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x1d, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x5c, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x49, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x3a, 0x3a,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_audit_v1_websocket_details_proto_rawDescOnce sync.Once
+	file_audit_v1_websocket_details_proto_rawDescData = file_audit_v1_websocket_details_proto_rawDesc
+)
+
+func file_audit_v1_websocket_details_proto_rawDescGZIP() []byte {
+	file_audit_v1_websocket_details_proto_rawDescOnce.Do(func() {
+		file_audit_v1_websocket_details_proto_rawDescData = protoimpl.X.CompressGZIP(file_audit_v1_websocket_details_proto_rawDescData)
+	})
+	return file_audit_v1_websocket_details_proto_rawDescData
+}
+
+var file_audit_v1_websocket_details_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_audit_v1_websocket_details_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_audit_v1_websocket_details_proto_goTypes = []interface{}{
+	(WebSocketDirection)(0),        // 0: inetmock.audit.v1.WebSocketDirection
+	(WebSocketOpcode)(0),           // 1: inetmock.audit.v1.WebSocketOpcode
+	(*WebSocketDetailsEntity)(nil), // 2: inetmock.audit.v1.WebSocketDetailsEntity
+}
+var file_audit_v1_websocket_details_proto_depIdxs = []int32{
+	0, // 0: inetmock.audit.v1.WebSocketDetailsEntity.direction:type_name -> inetmock.audit.v1.WebSocketDirection
+	1, // 1: inetmock.audit.v1.WebSocketDetailsEntity.opcode:type_name -> inetmock.audit.v1.WebSocketOpcode
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_audit_v1_websocket_details_proto_init() }
+func file_audit_v1_websocket_details_proto_init() {
+	if File_audit_v1_websocket_details_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_audit_v1_websocket_details_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebSocketDetailsEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_audit_v1_websocket_details_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_audit_v1_websocket_details_proto_goTypes,
+		DependencyIndexes: file_audit_v1_websocket_details_proto_depIdxs,
+		EnumInfos:         file_audit_v1_websocket_details_proto_enumTypes,
+		MessageInfos:      file_audit_v1_websocket_details_proto_msgTypes,
+	}.Build()
+	File_audit_v1_websocket_details_proto = out.File
+	file_audit_v1_websocket_details_proto_rawDesc = nil
+	file_audit_v1_websocket_details_proto_goTypes = nil
+	file_audit_v1_websocket_details_proto_depIdxs = nil
+}
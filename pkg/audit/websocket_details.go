@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"reflect"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var _ Details = (*WebSocket)(nil)
+
+func init() {
+	AddMapping(reflect.TypeOf(new(auditv1.EventEntity_WebSocket)), func(msg *auditv1.EventEntity) Details {
+		var entity *auditv1.WebSocketDetailsEntity
+		if e, ok := msg.ProtocolDetails.(*auditv1.EventEntity_WebSocket); !ok {
+			return nil
+		} else {
+			entity = e.WebSocket
+		}
+
+		return &WebSocket{
+			Direction:   entity.Direction,
+			Opcode:      entity.Opcode,
+			PayloadSize: entity.PayloadSize,
+			URI:         entity.Uri,
+		}
+	})
+}
+
+// WebSocket describes a single frame received from or sent to a client after its HTTP request was upgraded.
+// URI is the URI of the upgraded request.
+type WebSocket struct {
+	Direction   auditv1.WebSocketDirection
+	Opcode      auditv1.WebSocketOpcode
+	PayloadSize int64
+	URI         string
+}
+
+func (d WebSocket) AddToMsg(msg *auditv1.EventEntity) {
+	msg.ProtocolDetails = &auditv1.EventEntity_WebSocket{
+		WebSocket: &auditv1.WebSocketDetailsEntity{
+			Direction:   d.Direction,
+			Opcode:      d.Opcode,
+			PayloadSize: d.PayloadSize,
+			Uri:         d.URI,
+		},
+	}
+}
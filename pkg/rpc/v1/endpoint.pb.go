@@ -12,6 +12,7 @@ import (
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 )
 
 const (
@@ -21,6 +22,113 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ClientAuthMode int32
+
+const (
+	ClientAuthMode_CLIENT_AUTH_MODE_UNSPECIFIED ClientAuthMode = 0
+	ClientAuthMode_CLIENT_AUTH_MODE_NONE        ClientAuthMode = 1
+	ClientAuthMode_CLIENT_AUTH_MODE_REQUEST     ClientAuthMode = 2
+	ClientAuthMode_CLIENT_AUTH_MODE_REQUIRE     ClientAuthMode = 3
+)
+
+// Enum value maps for ClientAuthMode.
+var (
+	ClientAuthMode_name = map[int32]string{
+		0: "CLIENT_AUTH_MODE_UNSPECIFIED",
+		1: "CLIENT_AUTH_MODE_NONE",
+		2: "CLIENT_AUTH_MODE_REQUEST",
+		3: "CLIENT_AUTH_MODE_REQUIRE",
+	}
+	ClientAuthMode_value = map[string]int32{
+		"CLIENT_AUTH_MODE_UNSPECIFIED": 0,
+		"CLIENT_AUTH_MODE_NONE":        1,
+		"CLIENT_AUTH_MODE_REQUEST":     2,
+		"CLIENT_AUTH_MODE_REQUIRE":     3,
+	}
+)
+
+func (x ClientAuthMode) Enum() *ClientAuthMode {
+	p := new(ClientAuthMode)
+	*p = x
+	return p
+}
+
+func (x ClientAuthMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ClientAuthMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_v1_endpoint_proto_enumTypes[0].Descriptor()
+}
+
+func (ClientAuthMode) Type() protoreflect.EnumType {
+	return &file_rpc_v1_endpoint_proto_enumTypes[0]
+}
+
+func (x ClientAuthMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ClientAuthMode.Descriptor instead.
+func (ClientAuthMode) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{0}
+}
+
+type ReconcileAction int32
+
+const (
+	ReconcileAction_RECONCILE_ACTION_UNSPECIFIED ReconcileAction = 0
+	ReconcileAction_RECONCILE_ACTION_UNCHANGED   ReconcileAction = 1
+	ReconcileAction_RECONCILE_ACTION_ADDED       ReconcileAction = 2
+	ReconcileAction_RECONCILE_ACTION_UPDATED     ReconcileAction = 3
+	ReconcileAction_RECONCILE_ACTION_REMOVED     ReconcileAction = 4
+)
+
+// Enum value maps for ReconcileAction.
+var (
+	ReconcileAction_name = map[int32]string{
+		0: "RECONCILE_ACTION_UNSPECIFIED",
+		1: "RECONCILE_ACTION_UNCHANGED",
+		2: "RECONCILE_ACTION_ADDED",
+		3: "RECONCILE_ACTION_UPDATED",
+		4: "RECONCILE_ACTION_REMOVED",
+	}
+	ReconcileAction_value = map[string]int32{
+		"RECONCILE_ACTION_UNSPECIFIED": 0,
+		"RECONCILE_ACTION_UNCHANGED":   1,
+		"RECONCILE_ACTION_ADDED":       2,
+		"RECONCILE_ACTION_UPDATED":     3,
+		"RECONCILE_ACTION_REMOVED":     4,
+	}
+)
+
+func (x ReconcileAction) Enum() *ReconcileAction {
+	p := new(ReconcileAction)
+	*p = x
+	return p
+}
+
+func (x ReconcileAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReconcileAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_v1_endpoint_proto_enumTypes[1].Descriptor()
+}
+
+func (ReconcileAction) Type() protoreflect.EnumType {
+	return &file_rpc_v1_endpoint_proto_enumTypes[1]
+}
+
+func (x ReconcileAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReconcileAction.Descriptor instead.
+func (ReconcileAction) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{1}
+}
+
 type ListenerGroup struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -28,6 +136,8 @@ type ListenerGroup struct {
 
 	Name      string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Endpoints []string `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	Address   string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Addresses []string `protobuf:"bytes,4,rep,name=addresses,proto3" json:"addresses,omitempty"`
 }
 
 func (x *ListenerGroup) Reset() {
@@ -76,6 +186,253 @@ func (x *ListenerGroup) GetEndpoints() []string {
 	return nil
 }
 
+func (x *ListenerGroup) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListenerGroup) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type ClientAuthSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// unspecified is the same as none i.e. clients are not asked for a certificate
+	Mode ClientAuthMode `protobuf:"varint,1,opt,name=mode,proto3,enum=inetmock.rpc.v1.ClientAuthMode" json:"mode,omitempty"`
+	// PEM files of the CAs client certificates are verified against, if empty any certificate is accepted
+	CaFiles []string `protobuf:"bytes,2,rep,name=ca_files,json=caFiles,proto3" json:"ca_files,omitempty"`
+}
+
+func (x *ClientAuthSpec) Reset() {
+	*x = ClientAuthSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientAuthSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientAuthSpec) ProtoMessage() {}
+
+func (x *ClientAuthSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientAuthSpec.ProtoReflect.Descriptor instead.
+func (*ClientAuthSpec) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ClientAuthSpec) GetMode() ClientAuthMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ClientAuthMode_CLIENT_AUTH_MODE_UNSPECIFIED
+}
+
+func (x *ClientAuthSpec) GetCaFiles() []string {
+	if x != nil {
+		return x.CaFiles
+	}
+	return nil
+}
+
+type EndpointSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Handler string           `protobuf:"bytes,1,opt,name=handler,proto3" json:"handler,omitempty"`
+	Tls     bool             `protobuf:"varint,2,opt,name=tls,proto3" json:"tls,omitempty"`
+	Options *structpb.Struct `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	// server name patterns TLS connections are routed to the endpoint by
+	Sni        []string        `protobuf:"bytes,4,rep,name=sni,proto3" json:"sni,omitempty"`
+	ClientAuth *ClientAuthSpec `protobuf:"bytes,5,opt,name=client_auth,json=clientAuth,proto3" json:"client_auth,omitempty"`
+}
+
+func (x *EndpointSpec) Reset() {
+	*x = EndpointSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EndpointSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndpointSpec) ProtoMessage() {}
+
+func (x *EndpointSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndpointSpec.ProtoReflect.Descriptor instead.
+func (*EndpointSpec) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EndpointSpec) GetHandler() string {
+	if x != nil {
+		return x.Handler
+	}
+	return ""
+}
+
+func (x *EndpointSpec) GetTls() bool {
+	if x != nil {
+		return x.Tls
+	}
+	return false
+}
+
+func (x *EndpointSpec) GetOptions() *structpb.Struct {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *EndpointSpec) GetSni() []string {
+	if x != nil {
+		return x.Sni
+	}
+	return nil
+}
+
+func (x *EndpointSpec) GetClientAuth() *ClientAuthSpec {
+	if x != nil {
+		return x.ClientAuth
+	}
+	return nil
+}
+
+type ListenerSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Protocol      string                   `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	ListenAddress string                   `protobuf:"bytes,3,opt,name=listen_address,json=listenAddress,proto3" json:"listen_address,omitempty"`
+	Port          uint32                   `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	Endpoints     map[string]*EndpointSpec `protobuf:"bytes,5,rep,name=endpoints,proto3" json:"endpoints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Unmanaged     bool                     `protobuf:"varint,6,opt,name=unmanaged,proto3" json:"unmanaged,omitempty"`
+	// additional addresses the listener group binds to
+	ListenAddresses []string `protobuf:"bytes,7,rep,name=listen_addresses,json=listenAddresses,proto3" json:"listen_addresses,omitempty"`
+}
+
+func (x *ListenerSpec) Reset() {
+	*x = ListenerSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListenerSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerSpec) ProtoMessage() {}
+
+func (x *ListenerSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerSpec.ProtoReflect.Descriptor instead.
+func (*ListenerSpec) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListenerSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListenerSpec) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ListenerSpec) GetListenAddress() string {
+	if x != nil {
+		return x.ListenAddress
+	}
+	return ""
+}
+
+func (x *ListenerSpec) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ListenerSpec) GetEndpoints() map[string]*EndpointSpec {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *ListenerSpec) GetUnmanaged() bool {
+	if x != nil {
+		return x.Unmanaged
+	}
+	return false
+}
+
+func (x *ListenerSpec) GetListenAddresses() []string {
+	if x != nil {
+		return x.ListenAddresses
+	}
+	return nil
+}
+
 type ListAllServingGroupsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -85,7 +442,7 @@ type ListAllServingGroupsRequest struct {
 func (x *ListAllServingGroupsRequest) Reset() {
 	*x = ListAllServingGroupsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[1]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -98,7 +455,7 @@ func (x *ListAllServingGroupsRequest) String() string {
 func (*ListAllServingGroupsRequest) ProtoMessage() {}
 
 func (x *ListAllServingGroupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[1]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -111,7 +468,7 @@ func (x *ListAllServingGroupsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAllServingGroupsRequest.ProtoReflect.Descriptor instead.
 func (*ListAllServingGroupsRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{1}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{4}
 }
 
 type ListAllServingGroupsResponse struct {
@@ -125,7 +482,7 @@ type ListAllServingGroupsResponse struct {
 func (x *ListAllServingGroupsResponse) Reset() {
 	*x = ListAllServingGroupsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[2]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -138,7 +495,7 @@ func (x *ListAllServingGroupsResponse) String() string {
 func (*ListAllServingGroupsResponse) ProtoMessage() {}
 
 func (x *ListAllServingGroupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[2]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -151,7 +508,7 @@ func (x *ListAllServingGroupsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAllServingGroupsResponse.ProtoReflect.Descriptor instead.
 func (*ListAllServingGroupsResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{2}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListAllServingGroupsResponse) GetGroups() []*ListenerGroup {
@@ -170,7 +527,7 @@ type ListAllConfiguredGroupsRequest struct {
 func (x *ListAllConfiguredGroupsRequest) Reset() {
 	*x = ListAllConfiguredGroupsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[3]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -183,7 +540,7 @@ func (x *ListAllConfiguredGroupsRequest) String() string {
 func (*ListAllConfiguredGroupsRequest) ProtoMessage() {}
 
 func (x *ListAllConfiguredGroupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[3]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -196,7 +553,7 @@ func (x *ListAllConfiguredGroupsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAllConfiguredGroupsRequest.ProtoReflect.Descriptor instead.
 func (*ListAllConfiguredGroupsRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{3}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{6}
 }
 
 type ListAllConfiguredGroupsResponse struct {
@@ -210,7 +567,7 @@ type ListAllConfiguredGroupsResponse struct {
 func (x *ListAllConfiguredGroupsResponse) Reset() {
 	*x = ListAllConfiguredGroupsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[4]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -223,7 +580,7 @@ func (x *ListAllConfiguredGroupsResponse) String() string {
 func (*ListAllConfiguredGroupsResponse) ProtoMessage() {}
 
 func (x *ListAllConfiguredGroupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[4]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -236,7 +593,7 @@ func (x *ListAllConfiguredGroupsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAllConfiguredGroupsResponse.ProtoReflect.Descriptor instead.
 func (*ListAllConfiguredGroupsResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{4}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListAllConfiguredGroupsResponse) GetGroups() []*ListenerGroup {
@@ -257,7 +614,7 @@ type StartListenerGroupRequest struct {
 func (x *StartListenerGroupRequest) Reset() {
 	*x = StartListenerGroupRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[5]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -270,7 +627,7 @@ func (x *StartListenerGroupRequest) String() string {
 func (*StartListenerGroupRequest) ProtoMessage() {}
 
 func (x *StartListenerGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[5]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -283,7 +640,7 @@ func (x *StartListenerGroupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartListenerGroupRequest.ProtoReflect.Descriptor instead.
 func (*StartListenerGroupRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{5}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *StartListenerGroupRequest) GetGroupName() string {
@@ -302,7 +659,7 @@ type StartListenerGroupResponse struct {
 func (x *StartListenerGroupResponse) Reset() {
 	*x = StartListenerGroupResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[6]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -315,7 +672,7 @@ func (x *StartListenerGroupResponse) String() string {
 func (*StartListenerGroupResponse) ProtoMessage() {}
 
 func (x *StartListenerGroupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[6]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +685,7 @@ func (x *StartListenerGroupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartListenerGroupResponse.ProtoReflect.Descriptor instead.
 func (*StartListenerGroupResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{6}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{9}
 }
 
 type StartAllGroupsRequest struct {
@@ -340,7 +697,7 @@ type StartAllGroupsRequest struct {
 func (x *StartAllGroupsRequest) Reset() {
 	*x = StartAllGroupsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[7]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -353,7 +710,7 @@ func (x *StartAllGroupsRequest) String() string {
 func (*StartAllGroupsRequest) ProtoMessage() {}
 
 func (x *StartAllGroupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[7]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,7 +723,7 @@ func (x *StartAllGroupsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartAllGroupsRequest.ProtoReflect.Descriptor instead.
 func (*StartAllGroupsRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{7}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{10}
 }
 
 type StartAllGroupsResponse struct {
@@ -378,7 +735,7 @@ type StartAllGroupsResponse struct {
 func (x *StartAllGroupsResponse) Reset() {
 	*x = StartAllGroupsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[8]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -391,7 +748,7 @@ func (x *StartAllGroupsResponse) String() string {
 func (*StartAllGroupsResponse) ProtoMessage() {}
 
 func (x *StartAllGroupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[8]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -404,7 +761,7 @@ func (x *StartAllGroupsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartAllGroupsResponse.ProtoReflect.Descriptor instead.
 func (*StartAllGroupsResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{8}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{11}
 }
 
 type StopListenerGroupRequest struct {
@@ -418,7 +775,7 @@ type StopListenerGroupRequest struct {
 func (x *StopListenerGroupRequest) Reset() {
 	*x = StopListenerGroupRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[9]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -431,7 +788,7 @@ func (x *StopListenerGroupRequest) String() string {
 func (*StopListenerGroupRequest) ProtoMessage() {}
 
 func (x *StopListenerGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[9]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +801,7 @@ func (x *StopListenerGroupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopListenerGroupRequest.ProtoReflect.Descriptor instead.
 func (*StopListenerGroupRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{9}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *StopListenerGroupRequest) GetGroupName() string {
@@ -463,7 +820,7 @@ type StopListenerGroupResponse struct {
 func (x *StopListenerGroupResponse) Reset() {
 	*x = StopListenerGroupResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[10]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -476,7 +833,7 @@ func (x *StopListenerGroupResponse) String() string {
 func (*StopListenerGroupResponse) ProtoMessage() {}
 
 func (x *StopListenerGroupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[10]
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,32 +846,444 @@ func (x *StopListenerGroupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopListenerGroupResponse.ProtoReflect.Descriptor instead.
 func (*StopListenerGroupResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{10}
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{13}
+}
+
+type StopAllGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StopAllGroupsRequest) Reset() {
+	*x = StopAllGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopAllGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopAllGroupsRequest) ProtoMessage() {}
+
+func (x *StopAllGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopAllGroupsRequest.ProtoReflect.Descriptor instead.
+func (*StopAllGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{14}
+}
+
+type StopAllGroupsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StopAllGroupsResponse) Reset() {
+	*x = StopAllGroupsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopAllGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopAllGroupsResponse) ProtoMessage() {}
+
+func (x *StopAllGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopAllGroupsResponse.ProtoReflect.Descriptor instead.
+func (*StopAllGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{15}
+}
+
+type RestartListenerGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupName string `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+}
+
+func (x *RestartListenerGroupRequest) Reset() {
+	*x = RestartListenerGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestartListenerGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartListenerGroupRequest) ProtoMessage() {}
+
+func (x *RestartListenerGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartListenerGroupRequest.ProtoReflect.Descriptor instead.
+func (*RestartListenerGroupRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestartListenerGroupRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+type RestartListenerGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RestartListenerGroupResponse) Reset() {
+	*x = RestartListenerGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestartListenerGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartListenerGroupResponse) ProtoMessage() {}
+
+func (x *RestartListenerGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartListenerGroupResponse.ProtoReflect.Descriptor instead.
+func (*RestartListenerGroupResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{17}
+}
+
+type RestartAllGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RestartAllGroupsRequest) Reset() {
+	*x = RestartAllGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestartAllGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartAllGroupsRequest) ProtoMessage() {}
+
+func (x *RestartAllGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartAllGroupsRequest.ProtoReflect.Descriptor instead.
+func (*RestartAllGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{18}
+}
+
+type RestartAllGroupsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RestartAllGroupsResponse) Reset() {
+	*x = RestartAllGroupsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestartAllGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartAllGroupsResponse) ProtoMessage() {}
+
+func (x *RestartAllGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartAllGroupsResponse.ProtoReflect.Descriptor instead.
+func (*RestartAllGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{19}
+}
+
+type ListenerGroupReconcileResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupName string          `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Action    ReconcileAction `protobuf:"varint,2,opt,name=action,proto3,enum=inetmock.rpc.v1.ReconcileAction" json:"action,omitempty"`
+	Success   bool            `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Error     string          `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ListenerGroupReconcileResult) Reset() {
+	*x = ListenerGroupReconcileResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListenerGroupReconcileResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerGroupReconcileResult) ProtoMessage() {}
+
+func (x *ListenerGroupReconcileResult) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerGroupReconcileResult.ProtoReflect.Descriptor instead.
+func (*ListenerGroupReconcileResult) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListenerGroupReconcileResult) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *ListenerGroupReconcileResult) GetAction() ReconcileAction {
+	if x != nil {
+		return x.Action
+	}
+	return ReconcileAction_RECONCILE_ACTION_UNSPECIFIED
+}
+
+func (x *ListenerGroupReconcileResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListenerGroupReconcileResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CreateListenerGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spec  *ListenerSpec `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Start bool          `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+}
+
+func (x *CreateListenerGroupRequest) Reset() {
+	*x = CreateListenerGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateListenerGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateListenerGroupRequest) ProtoMessage() {}
+
+func (x *CreateListenerGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateListenerGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateListenerGroupRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateListenerGroupRequest) GetSpec() *ListenerSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *CreateListenerGroupRequest) GetStart() bool {
+	if x != nil {
+		return x.Start
+	}
+	return false
+}
+
+type CreateListenerGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group *ListenerGroup `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *CreateListenerGroupResponse) Reset() {
+	*x = CreateListenerGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateListenerGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateListenerGroupResponse) ProtoMessage() {}
+
+func (x *CreateListenerGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateListenerGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateListenerGroupResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{22}
 }
 
-type StopAllGroupsRequest struct {
+func (x *CreateListenerGroupResponse) GetGroup() *ListenerGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type DeleteListenerGroupRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	GroupName string `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
 }
 
-func (x *StopAllGroupsRequest) Reset() {
-	*x = StopAllGroupsRequest{}
+func (x *DeleteListenerGroupRequest) Reset() {
+	*x = DeleteListenerGroupRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[11]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StopAllGroupsRequest) String() string {
+func (x *DeleteListenerGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopAllGroupsRequest) ProtoMessage() {}
+func (*DeleteListenerGroupRequest) ProtoMessage() {}
 
-func (x *StopAllGroupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[11]
+func (x *DeleteListenerGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,34 +1294,41 @@ func (x *StopAllGroupsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopAllGroupsRequest.ProtoReflect.Descriptor instead.
-func (*StopAllGroupsRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DeleteListenerGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteListenerGroupRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{23}
 }
 
-type StopAllGroupsResponse struct {
+func (x *DeleteListenerGroupRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+type DeleteListenerGroupResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *StopAllGroupsResponse) Reset() {
-	*x = StopAllGroupsResponse{}
+func (x *DeleteListenerGroupResponse) Reset() {
+	*x = DeleteListenerGroupResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[12]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StopAllGroupsResponse) String() string {
+func (x *DeleteListenerGroupResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopAllGroupsResponse) ProtoMessage() {}
+func (*DeleteListenerGroupResponse) ProtoMessage() {}
 
-func (x *StopAllGroupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[12]
+func (x *DeleteListenerGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,36 +1339,38 @@ func (x *StopAllGroupsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopAllGroupsResponse.ProtoReflect.Descriptor instead.
-func (*StopAllGroupsResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use DeleteListenerGroupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteListenerGroupResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{24}
 }
 
-type RestartListenerGroupRequest struct {
+type UpdateEndpointOptionsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GroupName string `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	GroupName    string           `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	EndpointName string           `protobuf:"bytes,2,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
+	Options      *structpb.Struct `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
 }
 
-func (x *RestartListenerGroupRequest) Reset() {
-	*x = RestartListenerGroupRequest{}
+func (x *UpdateEndpointOptionsRequest) Reset() {
+	*x = UpdateEndpointOptionsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[13]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RestartListenerGroupRequest) String() string {
+func (x *UpdateEndpointOptionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestartListenerGroupRequest) ProtoMessage() {}
+func (*UpdateEndpointOptionsRequest) ProtoMessage() {}
 
-func (x *RestartListenerGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[13]
+func (x *UpdateEndpointOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -603,41 +1381,55 @@ func (x *RestartListenerGroupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestartListenerGroupRequest.ProtoReflect.Descriptor instead.
-func (*RestartListenerGroupRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use UpdateEndpointOptionsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEndpointOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *RestartListenerGroupRequest) GetGroupName() string {
+func (x *UpdateEndpointOptionsRequest) GetGroupName() string {
 	if x != nil {
 		return x.GroupName
 	}
 	return ""
 }
 
-type RestartListenerGroupResponse struct {
+func (x *UpdateEndpointOptionsRequest) GetEndpointName() string {
+	if x != nil {
+		return x.EndpointName
+	}
+	return ""
+}
+
+func (x *UpdateEndpointOptionsRequest) GetOptions() *structpb.Struct {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type UpdateEndpointOptionsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *RestartListenerGroupResponse) Reset() {
-	*x = RestartListenerGroupResponse{}
+func (x *UpdateEndpointOptionsResponse) Reset() {
+	*x = UpdateEndpointOptionsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[14]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RestartListenerGroupResponse) String() string {
+func (x *UpdateEndpointOptionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestartListenerGroupResponse) ProtoMessage() {}
+func (*UpdateEndpointOptionsResponse) ProtoMessage() {}
 
-func (x *RestartListenerGroupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[14]
+func (x *UpdateEndpointOptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,34 +1440,34 @@ func (x *RestartListenerGroupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestartListenerGroupResponse.ProtoReflect.Descriptor instead.
-func (*RestartListenerGroupResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use UpdateEndpointOptionsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateEndpointOptionsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{26}
 }
 
-type RestartAllGroupsRequest struct {
+type ReloadConfigRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *RestartAllGroupsRequest) Reset() {
-	*x = RestartAllGroupsRequest{}
+func (x *ReloadConfigRequest) Reset() {
+	*x = ReloadConfigRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[15]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RestartAllGroupsRequest) String() string {
+func (x *ReloadConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestartAllGroupsRequest) ProtoMessage() {}
+func (*ReloadConfigRequest) ProtoMessage() {}
 
-func (x *RestartAllGroupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[15]
+func (x *ReloadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -686,34 +1478,36 @@ func (x *RestartAllGroupsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestartAllGroupsRequest.ProtoReflect.Descriptor instead.
-func (*RestartAllGroupsRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ReloadConfigRequest.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{27}
 }
 
-type RestartAllGroupsResponse struct {
+type ReloadConfigResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Results []*ListenerGroupReconcileResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *RestartAllGroupsResponse) Reset() {
-	*x = RestartAllGroupsResponse{}
+func (x *ReloadConfigResponse) Reset() {
+	*x = ReloadConfigResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpc_v1_endpoint_proto_msgTypes[16]
+		mi := &file_rpc_v1_endpoint_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RestartAllGroupsResponse) String() string {
+func (x *ReloadConfigResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestartAllGroupsResponse) ProtoMessage() {}
+func (*ReloadConfigResponse) ProtoMessage() {}
 
-func (x *RestartAllGroupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_v1_endpoint_proto_msgTypes[16]
+func (x *ReloadConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_v1_endpoint_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -724,9 +1518,16 @@ func (x *RestartAllGroupsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestartAllGroupsResponse.ProtoReflect.Descriptor instead.
-func (*RestartAllGroupsResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ReloadConfigResponse.ProtoReflect.Descriptor instead.
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_v1_endpoint_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReloadConfigResponse) GetResults() []*ListenerGroupReconcileResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
 }
 
 var File_rpc_v1_endpoint_proto protoreflect.FileDescriptor
@@ -734,110 +1535,251 @@ var File_rpc_v1_endpoint_proto protoreflect.FileDescriptor
 var file_rpc_v1_endpoint_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
 	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
-	0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x22, 0x41, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74,
-	0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a,
-	0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x1d, 0x0a, 0x1b, 0x4c,
-	0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x56, 0x0a, 0x1c, 0x4c, 0x69,
-	0x73, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x6e, 0x65,
+	0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x79, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x22, 0x60, 0x0a, 0x0e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x53,
+	0x70, 0x65, 0x63, 0x12, 0x33, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x61, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x61, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x22, 0xc1, 0x01, 0x0a, 0x0c, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x53, 0x70, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x6c, 0x73,
+	0x12, 0x31, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x6e, 0x69, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x03, 0x73, 0x6e, 0x69, 0x12, 0x40, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x61, 0x75, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x53, 0x70, 0x65, 0x63, 0x52, 0x0a, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x22, 0xeb, 0x02, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x4a, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
+	0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x53, 0x70, 0x65, 0x63, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x75, 0x6e, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x12, 0x29, 0x0a,
+	0x10, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x1a, 0x5b, 0x0a, 0x0e, 0x45, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1d, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x56, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x20, 0x0a, 0x1e,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
+	0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x59,
+	0x0a, 0x1f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x36, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x3a, 0x0a, 0x19, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x1c, 0x0a, 0x1a, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x18, 0x0a, 0x16,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x39, 0x0a, 0x18, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d,
+	0x65, 0x22, 0x1b, 0x0a, 0x19, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16,
+	0x0a, 0x14, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x6c,
+	0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x3c, 0x0a, 0x1b, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x1e, 0x0a,
+	0x1c, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x19, 0x0a,
+	0x17, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x1a, 0x0a, 0x18, 0x52, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa7, 0x01, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x65,
+	0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x04,
+	0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x65,
 	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75,
-	0x70, 0x73, 0x22, 0x20, 0x0a, 0x1e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x22, 0x59, 0x0a, 0x1f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f,
-	0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
-	0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22,
-	0x3a, 0x0a, 0x19, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
-	0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x1c, 0x0a, 0x1a, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x22, 0x18, 0x0a, 0x16, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x39, 0x0a, 0x18,
-	0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0x53, 0x0a, 0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x3b, 0x0a, 0x1a, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75,
 	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75,
 	0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x53, 0x74, 0x6f, 0x70, 0x4c,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x6c, 0x6c, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x17, 0x0a, 0x15,
-	0x53, 0x74, 0x6f, 0x70, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3c, 0x0a, 0x1b, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e,
-	0x61, 0x6d, 0x65, 0x22, 0x1e, 0x0a, 0x1c, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69,
+	0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x1d, 0x0a, 0x1b, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x95, 0x01, 0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x1f,
+	0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x15, 0x0a, 0x13, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5f, 0x0a, 0x14, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47,
+	0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x2a, 0x89, 0x01, 0x0a, 0x0e, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4c,
+	0x49, 0x45, 0x4e, 0x54, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15,
+	0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4c, 0x49, 0x45, 0x4e,
+	0x54, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55,
+	0x45, 0x53, 0x54, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f,
+	0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52,
+	0x45, 0x10, 0x03, 0x2a, 0xab, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c,
+	0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x45, 0x43, 0x4f, 0x4e,
+	0x43, 0x49, 0x4c, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45, 0x43,
+	0x4f, 0x4e, 0x43, 0x49, 0x4c, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e,
+	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x43,
+	0x4f, 0x4e, 0x43, 0x49, 0x4c, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x44,
+	0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x4e, 0x43, 0x49,
+	0x4c, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45,
+	0x44, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x4e, 0x43, 0x49, 0x4c, 0x45,
+	0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10,
+	0x04, 0x32, 0xc5, 0x0a, 0x0a, 0x1b, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4f, 0x72,
+	0x63, 0x68, 0x65, 0x73, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x73, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x2c, 0x2e, 0x69, 0x6e, 0x65, 0x74,
+	0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f,
+	0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c,
+	0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7c, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c,
+	0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x12, 0x2f, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x30, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2a, 0x2e, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
+	0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69,
 	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c,
-	0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x1a,
-	0x0a, 0x18, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x8c, 0x07, 0x0a, 0x1b, 0x45,
-	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4f, 0x72, 0x63, 0x68, 0x65, 0x73, 0x74, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x73, 0x0a, 0x14, 0x4c, 0x69,
-	0x73, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x73, 0x12, 0x2c, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70,
-	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e,
-	0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x7c, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x75, 0x72, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x2f, 0x2e, 0x69, 0x6e, 0x65,
-	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x69, 0x6e,
-	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x41, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a,
-	0x12, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x12, 0x2a, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65,
-	0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x2b, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0e,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x26,
-	0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
-	0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c,
-	0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x6a, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x12, 0x29, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x65,
-	0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x2a, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0d, 0x53,
-	0x74, 0x6f, 0x70, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x25, 0x2e, 0x69,
-	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x74, 0x6f, 0x70, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72,
+	0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x26, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e,
+	0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x29, 0x2e, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x6f, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
+	0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x12, 0x25, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72,
 	0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x73, 0x0a, 0x14, 0x52,
-	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x12, 0x2c, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73,
-	0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65,
-	0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x67, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x73, 0x12, 0x28, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c,
-	0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29,
-	0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xb3, 0x01, 0x0a, 0x13, 0x63, 0x6f,
+	0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f,
+	0x70, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x73, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2c, 0x2e, 0x69, 0x6e, 0x65,
+	0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d,
+	0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x28, 0x2e, 0x69, 0x6e,
+	0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41,
+	0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x70, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2b, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f,
+	0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x70, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2b, 0x2e, 0x69, 0x6e, 0x65, 0x74,
+	0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63,
+	0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x76, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x2e,
+	0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x69,
+	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x0c,
+	0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x2e, 0x69,
+	0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x25, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xb3, 0x01, 0x0a, 0x13, 0x63, 0x6f,
 	0x6d, 0x2e, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x76,
 	0x31, 0x42, 0x0d, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f,
 	0x48, 0x02, 0x50, 0x01, 0x5a, 0x2d, 0x69, 0x6e, 0x65, 0x74, 0x6d, 0x6f, 0x63, 0x6b, 0x2e, 0x69,
@@ -864,50 +1806,85 @@ func file_rpc_v1_endpoint_proto_rawDescGZIP() []byte {
 	return file_rpc_v1_endpoint_proto_rawDescData
 }
 
-var file_rpc_v1_endpoint_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_rpc_v1_endpoint_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_rpc_v1_endpoint_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_rpc_v1_endpoint_proto_goTypes = []interface{}{
-	(*ListenerGroup)(nil),                   // 0: inetmock.rpc.v1.ListenerGroup
-	(*ListAllServingGroupsRequest)(nil),     // 1: inetmock.rpc.v1.ListAllServingGroupsRequest
-	(*ListAllServingGroupsResponse)(nil),    // 2: inetmock.rpc.v1.ListAllServingGroupsResponse
-	(*ListAllConfiguredGroupsRequest)(nil),  // 3: inetmock.rpc.v1.ListAllConfiguredGroupsRequest
-	(*ListAllConfiguredGroupsResponse)(nil), // 4: inetmock.rpc.v1.ListAllConfiguredGroupsResponse
-	(*StartListenerGroupRequest)(nil),       // 5: inetmock.rpc.v1.StartListenerGroupRequest
-	(*StartListenerGroupResponse)(nil),      // 6: inetmock.rpc.v1.StartListenerGroupResponse
-	(*StartAllGroupsRequest)(nil),           // 7: inetmock.rpc.v1.StartAllGroupsRequest
-	(*StartAllGroupsResponse)(nil),          // 8: inetmock.rpc.v1.StartAllGroupsResponse
-	(*StopListenerGroupRequest)(nil),        // 9: inetmock.rpc.v1.StopListenerGroupRequest
-	(*StopListenerGroupResponse)(nil),       // 10: inetmock.rpc.v1.StopListenerGroupResponse
-	(*StopAllGroupsRequest)(nil),            // 11: inetmock.rpc.v1.StopAllGroupsRequest
-	(*StopAllGroupsResponse)(nil),           // 12: inetmock.rpc.v1.StopAllGroupsResponse
-	(*RestartListenerGroupRequest)(nil),     // 13: inetmock.rpc.v1.RestartListenerGroupRequest
-	(*RestartListenerGroupResponse)(nil),    // 14: inetmock.rpc.v1.RestartListenerGroupResponse
-	(*RestartAllGroupsRequest)(nil),         // 15: inetmock.rpc.v1.RestartAllGroupsRequest
-	(*RestartAllGroupsResponse)(nil),        // 16: inetmock.rpc.v1.RestartAllGroupsResponse
+	(ClientAuthMode)(0),                     // 0: inetmock.rpc.v1.ClientAuthMode
+	(ReconcileAction)(0),                    // 1: inetmock.rpc.v1.ReconcileAction
+	(*ListenerGroup)(nil),                   // 2: inetmock.rpc.v1.ListenerGroup
+	(*ClientAuthSpec)(nil),                  // 3: inetmock.rpc.v1.ClientAuthSpec
+	(*EndpointSpec)(nil),                    // 4: inetmock.rpc.v1.EndpointSpec
+	(*ListenerSpec)(nil),                    // 5: inetmock.rpc.v1.ListenerSpec
+	(*ListAllServingGroupsRequest)(nil),     // 6: inetmock.rpc.v1.ListAllServingGroupsRequest
+	(*ListAllServingGroupsResponse)(nil),    // 7: inetmock.rpc.v1.ListAllServingGroupsResponse
+	(*ListAllConfiguredGroupsRequest)(nil),  // 8: inetmock.rpc.v1.ListAllConfiguredGroupsRequest
+	(*ListAllConfiguredGroupsResponse)(nil), // 9: inetmock.rpc.v1.ListAllConfiguredGroupsResponse
+	(*StartListenerGroupRequest)(nil),       // 10: inetmock.rpc.v1.StartListenerGroupRequest
+	(*StartListenerGroupResponse)(nil),      // 11: inetmock.rpc.v1.StartListenerGroupResponse
+	(*StartAllGroupsRequest)(nil),           // 12: inetmock.rpc.v1.StartAllGroupsRequest
+	(*StartAllGroupsResponse)(nil),          // 13: inetmock.rpc.v1.StartAllGroupsResponse
+	(*StopListenerGroupRequest)(nil),        // 14: inetmock.rpc.v1.StopListenerGroupRequest
+	(*StopListenerGroupResponse)(nil),       // 15: inetmock.rpc.v1.StopListenerGroupResponse
+	(*StopAllGroupsRequest)(nil),            // 16: inetmock.rpc.v1.StopAllGroupsRequest
+	(*StopAllGroupsResponse)(nil),           // 17: inetmock.rpc.v1.StopAllGroupsResponse
+	(*RestartListenerGroupRequest)(nil),     // 18: inetmock.rpc.v1.RestartListenerGroupRequest
+	(*RestartListenerGroupResponse)(nil),    // 19: inetmock.rpc.v1.RestartListenerGroupResponse
+	(*RestartAllGroupsRequest)(nil),         // 20: inetmock.rpc.v1.RestartAllGroupsRequest
+	(*RestartAllGroupsResponse)(nil),        // 21: inetmock.rpc.v1.RestartAllGroupsResponse
+	(*ListenerGroupReconcileResult)(nil),    // 22: inetmock.rpc.v1.ListenerGroupReconcileResult
+	(*CreateListenerGroupRequest)(nil),      // 23: inetmock.rpc.v1.CreateListenerGroupRequest
+	(*CreateListenerGroupResponse)(nil),     // 24: inetmock.rpc.v1.CreateListenerGroupResponse
+	(*DeleteListenerGroupRequest)(nil),      // 25: inetmock.rpc.v1.DeleteListenerGroupRequest
+	(*DeleteListenerGroupResponse)(nil),     // 26: inetmock.rpc.v1.DeleteListenerGroupResponse
+	(*UpdateEndpointOptionsRequest)(nil),    // 27: inetmock.rpc.v1.UpdateEndpointOptionsRequest
+	(*UpdateEndpointOptionsResponse)(nil),   // 28: inetmock.rpc.v1.UpdateEndpointOptionsResponse
+	(*ReloadConfigRequest)(nil),             // 29: inetmock.rpc.v1.ReloadConfigRequest
+	(*ReloadConfigResponse)(nil),            // 30: inetmock.rpc.v1.ReloadConfigResponse
+	nil,                                     // 31: inetmock.rpc.v1.ListenerSpec.EndpointsEntry
+	(*structpb.Struct)(nil),                 // 32: google.protobuf.Struct
 }
 var file_rpc_v1_endpoint_proto_depIdxs = []int32{
-	0,  // 0: inetmock.rpc.v1.ListAllServingGroupsResponse.groups:type_name -> inetmock.rpc.v1.ListenerGroup
-	0,  // 1: inetmock.rpc.v1.ListAllConfiguredGroupsResponse.groups:type_name -> inetmock.rpc.v1.ListenerGroup
-	1,  // 2: inetmock.rpc.v1.EndpointOrchestratorService.ListAllServingGroups:input_type -> inetmock.rpc.v1.ListAllServingGroupsRequest
-	3,  // 3: inetmock.rpc.v1.EndpointOrchestratorService.ListAllConfiguredGroups:input_type -> inetmock.rpc.v1.ListAllConfiguredGroupsRequest
-	5,  // 4: inetmock.rpc.v1.EndpointOrchestratorService.StartListenerGroup:input_type -> inetmock.rpc.v1.StartListenerGroupRequest
-	7,  // 5: inetmock.rpc.v1.EndpointOrchestratorService.StartAllGroups:input_type -> inetmock.rpc.v1.StartAllGroupsRequest
-	9,  // 6: inetmock.rpc.v1.EndpointOrchestratorService.StopListenerGroup:input_type -> inetmock.rpc.v1.StopListenerGroupRequest
-	11, // 7: inetmock.rpc.v1.EndpointOrchestratorService.StopAllGroups:input_type -> inetmock.rpc.v1.StopAllGroupsRequest
-	13, // 8: inetmock.rpc.v1.EndpointOrchestratorService.RestartListenerGroup:input_type -> inetmock.rpc.v1.RestartListenerGroupRequest
-	15, // 9: inetmock.rpc.v1.EndpointOrchestratorService.RestartAllGroups:input_type -> inetmock.rpc.v1.RestartAllGroupsRequest
-	2,  // 10: inetmock.rpc.v1.EndpointOrchestratorService.ListAllServingGroups:output_type -> inetmock.rpc.v1.ListAllServingGroupsResponse
-	4,  // 11: inetmock.rpc.v1.EndpointOrchestratorService.ListAllConfiguredGroups:output_type -> inetmock.rpc.v1.ListAllConfiguredGroupsResponse
-	6,  // 12: inetmock.rpc.v1.EndpointOrchestratorService.StartListenerGroup:output_type -> inetmock.rpc.v1.StartListenerGroupResponse
-	8,  // 13: inetmock.rpc.v1.EndpointOrchestratorService.StartAllGroups:output_type -> inetmock.rpc.v1.StartAllGroupsResponse
-	10, // 14: inetmock.rpc.v1.EndpointOrchestratorService.StopListenerGroup:output_type -> inetmock.rpc.v1.StopListenerGroupResponse
-	12, // 15: inetmock.rpc.v1.EndpointOrchestratorService.StopAllGroups:output_type -> inetmock.rpc.v1.StopAllGroupsResponse
-	14, // 16: inetmock.rpc.v1.EndpointOrchestratorService.RestartListenerGroup:output_type -> inetmock.rpc.v1.RestartListenerGroupResponse
-	16, // 17: inetmock.rpc.v1.EndpointOrchestratorService.RestartAllGroups:output_type -> inetmock.rpc.v1.RestartAllGroupsResponse
-	10, // [10:18] is the sub-list for method output_type
-	2,  // [2:10] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	0,  // 0: inetmock.rpc.v1.ClientAuthSpec.mode:type_name -> inetmock.rpc.v1.ClientAuthMode
+	32, // 1: inetmock.rpc.v1.EndpointSpec.options:type_name -> google.protobuf.Struct
+	3,  // 2: inetmock.rpc.v1.EndpointSpec.client_auth:type_name -> inetmock.rpc.v1.ClientAuthSpec
+	31, // 3: inetmock.rpc.v1.ListenerSpec.endpoints:type_name -> inetmock.rpc.v1.ListenerSpec.EndpointsEntry
+	2,  // 4: inetmock.rpc.v1.ListAllServingGroupsResponse.groups:type_name -> inetmock.rpc.v1.ListenerGroup
+	2,  // 5: inetmock.rpc.v1.ListAllConfiguredGroupsResponse.groups:type_name -> inetmock.rpc.v1.ListenerGroup
+	1,  // 6: inetmock.rpc.v1.ListenerGroupReconcileResult.action:type_name -> inetmock.rpc.v1.ReconcileAction
+	5,  // 7: inetmock.rpc.v1.CreateListenerGroupRequest.spec:type_name -> inetmock.rpc.v1.ListenerSpec
+	2,  // 8: inetmock.rpc.v1.CreateListenerGroupResponse.group:type_name -> inetmock.rpc.v1.ListenerGroup
+	32, // 9: inetmock.rpc.v1.UpdateEndpointOptionsRequest.options:type_name -> google.protobuf.Struct
+	22, // 10: inetmock.rpc.v1.ReloadConfigResponse.results:type_name -> inetmock.rpc.v1.ListenerGroupReconcileResult
+	4,  // 11: inetmock.rpc.v1.ListenerSpec.EndpointsEntry.value:type_name -> inetmock.rpc.v1.EndpointSpec
+	6,  // 12: inetmock.rpc.v1.EndpointOrchestratorService.ListAllServingGroups:input_type -> inetmock.rpc.v1.ListAllServingGroupsRequest
+	8,  // 13: inetmock.rpc.v1.EndpointOrchestratorService.ListAllConfiguredGroups:input_type -> inetmock.rpc.v1.ListAllConfiguredGroupsRequest
+	10, // 14: inetmock.rpc.v1.EndpointOrchestratorService.StartListenerGroup:input_type -> inetmock.rpc.v1.StartListenerGroupRequest
+	12, // 15: inetmock.rpc.v1.EndpointOrchestratorService.StartAllGroups:input_type -> inetmock.rpc.v1.StartAllGroupsRequest
+	14, // 16: inetmock.rpc.v1.EndpointOrchestratorService.StopListenerGroup:input_type -> inetmock.rpc.v1.StopListenerGroupRequest
+	16, // 17: inetmock.rpc.v1.EndpointOrchestratorService.StopAllGroups:input_type -> inetmock.rpc.v1.StopAllGroupsRequest
+	18, // 18: inetmock.rpc.v1.EndpointOrchestratorService.RestartListenerGroup:input_type -> inetmock.rpc.v1.RestartListenerGroupRequest
+	20, // 19: inetmock.rpc.v1.EndpointOrchestratorService.RestartAllGroups:input_type -> inetmock.rpc.v1.RestartAllGroupsRequest
+	23, // 20: inetmock.rpc.v1.EndpointOrchestratorService.CreateListenerGroup:input_type -> inetmock.rpc.v1.CreateListenerGroupRequest
+	25, // 21: inetmock.rpc.v1.EndpointOrchestratorService.DeleteListenerGroup:input_type -> inetmock.rpc.v1.DeleteListenerGroupRequest
+	27, // 22: inetmock.rpc.v1.EndpointOrchestratorService.UpdateEndpointOptions:input_type -> inetmock.rpc.v1.UpdateEndpointOptionsRequest
+	29, // 23: inetmock.rpc.v1.EndpointOrchestratorService.ReloadConfig:input_type -> inetmock.rpc.v1.ReloadConfigRequest
+	7,  // 24: inetmock.rpc.v1.EndpointOrchestratorService.ListAllServingGroups:output_type -> inetmock.rpc.v1.ListAllServingGroupsResponse
+	9,  // 25: inetmock.rpc.v1.EndpointOrchestratorService.ListAllConfiguredGroups:output_type -> inetmock.rpc.v1.ListAllConfiguredGroupsResponse
+	11, // 26: inetmock.rpc.v1.EndpointOrchestratorService.StartListenerGroup:output_type -> inetmock.rpc.v1.StartListenerGroupResponse
+	13, // 27: inetmock.rpc.v1.EndpointOrchestratorService.StartAllGroups:output_type -> inetmock.rpc.v1.StartAllGroupsResponse
+	15, // 28: inetmock.rpc.v1.EndpointOrchestratorService.StopListenerGroup:output_type -> inetmock.rpc.v1.StopListenerGroupResponse
+	17, // 29: inetmock.rpc.v1.EndpointOrchestratorService.StopAllGroups:output_type -> inetmock.rpc.v1.StopAllGroupsResponse
+	19, // 30: inetmock.rpc.v1.EndpointOrchestratorService.RestartListenerGroup:output_type -> inetmock.rpc.v1.RestartListenerGroupResponse
+	21, // 31: inetmock.rpc.v1.EndpointOrchestratorService.RestartAllGroups:output_type -> inetmock.rpc.v1.RestartAllGroupsResponse
+	24, // 32: inetmock.rpc.v1.EndpointOrchestratorService.CreateListenerGroup:output_type -> inetmock.rpc.v1.CreateListenerGroupResponse
+	26, // 33: inetmock.rpc.v1.EndpointOrchestratorService.DeleteListenerGroup:output_type -> inetmock.rpc.v1.DeleteListenerGroupResponse
+	28, // 34: inetmock.rpc.v1.EndpointOrchestratorService.UpdateEndpointOptions:output_type -> inetmock.rpc.v1.UpdateEndpointOptionsResponse
+	30, // 35: inetmock.rpc.v1.EndpointOrchestratorService.ReloadConfig:output_type -> inetmock.rpc.v1.ReloadConfigResponse
+	24, // [24:36] is the sub-list for method output_type
+	12, // [12:24] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_rpc_v1_endpoint_proto_init() }
@@ -929,7 +1906,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListAllServingGroupsRequest); i {
+			switch v := v.(*ClientAuthSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -941,7 +1918,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListAllServingGroupsResponse); i {
+			switch v := v.(*EndpointSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -953,7 +1930,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListAllConfiguredGroupsRequest); i {
+			switch v := v.(*ListenerSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -965,7 +1942,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListAllConfiguredGroupsResponse); i {
+			switch v := v.(*ListAllServingGroupsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -977,7 +1954,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartListenerGroupRequest); i {
+			switch v := v.(*ListAllServingGroupsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -989,7 +1966,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartListenerGroupResponse); i {
+			switch v := v.(*ListAllConfiguredGroupsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1001,7 +1978,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartAllGroupsRequest); i {
+			switch v := v.(*ListAllConfiguredGroupsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1013,7 +1990,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartAllGroupsResponse); i {
+			switch v := v.(*StartListenerGroupRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1025,7 +2002,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopListenerGroupRequest); i {
+			switch v := v.(*StartListenerGroupResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1037,7 +2014,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopListenerGroupResponse); i {
+			switch v := v.(*StartAllGroupsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1049,7 +2026,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopAllGroupsRequest); i {
+			switch v := v.(*StartAllGroupsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1061,7 +2038,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopAllGroupsResponse); i {
+			switch v := v.(*StopListenerGroupRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1073,7 +2050,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RestartListenerGroupRequest); i {
+			switch v := v.(*StopListenerGroupResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1085,7 +2062,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RestartListenerGroupResponse); i {
+			switch v := v.(*StopAllGroupsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1097,7 +2074,7 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RestartAllGroupsRequest); i {
+			switch v := v.(*StopAllGroupsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1109,6 +2086,42 @@ func file_rpc_v1_endpoint_proto_init() {
 			}
 		}
 		file_rpc_v1_endpoint_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestartListenerGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestartListenerGroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestartAllGroupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RestartAllGroupsResponse); i {
 			case 0:
 				return &v.state
@@ -1120,19 +2133,128 @@ func file_rpc_v1_endpoint_proto_init() {
 				return nil
 			}
 		}
+		file_rpc_v1_endpoint_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListenerGroupReconcileResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateListenerGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateListenerGroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteListenerGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteListenerGroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateEndpointOptionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateEndpointOptionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_v1_endpoint_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpc_v1_endpoint_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   17,
+			NumEnums:      2,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_rpc_v1_endpoint_proto_goTypes,
 		DependencyIndexes: file_rpc_v1_endpoint_proto_depIdxs,
+		EnumInfos:         file_rpc_v1_endpoint_proto_enumTypes,
 		MessageInfos:      file_rpc_v1_endpoint_proto_msgTypes,
 	}.Build()
 	File_rpc_v1_endpoint_proto = out.File
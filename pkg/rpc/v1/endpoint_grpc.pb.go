@@ -31,6 +31,10 @@ type EndpointOrchestratorServiceClient interface {
 	StopAllGroups(ctx context.Context, in *StopAllGroupsRequest, opts ...grpc.CallOption) (*StopAllGroupsResponse, error)
 	RestartListenerGroup(ctx context.Context, in *RestartListenerGroupRequest, opts ...grpc.CallOption) (*RestartListenerGroupResponse, error)
 	RestartAllGroups(ctx context.Context, in *RestartAllGroupsRequest, opts ...grpc.CallOption) (*RestartAllGroupsResponse, error)
+	CreateListenerGroup(ctx context.Context, in *CreateListenerGroupRequest, opts ...grpc.CallOption) (*CreateListenerGroupResponse, error)
+	DeleteListenerGroup(ctx context.Context, in *DeleteListenerGroupRequest, opts ...grpc.CallOption) (*DeleteListenerGroupResponse, error)
+	UpdateEndpointOptions(ctx context.Context, in *UpdateEndpointOptionsRequest, opts ...grpc.CallOption) (*UpdateEndpointOptionsResponse, error)
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
 }
 
 type endpointOrchestratorServiceClient struct {
@@ -113,6 +117,42 @@ func (c *endpointOrchestratorServiceClient) RestartAllGroups(ctx context.Context
 	return out, nil
 }
 
+func (c *endpointOrchestratorServiceClient) CreateListenerGroup(ctx context.Context, in *CreateListenerGroupRequest, opts ...grpc.CallOption) (*CreateListenerGroupResponse, error) {
+	out := new(CreateListenerGroupResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.EndpointOrchestratorService/CreateListenerGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointOrchestratorServiceClient) DeleteListenerGroup(ctx context.Context, in *DeleteListenerGroupRequest, opts ...grpc.CallOption) (*DeleteListenerGroupResponse, error) {
+	out := new(DeleteListenerGroupResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.EndpointOrchestratorService/DeleteListenerGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointOrchestratorServiceClient) UpdateEndpointOptions(ctx context.Context, in *UpdateEndpointOptionsRequest, opts ...grpc.CallOption) (*UpdateEndpointOptionsResponse, error) {
+	out := new(UpdateEndpointOptionsResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.EndpointOrchestratorService/UpdateEndpointOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointOrchestratorServiceClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.EndpointOrchestratorService/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EndpointOrchestratorServiceServer is the server API for EndpointOrchestratorService service.
 // All implementations must embed UnimplementedEndpointOrchestratorServiceServer
 // for forward compatibility
@@ -125,6 +165,10 @@ type EndpointOrchestratorServiceServer interface {
 	StopAllGroups(context.Context, *StopAllGroupsRequest) (*StopAllGroupsResponse, error)
 	RestartListenerGroup(context.Context, *RestartListenerGroupRequest) (*RestartListenerGroupResponse, error)
 	RestartAllGroups(context.Context, *RestartAllGroupsRequest) (*RestartAllGroupsResponse, error)
+	CreateListenerGroup(context.Context, *CreateListenerGroupRequest) (*CreateListenerGroupResponse, error)
+	DeleteListenerGroup(context.Context, *DeleteListenerGroupRequest) (*DeleteListenerGroupResponse, error)
+	UpdateEndpointOptions(context.Context, *UpdateEndpointOptionsRequest) (*UpdateEndpointOptionsResponse, error)
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
 	mustEmbedUnimplementedEndpointOrchestratorServiceServer()
 }
 
@@ -156,6 +200,18 @@ func (UnimplementedEndpointOrchestratorServiceServer) RestartListenerGroup(conte
 func (UnimplementedEndpointOrchestratorServiceServer) RestartAllGroups(context.Context, *RestartAllGroupsRequest) (*RestartAllGroupsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RestartAllGroups not implemented")
 }
+func (UnimplementedEndpointOrchestratorServiceServer) CreateListenerGroup(context.Context, *CreateListenerGroupRequest) (*CreateListenerGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateListenerGroup not implemented")
+}
+func (UnimplementedEndpointOrchestratorServiceServer) DeleteListenerGroup(context.Context, *DeleteListenerGroupRequest) (*DeleteListenerGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteListenerGroup not implemented")
+}
+func (UnimplementedEndpointOrchestratorServiceServer) UpdateEndpointOptions(context.Context, *UpdateEndpointOptionsRequest) (*UpdateEndpointOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEndpointOptions not implemented")
+}
+func (UnimplementedEndpointOrchestratorServiceServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
 func (UnimplementedEndpointOrchestratorServiceServer) mustEmbedUnimplementedEndpointOrchestratorServiceServer() {
 }
 
@@ -314,6 +370,78 @@ func _EndpointOrchestratorService_RestartAllGroups_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EndpointOrchestratorService_CreateListenerGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateListenerGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointOrchestratorServiceServer).CreateListenerGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.EndpointOrchestratorService/CreateListenerGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointOrchestratorServiceServer).CreateListenerGroup(ctx, req.(*CreateListenerGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointOrchestratorService_DeleteListenerGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteListenerGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointOrchestratorServiceServer).DeleteListenerGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.EndpointOrchestratorService/DeleteListenerGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointOrchestratorServiceServer).DeleteListenerGroup(ctx, req.(*DeleteListenerGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointOrchestratorService_UpdateEndpointOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEndpointOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointOrchestratorServiceServer).UpdateEndpointOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.EndpointOrchestratorService/UpdateEndpointOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointOrchestratorServiceServer).UpdateEndpointOptions(ctx, req.(*UpdateEndpointOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointOrchestratorService_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointOrchestratorServiceServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.EndpointOrchestratorService/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointOrchestratorServiceServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EndpointOrchestratorService_ServiceDesc is the grpc.ServiceDesc for EndpointOrchestratorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -353,6 +481,22 @@ var EndpointOrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RestartAllGroups",
 			Handler:    _EndpointOrchestratorService_RestartAllGroups_Handler,
 		},
+		{
+			MethodName: "CreateListenerGroup",
+			Handler:    _EndpointOrchestratorService_CreateListenerGroup_Handler,
+		},
+		{
+			MethodName: "DeleteListenerGroup",
+			Handler:    _EndpointOrchestratorService_DeleteListenerGroup_Handler,
+		},
+		{
+			MethodName: "UpdateEndpointOptions",
+			Handler:    _EndpointOrchestratorService_UpdateEndpointOptions_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _EndpointOrchestratorService_ReloadConfig_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc/v1/endpoint.proto",
@@ -0,0 +1,106 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: rpc/v1/messaging.proto
+
+package rpcv1
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// MessagingServiceClient is the client API for MessagingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MessagingServiceClient interface {
+	InjectMessage(ctx context.Context, in *InjectMessageRequest, opts ...grpc.CallOption) (*InjectMessageResponse, error)
+}
+
+type messagingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMessagingServiceClient(cc grpc.ClientConnInterface) MessagingServiceClient {
+	return &messagingServiceClient{cc}
+}
+
+func (c *messagingServiceClient) InjectMessage(ctx context.Context, in *InjectMessageRequest, opts ...grpc.CallOption) (*InjectMessageResponse, error) {
+	out := new(InjectMessageResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.MessagingService/InjectMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MessagingServiceServer is the server API for MessagingService service.
+// All implementations must embed UnimplementedMessagingServiceServer
+// for forward compatibility
+type MessagingServiceServer interface {
+	InjectMessage(context.Context, *InjectMessageRequest) (*InjectMessageResponse, error)
+	mustEmbedUnimplementedMessagingServiceServer()
+}
+
+// UnimplementedMessagingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMessagingServiceServer struct {
+}
+
+func (UnimplementedMessagingServiceServer) InjectMessage(context.Context, *InjectMessageRequest) (*InjectMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectMessage not implemented")
+}
+func (UnimplementedMessagingServiceServer) mustEmbedUnimplementedMessagingServiceServer() {}
+
+// UnsafeMessagingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MessagingServiceServer will
+// result in compilation errors.
+type UnsafeMessagingServiceServer interface {
+	mustEmbedUnimplementedMessagingServiceServer()
+}
+
+func RegisterMessagingServiceServer(s grpc.ServiceRegistrar, srv MessagingServiceServer) {
+	s.RegisterService(&MessagingService_ServiceDesc, srv)
+}
+
+func _MessagingService_InjectMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessagingServiceServer).InjectMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.MessagingService/InjectMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessagingServiceServer).InjectMessage(ctx, req.(*InjectMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MessagingService_ServiceDesc is the grpc.ServiceDesc for MessagingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MessagingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inetmock.rpc.v1.MessagingService",
+	HandlerType: (*MessagingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InjectMessage",
+			Handler:    _MessagingService_InjectMessage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc/v1/messaging.proto",
+}
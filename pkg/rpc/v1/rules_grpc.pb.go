@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: rpc/v1/rules.proto
+
+package rpcv1
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RulesServiceClient is the client API for RulesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RulesServiceClient interface {
+	ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error)
+	InsertRule(ctx context.Context, in *InsertRuleRequest, opts ...grpc.CallOption) (*InsertRuleResponse, error)
+	ReplaceRule(ctx context.Context, in *ReplaceRuleRequest, opts ...grpc.CallOption) (*ReplaceRuleResponse, error)
+	DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*DeleteRuleResponse, error)
+	ValidateRule(ctx context.Context, in *ValidateRuleRequest, opts ...grpc.CallOption) (*ValidateRuleResponse, error)
+}
+
+type rulesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRulesServiceClient(cc grpc.ClientConnInterface) RulesServiceClient {
+	return &rulesServiceClient{cc}
+}
+
+func (c *rulesServiceClient) ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error) {
+	out := new(ListRulesResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.RulesService/ListRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesServiceClient) InsertRule(ctx context.Context, in *InsertRuleRequest, opts ...grpc.CallOption) (*InsertRuleResponse, error) {
+	out := new(InsertRuleResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.RulesService/InsertRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesServiceClient) ReplaceRule(ctx context.Context, in *ReplaceRuleRequest, opts ...grpc.CallOption) (*ReplaceRuleResponse, error) {
+	out := new(ReplaceRuleResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.RulesService/ReplaceRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesServiceClient) DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*DeleteRuleResponse, error) {
+	out := new(DeleteRuleResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.RulesService/DeleteRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesServiceClient) ValidateRule(ctx context.Context, in *ValidateRuleRequest, opts ...grpc.CallOption) (*ValidateRuleResponse, error) {
+	out := new(ValidateRuleResponse)
+	err := c.cc.Invoke(ctx, "/inetmock.rpc.v1.RulesService/ValidateRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RulesServiceServer is the server API for RulesService service.
+// All implementations must embed UnimplementedRulesServiceServer
+// for forward compatibility
+type RulesServiceServer interface {
+	ListRules(context.Context, *ListRulesRequest) (*ListRulesResponse, error)
+	InsertRule(context.Context, *InsertRuleRequest) (*InsertRuleResponse, error)
+	ReplaceRule(context.Context, *ReplaceRuleRequest) (*ReplaceRuleResponse, error)
+	DeleteRule(context.Context, *DeleteRuleRequest) (*DeleteRuleResponse, error)
+	ValidateRule(context.Context, *ValidateRuleRequest) (*ValidateRuleResponse, error)
+	mustEmbedUnimplementedRulesServiceServer()
+}
+
+// UnimplementedRulesServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRulesServiceServer struct {
+}
+
+func (UnimplementedRulesServiceServer) ListRules(context.Context, *ListRulesRequest) (*ListRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRules not implemented")
+}
+func (UnimplementedRulesServiceServer) InsertRule(context.Context, *InsertRuleRequest) (*InsertRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertRule not implemented")
+}
+func (UnimplementedRulesServiceServer) ReplaceRule(context.Context, *ReplaceRuleRequest) (*ReplaceRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceRule not implemented")
+}
+func (UnimplementedRulesServiceServer) DeleteRule(context.Context, *DeleteRuleRequest) (*DeleteRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRule not implemented")
+}
+func (UnimplementedRulesServiceServer) ValidateRule(context.Context, *ValidateRuleRequest) (*ValidateRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateRule not implemented")
+}
+func (UnimplementedRulesServiceServer) mustEmbedUnimplementedRulesServiceServer() {}
+
+// UnsafeRulesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RulesServiceServer will
+// result in compilation errors.
+type UnsafeRulesServiceServer interface {
+	mustEmbedUnimplementedRulesServiceServer()
+}
+
+func RegisterRulesServiceServer(s grpc.ServiceRegistrar, srv RulesServiceServer) {
+	s.RegisterService(&RulesService_ServiceDesc, srv)
+}
+
+func _RulesService_ListRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesServiceServer).ListRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.RulesService/ListRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesServiceServer).ListRules(ctx, req.(*ListRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesService_InsertRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesServiceServer).InsertRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.RulesService/InsertRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesServiceServer).InsertRule(ctx, req.(*InsertRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesService_ReplaceRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesServiceServer).ReplaceRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.RulesService/ReplaceRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesServiceServer).ReplaceRule(ctx, req.(*ReplaceRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesService_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesServiceServer).DeleteRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.RulesService/DeleteRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesServiceServer).DeleteRule(ctx, req.(*DeleteRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesService_ValidateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesServiceServer).ValidateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inetmock.rpc.v1.RulesService/ValidateRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesServiceServer).ValidateRule(ctx, req.(*ValidateRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RulesService_ServiceDesc is the grpc.ServiceDesc for RulesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RulesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inetmock.rpc.v1.RulesService",
+	HandlerType: (*RulesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRules",
+			Handler:    _RulesService_ListRules_Handler,
+		},
+		{
+			MethodName: "InsertRule",
+			Handler:    _RulesService_InsertRule_Handler,
+		},
+		{
+			MethodName: "ReplaceRule",
+			Handler:    _RulesService_ReplaceRule_Handler,
+		},
+		{
+			MethodName: "DeleteRule",
+			Handler:    _RulesService_DeleteRule_Handler,
+		},
+		{
+			MethodName: "ValidateRule",
+			Handler:    _RulesService_ValidateRule_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc/v1/rules.proto",
+}
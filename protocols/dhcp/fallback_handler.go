@@ -4,13 +4,20 @@ import (
 	"net"
 
 	"github.com/insomniacslk/dhcp/dhcpv4"
+	"go.uber.org/zap"
 
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
+// optionWPAD is the private option 252 used by browsers to discover the PAC file
+const optionWPAD = dhcpv4.GenericOptionCode(252)
+
 type FallbackHandler struct {
 	Previous DHCPv4MessageHandler
 	Logger   logging.Logger
+	// WPAD is optional, if set its URL is announced as option 252
+	WPAD *wpad.Service
 	DefaultOptions
 }
 
@@ -24,6 +31,8 @@ func (h *FallbackHandler) Handle(req, resp *dhcpv4.DHCPv4) error {
 		DHCPv4MessageHandlerFunc(h.handleRouter),
 		DHCPv4MessageHandlerFunc(h.handleNetmask),
 		DHCPv4MessageHandlerFunc(h.handleDNS),
+		DHCPv4MessageHandlerFunc(h.handleBootFileName),
+		DHCPv4MessageHandlerFunc(h.handleWPAD),
 	}
 
 	for idx := range internalHandlers {
@@ -61,6 +70,22 @@ func (h *FallbackHandler) handleDNS(_, resp *dhcpv4.DHCPv4) error {
 	return nil
 }
 
+func (h *FallbackHandler) handleBootFileName(_, resp *dhcpv4.DHCPv4) error {
+	if h.BootFileName != "" && resp.BootFileName == "" {
+		h.Logger.Info("Set fallback boot file name", zap.String("boot_file_name", h.BootFileName))
+		setBootFileName(resp, h.BootFileName)
+	}
+	return nil
+}
+
+func (h *FallbackHandler) handleWPAD(_, resp *dhcpv4.DHCPv4) error {
+	if h.WPAD != nil && !resp.Options.Has(optionWPAD) {
+		h.Logger.Info("Set WPAD URL", zap.String("wpad_url", h.WPAD.URL()))
+		resp.Options.Update(dhcpv4.OptGeneric(optionWPAD, []byte(h.WPAD.URL())))
+	}
+	return nil
+}
+
 func (h *FallbackHandler) handleServerID(req, resp *dhcpv4.DHCPv4) error {
 	if req.OpCode != dhcpv4.OpcodeBootRequest {
 		return nil
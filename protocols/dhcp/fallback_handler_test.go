@@ -3,20 +3,32 @@ package dhcp_test
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"testing"
 
 	"github.com/insomniacslk/dhcp/dhcpv4"
 	"github.com/maxatome/go-testdeep/td"
 
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
 	"inetmock.icb4dc0.de/inetmock/protocols/dhcp"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
 func TestFallbackHandler_Handle(t *testing.T) {
 	t.Parallel()
+	wpadService, err := wpad.New(wpad.Config{
+		IP:            netip.MustParseAddr("10.10.1.1"),
+		ProxyListener: "tcp_3128",
+	}, map[string]endpoint.ListenerSpec{
+		"tcp_3128": {Protocol: "tcp", Port: 3128},
+	})
+	td.CmpNoError(t, err)
+
 	tests := []struct {
 		name           string
 		DefaultOptions dhcp.DefaultOptions
+		WPAD           *wpad.Service
 		want           any
 		wantErr        bool
 	}{
@@ -55,6 +67,16 @@ func TestFallbackHandler_Handle(t *testing.T) {
 			want:    WantOption(dhcpv4.OptionSubnetMask, net.IPv4(255, 255, 255, 255)),
 			wantErr: false,
 		},
+		{
+			name: "Set boot file name if missing",
+			DefaultOptions: dhcp.DefaultOptions{
+				BootFileName: "ipxe.efi",
+			},
+			want: td.Struct(new(dhcpv4.DHCPv4), td.StructFields{
+				"BootFileName": "ipxe.efi",
+			}),
+			wantErr: false,
+		},
 		{
 			name: "Set DNS if missing - single",
 			DefaultOptions: dhcp.DefaultOptions{
@@ -76,6 +98,27 @@ func TestFallbackHandler_Handle(t *testing.T) {
 			want:    WantDNS(net.IPv4(1, 1, 1, 1), net.IPv4(9, 9, 9, 9)),
 			wantErr: false,
 		},
+		{
+			name: "Set WPAD URL if configured",
+			WPAD: wpadService,
+			want: td.Code(func(resp *dhcpv4.DHCPv4) error {
+				if optVal := string(resp.Options.Get(dhcpv4.GenericOptionCode(252))); optVal != "http://10.10.1.1/wpad.dat" {
+					return fmt.Errorf("WPAD URL %q does not match", optVal)
+				}
+				return nil
+			}),
+			wantErr: false,
+		},
+		{
+			name: "Omit WPAD URL if not configured",
+			want: td.Code(func(resp *dhcpv4.DHCPv4) error {
+				if resp.Options.Has(dhcpv4.GenericOptionCode(252)) {
+					return fmt.Errorf("unexpected WPAD URL %q", resp.Options.Get(dhcpv4.GenericOptionCode(252)))
+				}
+				return nil
+			}),
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -84,6 +127,7 @@ func TestFallbackHandler_Handle(t *testing.T) {
 			h := &dhcp.FallbackHandler{
 				Previous:       dhcp.NoOpHandler,
 				Logger:         logging.CreateTestLogger(t),
+				WPAD:           tt.WPAD,
 				DefaultOptions: tt.DefaultOptions,
 			}
 			var (
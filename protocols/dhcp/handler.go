@@ -10,9 +10,11 @@ import (
 	"golang.org/x/net/ipv4"
 
 	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
 	"inetmock.icb4dc0.de/inetmock/internal/state"
 	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
 const (
@@ -20,10 +22,12 @@ const (
 )
 
 type dhcpHandler struct {
-	logger     logging.Logger
-	emitter    audit.Emitter
-	stateStore state.KVStore
-	server     *Server4
+	logger       logging.Logger
+	emitter      audit.Emitter
+	stateStore   state.KVStore
+	server       *Server4
+	ruledHandler *RuledHandler
+	wpadService  *wpad.Service
 }
 
 func (h *dhcpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) error {
@@ -44,7 +48,7 @@ func (h *dhcpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec
 		conn = c
 	}
 
-	rh := &RuledHandler{
+	h.ruledHandler = &RuledHandler{
 		HandlerName:     startupSpec.Name,
 		ProtocolOptions: options,
 		Logger:          h.logger,
@@ -53,7 +57,7 @@ func (h *dhcpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec
 
 	for idx := range options.Rules {
 		rule := options.Rules[idx]
-		if err := rh.RegisterRule(rule); err != nil {
+		if err := h.ruledHandler.RegisterRule(rule); err != nil {
 			h.logger.Error("Failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
 			return err
 		}
@@ -63,9 +67,10 @@ func (h *dhcpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec
 		PacketConn: conn,
 		Handler: &EmittingHandler{
 			Upstream: &FallbackHandler{
-				Previous:       rh,
+				Previous:       h.ruledHandler,
 				Logger:         h.logger,
 				DefaultOptions: options.Default,
+				WPAD:           h.wpadService,
 			},
 			Emitter: h.emitter,
 		},
@@ -84,6 +89,13 @@ func (h *dhcpHandler) Stop(context.Context) error {
 	return err
 }
 
+func (h *dhcpHandler) RuleManager() rules.Manager {
+	if h.ruledHandler == nil {
+		return nil
+	}
+	return h.ruledHandler.RuleManager()
+}
+
 func (h *dhcpHandler) serve() {
 	if err := h.server.Serve(); err != nil {
 		h.logger.Error("Failed to serve", zap.Error(err))
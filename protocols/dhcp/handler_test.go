@@ -191,7 +191,7 @@ func Test_dhcpHandler_Start(t *testing.T) {
 
 			srvAddr := randomUDPAddr(t)
 			lifecycle := endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(srvAddr), tt.args.opts)
-			handler := dhcp.New(logger, emitterMock, statetest.NewTestStore(t))
+			handler := dhcp.New(logger, emitterMock, statetest.NewTestStore(t), nil)
 			if err := handler.Start(ctx, lifecycle); err != nil {
 				if !tt.wantErr {
 					t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
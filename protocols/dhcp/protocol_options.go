@@ -20,6 +20,8 @@ type DefaultOptions struct {
 	Router    net.IP
 	Netmask   net.IP
 	LeaseTime time.Duration
+	// BootFileName is announced to PXE clients, the next server is always the ServerID
+	BootFileName string
 }
 
 type ProtocolOptions struct {
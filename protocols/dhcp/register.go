@@ -5,18 +5,27 @@ import (
 	"inetmock.icb4dc0.de/inetmock/internal/state"
 	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
-func New(logger logging.Logger, emitter audit.Emitter, stateStore state.KVStore) endpoint.ProtocolHandler {
+// New creates a dhcp_mock handler, if wpadService is not nil its URL is announced as option 252
+func New(logger logging.Logger, emitter audit.Emitter, stateStore state.KVStore, wpadService *wpad.Service) endpoint.ProtocolHandler {
 	return &dhcpHandler{
-		logger:     logger,
-		emitter:    emitter,
-		stateStore: stateStore,
+		logger:      logger,
+		emitter:     emitter,
+		stateStore:  stateStore,
+		wpadService: wpadService,
 	}
 }
 
-func AddDHCPMock(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter, stateStore state.KVStore) {
+func AddDHCPMock(
+	registry endpoint.HandlerRegistry,
+	logger logging.Logger,
+	emitter audit.Emitter,
+	stateStore state.KVStore,
+	wpadService *wpad.Service,
+) {
 	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
-		return New(logger, emitter, stateStore)
+		return New(logger, emitter, stateStore, wpadService)
 	})
 }
@@ -60,20 +60,39 @@ type RuledHandler struct {
 	ProtocolOptions ProtocolOptions
 	Logger          logging.Logger
 	StateStore      state.KVStore
-	handlers        []ConditionalHandler
+	handlers        rules.Set[ConditionalHandler]
 }
 
-func (h *RuledHandler) RegisterRule(rawRule string) (err error) {
+func (h *RuledHandler) RegisterRule(rawRule string) error {
 	h.Logger.Debug("Adding routing rule", zap.String("rawRule", rawRule))
-	var rule *rules.ChainedResponsePipeline
-	if rule, err = rules.Parse[rules.ChainedResponsePipeline](rawRule); err != nil {
+
+	conditionalHandler, err := h.compileRule(rawRule)
+	if err != nil {
 		return err
 	}
 
-	var conditionalHandler ConditionalHandler
+	h.Logger.Debug("Configure successfully parsed routing rule")
+	h.handlers.Append(rawRule, conditionalHandler)
+
+	return nil
+}
+
+// RuleManager exposes the lease rules, clients keep addresses already leased until they renew them.
+func (h *RuledHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalHandler]{
+		Set:     &h.handlers,
+		Compile: h.compileRule,
+	}
+}
+
+func (h *RuledHandler) compileRule(rawRule string) (conditionalHandler ConditionalHandler, err error) {
+	var rule *rules.ChainedResponsePipeline
+	if rule, err = rules.Parse[rules.ChainedResponsePipeline](rawRule); err != nil {
+		return conditionalHandler, err
+	}
 
 	if conditionalHandler.Chain, err = RequestFiltersForRoutingRule(rule); err != nil {
-		return err
+		return conditionalHandler, err
 	}
 
 	handlerOptions := HandlerOptions{
@@ -82,22 +101,21 @@ func (h *RuledHandler) RegisterRule(rawRule string) (err error) {
 		ProtocolOptions: h.ProtocolOptions,
 	}
 	if conditionalHandler.Handlers, err = HandlerForRoutingRule(rule, handlerOptions); err != nil {
-		return err
+		return conditionalHandler, err
 	}
 
-	h.Logger.Debug("Configure successfully parsed routing rule")
-	h.handlers = append(h.handlers, conditionalHandler)
-
-	return nil
+	return conditionalHandler, nil
 }
 
 func (h *RuledHandler) Handle(req, resp *dhcpv4.DHCPv4) error {
 	defer prometheus.NewTimer(protocols.RequestDurationHistogram.WithLabelValues("dhcp", h.HandlerName)).ObserveDuration()
 
-	for idx := range h.handlers {
-		handler := h.handlers[idx]
-		if handler.Chain.Matches(req) {
-			if err := handler.Handlers.Apply(req, resp); err != nil {
+	handlers := h.handlers.Entries()
+	for idx := range handlers {
+		handler := handlers[idx]
+		if handler.Value.Chain.Matches(req) {
+			protocols.RecordRuleMatch(handler, name, h.HandlerName, req.ClientHWAddr.String())
+			if err := handler.Value.Handlers.Apply(req, resp); err != nil {
 				return err
 			}
 			return nil
@@ -14,11 +14,12 @@ import (
 )
 
 var knownResponseHandlers = map[string]func(opts HandlerOptions, args ...rules.Param) (DHCPv4MessageHandler, error){
-	"ip":      StaticIPHandler,
-	"range":   IPRangeHandler,
-	"router":  RouterIPHandler,
-	"dns":     DNSHandler,
-	"netmask": NetmaskHandler,
+	"ip":       StaticIPHandler,
+	"range":    IPRangeHandler,
+	"router":   RouterIPHandler,
+	"dns":      DNSHandler,
+	"netmask":  NetmaskHandler,
+	"bootfile": BootFileHandler,
 }
 
 type HandlerOptions struct {
@@ -110,6 +111,31 @@ func DNSHandler(opts HandlerOptions, args ...rules.Param) (DHCPv4MessageHandler,
 	}), nil
 }
 
+// BootFileHandler announces the file PXE clients should fetch from the next server e.g. BootFile("pxelinux.0")
+func BootFileHandler(opts HandlerOptions, args ...rules.Param) (DHCPv4MessageHandler, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	bootFileName, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	handlerLogger := opts.Logger.With(zap.String("handler_type", "boot_file_handler"), zap.String("boot_file_name", bootFileName))
+	return DHCPv4MessageHandlerFunc(func(req, resp *dhcpv4.DHCPv4) error {
+		handlerLogger.Info("Set boot file name", zap.Stringer("client_mac", req.ClientHWAddr))
+		setBootFileName(resp, bootFileName)
+		return nil
+	}), nil
+}
+
+// setBootFileName sets the BOOTP file field as well as option 67 because not all clients evaluate both
+func setBootFileName(resp *dhcpv4.DHCPv4, bootFileName string) {
+	resp.BootFileName = bootFileName
+	resp.Options.Update(dhcpv4.OptBootFileName(bootFileName))
+}
+
 func singleIPModifier(
 	name string,
 	logger logging.Logger,
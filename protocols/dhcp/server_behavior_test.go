@@ -76,6 +76,22 @@ func TestHandlerForRoutingRule(t *testing.T) {
 			want:    WantOption(dhcpv4.OptionSubnetMask, net.IPv4(255, 255, 255, 0)),
 			wantErr: false,
 		},
+		{
+			name: "Boot file handler",
+			args: args{
+				rawRule: `=> BootFile("pxelinux.0")`,
+				req: &dhcpv4.DHCPv4{
+					ClientHWAddr: netutils.MustParseMAC("54:df:83:56:2c:f3"),
+				},
+			},
+			want: td.Struct(new(dhcpv4.DHCPv4), td.StructFields{
+				"BootFileName": "pxelinux.0",
+				"Options": td.Code(func(opts dhcpv4.Options) bool {
+					return string(opts.Get(dhcpv4.OptionBootfileName)) == "pxelinux.0"
+				}),
+			}),
+			wantErr: false,
+		},
 		{
 			name: "Single DNS option handler",
 			args: args{
@@ -142,12 +142,26 @@ func (c *Cache) ReverseLookup(address net.IP) (host string, miss bool) {
 	}
 }
 
+// Flush drops all records e.g. because the rules the answers were derived from changed.
+// The flushed entries stay in the queue until they expire but are not returned by any lookup.
+func (c *Cache) Flush() {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	c.forwardIndex = make(map[string]*queue.Entry)
+	c.reverseIndex = make(map[uint32]*queue.Entry)
+}
+
 func (c *Cache) onCacheEvicted(evictedItems []*queue.Entry) {
 	c.writeLock.Lock()
 	defer c.writeLock.Unlock()
 	for idx := range evictedItems {
+		// the index might already refer to a newer record of the same name or address e.g. after a flush
 		record := evictedItems[idx].Value.(*Record)
-		delete(c.forwardIndex, record.Name)
-		delete(c.reverseIndex, netutils.IPToInt32(record.Address))
+		if c.forwardIndex[record.Name] == evictedItems[idx] {
+			delete(c.forwardIndex, record.Name)
+		}
+		if ipIdx := netutils.IPToInt32(record.Address); c.reverseIndex[ipIdx] == evictedItems[idx] {
+			delete(c.reverseIndex, ipIdx)
+		}
 	}
 }
@@ -1,6 +1,7 @@
 package dns
 
 import (
+	"context"
 	"math"
 	"net"
 	"time"
@@ -18,6 +19,7 @@ type ResourceRecordCache interface {
 	PutRecord(host string, address net.IP)
 	ForwardLookup(host string) net.IP
 	ReverseLookup(address net.IP) (host string, miss bool)
+	Flush()
 }
 
 type CacheHandler struct {
@@ -26,10 +28,10 @@ type CacheHandler struct {
 	Fallback Handler
 }
 
-func (h *CacheHandler) AnswerDNSQuestion(q Question) (rr ResourceRecord, err error) {
+func (h *CacheHandler) AnswerDNSQuestion(ctx context.Context, q Question) (rr ResourceRecord, err error) {
 	switch q.Qtype {
 	case mdns.TypeA, mdns.TypeAAAA:
-		return h.answerForwardLookup(q)
+		return h.answerForwardLookup(ctx, q)
 	case mdns.TypePTR:
 		return h.answerReverseLookup(q)
 	default:
@@ -37,7 +39,7 @@ func (h *CacheHandler) AnswerDNSQuestion(q Question) (rr ResourceRecord, err err
 	}
 }
 
-func (h CacheHandler) answerForwardLookup(q Question) (rr ResourceRecord, err error) {
+func (h CacheHandler) answerForwardLookup(ctx context.Context, q Question) (rr ResourceRecord, err error) {
 	if ip := h.Cache.ForwardLookup(q.Name); ip != nil {
 		return &mdns.A{
 			A: ip,
@@ -50,7 +52,7 @@ func (h CacheHandler) answerForwardLookup(q Question) (rr ResourceRecord, err er
 		}, nil
 	}
 	// try to get answer from fallback handler
-	if rr, err = h.Fallback.AnswerDNSQuestion(q); err != nil {
+	if rr, err = h.Fallback.AnswerDNSQuestion(ctx, q); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,7 @@
 package dns_test
 
 import (
+	"context"
 	"net"
 	"testing"
 	"time"
@@ -63,7 +64,7 @@ func TestCacheHandler_AnswerDNSQuestion(t *testing.T) {
 						}
 					},
 				},
-				Fallback: dns.HandlerFunc(func(q dns.Question) (dns.ResourceRecord, error) {
+				Fallback: dns.HandlerFunc(func(_ context.Context, q dns.Question) (dns.ResourceRecord, error) {
 					return &mdns.A{
 						A: net.IPv4(10, 0, 10, 17),
 					}, nil
@@ -127,7 +128,7 @@ func TestCacheHandler_AnswerDNSQuestion(t *testing.T) {
 				TTL:      defaultTTL,
 				Fallback: tt.fields.Fallback,
 			}
-			gotRr, err := h.AnswerDNSQuestion(tt.question)
+			gotRr, err := h.AnswerDNSQuestion(context.Background(), tt.question)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AnswerDNSQuestion() error = %v, wantErr %v", err, tt.wantErr)
 				return
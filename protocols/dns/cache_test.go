@@ -52,6 +52,20 @@ func Test_cache_PutRecord(t *testing.T) {
 	}
 }
 
+func Test_cache_Flush(t *testing.T) {
+	t.Parallel()
+	c := dns.NewCache(dns.WithTTL(time.Minute), dns.WithInitialSize(500))
+	c.PutRecord("localhost", net.IPv4(127, 0, 0, 1))
+	c.Flush()
+
+	td.Cmp(t, c.ForwardLookup("localhost"), td.Nil())
+	_, miss := c.ReverseLookup(net.IPv4(127, 0, 0, 1))
+	td.Cmp(t, miss, true)
+
+	c.PutRecord("localhost", net.IPv4(127, 0, 0, 2))
+	td.Cmp(t, c.ForwardLookup("localhost"), net.IPv4(127, 0, 0, 2))
+}
+
 //nolint:gosec
 func Test_cache_ForwardLookup(t *testing.T) {
 	t.Parallel()
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
 	"inetmock.icb4dc0.de/inetmock/multiplexing"
 	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
@@ -18,9 +19,10 @@ import (
 )
 
 type dohHandler struct {
-	logger  logging.Logger
-	emitter audit.Emitter
-	server  *Server
+	logger      logging.Logger
+	emitter     audit.Emitter
+	server      *Server
+	ruleHandler *dns.RuleHandler
 }
 
 func (d dohHandler) Matchers() []cmux.Matcher {
@@ -45,8 +47,12 @@ func (d *dohHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec)
 	)
 
 	ruleHandler := &dns.RuleHandler{
-		TTL: options.TTL,
+		HandlerRef:  name,
+		HandlerName: startupSpec.Name,
+		TTL:         options.TTL,
+		Cache:       options.Cache,
 	}
+	d.ruleHandler = ruleHandler
 
 	for _, rule := range options.Rules {
 		d.logger.Debug(
@@ -72,6 +78,13 @@ func (d *dohHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec)
 	return nil
 }
 
+func (d *dohHandler) RuleManager() rules.Manager {
+	if d.ruleHandler == nil {
+		return nil
+	}
+	return d.ruleHandler.RuleManager()
+}
+
 func (d *dohHandler) startServer(listener net.Listener) {
 	if err := endpoint.IgnoreShutdownError(d.server.Serve(listener)); err != nil {
 		d.logger.Error("Failed to start DoH server", zap.Error(err))
@@ -70,7 +70,7 @@ func DNSQueryHandler(logger logging.Logger, name string, handler dns.Handler) ht
 		for idx := range msg.Question {
 			question := msg.Question[idx]
 			var rr dns.ResourceRecord
-			if rr, err = handler.AnswerDNSQuestion(dns.Question(question)); !errors.Is(err, nil) {
+			if rr, err = handler.AnswerDNSQuestion(request.Context(), dns.Question(question)); !errors.Is(err, nil) {
 				logger.Error("Error occurred while answering DNS question", zap.Error(err))
 			} else {
 				resp.Answer = append(resp.Answer, rr)
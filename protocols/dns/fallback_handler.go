@@ -1,14 +1,15 @@
 package dns
 
 import (
+	"context"
 	"time"
 
 	mdns "github.com/miekg/dns"
 )
 
 func FallbackHandler(handler Handler, resolver IPResolver, ttl time.Duration) Handler {
-	return HandlerFunc(func(q Question) (ResourceRecord, error) {
-		rr, err := handler.AnswerDNSQuestion(q)
+	return HandlerFunc(func(ctx context.Context, q Question) (ResourceRecord, error) {
+		rr, err := handler.AnswerDNSQuestion(ctx, q)
 		if err == nil {
 			return rr, nil
 		}
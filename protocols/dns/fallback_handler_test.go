@@ -1,6 +1,7 @@
 package dns_test
 
 import (
+	"context"
 	"net"
 	"testing"
 	"time"
@@ -27,7 +28,7 @@ func TestFallbackHandler_AnswerDNSQuestion(t *testing.T) {
 		{
 			name: "Get answer from backing handler",
 			fields: fields{
-				Handler: dns.HandlerFunc(func(q dns.Question) (dns.ResourceRecord, error) {
+				Handler: dns.HandlerFunc(func(_ context.Context, q dns.Question) (dns.ResourceRecord, error) {
 					return new(mdns.A), nil
 				}),
 			},
@@ -39,7 +40,7 @@ func TestFallbackHandler_AnswerDNSQuestion(t *testing.T) {
 				Resolver: dns.IPResolverFunc(func(host string) net.IP {
 					return net.IPv4(10, 10, 0, 4)
 				}),
-				Handler: dns.HandlerFunc(func(q dns.Question) (dns.ResourceRecord, error) {
+				Handler: dns.HandlerFunc(func(_ context.Context, q dns.Question) (dns.ResourceRecord, error) {
 					return nil, dns.ErrNoAnswerForQuestion
 				}),
 			},
@@ -51,7 +52,7 @@ func TestFallbackHandler_AnswerDNSQuestion(t *testing.T) {
 				Resolver: dns.IPResolverFunc(func(host string) net.IP {
 					return nil
 				}),
-				Handler: dns.HandlerFunc(func(q dns.Question) (dns.ResourceRecord, error) {
+				Handler: dns.HandlerFunc(func(_ context.Context, q dns.Question) (dns.ResourceRecord, error) {
 					return nil, dns.ErrNoAnswerForQuestion
 				}),
 			},
@@ -63,7 +64,7 @@ func TestFallbackHandler_AnswerDNSQuestion(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			h := dns.FallbackHandler(tt.fields.Handler, tt.fields.Resolver, defaultTTL)
-			got, err := h.AnswerDNSQuestion(dns.Question{Qtype: mdns.TypeA})
+			got, err := h.AnswerDNSQuestion(context.Background(), dns.Question{Qtype: mdns.TypeA})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AnswerDNSQuestion() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1,6 +1,7 @@
 package dns
 
 import (
+	"context"
 	"errors"
 
 	mdns "github.com/miekg/dns"
@@ -11,13 +12,15 @@ var ErrNoAnswerForQuestion = errors.New("cannot answer given question")
 type (
 	Question       mdns.Question
 	ResourceRecord mdns.RR
-	HandlerFunc    func(q Question) (ResourceRecord, error)
+	HandlerFunc    func(ctx context.Context, q Question) (ResourceRecord, error)
 )
 
-func (f HandlerFunc) AnswerDNSQuestion(q Question) (ResourceRecord, error) {
-	return f(q)
+func (f HandlerFunc) AnswerDNSQuestion(ctx context.Context, q Question) (ResourceRecord, error) {
+	return f(ctx, q)
 }
 
 type Handler interface {
-	AnswerDNSQuestion(q Question) (ResourceRecord, error)
+	// AnswerDNSQuestion resolves a single question,
+	// the context might carry the addresses of the client as stored by audit.StoreAddrsInContext
+	AnswerDNSQuestion(ctx context.Context, q Question) (ResourceRecord, error)
 }
@@ -7,15 +7,19 @@ import (
 	"go.uber.org/zap"
 
 	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
 	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
 	"inetmock.icb4dc0.de/inetmock/protocols/dns"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
 type dnsHandler struct {
-	logger    logging.Logger
-	emitter   audit.Emitter
-	dnsServer *mdns.Server
+	logger      logging.Logger
+	emitter     audit.Emitter
+	dnsServer   *mdns.Server
+	ruleHandler *dns.RuleHandler
+	wpadService *wpad.Service
 }
 
 func (d *dnsHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) error {
@@ -32,8 +36,12 @@ func (d *dnsHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec)
 	)
 
 	ruleHandler := &dns.RuleHandler{
-		TTL: options.TTL,
+		HandlerRef:  name,
+		HandlerName: startupSpec.Name,
+		TTL:         options.TTL,
+		Cache:       options.Cache,
 	}
+	d.ruleHandler = ruleHandler
 
 	for _, rule := range options.Rules {
 		d.logger.Debug(
@@ -50,7 +58,7 @@ func (d *dnsHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec)
 		Handler: &dns.CacheHandler{
 			Cache:    options.Cache,
 			TTL:      options.TTL,
-			Fallback: dns.FallbackHandler(ruleHandler, options.Default, options.TTL),
+			Fallback: dns.FallbackHandler(d.wpadService.DNSHandler(ruleHandler, options.TTL), options.Default, options.TTL),
 		},
 		Logger:  d.logger,
 		Emitter: d.emitter,
@@ -72,6 +80,13 @@ func (d *dnsHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec)
 	return nil
 }
 
+func (d *dnsHandler) RuleManager() rules.Manager {
+	if d.ruleHandler == nil {
+		return nil
+	}
+	return d.ruleHandler.RuleManager()
+}
+
 func (d *dnsHandler) startServer() {
 	if err := endpoint.IgnoreShutdownError(d.dnsServer.ActivateAndServe()); err != nil {
 		d.logger.Error(
@@ -13,6 +13,7 @@ import (
 	auditmock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
 	"inetmock.icb4dc0.de/inetmock/internal/test"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/dns"
 	"inetmock.icb4dc0.de/inetmock/protocols/dns/mock"
 )
 
@@ -134,7 +135,7 @@ default:
 					})
 				})
 			}
-			handler := mock.New(logging.CreateTestLogger(t), emitter)
+			handler := mock.New(logging.CreateTestLogger(t), emitter, nil)
 			if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), optsMap)); err != nil {
 				if !tt.wantErr {
 					t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
@@ -164,3 +165,54 @@ default:
 		})
 	}
 }
+
+func Test_dnsHandler_RuleChangeFlushesCache(t *testing.T) {
+	t.Parallel()
+	dns.ConfigureCache()
+
+	// language=yaml
+	const opts = `
+ttl: 30s
+cache:
+  type: inMemory
+rules:
+- A("^flush\\.inetmock\\.test\\.$") => IP(1.1.1.1)
+default:
+  type: incremental
+  cidr: 10.10.0.0/16
+`
+	optsMap := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(opts), optsMap); err != nil {
+		t.Fatalf("yaml.Unmarshal() err = %v", err)
+	}
+
+	listener := test.NewInMemoryListener(t)
+	ctx, cancel := context.WithCancel(test.Context(t))
+	t.Cleanup(cancel)
+
+	handler := mock.New(logging.CreateTestLogger(t), new(auditmock.EmitterMock), nil)
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), optsMap)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	resolver := test.DNSResolverForInMemListener(listener)
+	lookup := func() []net.IP {
+		t.Helper()
+		requestCtx, requestCancel := context.WithTimeout(ctx, 250*time.Millisecond)
+		defer requestCancel()
+		ips, err := resolver.LookupA(requestCtx, "flush.inetmock.test")
+		td.CmpNoError(t, err)
+		return ips
+	}
+
+	td.Cmp(t, lookup(), []net.IP{net.IPv4(1, 1, 1, 1).To4()})
+
+	manager := handler.(endpoint.RuleManagingHandler).RuleManager()
+	td.CmpNoError(t, manager.ReplaceRule(0, `A("^flush\\.inetmock\\.test\\.$") => IP(2.2.2.2)`))
+	td.Cmp(t, lookup(), []net.IP{net.IPv4(2, 2, 2, 2).To4()})
+
+	td.CmpNoError(t, manager.DeleteRule(0))
+	td.Cmp(t, lookup(), td.All(td.Len(1), td.ArrayEach(td.Code(func(ip net.IP) bool {
+		return !ip.Equal(net.IPv4(2, 2, 2, 2))
+	}))))
+}
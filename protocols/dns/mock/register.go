@@ -4,17 +4,20 @@ import (
 	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
 	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
-func New(logger logging.Logger, emitter audit.Emitter) endpoint.ProtocolHandler {
+// New creates a dns_mock handler, if wpadService is not nil WPAD names without matching rule are resolved by it
+func New(logger logging.Logger, emitter audit.Emitter, wpadService *wpad.Service) endpoint.ProtocolHandler {
 	return &dnsHandler{
-		logger:  logger,
-		emitter: emitter,
+		logger:      logger,
+		emitter:     emitter,
+		wpadService: wpadService,
 	}
 }
 
-func AddDNSMock(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter) {
+func AddDNSMock(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter, wpadService *wpad.Service) {
 	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
-		return New(logger, emitter)
+		return New(logger, emitter, wpadService)
 	})
 }
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"errors"
 	"net"
 	"sync"
@@ -58,9 +59,10 @@ func (s *Server) ServeDNS(w mdns.ResponseWriter, req *mdns.Msg) {
 	resp := new(mdns.Msg)
 	resp = resp.SetReply(req)
 
+	ctx := audit.StoreAddrsInContext(context.Background(), w.LocalAddr(), w.RemoteAddr())
 	for qIdx := range req.Question {
 		question := req.Question[qIdx]
-		if rr, err := s.Handler.AnswerDNSQuestion(dns.Question(question)); !errors.Is(err, nil) {
+		if rr, err := s.Handler.AnswerDNSQuestion(ctx, dns.Question(question)); !errors.Is(err, nil) {
 			if errors.Is(err, dns.ErrNoAnswerForQuestion) {
 				totalProcessedQuestionsCounter.WithLabelValues(s.Name, "false")
 			}
@@ -1,6 +1,7 @@
 package mock_test
 
 import (
+	"context"
 	"net"
 	"testing"
 
@@ -30,7 +31,7 @@ func TestServer_ServeDNS(t *testing.T) {
 		{
 			name: "Successfully resolve with handler",
 			fields: fields{
-				Handler: dns.HandlerFunc(func(q dns.Question) (dns.ResourceRecord, error) {
+				Handler: dns.HandlerFunc(func(_ context.Context, q dns.Question) (dns.ResourceRecord, error) {
 					return &mdns.A{
 						A: net.IPv4(10, 10, 0, 1),
 					}, nil
@@ -53,7 +54,7 @@ func TestServer_ServeDNS(t *testing.T) {
 		{
 			name: "Handler does not resolve but returns error",
 			fields: fields{
-				Handler: dns.HandlerFunc(func(q dns.Question) (dns.ResourceRecord, error) {
+				Handler: dns.HandlerFunc(func(_ context.Context, q dns.Question) (dns.ResourceRecord, error) {
 					return nil, dns.ErrNoAnswerForQuestion
 				}),
 			},
@@ -0,0 +1,154 @@
+package multicast
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	inetnet "inetmock.icb4dc0.de/inetmock/internal/net"
+)
+
+var (
+	ErrUnsupportedUplink = errors.New("uplink is not a UDP socket")
+	ErrUnknownInterface  = errors.New("unknown interface")
+	ErrMultipleAddresses = errors.New("responders support a single listen address, configure a listener per address")
+)
+
+// packetConn unifies IPv4 and IPv6 sockets,
+// the interface index of received packets is used to send multicast responses through the same interface
+type packetConn interface {
+	ReadPacket(b []byte) (n int, ifIndex int, src net.Addr, err error)
+	WritePacket(b []byte, ifIndex int, dst net.Addr) (int, error)
+	JoinGroup(ifi *net.Interface, group net.Addr) error
+	SetMulticastTTL(ttl int) error
+	LocalAddr() net.Addr
+	Close() error
+}
+
+type ipv4Conn struct {
+	*ipv4.PacketConn
+}
+
+func (c ipv4Conn) ReadPacket(b []byte) (n int, ifIndex int, src net.Addr, err error) {
+	var cm *ipv4.ControlMessage
+	n, cm, src, err = c.ReadFrom(b)
+	if cm != nil {
+		ifIndex = cm.IfIndex
+	}
+	return n, ifIndex, src, err
+}
+
+func (c ipv4Conn) WritePacket(b []byte, ifIndex int, dst net.Addr) (int, error) {
+	var cm *ipv4.ControlMessage
+	if ifIndex > 0 {
+		cm = &ipv4.ControlMessage{IfIndex: ifIndex}
+	}
+	return c.WriteTo(b, cm, dst)
+}
+
+type ipv6Conn struct {
+	*ipv6.PacketConn
+}
+
+func (c ipv6Conn) ReadPacket(b []byte) (n int, ifIndex int, src net.Addr, err error) {
+	var cm *ipv6.ControlMessage
+	n, cm, src, err = c.ReadFrom(b)
+	if cm != nil {
+		ifIndex = cm.IfIndex
+	}
+	return n, ifIndex, src, err
+}
+
+func (c ipv6Conn) WritePacket(b []byte, ifIndex int, dst net.Addr) (int, error) {
+	var cm *ipv6.ControlMessage
+	if ifIndex > 0 {
+		cm = &ipv6.ControlMessage{IfIndex: ifIndex}
+	}
+	return c.WriteTo(b, cm, dst)
+}
+
+func (c ipv6Conn) SetMulticastTTL(ttl int) error {
+	return c.SetMulticastHopLimit(ttl)
+}
+
+// openConn either uses the socket of a managed listener or binds its own socket for unmanaged listeners,
+// the own socket allows to share the port with other responders like avahi running on the same host
+func openConn(uplink endpoint.Uplink) (packetConn, error) {
+	addr, ok := uplink.Addr.(*net.UDPAddr)
+	if !ok {
+		return nil, ErrUnsupportedUplink
+	}
+
+	var udpConn *net.UDPConn
+	if uplink.Unmanaged {
+		network := "udp6"
+		if addr.IP.To4() != nil {
+			network = "udp4"
+		}
+
+		var err error
+		if udpConn, err = inetnet.ListenReusableUDP(network, addr); err != nil {
+			return nil, err
+		}
+	} else if inetnet.IsMultiPacketConn(uplink.PacketConn) {
+		// the interface of received packets and the multicast group membership are bound to a single socket
+		return nil, ErrMultipleAddresses
+	} else if udpConn, ok = uplink.PacketConn.(*net.UDPConn); !ok {
+		return nil, ErrUnsupportedUplink
+	}
+
+	if addr.IP.To4() != nil {
+		conn := ipv4.NewPacketConn(udpConn)
+		if err := conn.SetControlMessage(ipv4.FlagInterface, true); err != nil {
+			_ = closeUnmanaged(uplink, udpConn)
+			return nil, err
+		}
+		return ipv4Conn{PacketConn: conn}, nil
+	}
+
+	conn := ipv6.NewPacketConn(udpConn)
+	if err := conn.SetControlMessage(ipv6.FlagInterface, true); err != nil {
+		_ = closeUnmanaged(uplink, udpConn)
+		return nil, err
+	}
+	return ipv6Conn{PacketConn: conn}, nil
+}
+
+func closeUnmanaged(uplink endpoint.Uplink, conn *net.UDPConn) error {
+	if uplink.Unmanaged {
+		return conn.Close()
+	}
+	return nil
+}
+
+// interfacesByName resolves the given interface names,
+// if no name is given all interfaces that are up and support multicast are returned
+func interfacesByName(names []string) (ifis []net.Interface, err error) {
+	if len(names) == 0 {
+		var all []net.Interface
+		if all, err = net.Interfaces(); err != nil {
+			return nil, err
+		}
+		for idx := range all {
+			if all[idx].Flags&net.FlagUp != 0 && all[idx].Flags&net.FlagMulticast != 0 {
+				ifis = append(ifis, all[idx])
+			}
+		}
+		return ifis, nil
+	}
+
+	ifis = make([]net.Interface, 0, len(names))
+	for _, name := range names {
+		ifi, lookupErr := net.InterfaceByName(name)
+		if lookupErr != nil {
+			return nil, fmt.Errorf("%w %s: %v", ErrUnknownInterface, name, lookupErr)
+		}
+		ifis = append(ifis, *ifi)
+	}
+
+	return ifis, nil
+}
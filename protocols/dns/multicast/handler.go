@@ -0,0 +1,252 @@
+package multicast
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+
+	mdns "github.com/miekg/dns"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/dns"
+)
+
+// maxPacketSize is the maximum size of mDNS packets, LLMNR and NBNS packets are even smaller
+const maxPacketSize = 9000
+
+type (
+	// protocol contains the name service specific parts of a responder
+	protocol struct {
+		name string
+		app  auditv1.AppProtocol
+		// groupV4 and groupV6 are the multicast groups joined on the configured interfaces, nil if not required
+		groupV4 net.IP
+		groupV6 net.IP
+		// multicastTTL is the TTL respectively hop limit of multicast responses, 0 keeps the default
+		multicastTTL int
+		// auditQuestions converts the questions of a query to their audit representation
+		auditQuestions func(msg *mdns.Msg) []audit.DNSQuestion
+		// respond answers a query, queries without response return a nil message
+		respond func(ctx context.Context, h *responderHandler, q query) (resp *mdns.Msg, dst net.Addr)
+	}
+
+	query struct {
+		msg     *mdns.Msg
+		src     net.Addr
+		ifIndex int
+	}
+)
+
+type responderHandler struct {
+	protocol    protocol
+	logger      logging.Logger
+	emitter     audit.Emitter
+	ruleHandler *dns.RuleHandler
+	resolver    dns.Handler
+	conn        packetConn
+	ownsConn    bool
+	group       *net.UDPAddr
+	// ifIndexes restricts the interfaces packets are accepted from, nil if all interfaces are accepted
+	ifIndexes map[int]bool
+}
+
+func (h *responderHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	var opts responderOptions
+	if opts, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", h.protocol.name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	h.ruleHandler = &dns.RuleHandler{
+		HandlerRef:  h.protocol.name,
+		HandlerName: startupSpec.Name,
+		TTL:         opts.TTL,
+		Cache:       opts.Cache,
+	}
+
+	for _, rule := range opts.Rules {
+		if err = h.ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("Failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+
+	h.resolver = opts.handlerChain(h.ruleHandler)
+
+	if h.conn, err = openConn(startupSpec.Uplink); err != nil {
+		return err
+	}
+	h.ownsConn = startupSpec.Unmanaged
+
+	if err = h.joinGroups(startupSpec.Addr.(*net.UDPAddr), opts.Interfaces); err != nil {
+		_ = h.Stop(context.Background())
+		return err
+	}
+
+	go h.serve()
+
+	return nil
+}
+
+// Stop closes the socket if it was opened by the handler itself, sockets of managed listeners are closed by the endpoint
+func (h *responderHandler) Stop(context.Context) error {
+	if h.conn == nil || !h.ownsConn {
+		return nil
+	}
+
+	return h.conn.Close()
+}
+
+func (h *responderHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+// joinGroups joins the multicast group of the protocol on the given interfaces or on all multicast capable interfaces,
+// failing to join the group on an explicitly configured interface is an error, otherwise the interface is skipped
+func (h *responderHandler) joinGroups(addr *net.UDPAddr, interfaces []string) error {
+	ifis, err := interfacesByName(interfaces)
+	if err != nil {
+		return err
+	}
+
+	if len(interfaces) > 0 {
+		h.ifIndexes = make(map[int]bool, len(ifis))
+		for idx := range ifis {
+			h.ifIndexes[ifis[idx].Index] = true
+		}
+	}
+
+	group := h.protocol.groupV6
+	if addr.IP.To4() != nil {
+		group = h.protocol.groupV4
+	}
+
+	if group == nil {
+		return nil
+	}
+
+	h.group = &net.UDPAddr{IP: group, Port: addr.Port}
+
+	for idx := range ifis {
+		if joinErr := h.conn.JoinGroup(&ifis[idx], &net.UDPAddr{IP: group}); joinErr != nil {
+			if len(interfaces) > 0 {
+				return joinErr
+			}
+			h.logger.Warn("Failed to join multicast group", zap.String("interface", ifis[idx].Name), zap.Error(joinErr))
+		}
+	}
+
+	if h.protocol.multicastTTL > 0 {
+		return h.conn.SetMulticastTTL(h.protocol.multicastTTL)
+	}
+
+	return nil
+}
+
+func (h *responderHandler) serve() {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, ifIndex, src, err := h.conn.ReadPacket(buf)
+		if err != nil {
+			// managed listeners are closed by setting a deadline first
+			if err = endpoint.IgnoreShutdownError(err); err != nil && !errors.Is(err, os.ErrDeadlineExceeded) {
+				h.logger.Error("Failed to read packet", zap.Error(err))
+			}
+			return
+		}
+
+		if h.ifIndexes != nil && !h.ifIndexes[ifIndex] {
+			continue
+		}
+
+		msg := new(mdns.Msg)
+		if err = msg.Unpack(buf[:n]); err != nil {
+			h.logger.Debug("Failed to parse packet", zap.String("source", src.String()), zap.Error(err))
+			continue
+		}
+
+		// responses of other hosts e.g. mDNS announcements or our own multicast responses are ignored
+		if msg.Response || msg.Opcode != mdns.OpcodeQuery {
+			continue
+		}
+
+		h.handleQuery(query{msg: msg, src: src, ifIndex: ifIndex})
+	}
+}
+
+func (h *responderHandler) handleQuery(q query) {
+	h.emit(q)
+
+	ctx := audit.StoreAddrsInContext(context.Background(), h.conn.LocalAddr(), q.src)
+	resp, dst := h.protocol.respond(ctx, h, q)
+	if resp == nil {
+		return
+	}
+
+	packed, err := resp.Pack()
+	if err != nil {
+		h.logger.Error("Failed to pack response", zap.Error(err))
+		return
+	}
+
+	if _, err = h.conn.WritePacket(packed, q.ifIndex, dst); err != nil {
+		h.logger.Error("Failed to write response", zap.String("destination", dst.String()), zap.Error(err))
+	}
+}
+
+// answer resolves the given questions, questions without an answer are skipped
+func (h *responderHandler) answer(ctx context.Context, questions []mdns.Question) (answers []mdns.RR) {
+	for idx := range questions {
+		rr, err := h.resolver.AnswerDNSQuestion(ctx, dns.Question(questions[idx]))
+		if err != nil {
+			if !errors.Is(err, dns.ErrNoAnswerForQuestion) {
+				h.logger.Error("Error occurred while answering question", zap.String("question", questions[idx].Name), zap.Error(err))
+			}
+			continue
+		}
+		answers = append(answers, rr)
+	}
+	return answers
+}
+
+func (h *responderHandler) emit(q query) {
+	builder := h.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP).
+		WithApplication(h.protocol.app).
+		WithProtocolDetails(&audit.DNS{
+			OPCode:    auditv1.DNSOpCode(q.msg.Opcode),
+			Questions: h.protocol.auditQuestions(q.msg),
+		})
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(q.src)
+	builder, _ = builder.WithDestinationFromAddr(h.conn.LocalAddr())
+
+	builder.Emit()
+}
+
+// dnsQuestions is the audit representation of LLMNR and mDNS queries which use the same questions as DNS
+func dnsQuestions(msg *mdns.Msg) []audit.DNSQuestion {
+	questions := make([]audit.DNSQuestion, 0, len(msg.Question))
+	for _, q := range msg.Question {
+		questions = append(questions, audit.DNSQuestion{
+			RRType: auditv1.ResourceRecordType(q.Qtype),
+			Name:   q.Name,
+		})
+	}
+	return questions
+}
@@ -0,0 +1,335 @@
+package multicast_test
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+	mdns "github.com/miekg/dns"
+	"gopkg.in/yaml.v3"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	inetnet "inetmock.icb4dc0.de/inetmock/internal/net"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/dns/multicast"
+)
+
+const (
+	responseTimeout   = 2 * time.Second
+	noResponseTimeout = 200 * time.Millisecond
+
+	nbnsTypeNB     uint16 = 0x20
+	nbnsTypeNBSTAT uint16 = 0x21
+)
+
+// language=yaml
+const defaultOptions = `
+ttl: 30s
+rules:
+- A("^wpad\\.$") => IP(192.0.2.1)
+- A("\\.local\\.$") => IP(192.0.2.2)
+`
+
+var appProtocols = map[endpoint.HandlerReference]auditv1.AppProtocol{
+	"llmnr_mock": auditv1.AppProtocol_APP_PROTOCOL_LLMNR,
+	"mdns_mock":  auditv1.AppProtocol_APP_PROTOCOL_MDNS,
+	"nbns_mock":  auditv1.AppProtocol_APP_PROTOCOL_NBNS,
+}
+
+func Test_responderHandler(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		handler    endpoint.HandlerReference
+		opts       string
+		query      *mdns.Msg
+		wantResp   any
+		wantEvents []any
+	}{
+		{
+			name:    "LLMNR - answer matching rule",
+			handler: "llmnr_mock",
+			opts:    defaultOptions,
+			query:   dnsQuery(4711, "wpad.", mdns.TypeA, mdns.ClassINET),
+			wantResp: td.Struct(&mdns.Msg{
+				MsgHdr: mdns.MsgHdr{Id: 4711, Response: true},
+				Question: []mdns.Question{
+					{Name: "wpad.", Qtype: mdns.TypeA, Qclass: mdns.ClassINET},
+				},
+				Answer: []mdns.RR{
+					&mdns.A{
+						Hdr: mdns.RR_Header{Name: "wpad.", Rrtype: mdns.TypeA, Class: mdns.ClassINET, Ttl: 30, Rdlength: 4},
+						A:   net.IPv4(192, 0, 2, 1).To4(),
+					},
+				},
+			}, nil),
+			wantEvents: []any{
+				&audit.DNS{
+					OPCode: auditv1.DNSOpCode_DNS_OP_CODE_QUERY,
+					Questions: []audit.DNSQuestion{
+						{RRType: auditv1.ResourceRecordType_RESOURCE_RECORD_TYPE_A, Name: "wpad."},
+					},
+				},
+			},
+		},
+		{
+			name:    "LLMNR - answer with default resolver",
+			handler: "llmnr_mock",
+			opts: `
+ttl: 30s
+default:
+  type: incremental
+  cidr: 10.10.0.0/16
+`,
+			query: dnsQuery(1, "fileserver.", mdns.TypeA, mdns.ClassINET),
+			wantResp: td.Struct(&mdns.Msg{
+				MsgHdr: mdns.MsgHdr{Id: 1, Response: true},
+			}, td.StructFields{
+				"Answer": td.Bag(td.Struct(&mdns.A{A: net.IPv4(10, 10, 0, 1).To4()}, nil)),
+			}),
+			wantEvents: []any{
+				&audit.DNS{
+					OPCode: auditv1.DNSOpCode_DNS_OP_CODE_QUERY,
+					Questions: []audit.DNSQuestion{
+						{RRType: auditv1.ResourceRecordType_RESOURCE_RECORD_TYPE_A, Name: "fileserver."},
+					},
+				},
+			},
+		},
+		{
+			name:    "LLMNR - ignore query without answer",
+			handler: "llmnr_mock",
+			opts:    defaultOptions,
+			query:   dnsQuery(4711, "fileserver.", mdns.TypeA, mdns.ClassINET),
+			wantEvents: []any{
+				&audit.DNS{
+					OPCode: auditv1.DNSOpCode_DNS_OP_CODE_QUERY,
+					Questions: []audit.DNSQuestion{
+						{RRType: auditv1.ResourceRecordType_RESOURCE_RECORD_TYPE_A, Name: "fileserver."},
+					},
+				},
+			},
+		},
+		{
+			name:    "mDNS - legacy unicast response",
+			handler: "mdns_mock",
+			opts:    defaultOptions,
+			query:   dnsQuery(42, "printer.local.", mdns.TypeA, mdns.ClassINET|1<<15),
+			wantResp: td.Struct(&mdns.Msg{
+				MsgHdr: mdns.MsgHdr{Id: 42, Response: true, Authoritative: true},
+				Question: []mdns.Question{
+					{Name: "printer.local.", Qtype: mdns.TypeA, Qclass: mdns.ClassINET},
+				},
+				Answer: []mdns.RR{
+					&mdns.A{
+						Hdr: mdns.RR_Header{Name: "printer.local.", Rrtype: mdns.TypeA, Class: mdns.ClassINET, Ttl: 30, Rdlength: 4},
+						A:   net.IPv4(192, 0, 2, 2).To4(),
+					},
+				},
+			}, nil),
+			wantEvents: []any{
+				&audit.DNS{
+					OPCode: auditv1.DNSOpCode_DNS_OP_CODE_QUERY,
+					Questions: []audit.DNSQuestion{
+						{RRType: auditv1.ResourceRecordType_RESOURCE_RECORD_TYPE_A, Name: "printer.local."},
+					},
+				},
+			},
+		},
+		{
+			name:    "mDNS - ignore responses",
+			handler: "mdns_mock",
+			opts:    defaultOptions,
+			query: func() *mdns.Msg {
+				msg := dnsQuery(0, "printer.local.", mdns.TypeA, mdns.ClassINET)
+				msg.Response = true
+				return msg
+			}(),
+		},
+		{
+			name:    "NBNS - answer name query",
+			handler: "nbns_mock",
+			opts:    defaultOptions,
+			query:   dnsQuery(1337, encodeNetBIOSName("WPAD", 0x00), nbnsTypeNB, mdns.ClassINET),
+			wantResp: td.Struct(&mdns.Msg{
+				MsgHdr: mdns.MsgHdr{Id: 1337, Response: true, Authoritative: true},
+				Answer: []mdns.RR{
+					// miekg/dns parses the NB type code 0x20 as NIMLOC record with hex encoded data
+					&mdns.NIMLOC{
+						Hdr: mdns.RR_Header{
+							Name:     encodeNetBIOSName("WPAD", 0x00),
+							Rrtype:   nbnsTypeNB,
+							Class:    mdns.ClassINET,
+							Ttl:      30,
+							Rdlength: 6,
+						},
+						Locator: "0000c0000201",
+					},
+				},
+			}, nil),
+			wantEvents: []any{
+				&audit.DNS{
+					OPCode: auditv1.DNSOpCode_DNS_OP_CODE_QUERY,
+					Questions: []audit.DNSQuestion{
+						{RRType: auditv1.ResourceRecordType_RESOURCE_RECORD_TYPE_NB, Name: "WPAD<00>"},
+					},
+				},
+			},
+		},
+		{
+			name:    "NBNS - record node status request",
+			handler: "nbns_mock",
+			opts:    defaultOptions,
+			query:   dnsQuery(1338, encodeNetBIOSName("*", 0x00), nbnsTypeNBSTAT, mdns.ClassINET),
+			wantEvents: []any{
+				&audit.DNS{
+					OPCode: auditv1.DNSOpCode_DNS_OP_CODE_QUERY,
+					Questions: []audit.DNSQuestion{
+						{RRType: auditv1.ResourceRecordType_RESOURCE_RECORD_TYPE_UNSPECIFIED, Name: "*<00>"},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			emitterMock := new(audit_mock.EmitterMock)
+			addr := startHandler(t, emitterMock, tt.handler, tt.opts)
+
+			client, err := net.DialUDP("udp4", nil, addr)
+			if err != nil {
+				t.Fatalf("net.DialUDP() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = client.Close()
+			})
+
+			packed, err := tt.query.Pack()
+			if err != nil {
+				t.Fatalf("Pack() error = %v", err)
+			}
+
+			if _, err = client.Write(packed); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+
+			timeout := responseTimeout
+			if tt.wantResp == nil {
+				timeout = noResponseTimeout
+			}
+			_ = client.SetReadDeadline(time.Now().Add(timeout))
+
+			buf := make([]byte, 1500)
+			n, err := client.Read(buf)
+			if tt.wantResp == nil {
+				td.Cmp(t, errors.Is(err, os.ErrDeadlineExceeded), true, "expected no response")
+			} else if td.CmpNoError(t, err) {
+				resp := new(mdns.Msg)
+				td.CmpNoError(t, resp.Unpack(buf[:n]))
+				td.Cmp(t, resp, tt.wantResp)
+			}
+
+			test.AwaitEventDetails(t, emitterMock, appProtocols[tt.handler], tt.wantEvents)
+		})
+	}
+}
+
+func Test_responderHandler_Start_UnknownInterface(t *testing.T) {
+	t.Parallel()
+	registry := endpoint.NewHandlerRegistry()
+	multicast.AddResponders(registry, logging.CreateTestLogger(t), new(audit_mock.EmitterMock))
+
+	handler, ok := registry.HandlerForName("llmnr_mock")
+	if !ok {
+		t.Fatal("handler llmnr_mock not registered")
+	}
+
+	conn := listenUDP(t)
+	opts := map[string]any{"interfaces": []any{"does-not-exist0"}}
+	err := handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(conn), opts))
+	td.Cmp(t, errors.Is(err, multicast.ErrUnknownInterface), true)
+}
+
+func Test_responderHandler_Start_MultipleAddresses(t *testing.T) {
+	t.Parallel()
+	registry := endpoint.NewHandlerRegistry()
+	multicast.AddResponders(registry, logging.CreateTestLogger(t), new(audit_mock.EmitterMock))
+
+	handler, ok := registry.HandlerForName("mdns_mock")
+	if !ok {
+		t.Fatal("handler mdns_mock not registered")
+	}
+
+	conn := inetnet.MultiPacketConn(listenUDP(t), listenUDP(t))
+	err := handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(conn), map[string]any{}))
+	td.Cmp(t, errors.Is(err, multicast.ErrMultipleAddresses), true)
+}
+
+func startHandler(tb *testing.T, emitter audit.Emitter, handlerRef endpoint.HandlerReference, rawOpts string) *net.UDPAddr {
+	tb.Helper()
+	registry := endpoint.NewHandlerRegistry()
+	multicast.AddResponders(registry, logging.CreateTestLogger(tb), emitter)
+
+	handler, ok := registry.HandlerForName(handlerRef)
+	if !ok {
+		tb.Fatalf("handler %s not registered", handlerRef)
+	}
+
+	opts := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(rawOpts), opts); err != nil {
+		tb.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+
+	conn := listenUDP(tb)
+	if err := handler.Start(test.Context(tb), endpoint.NewStartupSpec(tb.Name(), endpoint.NewUplink(conn), opts)); err != nil {
+		tb.Fatalf("Start() error = %v", err)
+	}
+
+	return conn.LocalAddr().(*net.UDPAddr)
+}
+
+func listenUDP(tb testing.TB) net.PacketConn {
+	tb.Helper()
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		tb.Fatalf("net.ListenUDP() error = %v", err)
+	}
+	tb.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func dnsQuery(id uint16, name string, qType, qClass uint16) *mdns.Msg {
+	msg := new(mdns.Msg)
+	msg.Id = id
+	msg.Question = []mdns.Question{{Name: name, Qtype: qType, Qclass: qClass}}
+	return msg
+}
+
+// encodeNetBIOSName encodes a NetBIOS name with the first-level encoding of RFC 1001
+func encodeNetBIOSName(name string, suffix byte) string {
+	raw := []byte(name + strings.Repeat(" ", 15-len(name)))
+	if name == "*" {
+		raw = append([]byte(name), make([]byte, 14)...)
+	}
+	raw = append(raw, suffix)
+
+	var builder strings.Builder
+	for _, b := range raw {
+		builder.WriteByte('A' + b>>4)
+		builder.WriteByte('A' + b&0x0F)
+	}
+	builder.WriteByte('.')
+	return builder.String()
+}
@@ -0,0 +1,36 @@
+package multicast
+
+import (
+	"context"
+	"net"
+
+	mdns "github.com/miekg/dns"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+// llmnrProtocol is a LLMNR (RFC 4795) responder claiming to be authoritative for every name it has an answer for
+var llmnrProtocol = protocol{
+	name:           "llmnr_mock",
+	app:            auditv1.AppProtocol_APP_PROTOCOL_LLMNR,
+	groupV4:        net.IPv4(224, 0, 0, 252),
+	groupV6:        net.ParseIP("ff02::1:3"),
+	auditQuestions: dnsQuestions,
+	respond:        respondLLMNR,
+}
+
+// respondLLMNR answers the query directly to the sender,
+// responders must not answer queries they're not authoritative for hence queries without answers are ignored
+func respondLLMNR(ctx context.Context, h *responderHandler, q query) (*mdns.Msg, net.Addr) {
+	answers := h.answer(ctx, q.msg.Question)
+	if len(answers) == 0 {
+		return nil, nil
+	}
+
+	resp := new(mdns.Msg).SetReply(q.msg)
+	// LLMNR doesn't know recursion, the bit is reserved
+	resp.RecursionDesired = false
+	resp.Answer = answers
+
+	return resp, q.src
+}
@@ -0,0 +1,72 @@
+package multicast
+
+import (
+	"context"
+	"net"
+
+	mdns "github.com/miekg/dns"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const (
+	mdnsPort = 5353
+	// mdnsUnicastResponse is the top bit of the question class requesting a unicast response
+	mdnsUnicastResponse = 1 << 15
+	// mdnsCacheFlush is the top bit of the record class telling clients to replace cached records
+	mdnsCacheFlush = 1 << 15
+	// mdnsMulticastTTL is the IP TTL required by RFC 6762 to detect packets from outside the local link
+	mdnsMulticastTTL = 255
+)
+
+// mdnsProtocol is a mDNS (RFC 6762) responder answering queries for all names it has an answer for
+var mdnsProtocol = protocol{
+	name:           "mdns_mock",
+	app:            auditv1.AppProtocol_APP_PROTOCOL_MDNS,
+	groupV4:        net.IPv4(224, 0, 0, 251),
+	groupV6:        net.ParseIP("ff02::fb"),
+	multicastTTL:   mdnsMulticastTTL,
+	auditQuestions: dnsQuestions,
+	respond:        respondMDNS,
+}
+
+// respondMDNS answers queries via multicast unless the sender requested a unicast response
+// or isn't a fully-fledged mDNS querier i.e. doesn't use port 5353 as source port (legacy unicast response)
+func respondMDNS(ctx context.Context, h *responderHandler, q query) (*mdns.Msg, net.Addr) {
+	var (
+		unicast   bool
+		questions = make([]mdns.Question, 0, len(q.msg.Question))
+	)
+
+	for _, question := range q.msg.Question {
+		unicast = unicast || question.Qclass&mdnsUnicastResponse != 0
+		question.Qclass &^= mdnsUnicastResponse
+		questions = append(questions, question)
+	}
+
+	answers := h.answer(ctx, questions)
+	if len(answers) == 0 {
+		return nil, nil
+	}
+
+	resp := new(mdns.Msg)
+	resp.Response = true
+	resp.Authoritative = true
+	resp.Answer = answers
+
+	if src, ok := q.src.(*net.UDPAddr); ok && src.Port != mdnsPort {
+		resp.Id = q.msg.Id
+		resp.Question = questions
+		return resp, q.src
+	}
+
+	for idx := range answers {
+		answers[idx].Header().Class |= mdnsCacheFlush
+	}
+
+	if unicast || h.group == nil {
+		return resp, q.src
+	}
+
+	return resp, h.group
+}
@@ -0,0 +1,123 @@
+package multicast
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	mdns "github.com/miekg/dns"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const (
+	nbnsTypeNB uint16 = 0x20
+
+	netBIOSNameLength        = 16
+	encodedNetBIOSNameLength = 2 * netBIOSNameLength
+)
+
+// nbnsFlagsUniqueBNode are the NB_FLAGS of answers: a unique name of a B-node
+var nbnsFlagsUniqueBNode = []byte{0, 0}
+
+// nbnsProtocol is a NetBIOS name service (RFC 1002) responder answering broadcast and unicast name queries,
+// NetBIOS names are resolved like the DNS name <lower case NetBIOS name>. e.g. WPAD<00> is resolved as 'wpad.'
+var nbnsProtocol = protocol{
+	name:           "nbns_mock",
+	app:            auditv1.AppProtocol_APP_PROTOCOL_NBNS,
+	auditQuestions: nbnsQuestions,
+	respond:        respondNBNS,
+}
+
+// respondNBNS answers name queries with a positive name query response directly to the sender,
+// node status requests are only recorded
+func respondNBNS(ctx context.Context, h *responderHandler, q query) (*mdns.Msg, net.Addr) {
+	resp := new(mdns.Msg)
+	resp.Id = q.msg.Id
+	resp.Response = true
+	resp.Authoritative = true
+	resp.RecursionDesired = q.msg.RecursionDesired
+
+	for _, question := range q.msg.Question {
+		name, _, ok := decodeNetBIOSName(question.Name)
+		if question.Qtype != nbnsTypeNB || !ok {
+			continue
+		}
+
+		answers := h.answer(ctx, []mdns.Question{{
+			Name:   strings.ToLower(name) + ".",
+			Qtype:  mdns.TypeA,
+			Qclass: mdns.ClassINET,
+		}})
+
+		for idx := range answers {
+			a, isA := answers[idx].(*mdns.A)
+			if !isA || a.A.To4() == nil {
+				continue
+			}
+
+			resp.Answer = append(resp.Answer, &mdns.RFC3597{
+				Hdr: mdns.RR_Header{
+					Name:   question.Name,
+					Rrtype: nbnsTypeNB,
+					Class:  mdns.ClassINET,
+					Ttl:    a.Hdr.Ttl,
+				},
+				Rdata: hex.EncodeToString(append(append([]byte{}, nbnsFlagsUniqueBNode...), a.A.To4()...)),
+			})
+			break
+		}
+	}
+
+	if len(resp.Answer) == 0 {
+		return nil, nil
+	}
+
+	return resp, q.src
+}
+
+// nbnsQuestions records the decoded NetBIOS names in the usual notation e.g. WPAD<00>
+func nbnsQuestions(msg *mdns.Msg) []audit.DNSQuestion {
+	questions := make([]audit.DNSQuestion, 0, len(msg.Question))
+	for _, q := range msg.Question {
+		question := audit.DNSQuestion{
+			Name: q.Name,
+		}
+
+		if name, suffix, ok := decodeNetBIOSName(q.Name); ok {
+			question.Name = fmt.Sprintf("%s<%02X>", name, suffix)
+		}
+
+		// NBSTAT shares its type code with SRV and is therefore not mapped
+		if q.Qtype == nbnsTypeNB {
+			question.RRType = auditv1.ResourceRecordType_RESOURCE_RECORD_TYPE_NB
+		}
+
+		questions = append(questions, question)
+	}
+	return questions
+}
+
+// decodeNetBIOSName decodes a first-level encoded NetBIOS name (RFC 1001 section 14.1),
+// the scope of the name is ignored, e.g. FHEPFCELEHFCEPFFFACACACACACACAAA is WORKGROUP with suffix 0x00
+func decodeNetBIOSName(encoded string) (name string, suffix byte, ok bool) {
+	label, _, _ := strings.Cut(encoded, ".")
+	if len(label) != encodedNetBIOSNameLength {
+		return "", 0, false
+	}
+
+	decoded := make([]byte, netBIOSNameLength)
+	for idx := range decoded {
+		high, low := label[2*idx]-'A', label[2*idx+1]-'A'
+		if high > 0x0F || low > 0x0F {
+			return "", 0, false
+		}
+		decoded[idx] = high<<4 | low
+	}
+
+	// names are padded with spaces except for the wildcard name * which is padded with NUL bytes
+	return strings.TrimRight(string(decoded[:netBIOSNameLength-1]), " \x00"), decoded[netBIOSNameLength-1], true
+}
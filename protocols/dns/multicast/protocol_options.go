@@ -0,0 +1,49 @@
+package multicast
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/dns"
+)
+
+type responderOptions struct {
+	*dns.Options
+	// Interfaces the multicast groups are joined on and packets are accepted from,
+	// if empty all interfaces that are up and support multicast are used
+	Interfaces []string
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts responderOptions, err error) {
+	if opts.Options, err = dns.OptionsFromLifecycle(startupSpec); err != nil {
+		return opts, err
+	}
+
+	var ifaces struct {
+		Interfaces []string
+	}
+
+	if err = startupSpec.UnmarshalOptions(&ifaces); err != nil {
+		return opts, err
+	}
+
+	opts.Interfaces = ifaces.Interfaces
+
+	return opts, nil
+}
+
+// handlerChain answers questions by the rules first, the default resolver and the cache are optional
+func (o responderOptions) handlerChain(ruleHandler *dns.RuleHandler) (handler dns.Handler) {
+	handler = ruleHandler
+	if o.Default != nil {
+		handler = dns.FallbackHandler(handler, o.Default, o.TTL)
+	}
+
+	if o.Cache != nil {
+		handler = &dns.CacheHandler{
+			Cache:    o.Cache,
+			TTL:      o.TTL,
+			Fallback: handler,
+		}
+	}
+
+	return handler
+}
@@ -0,0 +1,23 @@
+package multicast
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+var protocols = []protocol{llmnrProtocol, mdnsProtocol, nbnsProtocol}
+
+// AddResponders registers the handlers llmnr_mock, mdns_mock and nbns_mock
+func AddResponders(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter) {
+	for idx := range protocols {
+		proto := protocols[idx]
+		registry.RegisterHandler(endpoint.HandlerReference(proto.name), func() endpoint.ProtocolHandler {
+			return &responderHandler{
+				protocol: proto,
+				logger:   logger.Named(proto.name),
+				emitter:  emitter,
+			}
+		})
+	}
+}
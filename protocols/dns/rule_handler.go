@@ -1,21 +1,31 @@
 package dns
 
 import (
+	"context"
+	"net"
 	"time"
 
 	mdns "github.com/miekg/dns"
 
 	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/protocols"
 )
 
 type RuleHandler struct {
-	resolvers []ConditionalResolver
-	TTL       time.Duration
+	HandlerRef  string
+	HandlerName string
+	resolvers   rules.Set[ConditionalResolver]
+	TTL         time.Duration
+	// Cache is flushed whenever the rules are modified, it is optional
+	Cache ResourceRecordCache
 }
 
-func (r RuleHandler) AnswerDNSQuestion(q Question) (ResourceRecord, error) {
-	for idx := range r.resolvers {
-		if res := r.resolvers[idx]; res.Matches(q) {
+func (r *RuleHandler) AnswerDNSQuestion(ctx context.Context, q Question) (ResourceRecord, error) {
+	resolvers := r.resolvers.Entries()
+	for idx := range resolvers {
+		if res := resolvers[idx].Value; res.Matches(q) {
+			protocols.RecordRuleMatch(resolvers[idx], r.HandlerRef, r.HandlerName, clientFromContext(ctx))
 			resolvedIP := res.Lookup(q.Name)
 			switch q.Qtype {
 			case mdns.TypeA:
@@ -35,22 +45,57 @@ func (r RuleHandler) AnswerDNSQuestion(q Question) (ResourceRecord, error) {
 	return nil, ErrNoAnswerForQuestion
 }
 
-func (r *RuleHandler) RegisterRule(rawRule string) (err error) {
-	var rule *rules.SingleResponsePipeline
-	if rule, err = rules.Parse[rules.SingleResponsePipeline](rawRule); err != nil {
+func (r *RuleHandler) RegisterRule(rawRule string) error {
+	conditionalResolver, err := compileRule(rawRule)
+	if err != nil {
 		return err
 	}
 
-	var conditionalResolver ConditionalResolver
+	r.resolvers.Append(rawRule, conditionalResolver)
+	return nil
+}
+
+// RuleManager allows to modify the rules of the handler while it is answering questions.
+// Cached answers might stem from the previous rules, hence the cache is flushed on every modification.
+func (r *RuleHandler) RuleManager() rules.Manager {
+	var manager rules.Manager = rules.CompilingManager[ConditionalResolver]{
+		Set:     &r.resolvers,
+		Compile: compileRule,
+	}
+
+	if r.Cache != nil {
+		manager = rules.NotifyingManager{Manager: manager, OnChange: r.Cache.Flush}
+	}
+
+	return manager
+}
+
+func compileRule(rawRule string) (conditionalResolver ConditionalResolver, err error) {
+	var rule *rules.SingleResponsePipeline
+	if rule, err = rules.Parse[rules.SingleResponsePipeline](rawRule); err != nil {
+		return conditionalResolver, err
+	}
 
 	if conditionalResolver.Predicates, err = QuestionPredicatesForRoutingRule(rule); err != nil {
-		return err
+		return conditionalResolver, err
 	}
 
 	if conditionalResolver.IPResolver, err = ResolverForRule(rule); err != nil {
-		return err
+		return conditionalResolver, err
 	}
 
-	r.resolvers = append(r.resolvers, conditionalResolver)
-	return nil
+	return conditionalResolver, nil
+}
+
+func clientFromContext(ctx context.Context) string {
+	switch addr := audit.RemoteAddr(ctx).(type) {
+	case *net.UDPAddr:
+		return addr.IP.String()
+	case *net.TCPAddr:
+		return addr.IP.String()
+	case nil:
+		return ""
+	default:
+		return addr.String()
+	}
 }
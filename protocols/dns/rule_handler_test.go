@@ -1,6 +1,7 @@
 package dns_test
 
 import (
+	"context"
 	"net"
 	"testing"
 	"time"
@@ -8,6 +9,8 @@ import (
 	"github.com/maxatome/go-testdeep/td"
 	mdns "github.com/miekg/dns"
 
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	"inetmock.icb4dc0.de/inetmock/protocols/dns"
 )
 
@@ -73,7 +76,7 @@ func TestRuleHandler_AnswerDNSQuestion(t *testing.T) {
 					return
 				}
 			}
-			got, err := r.AnswerDNSQuestion(tt.question)
+			got, err := r.AnswerDNSQuestion(context.Background(), tt.question)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AnswerDNSQuestion() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -82,3 +85,44 @@ func TestRuleHandler_AnswerDNSQuestion(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleHandler_RuleStatistics(t *testing.T) {
+	t.Parallel()
+	r := &dns.RuleHandler{HandlerRef: "dns_mock", HandlerName: t.Name(), TTL: 30 * time.Second}
+	for _, rawRule := range []string{`A("gitlab.com") => IP(1.2.3.4)`, `=> IP(1.1.1.1)`} {
+		if err := r.RegisterRule(rawRule); err != nil {
+			t.Fatalf("Failed to register rule: %v", err)
+		}
+	}
+
+	ctx := audit.StoreAddrsInContext(
+		context.Background(),
+		&net.UDPAddr{IP: net.IPv4(10, 10, 0, 1), Port: 53},
+		&net.UDPAddr{IP: net.IPv4(10, 10, 0, 2), Port: 34567},
+	)
+
+	for _, name := range []string{"gitlab.com", "github.com", "gitlab.com"} {
+		if _, err := r.AnswerDNSQuestion(ctx, dns.Question{Qtype: mdns.TypeA, Name: name}); err != nil {
+			t.Fatalf("AnswerDNSQuestion() error = %v", err)
+		}
+	}
+
+	td.Cmp(t, r.RuleManager().Rules(), td.Slice([]rules.Info{}, td.ArrayEntries{
+		0: td.Struct(rules.Info{
+			Index:      0,
+			Raw:        `A("gitlab.com") => IP(1.2.3.4)`,
+			Hits:       2,
+			LastClient: "10.10.0.2",
+		}, td.StructFields{
+			"LastMatch": td.NotZero(),
+		}),
+		1: td.Struct(rules.Info{
+			Index:      1,
+			Raw:        `=> IP(1.1.1.1)`,
+			Hits:       1,
+			LastClient: "10.10.0.2",
+		}, td.StructFields{
+			"LastMatch": td.NotZero(),
+		}),
+	}))
+}
@@ -0,0 +1,507 @@
+package ftp
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/protocols/quarantine"
+)
+
+func (s *session) cmdUser(arg string) error {
+	s.user = arg
+	s.loggedIn = false
+	return s.reply(331, "Please specify the password")
+}
+
+// cmdPass accepts any password
+func (s *session) cmdPass(arg string) error {
+	s.emit(audit.FTP{Command: "PASS", Password: arg})
+
+	if s.user == "" {
+		return s.reply(503, "Login with USER first")
+	}
+
+	s.loggedIn = true
+	return s.reply(230, "Login successful")
+}
+
+func (s *session) cmdAuth(arg string) error {
+	switch {
+	case s.handler.tlsConfig == nil:
+		return s.reply(502, "TLS not available")
+	case s.isTLS():
+		return s.reply(503, "TLS already established")
+	case !strings.EqualFold(arg, "TLS") && !strings.EqualFold(arg, "SSL"):
+		return s.reply(504, "Unsupported security mechanism")
+	}
+
+	if err := s.reply(234, "Proceed with negotiation"); err != nil {
+		return err
+	}
+
+	tlsConn := tls.Server(s.conn, s.handler.tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		return err
+	}
+
+	s.setConn(tlsConn)
+	return nil
+}
+
+func (s *session) cmdPbsz(string) error {
+	return s.reply(200, "PBSZ=0")
+}
+
+func (s *session) cmdProt(arg string) error {
+	switch strings.ToUpper(arg) {
+	case "C":
+		s.protected = false
+	case "P":
+		if !s.isTLS() {
+			return s.reply(503, "PROT P requires a secure control connection")
+		}
+		s.protected = true
+	default:
+		return s.reply(536, "Unsupported protection level")
+	}
+
+	return s.reply(200, "Protection level set")
+}
+
+func (s *session) cmdFeat(string) error {
+	features := []string{"EPRT", "EPSV", "MDTM", "MLST type*;size*;modify*;", "PASV", "REST STREAM", "SIZE", "UTF8"}
+	if s.handler.tlsConfig != nil {
+		features = append([]string{"AUTH TLS", "PBSZ", "PROT"}, features...)
+	}
+	return s.replyLines(211, "Features:", features, "End")
+}
+
+func (s *session) cmdSyst(string) error {
+	return s.reply(215, "UNIX Type: L8")
+}
+
+func (s *session) cmdOpts(arg string) error {
+	if strings.EqualFold(arg, "UTF8 ON") {
+		return s.reply(200, "Always in UTF8 mode")
+	}
+	return s.reply(501, "Option not understood")
+}
+
+func (s *session) cmdNoop(string) error {
+	return s.reply(200, "OK")
+}
+
+func (s *session) cmdHelp(string) error {
+	return s.reply(214, "Help OK")
+}
+
+func (s *session) cmdQuit(string) error {
+	_ = s.reply(221, "Goodbye")
+	return errQuit
+}
+
+func (s *session) cmdPwd(string) error {
+	return s.replyf(257, "%q is the current directory", s.cwd)
+}
+
+func (s *session) cmdCwd(arg string) error {
+	target := s.resolve(arg)
+	if info, err := fs.Stat(s.handler.fakeFileFS, fsPath(target)); err != nil || !info.IsDir() {
+		return s.reply(550, "Failed to change directory")
+	}
+
+	s.cwd = target
+	return s.reply(250, "Directory successfully changed")
+}
+
+func (s *session) cmdCdup(string) error {
+	return s.cmdCwd("..")
+}
+
+func (s *session) cmdType(arg string) error {
+	dataType, _, _ := strings.Cut(arg, " ")
+	switch strings.ToUpper(dataType) {
+	case "A":
+		return s.reply(200, "Switching to ASCII mode")
+	case "I", "L":
+		return s.reply(200, "Switching to Binary mode")
+	default:
+		return s.reply(504, "Unsupported type")
+	}
+}
+
+func (s *session) cmdMode(arg string) error {
+	if strings.EqualFold(arg, "S") {
+		return s.reply(200, "Mode set to S")
+	}
+	return s.reply(504, "Unsupported mode")
+}
+
+func (s *session) cmdStru(arg string) error {
+	if strings.EqualFold(arg, "F") {
+		return s.reply(200, "Structure set to F")
+	}
+	return s.reply(504, "Unsupported structure")
+}
+
+func (s *session) cmdPasv(string) error {
+	announced := s.handler.options.passiveIP
+	if announced == nil {
+		announced = s.localIP()
+	}
+
+	if announced = announced.To4(); announced == nil {
+		return s.reply(425, "PASV requires IPv4, use EPSV instead")
+	}
+
+	connector, err := listenPassive(s.localIP(), s.remoteIP(), s.handler.options.passivePorts)
+	if err != nil {
+		s.handler.logger.Warn("Failed to setup passive data connection", zap.Error(err))
+		return s.reply(425, "Can't open data connection")
+	}
+
+	s.setData(connector)
+	port := connector.Port()
+	return s.replyf(227, "Entering Passive Mode (%d,%d,%d,%d,%d,%d)", announced[0], announced[1], announced[2], announced[3], port>>8, port&0xff)
+}
+
+func (s *session) cmdEpsv(arg string) error {
+	if strings.EqualFold(arg, "ALL") {
+		return s.reply(200, "EPSV ALL ok")
+	}
+
+	connector, err := listenPassive(s.localIP(), s.remoteIP(), s.handler.options.passivePorts)
+	if err != nil {
+		s.handler.logger.Warn("Failed to setup passive data connection", zap.Error(err))
+		return s.reply(425, "Can't open data connection")
+	}
+
+	s.setData(connector)
+	return s.replyf(229, "Entering Extended Passive Mode (|||%d|)", connector.Port())
+}
+
+func (s *session) cmdPort(arg string) error {
+	addr, err := parsePortArg(arg)
+	if err != nil {
+		return s.reply(501, err.Error())
+	}
+	return s.setActive(addr)
+}
+
+func (s *session) cmdEprt(arg string) error {
+	addr, err := parseEPRTArg(arg)
+	if err != nil {
+		return s.reply(501, err.Error())
+	}
+	return s.setActive(addr)
+}
+
+// setActive only allows data connections to the client itself to prevent FTP bounce attacks
+func (s *session) setActive(addr *net.TCPAddr) error {
+	if !addr.IP.Equal(s.remoteIP()) {
+		return s.reply(500, "Illegal PORT command")
+	}
+
+	s.setData(&activeConnector{addr: addr})
+	return s.reply(200, "PORT command successful")
+}
+
+func (s *session) cmdRest(arg string) error {
+	offset, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || offset < 0 {
+		return s.reply(501, "Invalid restart position")
+	}
+
+	s.restOffset = offset
+	return s.replyf(350, "Restarting at %d", offset)
+}
+
+func (s *session) cmdAbor(string) error {
+	s.closeData()
+	return s.reply(226, "No transfer to abort")
+}
+
+func (s *session) cmdStat(arg string) error {
+	if arg == "" {
+		return s.replyLines(211, "InetMock FTP status", []string{"Logged in as " + s.user}, "End of status")
+	}
+
+	entries, err := listEntries(s.handler.fakeFileFS, s.resolve(arg))
+	if err != nil {
+		return s.reply(550, "No such file or directory")
+	}
+
+	lines := make([]string, 0, len(entries))
+	now := time.Now()
+	for _, entry := range entries {
+		lines = append(lines, longFormat(entry, now))
+	}
+
+	return s.replyLines(213, "Status follows:", lines, "End of status")
+}
+
+func (s *session) cmdSize(arg string) error {
+	p := s.resolve(arg)
+	file, verdict, err := s.openDownload(p)
+	if err != nil {
+		return s.rejectDownload(verdict)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return s.reply(550, "Could not get file size")
+	}
+
+	return s.replyf(213, "%d", info.Size())
+}
+
+func (s *session) cmdMdtm(arg string) error {
+	p := s.resolve(arg)
+	file, verdict, err := s.openDownload(p)
+	if err != nil {
+		return s.rejectDownload(verdict)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return s.reply(550, "Could not get file modification time")
+	}
+
+	return s.reply(213, info.ModTime().UTC().Format(mlstTimeFormat))
+}
+
+func (s *session) cmdMlst(arg string) error {
+	p := s.resolve(arg)
+	info, err := fs.Stat(s.handler.fakeFileFS, fsPath(p))
+	if err != nil {
+		return s.reply(550, "No such file or directory")
+	}
+
+	return s.replyLines(250, "Listing "+p, []string{machineFormat(info, p)}, "End")
+}
+
+func (s *session) cmdList(arg string) error {
+	return s.sendListing("LIST", arg, func(entry fs.FileInfo) string {
+		return longFormat(entry, time.Now())
+	})
+}
+
+func (s *session) cmdNlst(arg string) error {
+	return s.sendListing("NLST", arg, fs.FileInfo.Name)
+}
+
+func (s *session) cmdMlsd(arg string) error {
+	return s.sendListing("MLSD", arg, func(entry fs.FileInfo) string {
+		return machineFormat(entry, entry.Name())
+	})
+}
+
+// sendListing sends the entries of the given directory, options like -la some clients send are ignored
+func (s *session) sendListing(cmd, arg string, format func(entry fs.FileInfo) string) error {
+	if strings.HasPrefix(arg, "-") {
+		_, arg, _ = strings.Cut(arg, " ")
+	}
+
+	p := s.resolve(arg)
+	entries, err := listEntries(s.handler.fakeFileFS, p)
+	if err != nil {
+		s.emit(audit.FTP{Command: cmd, Path: p})
+		s.closeData()
+		return s.reply(550, "No such file or directory")
+	}
+
+	var listing strings.Builder
+	for _, entry := range entries {
+		listing.WriteString(format(entry))
+		listing.WriteString("\r\n")
+	}
+
+	return s.transfer(cmd, p, func(conn net.Conn) (int64, error) {
+		return io.Copy(conn, strings.NewReader(listing.String()))
+	})
+}
+
+func (s *session) cmdRetr(arg string) error {
+	p := s.resolve(arg)
+	offset := s.restOffset
+	s.restOffset = 0
+
+	file, verdict, err := s.openDownload(p)
+	if err != nil {
+		s.emit(audit.FTP{Command: "RETR", Path: p})
+		s.closeData()
+		return s.rejectDownload(verdict)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if offset > 0 {
+		if _, err = io.CopyN(io.Discard, file, offset); err != nil && !errors.Is(err, io.EOF) {
+			s.closeData()
+			return s.reply(550, "Failed to seek to restart position")
+		}
+	}
+
+	return s.transfer("RETR", p, func(conn net.Conn) (int64, error) {
+		return io.Copy(conn, file)
+	})
+}
+
+// openDownload opens the file the first matching rule refers to or the file of the virtual tree if no rule matches
+func (s *session) openDownload(p string) (fs.File, *Verdict, error) {
+	filePath := fsPath(p)
+	if verdict, matched := s.handler.ruleHandler.Evaluate(Request{User: s.user, Path: p}, s.client()); matched {
+		if verdict.Rejects() {
+			return nil, &verdict, fs.ErrPermission
+		}
+		filePath = verdict.File
+	}
+
+	file, err := s.handler.fakeFileFS.Open(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if info, err := file.Stat(); err != nil || info.IsDir() {
+		_ = file.Close()
+		return nil, nil, fs.ErrNotExist
+	}
+
+	return file, nil, nil
+}
+
+func (s *session) rejectDownload(verdict *Verdict) error {
+	if verdict != nil {
+		return s.reply(verdict.Code, verdict.Message)
+	}
+	return s.reply(550, "Failed to open file")
+}
+
+func (s *session) cmdStor(arg string) error {
+	return s.receiveUpload("STOR", s.resolve(arg))
+}
+
+// cmdAppe stores the appended data as a new file because uploads are never visible in the virtual tree
+func (s *session) cmdAppe(arg string) error {
+	return s.receiveUpload("APPE", s.resolve(arg))
+}
+
+func (s *session) cmdStou(arg string) error {
+	if arg == "" {
+		arg = fmt.Sprintf("upload-%d", time.Now().UnixNano())
+	}
+	return s.receiveUpload("STOU", s.resolve(arg))
+}
+
+func (s *session) receiveUpload(cmd, p string) error {
+	s.restOffset = 0
+
+	if verdict, matched := s.handler.ruleHandler.Evaluate(Request{User: s.user, Path: p}, s.client()); matched && verdict.Rejects() {
+		s.emit(audit.FTP{Command: cmd, Path: p})
+		s.closeData()
+		return s.reply(verdict.Code, verdict.Message)
+	}
+
+	return s.transfer(cmd, p, func(conn net.Conn) (int64, error) {
+		stored, size, err := s.handler.quarantine.Save(p, conn)
+		if err == nil {
+			s.handler.logger.Info("Stored uploaded file", zap.String("path", p), zap.String("stored", stored), zap.Int64("size", size))
+		}
+		return size, err
+	})
+}
+
+// transfer opens the prepared data connection, runs the given transfer and emits an audit event with the transferred bytes
+func (s *session) transfer(cmd, p string, run func(conn net.Conn) (int64, error)) error {
+	connector := s.data
+	s.data = nil
+	if connector == nil {
+		s.emit(audit.FTP{Command: cmd, Path: p})
+		return s.reply(425, "Use PORT or PASV first")
+	}
+
+	defer func() {
+		_ = connector.Close()
+	}()
+
+	if err := s.reply(150, "Opening data connection"); err != nil {
+		return err
+	}
+
+	conn, err := s.openData(connector)
+	if err != nil {
+		s.emit(audit.FTP{Command: cmd, Path: p, Passive: connector.Passive()})
+		return s.reply(425, "Can't open data connection")
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(defaultIdleTimeout))
+	n, err := run(conn)
+	closeErr := conn.Close()
+
+	s.emit(audit.FTP{Command: cmd, Path: p, Bytes: n, Passive: connector.Passive()})
+
+	switch {
+	case errors.Is(err, quarantine.ErrUploadTooLarge):
+		return s.reply(552, "Exceeded storage allocation")
+	case err != nil || closeErr != nil:
+		s.handler.logger.Debug("Data transfer failed", zap.String("command", cmd), zap.Error(errors.Join(err, closeErr)))
+		return s.reply(426, "Connection closed; transfer aborted")
+	default:
+		return s.reply(226, "Transfer complete")
+	}
+}
+
+func (s *session) openData(connector dataConnector) (net.Conn, error) {
+	conn, err := connector.Open()
+	if err != nil || !s.protected {
+		return conn, err
+	}
+
+	tlsConn := tls.Server(conn, s.handler.tlsConfig)
+	if err = tlsConn.Handshake(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+// cmdFakeModification pretends to delete files or directories, the virtual tree is never modified
+func (s *session) cmdFakeModification(string) error {
+	return s.reply(250, "Requested file action okay, completed")
+}
+
+func (s *session) cmdMkd(arg string) error {
+	return s.replyf(257, "%q created", s.resolve(arg))
+}
+
+func (s *session) cmdRnfr(arg string) error {
+	s.renameFrom = s.resolve(arg)
+	return s.reply(350, "Ready for RNTO")
+}
+
+func (s *session) cmdRnto(string) error {
+	if s.renameFrom == "" {
+		return s.reply(503, "RNFR required first")
+	}
+
+	s.renameFrom = ""
+	return s.reply(250, "Rename successful")
+}
@@ -0,0 +1,137 @@
+package ftp
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errNoPassivePort = errors.New("no free passive port available")
+
+// dataConnector opens the data connection for the next transfer
+type dataConnector interface {
+	Open() (net.Conn, error)
+	Passive() bool
+	Close() error
+}
+
+type passiveConnector struct {
+	listener net.Listener
+	// peerIP is the IP of the control connection, data connections from other IPs are rejected
+	peerIP net.IP
+}
+
+// listenPassive listens on the local IP of the control connection on a port of the given range,
+// only the client of the control connection identified by peerIP may connect
+func listenPassive(localIP, peerIP net.IP, ports portRange) (*passiveConnector, error) {
+	//nolint:gosec // the start of the search does not have to be unpredictable
+	offset := rand.Intn(ports.size())
+	for i := 0; i < ports.size(); i++ {
+		port := 0
+		if ports.min != 0 {
+			port = ports.min + (offset+i)%ports.size()
+		}
+
+		listener, err := net.Listen("tcp", net.JoinHostPort(localIP.String(), strconv.Itoa(port)))
+		if err == nil {
+			return &passiveConnector{listener: listener, peerIP: peerIP}, nil
+		}
+	}
+
+	return nil, errNoPassivePort
+}
+
+func (c *passiveConnector) Port() int {
+	return c.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (c *passiveConnector) Open() (net.Conn, error) {
+	if tcpListener, ok := c.listener.(*net.TCPListener); ok {
+		_ = tcpListener.SetDeadline(time.Now().Add(defaultConnectTimeout))
+	}
+
+	defer func() {
+		_ = c.listener.Close()
+	}()
+
+	for {
+		conn, err := c.listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		// like the PORT bounce check, connections of third parties must not steal the data of the session
+		if tcpAddr, ok := conn.RemoteAddr().(*net.TCPAddr); ok && tcpAddr.IP.Equal(c.peerIP) {
+			return conn, nil
+		}
+
+		_ = conn.Close()
+	}
+}
+
+func (c *passiveConnector) Passive() bool {
+	return true
+}
+
+func (c *passiveConnector) Close() error {
+	return c.listener.Close()
+}
+
+type activeConnector struct {
+	addr *net.TCPAddr
+}
+
+func (c *activeConnector) Open() (net.Conn, error) {
+	return net.DialTimeout("tcp", c.addr.String(), defaultConnectTimeout)
+}
+
+func (c *activeConnector) Passive() bool {
+	return false
+}
+
+func (c *activeConnector) Close() error {
+	return nil
+}
+
+// parsePortArg parses the argument of a PORT command e.g. 127,0,0,1,4,1
+func parsePortArg(arg string) (*net.TCPAddr, error) {
+	var parts [6]int
+	if n, err := fmt.Sscanf(arg, "%d,%d,%d,%d,%d,%d", &parts[0], &parts[1], &parts[2], &parts[3], &parts[4], &parts[5]); err != nil || n != len(parts) {
+		return nil, fmt.Errorf("malformed PORT argument %q", arg)
+	}
+
+	for _, p := range parts {
+		if p < 0 || p > 255 {
+			return nil, fmt.Errorf("malformed PORT argument %q", arg)
+		}
+	}
+
+	return &net.TCPAddr{
+		IP:   net.IPv4(byte(parts[0]), byte(parts[1]), byte(parts[2]), byte(parts[3])),
+		Port: parts[4]<<8 | parts[5],
+	}, nil
+}
+
+// parseEPRTArg parses the argument of an EPRT command e.g. |2|::1|1025|
+func parseEPRTArg(arg string) (*net.TCPAddr, error) {
+	if len(arg) < 2 {
+		return nil, fmt.Errorf("malformed EPRT argument %q", arg)
+	}
+
+	fields := strings.Split(arg[1:], arg[:1])
+	if len(fields) != 4 || fields[3] != "" {
+		return nil, fmt.Errorf("malformed EPRT argument %q", arg)
+	}
+
+	ip := net.ParseIP(fields[1])
+	port, err := strconv.Atoi(fields[2])
+	if ip == nil || err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("malformed EPRT argument %q", arg)
+	}
+
+	return &net.TCPAddr{IP: ip, Port: port}, nil
+}
@@ -0,0 +1,122 @@
+package ftp
+
+import (
+	"context"
+	"crypto/tls"
+	"io/fs"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/quarantine"
+)
+
+const (
+	name                  = "ftp_mock"
+	defaultIdleTimeout    = 5 * time.Minute
+	defaultConnectTimeout = 30 * time.Second
+)
+
+type ftpHandler struct {
+	logger        logging.Logger
+	emitter       audit.Emitter
+	certStore     cert.Store
+	fakeFileFS    fs.FS
+	quarantineDir string
+	options       ftpOptions
+	tlsConfig     *tls.Config
+	quarantine    *quarantine.Store
+	ruleHandler   *RuleHandler
+	conns         endpoint.ConnTracker
+}
+
+func (h *ftpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec, h.quarantineDir); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	if h.quarantine, err = quarantine.New(h.options.QuarantineDir, h.options.MaxUploadBytes); err != nil {
+		h.logger.Error("Failed to setup quarantine directory", zap.String("quarantine_dir", h.options.QuarantineDir), zap.Error(err))
+		return err
+	}
+
+	ruleHandler := &RuleHandler{
+		HandlerName: startupSpec.Name,
+	}
+
+	for idx := range h.options.Rules {
+		rule := h.options.Rules[idx]
+		if err = ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+	h.ruleHandler = ruleHandler
+
+	if h.certStore != nil {
+		h.tlsConfig = h.certStore.TLSConfig()
+	}
+
+	h.conns.Open()
+
+	go h.serve(startupSpec.Listener)
+	return nil
+}
+
+// Stop closes all control connections, the data connection of a session is closed when its control connection ends
+func (h *ftpHandler) Stop(context.Context) error {
+	h.conns.CloseAll()
+	return nil
+}
+
+func (h *ftpHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+func (h *ftpHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept FTP connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *ftpHandler) handleConn(conn net.Conn) {
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+	}()
+
+	s := newSession(h, conn)
+	defer s.closeData()
+
+	if err := endpoint.IgnoreShutdownError(s.serve()); err != nil {
+		h.logger.Debug("FTP session terminated", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,465 @@
+package ftp_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	goftp "github.com/jlaffaye/ftp"
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/ftp"
+)
+
+const (
+	readmeContent  = "nothing to see here\n"
+	payloadContent = "MZ fake payload"
+)
+
+var fakeFiles = fstest.MapFS{
+	"pub/readme.txt": &fstest.MapFile{Data: []byte(readmeContent)},
+	"default.exe":    &fstest.MapFile{Data: []byte(payloadContent)},
+}
+
+func Test_ftpHandler_Start(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		opts          map[string]any
+		session       func(tb testing.TB, c *goftp.ServerConn)
+		wantStartErr  bool
+		wantEvents    any
+		wantFileCount int
+	}{
+		{
+			name: "Browse virtual tree and download file",
+			session: func(tb testing.TB, c *goftp.ServerConn) {
+				tb.Helper()
+				td.CmpNoError(tb, c.ChangeDir("pub"))
+
+				entries, err := c.List("")
+				td.CmpNoError(tb, err)
+				td.Cmp(tb, entries, td.All(
+					td.Len(1),
+					td.ArrayEach(td.Struct(&goftp.Entry{Name: "readme.txt", Type: goftp.EntryTypeFile, Size: uint64(len(readmeContent))}, td.StructFields{
+						"Time": td.Ignore(),
+					})),
+				))
+
+				td.Cmp(tb, retrieve(tb, c, "readme.txt"), readmeContent)
+			},
+			wantEvents: td.All(
+				td.Contains(td.Struct(new(audit.Event), td.StructFields{
+					"Application": auditv1.AppProtocol_APP_PROTOCOL_FTP,
+					"Transport":   auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP,
+					"ProtocolDetails": td.Struct(audit.FTP{
+						Command:  "PASS",
+						User:     "anonymous",
+						Password: "anonymous",
+					}, nil),
+				})),
+				td.Contains(td.Struct(new(audit.Event), td.StructFields{
+					"ProtocolDetails": td.Struct(audit.FTP{
+						Command: "CWD",
+						User:    "anonymous",
+						Path:    "/pub",
+					}, nil),
+				})),
+				td.Contains(td.Struct(new(audit.Event), td.StructFields{
+					"ProtocolDetails": td.Struct(audit.FTP{
+						Command: "RETR",
+						User:    "anonymous",
+						Path:    "/pub/readme.txt",
+						Bytes:   int64(len(readmeContent)),
+						Passive: true,
+					}, nil),
+				})),
+			),
+		},
+		{
+			name: "Download file served by rule",
+			opts: map[string]any{
+				"rules": []string{
+					`Path("\\.exe$") => File("default.exe")`,
+				},
+			},
+			session: func(tb testing.TB, c *goftp.ServerConn) {
+				tb.Helper()
+				size, err := c.FileSize("/payloads/stage2.exe")
+				td.CmpNoError(tb, err)
+				td.Cmp(tb, size, int64(len(payloadContent)))
+				td.Cmp(tb, retrieve(tb, c, "/payloads/stage2.exe"), payloadContent)
+			},
+			wantEvents: td.Contains(td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(audit.FTP{
+					Command: "RETR",
+					User:    "anonymous",
+					Path:    "/payloads/stage2.exe",
+					Bytes:   int64(len(payloadContent)),
+					Passive: true,
+				}, nil),
+			})),
+		},
+		{
+			name: "Download rejected by rule",
+			opts: map[string]any{
+				"rules": []string{
+					`User("^anonymous$") => Reject(550, "no access")`,
+				},
+			},
+			session: func(tb testing.TB, c *goftp.ServerConn) {
+				tb.Helper()
+				_, err := c.Retr("/pub/readme.txt")
+				td.Cmp(tb, err, &textproto.Error{Code: 550, Msg: "no access"})
+			},
+			wantEvents: td.Contains(td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(audit.FTP{
+					Command: "RETR",
+					User:    "anonymous",
+					Path:    "/pub/readme.txt",
+				}, nil),
+			})),
+		},
+		{
+			name: "Missing file",
+			session: func(tb testing.TB, c *goftp.ServerConn) {
+				tb.Helper()
+				_, err := c.Retr("/missing.txt")
+				td.CmpError(tb, err)
+				td.CmpError(tb, c.ChangeDir("/missing"))
+			},
+			wantEvents: td.Len(td.Gt(0)),
+		},
+		{
+			name: "Upload is captured",
+			session: func(tb testing.TB, c *goftp.ServerConn) {
+				tb.Helper()
+				td.CmpNoError(tb, c.Stor("/incoming/loot.zip", bytes.NewReader([]byte("secret data"))))
+			},
+			wantEvents: td.Contains(td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(audit.FTP{
+					Command: "STOR",
+					User:    "anonymous",
+					Path:    "/incoming/loot.zip",
+					Bytes:   11,
+					Passive: true,
+				}, nil),
+			})),
+			wantFileCount: 1,
+		},
+		{
+			name: "Upload exceeding maximum size is discarded",
+			opts: map[string]any{
+				"maxUploadBytes": 4,
+			},
+			session: func(tb testing.TB, c *goftp.ServerConn) {
+				tb.Helper()
+				td.CmpError(tb, c.Stor("/incoming/loot.zip", bytes.NewReader([]byte("secret data"))))
+			},
+			wantEvents: td.Len(td.Gt(0)),
+		},
+		{
+			name: "Upload rejected by rule",
+			opts: map[string]any{
+				"rules": []string{
+					`Path("^/incoming/") => Reject(553, "read-only")`,
+				},
+			},
+			session: func(tb testing.TB, c *goftp.ServerConn) {
+				tb.Helper()
+				td.CmpError(tb, c.Stor("/incoming/loot.zip", bytes.NewReader([]byte("secret data"))))
+			},
+			wantEvents: td.Len(td.Gt(0)),
+		},
+		{
+			name: "Error because of unknown verdict",
+			opts: map[string]any{
+				"rules": []string{
+					`=> Serve()`,
+				},
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of invalid passive port range",
+			opts: map[string]any{
+				"passivePortRange": "30100-30000",
+			},
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			quarantineDir := t.TempDir()
+			listener := test.NewTCPListener(t, "127.0.0.1:0")
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := ftp.New(logging.CreateTestLogger(t), emitterMock, nil, fakeFiles, quarantineDir)
+			startupSpec := endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.opts)
+
+			if err := handler.Start(ctx, startupSpec); err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			t.Cleanup(func() {
+				_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+				_ = listener.Close()
+			})
+
+			c, err := goftp.Dial(listener.Addr().String(), goftp.DialWithTimeout(5*time.Second))
+			if err != nil {
+				t.Fatalf("Dial() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = c.Quit()
+			})
+
+			td.CmpNoError(t, c.Login("anonymous", "anonymous"))
+			tt.session(t, c)
+			td.CmpNoError(t, c.NoOp())
+
+			files, err := os.ReadDir(quarantineDir)
+			td.CmpNoError(t, err)
+			td.Cmp(t, len(files), tt.wantFileCount)
+
+			emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+				events := make([]*audit.Event, 0, len(calls.Emit()))
+				for _, call := range calls.Emit() {
+					events = append(events, call.Params.Ev)
+				}
+				td.Cmp(t, events, tt.wantEvents)
+			})
+		})
+	}
+}
+
+func Test_ftpHandler_ExplicitTLS(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(test.Context(t))
+	t.Cleanup(cancel)
+
+	quarantineDir := t.TempDir()
+	listener := test.NewTCPListener(t, "127.0.0.1:0")
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := ftp.New(logging.CreateTestLogger(t), emitterMock, test.NewSelfSignedCertStore(t), fakeFiles, quarantineDir)
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+		_ = listener.Close()
+	})
+
+	c, err := goftp.Dial(
+		listener.Addr().String(),
+		goftp.DialWithTimeout(5*time.Second),
+		//nolint:gosec // the test server uses a self-signed certificate
+		goftp.DialWithExplicitTLS(&tls.Config{InsecureSkipVerify: true, ServerName: "ftp.example.com"}),
+	)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = c.Quit()
+	})
+
+	td.CmpNoError(t, c.Login("ted", "secret"))
+	td.Cmp(t, retrieve(t, c, "/pub/readme.txt"), readmeContent)
+	td.CmpNoError(t, c.Stor("upload.bin", bytes.NewReader([]byte(payloadContent))))
+
+	uploads, err := filepath.Glob(filepath.Join(quarantineDir, "*-upload.bin"))
+	td.CmpNoError(t, err)
+	if td.Cmp(t, uploads, td.Len(1)) {
+		content, err := os.ReadFile(uploads[0])
+		td.CmpNoError(t, err)
+		td.Cmp(t, string(content), payloadContent)
+	}
+
+	emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+		var retrEvent *audit.Event
+		for _, call := range calls.Emit() {
+			if details, ok := call.Params.Ev.ProtocolDetails.(audit.FTP); ok && details.Command == "RETR" {
+				retrEvent = call.Params.Ev
+			}
+		}
+
+		if td.CmpNotNil(t, retrEvent) {
+			td.Cmp(t, retrEvent.TLS, td.Struct(&audit.TLSDetails{
+				ServerName: "ftp.example.com",
+			}, td.StructFields{
+				"Version": td.NotZero(),
+			}))
+		}
+	})
+}
+
+func Test_ftpHandler_ActiveMode(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(test.Context(t))
+	t.Cleanup(cancel)
+
+	listener := test.NewTCPListener(t, "127.0.0.1:0")
+	handler := ftp.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock), nil, fakeFiles, t.TempDir())
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+		_ = listener.Close()
+	})
+
+	conn, err := textproto.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("textproto.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	dataListener := test.NewTCPListener(t, "127.0.0.1:0")
+	dataPort := dataListener.Addr().(*net.TCPAddr).Port
+
+	expect := func(code int, format string, args ...any) {
+		t.Helper()
+		if format != "" {
+			td.CmpNoError(t, conn.PrintfLine(format, args...))
+		}
+		_, _, err := conn.ReadResponse(code)
+		td.CmpNoError(t, err)
+	}
+
+	expect(220, "")
+	expect(331, "USER ted")
+	expect(230, "PASS secret")
+	expect(500, "PORT 10,0,0,1,4,1")
+	expect(200, "PORT 127,0,0,1,%d,%d", dataPort>>8, dataPort&0xff)
+	expect(150, "RETR /default.exe")
+
+	dataConn, err := dataListener.Accept()
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	content, err := io.ReadAll(dataConn)
+	td.CmpNoError(t, err)
+	td.Cmp(t, string(content), payloadContent)
+
+	expect(226, "")
+	expect(200, "EPRT |1|127.0.0.1|%d|", dataPort)
+	expect(221, "QUIT")
+}
+
+func Test_ftpHandler_PassiveModeRejectsForeignPeer(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(test.Context(t))
+	t.Cleanup(cancel)
+
+	listener := test.NewTCPListener(t, "127.0.0.1:0")
+	handler := ftp.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock), nil, fakeFiles, t.TempDir())
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+		_ = listener.Close()
+	})
+
+	conn, err := textproto.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("textproto.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	expect := func(code int, format string, args ...any) string {
+		t.Helper()
+		if format != "" {
+			td.CmpNoError(t, conn.PrintfLine(format, args...))
+		}
+		_, msg, err := conn.ReadResponse(code)
+		td.CmpNoError(t, err)
+		return msg
+	}
+
+	expect(220, "")
+	expect(331, "USER ted")
+	expect(230, "PASS secret")
+
+	var dataPort int
+	if _, err := fmt.Sscanf(expect(229, "EPSV"), "Entering Extended Passive Mode (|||%d|)", &dataPort); err != nil {
+		t.Fatalf("Sscanf() error = %v", err)
+	}
+	dataAddr := net.JoinHostPort("127.0.0.1", fmt.Sprint(dataPort))
+
+	// the foreign peer connects before the client and must not receive the data of the transfer
+	foreignDialer := net.Dialer{Timeout: time.Second, LocalAddr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 2)}}
+	foreignConn, err := foreignDialer.Dial("tcp", dataAddr)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = foreignConn.Close()
+	})
+
+	dataConn, err := net.DialTimeout("tcp", dataAddr, time.Second)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = dataConn.Close()
+	})
+
+	expect(150, "RETR /default.exe")
+
+	content, err := io.ReadAll(dataConn)
+	td.CmpNoError(t, err)
+	td.Cmp(t, string(content), payloadContent)
+
+	stolen, _ := io.ReadAll(foreignConn)
+	td.Cmp(t, stolen, td.Empty())
+
+	expect(226, "")
+	expect(221, "QUIT")
+}
+
+func retrieve(tb testing.TB, c *goftp.ServerConn, path string) string {
+	tb.Helper()
+	resp, err := c.Retr(path)
+	if err != nil {
+		tb.Fatalf("Retr(%s) error = %v", path, err)
+	}
+
+	content, err := io.ReadAll(resp)
+	td.CmpNoError(tb, err)
+	td.CmpNoError(tb, resp.Close(), fmt.Sprintf("close %s", path))
+
+	return string(content)
+}
@@ -0,0 +1,71 @@
+package ftp
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+)
+
+const mlstTimeFormat = "20060102150405"
+
+// fsPath converts an absolute virtual path to a path of the fake file FS
+func fsPath(virtualPath string) string {
+	if p := strings.TrimPrefix(path.Clean(virtualPath), "/"); p != "" {
+		return p
+	}
+	return "."
+}
+
+// listEntries returns the entries of a directory or the file itself if p is not a directory
+func listEntries(fsys fs.FS, p string) ([]fs.FileInfo, error) {
+	info, err := fs.Stat(fsys, fsPath(p))
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []fs.FileInfo{info}, nil
+	}
+
+	entries, err := fs.ReadDir(fsys, fsPath(p))
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]fs.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entryInfo, err := entry.Info(); err == nil {
+			infos = append(infos, entryInfo)
+		}
+	}
+
+	return infos, nil
+}
+
+// longFormat formats an entry like `ls -l` does which is what most clients expect as LIST output
+func longFormat(info fs.FileInfo, now time.Time) string {
+	perms := "-rw-r--r--"
+	if info.IsDir() {
+		perms = "drwxr-xr-x"
+	}
+
+	modTime := info.ModTime()
+	timeFormat := "Jan _2 15:04"
+	if modTime.Year() != now.Year() {
+		timeFormat = "Jan _2  2006"
+	}
+
+	return fmt.Sprintf("%s 1 ftp ftp %12d %s %s", perms, info.Size(), modTime.Format(timeFormat), info.Name())
+}
+
+// machineFormat formats the facts of an entry as defined for MLST and MLSD in RFC 3659
+func machineFormat(info fs.FileInfo, name string) string {
+	entryType := "file"
+	if info.IsDir() {
+		entryType = "dir"
+	}
+
+	return fmt.Sprintf("type=%s;size=%d;modify=%s; %s", entryType, info.Size(), info.ModTime().UTC().Format(mlstTimeFormat), name)
+}
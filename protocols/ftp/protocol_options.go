@@ -0,0 +1,92 @@
+package ftp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/quarantine"
+)
+
+const (
+	defaultBanner         = "InetMock FTP server ready"
+	defaultMaxUploadBytes = 100 * 1024 * 1024
+)
+
+var ErrInvalidPortRange = errors.New("invalid passive port range")
+
+type ftpOptions struct {
+	Banner           string
+	PassivePortRange string
+	PassiveAddress   string
+	QuarantineDir    string
+	MaxUploadBytes   int64
+	Rules            []string
+	passivePorts     portRange
+	passiveIP        net.IP
+}
+
+// portRange is the range passive data ports are allocated from, the zero value lets the OS pick a port
+type portRange struct {
+	min, max int
+}
+
+func (r portRange) size() int {
+	if r.min == 0 {
+		return 1
+	}
+	return r.max - r.min + 1
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec, quarantineDir string) (opts ftpOptions, err error) {
+	opts = ftpOptions{
+		Banner:         defaultBanner,
+		MaxUploadBytes: defaultMaxUploadBytes,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts); err != nil {
+		return opts, err
+	}
+
+	if opts.passivePorts, err = parsePortRange(opts.PassivePortRange); err != nil {
+		return opts, err
+	}
+
+	if opts.PassiveAddress != "" {
+		if opts.passiveIP = net.ParseIP(opts.PassiveAddress); opts.passiveIP == nil {
+			return opts, fmt.Errorf("passive address %q is not an IP address", opts.PassiveAddress)
+		}
+	}
+
+	opts.QuarantineDir = quarantine.ResolveDir(quarantineDir, opts.QuarantineDir)
+
+	return opts, nil
+}
+
+// parsePortRange parses ranges like 30000-30100 or a single port
+func parsePortRange(raw string) (r portRange, err error) {
+	if raw == "" {
+		return r, nil
+	}
+
+	lower, upper, isRange := strings.Cut(raw, "-")
+	if r.min, err = strconv.Atoi(strings.TrimSpace(lower)); err != nil {
+		return r, fmt.Errorf("%w %q: %v", ErrInvalidPortRange, raw, err)
+	}
+
+	r.max = r.min
+	if isRange {
+		if r.max, err = strconv.Atoi(strings.TrimSpace(upper)); err != nil {
+			return r, fmt.Errorf("%w %q: %v", ErrInvalidPortRange, raw, err)
+		}
+	}
+
+	if r.min < 1 || r.max > 65535 || r.min > r.max {
+		return r, fmt.Errorf("%w %q", ErrInvalidPortRange, raw)
+	}
+
+	return r, nil
+}
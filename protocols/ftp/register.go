@@ -0,0 +1,33 @@
+package ftp
+
+import (
+	"io/fs"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+func New(logger logging.Logger, emitter audit.Emitter, certStore cert.Store, fakeFileFS fs.FS, quarantineDir string) endpoint.ProtocolHandler {
+	return &ftpHandler{
+		logger:        logger,
+		emitter:       emitter,
+		certStore:     certStore,
+		fakeFileFS:    fakeFileFS,
+		quarantineDir: quarantineDir,
+	}
+}
+
+func AddFTPMock(
+	registry endpoint.HandlerRegistry,
+	logger logging.Logger,
+	emitter audit.Emitter,
+	certStore cert.Store,
+	fakeFileFS fs.FS,
+	quarantineDir string,
+) {
+	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
+		return New(logger, emitter, certStore, fakeFileFS, quarantineDir)
+	})
+}
@@ -0,0 +1,215 @@
+package ftp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+const (
+	defaultRejectCode    = 550
+	defaultRejectMessage = "Permission denied"
+)
+
+var (
+	knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
+		"path": PathFilter,
+		"user": UserFilter,
+	}
+	knownVerdicts = map[string]func(args ...rules.Param) (Verdict, error){
+		"file":   FileVerdict,
+		"reject": RejectVerdict,
+	}
+)
+
+type (
+	// Request contains the information a rule may match on.
+	// Path is the absolute path within the virtual file tree.
+	Request struct {
+		User string
+		Path string
+	}
+	RequestFilter interface {
+		Matches(req Request) bool
+	}
+	RequestFilterFunc func(req Request) bool
+	FilterChain       []RequestFilter
+
+	// Verdict either serves a fake file for a download or rejects the transfer if Code is set.
+	// Uploads are only affected by rejecting verdicts.
+	Verdict struct {
+		File    string
+		Code    int
+		Message string
+	}
+
+	ConditionalVerdict struct {
+		Filters FilterChain
+		Verdict Verdict
+	}
+)
+
+func (f RequestFilterFunc) Matches(req Request) bool {
+	return f(req)
+}
+
+func (c FilterChain) Matches(req Request) bool {
+	for idx := range c {
+		if !c[idx].Matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v Verdict) Rejects() bool {
+	return v.Code != 0
+}
+
+type RuleHandler struct {
+	HandlerName string
+	verdicts    rules.Set[ConditionalVerdict]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	verdict, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.verdicts.Append(rawRule, verdict)
+	return nil
+}
+
+// RuleManager exposes the download and upload rules, changes apply to the next transfer of open sessions.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalVerdict]{
+		Set:     &h.verdicts,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the verdict of the first rule matching the given request
+func (h *RuleHandler) Evaluate(req Request, client string) (verdict Verdict, matched bool) {
+	verdicts := h.verdicts.Entries()
+	for idx := range verdicts {
+		entry := verdicts[idx]
+		if entry.Value.Filters.Matches(req) {
+			protocols.RecordRuleMatch(entry, name, h.HandlerName, client)
+			return entry.Value.Verdict, true
+		}
+	}
+
+	return verdict, false
+}
+
+func compileRule(rawRule string) (verdict ConditionalVerdict, err error) {
+	var rule *rules.SingleResponsePipeline
+	if rule, err = rules.Parse[rules.SingleResponsePipeline](rawRule); err != nil {
+		return verdict, err
+	}
+
+	if verdict.Filters, err = filtersForRule(rule); err != nil {
+		return verdict, err
+	}
+
+	if rule.Response == nil {
+		return verdict, rules.ErrNoTerminatorDefined
+	}
+
+	if constructor, ok := knownVerdicts[strings.ToLower(rule.Response.Name)]; !ok {
+		return verdict, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response.Name)
+	} else if verdict.Verdict, err = constructor(rule.Response.Params...); err != nil {
+		return verdict, err
+	}
+
+	return verdict, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownRequestFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+// PathFilter matches the absolute path of the requested file e.g. Path(`\.exe$`)
+func PathFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return req.Path
+	})
+}
+
+func UserFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return req.User
+	})
+}
+
+func regexFilter(args []rules.Param, selector func(req Request) string) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var exp *regexp.Regexp
+	if rawExp, err := args[0].AsString(); err != nil {
+		return nil, err
+	} else if exp, err = regexp.Compile(rawExp); err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return exp.MatchString(selector(req))
+	}), nil
+}
+
+// FileVerdict serves the given file of the fake files directory e.g. File("default.exe")
+func FileVerdict(args ...rules.Param) (verdict Verdict, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return verdict, err
+	}
+
+	verdict.File, err = args[0].AsString()
+	return verdict, err
+}
+
+// RejectVerdict rejects a transfer with an optional FTP reply code and message e.g. Reject(553, "not allowed")
+func RejectVerdict(args ...rules.Param) (verdict Verdict, err error) {
+	verdict = Verdict{
+		Code:    defaultRejectCode,
+		Message: defaultRejectMessage,
+	}
+
+	if len(args) > 0 {
+		if verdict.Code, err = args[0].AsInt(); err != nil {
+			return verdict, err
+		}
+		if verdict.Code < 400 || verdict.Code > 599 {
+			return verdict, fmt.Errorf("%w: reject code %d is not a 4xx or 5xx reply", rules.ErrTypeMismatch, verdict.Code)
+		}
+	}
+
+	if len(args) > 1 {
+		if verdict.Message, err = args[1].AsString(); err != nil {
+			return verdict, err
+		}
+	}
+
+	return verdict, nil
+}
@@ -0,0 +1,254 @@
+package ftp
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"path"
+	"strings"
+	"time"
+
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var errQuit = errors.New("client quit")
+
+type command struct {
+	handle func(s *session, arg string) error
+	// public commands are allowed before the client logged in
+	public bool
+	// pathArg commands take a path as argument which is resolved for the audit event
+	pathArg bool
+	// selfAudited commands emit their own audit event e.g. with the transferred bytes
+	selfAudited bool
+}
+
+var commands = map[string]command{
+	"USER": {handle: (*session).cmdUser, public: true},
+	"PASS": {handle: (*session).cmdPass, public: true, selfAudited: true},
+	"AUTH": {handle: (*session).cmdAuth, public: true},
+	"PBSZ": {handle: (*session).cmdPbsz, public: true},
+	"PROT": {handle: (*session).cmdProt, public: true},
+	"FEAT": {handle: (*session).cmdFeat, public: true},
+	"SYST": {handle: (*session).cmdSyst, public: true},
+	"OPTS": {handle: (*session).cmdOpts, public: true},
+	"NOOP": {handle: (*session).cmdNoop, public: true},
+	"HELP": {handle: (*session).cmdHelp, public: true},
+	"QUIT": {handle: (*session).cmdQuit, public: true},
+
+	"PWD":  {handle: (*session).cmdPwd},
+	"XPWD": {handle: (*session).cmdPwd},
+	"CWD":  {handle: (*session).cmdCwd, pathArg: true},
+	"XCWD": {handle: (*session).cmdCwd, pathArg: true},
+	"CDUP": {handle: (*session).cmdCdup},
+	"XCUP": {handle: (*session).cmdCdup},
+	"TYPE": {handle: (*session).cmdType},
+	"MODE": {handle: (*session).cmdMode},
+	"STRU": {handle: (*session).cmdStru},
+	"ALLO": {handle: (*session).cmdNoop},
+	"PASV": {handle: (*session).cmdPasv},
+	"EPSV": {handle: (*session).cmdEpsv},
+	"PORT": {handle: (*session).cmdPort},
+	"EPRT": {handle: (*session).cmdEprt},
+	"REST": {handle: (*session).cmdRest},
+	"ABOR": {handle: (*session).cmdAbor},
+	"STAT": {handle: (*session).cmdStat},
+
+	"SIZE": {handle: (*session).cmdSize, pathArg: true},
+	"MDTM": {handle: (*session).cmdMdtm, pathArg: true},
+	"MLST": {handle: (*session).cmdMlst, pathArg: true},
+	"LIST": {handle: (*session).cmdList, selfAudited: true},
+	"NLST": {handle: (*session).cmdNlst, selfAudited: true},
+	"MLSD": {handle: (*session).cmdMlsd, selfAudited: true},
+	"RETR": {handle: (*session).cmdRetr, selfAudited: true},
+	"STOR": {handle: (*session).cmdStor, selfAudited: true},
+	"APPE": {handle: (*session).cmdAppe, selfAudited: true},
+	"STOU": {handle: (*session).cmdStou, selfAudited: true},
+
+	"DELE": {handle: (*session).cmdFakeModification, pathArg: true},
+	"RMD":  {handle: (*session).cmdFakeModification, pathArg: true},
+	"XRMD": {handle: (*session).cmdFakeModification, pathArg: true},
+	"MKD":  {handle: (*session).cmdMkd, pathArg: true},
+	"XMKD": {handle: (*session).cmdMkd, pathArg: true},
+	"RNFR": {handle: (*session).cmdRnfr, pathArg: true},
+	"RNTO": {handle: (*session).cmdRnto, pathArg: true},
+}
+
+type session struct {
+	handler    *ftpHandler
+	conn       net.Conn
+	reader     *textproto.Reader
+	writer     *textproto.Writer
+	user       string
+	loggedIn   bool
+	cwd        string
+	protected  bool
+	restOffset int64
+	renameFrom string
+	data       dataConnector
+}
+
+func newSession(handler *ftpHandler, conn net.Conn) *session {
+	s := &session{
+		handler: handler,
+		cwd:     "/",
+	}
+	s.setConn(conn)
+	return s
+}
+
+func (s *session) setConn(conn net.Conn) {
+	s.conn = conn
+	s.reader = textproto.NewReader(bufio.NewReader(conn))
+	s.writer = textproto.NewWriter(bufio.NewWriter(conn))
+}
+
+func (s *session) serve() error {
+	if err := s.reply(220, s.handler.options.Banner); err != nil {
+		return err
+	}
+
+	for {
+		_ = s.conn.SetDeadline(time.Now().Add(defaultIdleTimeout))
+		line, err := s.reader.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		if err = s.handle(line); errors.Is(err, errQuit) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *session) handle(line string) error {
+	name, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	name = strings.ToUpper(name)
+
+	cmd, known := commands[name]
+	if !known {
+		s.emit(audit.FTP{Command: name})
+		return s.reply(502, "Command not implemented")
+	}
+
+	if !cmd.selfAudited {
+		details := audit.FTP{Command: name}
+		if cmd.pathArg {
+			details.Path = s.resolve(arg)
+		}
+		s.emit(details)
+	}
+
+	if !cmd.public && !s.loggedIn {
+		return s.reply(530, "Please login with USER and PASS")
+	}
+
+	// RNTO has to follow RNFR immediately
+	if name != "RNFR" && name != "RNTO" {
+		s.renameFrom = ""
+	}
+
+	return cmd.handle(s, arg)
+}
+
+func (s *session) reply(code int, msg string) error {
+	return s.writer.PrintfLine("%d %s", code, msg)
+}
+
+func (s *session) replyf(code int, format string, args ...any) error {
+	return s.reply(code, fmt.Sprintf(format, args...))
+}
+
+// replyLines sends a multi-line reply, all but the first and the last line are indented
+func (s *session) replyLines(code int, first string, lines []string, last string) error {
+	if err := s.writer.PrintfLine("%d-%s", code, first); err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if err := s.writer.PrintfLine(" %s", line); err != nil {
+			return err
+		}
+	}
+	return s.writer.PrintfLine("%d %s", code, last)
+}
+
+// resolve returns the absolute path of arg within the virtual file tree
+func (s *session) resolve(arg string) string {
+	if arg == "" {
+		return s.cwd
+	}
+	if !path.IsAbs(arg) {
+		arg = path.Join(s.cwd, arg)
+	}
+	return path.Clean(arg)
+}
+
+func (s *session) isTLS() bool {
+	_, ok := s.conn.(*tls.Conn)
+	return ok
+}
+
+func (s *session) localIP() net.IP {
+	if tcpAddr, ok := s.conn.LocalAddr().(*net.TCPAddr); ok {
+		return tcpAddr.IP
+	}
+	return net.IPv4zero
+}
+
+func (s *session) remoteIP() net.IP {
+	if tcpAddr, ok := s.conn.RemoteAddr().(*net.TCPAddr); ok {
+		return tcpAddr.IP
+	}
+	return nil
+}
+
+func (s *session) client() string {
+	if ip, _, err := netutils.IPPortFromAddress(s.conn.RemoteAddr()); err == nil {
+		return ip.String()
+	}
+	return ""
+}
+
+func (s *session) setData(connector dataConnector) {
+	s.closeData()
+	s.data = connector
+}
+
+func (s *session) closeData() {
+	if s.data != nil {
+		_ = s.data.Close()
+		s.data = nil
+	}
+}
+
+func (s *session) emit(details audit.FTP) {
+	if details.User == "" {
+		details.User = s.user
+	}
+
+	builder := s.handler.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_FTP).
+		WithProtocolDetails(details)
+
+	if details, ok := audit.NewTLSDetailsFromConn(s.conn); ok {
+		builder = builder.WithTLSDetails(details)
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(s.conn.RemoteAddr())
+	builder, _ = builder.WithDestinationFromAddr(s.conn.LocalAddr())
+
+	builder.Emit()
+}
@@ -0,0 +1,220 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const (
+	authorityKey = ":authority"
+	// maxRecordedRequests limits the request messages of streaming calls added to the audit event
+	maxRecordedRequests = 100
+)
+
+// call is a single RPC, the method is nil if it is not part of the descriptor set
+type call struct {
+	handler    *grpcHandler
+	stream     grpc.ServerStream
+	conn       net.Conn
+	fullMethod string
+	method     protoreflect.MethodDescriptor
+	metadata   metadata.MD
+	requests   []string
+	sent       int
+}
+
+// serve evaluates the rules for the single request of unary and server streaming calls,
+// for the last request of client streaming calls and for every request of bidirectional streaming calls
+func (c *call) serve() error {
+	switch {
+	case c.method != nil && c.method.IsStreamingClient() && c.method.IsStreamingServer():
+		for {
+			req, err := c.recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			// unmatched messages of bidirectional streams are ignored like unmatched WebSocket messages
+			if resp, ok := c.evaluate(req); ok {
+				if final, err := c.execute(resp); final || err != nil {
+					return err
+				}
+			}
+		}
+	case c.method != nil && c.method.IsStreamingClient():
+		last := Request{
+			FullMethod: c.fullMethod,
+			Metadata:   c.metadata,
+			Message:    dynamicpb.NewMessage(c.method.Input()),
+		}
+		for {
+			req, err := c.recv()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return err
+			}
+			last = req
+		}
+		return c.respond(last)
+	default:
+		req, err := c.recv()
+		if err != nil {
+			return err
+		}
+		return c.respond(req)
+	}
+}
+
+func (c *call) recv() (req Request, err error) {
+	req = Request{
+		FullMethod: c.fullMethod,
+		Metadata:   c.metadata,
+	}
+
+	if c.method == nil {
+		// the message is only consumed because it can't be decoded without its descriptor
+		return req, c.stream.RecvMsg(new(emptypb.Empty))
+	}
+
+	msg := dynamicpb.NewMessage(c.method.Input())
+	if err = c.stream.RecvMsg(msg); err != nil {
+		return req, err
+	}
+
+	if len(c.requests) < maxRecordedRequests {
+		// protojson randomizes whitespaces, compacting keeps the recorded requests stable
+		if encoded, err := protojson.Marshal(msg); err == nil {
+			compacted := new(bytes.Buffer)
+			if json.Compact(compacted, encoded) == nil {
+				c.requests = append(c.requests, compacted.String())
+			}
+		}
+	}
+
+	req.Message = msg
+	return req, nil
+}
+
+func (c *call) respond(req Request) error {
+	resp, ok := c.evaluate(req)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "no rule matches %s", c.fullMethod)
+	}
+
+	_, err := c.execute(resp)
+	return err
+}
+
+func (c *call) evaluate(req Request) (Response, bool) {
+	var client string
+	if c.conn != nil {
+		client = c.conn.RemoteAddr().String()
+	}
+
+	return c.handler.ruleHandler.Evaluate(req, client)
+}
+
+// execute runs the actions in order until a status action terminates the call
+func (c *call) execute(resp Response) (final bool, err error) {
+	for idx := range resp {
+		action := resp[idx]
+		switch {
+		case action.Status != nil:
+			return true, action.Status.Err()
+		case action.Message != nil:
+			if err = c.send(action.Message); err != nil {
+				return true, err
+			}
+		case action.Header != nil:
+			if err = c.stream.SetHeader(action.Header); err != nil {
+				c.handler.logger.Debug("failed to set header", zap.String("method", c.fullMethod), zap.Error(err))
+			}
+		case action.Trailer != nil:
+			c.stream.SetTrailer(action.Trailer)
+		case action.Delay > 0:
+			timer := time.NewTimer(action.Delay)
+			select {
+			case <-c.stream.Context().Done():
+				timer.Stop()
+				return true, status.FromContextError(c.stream.Context().Err()).Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (c *call) send(rawMsg []byte) error {
+	if c.method == nil {
+		return status.Errorf(codes.Internal, "can't encode message for %s without descriptor", c.fullMethod)
+	}
+
+	if c.sent > 0 && !c.method.IsStreamingServer() {
+		c.handler.logger.Debug("ignoring additional message of non streaming method", zap.String("method", c.fullMethod))
+		return nil
+	}
+
+	var msg proto.Message = dynamicpb.NewMessage(c.method.Output())
+	if err := protojson.Unmarshal(rawMsg, msg); err != nil {
+		c.handler.logger.Warn("failed to decode response message", zap.String("method", c.fullMethod), zap.Error(err))
+		return status.Errorf(codes.Internal, "invalid response message for %s", c.fullMethod)
+	}
+
+	c.sent++
+	return c.stream.SendMsg(msg)
+}
+
+func (c *call) emit(err error) {
+	st := status.Convert(err)
+	details := audit.GRPC{
+		FullMethod:    c.fullMethod,
+		Metadata:      c.metadata.Copy(),
+		Requests:      c.requests,
+		StatusCode:    st.Code(),
+		StatusMessage: st.Message(),
+	}
+
+	if authority := details.Metadata.Get(authorityKey); len(authority) > 0 {
+		details.Authority = authority[0]
+		details.Metadata.Delete(authorityKey)
+	}
+
+	builder := c.handler.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_GRPC).
+		WithProtocolDetails(details)
+
+	if c.conn != nil {
+		if details, ok := audit.NewTLSDetailsFromConn(c.conn); ok {
+			builder = builder.WithTLSDetails(details)
+		}
+
+		// it's considered to be okay if these details are missing
+		builder, _ = builder.WithSourceFromAddr(c.conn.RemoteAddr())
+		builder, _ = builder.WithDestinationFromAddr(c.conn.LocalAddr())
+	}
+
+	builder.Emit()
+}
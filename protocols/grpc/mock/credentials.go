@@ -0,0 +1,46 @@
+package mock
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+)
+
+var _ credentials.TransportCredentials = connCredentials{}
+
+// connCredentials don't secure anything, TLS is terminated by the endpoint if configured.
+// They only make the underlying connection available to the handler to emit its addresses and TLS details.
+type connCredentials struct{}
+
+type connInfo struct {
+	credentials.CommonAuthInfo
+	conn net.Conn
+}
+
+func (connInfo) AuthType() string {
+	return "inetmock"
+}
+
+func (connCredentials) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, connInfo{conn: conn}, nil
+}
+
+func (connCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, connInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+		conn:           conn,
+	}, nil
+}
+
+func (connCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "insecure"}
+}
+
+func (c connCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (connCredentials) OverrideServerName(string) error {
+	return nil
+}
@@ -0,0 +1,142 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var ErrMissingDependency = errors.New("dependency of file descriptor is missing")
+
+var _ protodesc.Resolver = (*descriptors)(nil)
+
+// descriptors contains all files of a FileDescriptorSet.
+// Dependencies not contained in the set e.g. well known types are resolved from the global registry.
+type descriptors struct {
+	files    *protoregistry.Files
+	services []string
+	methods  map[string]protoreflect.MethodDescriptor
+}
+
+func loadDescriptors(path string) (*descriptors, error) {
+	d := &descriptors{
+		files:   new(protoregistry.Files),
+		methods: make(map[string]protoreflect.MethodDescriptor),
+	}
+
+	if path == "" {
+		return d, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	set := new(descriptorpb.FileDescriptorSet)
+	if err = proto.Unmarshal(raw, set); err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor set %s: %w", path, err)
+	}
+
+	pending := make(map[string]*descriptorpb.FileDescriptorProto, len(set.File))
+	for _, file := range set.File {
+		pending[file.GetName()] = file
+	}
+
+	for _, file := range set.File {
+		if err = d.register(file, pending); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// register adds the file after all its dependencies because descriptor sets are not necessarily ordered topologically
+func (d *descriptors) register(file *descriptorpb.FileDescriptorProto, pending map[string]*descriptorpb.FileDescriptorProto) error {
+	if _, err := d.files.FindFileByPath(file.GetName()); err == nil {
+		return nil
+	}
+
+	// remove the file before resolving its dependencies to stop on import cycles
+	delete(pending, file.GetName())
+
+	for _, dependency := range file.Dependency {
+		if dependencyFile, ok := pending[dependency]; ok {
+			if err := d.register(dependencyFile, pending); err != nil {
+				return err
+			}
+		} else if _, err := d.FindFileByPath(dependency); err != nil {
+			return fmt.Errorf("%w: %s imported by %s", ErrMissingDependency, dependency, file.GetName())
+		}
+	}
+
+	fd, err := protodesc.NewFile(file, d)
+	if err != nil {
+		return err
+	}
+
+	if err = d.files.RegisterFile(fd); err != nil {
+		return err
+	}
+
+	services := fd.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		d.services = append(d.services, string(service.FullName()))
+		methods := service.Methods()
+		for j := 0; j < methods.Len(); j++ {
+			d.methods[fullMethodName(methods.Get(j))] = methods.Get(j)
+		}
+	}
+
+	return nil
+}
+
+func (d *descriptors) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := d.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (d *descriptors) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if desc, err := d.files.FindDescriptorByName(name); err == nil {
+		return desc, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// Method returns the descriptor of a full method name e.g. /grpc.health.v1.Health/Check
+func (d *descriptors) Method(fullMethod string) (protoreflect.MethodDescriptor, bool) {
+	method, ok := d.methods[fullMethod]
+	return method, ok
+}
+
+// serviceInfoProvider merges the services registered at the server e.g. the reflection service
+// with the services of the descriptor set handled by the unknown service handler
+type serviceInfoProvider struct {
+	server      *grpc.Server
+	descriptors *descriptors
+}
+
+func (p serviceInfoProvider) GetServiceInfo() map[string]grpc.ServiceInfo {
+	info := p.server.GetServiceInfo()
+	for _, service := range p.descriptors.services {
+		if _, ok := info[service]; !ok {
+			info[service] = grpc.ServiceInfo{}
+		}
+	}
+	return info
+}
+
+func fullMethodName(method protoreflect.MethodDescriptor) string {
+	return fmt.Sprintf("/%s/%s", method.Parent().FullName(), method.Name())
+}
@@ -0,0 +1,135 @@
+package mock
+
+import (
+	"context"
+	"net"
+
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/multiplexing"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const name = "grpc_mock"
+
+var (
+	_ endpoint.MatchWritingHandler = (*grpcHandler)(nil)
+	_ endpoint.StoppableHandler    = (*grpcHandler)(nil)
+)
+
+type grpcHandler struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	options     grpcOptions
+	descriptors *descriptors
+	ruleHandler *RuleHandler
+	server      *grpc.Server
+}
+
+// Matchers are not used because gRPC clients only send their request headers after the server sent its settings
+// hence the MatchWriters are used instead
+func (h *grpcHandler) Matchers() []cmux.Matcher {
+	return nil
+}
+
+func (h *grpcHandler) MatchWriters() []cmux.MatchWriter {
+	return []cmux.MatchWriter{multiplexing.GRPC()}
+}
+
+func (h *grpcHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	if h.descriptors, err = loadDescriptors(h.options.DescriptorSet); err != nil {
+		h.logger.Error("failed to load descriptor set", zap.String("descriptor_set", h.options.DescriptorSet), zap.Error(err))
+		return err
+	}
+
+	ruleHandler := &RuleHandler{
+		HandlerName: startupSpec.Name,
+	}
+
+	for idx := range h.options.Rules {
+		rule := h.options.Rules[idx]
+		if err = ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+	h.ruleHandler = ruleHandler
+
+	h.server = grpc.NewServer(
+		grpc.Creds(connCredentials{}),
+		grpc.UnknownServiceHandler(h.handleStream),
+	)
+
+	if h.options.Reflection {
+		reflectionv1alpha.RegisterServerReflectionServer(h.server, reflection.NewServer(reflection.ServerOptions{
+			Services:           serviceInfoProvider{server: h.server, descriptors: h.descriptors},
+			DescriptorResolver: h.descriptors,
+		}))
+	}
+
+	go h.serve(startupSpec.Listener, h.server)
+	return nil
+}
+
+// Stop closes all open connections, the listener itself is closed by the endpoint
+func (h *grpcHandler) Stop(context.Context) error {
+	if h.server != nil {
+		h.server.Stop()
+	}
+	return nil
+}
+
+func (h *grpcHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+func (h *grpcHandler) serve(listener net.Listener, server *grpc.Server) {
+	if err := endpoint.IgnoreShutdownError(server.Serve(listener)); err != nil {
+		h.logger.Error("Failed to serve gRPC", zap.Error(err))
+	}
+}
+
+// handleStream handles every call because the mocked services are only known at runtime
+func (h *grpcHandler) handleStream(_ any, stream grpc.ServerStream) (err error) {
+	c := &call{
+		handler: h,
+		stream:  stream,
+	}
+
+	c.fullMethod, _ = grpc.MethodFromServerStream(stream)
+	c.method, _ = h.descriptors.Method(c.fullMethod)
+	c.metadata, _ = metadata.FromIncomingContext(stream.Context())
+	if p, ok := peer.FromContext(stream.Context()); ok {
+		if info, ok := p.AuthInfo.(connInfo); ok {
+			c.conn = info.conn
+		}
+	}
+
+	defer func() {
+		c.emit(err)
+	}()
+
+	return c.serve()
+}
@@ -0,0 +1,427 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/grpc/mock"
+)
+
+const (
+	callTimeout      = 5 * time.Second
+	healthCheck      = "/grpc.health.v1.Health/Check"
+	healthWatch      = "/grpc.health.v1.Health/Watch"
+	reflectionInfo   = "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo"
+	defaultAuthority = "inetmock.icb4dc0.de:443"
+)
+
+type grpcCall func(ctx context.Context, conn *grpc.ClientConn) (any, error)
+
+func Test_grpcHandler_Call(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		opts       map[string]any
+		call       grpcCall
+		want       any
+		wantCode   codes.Code
+		wantEvents any
+	}{
+		{
+			name: "Unary call matched by field",
+			opts: healthOptions(t,
+				`Method("^/grpc.health.v1.Health/Check$") -> Field("service", "^db$") => Message('{"status": "NOT_SERVING"}')`,
+				`Method("^/grpc.health.v1.Health/Check$") => Message('{"status": "SERVING"}')`,
+			),
+			call: checkHealth("db"),
+			want: healthv1.HealthCheckResponse_NOT_SERVING,
+			wantEvents: []any{
+				td.Struct(audit.GRPC{
+					FullMethod: healthCheck,
+					Authority:  defaultAuthority,
+					Requests:   []string{`{"service":"db"}`},
+					StatusCode: codes.OK,
+				}, td.StructFields{
+					"Metadata": td.SuperMapOf(metadata.MD{"content-type": []string{"application/grpc"}}, nil),
+				}),
+			},
+		},
+		{
+			name: "Unary call matched by fallback rule",
+			opts: healthOptions(t,
+				`Field("service", "^db$") => Message('{"status": "NOT_SERVING"}')`,
+				`=> Message('{"status": "SERVING"}')`,
+			),
+			call: checkHealth("web"),
+			want: healthv1.HealthCheckResponse_SERVING,
+		},
+		{
+			name: "Unary call matched by metadata with header",
+			opts: healthOptions(t,
+				`Metadata("authorization", "^Bearer ") => Header("x-server", "inetmock") => Message('{"status": "SERVING"}')`,
+				`=> Status("UNAUTHENTICATED", "missing token")`,
+			),
+			call: func(ctx context.Context, conn *grpc.ClientConn) (any, error) {
+				var header metadata.MD
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer 42")
+				_, err := healthv1.NewHealthClient(conn).Check(ctx, new(healthv1.HealthCheckRequest), grpc.Header(&header))
+				return header.Get("x-server"), err
+			},
+			want: []string{"inetmock"},
+		},
+		{
+			name: "Status by name",
+			opts: healthOptions(t,
+				`Metadata("authorization", "^Bearer ") => Message('{"status": "SERVING"}')`,
+				`=> Trailer("x-reason", "token") => Status("UNAUTHENTICATED", "missing token")`,
+			),
+			call:     checkHealth(""),
+			wantCode: codes.Unauthenticated,
+			wantEvents: []any{
+				td.Struct(audit.GRPC{
+					FullMethod:    healthCheck,
+					Authority:     defaultAuthority,
+					Requests:      []string{`{}`},
+					StatusCode:    codes.Unauthenticated,
+					StatusMessage: "missing token",
+				}, td.StructFields{
+					"Metadata": td.Ignore(),
+				}),
+			},
+		},
+		{
+			name:     "Unimplemented if no rule matches",
+			opts:     healthOptions(t, `Field("service", "^db$") => Message('{"status": "NOT_SERVING"}')`),
+			call:     checkHealth("web"),
+			wantCode: codes.Unimplemented,
+		},
+		{
+			name:     "Internal error for invalid response message",
+			opts:     healthOptions(t, `=> Message('{"state": "SERVING"}')`),
+			call:     checkHealth("web"),
+			wantCode: codes.Internal,
+		},
+		{
+			name: "Status for method without descriptor",
+			opts: map[string]any{
+				"rules": []string{`Method("Check$") => Status(14, "maintenance")`},
+			},
+			call:     checkHealth("web"),
+			wantCode: codes.Unavailable,
+			wantEvents: []any{
+				td.Struct(audit.GRPC{
+					FullMethod:    healthCheck,
+					Authority:     defaultAuthority,
+					StatusCode:    codes.Unavailable,
+					StatusMessage: "maintenance",
+				}, td.StructFields{
+					"Metadata": td.Ignore(),
+				}),
+			},
+		},
+		{
+			name: "Server streaming call",
+			opts: healthOptions(t,
+				`Method("Watch$") => Message('{"status": "SERVING"}') => Delay("10ms") => Message('{"status": "NOT_SERVING"}')`,
+			),
+			call: func(ctx context.Context, conn *grpc.ClientConn) (any, error) {
+				stream, err := healthv1.NewHealthClient(conn).Watch(ctx, &healthv1.HealthCheckRequest{Service: "db"})
+				if err != nil {
+					return nil, err
+				}
+
+				var got []healthv1.HealthCheckResponse_ServingStatus
+				for {
+					resp, err := stream.Recv()
+					if errors.Is(err, io.EOF) {
+						return got, nil
+					} else if err != nil {
+						return got, err
+					}
+					got = append(got, resp.Status)
+				}
+			},
+			want: []healthv1.HealthCheckResponse_ServingStatus{
+				healthv1.HealthCheckResponse_SERVING,
+				healthv1.HealthCheckResponse_NOT_SERVING,
+			},
+			wantEvents: []any{
+				td.Struct(audit.GRPC{
+					FullMethod: healthWatch,
+					Authority:  defaultAuthority,
+					Requests:   []string{`{"service":"db"}`},
+					StatusCode: codes.OK,
+				}, td.StructFields{
+					"Metadata": td.Ignore(),
+				}),
+			},
+		},
+		{
+			name: "Bidirectional streaming call",
+			opts: map[string]any{
+				"descriptorSet": writeDescriptorSet(t, reflectionv1alpha.File_grpc_reflection_v1alpha_reflection_proto),
+				"reflection":    false,
+				"rules": []string{
+					`Field("host", "^bye$") => Status(0)`,
+					`Field("host", "^inetmock$") => Message('{"validHost": "inetmock"}')`,
+				},
+			},
+			call: func(ctx context.Context, conn *grpc.ClientConn) (any, error) {
+				stream, err := reflectionv1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, host := range []string{"inetmock", "ignored", "inetmock", "bye"} {
+					if err := stream.Send(&reflectionv1alpha.ServerReflectionRequest{Host: host}); err != nil {
+						return nil, err
+					}
+				}
+
+				var got []string
+				for {
+					resp, err := stream.Recv()
+					if errors.Is(err, io.EOF) {
+						return got, nil
+					} else if err != nil {
+						return got, err
+					}
+					got = append(got, resp.ValidHost)
+				}
+			},
+			want: []string{"inetmock", "inetmock"},
+			wantEvents: []any{
+				td.Struct(audit.GRPC{
+					FullMethod: reflectionInfo,
+					Authority:  defaultAuthority,
+					Requests: []string{
+						`{"host":"inetmock"}`,
+						`{"host":"ignored"}`,
+						`{"host":"inetmock"}`,
+						`{"host":"bye"}`,
+					},
+					StatusCode: codes.OK,
+				}, td.StructFields{
+					"Metadata": td.Ignore(),
+				}),
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			listener, emitterMock := startHandler(t, tt.opts)
+
+			ctx, cancel := context.WithTimeout(test.Context(t), callTimeout)
+			t.Cleanup(cancel)
+
+			got, err := tt.call(ctx, test.GRPCClientConnForInMemListener(ctx, t, listener))
+			td.Cmp(t, status.Code(err), tt.wantCode)
+			if tt.want != nil {
+				td.Cmp(t, got, tt.want)
+			}
+
+			if tt.wantEvents != nil {
+				test.AwaitEventDetails(t, emitterMock, auditv1.AppProtocol_APP_PROTOCOL_GRPC, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func Test_grpcHandler_Reflection(t *testing.T) {
+	t.Parallel()
+	listener, _ := startHandler(t, healthOptions(t))
+
+	ctx, cancel := context.WithTimeout(test.Context(t), callTimeout)
+	t.Cleanup(cancel)
+
+	conn := test.GRPCClientConnForInMemListener(ctx, t, listener)
+	stream, err := reflectionv1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if !td.CmpNoError(t, err) {
+		return
+	}
+
+	err = stream.Send(&reflectionv1alpha.ServerReflectionRequest{
+		MessageRequest: &reflectionv1alpha.ServerReflectionRequest_ListServices{},
+	})
+	if !td.CmpNoError(t, err) {
+		return
+	}
+
+	resp, err := stream.Recv()
+	if !td.CmpNoError(t, err) {
+		return
+	}
+
+	td.Cmp(t, resp.GetListServicesResponse().GetService(), td.Bag(
+		td.Struct(&reflectionv1alpha.ServiceResponse{Name: "grpc.health.v1.Health"}, nil),
+		td.Struct(&reflectionv1alpha.ServiceResponse{Name: "grpc.reflection.v1alpha.ServerReflection"}, nil),
+	))
+}
+
+func Test_grpcHandler_Start(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		opts      map[string]any
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "Start with descriptor set and rules",
+			opts: healthOptions(t,
+				`Method("Check$") -> Metadata("authorization", "^Bearer ") => Header("x-server", "inetmock") => Message('{}')`,
+				`=> Delay("10ms") => Trailer("x-reason", "token") => Status(16)`,
+			),
+		},
+		{
+			name: "Error because of missing descriptor set",
+			opts: map[string]any{
+				"descriptorSet": filepath.Join(t.TempDir(), "missing.protoset"),
+			},
+			wantErr:   true,
+			wantErrIs: os.ErrNotExist,
+		},
+		{
+			name: "Error because of missing dependency",
+			opts: map[string]any{
+				"descriptorSet": writeRawDescriptorSet(t, &descriptorpb.FileDescriptorSet{
+					File: []*descriptorpb.FileDescriptorProto{
+						{Name: proto.String("c2.proto"), Dependency: []string{"missing.proto"}},
+					},
+				}),
+			},
+			wantErr:   true,
+			wantErrIs: mock.ErrMissingDependency,
+		},
+		{
+			name:      "Error because of invalid JSON",
+			opts:      healthOptions(t, `=> Message('{"status": ')`),
+			wantErr:   true,
+			wantErrIs: mock.ErrInvalidJSON,
+		},
+		{
+			name:      "Error because of invalid status code",
+			opts:      healthOptions(t, `=> Status(17)`),
+			wantErr:   true,
+			wantErrIs: mock.ErrInvalidStatusCode,
+		},
+		{
+			name:      "Error because of unknown status name",
+			opts:      healthOptions(t, `=> Status("BROKEN")`),
+			wantErr:   true,
+			wantErrIs: mock.ErrInvalidStatusCode,
+		},
+		{
+			name:    "Error because of unknown action",
+			opts:    healthOptions(t, `=> Reply('{}')`),
+			wantErr: true,
+		},
+		{
+			name:    "Error because of invalid delay",
+			opts:    healthOptions(t, `=> Delay("soon")`),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			handler := mock.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock))
+			spec := endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(test.NewInMemoryListener(t)), tt.opts)
+
+			err := handler.Start(test.Context(t), spec)
+			if stoppable, ok := handler.(endpoint.StoppableHandler); ok {
+				t.Cleanup(func() {
+					_ = stoppable.Stop(context.Background())
+				})
+			}
+
+			td.Cmp(t, err != nil, tt.wantErr)
+			if tt.wantErrIs != nil {
+				td.Cmp(t, errors.Is(err, tt.wantErrIs), true)
+			}
+		})
+	}
+}
+
+func startHandler(t *testing.T, opts map[string]any) (test.InMemListener, *audit_mock.EmitterMock) {
+	t.Helper()
+	listener := test.NewInMemoryListener(t)
+	emitterMock := new(audit_mock.EmitterMock)
+
+	handler := mock.New(logging.CreateTestLogger(t), emitterMock)
+	if err := handler.Start(test.Context(t), endpoint.NewStartupSpec("grpc", endpoint.NewUplink(listener), opts)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+	})
+
+	return listener, emitterMock
+}
+
+func checkHealth(service string) grpcCall {
+	return func(ctx context.Context, conn *grpc.ClientConn) (any, error) {
+		resp, err := healthv1.NewHealthClient(conn).Check(ctx, &healthv1.HealthCheckRequest{Service: service})
+		return resp.GetStatus(), err
+	}
+}
+
+func healthOptions(tb testing.TB, rules ...string) map[string]any {
+	tb.Helper()
+	return map[string]any{
+		"descriptorSet": writeDescriptorSet(tb, healthv1.File_grpc_health_v1_health_proto),
+		"rules":         rules,
+	}
+}
+
+func writeDescriptorSet(tb testing.TB, files ...protoreflect.FileDescriptor) string {
+	tb.Helper()
+	set := new(descriptorpb.FileDescriptorSet)
+	for _, file := range files {
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+
+	return writeRawDescriptorSet(tb, set)
+}
+
+func writeRawDescriptorSet(tb testing.TB, set *descriptorpb.FileDescriptorSet) string {
+	tb.Helper()
+	raw, err := proto.Marshal(set)
+	if err != nil {
+		tb.Fatalf("proto.Marshal() error = %v", err)
+	}
+
+	path := filepath.Join(tb.TempDir(), "services.protoset")
+	if err = os.WriteFile(path, raw, 0o600); err != nil {
+		tb.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	return path
+}
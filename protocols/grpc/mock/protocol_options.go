@@ -0,0 +1,23 @@
+package mock
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+type grpcOptions struct {
+	// DescriptorSet is the path to a binary FileDescriptorSet describing the mocked services
+	// e.g. recorded from a real server with grpcurl -protoset-out or compiled with protoc --descriptor_set_out
+	DescriptorSet string
+	// Reflection serves the server reflection service for all services of the descriptor set
+	Reflection bool
+	Rules      []string
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts grpcOptions, err error) {
+	opts = grpcOptions{
+		Reflection: true,
+	}
+
+	err = startupSpec.UnmarshalOptions(&opts)
+	return opts, err
+}
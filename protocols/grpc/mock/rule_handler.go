@@ -0,0 +1,407 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+var (
+	ErrInvalidJSON       = errors.New("message is not valid JSON")
+	ErrInvalidStatusCode = errors.New("invalid gRPC status code")
+	ErrEmptyFieldPath    = errors.New("field path must not be empty")
+
+	knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
+		"method":   MethodFilter,
+		"field":    FieldFilter,
+		"metadata": MetadataFilter,
+	}
+	knownActions = map[string]func(args ...rules.Param) (Action, error){
+		"message": MessageAction,
+		"status":  StatusAction,
+		"header":  HeaderAction,
+		"trailer": TrailerAction,
+		"delay":   DelayAction,
+	}
+)
+
+type (
+	// Request is a single message received from a client.
+	// FullMethod is the full method name e.g. /grpc.health.v1.Health/Check, Message is nil if the method is not part
+	// of the descriptor set.
+	Request struct {
+		FullMethod string
+		Metadata   metadata.MD
+		Message    protoreflect.Message
+	}
+	RequestFilter interface {
+		Matches(req Request) bool
+	}
+	RequestFilterFunc func(req Request) bool
+	FilterChain       []RequestFilter
+
+	// Action is executed in the order of the rule.
+	// Exactly one of the fields is set: a message sent as JSON, response header or trailer metadata, a delay or the
+	// status of the call.
+	Action struct {
+		Message []byte
+		Header  metadata.MD
+		Trailer metadata.MD
+		Delay   time.Duration
+		Status  *status.Status
+	}
+
+	Response []Action
+
+	ConditionalResponse struct {
+		Filters  FilterChain
+		Response Response
+	}
+)
+
+func (f RequestFilterFunc) Matches(req Request) bool {
+	return f(req)
+}
+
+func (c FilterChain) Matches(req Request) bool {
+	for idx := range c {
+		if !c[idx].Matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+type RuleHandler struct {
+	HandlerName string
+	responses   rules.Set[ConditionalResponse]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	response, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.responses.Append(rawRule, response)
+	return nil
+}
+
+// RuleManager exposes the call rules, bidirectional streams evaluate every further message against the modified rules.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalResponse]{
+		Set:     &h.responses,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the response of the first rule matching the given request
+func (h *RuleHandler) Evaluate(req Request, client string) (Response, bool) {
+	responses := h.responses.Entries()
+	for idx := range responses {
+		entry := responses[idx]
+		if entry.Value.Filters.Matches(req) {
+			protocols.RecordRuleMatch(entry, name, h.HandlerName, client)
+			return entry.Value.Response, true
+		}
+	}
+
+	return nil, false
+}
+
+func compileRule(rawRule string) (response ConditionalResponse, err error) {
+	var rule *rules.ChainedResponsePipeline
+	if rule, err = rules.Parse[rules.ChainedResponsePipeline](rawRule); err != nil {
+		return response, err
+	}
+
+	if response.Filters, err = filtersForRule(rule); err != nil {
+		return response, err
+	}
+
+	if len(rule.Response) == 0 {
+		return response, rules.ErrNoTerminatorDefined
+	}
+
+	response.Response = make(Response, 0, len(rule.Response))
+	for idx := range rule.Response {
+		constructor, ok := knownActions[strings.ToLower(rule.Response[idx].Name)]
+		if !ok {
+			return response, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response[idx].Name)
+		}
+
+		var action Action
+		if action, err = constructor(rule.Response[idx].Params...); err != nil {
+			return response, err
+		}
+		response.Response = append(response.Response, action)
+	}
+
+	return response, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownRequestFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+// MethodFilter matches the full method name against a regular expression e.g. Method(`^/grpc.health.v1.Health/Check$`)
+func MethodFilter(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	exp, err := regexParam(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return exp.MatchString(req.FullMethod)
+	}), nil
+}
+
+// FieldFilter matches a field of the request message against a regular expression.
+// Nested fields are separated by dots e.g. Field("user.name", "^admin$"), repeated fields match if any element matches.
+func FieldFilter(args ...rules.Param) (RequestFilter, error) {
+	const expectedParams = 2
+	if err := rules.ValidateParameterCount(args, expectedParams); err != nil {
+		return nil, err
+	}
+
+	rawPath, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	if rawPath == "" {
+		return nil, ErrEmptyFieldPath
+	}
+
+	exp, err := regexParam(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	path := strings.Split(rawPath, ".")
+	return RequestFilterFunc(func(req Request) bool {
+		if req.Message == nil {
+			return false
+		}
+		for _, value := range fieldValues(req.Message, path) {
+			if exp.MatchString(value) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// MetadataFilter matches the values of a request metadata key against a regular expression
+// e.g. Metadata("authorization", "^Bearer ")
+func MetadataFilter(args ...rules.Param) (RequestFilter, error) {
+	const expectedParams = 2
+	if err := rules.ValidateParameterCount(args, expectedParams); err != nil {
+		return nil, err
+	}
+
+	key, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	exp, err := regexParam(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		for _, value := range req.Metadata.Get(key) {
+			if exp.MatchString(value) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// MessageAction sends a response message encoded as JSON e.g. Message(`{"status": "SERVING"}`),
+// server streaming methods send one message per action
+func MessageAction(args ...rules.Param) (action Action, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return action, err
+	}
+
+	var msg string
+	if msg, err = args[0].AsString(); err != nil {
+		return action, err
+	}
+
+	if !json.Valid([]byte(msg)) {
+		return action, fmt.Errorf("%w: %s", ErrInvalidJSON, msg)
+	}
+
+	action.Message = []byte(msg)
+	return action, nil
+}
+
+// StatusAction sets the status of the call either by its number or its name and an optional message
+// e.g. Status(5, "user not found") or Status("PERMISSION_DENIED")
+func StatusAction(args ...rules.Param) (action Action, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return action, err
+	}
+
+	var code codes.Code
+	if rawCode, intErr := args[0].AsInt(); intErr == nil {
+		if rawCode < int(codes.OK) || rawCode > int(codes.Unauthenticated) {
+			return action, fmt.Errorf("%w: %d", ErrInvalidStatusCode, rawCode)
+		}
+		code = codes.Code(rawCode)
+	} else if rawCode, strErr := args[0].AsString(); strErr != nil {
+		return action, strErr
+	} else if err = code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(rawCode)))); err != nil {
+		return action, fmt.Errorf("%w: %s", ErrInvalidStatusCode, rawCode)
+	}
+
+	var msg string
+	if len(args) > 1 {
+		if msg, err = args[1].AsString(); err != nil {
+			return action, err
+		}
+	}
+
+	action.Status = status.New(code, msg)
+	return action, nil
+}
+
+// HeaderAction adds a response header e.g. Header("x-server", "inetmock"),
+// headers are sent with the first message or the status of the call
+func HeaderAction(args ...rules.Param) (Action, error) {
+	md, err := metadataParams(args)
+	return Action{Header: md}, err
+}
+
+// TrailerAction adds a response trailer sent with the status of the call e.g. Trailer("x-request-cost", "42")
+func TrailerAction(args ...rules.Param) (Action, error) {
+	md, err := metadataParams(args)
+	return Action{Trailer: md}, err
+}
+
+// DelayAction waits before the next action is executed e.g. Delay("500ms")
+func DelayAction(args ...rules.Param) (action Action, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return action, err
+	}
+
+	var rawDelay string
+	if rawDelay, err = args[0].AsString(); err != nil {
+		return action, err
+	}
+
+	action.Delay, err = time.ParseDuration(rawDelay)
+	return action, err
+}
+
+func metadataParams(args []rules.Param) (md metadata.MD, err error) {
+	const expectedParams = 2
+	if err = rules.ValidateParameterCount(args, expectedParams); err != nil {
+		return nil, err
+	}
+
+	var key, value string
+	if key, err = args[0].AsString(); err != nil {
+		return nil, err
+	}
+
+	if value, err = args[1].AsString(); err != nil {
+		return nil, err
+	}
+
+	return metadata.Pairs(key, value), nil
+}
+
+func regexParam(param rules.Param) (*regexp.Regexp, error) {
+	rawExp, err := param.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return regexp.Compile(rawExp)
+}
+
+// fieldValues resolves the path by proto or JSON field names and formats the values of the last field as strings
+func fieldValues(msg protoreflect.Message, path []string) []string {
+	fields := msg.Descriptor().Fields()
+	field := fields.ByName(protoreflect.Name(path[0]))
+	if field == nil {
+		field = fields.ByJSONName(path[0])
+	}
+
+	if field == nil || field.IsMap() {
+		return nil
+	}
+
+	value := msg.Get(field)
+	if len(path) > 1 {
+		if field.Message() == nil || field.IsList() {
+			return nil
+		}
+		return fieldValues(value.Message(), path[1:])
+	}
+
+	if !field.IsList() {
+		return []string{formatValue(field, value)}
+	}
+
+	list := value.List()
+	values := make([]string, 0, list.Len())
+	for idx := 0; idx < list.Len(); idx++ {
+		values = append(values, formatValue(field, list.Get(idx)))
+	}
+	return values
+}
+
+func formatValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return strconv.Itoa(int(value.Enum()))
+	case protoreflect.BytesKind:
+		return string(value.Bytes())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		encoded, _ := protojson.Marshal(value.Message().Interface())
+		return string(encoded)
+	default:
+		return value.String()
+	}
+}
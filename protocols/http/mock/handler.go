@@ -13,10 +13,12 @@ import (
 	"golang.org/x/net/http2/h2c"
 
 	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
 	"inetmock.icb4dc0.de/inetmock/multiplexing"
 	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
 const (
@@ -25,10 +27,12 @@ const (
 )
 
 type httpHandler struct {
-	logger     logging.Logger
-	fakeFileFS fs.FS
-	server     *http.Server
-	emitter    audit.Emitter
+	logger      logging.Logger
+	fakeFileFS  fs.FS
+	server      *http.Server
+	router      *Router
+	emitter     audit.Emitter
+	wpadService *wpad.Service
 }
 
 func (p *httpHandler) Matchers() []cmux.Matcher {
@@ -53,21 +57,33 @@ func (p *httpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec
 		zap.String("address", startupSpec.Addr.String()),
 	)
 
-	router := &Router{
+	p.router = &Router{
 		HandlerName: startupSpec.Name,
 		Logger:      p.logger,
 		FakeFileFS:  p.fakeFileFS,
+		WebSockets:  make(map[string]WebSocketScript, len(options.WebSockets)),
+		Emitter:     p.emitter,
+	}
+
+	for scriptName, script := range options.WebSockets {
+		if p.router.WebSockets[scriptName], err = compileWebSocketScript(script); err != nil {
+			p.logger.Error("failed to setup WebSocket script", zap.String("script", scriptName), zap.Error(err))
+			return err
+		}
 	}
 
 	p.server = &http.Server{
-		Handler:           h2c.NewHandler(audit.EmittingHandler(p.emitter, auditv1.AppProtocol_APP_PROTOCOL_HTTP, router), new(http2.Server)),
+		Handler: h2c.NewHandler(
+			audit.EmittingHandler(p.emitter, auditv1.AppProtocol_APP_PROTOCOL_HTTP, p.wpadService.HTTPHandler(p.router)),
+			new(http2.Server),
+		),
 		ConnContext:       audit.StoreConnPropertiesInContext,
 		ReadHeaderTimeout: defaultReadHeaderTimeout,
 	}
 
 	for idx := range options.Rules {
 		rule := options.Rules[idx]
-		if err = router.RegisterRule(rule); err != nil {
+		if err = p.router.RegisterRule(rule); err != nil {
 			p.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
 			return err
 		}
@@ -77,6 +93,13 @@ func (p *httpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec
 	return nil
 }
 
+func (p *httpHandler) RuleManager() rules.Manager {
+	if p.router == nil {
+		return nil
+	}
+	return p.router.RuleManager()
+}
+
 func (p *httpHandler) startServer(listener net.Listener) {
 	if err := endpoint.IgnoreShutdownError(p.server.Serve(listener)); err != nil {
 		p.logger.Error("Failed to start HTTP listener", zap.Error(err))
@@ -154,7 +154,7 @@ func Test_httpHandler_Start(t *testing.T) {
 					})
 				})
 			}
-			handler := mock.New(logger, emitterMock, tt.fields.fakeFileFS)
+			handler := mock.New(logger, emitterMock, tt.fields.fakeFileFS, nil)
 			if err := handler.Start(ctx, lifecycle); err != nil {
 				if !tt.wantErr {
 					t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
@@ -1,14 +1,45 @@
 package mock
 
 import (
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
 	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
 )
 
+const defaultWebSocketIdleTimeout = 1 * time.Minute
+
+type webSocketOptions struct {
+	// OnOpen contains the actions executed right after the connection was upgraded e.g. Text("hello")
+	OnOpen []string
+	// Rules are evaluated for every received text or binary message
+	Rules []string
+	// IdleTimeout closes the connection if the client did not send anything for the given time
+	IdleTimeout time.Duration
+}
+
 type httpOptions struct {
 	Rules []string
+	// WebSockets contains the scripts referenced by WebSocket(name) terminators
+	WebSockets map[string]webSocketOptions
 }
 
 func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts httpOptions, err error) {
-	err = startupSpec.UnmarshalOptions(&opts)
-	return
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc())); err != nil {
+		return opts, err
+	}
+
+	// script names are case-insensitive because the config keys are lower case anyway
+	scripts := make(map[string]webSocketOptions, len(opts.WebSockets))
+	for name, script := range opts.WebSockets {
+		if script.IdleTimeout == 0 {
+			script.IdleTimeout = defaultWebSocketIdleTimeout
+		}
+		scripts[strings.ToLower(name)] = script
+	}
+	opts.WebSockets = scripts
+
+	return opts, nil
 }
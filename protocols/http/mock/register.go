@@ -6,18 +6,27 @@ import (
 	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
 	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
 )
 
-func New(logger logging.Logger, emitter audit.Emitter, fakeFileFS fs.FS) endpoint.ProtocolHandler {
+// New creates a http_mock handler, if wpadService is not nil the PAC file is served before the rules are evaluated
+func New(logger logging.Logger, emitter audit.Emitter, fakeFileFS fs.FS, wpadService *wpad.Service) endpoint.ProtocolHandler {
 	return &httpHandler{
-		logger:     logger,
-		fakeFileFS: fakeFileFS,
-		emitter:    emitter,
+		logger:      logger,
+		fakeFileFS:  fakeFileFS,
+		emitter:     emitter,
+		wpadService: wpadService,
 	}
 }
 
-func AddHTTPMock(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter, fakeFileFS fs.FS) {
+func AddHTTPMock(
+	registry endpoint.HandlerRegistry,
+	logger logging.Logger,
+	emitter audit.Emitter,
+	fakeFileFS fs.FS,
+	wpadService *wpad.Service,
+) {
 	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
-		return New(logger, emitter, fakeFileFS)
+		return New(logger, emitter, fakeFileFS, wpadService)
 	})
 }
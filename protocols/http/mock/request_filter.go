@@ -1,22 +1,49 @@
 package mock
 
 import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
+	"path"
 	"regexp"
 	"strings"
 
 	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 )
 
 var knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
 	"method":      HTTPMethodMatcher,
 	"pathpattern": PathPatternMatcher,
 	"header":      HeaderValueMatcher,
+	"ja3":         JA3Matcher,
+	"ja4":         JA4Matcher,
+	"clientcert":  ClientCertMatcher,
 }
 
 const (
 	expectedHeaderValueParamCount = 2
+	expectedClientCertParamCount  = 2
+)
+
+var (
+	ErrUnknownClientCertField = errors.New("unknown client certificate field")
+
+	clientCertFields = map[string]func(cert *x509.Certificate) []string{
+		"subject": func(cert *x509.Certificate) []string { return []string{cert.Subject.String()} },
+		"cn":      func(cert *x509.Certificate) []string { return []string{cert.Subject.CommonName} },
+		"issuer":  func(cert *x509.Certificate) []string { return []string{cert.Issuer.String()} },
+		"serial":  func(cert *x509.Certificate) []string { return []string{cert.SerialNumber.String()} },
+		"fingerprint": func(cert *x509.Certificate) []string {
+			fingerprint := sha256.Sum256(cert.Raw)
+			return []string{hex.EncodeToString(fingerprint[:])}
+		},
+		"dns":   func(cert *x509.Certificate) []string { return cert.DNSNames },
+		"email": func(cert *x509.Certificate) []string { return cert.EmailAddresses },
+	}
 )
 
 type RequestFilterFunc func(req *http.Request) bool
@@ -117,3 +144,117 @@ func HeaderValueMatcher(args ...rules.Param) (RequestFilter, error) {
 		return false
 	}), nil
 }
+
+// JA3Matcher matches requests sent via TLS whose client fingerprint equals the given JA3 string or its MD5 hash
+func JA3Matcher(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var (
+		err      error
+		expected string
+	)
+
+	if expected, err = args[0].AsString(); err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req *http.Request) bool {
+		fingerprint := audit.TLSFingerprintFromContext(req.Context())
+		if fingerprint == nil {
+			return false
+		}
+		return strings.EqualFold(expected, fingerprint.JA3Hash) || expected == fingerprint.JA3
+	}), nil
+}
+
+// JA4Matcher matches requests sent via TLS whose JA4 client fingerprint matches the given path.Match pattern
+// e.g. t13d*h2_* for TLS 1.3 clients with SNI preferring HTTP/2
+func JA4Matcher(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var (
+		err     error
+		pattern string
+	)
+
+	if pattern, err = args[0].AsString(); err != nil {
+		return nil, err
+	}
+
+	if _, err = path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req *http.Request) bool {
+		fingerprint := audit.TLSFingerprintFromContext(req.Context())
+		if fingerprint == nil {
+			return false
+		}
+		matched, _ := path.Match(pattern, fingerprint.JA4)
+		return matched
+	}), nil
+}
+
+// ClientCertMatcher matches requests whose client certificate has a field matching the given regular expression.
+// Supported fields are subject, cn, issuer, serial, fingerprint (hex encoded SHA-256), dns and email,
+// only the leaf certificate is taken into account.
+func ClientCertMatcher(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, expectedClientCertParamCount); err != nil {
+		return nil, err
+	}
+
+	var (
+		err                   error
+		fieldName, rawPattern string
+	)
+
+	if fieldName, err = args[0].AsString(); err != nil {
+		return nil, err
+	}
+	if rawPattern, err = args[1].AsString(); err != nil {
+		return nil, err
+	}
+
+	field, known := clientCertFields[strings.ToLower(fieldName)]
+	if !known {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownClientCertField, fieldName)
+	}
+
+	pattern, err := regexp.Compile(rawPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req *http.Request) bool {
+		cert := clientCertificate(req)
+		if cert == nil {
+			return false
+		}
+
+		for _, value := range field(cert) {
+			if pattern.MatchString(value) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func clientCertificate(req *http.Request) *x509.Certificate {
+	state := req.TLS
+	if state == nil {
+		if s, ok := audit.TLSConnectionState(req.Context()); ok {
+			state = &s
+		}
+	}
+
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	return state.PeerCertificates[0]
+}
@@ -1,6 +1,11 @@
 package mock_test
 
 import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
 	"net/http"
 	"testing"
 
@@ -8,6 +13,8 @@ import (
 	"github.com/maxatome/go-testdeep/td"
 
 	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	"inetmock.icb4dc0.de/inetmock/protocols/http/mock"
 )
 
@@ -270,3 +277,237 @@ func TestHeaderValueMatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestJA3Matcher(t *testing.T) {
+	t.Parallel()
+	fingerprint := &audit.TLSFingerprint{
+		JA3:     "771,4865-4866,0-10-11-13-16-43,29-23,0",
+		JA3Hash: "8b85ec5fe3da506907f3cac65cd06803",
+	}
+	tests := []struct {
+		name        string
+		args        []rules.Param
+		fingerprint *audit.TLSFingerprint
+		wantMatch   bool
+		wantErr     bool
+	}{
+		{
+			name:        "Match JA3 hash ignoring case",
+			args:        []rules.Param{{String: rules.StringP("8B85EC5FE3DA506907F3CAC65CD06803")}},
+			fingerprint: fingerprint,
+			wantMatch:   true,
+		},
+		{
+			name:        "Match JA3 string",
+			args:        []rules.Param{{String: rules.StringP("771,4865-4866,0-10-11-13-16-43,29-23,0")}},
+			fingerprint: fingerprint,
+			wantMatch:   true,
+		},
+		{
+			name:        "Do not match other JA3 hash",
+			args:        []rules.Param{{String: rules.StringP("e7d705a3286e19ea42f587b344ee6865")}},
+			fingerprint: fingerprint,
+			wantMatch:   false,
+		},
+		{
+			name:      "Do not match plain text request",
+			args:      []rules.Param{{String: rules.StringP("8b85ec5fe3da506907f3cac65cd06803")}},
+			wantMatch: false,
+		},
+		{
+			name:    "Expect error due to argument type mismatch",
+			args:    []rules.Param{{Int: rules.IntP(42)}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := mock.JA3Matcher(tt.args...)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("JA3Matcher() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			td.Cmp(t, got.Matches(newFingerprintedRequest(t, tt.fingerprint)), tt.wantMatch)
+		})
+	}
+}
+
+func TestJA4Matcher(t *testing.T) {
+	t.Parallel()
+	fingerprint := &audit.TLSFingerprint{
+		JA4: "t13d1516h2_8daaf6152771_e5627efa2ab1",
+	}
+	tests := []struct {
+		name        string
+		args        []rules.Param
+		fingerprint *audit.TLSFingerprint
+		wantMatch   bool
+		wantErr     bool
+	}{
+		{
+			name:        "Match exact JA4",
+			args:        []rules.Param{{String: rules.StringP("t13d1516h2_8daaf6152771_e5627efa2ab1")}},
+			fingerprint: fingerprint,
+			wantMatch:   true,
+		},
+		{
+			name:        "Match JA4 pattern",
+			args:        []rules.Param{{String: rules.StringP("t13d*h2_*")}},
+			fingerprint: fingerprint,
+			wantMatch:   true,
+		},
+		{
+			name:        "Do not match other JA4 pattern",
+			args:        []rules.Param{{String: rules.StringP("t12?????h1_*")}},
+			fingerprint: fingerprint,
+			wantMatch:   false,
+		},
+		{
+			name:      "Do not match plain text request",
+			args:      []rules.Param{{String: rules.StringP("*")}},
+			wantMatch: false,
+		},
+		{
+			name:    "Expect error due to malformed pattern",
+			args:    []rules.Param{{String: rules.StringP("t13[d")}},
+			wantErr: true,
+		},
+		{
+			name:    "Expect error due to missing argument",
+			args:    []rules.Param{},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := mock.JA4Matcher(tt.args...)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("JA4Matcher() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			td.Cmp(t, got.Matches(newFingerprintedRequest(t, tt.fingerprint)), tt.wantMatch)
+		})
+	}
+}
+
+func TestClientCertMatcher(t *testing.T) {
+	t.Parallel()
+	clientCert := test.NewClientCA(t).ClientCert(t, "device-42")
+	leaf, err := x509.ParseCertificate(clientCert.Certificate[0])
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() error = %v", err)
+	}
+	fingerprint := sha256.Sum256(leaf.Raw)
+
+	tests := []struct {
+		name      string
+		args      []rules.Param
+		state     *tls.ConnectionState
+		wantMatch bool
+		wantErr   bool
+	}{
+		{
+			name:      "Match common name",
+			args:      []rules.Param{{String: rules.StringP("CN")}, {String: rules.StringP("^device-\\d+$")}},
+			state:     &tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}},
+			wantMatch: true,
+		},
+		{
+			name:      "Match issuer",
+			args:      []rules.Param{{String: rules.StringP("issuer")}, {String: rules.StringP("CN=INetMock Test Client CA")}},
+			state:     &tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}},
+			wantMatch: true,
+		},
+		{
+			name:      "Match fingerprint",
+			args:      []rules.Param{{String: rules.StringP("fingerprint")}, {String: rules.StringP(hex.EncodeToString(fingerprint[:]))}},
+			state:     &tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}},
+			wantMatch: true,
+		},
+		{
+			name:      "Do not match other subject",
+			args:      []rules.Param{{String: rules.StringP("subject")}, {String: rules.StringP("CN=admin")}},
+			state:     &tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}},
+			wantMatch: false,
+		},
+		{
+			name:      "Do not match TLS request without client certificate",
+			args:      []rules.Param{{String: rules.StringP("cn")}, {String: rules.StringP(".*")}},
+			state:     &tls.ConnectionState{},
+			wantMatch: false,
+		},
+		{
+			name:      "Do not match plain text request",
+			args:      []rules.Param{{String: rules.StringP("cn")}, {String: rules.StringP(".*")}},
+			wantMatch: false,
+		},
+		{
+			name:    "Expect error due to unknown field",
+			args:    []rules.Param{{String: rules.StringP("organization")}, {String: rules.StringP(".*")}},
+			wantErr: true,
+		},
+		{
+			name:    "Expect error due to invalid regex",
+			args:    []rules.Param{{String: rules.StringP("cn")}, {String: rules.StringP("device-(")}},
+			wantErr: true,
+		},
+		{
+			name:    "Expect error due to missing argument",
+			args:    []rules.Param{{String: rules.StringP("cn")}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := mock.ClientCertMatcher(tt.args...)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("ClientCertMatcher() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			req := tdhttp.NewRequest(http.MethodGet, "https://www.example.com/", nil)
+			req.TLS = tt.state
+			td.Cmp(t, got.Matches(req), tt.wantMatch)
+		})
+	}
+}
+
+type fingerprintedConn struct {
+	net.Conn
+	fingerprint *audit.TLSFingerprint
+}
+
+func (c fingerprintedConn) TLSFingerprint() *audit.TLSFingerprint {
+	return c.fingerprint
+}
+
+// newFingerprintedRequest returns a request whose context contains the connection properties of a TLS client
+// with the given fingerprint or those of a plain text connection if fingerprint is nil
+func newFingerprintedRequest(tb testing.TB, fingerprint *audit.TLSFingerprint) *http.Request {
+	tb.Helper()
+	client, server := net.Pipe()
+	tb.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+
+	var conn net.Conn = server
+	if fingerprint != nil {
+		conn = tls.Server(fingerprintedConn{Conn: server, fingerprint: fingerprint}, new(tls.Config))
+	}
+
+	req := tdhttp.NewRequest(http.MethodGet, "https://www.example.com/", nil)
+	return req.WithContext(audit.StoreConnPropertiesInContext(req.Context(), conn))
+}
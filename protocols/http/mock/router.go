@@ -2,12 +2,15 @@ package mock
 
 import (
 	"io/fs"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
 	"inetmock.icb4dc0.de/inetmock/pkg/logging"
 	"inetmock.icb4dc0.de/inetmock/protocols"
 )
@@ -38,46 +41,78 @@ type Router struct {
 	HandlerName string
 	Logger      logging.Logger
 	FakeFileFS  fs.FS
-	handlers    []ConditionalHandler
+	// WebSockets contains the scripts referenced by WebSocket(name) terminators by their lower case name
+	WebSockets map[string]WebSocketScript
+	// Emitter records the frames of upgraded WebSocket connections
+	Emitter  audit.Emitter
+	handlers rules.Set[ConditionalHandler]
 }
 
 func (r *Router) RegisterRule(rawRule string) error {
 	r.Logger.Debug("Adding routing rule", zap.String("rawRule", rawRule))
 
-	var (
-		rule *rules.SingleResponsePipeline
-		err  error
-	)
-
-	if rule, err = rules.Parse[rules.SingleResponsePipeline](rawRule); err != nil {
+	conditionalHandler, err := r.compileRule(rawRule)
+	if err != nil {
 		return err
 	}
 
-	var conditionalHandler ConditionalHandler
+	r.Logger.Debug("Configure successfully parsed routing rule")
+	r.handlers.Append(rawRule, conditionalHandler)
+
+	return nil
+}
+
+// RuleManager allows to modify the rules of the router while it is serving requests.
+func (r *Router) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalHandler]{
+		Set:     &r.handlers,
+		Compile: r.compileRule,
+	}
+}
+
+func (r *Router) compileRule(rawRule string) (conditionalHandler ConditionalHandler, err error) {
+	var rule *rules.SingleResponsePipeline
+
+	if rule, err = rules.Parse[rules.SingleResponsePipeline](rawRule); err != nil {
+		return conditionalHandler, err
+	}
 
 	if conditionalHandler.Chain, err = RequestFiltersForRoutingRule(rule); err != nil {
-		return err
+		return conditionalHandler, err
 	}
 
-	if conditionalHandler.Handler, err = HandlerForRoutingRule(rule, r.Logger, r.FakeFileFS); err != nil {
-		return err
+	// WebSocket terminators depend on the scripts of the router hence they're not part of the known response handlers
+	if rule.Response != nil && strings.EqualFold(rule.Response.Name, webSocketTerminator) {
+		conditionalHandler.Handler, err = r.webSocketHandler(rule.Response.Params...)
+	} else {
+		conditionalHandler.Handler, err = HandlerForRoutingRule(rule, r.Logger, r.FakeFileFS)
 	}
 
-	r.Logger.Debug("Configure successfully parsed routing rule")
-	r.handlers = append(r.handlers, conditionalHandler)
+	if err != nil {
+		return conditionalHandler, err
+	}
 
-	return nil
+	return conditionalHandler, nil
 }
 
 func (r *Router) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	defer prometheus.NewTimer(protocols.RequestDurationHistogram.WithLabelValues("http", r.HandlerName)).ObserveDuration()
 
-	for idx := range r.handlers {
-		if r.handlers[idx].Chain.Matches(request) {
-			r.handlers[idx].ServeHTTP(writer, request)
+	handlers := r.handlers.Entries()
+	for idx := range handlers {
+		if handler := handlers[idx]; handler.Value.Chain.Matches(request) {
+			protocols.RecordRuleMatch(handler, name, r.HandlerName, clientHost(request.RemoteAddr))
+			handler.Value.ServeHTTP(writer, request)
 			return
 		}
 	}
 
 	writer.WriteHeader(http.StatusNotFound)
 }
+
+func clientHost(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
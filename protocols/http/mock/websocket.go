@@ -0,0 +1,443 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const (
+	webSocketTerminator     = "websocket"
+	maxWebSocketMessageSize = 1024 * 1024
+	webSocketWriteTimeout   = 10 * time.Second
+)
+
+var (
+	ErrUnknownWebSocketScript = errors.New("unknown WebSocket script")
+
+	knownWebSocketFilters = map[string]func(args ...rules.Param) (WebSocketFilter, error){
+		"regex":         WebSocketRegexFilter,
+		"hex":           WebSocketHexFilter,
+		"textmessage":   webSocketTypeFilter(websocket.TextMessage),
+		"binarymessage": webSocketTypeFilter(websocket.BinaryMessage),
+	}
+	knownWebSocketActions = map[string]func(args ...rules.Param) (WebSocketAction, error){
+		"text":   WebSocketTextAction,
+		"binary": WebSocketBinaryAction,
+		"file":   WebSocketFileAction,
+		"close":  WebSocketCloseAction,
+	}
+
+	webSocketUpgrader = websocket.Upgrader{
+		// clients of a mock are not restricted to any origin
+		CheckOrigin: func(*http.Request) bool { return true },
+	}
+)
+
+type (
+	// WebSocketMessage is a text or binary message received from the client
+	WebSocketMessage struct {
+		Type int
+		Data []byte
+	}
+	WebSocketFilter interface {
+		Matches(msg WebSocketMessage) bool
+	}
+	WebSocketFilterFunc func(msg WebSocketMessage) bool
+
+	// WebSocketAction is a single step of a scripted response, it either sends a message, a fake file or closes the connection
+	WebSocketAction struct {
+		Type      int
+		Data      []byte
+		File      string
+		Close     bool
+		CloseCode int
+	}
+
+	webSocketRule struct {
+		filters []WebSocketFilter
+		actions []WebSocketAction
+	}
+
+	// WebSocketScript is the compiled message exchange executed by a WebSocket(name) terminator
+	WebSocketScript struct {
+		onOpen      []WebSocketAction
+		rules       []webSocketRule
+		idleTimeout time.Duration
+	}
+)
+
+func (f WebSocketFilterFunc) Matches(msg WebSocketMessage) bool {
+	return f(msg)
+}
+
+func compileWebSocketScript(opts webSocketOptions) (script WebSocketScript, err error) {
+	script.idleTimeout = opts.IdleTimeout
+
+	for _, rawAction := range opts.OnOpen {
+		// the actions are parsed as rule without filters
+		var rule webSocketRule
+		if rule, err = compileWebSocketRule("=> " + rawAction); err != nil {
+			return script, err
+		}
+		script.onOpen = append(script.onOpen, rule.actions...)
+	}
+
+	script.rules = make([]webSocketRule, 0, len(opts.Rules))
+	for _, rawRule := range opts.Rules {
+		var rule webSocketRule
+		if rule, err = compileWebSocketRule(rawRule); err != nil {
+			return script, err
+		}
+		script.rules = append(script.rules, rule)
+	}
+
+	return script, nil
+}
+
+func compileWebSocketRule(rawRule string) (compiled webSocketRule, err error) {
+	var rule *rules.ChainedResponsePipeline
+	if rule, err = rules.Parse[rules.ChainedResponsePipeline](rawRule); err != nil {
+		return compiled, err
+	}
+
+	for _, filter := range rule.Filters() {
+		constructor, ok := knownWebSocketFilters[strings.ToLower(filter.Name)]
+		if !ok {
+			return compiled, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, filter.Name)
+		}
+
+		var f WebSocketFilter
+		if f, err = constructor(filter.Params...); err != nil {
+			return compiled, err
+		}
+		compiled.filters = append(compiled.filters, f)
+	}
+
+	if len(rule.Response) == 0 {
+		return compiled, rules.ErrNoTerminatorDefined
+	}
+
+	for idx := range rule.Response {
+		constructor, ok := knownWebSocketActions[strings.ToLower(rule.Response[idx].Name)]
+		if !ok {
+			return compiled, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response[idx].Name)
+		}
+
+		var action WebSocketAction
+		if action, err = constructor(rule.Response[idx].Params...); err != nil {
+			return compiled, err
+		}
+		compiled.actions = append(compiled.actions, action)
+	}
+
+	return compiled, nil
+}
+
+// evaluate returns the actions of the first rule matching the given message
+func (s WebSocketScript) evaluate(msg WebSocketMessage) ([]WebSocketAction, bool) {
+	for _, rule := range s.rules {
+		if rule.matches(msg) {
+			return rule.actions, true
+		}
+	}
+	return nil, false
+}
+
+func (r webSocketRule) matches(msg WebSocketMessage) bool {
+	for _, f := range r.filters {
+		if !f.Matches(msg) {
+			return false
+		}
+	}
+	return true
+}
+
+// WebSocketRegexFilter matches the payload of a message against a regular expression e.g. Regex(`^ping$`)
+func WebSocketRegexFilter(args ...rules.Param) (WebSocketFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var exp *regexp.Regexp
+	if rawExp, err := args[0].AsString(); err != nil {
+		return nil, err
+	} else if exp, err = regexp.Compile(rawExp); err != nil {
+		return nil, err
+	}
+
+	return WebSocketFilterFunc(func(msg WebSocketMessage) bool {
+		return exp.Match(msg.Data)
+	}), nil
+}
+
+// WebSocketHexFilter matches if the payload of a message starts with the given hex encoded bytes e.g. Hex("de ad")
+func WebSocketHexFilter(args ...rules.Param) (WebSocketFilter, error) {
+	prefix, err := hexParam(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return WebSocketFilterFunc(func(msg WebSocketMessage) bool {
+		return bytes.HasPrefix(msg.Data, prefix)
+	}), nil
+}
+
+// webSocketTypeFilter matches messages of the given type e.g. TextMessage() or BinaryMessage()
+func webSocketTypeFilter(messageType int) func(args ...rules.Param) (WebSocketFilter, error) {
+	return func(...rules.Param) (WebSocketFilter, error) {
+		return WebSocketFilterFunc(func(msg WebSocketMessage) bool {
+			return msg.Type == messageType
+		}), nil
+	}
+}
+
+// WebSocketTextAction sends a text message e.g. Text("pong")
+func WebSocketTextAction(args ...rules.Param) (action WebSocketAction, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return action, err
+	}
+
+	var data string
+	if data, err = args[0].AsString(); err != nil {
+		return action, err
+	}
+
+	return WebSocketAction{Type: websocket.TextMessage, Data: []byte(data)}, nil
+}
+
+// WebSocketBinaryAction sends a binary message of the given hex encoded bytes e.g. Binary("de ad be ef")
+func WebSocketBinaryAction(args ...rules.Param) (action WebSocketAction, err error) {
+	action.Type = websocket.BinaryMessage
+	action.Data, err = hexParam(args)
+	return action, err
+}
+
+// WebSocketFileAction sends the content of the given file of the fake files directory as binary message
+// e.g. File("sample.exe")
+func WebSocketFileAction(args ...rules.Param) (action WebSocketAction, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return action, err
+	}
+
+	action.Type = websocket.BinaryMessage
+	action.File, err = args[0].AsString()
+	return action, err
+}
+
+// WebSocketCloseAction sends a close frame and closes the connection, the status code is optional e.g. Close(1001)
+func WebSocketCloseAction(args ...rules.Param) (action WebSocketAction, err error) {
+	action = WebSocketAction{Close: true, CloseCode: websocket.CloseNormalClosure}
+	if len(args) > 0 {
+		action.CloseCode, err = args[0].AsInt()
+	}
+	return action, err
+}
+
+func hexParam(args []rules.Param) ([]byte, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	rawHex, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(strings.NewReplacer(" ", "", ":", "").Replace(rawHex))
+}
+
+func (r *Router) webSocketHandler(args ...rules.Param) (http.Handler, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	scriptName, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	script, ok := r.WebSockets[strings.ToLower(scriptName)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownWebSocketScript, scriptName)
+	}
+
+	return webSocketHandler{
+		script:     script,
+		logger:     r.Logger.With(zap.String("handler_type", "WebSocketHandler"), zap.String("script", scriptName)),
+		fakeFileFS: r.FakeFileFS,
+		emitter:    r.Emitter,
+	}, nil
+}
+
+type webSocketHandler struct {
+	script     WebSocketScript
+	logger     logging.Logger
+	fakeFileFS fs.FS
+	emitter    audit.Emitter
+}
+
+func (h webSocketHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	conn, err := webSocketUpgrader.Upgrade(writer, request, nil)
+	if err != nil {
+		// the upgrader already replied with an error status
+		h.logger.Debug("Failed to upgrade connection", zap.Error(err))
+		return
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	session := &webSocketSession{handler: h, conn: conn, request: request}
+	if err = session.run(); err != nil {
+		h.logger.Warn("WebSocket session failed", zap.Error(err))
+	}
+}
+
+type webSocketSession struct {
+	handler webSocketHandler
+	conn    *websocket.Conn
+	request *http.Request
+}
+
+func (s *webSocketSession) run() error {
+	s.conn.SetReadLimit(maxWebSocketMessageSize)
+	s.conn.SetPingHandler(s.handlePing)
+	s.conn.SetPongHandler(func(data string) error {
+		s.record(auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_INBOUND, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_PONG, len(data))
+		return nil
+	})
+	s.conn.SetCloseHandler(s.handleClose)
+
+	if closeConn, err := s.execute(s.handler.script.onOpen); closeConn || err != nil {
+		return err
+	}
+
+	for {
+		_ = s.conn.SetReadDeadline(time.Now().Add(s.handler.script.idleTimeout))
+		messageType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) || errors.Is(err, os.ErrDeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+
+		s.record(auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_INBOUND, webSocketOpcode(messageType), len(data))
+
+		actions, matched := s.handler.script.evaluate(WebSocketMessage{Type: messageType, Data: data})
+		if !matched {
+			continue
+		}
+
+		if closeConn, err := s.execute(actions); closeConn || err != nil {
+			return err
+		}
+	}
+}
+
+// execute sends the messages of the given actions, it returns true if the connection should be closed.
+// Frames are recorded before they're sent hence the events are complete as soon as the client received the frame.
+func (s *webSocketSession) execute(actions []WebSocketAction) (closeConn bool, err error) {
+	for _, action := range actions {
+		if action.Close {
+			msg := websocket.FormatCloseMessage(action.CloseCode, "")
+			s.record(auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_OUTBOUND, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_CLOSE, len(msg))
+			return true, s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(webSocketWriteTimeout))
+		}
+
+		data := action.Data
+		if action.File != "" {
+			if data, err = fs.ReadFile(s.handler.fakeFileFS, action.File); err != nil {
+				s.handler.logger.Error("Failed to read file to send", zap.String("file", action.File), zap.Error(err))
+				continue
+			}
+		}
+
+		s.record(auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_OUTBOUND, webSocketOpcode(action.Type), len(data))
+		_ = s.conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+		if err = s.conn.WriteMessage(action.Type, data); err != nil {
+			return true, err
+		}
+	}
+
+	return false, nil
+}
+
+func (s *webSocketSession) handlePing(data string) error {
+	s.record(auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_INBOUND, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_PING, len(data))
+
+	s.record(auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_OUTBOUND, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_PONG, len(data))
+	return s.conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(webSocketWriteTimeout))
+}
+
+// handleClose records the close frame of the client and echoes its status code like the default close handler does
+func (s *webSocketSession) handleClose(code int, text string) error {
+	var msg []byte
+	if code != websocket.CloseNoStatusReceived {
+		msg = websocket.FormatCloseMessage(code, "")
+	}
+
+	inboundSize := 0
+	if msg != nil {
+		inboundSize = len(websocket.FormatCloseMessage(code, text))
+	}
+	s.record(auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_INBOUND, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_CLOSE, inboundSize)
+
+	s.record(auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_OUTBOUND, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_CLOSE, len(msg))
+	_ = s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(webSocketWriteTimeout))
+
+	return nil
+}
+
+func (s *webSocketSession) record(direction auditv1.WebSocketDirection, opcode auditv1.WebSocketOpcode, payloadSize int) {
+	ctx := s.request.Context()
+	builder := s.handler.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_WEBSOCKET).
+		WithProtocolDetails(audit.WebSocket{
+			Direction:   direction,
+			Opcode:      opcode,
+			PayloadSize: int64(payloadSize),
+			URI:         s.request.RequestURI,
+		})
+
+	if details, ok := audit.NewTLSDetailsFromContext(ctx); ok {
+		builder = builder.WithTLSDetails(details)
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(audit.RemoteAddr(ctx))
+	builder, _ = builder.WithDestinationFromAddr(audit.LocalAddr(ctx))
+
+	builder.Emit()
+}
+
+func webSocketOpcode(messageType int) auditv1.WebSocketOpcode {
+	switch messageType {
+	case websocket.TextMessage:
+		return auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT
+	case websocket.BinaryMessage:
+		return auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_BINARY
+	default:
+		return auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_UNSPECIFIED
+	}
+}
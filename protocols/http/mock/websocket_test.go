@@ -0,0 +1,292 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/http/mock"
+)
+
+const (
+	inbound  = auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_INBOUND
+	outbound = auditv1.WebSocketDirection_WEB_SOCKET_DIRECTION_OUTBOUND
+)
+
+type webSocketStep struct {
+	send        bool
+	messageType int
+	data        string
+	// closeCode is sent respectively expected as close frame if not 0
+	closeCode int
+}
+
+func Test_httpHandler_WebSocket(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		script     map[string]any
+		steps      []webSocketStep
+		wantEvents []any
+	}{
+		{
+			name: "Scripted exchange closed by server",
+			script: map[string]any{
+				"onOpen": []string{`Text("hello")`},
+				"rules": []string{
+					`Regex("^ping$") => Text("pong")`,
+					`BinaryMessage() -> Hex("de ad") => Binary("be ef")`,
+					`Regex("^download$") => File("default.html")`,
+					`Regex("^bye$") => Text("bye") => Close(1001)`,
+				},
+			},
+			steps: []webSocketStep{
+				{messageType: websocket.TextMessage, data: "hello"},
+				{send: true, messageType: websocket.TextMessage, data: "ping"},
+				{messageType: websocket.TextMessage, data: "pong"},
+				{send: true, messageType: websocket.BinaryMessage, data: "\xde\xad"},
+				{messageType: websocket.BinaryMessage, data: "\xbe\xef"},
+				{send: true, messageType: websocket.TextMessage, data: "unknown"},
+				{send: true, messageType: websocket.TextMessage, data: "download"},
+				{messageType: websocket.BinaryMessage, data: defaultHTMLContent},
+				{send: true, messageType: websocket.TextMessage, data: "bye"},
+				{messageType: websocket.TextMessage, data: "bye"},
+				{closeCode: websocket.CloseGoingAway},
+			},
+			wantEvents: []any{
+				webSocketEvent(outbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT, 5),
+				webSocketEvent(inbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT, 4),
+				webSocketEvent(outbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT, 4),
+				webSocketEvent(inbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_BINARY, 2),
+				webSocketEvent(outbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_BINARY, 2),
+				webSocketEvent(inbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT, 7),
+				webSocketEvent(inbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT, 8),
+				webSocketEvent(outbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_BINARY, int64(len(defaultHTMLContent))),
+				webSocketEvent(inbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT, 3),
+				webSocketEvent(outbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT, 3),
+				webSocketEvent(outbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_CLOSE, 2),
+			},
+		},
+		{
+			name: "Text filter ignores binary messages",
+			script: map[string]any{
+				"rules": []string{
+					`TextMessage() -> Regex("^ping$") => Text("pong")`,
+				},
+			},
+			steps: []webSocketStep{
+				{send: true, messageType: websocket.BinaryMessage, data: "ping"},
+				{send: true, messageType: websocket.TextMessage, data: "ping"},
+				{messageType: websocket.TextMessage, data: "pong"},
+				{send: true, closeCode: websocket.CloseNormalClosure},
+				{closeCode: websocket.CloseNormalClosure},
+			},
+			wantEvents: []any{
+				webSocketEvent(inbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_BINARY, 4),
+				webSocketEvent(inbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT, 4),
+				webSocketEvent(outbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_TEXT, 4),
+				webSocketEvent(inbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_CLOSE, 2),
+				webSocketEvent(outbound, auditv1.WebSocketOpcode_WEB_SOCKET_OPCODE_CLOSE, 2),
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			listener, emitterMock := startWebSocketHandler(t, tt.script)
+
+			conn := dialWebSocket(t, listener)
+			for _, step := range tt.steps {
+				if step.send {
+					sendWebSocketStep(t, conn, step)
+				} else {
+					expectWebSocketStep(t, conn, step)
+				}
+			}
+
+			var events []any
+			emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+				for _, call := range calls.Emit() {
+					if call.Params.Ev.Application == auditv1.AppProtocol_APP_PROTOCOL_WEBSOCKET {
+						events = append(events, call.Params.Ev.ProtocolDetails)
+					}
+				}
+			})
+
+			td.Cmp(t, events, tt.wantEvents)
+		})
+	}
+}
+
+func Test_httpHandler_WebSocket_NoUpgrade(t *testing.T) {
+	t.Parallel()
+	listener, _ := startWebSocketHandler(t, map[string]any{
+		"rules": []string{`Regex("^ping$") => Text("pong")`},
+	})
+
+	resp, err := test.HTTPClientForInMemListener(listener).Get("http://inetmock.icb4dc0.de/ws")
+	if !td.CmpNoError(t, err) {
+		return
+	}
+	_ = resp.Body.Close()
+
+	td.Cmp(t, resp.StatusCode, http.StatusBadRequest)
+}
+
+func Test_httpHandler_WebSocket_InvalidScript(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		opts    map[string]any
+		wantErr error
+	}{
+		{
+			name: "Unknown script",
+			opts: map[string]any{
+				"rules": []string{`PathPattern("^/ws$") => WebSocket("unknown")`},
+			},
+			wantErr: mock.ErrUnknownWebSocketScript,
+		},
+		{
+			name: "Missing parameter",
+			opts: map[string]any{
+				"rules": []string{`PathPattern("^/ws$") => WebSocket()`},
+			},
+		},
+		{
+			name: "Unknown action",
+			opts: map[string]any{
+				"rules": []string{`PathPattern("^/ws$") => WebSocket("c2")`},
+				"webSockets": map[string]any{
+					"c2": map[string]any{
+						"rules": []string{`Regex(".*") => Reply("pong")`},
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid hex",
+			opts: map[string]any{
+				"rules": []string{`PathPattern("^/ws$") => WebSocket("c2")`},
+				"webSockets": map[string]any{
+					"c2": map[string]any{
+						"onOpen": []string{`Binary("xyz")`},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			listener := test.NewInMemoryListener(t)
+			handler := mock.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock), defaultFakeFileFS, nil)
+
+			err := handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.opts))
+			td.CmpError(t, err)
+			if tt.wantErr != nil {
+				td.Cmp(t, errors.Is(err, tt.wantErr), true)
+			}
+		})
+	}
+}
+
+func startWebSocketHandler(tb testing.TB, script map[string]any) (test.InMemListener, *audit_mock.EmitterMock) {
+	tb.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+
+	listener := test.NewInMemoryListener(tb)
+	emitterMock := new(audit_mock.EmitterMock)
+	opts := map[string]any{
+		"rules": []string{`PathPattern("^/ws$") => WebSocket("c2")`},
+		"webSockets": map[string]any{
+			"C2": script,
+		},
+	}
+
+	handler := mock.New(logging.CreateTestLogger(tb), emitterMock, defaultFakeFileFS, nil)
+	if err := handler.Start(ctx, endpoint.NewStartupSpec("websocket", endpoint.NewUplink(listener), opts)); err != nil {
+		tb.Fatalf("Start() error = %v", err)
+	}
+
+	return listener, emitterMock
+}
+
+func dialWebSocket(tb testing.TB, listener test.InMemListener) *websocket.Conn {
+	tb.Helper()
+	dialer := websocket.Dialer{
+		NetDialContext:   listener.DialContext,
+		HandshakeTimeout: time.Second,
+	}
+
+	conn, resp, err := dialer.Dial("ws://inetmock.icb4dc0.de/ws", nil)
+	if err != nil {
+		tb.Fatalf("Dial() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	tb.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+func sendWebSocketStep(tb testing.TB, conn *websocket.Conn, step webSocketStep) {
+	tb.Helper()
+	var err error
+	if step.closeCode != 0 {
+		err = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(step.closeCode, ""), time.Now().Add(time.Second))
+	} else {
+		err = conn.WriteMessage(step.messageType, []byte(step.data))
+	}
+
+	if err != nil {
+		tb.Fatalf("failed to send %q: %v", step.data, err)
+	}
+}
+
+func expectWebSocketStep(tb testing.TB, conn *websocket.Conn, step webSocketStep) {
+	tb.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	messageType, data, err := conn.ReadMessage()
+
+	if step.closeCode != 0 {
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) {
+			tb.Fatalf("expected close frame but got %v", err)
+		}
+		td.Cmp(tb, closeErr.Code, step.closeCode)
+		return
+	}
+
+	if err != nil {
+		tb.Fatalf("failed to read message: %v", err)
+	}
+
+	td.Cmp(tb, messageType, step.messageType)
+	td.Cmp(tb, string(data), step.data)
+}
+
+func webSocketEvent(direction auditv1.WebSocketDirection, opcode auditv1.WebSocketOpcode, size int64) any {
+	return audit.WebSocket{
+		Direction:   direction,
+		Opcode:      opcode,
+		PayloadSize: size,
+		URI:         "/ws",
+	}
+}
@@ -0,0 +1,252 @@
+package irc
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const writeTimeout = 10 * time.Second
+
+var errQuit = errors.New("client quit")
+
+type identity struct {
+	nick     string
+	user     string
+	realName string
+	host     string
+}
+
+type client struct {
+	handler   *ircHandler
+	conn      net.Conn
+	host      string
+	writeLock sync.Mutex
+	// nick, user and realName are guarded by the lock of the state
+	nick     string
+	user     string
+	realName string
+	// channels is guarded by the lock of the state
+	channels       map[string]*channel
+	registered     bool
+	capNegotiation bool
+}
+
+func newClient(handler *ircHandler, conn net.Conn) *client {
+	c := &client{
+		handler:  handler,
+		conn:     conn,
+		host:     conn.RemoteAddr().String(),
+		channels: make(map[string]*channel),
+	}
+
+	if ip, _, err := netutils.IPPortFromAddress(conn.RemoteAddr()); err == nil {
+		c.host = ip.String()
+	}
+
+	return c
+}
+
+func (c *client) serve() error {
+	defer c.quit("Connection closed")
+
+	reader := bufio.NewReaderSize(c.conn, maxLineLength)
+	var (
+		pending  []byte
+		pingSent bool
+	)
+
+	for {
+		_ = c.conn.SetReadDeadline(time.Now().Add(c.handler.options.PingInterval))
+		line, err := reader.ReadSlice('\n')
+		switch {
+		case errors.Is(err, os.ErrDeadlineExceeded) && !pingSent:
+			// the partially received line is kept until the client sends the remaining part
+			pending = append(pending, line...)
+			pingSent = true
+			c.send(message{command: "PING", params: []string{c.handler.options.ServerName}})
+			continue
+		case errors.Is(err, bufio.ErrBufferFull):
+			pending = pending[:0]
+			c.reply("417", "Input line was too long")
+			continue
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		}
+
+		pingSent = false
+		if len(pending) > 0 {
+			line = append(pending, line...)
+			pending = pending[:0]
+		}
+
+		msg, ok := parseMessage(strings.TrimRight(string(line), "\r\n"))
+		if !ok {
+			continue
+		}
+
+		if err = c.handle(msg); errors.Is(err, errQuit) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *client) handle(msg message) error {
+	c.emit(msg)
+
+	cmd, known := commands[msg.command]
+	switch {
+	case !known:
+		c.reply("421", msg.command, "Unknown command")
+		return nil
+	case !cmd.public && !c.registered:
+		c.reply("451", "You have not registered")
+		return nil
+	case len(msg.params) < cmd.minParams:
+		c.reply("461", msg.command, "Not enough parameters")
+		return nil
+	}
+
+	if err := cmd.handle(c, msg); err != nil {
+		return err
+	}
+
+	if c.registered && cmd.evaluated {
+		c.evaluate(Request{Command: msg.command, Target: msg.param(0), Nick: c.nick, Text: msg.param(1)})
+	}
+
+	return nil
+}
+
+// evaluate executes the actions of the first rule matching the given request
+func (c *client) evaluate(req Request) {
+	response, matched := c.handler.ruleHandler.Evaluate(req, c.host)
+	if !matched {
+		return
+	}
+
+	target := c.nick
+	if isChannel(req.Target) {
+		target = req.Target
+	}
+
+	for _, action := range response {
+		if action.Command == "TOPIC" {
+			if isChannel(target) {
+				c.handler.setTopic(c.handler.options.operatorPrefix(), target, action.Text)
+			}
+			continue
+		}
+
+		_, _ = c.handler.deliver(c.handler.options.operatorPrefix(), action.Command, target, action.Text, nil)
+	}
+}
+
+// tryRegister completes the registration as soon as the client sent NICK and USER and finished the capability negotiation
+func (c *client) tryRegister() {
+	if c.registered || c.capNegotiation || c.nick == "" || c.user == "" {
+		return
+	}
+
+	c.registered = true
+	opts := c.handler.options
+
+	c.reply("001", "Welcome to the "+opts.Network+" Internet Relay Chat Network "+c.nick)
+	c.reply("002", "Your host is "+opts.ServerName+", running version "+serverVersion)
+	c.reply("003", "This server was created "+c.handler.created.Format(time.RFC1123))
+	c.reply("004", opts.ServerName, serverVersion, "iow", "beiklmnost")
+	c.reply("005", "NETWORK="+opts.Network, "CHANTYPES=#&", "CASEMAPPING=ascii", "NICKLEN=30", "are supported by this server")
+
+	if len(opts.MOTD) == 0 {
+		c.reply("422", "MOTD File is missing")
+		return
+	}
+
+	c.reply("375", "- "+opts.ServerName+" Message of the day - ")
+	for _, line := range opts.MOTD {
+		c.reply("372", "- "+line)
+	}
+	c.reply("376", "End of /MOTD command")
+}
+
+// quit removes the client from all channels and notifies all clients it shared a channel with
+func (c *client) quit(reason string) {
+	peers := c.handler.state.remove(c)
+	if !c.registered {
+		return
+	}
+
+	msg := message{prefix: c.prefix(), command: "QUIT", params: []string{reason}}
+	for _, peer := range peers {
+		peer.send(msg)
+	}
+}
+
+func (c *client) prefix() string {
+	return c.nick + "!" + c.user + "@" + c.host
+}
+
+func (c *client) identity() identity {
+	return identity{nick: c.nick, user: c.user, realName: c.realName, host: c.host}
+}
+
+// reply sends a numeric reply, the nick of the client is added as first parameter
+func (c *client) reply(numeric string, params ...string) {
+	nick := c.nick
+	if nick == "" {
+		nick = "*"
+	}
+
+	c.send(message{prefix: c.handler.options.ServerName, command: numeric, params: append([]string{nick}, params...)})
+}
+
+// send writes the message to the client, it's safe to be called by the connections of other clients.
+// Errors are ignored because the reading side of the connection will notice a broken connection.
+func (c *client) send(msg message) {
+	line := msg.String()
+	if len(line) > maxLineLength-2 {
+		line = line[:maxLineLength-2]
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, _ = io.WriteString(c.conn, line+"\r\n")
+}
+
+func (c *client) emit(msg message) {
+	builder := c.handler.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_IRC).
+		WithProtocolDetails(audit.IRC{
+			Nick:     c.nick,
+			User:     c.user,
+			RealName: c.realName,
+			Command:  msg.command,
+			Params:   msg.params,
+		})
+
+	if details, ok := audit.NewTLSDetailsFromConn(c.conn); ok {
+		builder = builder.WithTLSDetails(details)
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(c.conn.RemoteAddr())
+	builder, _ = builder.WithDestinationFromAddr(c.conn.LocalAddr())
+
+	builder.Emit()
+}
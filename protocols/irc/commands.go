@@ -0,0 +1,336 @@
+package irc
+
+import (
+	"strconv"
+	"strings"
+)
+
+const serverVersion = "inetmock-irc"
+
+type command struct {
+	handle func(c *client, msg message) error
+	// public commands are allowed before the client registered
+	public bool
+	// minParams is the number of parameters required by the command
+	minParams int
+	// evaluated commands are evaluated against the rules after they were handled
+	evaluated bool
+}
+
+var commands = map[string]command{
+	"CAP":  {handle: (*client).cmdCap, public: true, minParams: 1},
+	"PASS": {handle: (*client).cmdNoop, public: true, minParams: 1},
+	"NICK": {handle: (*client).cmdNick, public: true},
+	"USER": {handle: (*client).cmdUser, public: true, minParams: 4},
+	"PING": {handle: (*client).cmdPing, public: true},
+	"PONG": {handle: (*client).cmdNoop, public: true},
+	"QUIT": {handle: (*client).cmdQuit, public: true},
+
+	// JOIN evaluates the rules for every joined channel on its own
+	"JOIN":     {handle: (*client).cmdJoin, minParams: 1},
+	"PART":     {handle: (*client).cmdPart, minParams: 1, evaluated: true},
+	"PRIVMSG":  {handle: (*client).cmdPrivmsg, evaluated: true},
+	"NOTICE":   {handle: (*client).cmdPrivmsg, evaluated: true},
+	"TOPIC":    {handle: (*client).cmdTopic, minParams: 1, evaluated: true},
+	"NAMES":    {handle: (*client).cmdNames},
+	"LIST":     {handle: (*client).cmdList},
+	"WHO":      {handle: (*client).cmdWho, minParams: 1},
+	"WHOIS":    {handle: (*client).cmdWhois, minParams: 1},
+	"MODE":     {handle: (*client).cmdMode, minParams: 1},
+	"USERHOST": {handle: (*client).cmdUserhost, minParams: 1},
+	"ISON":     {handle: (*client).cmdIson, minParams: 1},
+	"AWAY":     {handle: (*client).cmdAway},
+}
+
+func (c *client) cmdNoop(message) error {
+	return nil
+}
+
+// cmdCap offers no capabilities, the registration is delayed until the client ends the negotiation
+func (c *client) cmdCap(msg message) error {
+	switch strings.ToUpper(msg.param(0)) {
+	case "LS", "LIST":
+		c.capNegotiation = !c.registered
+		c.send(message{prefix: c.handler.options.ServerName, command: "CAP", params: []string{"*", strings.ToUpper(msg.param(0)), ""}})
+	case "REQ":
+		c.capNegotiation = !c.registered
+		c.send(message{prefix: c.handler.options.ServerName, command: "CAP", params: []string{"*", "NAK", msg.param(1)}})
+	case "END":
+		c.capNegotiation = false
+		c.tryRegister()
+	}
+	return nil
+}
+
+func (c *client) cmdNick(msg message) error {
+	nick := msg.param(0)
+	switch {
+	case nick == "":
+		c.reply("431", "No nickname given")
+		return nil
+	case !validNick(nick):
+		c.reply("432", nick, "Erroneous nickname")
+		return nil
+	}
+
+	oldPrefix := c.prefix()
+	if !c.handler.state.claimNick(c, nick) {
+		c.reply("433", nick, "Nickname is already in use")
+		return nil
+	}
+
+	if !c.registered {
+		c.tryRegister()
+		return nil
+	}
+
+	msg = message{prefix: oldPrefix, command: "NICK", params: []string{nick}}
+	c.send(msg)
+	for _, peer := range c.handler.state.peers(c) {
+		peer.send(msg)
+	}
+
+	return nil
+}
+
+func (c *client) cmdUser(msg message) error {
+	if c.registered {
+		c.reply("462", "You may not reregister")
+		return nil
+	}
+
+	c.handler.state.setUser(c, msg.param(0), msg.param(3))
+	c.tryRegister()
+	return nil
+}
+
+func (c *client) cmdPing(msg message) error {
+	c.send(message{prefix: c.handler.options.ServerName, command: "PONG", params: []string{c.handler.options.ServerName, msg.param(0)}})
+	return nil
+}
+
+func (c *client) cmdQuit(msg message) error {
+	reason := msg.param(0)
+	if reason == "" {
+		reason = "Client Quit"
+	}
+
+	c.quit("Quit: " + reason)
+	c.send(message{command: "ERROR", params: []string{"Closing Link: " + c.host + " (" + reason + ")"}})
+	return errQuit
+}
+
+func (c *client) cmdJoin(msg message) error {
+	if msg.param(0) == "0" {
+		for _, name := range c.joinedChannels() {
+			c.partChannel(name, "")
+		}
+		return nil
+	}
+
+	for _, name := range strings.Split(msg.param(0), ",") {
+		if !validChannelName(name) {
+			c.reply("403", name, "No such channel")
+			continue
+		}
+
+		members, topic, joined := c.handler.state.join(c, name)
+		if !joined {
+			continue
+		}
+
+		join := message{prefix: c.prefix(), command: "JOIN", params: []string{name}}
+		for _, member := range members {
+			member.send(join)
+		}
+
+		if topic != "" {
+			c.reply("332", name, topic)
+		}
+		c.sendNames(name)
+
+		c.evaluate(Request{Command: "JOIN", Target: name, Nick: c.nick})
+	}
+
+	return nil
+}
+
+func (c *client) cmdPart(msg message) error {
+	for _, name := range strings.Split(msg.param(0), ",") {
+		c.partChannel(name, msg.param(1))
+	}
+	return nil
+}
+
+func (c *client) partChannel(name, reason string) {
+	members, ok := c.handler.state.part(c, name)
+	if !ok {
+		c.reply("442", name, "You're not on that channel")
+		return
+	}
+
+	params := []string{name}
+	if reason != "" {
+		params = append(params, reason)
+	}
+
+	part := message{prefix: c.prefix(), command: "PART", params: params}
+	for _, member := range members {
+		member.send(part)
+	}
+}
+
+func (c *client) joinedChannels() []string {
+	c.handler.state.lock.Lock()
+	defer c.handler.state.lock.Unlock()
+
+	names := make([]string, 0, len(c.channels))
+	for _, ch := range c.channels {
+		names = append(names, ch.name)
+	}
+	return names
+}
+
+func (c *client) cmdPrivmsg(msg message) error {
+	switch {
+	case len(msg.params) == 0:
+		c.reply("411", "No recipient given ("+msg.command+")")
+		return nil
+	case len(msg.params) == 1 || msg.params[1] == "":
+		c.reply("412", "No text to send")
+		return nil
+	}
+
+	for _, target := range strings.Split(msg.params[0], ",") {
+		if _, err := c.handler.deliver(c.prefix(), msg.command, target, msg.params[1], c); err != nil && msg.command == "PRIVMSG" {
+			c.reply("401", target, "No such nick/channel")
+		}
+	}
+
+	return nil
+}
+
+func (c *client) cmdTopic(msg message) error {
+	name := msg.param(0)
+	if len(msg.params) > 1 {
+		if !c.handler.setTopic(c.prefix(), name, msg.params[1]) {
+			c.reply("403", name, "No such channel")
+		}
+		return nil
+	}
+
+	switch topic, ok := c.handler.state.topic(name); {
+	case !ok:
+		c.reply("403", name, "No such channel")
+	case topic == "":
+		c.reply("331", name, "No topic is set")
+	default:
+		c.reply("332", name, topic)
+	}
+
+	return nil
+}
+
+func (c *client) cmdNames(msg message) error {
+	if msg.param(0) == "" {
+		c.reply("366", "*", "End of /NAMES list")
+		return nil
+	}
+
+	for _, name := range strings.Split(msg.param(0), ",") {
+		c.sendNames(name)
+	}
+	return nil
+}
+
+func (c *client) sendNames(name string) {
+	if names, ok := c.handler.state.names(name); ok {
+		c.reply("353", "=", name, strings.Join(names, " "))
+	}
+	c.reply("366", name, "End of /NAMES list")
+}
+
+func (c *client) cmdList(message) error {
+	c.reply("321", "Channel", "Users  Name")
+	for _, info := range c.handler.state.channelInfos() {
+		// the operator is counted as member of every channel
+		c.reply("322", info.name, strconv.Itoa(info.members+1), info.topic)
+	}
+	c.reply("323", "End of /LIST")
+	return nil
+}
+
+func (c *client) cmdWho(msg message) error {
+	mask := msg.param(0)
+	channelName := "*"
+	if isChannel(mask) {
+		channelName = mask
+	}
+
+	for _, member := range c.handler.state.who(mask) {
+		c.reply("352", channelName, member.user, member.host, c.handler.options.ServerName, member.nick, "H", "0 "+member.realName)
+	}
+	c.reply("315", mask, "End of /WHO list")
+	return nil
+}
+
+func (c *client) cmdWhois(msg message) error {
+	nick := msg.params[len(msg.params)-1]
+	identities := c.handler.state.who(nick)
+	if isChannel(nick) || len(identities) == 0 {
+		c.reply("401", nick, "No such nick/channel")
+	} else {
+		c.reply("311", identities[0].nick, identities[0].user, identities[0].host, "*", identities[0].realName)
+		c.reply("312", identities[0].nick, c.handler.options.ServerName, c.handler.options.Network)
+	}
+
+	c.reply("318", nick, "End of /WHOIS list")
+	return nil
+}
+
+// cmdMode reports fixed modes, mode changes are silently ignored
+func (c *client) cmdMode(msg message) error {
+	target := msg.param(0)
+	switch {
+	case isChannel(target) && len(msg.params) == 1:
+		c.reply("324", target, "+nt")
+	case !isChannel(target) && strings.EqualFold(target, c.nick) && len(msg.params) == 1:
+		c.reply("221", "+i")
+	}
+	return nil
+}
+
+func (c *client) cmdUserhost(msg message) error {
+	replies := make([]string, 0, len(msg.params))
+	for _, nick := range msg.params {
+		if identities := c.handler.state.who(nick); !isChannel(nick) && len(identities) > 0 {
+			replies = append(replies, identities[0].nick+"=+"+identities[0].user+"@"+identities[0].host)
+		}
+	}
+
+	c.reply("302", strings.Join(replies, " "))
+	return nil
+}
+
+func (c *client) cmdIson(msg message) error {
+	online := make([]string, 0, len(msg.params))
+	for _, param := range msg.params {
+		for _, nick := range strings.Fields(param) {
+			if _, ok := c.handler.state.client(nick); ok || strings.EqualFold(nick, c.handler.options.OperatorNick) {
+				online = append(online, nick)
+			}
+		}
+	}
+
+	c.reply("303", strings.Join(online, " "))
+	return nil
+}
+
+func (c *client) cmdAway(msg message) error {
+	if msg.param(0) == "" {
+		c.reply("305", "You are no longer marked as being away")
+	} else {
+		c.reply("306", "You have been marked as being away")
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package irc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/multiplexing"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const name = "irc_mock"
+
+var (
+	_ endpoint.MultiplexHandler        = (*ircHandler)(nil)
+	_ endpoint.MessageInjectingHandler = (*ircHandler)(nil)
+)
+
+type ircHandler struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	options     ircOptions
+	ruleHandler *RuleHandler
+	state       *state
+	created     time.Time
+	lock        sync.Mutex
+	conns       endpoint.ConnTracker
+}
+
+func (h *ircHandler) Matchers() []cmux.Matcher {
+	return []cmux.Matcher{multiplexing.IRC()}
+}
+
+func (h *ircHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	ruleHandler := &RuleHandler{
+		HandlerName: startupSpec.Name,
+	}
+
+	for idx := range h.options.Rules {
+		rule := h.options.Rules[idx]
+		if err = ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+	h.ruleHandler = ruleHandler
+
+	h.created = time.Now()
+	h.conns.Open()
+
+	h.lock.Lock()
+	h.state = newState(h.options.OperatorNick)
+	h.lock.Unlock()
+
+	go h.serve(startupSpec.Listener)
+	return nil
+}
+
+// Stop disconnects all clients, the channels of the server are reset when the handler is started again
+func (h *ircHandler) Stop(context.Context) error {
+	h.conns.CloseAll()
+	return nil
+}
+
+func (h *ircHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+// InjectMessage sends a PRIVMSG to all members of a channel or to a single client,
+// multi-line messages are split into one PRIVMSG per line
+func (h *ircHandler) InjectMessage(target, sender, text string) (recipients int, err error) {
+	h.lock.Lock()
+	serving := h.state != nil && h.conns.IsOpen()
+	h.lock.Unlock()
+
+	if !serving {
+		return 0, endpoint.ErrNotServing
+	}
+
+	prefix := h.options.operatorPrefix()
+	if sender != "" {
+		prefix = sender + "!" + sender + "@" + h.options.ServerName
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(text, "\r\n"), "\n") {
+		if recipients, err = h.deliver(prefix, "PRIVMSG", target, strings.TrimRight(line, "\r"), nil); err != nil {
+			return 0, err
+		}
+	}
+
+	return recipients, nil
+}
+
+// deliver sends a PRIVMSG or NOTICE to all members of a channel except the sender or to a single client.
+// Messages to the operator are accepted but not delivered.
+func (h *ircHandler) deliver(prefix, command, target, text string, sender *client) (recipients int, err error) {
+	msg := message{prefix: prefix, command: command, params: []string{target, text}}
+
+	if isChannel(target) {
+		members, ok := h.state.members(target)
+		if !ok {
+			return 0, endpoint.ErrUnknownMessageTarget
+		}
+
+		for _, member := range members {
+			if member != sender {
+				member.send(msg)
+				recipients++
+			}
+		}
+		return recipients, nil
+	}
+
+	if strings.EqualFold(target, h.options.OperatorNick) {
+		return 0, nil
+	}
+
+	receiver, ok := h.state.client(target)
+	if !ok {
+		return 0, endpoint.ErrUnknownMessageTarget
+	}
+
+	receiver.send(msg)
+	return 1, nil
+}
+
+func (h *ircHandler) setTopic(prefix, channelName, topic string) bool {
+	members, ok := h.state.setTopic(channelName, topic)
+	if !ok {
+		return false
+	}
+
+	msg := message{prefix: prefix, command: "TOPIC", params: []string{channelName, topic}}
+	for _, member := range members {
+		member.send(msg)
+	}
+
+	return true
+}
+
+func (h *ircHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept IRC connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *ircHandler) handleConn(conn net.Conn) {
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+	}()
+
+	if err := endpoint.IgnoreShutdownError(newClient(h, conn).serve()); err != nil {
+		h.logger.Debug("IRC session terminated", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,304 @@
+package irc_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/irc"
+)
+
+const clientTimeout = 5 * time.Second
+
+type step struct {
+	client int
+	send   string
+	// want contains regular expressions the next lines received by the client have to match in order
+	want []string
+}
+
+func Test_ircHandler(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		opts         map[string]any
+		clients      int
+		register     bool
+		steps        []step
+		wantStartErr bool
+		wantEvents   any
+	}{
+		{
+			name:    "Registration with capability negotiation",
+			clients: 1,
+			opts: map[string]any{
+				"network": "C2Net",
+				"motd":    []string{"Welcome bots"},
+			},
+			steps: []step{
+				{send: "CAP LS 302", want: []string{`^:irc\.inetmock\.local CAP \* LS :$`}},
+				{send: "NICK bot"},
+				{send: "USER bot 0 * :Real Bot"},
+				{send: "CAP END", want: []string{
+					`^:irc\.inetmock\.local 001 bot :Welcome to the C2Net Internet Relay Chat Network bot$`,
+					` 002 bot `,
+					` 003 bot `,
+					` 004 bot irc\.inetmock\.local `,
+					` 005 bot NETWORK=C2Net `,
+					` 375 bot `,
+					` 372 bot :- Welcome bots$`,
+					` 376 bot `,
+				}},
+				{send: "PING :abc", want: []string{`^:irc\.inetmock\.local PONG irc\.inetmock\.local abc$`}},
+			},
+			wantEvents: []any{
+				audit.IRC{Command: "CAP", Params: []string{"LS", "302"}},
+				audit.IRC{Command: "NICK", Params: []string{"bot"}},
+				audit.IRC{Nick: "bot", Command: "USER", Params: []string{"bot", "0", "*", "Real Bot"}},
+				audit.IRC{Nick: "bot", User: "bot", RealName: "Real Bot", Command: "CAP", Params: []string{"END"}},
+				audit.IRC{Nick: "bot", User: "bot", RealName: "Real Bot", Command: "PING", Params: []string{"abc"}},
+			},
+		},
+		{
+			name:    "Commands require registration",
+			clients: 1,
+			steps: []step{
+				{send: "JOIN #botnet", want: []string{` 451 \* :You have not registered$`}},
+				{send: "NICK operator", want: []string{` 433 \* operator :Nickname is already in use$`}},
+				{send: "NICK 1bot", want: []string{` 432 \* 1bot :Erroneous nickname$`}},
+			},
+			wantEvents: td.Len(3),
+		},
+		{
+			name:     "Channel messages are relayed",
+			clients:  2,
+			register: true,
+			steps: []step{
+				{client: 0, send: "JOIN #botnet", want: []string{
+					`^:bot0!bot0@127\.0\.0\.1 JOIN #botnet$`,
+					` 353 bot0 = #botnet :@operator bot0$`,
+					` 366 bot0 #botnet `,
+				}},
+				{client: 1, send: "JOIN #botnet", want: []string{
+					`^:bot1!bot1@127\.0\.0\.1 JOIN #botnet$`,
+					` 353 bot1 = #botnet :@operator bot0 bot1$`,
+					` 366 bot1 #botnet `,
+				}},
+				{client: 0, want: []string{`^:bot1!bot1@127\.0\.0\.1 JOIN #botnet$`}},
+				{client: 1, send: "PRIVMSG #botnet :hello bots", want: nil},
+				{client: 0, want: []string{`^:bot1!bot1@127\.0\.0\.1 PRIVMSG #botnet :hello bots$`}},
+				{client: 1, send: "PRIVMSG bot0 :psst", want: nil},
+				{client: 0, want: []string{`^:bot1!bot1@127\.0\.0\.1 PRIVMSG bot0 psst$`}},
+				{client: 1, send: "NICK zombie", want: []string{`^:bot1!bot1@127\.0\.0\.1 NICK zombie$`}},
+				{client: 0, want: []string{`^:bot1!bot1@127\.0\.0\.1 NICK zombie$`}},
+				{client: 1, send: "PRIVMSG nobody :hello", want: []string{` 401 zombie nobody :No such nick/channel$`}},
+				{client: 1, send: "QUIT :bye", want: []string{`^ERROR :Closing Link: 127\.0\.0\.1 \(bye\)$`}},
+				{client: 0, want: []string{`^:zombie!bot1@127\.0\.0\.1 QUIT :Quit: bye$`}},
+			},
+			wantEvents: td.SuperBagOf(
+				audit.IRC{Nick: "bot1", User: "bot1", RealName: "Bot", Command: "PRIVMSG", Params: []string{"#botnet", "hello bots"}},
+				audit.IRC{Nick: "zombie", User: "bot1", RealName: "Bot", Command: "QUIT", Params: []string{"bye"}},
+			),
+		},
+		{
+			name:     "Rules send commands to bots",
+			clients:  1,
+			register: true,
+			opts: map[string]any{
+				"rules": []string{
+					`Command("JOIN") -> Target("#botnet") => Topic("!download http://192.0.2.1/payload.exe") => Say("!scan 10.0.0.0/8")`,
+					`Command("PRIVMSG") -> Text("^!version") => Notice("v1.0")`,
+				},
+			},
+			steps: []step{
+				{send: "JOIN #botnet", want: []string{
+					` JOIN #botnet$`,
+					` 353 bot0 = #botnet :@operator bot0$`,
+					` 366 bot0 #botnet `,
+					`^:operator!operator@irc\.inetmock\.local TOPIC #botnet :!download http://192\.0\.2\.1/payload\.exe$`,
+					`^:operator!operator@irc\.inetmock\.local PRIVMSG #botnet :!scan 10\.0\.0\.0/8$`,
+				}},
+				{send: "PRIVMSG operator :!version", want: []string{
+					`^:operator!operator@irc\.inetmock\.local NOTICE bot0 v1\.0$`,
+				}},
+				{send: "TOPIC #botnet", want: []string{` 332 bot0 #botnet :!download http://192\.0\.2\.1/payload\.exe$`}},
+			},
+			wantEvents: td.Len(5),
+		},
+		{
+			name: "Error because of invalid operator nick",
+			opts: map[string]any{
+				"operatorNick": "#op",
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of unknown action",
+			opts: map[string]any{
+				"rules": []string{
+					`Command("JOIN") => Kick()`,
+				},
+			},
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			emitterMock := new(audit_mock.EmitterMock)
+			listener, err := startHandler(t, emitterMock, tt.opts)
+			if err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			clients := make([]*ircClient, 0, tt.clients)
+			for i := 0; i < tt.clients; i++ {
+				c := dialClient(t, listener.Addr())
+				if tt.register {
+					c.register(t, fmt.Sprintf("bot%d", i))
+				}
+				clients = append(clients, c)
+			}
+
+			for _, s := range tt.steps {
+				c := clients[s.client]
+				if s.send != "" {
+					c.send(t, s.send)
+				}
+				for _, want := range s.want {
+					c.expect(t, want)
+				}
+			}
+
+			test.AwaitEventDetails(t, emitterMock, auditv1.AppProtocol_APP_PROTOCOL_IRC, tt.wantEvents)
+		})
+	}
+}
+
+func Test_ircHandler_InjectMessage(t *testing.T) {
+	t.Parallel()
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := irc.New(logging.CreateTestLogger(t), emitterMock).(endpoint.MessageInjectingHandler)
+
+	if _, err := handler.InjectMessage("#botnet", "", "!ddos"); !errors.Is(err, endpoint.ErrNotServing) {
+		t.Errorf("InjectMessage() error = %v, want %v", err, endpoint.ErrNotServing)
+	}
+
+	listener := test.NewTCPListener(t, "127.0.0.1:0")
+	if err := handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	clients := []*ircClient{dialClient(t, listener.Addr()), dialClient(t, listener.Addr())}
+	for idx, c := range clients {
+		c.register(t, fmt.Sprintf("bot%d", idx))
+		c.send(t, "JOIN #botnet")
+		c.expect(t, ` JOIN #botnet$`)
+		c.expect(t, ` 353 bot\d = #botnet `)
+		c.expect(t, ` 366 bot\d #botnet `)
+	}
+	clients[0].expect(t, `^:bot1!bot1@127\.0\.0\.1 JOIN #botnet$`)
+
+	recipients, err := handler.InjectMessage("#BotNet", "master", "!ddos 192.0.2.1\n!sleep 60")
+	td.CmpNoError(t, err)
+	td.Cmp(t, recipients, 2)
+	for _, c := range clients {
+		c.expect(t, `^:master!master@irc\.inetmock\.local PRIVMSG #BotNet :!ddos 192\.0\.2\.1$`)
+		c.expect(t, `^:master!master@irc\.inetmock\.local PRIVMSG #BotNet :!sleep 60$`)
+	}
+
+	recipients, err = handler.InjectMessage("bot1", "", "!update")
+	td.CmpNoError(t, err)
+	td.Cmp(t, recipients, 1)
+	clients[1].expect(t, `^:operator!operator@irc\.inetmock\.local PRIVMSG bot1 !update$`)
+
+	if _, err = handler.InjectMessage("#unknown", "", "!ddos"); !errors.Is(err, endpoint.ErrUnknownMessageTarget) {
+		t.Errorf("InjectMessage() error = %v, want %v", err, endpoint.ErrUnknownMessageTarget)
+	}
+}
+
+func startHandler(tb testing.TB, emitter *audit_mock.EmitterMock, opts map[string]any) (net.Listener, error) {
+	tb.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+
+	listener := test.NewTCPListener(tb, "127.0.0.1:0")
+	handler := irc.New(logging.CreateTestLogger(tb), emitter)
+	return listener, handler.Start(ctx, endpoint.NewStartupSpec(tb.Name(), endpoint.NewUplink(listener), opts))
+}
+
+type ircClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialClient(tb testing.TB, addr net.Addr) *ircClient {
+	tb.Helper()
+	conn, err := net.DialTimeout("tcp", addr.String(), clientTimeout)
+	if err != nil {
+		tb.Fatalf("net.Dial() error = %v", err)
+	}
+	tb.Cleanup(func() {
+		_ = conn.Close()
+	})
+	_ = conn.SetDeadline(time.Now().Add(clientTimeout))
+
+	return &ircClient{conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (c *ircClient) register(tb testing.TB, nick string) {
+	tb.Helper()
+	c.send(tb, "NICK "+nick)
+	c.send(tb, "USER "+nick+" 0 * :Bot")
+	c.expect(tb, " 001 "+nick+" ")
+	// skip the remaining welcome messages until the end of the (missing) MOTD
+	c.expect(tb, " 422 "+nick+" ")
+}
+
+func (c *ircClient) send(tb testing.TB, line string) {
+	tb.Helper()
+	if _, err := c.conn.Write([]byte(line + "\r\n")); err != nil {
+		tb.Fatalf("Write() error = %v", err)
+	}
+}
+
+// expect reads lines until one matches the given pattern, lines not matching are only skipped for
+// the welcome messages, otherwise the next line has to match
+func (c *ircClient) expect(tb testing.TB, pattern string) {
+	tb.Helper()
+	exp := regexp.MustCompile(pattern)
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			tb.Fatalf("expected line matching %q but got error %v", pattern, err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if exp.MatchString(line) {
+			return
+		}
+		if !regexp.MustCompile(` 00[2-5] | 42[2] | 37[256] `).MatchString(line) {
+			tb.Fatalf("expected line matching %q but got %q", pattern, line)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package irc
+
+import (
+	"strings"
+)
+
+// maxLineLength is the maximum length of a message including the trailing CRLF as defined in RFC 1459
+const maxLineLength = 512
+
+type message struct {
+	prefix  string
+	command string
+	params  []string
+}
+
+// parseMessage parses a single line like ":nick!user@host PRIVMSG #channel :hello world"
+func parseMessage(line string) (msg message, ok bool) {
+	line = strings.TrimLeft(line, " ")
+	if strings.HasPrefix(line, ":") {
+		var found bool
+		if msg.prefix, line, found = strings.Cut(line[1:], " "); !found {
+			return msg, false
+		}
+	}
+
+	for line = strings.TrimLeft(line, " "); line != ""; line = strings.TrimLeft(line, " ") {
+		if strings.HasPrefix(line, ":") {
+			msg.params = append(msg.params, line[1:])
+			break
+		}
+
+		var param string
+		param, line, _ = strings.Cut(line, " ")
+		if msg.command == "" {
+			msg.command = strings.ToUpper(param)
+		} else {
+			msg.params = append(msg.params, param)
+		}
+	}
+
+	return msg, msg.command != ""
+}
+
+func (m message) param(idx int) string {
+	if idx < len(m.params) {
+		return m.params[idx]
+	}
+	return ""
+}
+
+// String formats the message without line ending,
+// the last parameter is always sent as trailing parameter if it's empty, contains spaces or starts with a colon
+func (m message) String() string {
+	var builder strings.Builder
+	if m.prefix != "" {
+		builder.WriteString(":")
+		builder.WriteString(m.prefix)
+		builder.WriteString(" ")
+	}
+
+	builder.WriteString(m.command)
+
+	for idx, param := range m.params {
+		builder.WriteString(" ")
+		if idx == len(m.params)-1 && (param == "" || strings.Contains(param, " ") || strings.HasPrefix(param, ":")) {
+			builder.WriteString(":")
+		}
+		builder.WriteString(param)
+	}
+
+	return builder.String()
+}
+
+func isChannel(target string) bool {
+	return strings.HasPrefix(target, "#") || strings.HasPrefix(target, "&")
+}
+
+func validChannelName(name string) bool {
+	const maxChannelNameLength = 50
+	return isChannel(name) && len(name) > 1 && len(name) <= maxChannelNameLength && !strings.ContainsAny(name, " ,\a")
+}
+
+func validNick(nick string) bool {
+	const maxNickLength = 30
+	if nick == "" || len(nick) > maxNickLength || strings.ContainsAny(nick[:1], "0123456789-") {
+		return false
+	}
+
+	for _, r := range nick {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', strings.ContainsRune("[]\\`_^{|}-", r):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,52 @@
+package irc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+const defaultPingInterval = 2 * time.Minute
+
+var ErrInvalidOperatorNick = errors.New("operator nick is not a valid nick name")
+
+type ircOptions struct {
+	// ServerName is the prefix of all messages sent by the server
+	ServerName string
+	// Network is the name of the network announced to clients
+	Network string
+	// MOTD is the message of the day sent after the registration
+	MOTD []string
+	// OperatorNick is the default sender of messages sent by rules or the API, it's listed as operator in every channel
+	OperatorNick string
+	// PingInterval is the time after which idle clients are pinged, clients not answering within another interval are
+	// disconnected
+	PingInterval time.Duration
+	Rules        []string
+}
+
+func (o ircOptions) operatorPrefix() string {
+	return o.OperatorNick + "!" + o.OperatorNick + "@" + o.ServerName
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts ircOptions, err error) {
+	opts = ircOptions{
+		ServerName:   "irc.inetmock.local",
+		Network:      "InetMock",
+		OperatorNick: "operator",
+		PingInterval: defaultPingInterval,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc())); err != nil {
+		return opts, err
+	}
+
+	if !validNick(opts.OperatorNick) {
+		return opts, ErrInvalidOperatorNick
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,241 @@
+package irc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+var (
+	knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
+		"command": CommandFilter,
+		"target":  TargetFilter,
+		"nick":    NickFilter,
+		"text":    TextFilter,
+	}
+	knownActions = map[string]func(args ...rules.Param) (Action, error){
+		"say":    SayAction,
+		"notice": NoticeAction,
+		"topic":  TopicAction,
+	}
+)
+
+type (
+	// Request is a message of a registered client,
+	// Target is the channel or nick a message is addressed to and Text the last parameter of the message
+	Request struct {
+		Command string
+		Target  string
+		Nick    string
+		Text    string
+	}
+	RequestFilter interface {
+		Matches(req Request) bool
+	}
+	RequestFilterFunc func(req Request) bool
+	FilterChain       []RequestFilter
+
+	// Action is a message sent by the operator after the client's message was processed.
+	// Messages are sent to the channel the client's message was addressed to or to the client itself.
+	Action struct {
+		Command string
+		Text    string
+	}
+
+	Response []Action
+
+	ConditionalResponse struct {
+		Filters  FilterChain
+		Response Response
+	}
+)
+
+func (f RequestFilterFunc) Matches(req Request) bool {
+	return f(req)
+}
+
+func (c FilterChain) Matches(req Request) bool {
+	for idx := range c {
+		if !c[idx].Matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+type RuleHandler struct {
+	HandlerName string
+	responses   rules.Set[ConditionalResponse]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	response, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.responses.Append(rawRule, response)
+	return nil
+}
+
+// RuleManager exposes the response rules, changes apply to the next message of every connected client.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalResponse]{
+		Set:     &h.responses,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the response of the first rule matching the given request
+func (h *RuleHandler) Evaluate(req Request, client string) (Response, bool) {
+	responses := h.responses.Entries()
+	for idx := range responses {
+		entry := responses[idx]
+		if entry.Value.Filters.Matches(req) {
+			protocols.RecordRuleMatch(entry, name, h.HandlerName, client)
+			return entry.Value.Response, true
+		}
+	}
+
+	return nil, false
+}
+
+func compileRule(rawRule string) (response ConditionalResponse, err error) {
+	var rule *rules.ChainedResponsePipeline
+	if rule, err = rules.Parse[rules.ChainedResponsePipeline](rawRule); err != nil {
+		return response, err
+	}
+
+	if response.Filters, err = filtersForRule(rule); err != nil {
+		return response, err
+	}
+
+	if len(rule.Response) == 0 {
+		return response, rules.ErrNoTerminatorDefined
+	}
+
+	response.Response = make(Response, 0, len(rule.Response))
+	for idx := range rule.Response {
+		constructor, ok := knownActions[strings.ToLower(rule.Response[idx].Name)]
+		if !ok {
+			return response, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response[idx].Name)
+		}
+
+		var action Action
+		if action, err = constructor(rule.Response[idx].Params...); err != nil {
+			return response, err
+		}
+		response.Response = append(response.Response, action)
+	}
+
+	return response, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownRequestFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+// CommandFilter matches the command of the message case-insensitive e.g. Command("JOIN")
+func CommandFilter(args ...rules.Param) (RequestFilter, error) {
+	return equalFoldFilter(args, func(req Request) string {
+		return req.Command
+	})
+}
+
+// TargetFilter matches the channel or nick a message is addressed to case-insensitive e.g. Target("#botnet")
+func TargetFilter(args ...rules.Param) (RequestFilter, error) {
+	return equalFoldFilter(args, func(req Request) string {
+		return req.Target
+	})
+}
+
+// NickFilter matches the nick of the client against a regular expression e.g. Nick(`^bot-\d+$`)
+func NickFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return req.Nick
+	})
+}
+
+// TextFilter matches the text of a message against a regular expression e.g. Text(`^!version`)
+func TextFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return req.Text
+	})
+}
+
+// SayAction sends a PRIVMSG e.g. Say("!ddos 192.0.2.1")
+func SayAction(args ...rules.Param) (Action, error) {
+	return textAction("PRIVMSG", args)
+}
+
+// NoticeAction sends a NOTICE e.g. Notice("maintenance in 5 minutes")
+func NoticeAction(args ...rules.Param) (Action, error) {
+	return textAction("NOTICE", args)
+}
+
+// TopicAction sets the topic of the channel, it's ignored for messages not addressed to a channel
+// e.g. Topic("!download http://192.0.2.1/payload.exe")
+func TopicAction(args ...rules.Param) (Action, error) {
+	return textAction("TOPIC", args)
+}
+
+func textAction(command string, args []rules.Param) (action Action, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return action, err
+	}
+
+	action.Command = command
+	action.Text, err = args[0].AsString()
+	return action, err
+}
+
+func equalFoldFilter(args []rules.Param, selector func(req Request) string) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	expected, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return strings.EqualFold(selector(req), expected)
+	}), nil
+}
+
+func regexFilter(args []rules.Param, selector func(req Request) string) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var exp *regexp.Regexp
+	if rawExp, err := args[0].AsString(); err != nil {
+		return nil, err
+	} else if exp, err = regexp.Compile(rawExp); err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return exp.MatchString(selector(req))
+	}), nil
+}
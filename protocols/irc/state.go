@@ -0,0 +1,259 @@
+package irc
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+type channel struct {
+	name    string
+	topic   string
+	members map[*client]struct{}
+}
+
+type channelInfo struct {
+	name    string
+	topic   string
+	members int
+}
+
+// state holds the clients and channels of an endpoint.
+// The nick, user and real name of clients are only modified while holding the lock
+// because they are read by the connections of other clients.
+type state struct {
+	lock         sync.Mutex
+	operatorNick string
+	clients      map[string]*client
+	channels     map[string]*channel
+}
+
+func newState(operatorNick string) *state {
+	return &state{
+		operatorNick: operatorNick,
+		clients:      make(map[string]*client),
+		channels:     make(map[string]*channel),
+	}
+}
+
+// claimNick assigns the nick to the client if it's not used by another client or the operator
+func (s *state) claimNick(c *client, nick string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := strings.ToLower(nick)
+	if other, used := s.clients[key]; (used && other != c) || key == strings.ToLower(s.operatorNick) {
+		return false
+	}
+
+	delete(s.clients, strings.ToLower(c.nick))
+	s.clients[key] = c
+	c.nick = nick
+
+	return true
+}
+
+func (s *state) setUser(c *client, user, realName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c.user = user
+	c.realName = realName
+}
+
+func (s *state) client(nick string) (*client, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c, ok := s.clients[strings.ToLower(nick)]
+	return c, ok
+}
+
+// join adds the client to the channel, the channel is created if it does not exist yet.
+// It returns false if the client already is a member of the channel.
+func (s *state) join(c *client, name string) (members []*client, topic string, joined bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ch, ok := s.channels[strings.ToLower(name)]
+	if !ok {
+		ch = &channel{name: name, members: make(map[*client]struct{})}
+		s.channels[strings.ToLower(name)] = ch
+	}
+
+	if _, member := ch.members[c]; member {
+		return nil, ch.topic, false
+	}
+
+	ch.members[c] = struct{}{}
+	c.channels[strings.ToLower(name)] = ch
+
+	return memberList(ch), ch.topic, true
+}
+
+// part removes the client from the channel and returns the members including the client itself,
+// empty channels are removed
+func (s *state) part(c *client, name string) (members []*client, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ch, member := c.channels[strings.ToLower(name)]
+	if !member {
+		return nil, false
+	}
+
+	members = memberList(ch)
+	s.leave(c, ch)
+
+	return members, true
+}
+
+// remove removes the client from all channels and returns the clients it shared a channel with
+func (s *state) remove(c *client) (peers []*client) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	peers = s.peersLocked(c)
+	for _, ch := range c.channels {
+		s.leave(c, ch)
+	}
+
+	if s.clients[strings.ToLower(c.nick)] == c {
+		delete(s.clients, strings.ToLower(c.nick))
+	}
+
+	return peers
+}
+
+// peers returns all clients sharing at least one channel with the given client
+func (s *state) peers(c *client) []*client {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.peersLocked(c)
+}
+
+func (s *state) peersLocked(c *client) []*client {
+	unique := make(map[*client]struct{})
+	for _, ch := range c.channels {
+		for member := range ch.members {
+			if member != c {
+				unique[member] = struct{}{}
+			}
+		}
+	}
+
+	peers := make([]*client, 0, len(unique))
+	for peer := range unique {
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+func (s *state) members(name string) ([]*client, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ch, ok := s.channels[strings.ToLower(name)]
+	if !ok {
+		return nil, false
+	}
+
+	return memberList(ch), true
+}
+
+func (s *state) setTopic(name, topic string) ([]*client, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ch, ok := s.channels[strings.ToLower(name)]
+	if !ok {
+		return nil, false
+	}
+
+	ch.topic = topic
+	return memberList(ch), true
+}
+
+func (s *state) topic(name string) (topic string, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ch, ok := s.channels[strings.ToLower(name)]
+	if !ok {
+		return "", false
+	}
+
+	return ch.topic, true
+}
+
+// names returns the nicks of all members of the channel, the operator is always listed first
+func (s *state) names(name string) (names []string, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ch, ok := s.channels[strings.ToLower(name)]
+	if !ok {
+		return nil, false
+	}
+
+	names = make([]string, 0, len(ch.members))
+	for member := range ch.members {
+		names = append(names, member.nick)
+	}
+	sort.Strings(names)
+
+	return append([]string{"@" + s.operatorNick}, names...), true
+}
+
+// who returns the identities of all members of the channel or of the client with the given nick
+func (s *state) who(mask string) (identities []identity) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if ch, ok := s.channels[strings.ToLower(mask)]; ok {
+		for member := range ch.members {
+			identities = append(identities, member.identity())
+		}
+	} else if c, ok := s.clients[strings.ToLower(mask)]; ok {
+		identities = append(identities, c.identity())
+	}
+
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i].nick < identities[j].nick
+	})
+
+	return identities
+}
+
+func (s *state) channelInfos() []channelInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	infos := make([]channelInfo, 0, len(s.channels))
+	for _, ch := range s.channels {
+		infos = append(infos, channelInfo{name: ch.name, topic: ch.topic, members: len(ch.members)})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].name < infos[j].name
+	})
+
+	return infos
+}
+
+func (s *state) leave(c *client, ch *channel) {
+	delete(ch.members, c)
+	delete(c.channels, strings.ToLower(ch.name))
+	if len(ch.members) == 0 {
+		delete(s.channels, strings.ToLower(ch.name))
+	}
+}
+
+func memberList(ch *channel) []*client {
+	members := make([]*client, 0, len(ch.members))
+	for member := range ch.members {
+		members = append(members, member)
+	}
+	return members
+}
@@ -0,0 +1,220 @@
+package ldap
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+const (
+	oidStartTLS = "1.3.6.1.4.1.1466.20037"
+	oidWhoAmI   = "1.3.6.1.4.1.4203.1.11.3"
+
+	allUserAttributes = "*"
+)
+
+var (
+	ErrDuplicateEntry = errors.New("duplicate entry")
+	ErrInvalidEntry   = errors.New("invalid entry")
+)
+
+// Entry is an object of the directory, attribute names are case-insensitive
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+type attribute struct {
+	name   string
+	values []string
+}
+
+type entry struct {
+	dn         string
+	parsedDN   *goldap.DN
+	attributes []attribute
+}
+
+func newEntry(dn string, attributes map[string][]string) (*entry, error) {
+	parsed, err := goldap.ParseDN(dn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: DN %q: %v", ErrInvalidEntry, dn, err)
+	}
+
+	e := &entry{
+		dn:       dn,
+		parsedDN: parsed,
+	}
+
+	for _, name := range sortedNames(attributes) {
+		e.setAttribute(name, attributes[name])
+	}
+
+	return e, nil
+}
+
+func (e *entry) values(name string) []string {
+	for idx := range e.attributes {
+		if strings.EqualFold(e.attributes[idx].name, name) {
+			return e.attributes[idx].values
+		}
+	}
+	return nil
+}
+
+// setAttribute replaces the values of an existing attribute with the same name or adds a new attribute
+func (e *entry) setAttribute(name string, values []string) {
+	for idx := range e.attributes {
+		if strings.EqualFold(e.attributes[idx].name, name) {
+			e.attributes[idx].values = values
+			return
+		}
+	}
+	e.attributes = append(e.attributes, attribute{name: name, values: values})
+}
+
+// selectAttributes returns the attributes requested by a search, either all attributes if none or * was requested or
+// the requested attributes the entry has, hence 1.1 selects no attribute at all
+func (e *entry) selectAttributes(requested []string) []attribute {
+	if len(requested) == 0 {
+		return e.attributes
+	}
+
+	selected := make([]attribute, 0, len(requested))
+	for idx := range e.attributes {
+		for _, name := range requested {
+			if name == allUserAttributes {
+				return e.attributes
+			}
+			if strings.EqualFold(e.attributes[idx].name, name) {
+				selected = append(selected, e.attributes[idx])
+				break
+			}
+		}
+	}
+
+	return selected
+}
+
+type directory struct {
+	entries []*entry
+	rootDSE *entry
+}
+
+func newDirectory(entries []Entry, rootDSE map[string][]string, startTLS bool) (*directory, error) {
+	d := new(directory)
+	for idx := range entries {
+		e, err := newEntry(entries[idx].DN, entries[idx].Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(e.parsedDN.RDNs) == 0 {
+			return nil, fmt.Errorf("%w: entries require a DN", ErrInvalidEntry)
+		}
+
+		if d.lookup(e.parsedDN) != nil {
+			return nil, fmt.Errorf("%w %s", ErrDuplicateEntry, e.dn)
+		}
+
+		d.entries = append(d.entries, e)
+	}
+
+	extensions := []string{oidWhoAmI}
+	if startTLS {
+		extensions = append(extensions, oidStartTLS)
+	}
+
+	namingContexts := d.namingContexts()
+	d.rootDSE = &entry{
+		parsedDN: new(goldap.DN),
+		attributes: []attribute{
+			{name: "objectClass", values: []string{"top"}},
+			{name: "namingContexts", values: namingContexts},
+			{name: "supportedLDAPVersion", values: []string{"3"}},
+			{name: "supportedExtension", values: extensions},
+		},
+	}
+
+	if len(namingContexts) > 0 {
+		d.rootDSE.setAttribute("defaultNamingContext", namingContexts[:1])
+	}
+
+	for _, name := range sortedNames(rootDSE) {
+		d.rootDSE.setAttribute(name, rootDSE[name])
+	}
+
+	return d, nil
+}
+
+// namingContexts returns the DNs of all entries without parent entry
+func (d *directory) namingContexts() (contexts []string) {
+	for _, candidate := range d.entries {
+		isRoot := true
+		for _, other := range d.entries {
+			if other.parsedDN.AncestorOfFold(candidate.parsedDN) {
+				isRoot = false
+				break
+			}
+		}
+		if isRoot {
+			contexts = append(contexts, candidate.dn)
+		}
+	}
+	return contexts
+}
+
+func (d *directory) lookup(dn *goldap.DN) *entry {
+	if len(dn.RDNs) == 0 {
+		return d.rootDSE
+	}
+
+	for _, e := range d.entries {
+		if e.parsedDN.EqualFold(dn) {
+			return e
+		}
+	}
+	return nil
+}
+
+// search returns all entries within the scope of the base DN matching the filter,
+// found is false if the base DN doesn't exist
+func (d *directory) search(base *goldap.DN, scope int, filter *ber.Packet) (result []*entry, found bool) {
+	baseEntry := d.lookup(base)
+	if baseEntry == nil {
+		return nil, false
+	}
+
+	if scope == goldap.ScopeBaseObject {
+		if matchesFilter(baseEntry, filter) {
+			result = append(result, baseEntry)
+		}
+		return result, true
+	}
+
+	for _, e := range d.entries {
+		inScope := base.AncestorOfFold(e.parsedDN)
+		if scope == goldap.ScopeSingleLevel {
+			inScope = inScope && len(e.parsedDN.RDNs) == len(base.RDNs)+1
+		}
+
+		if (inScope || (scope == goldap.ScopeWholeSubtree && e == baseEntry)) && matchesFilter(e, filter) {
+			result = append(result, e)
+		}
+	}
+
+	return result, true
+}
+
+func sortedNames(attributes map[string][]string) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,183 @@
+package ldap
+
+import (
+	"strconv"
+	"strings"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// matching rules used by Active Directory clients e.g. to filter disabled accounts via userAccountControl
+	ruleBitAnd = "1.2.840.113556.1.4.803"
+	ruleBitOr  = "1.2.840.113556.1.4.804"
+
+	objectClassAttribute = "objectClass"
+
+	extensibleMatchingRule = 1
+	extensibleType         = 2
+	extensibleMatchValue   = 3
+)
+
+// matchesFilter evaluates a search filter as defined in RFC 4511 against an entry.
+// Values are compared case-insensitively, ordering filters compare numerically if both values are integers.
+func matchesFilter(e *entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matchesFilter(e, child) {
+				return false
+			}
+		}
+		return true
+	case goldap.FilterOr:
+		for _, child := range filter.Children {
+			if matchesFilter(e, child) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterNot:
+		return len(filter.Children) == 1 && !matchesFilter(e, filter.Children[0])
+	case goldap.FilterPresent:
+		attr := packetString(filter)
+		return strings.EqualFold(attr, objectClassAttribute) || len(e.values(attr)) > 0
+	case goldap.FilterEqualityMatch, goldap.FilterApproxMatch:
+		return matchesAssertion(e, filter, strings.EqualFold)
+	case goldap.FilterGreaterOrEqual:
+		return matchesAssertion(e, filter, func(value, asserted string) bool {
+			return compareValues(value, asserted) >= 0
+		})
+	case goldap.FilterLessOrEqual:
+		return matchesAssertion(e, filter, func(value, asserted string) bool {
+			return compareValues(value, asserted) <= 0
+		})
+	case goldap.FilterSubstrings:
+		return matchesSubstrings(e, filter)
+	case goldap.FilterExtensibleMatch:
+		return matchesExtensible(e, filter)
+	default:
+		return false
+	}
+}
+
+func matchesAssertion(e *entry, filter *ber.Packet, matches func(value, asserted string) bool) bool {
+	if len(filter.Children) != 2 {
+		return false
+	}
+
+	return anyValue(e.values(packetString(filter.Children[0])), packetString(filter.Children[1]), matches)
+}
+
+func matchesSubstrings(e *entry, filter *ber.Packet) bool {
+	if len(filter.Children) != 2 {
+		return false
+	}
+
+	substrings := filter.Children[1].Children
+	for _, value := range e.values(packetString(filter.Children[0])) {
+		remaining := strings.ToLower(value)
+		matched := true
+		for _, substring := range substrings {
+			part := strings.ToLower(packetString(substring))
+			switch substring.Tag {
+			case goldap.FilterSubstringsInitial:
+				matched = strings.HasPrefix(remaining, part)
+				remaining = strings.TrimPrefix(remaining, part)
+			case goldap.FilterSubstringsAny:
+				var found bool
+				_, remaining, found = strings.Cut(remaining, part)
+				matched = found
+			case goldap.FilterSubstringsFinal:
+				matched = strings.HasSuffix(remaining, part)
+			}
+			if !matched {
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesExtensible supports the bitwise matching rules of Active Directory,
+// other matching rules are treated as equality match and matching DN attributes is not supported
+func matchesExtensible(e *entry, filter *ber.Packet) bool {
+	var rule, attr, asserted string
+	for _, child := range filter.Children {
+		switch child.Tag {
+		case extensibleMatchingRule:
+			rule = packetString(child)
+		case extensibleType:
+			attr = packetString(child)
+		case extensibleMatchValue:
+			asserted = packetString(child)
+		}
+	}
+
+	switch rule {
+	case ruleBitAnd:
+		return anyValue(e.values(attr), asserted, func(value, asserted string) bool {
+			v, a, ok := parseBitmasks(value, asserted)
+			return ok && v&a == a
+		})
+	case ruleBitOr:
+		return anyValue(e.values(attr), asserted, func(value, asserted string) bool {
+			v, a, ok := parseBitmasks(value, asserted)
+			return ok && v&a != 0
+		})
+	default:
+		return anyValue(e.values(attr), asserted, strings.EqualFold)
+	}
+}
+
+func anyValue(values []string, asserted string, matches func(value, asserted string) bool) bool {
+	for _, value := range values {
+		if matches(value, asserted) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareValues(value, asserted string) int {
+	v, vErr := strconv.ParseInt(value, 10, 64)
+	a, aErr := strconv.ParseInt(asserted, 10, 64)
+	switch {
+	case vErr != nil || aErr != nil:
+		return strings.Compare(strings.ToLower(value), strings.ToLower(asserted))
+	case v < a:
+		return -1
+	case v > a:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func parseBitmasks(value, asserted string) (v, a uint64, ok bool) {
+	var err error
+	if v, err = strconv.ParseUint(value, 10, 64); err != nil {
+		return 0, 0, false
+	}
+	if a, err = strconv.ParseUint(asserted, 10, 64); err != nil {
+		return 0, 0, false
+	}
+	return v, a, true
+}
+
+// packetString returns the string value of a decoded packet,
+// context specific packets aren't decoded by the BER parser hence their raw data is used
+func packetString(packet *ber.Packet) string {
+	if value, ok := packet.Value.(string); ok {
+		return value
+	}
+	if packet.Data != nil {
+		return packet.Data.String()
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"io/fs"
+	"net"
+
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/multiplexing"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const name = "ldap_mock"
+
+var (
+	_ endpoint.MultiplexHandler = (*ldapHandler)(nil)
+	_ endpoint.StoppableHandler = (*ldapHandler)(nil)
+)
+
+type ldapHandler struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	certStore   cert.Store
+	fakeFileFS  fs.FS
+	options     ldapOptions
+	tlsConfig   *tls.Config
+	directory   *directory
+	ruleHandler *RuleHandler
+	conns       endpoint.ConnTracker
+}
+
+func (h *ldapHandler) Matchers() []cmux.Matcher {
+	return []cmux.Matcher{multiplexing.LDAP()}
+}
+
+func (h *ldapHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	ruleHandler := &RuleHandler{
+		HandlerName: startupSpec.Name,
+	}
+
+	for idx := range h.options.Rules {
+		rule := h.options.Rules[idx]
+		if err = ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+	h.ruleHandler = ruleHandler
+
+	if h.certStore != nil {
+		h.tlsConfig = h.certStore.TLSConfig()
+	}
+
+	if h.directory, err = h.loadDirectory(); err != nil {
+		h.logger.Error("Failed to load directory", zap.String("ldif", h.options.LDIF), zap.Error(err))
+		return err
+	}
+
+	h.conns.Open()
+
+	go h.serve(startupSpec.Listener)
+	return nil
+}
+
+// Stop closes all LDAP connections, clients have to bind again after the handler was restarted
+func (h *ldapHandler) Stop(context.Context) error {
+	h.conns.CloseAll()
+	return nil
+}
+
+func (h *ldapHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+// loadDirectory merges the entries of the LDIF file and the configured entries
+func (h *ldapHandler) loadDirectory() (*directory, error) {
+	var entries []Entry
+	if h.options.LDIF != "" {
+		file, err := h.fakeFileFS.Open(h.options.LDIF)
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err = ParseLDIF(file)
+		_ = file.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newDirectory(append(entries, h.options.Entries...), h.options.RootDSE, h.tlsConfig != nil)
+}
+
+func (h *ldapHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept LDAP connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *ldapHandler) handleConn(conn net.Conn) {
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+	}()
+
+	s := newSession(h, conn)
+	if err := endpoint.IgnoreShutdownError(s.serve()); err != nil {
+		h.logger.Debug("LDAP session terminated", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,563 @@
+package ldap_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/ldap"
+)
+
+const directoryLDIF = `version: 1
+
+dn: dc=inetmock,dc=local
+objectClass: top
+objectClass: domain
+dc: inetmock
+
+dn: cn=Users,dc=inetmock,dc=local
+objectClass: top
+objectClass: container
+cn: Users
+
+dn: cn=John Doe,cn=Users,dc=inetmock,dc=local
+objectClass: top
+objectClass: person
+objectClass: user
+cn: John Doe
+sAMAccountName: jdoe
+mail: jdoe@inetmock.local
+userAccountControl: 512
+memberOf: cn=Admins,cn=Users,dc=inetmock,dc=local
+
+dn: cn=Jane Roe,cn=Users,dc=inetmock,dc=local
+objectClass: top
+objectClass: person
+objectClass: user
+cn: Jane Roe
+sAMAccountName: jroe
+description:: ZGlzYWJsZWQgYWNjb3VudA==
+userAccountControl: 514
+`
+
+var fakeFiles = fstest.MapFS{
+	"ldap/directory.ldif": &fstest.MapFile{Data: []byte(directoryLDIF)},
+}
+
+func Test_ldapHandler_Bind(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		rules      []string
+		dn         string
+		password   string
+		wantResult uint16
+		wantEvent  any
+	}{
+		{
+			name:       "Reject bind without rules",
+			dn:         "cn=admin,dc=inetmock,dc=local",
+			password:   "secret",
+			wantResult: goldap.LDAPResultInvalidCredentials,
+			wantEvent: audit.LDAP{
+				Operation:  auditv1.LDAPOperation_LDAP_OPERATION_BIND,
+				DN:         "cn=admin,dc=inetmock,dc=local",
+				Password:   "secret",
+				ResultCode: goldap.LDAPResultInvalidCredentials,
+			},
+		},
+		{
+			name: "Accept bind matching DN and password",
+			rules: []string{
+				"DN(`^cn=admin,`) -> Password(`^secret$`) => Accept()",
+			},
+			dn:         "cn=admin,dc=inetmock,dc=local",
+			password:   "secret",
+			wantResult: goldap.LDAPResultSuccess,
+			wantEvent: audit.LDAP{
+				Operation: auditv1.LDAPOperation_LDAP_OPERATION_BIND,
+				DN:        "cn=admin,dc=inetmock,dc=local",
+				Password:  "secret",
+			},
+		},
+		{
+			name: "Reject bind with diagnostic message",
+			rules: []string{
+				"DN(`^cn=admin,`) -> Password(`^secret$`) => Accept()",
+				`DN("jdoe") => Reject("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 775")`,
+			},
+			dn:         "jdoe@inetmock.local",
+			password:   "secret",
+			wantResult: goldap.LDAPResultInvalidCredentials,
+			wantEvent: td.SStruct(audit.LDAP{
+				Operation:  auditv1.LDAPOperation_LDAP_OPERATION_BIND,
+				DN:         "jdoe@inetmock.local",
+				ResultCode: goldap.LDAPResultInvalidCredentials,
+			}, td.StructFields{
+				"Password": "secret",
+			}),
+		},
+		{
+			name: "Accept any bind",
+			rules: []string{
+				"=> Accept()",
+			},
+			dn:         "cn=John Doe,cn=Users,dc=inetmock,dc=local",
+			password:   "Passw0rd!",
+			wantResult: goldap.LDAPResultSuccess,
+			wantEvent: audit.LDAP{
+				Operation: auditv1.LDAPOperation_LDAP_OPERATION_BIND,
+				DN:        "cn=John Doe,cn=Users,dc=inetmock,dc=local",
+				Password:  "Passw0rd!",
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			emitterMock, client := startHandler(t, nil, map[string]any{
+				"rules": tt.rules,
+			})
+
+			td.Cmp(t, resultCode(client.Bind(tt.dn, tt.password)), tt.wantResult)
+
+			emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+				if td.Cmp(t, calls.Emit(), td.Len(1)) {
+					ev := calls.Emit()[0].Params.Ev
+					td.Cmp(t, ev.Application, auditv1.AppProtocol_APP_PROTOCOL_LDAP)
+					td.Cmp(t, ev.ProtocolDetails, tt.wantEvent)
+				}
+			})
+		})
+	}
+}
+
+func Test_ldapHandler_Search(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		opts       map[string]any
+		bind       bool
+		request    *goldap.SearchRequest
+		wantResult uint16
+		wantDNs    any
+		wantAttrs  map[string][]string
+		wantEvent  any
+	}{
+		{
+			name: "Search users by account name",
+			request: goldap.NewSearchRequest(
+				"dc=inetmock,dc=local", goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+				"(&(objectClass=user)(sAMAccountName=JDOE))", []string{"mail", "memberOf"}, nil,
+			),
+			wantDNs: []string{"cn=John Doe,cn=Users,dc=inetmock,dc=local"},
+			wantAttrs: map[string][]string{
+				"mail":     {"jdoe@inetmock.local"},
+				"memberOf": {"cn=Admins,cn=Users,dc=inetmock,dc=local"},
+			},
+			wantEvent: audit.LDAP{
+				Operation:  auditv1.LDAPOperation_LDAP_OPERATION_SEARCH,
+				DN:         "dc=inetmock,dc=local",
+				Scope:      auditv1.LDAPSearchScope_LDAP_SEARCH_SCOPE_WHOLE_SUBTREE,
+				Filter:     "(&(objectClass=user)(sAMAccountName=JDOE))",
+				Attributes: []string{"mail", "memberOf"},
+				Entries:    1,
+			},
+		},
+		{
+			name: "Search enabled accounts with bitwise filter",
+			request: goldap.NewSearchRequest(
+				"cn=Users,dc=inetmock,dc=local", goldap.ScopeSingleLevel, goldap.NeverDerefAliases, 0, 0, false,
+				"(&(objectClass=user)(!(userAccountControl:1.2.840.113556.1.4.803:=2)))", []string{"1.1"}, nil,
+			),
+			wantDNs: []string{"cn=John Doe,cn=Users,dc=inetmock,dc=local"},
+		},
+		{
+			name: "Search with substring filter",
+			opts: map[string]any{
+				"entries": []map[string]any{
+					{
+						"dn": "cn=svc-backup,cn=Users,dc=inetmock,dc=local",
+						"attributes": map[string]any{
+							"objectClass":    []string{"top", "user"},
+							"sAMAccountName": "svc-backup",
+						},
+					},
+				},
+			},
+			request: goldap.NewSearchRequest(
+				"dc=inetmock,dc=local", goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+				"(|(sAMAccountName=j*e)(sAMAccountName=svc-*))", nil, nil,
+			),
+			wantDNs: []string{
+				"cn=John Doe,cn=Users,dc=inetmock,dc=local",
+				"cn=Jane Roe,cn=Users,dc=inetmock,dc=local",
+				"cn=svc-backup,cn=Users,dc=inetmock,dc=local",
+			},
+		},
+		{
+			name: "Search base object",
+			request: goldap.NewSearchRequest(
+				"cn=Jane Roe,cn=Users,dc=inetmock,dc=local", goldap.ScopeBaseObject, goldap.NeverDerefAliases, 0, 0, false,
+				"(objectClass=*)", []string{"description"}, nil,
+			),
+			wantDNs: []string{"cn=Jane Roe,cn=Users,dc=inetmock,dc=local"},
+			wantAttrs: map[string][]string{
+				"description": {"disabled account"},
+			},
+		},
+		{
+			name: "Search root DSE",
+			opts: map[string]any{
+				"rootDSE": map[string]any{
+					"dnsHostName": "dc01.inetmock.local",
+				},
+			},
+			request: goldap.NewSearchRequest(
+				"", goldap.ScopeBaseObject, goldap.NeverDerefAliases, 0, 0, false,
+				"(objectClass=*)", []string{"defaultNamingContext", "dnsHostName", "supportedExtension"}, nil,
+			),
+			wantDNs: []string{""},
+			wantAttrs: map[string][]string{
+				"defaultNamingContext": {"dc=inetmock,dc=local"},
+				"dnsHostName":          {"dc01.inetmock.local"},
+				"supportedExtension":   {"1.3.6.1.4.1.4203.1.11.3"},
+			},
+		},
+		{
+			name: "Search root DSE without bind although bind is required",
+			opts: map[string]any{
+				"requireBind": true,
+			},
+			request: goldap.NewSearchRequest(
+				"", goldap.ScopeBaseObject, goldap.NeverDerefAliases, 0, 0, false,
+				"(objectClass=*)", []string{"namingContexts"}, nil,
+			),
+			wantDNs: []string{""},
+		},
+		{
+			name: "Search without bind although bind is required",
+			opts: map[string]any{
+				"requireBind": true,
+			},
+			request: goldap.NewSearchRequest(
+				"dc=inetmock,dc=local", goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+				"(objectClass=user)", nil, nil,
+			),
+			wantResult: goldap.LDAPResultOperationsError,
+			wantDNs:    td.Empty(),
+		},
+		{
+			name: "Search after bind if bind is required",
+			opts: map[string]any{
+				"requireBind": true,
+			},
+			bind: true,
+			request: goldap.NewSearchRequest(
+				"dc=inetmock,dc=local", goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+				"(objectClass=user)", nil, nil,
+			),
+			wantDNs: td.Len(2),
+		},
+		{
+			name: "Search with exceeded size limit",
+			request: goldap.NewSearchRequest(
+				"dc=inetmock,dc=local", goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 1, 0, false,
+				"(objectClass=*)", nil, nil,
+			),
+			wantResult: goldap.LDAPResultSizeLimitExceeded,
+			wantDNs:    []string{"dc=inetmock,dc=local"},
+		},
+		{
+			name: "Search unknown base",
+			request: goldap.NewSearchRequest(
+				"dc=example,dc=com", goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+				"(objectClass=*)", nil, nil,
+			),
+			wantResult: goldap.LDAPResultNoSuchObject,
+			wantDNs:    td.Empty(),
+			wantEvent: td.SStruct(audit.LDAP{
+				Operation:  auditv1.LDAPOperation_LDAP_OPERATION_SEARCH,
+				DN:         "dc=example,dc=com",
+				ResultCode: goldap.LDAPResultNoSuchObject,
+			}, td.StructFields{
+				"Scope":  auditv1.LDAPSearchScope_LDAP_SEARCH_SCOPE_WHOLE_SUBTREE,
+				"Filter": "(objectClass=*)",
+			}),
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := map[string]any{
+				"ldif":  "ldap/directory.ldif",
+				"rules": []string{"=> Accept()"},
+			}
+			for k, v := range tt.opts {
+				opts[k] = v
+			}
+
+			emitterMock, client := startHandler(t, nil, opts)
+			if tt.bind {
+				td.CmpNoError(t, client.Bind("cn=John Doe,cn=Users,dc=inetmock,dc=local", "secret"))
+			}
+
+			result, err := client.Search(tt.request)
+			td.Cmp(t, resultCode(err), tt.wantResult)
+
+			var dns []string
+			if result != nil {
+				for _, e := range result.Entries {
+					dns = append(dns, e.DN)
+				}
+			}
+			td.Cmp(t, dns, tt.wantDNs)
+
+			if tt.wantAttrs != nil && len(dns) > 0 {
+				attrs := make(map[string][]string)
+				for _, attr := range result.Entries[0].Attributes {
+					attrs[attr.Name] = attr.Values
+				}
+				td.Cmp(t, attrs, tt.wantAttrs)
+			}
+
+			if tt.wantEvent != nil {
+				emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+					if td.Cmp(t, calls.Emit(), td.Len(1)) {
+						td.Cmp(t, calls.Emit()[0].Params.Ev.ProtocolDetails, tt.wantEvent)
+					}
+				})
+			}
+		})
+	}
+}
+
+func Test_ldapHandler_Compare(t *testing.T) {
+	t.Parallel()
+	_, client := startHandler(t, nil, map[string]any{
+		"ldif": "ldap/directory.ldif",
+	})
+
+	matched, err := client.Compare("cn=John Doe,cn=Users,dc=inetmock,dc=local", "sAMAccountName", "jdoe")
+	td.CmpNoError(t, err)
+	td.CmpTrue(t, matched)
+
+	matched, err = client.Compare("cn=John Doe,cn=Users,dc=inetmock,dc=local", "sAMAccountName", "jroe")
+	td.CmpNoError(t, err)
+	td.CmpFalse(t, matched)
+
+	_, err = client.Compare("cn=unknown,dc=inetmock,dc=local", "cn", "unknown")
+	td.Cmp(t, resultCode(err), uint16(goldap.LDAPResultNoSuchObject))
+}
+
+func Test_ldapHandler_WriteOperations(t *testing.T) {
+	t.Parallel()
+	emitterMock, client := startHandler(t, nil, map[string]any{
+		"ldif": "ldap/directory.ldif",
+	})
+
+	err := client.Del(goldap.NewDelRequest("cn=John Doe,cn=Users,dc=inetmock,dc=local", nil))
+	td.Cmp(t, resultCode(err), uint16(goldap.LDAPResultUnwillingToPerform))
+
+	modify := goldap.NewModifyRequest("cn=Jane Roe,cn=Users,dc=inetmock,dc=local", nil)
+	modify.Replace("userAccountControl", []string{"512"})
+	td.Cmp(t, resultCode(client.Modify(modify)), uint16(goldap.LDAPResultUnwillingToPerform))
+
+	emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+		if td.Cmp(t, calls.Emit(), td.Len(2)) {
+			td.Cmp(t, calls.Emit()[0].Params.Ev.ProtocolDetails, audit.LDAP{
+				Operation:  auditv1.LDAPOperation_LDAP_OPERATION_DELETE,
+				DN:         "cn=John Doe,cn=Users,dc=inetmock,dc=local",
+				ResultCode: goldap.LDAPResultUnwillingToPerform,
+			})
+			td.Cmp(t, calls.Emit()[1].Params.Ev.ProtocolDetails, audit.LDAP{
+				Operation:  auditv1.LDAPOperation_LDAP_OPERATION_MODIFY,
+				DN:         "cn=Jane Roe,cn=Users,dc=inetmock,dc=local",
+				ResultCode: goldap.LDAPResultUnwillingToPerform,
+			})
+		}
+	})
+}
+
+func Test_ldapHandler_StartTLS(t *testing.T) {
+	t.Parallel()
+	emitterMock, client := startHandler(t, test.NewSelfSignedCertStore(t), map[string]any{
+		"rules": []string{"DN(`^cn=admin,`) => Accept()"},
+	})
+
+	//nolint:gosec // the test server uses a self-signed certificate
+	td.CmpNoError(t, client.StartTLS(&tls.Config{InsecureSkipVerify: true, ServerName: "dc01.inetmock.local"}))
+	td.CmpNoError(t, client.Bind("cn=admin,dc=inetmock,dc=local", "secret"))
+
+	authzID, err := client.WhoAmI(nil)
+	td.CmpNoError(t, err)
+	td.Cmp(t, authzID.AuthzID, "dn:cn=admin,dc=inetmock,dc=local")
+
+	emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+		if !td.Cmp(t, calls.Emit(), td.Len(3)) {
+			return
+		}
+
+		startTLS := calls.Emit()[0].Params.Ev
+		td.Cmp(t, startTLS.TLS, td.Nil())
+		td.Cmp(t, startTLS.ProtocolDetails, audit.LDAP{
+			Operation:   auditv1.LDAPOperation_LDAP_OPERATION_EXTENDED,
+			RequestName: "1.3.6.1.4.1.1466.20037",
+		})
+
+		bind := calls.Emit()[1].Params.Ev
+		td.Cmp(t, bind.TLS, td.Struct(&audit.TLSDetails{
+			ServerName: "dc01.inetmock.local",
+		}, td.StructFields{
+			"Version": td.NotZero(),
+		}))
+		td.Cmp(t, bind.ProtocolDetails, td.SStruct(audit.LDAP{}, td.StructFields{
+			"Operation": auditv1.LDAPOperation_LDAP_OPERATION_BIND,
+			"DN":        "cn=admin,dc=inetmock,dc=local",
+			"Password":  "secret",
+		}))
+	})
+}
+
+func Test_ldapHandler_Start(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		opts      map[string]any
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "Start with defaults",
+		},
+		{
+			name: "Start with LDIF and entries",
+			opts: map[string]any{
+				"ldif": "ldap/directory.ldif",
+				"entries": []map[string]any{
+					{
+						"dn": "cn=svc-backup,cn=Users,dc=inetmock,dc=local",
+						"attributes": map[string]any{
+							"sAMAccountName": "svc-backup",
+						},
+					},
+				},
+				"requireBind": true,
+				"idleTimeout": "1m",
+			},
+		},
+		{
+			name: "Error because of unknown LDIF file",
+			opts: map[string]any{
+				"ldif": "ldap/unknown.ldif",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error because of duplicate entry",
+			opts: map[string]any{
+				"ldif": "ldap/directory.ldif",
+				"entries": []map[string]any{
+					{
+						"dn": "DC=inetmock,DC=local",
+					},
+				},
+			},
+			wantErr:   true,
+			wantErrIs: ldap.ErrDuplicateEntry,
+		},
+		{
+			name: "Error because of invalid DN",
+			opts: map[string]any{
+				"entries": []map[string]any{
+					{
+						"dn": "inetmock.local",
+					},
+				},
+			},
+			wantErr:   true,
+			wantErrIs: ldap.ErrInvalidEntry,
+		},
+		{
+			name: "Error because of invalid rule",
+			opts: map[string]any{
+				"rules": []string{"Username(`admin`) => Accept()"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error because of too small max message size",
+			opts: map[string]any{
+				"maxMessageSize": 100,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			handler := ldap.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock), nil, fakeFiles)
+			listener := test.NewTCPListener(t, "127.0.0.1:0")
+			t.Cleanup(func() {
+				_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+				_ = listener.Close()
+			})
+
+			err := handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.opts))
+			td.Cmp(t, err != nil, tt.wantErr)
+			if tt.wantErrIs != nil {
+				td.Cmp(t, errors.Is(err, tt.wantErrIs), true)
+			}
+		})
+	}
+}
+
+func startHandler(t *testing.T, certStore cert.Store, opts map[string]any) (*audit_mock.EmitterMock, *goldap.Conn) {
+	t.Helper()
+	listener := test.NewTCPListener(t, "127.0.0.1:0")
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := ldap.New(logging.CreateTestLogger(t), emitterMock, certStore, fakeFiles)
+
+	if err := handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), opts)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+		_ = listener.Close()
+	})
+
+	client, err := goldap.DialURL("ldap://" + listener.Addr().String())
+	if err != nil {
+		t.Fatalf("goldap.DialURL() error = %v", err)
+	}
+	client.SetTimeout(5 * time.Second)
+
+	t.Cleanup(client.Close)
+
+	return emitterMock, client
+}
+
+func resultCode(err error) uint16 {
+	var ldapErr *goldap.Error
+	if errors.As(err, &ldapErr) {
+		return ldapErr.ResultCode
+	}
+	return goldap.LDAPResultSuccess
+}
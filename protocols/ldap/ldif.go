@@ -0,0 +1,115 @@
+package ldap
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidLDIF = errors.New("invalid LDIF")
+
+// ParseLDIF parses the content records of an LDIF file as defined in RFC 2849.
+// Change records and values referencing URLs are not supported.
+func ParseLDIF(reader io.Reader) (entries []Entry, err error) {
+	var (
+		scanner = bufio.NewScanner(reader)
+		lines   []string
+		lineNo  int
+	)
+
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		entry, err := parseLDIFRecord(lines)
+		if err != nil {
+			return fmt.Errorf("%w: record ending in line %d: %v", ErrInvalidLDIF, lineNo, err)
+		}
+		if entry.DN != "" {
+			entries = append(entries, entry)
+		}
+		lines = lines[:0]
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		switch {
+		case line == "":
+			if err = flush(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, " "):
+			if len(lines) == 0 {
+				return nil, fmt.Errorf("%w: continuation without preceding line in line %d", ErrInvalidLDIF, lineNo)
+			}
+			lines[len(lines)-1] += line[1:]
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err = flush(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// parseLDIFRecord parses a single record, a record only containing the version results in an empty entry
+func parseLDIFRecord(lines []string) (entry Entry, err error) {
+	entry.Attributes = make(map[string][]string)
+	for idx := range lines {
+		attr, value, err := parseLDIFLine(lines[idx])
+		if err != nil {
+			return entry, err
+		}
+
+		switch {
+		case idx == 0 && strings.EqualFold(attr, "version"):
+			if len(lines) > 1 {
+				return entry, errors.New("version has to be followed by an empty line")
+			}
+			return entry, nil
+		case idx == 0 && strings.EqualFold(attr, "dn"):
+			entry.DN = value
+		case idx == 0:
+			return entry, fmt.Errorf("record has to start with dn but started with %s", attr)
+		case strings.EqualFold(attr, "changetype"):
+			return entry, errors.New("change records are not supported")
+		default:
+			entry.Attributes[attr] = append(entry.Attributes[attr], value)
+		}
+	}
+
+	return entry, nil
+}
+
+func parseLDIFLine(line string) (attr, value string, err error) {
+	attr, value, found := strings.Cut(line, ":")
+	if !found || attr == "" {
+		return "", "", fmt.Errorf("missing attribute name in line %q", line)
+	}
+
+	switch {
+	case strings.HasPrefix(value, ":"):
+		var decoded []byte
+		if decoded, err = base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:])); err != nil {
+			return "", "", err
+		}
+		return attr, string(decoded), nil
+	case strings.HasPrefix(value, "<"):
+		return "", "", fmt.Errorf("URL values are not supported for attribute %s", attr)
+	default:
+		return attr, strings.TrimLeft(value, " "), nil
+	}
+}
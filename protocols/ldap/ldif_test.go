@@ -0,0 +1,96 @@
+package ldap_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/protocols/ldap"
+)
+
+func TestParseLDIF(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		ldif    string
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "Entries with comments, folded lines and base64 values",
+			ldif: "version: 1\n" +
+				"\n" +
+				"# the domain\n" +
+				"dn: dc=inetmock,dc=local\r\n" +
+				"objectClass: top\r\n" +
+				"objectClass: domain\r\n" +
+				"\r\n" +
+				"\r\n" +
+				"dn: cn=John Doe,dc=inetmock,dc=lo\n" +
+				" cal\n" +
+				"description:: w4RiZXJ0cmFnZW4=\n" +
+				"mail:jdoe@inetmock.local\n",
+			want: []ldap.Entry{
+				{
+					DN: "dc=inetmock,dc=local",
+					Attributes: map[string][]string{
+						"objectClass": {"top", "domain"},
+					},
+				},
+				{
+					DN: "cn=John Doe,dc=inetmock,dc=local",
+					Attributes: map[string][]string{
+						"description": {"Äbertragen"},
+						"mail":        {"jdoe@inetmock.local"},
+					},
+				},
+			},
+		},
+		{
+			name: "Empty file",
+			ldif: "# nothing to see here\n",
+			want: td.Empty(),
+		},
+		{
+			name:    "Error because of record without DN",
+			ldif:    "cn: John Doe\n",
+			wantErr: true,
+		},
+		{
+			name:    "Error because of change record",
+			ldif:    "dn: cn=John Doe,dc=inetmock,dc=local\nchangetype: delete\n",
+			wantErr: true,
+		},
+		{
+			name:    "Error because of URL value",
+			ldif:    "dn: cn=John Doe,dc=inetmock,dc=local\njpegPhoto:< file:///tmp/photo.jpg\n",
+			wantErr: true,
+		},
+		{
+			name:    "Error because of invalid base64 value",
+			ldif:    "dn: cn=John Doe,dc=inetmock,dc=local\ndescription:: not base64!\n",
+			wantErr: true,
+		},
+		{
+			name:    "Error because of leading continuation line",
+			ldif:    " dn: cn=John Doe,dc=inetmock,dc=local\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ldap.ParseLDIF(strings.NewReader(tt.ldif))
+			if tt.wantErr {
+				td.Cmp(t, errors.Is(err, ldap.ErrInvalidLDIF), true)
+				return
+			}
+
+			td.CmpNoError(t, err)
+			td.Cmp(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package ldap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+const (
+	berSequence        = 0x30
+	berLongFormLength  = 0x80
+	berMaxLengthOctets = 4
+
+	tagAuthSimple            = 0
+	tagAuthSASL              = 3
+	tagExtendedRequestName   = 0
+	tagExtendedResponseName  = 10
+	tagExtendedResponseValue = 11
+)
+
+var (
+	ErrMessageTooLarge    = errors.New("LDAP message too large")
+	ErrMalformedMessage   = errors.New("malformed LDAP message")
+	errUnexpectedEnvelope = fmt.Errorf("%w: message has to be a sequence with definite length", ErrMalformedMessage)
+)
+
+// readMessage reads a single BER encoded LDAP message, the length is checked before the message is read to avoid
+// allocating huge buffers for malicious length values
+func readMessage(reader *bufio.Reader, maxSize int) (*ber.Packet, error) {
+	header, err := reader.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+
+	if header[0] != berSequence || header[1] == berLongFormLength {
+		return nil, errUnexpectedEnvelope
+	}
+
+	headerLength, length := 2, int(header[1])
+	if header[1]&berLongFormLength != 0 {
+		octets := int(header[1] &^ berLongFormLength)
+		if octets > berMaxLengthOctets {
+			return nil, ErrMessageTooLarge
+		}
+
+		headerLength += octets
+		if header, err = reader.Peek(headerLength); err != nil {
+			return nil, err
+		}
+
+		length = 0
+		for _, b := range header[2:] {
+			length = length<<8 | int(b)
+		}
+	}
+
+	if length < 0 || headerLength+length > maxSize {
+		return nil, ErrMessageTooLarge
+	}
+
+	raw := make([]byte, headerLength+length)
+	if _, err = io.ReadFull(reader, raw); err != nil {
+		return nil, err
+	}
+
+	packet, err := ber.DecodePacketErr(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedMessage, err)
+	}
+
+	return packet, nil
+}
+
+func newEnvelope(messageID int64, op *ber.Packet) *ber.Packet {
+	envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+	envelope.AppendChild(op)
+	return envelope
+}
+
+// newResult encodes an LDAPResult e.g. a BindResponse or SearchResultDone
+func newResult(tag ber.Tag, resultCode uint16, diagnosticMessage string) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, diagnosticMessage, "Diagnostic Message"))
+	return op
+}
+
+func newSearchResultEntry(dn string, attributes []attribute, typesOnly bool) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "Object Name"))
+
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for idx := range attributes {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, attributes[idx].name, "Type"))
+
+		values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		if !typesOnly {
+			for _, value := range attributes[idx].values {
+				values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+			}
+		}
+		attr.AppendChild(values)
+		attrs.AppendChild(attr)
+	}
+	op.AppendChild(attrs)
+
+	return op
+}
+
+func newExtendedResponse(resultCode uint16, diagnosticMessage, responseName, responseValue string) *ber.Packet {
+	op := newResult(goldap.ApplicationExtendedResponse, resultCode, diagnosticMessage)
+	if responseName != "" {
+		op.AppendChild(ber.NewString(ber.ClassContext, ber.TypePrimitive, tagExtendedResponseName, responseName, "Response Name"))
+	}
+	if responseValue != "" {
+		op.AppendChild(ber.NewString(ber.ClassContext, ber.TypePrimitive, tagExtendedResponseValue, responseValue, "Response Value"))
+	}
+	return op
+}
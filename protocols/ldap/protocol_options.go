@@ -0,0 +1,58 @@
+package ldap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+const (
+	defaultIdleTimeout    = 5 * time.Minute
+	defaultMaxMessageSize = 1024 * 1024
+	minMaxMessageSize     = 1024
+)
+
+type ldapOptions struct {
+	// LDIF file within the fake files directory the entries of the directory are loaded from
+	LDIF string
+	// Entries are added to the entries loaded from the LDIF file
+	Entries []Entry
+	// RootDSE overrides or extends the attributes of the generated root DSE e.g. dnsHostName
+	RootDSE map[string][]string
+	// RequireBind rejects searches and compares of clients which didn't bind with a DN like Active Directory does
+	RequireBind bool
+	// IdleTimeout closes connections not sending any request within the given time
+	IdleTimeout time.Duration
+	// MaxMessageSize closes connections sending larger requests
+	MaxMessageSize int
+	Rules          []string
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts ldapOptions, err error) {
+	opts = ldapOptions{
+		IdleTimeout:    defaultIdleTimeout,
+		MaxMessageSize: defaultMaxMessageSize,
+	}
+
+	err = startupSpec.UnmarshalOptions(
+		&opts,
+		endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc()),
+		endpoint.WithWeaklyTypedInput,
+	)
+	if err != nil {
+		return opts, err
+	}
+
+	if opts.MaxMessageSize < minMaxMessageSize {
+		return opts, fmt.Errorf("maxMessageSize has to be at least %d but was %d", minMaxMessageSize, opts.MaxMessageSize)
+	}
+
+	if opts.IdleTimeout <= 0 {
+		return opts, fmt.Errorf("idleTimeout has to be positive but was %s", opts.IdleTimeout)
+	}
+
+	return opts, nil
+}
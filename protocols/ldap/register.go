@@ -0,0 +1,31 @@
+package ldap
+
+import (
+	"io/fs"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+func New(logger logging.Logger, emitter audit.Emitter, certStore cert.Store, fakeFileFS fs.FS) endpoint.ProtocolHandler {
+	return &ldapHandler{
+		logger:     logger,
+		emitter:    emitter,
+		certStore:  certStore,
+		fakeFileFS: fakeFileFS,
+	}
+}
+
+func AddLDAPMock(
+	registry endpoint.HandlerRegistry,
+	logger logging.Logger,
+	emitter audit.Emitter,
+	certStore cert.Store,
+	fakeFileFS fs.FS,
+) {
+	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
+		return New(logger, emitter, certStore, fakeFileFS)
+	})
+}
@@ -0,0 +1,199 @@
+package ldap
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+var (
+	knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
+		"dn":        DNFilter,
+		"password":  PasswordFilter,
+		"anonymous": AnonymousFilter,
+	}
+	knownActions = map[string]func(verdict *Verdict, args ...rules.Param) error{
+		"accept": AcceptAction,
+		"reject": RejectAction,
+	}
+)
+
+type (
+	// BindRequest is a simple bind of a client, anonymous binds have neither a DN nor a password
+	BindRequest struct {
+		DN       string
+		Password string
+	}
+	RequestFilter interface {
+		Matches(req BindRequest) bool
+	}
+	RequestFilterFunc func(req BindRequest) bool
+	FilterChain       []RequestFilter
+
+	// Verdict decides whether a bind is accepted,
+	// Message is sent as diagnostic message of rejected binds e.g. to imitate the error codes of Active Directory
+	Verdict struct {
+		Accept  bool
+		Message string
+	}
+
+	ConditionalVerdict struct {
+		Filters FilterChain
+		Verdict Verdict
+	}
+)
+
+func (f RequestFilterFunc) Matches(req BindRequest) bool {
+	return f(req)
+}
+
+func (c FilterChain) Matches(req BindRequest) bool {
+	for idx := range c {
+		if !c[idx].Matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+type RuleHandler struct {
+	HandlerName string
+	verdicts    rules.Set[ConditionalVerdict]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	verdict, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.verdicts.Append(rawRule, verdict)
+	return nil
+}
+
+// RuleManager exposes the bind rules, connections keep the identity of their last successful bind.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalVerdict]{
+		Set:     &h.verdicts,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the verdict of the first rule matching the given bind, binds are rejected unless a rule accepts them
+func (h *RuleHandler) Evaluate(req BindRequest, client string) Verdict {
+	verdicts := h.verdicts.Entries()
+	for idx := range verdicts {
+		entry := verdicts[idx]
+		if entry.Value.Filters.Matches(req) {
+			protocols.RecordRuleMatch(entry, name, h.HandlerName, client)
+			return entry.Value.Verdict
+		}
+	}
+
+	return Verdict{}
+}
+
+func compileRule(rawRule string) (verdict ConditionalVerdict, err error) {
+	var rule *rules.ChainedResponsePipeline
+	if rule, err = rules.Parse[rules.ChainedResponsePipeline](rawRule); err != nil {
+		return verdict, err
+	}
+
+	if verdict.Filters, err = filtersForRule(rule); err != nil {
+		return verdict, err
+	}
+
+	if len(rule.Response) == 0 {
+		return verdict, rules.ErrNoTerminatorDefined
+	}
+
+	for idx := range rule.Response {
+		action, ok := knownActions[strings.ToLower(rule.Response[idx].Name)]
+		if !ok {
+			return verdict, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response[idx].Name)
+		}
+
+		if err = action(&verdict.Verdict, rule.Response[idx].Params...); err != nil {
+			return verdict, err
+		}
+	}
+
+	return verdict, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownRequestFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+// DNFilter matches the bind DN against a regular expression e.g. DN(`(?i)^cn=admin,`)
+func DNFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req BindRequest) string {
+		return req.DN
+	})
+}
+
+// PasswordFilter matches the password against a regular expression e.g. Password(`^(admin|Passw0rd!)$`)
+func PasswordFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req BindRequest) string {
+		return req.Password
+	})
+}
+
+// AnonymousFilter matches anonymous binds without DN and password e.g. Anonymous()
+func AnonymousFilter(...rules.Param) (RequestFilter, error) {
+	return RequestFilterFunc(func(req BindRequest) bool {
+		return req.DN == "" && req.Password == ""
+	}), nil
+}
+
+// AcceptAction accepts a bind e.g. Accept()
+func AcceptAction(verdict *Verdict, _ ...rules.Param) error {
+	verdict.Accept = true
+	return nil
+}
+
+// RejectAction rejects a bind with invalid credentials and an optional diagnostic message,
+// it's the default if no rule matches e.g. Reject("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 775")
+func RejectAction(verdict *Verdict, args ...rules.Param) (err error) {
+	verdict.Accept = false
+	if len(args) > 0 {
+		verdict.Message, err = args[0].AsString()
+	}
+	return err
+}
+
+func regexFilter(args []rules.Param, selector func(req BindRequest) string) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var exp *regexp.Regexp
+	if rawExp, err := args[0].AsString(); err != nil {
+		return nil, err
+	} else if exp, err = regexp.Compile(rawExp); err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req BindRequest) bool {
+		return exp.MatchString(selector(req))
+	}), nil
+}
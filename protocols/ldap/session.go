@@ -0,0 +1,344 @@
+package ldap
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const (
+	// bindRequiredMessage is the diagnostic message Active Directory sends for operations requiring a bind
+	bindRequiredMessage = "000004DC: LdapErr: DSID-0C090A5C, comment: " +
+		"In order to perform this operation a successful bind must be completed on the connection., data 0, v4563"
+	readOnlyMessage = "the directory is read-only"
+)
+
+var writeOperations = map[ber.Tag]auditv1.LDAPOperation{
+	goldap.ApplicationModifyRequest:   auditv1.LDAPOperation_LDAP_OPERATION_MODIFY,
+	goldap.ApplicationAddRequest:      auditv1.LDAPOperation_LDAP_OPERATION_ADD,
+	goldap.ApplicationDelRequest:      auditv1.LDAPOperation_LDAP_OPERATION_DELETE,
+	goldap.ApplicationModifyDNRequest: auditv1.LDAPOperation_LDAP_OPERATION_MODIFY_DN,
+}
+
+type session struct {
+	handler *ldapHandler
+	conn    net.Conn
+	reader  *bufio.Reader
+	client  string
+	boundDN string
+}
+
+func newSession(handler *ldapHandler, conn net.Conn) *session {
+	s := &session{
+		handler: handler,
+		conn:    conn,
+		reader:  bufio.NewReader(conn),
+		client:  conn.RemoteAddr().String(),
+	}
+
+	if ip, _, err := netutils.IPPortFromAddress(conn.RemoteAddr()); err == nil {
+		s.client = ip.String()
+	}
+
+	return s
+}
+
+// serve processes requests until the client unbinds or the connection is closed
+func (s *session) serve() error {
+	for {
+		if err := s.conn.SetReadDeadline(time.Now().Add(s.handler.options.IdleTimeout)); err != nil {
+			return err
+		}
+
+		packet, err := readMessage(s.reader, s.handler.options.MaxMessageSize)
+		if err != nil {
+			return err
+		}
+
+		if len(packet.Children) < 2 || packet.Children[1].ClassType != ber.ClassApplication {
+			return ErrMalformedMessage
+		}
+
+		messageID, ok := packet.Children[0].Value.(int64)
+		if !ok {
+			return ErrMalformedMessage
+		}
+
+		switch op := packet.Children[1]; op.Tag {
+		case goldap.ApplicationUnbindRequest:
+			return nil
+		case goldap.ApplicationAbandonRequest:
+			// requests are processed synchronously hence there's nothing to abandon
+		case goldap.ApplicationBindRequest:
+			err = s.bind(messageID, op)
+		case goldap.ApplicationSearchRequest:
+			err = s.search(messageID, op)
+		case goldap.ApplicationCompareRequest:
+			err = s.compare(messageID, op)
+		case goldap.ApplicationExtendedRequest:
+			err = s.extended(messageID, op)
+		case goldap.ApplicationModifyRequest, goldap.ApplicationAddRequest,
+			goldap.ApplicationDelRequest, goldap.ApplicationModifyDNRequest:
+			err = s.refuseWrite(messageID, op)
+		default:
+			return fmt.Errorf("%w: unsupported operation %d", ErrMalformedMessage, op.Tag)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *session) bind(messageID int64, op *ber.Packet) error {
+	if len(op.Children) < 3 {
+		return ErrMalformedMessage
+	}
+
+	var (
+		auth       = op.Children[2]
+		resultCode uint16
+		message    string
+		details    = audit.LDAP{
+			Operation: auditv1.LDAPOperation_LDAP_OPERATION_BIND,
+			DN:        packetString(op.Children[1]),
+		}
+	)
+
+	// every bind resets the authentication state of the connection
+	s.boundDN = ""
+
+	switch auth.Tag {
+	case tagAuthSimple:
+		details.Password = packetString(auth)
+		verdict := s.handler.ruleHandler.Evaluate(BindRequest{DN: details.DN, Password: details.Password}, s.client)
+		if verdict.Accept {
+			resultCode = goldap.LDAPResultSuccess
+			s.boundDN = details.DN
+		} else {
+			resultCode = goldap.LDAPResultInvalidCredentials
+			message = verdict.Message
+		}
+	case tagAuthSASL:
+		if len(auth.Children) > 0 {
+			details.SASLMechanism = packetString(auth.Children[0])
+		}
+		resultCode = goldap.LDAPResultAuthMethodNotSupported
+	default:
+		resultCode = goldap.LDAPResultAuthMethodNotSupported
+	}
+
+	details.ResultCode = uint32(resultCode)
+	s.emit(details)
+
+	return s.write(messageID, newResult(goldap.ApplicationBindResponse, resultCode, message))
+}
+
+func (s *session) search(messageID int64, op *ber.Packet) error {
+	const searchRequestFields = 8
+	if len(op.Children) < searchRequestFields {
+		return ErrMalformedMessage
+	}
+
+	var (
+		scope, _     = op.Children[1].Value.(int64)
+		sizeLimit, _ = op.Children[3].Value.(int64)
+		typesOnly, _ = op.Children[5].Value.(bool)
+		filter       = op.Children[6]
+		resultCode   uint16
+		message      string
+		details      = audit.LDAP{
+			Operation: auditv1.LDAPOperation_LDAP_OPERATION_SEARCH,
+			DN:        packetString(op.Children[0]),
+			Scope:     auditv1.LDAPSearchScope(scope + 1),
+		}
+	)
+
+	for _, attr := range op.Children[7].Children {
+		details.Attributes = append(details.Attributes, packetString(attr))
+	}
+
+	// an invalid filter is still recorded as far as it could be decompiled
+	details.Filter, _ = goldap.DecompileFilter(filter)
+
+	base, err := goldap.ParseDN(details.DN)
+	switch {
+	case err != nil:
+		resultCode = goldap.LDAPResultInvalidDNSyntax
+	case scope < goldap.ScopeBaseObject || scope > goldap.ScopeWholeSubtree:
+		resultCode = goldap.LDAPResultProtocolError
+		details.Scope = auditv1.LDAPSearchScope_LDAP_SEARCH_SCOPE_UNSPECIFIED
+	case !s.mayRead() && !(len(base.RDNs) == 0 && scope == goldap.ScopeBaseObject):
+		// like Active Directory the root DSE can be read without bind
+		resultCode, message = goldap.LDAPResultOperationsError, bindRequiredMessage
+	default:
+		entries, found := s.handler.directory.search(base, int(scope), filter)
+		if !found {
+			resultCode = goldap.LDAPResultNoSuchObject
+		}
+
+		for idx, e := range entries {
+			if sizeLimit > 0 && int64(idx) >= sizeLimit {
+				resultCode = goldap.LDAPResultSizeLimitExceeded
+				break
+			}
+
+			if err = s.write(messageID, newSearchResultEntry(e.dn, e.selectAttributes(details.Attributes), typesOnly)); err != nil {
+				return err
+			}
+			details.Entries++
+		}
+	}
+
+	details.ResultCode = uint32(resultCode)
+	s.emit(details)
+
+	return s.write(messageID, newResult(goldap.ApplicationSearchResultDone, resultCode, message))
+}
+
+func (s *session) compare(messageID int64, op *ber.Packet) error {
+	if len(op.Children) < 2 || len(op.Children[1].Children) < 2 {
+		return ErrMalformedMessage
+	}
+
+	var (
+		attr       = packetString(op.Children[1].Children[0])
+		value      = packetString(op.Children[1].Children[1])
+		resultCode uint16
+		message    string
+		details    = audit.LDAP{
+			Operation:  auditv1.LDAPOperation_LDAP_OPERATION_COMPARE,
+			DN:         packetString(op.Children[0]),
+			Filter:     fmt.Sprintf("(%s=%s)", attr, goldap.EscapeFilter(value)),
+			Attributes: []string{attr},
+		}
+	)
+
+	dn, err := goldap.ParseDN(details.DN)
+	switch {
+	case err != nil:
+		resultCode = goldap.LDAPResultInvalidDNSyntax
+	case !s.mayRead():
+		resultCode, message = goldap.LDAPResultOperationsError, bindRequiredMessage
+	default:
+		resultCode = goldap.LDAPResultCompareFalse
+		if e := s.handler.directory.lookup(dn); e == nil {
+			resultCode = goldap.LDAPResultNoSuchObject
+		} else if anyValue(e.values(attr), value, strings.EqualFold) {
+			resultCode = goldap.LDAPResultCompareTrue
+		}
+	}
+
+	details.ResultCode = uint32(resultCode)
+	s.emit(details)
+
+	return s.write(messageID, newResult(goldap.ApplicationCompareResponse, resultCode, message))
+}
+
+func (s *session) extended(messageID int64, op *ber.Packet) error {
+	if len(op.Children) < 1 || op.Children[0].Tag != tagExtendedRequestName {
+		return ErrMalformedMessage
+	}
+
+	var (
+		resultCode           uint16
+		message, name, value string
+		startTLS             bool
+		details              = audit.LDAP{
+			Operation:   auditv1.LDAPOperation_LDAP_OPERATION_EXTENDED,
+			DN:          s.boundDN,
+			RequestName: packetString(op.Children[0]),
+		}
+	)
+
+	switch details.RequestName {
+	case oidStartTLS:
+		name = oidStartTLS
+		if _, isTLS := audit.ConnTLSState(s.conn); isTLS {
+			resultCode, message = goldap.LDAPResultOperationsError, "TLS already established"
+		} else if s.handler.tlsConfig == nil {
+			resultCode, message = goldap.LDAPResultUnavailable, "TLS not available"
+		} else {
+			startTLS = true
+		}
+	case oidWhoAmI:
+		if s.boundDN != "" {
+			value = "dn:" + s.boundDN
+		}
+	default:
+		resultCode, message = goldap.LDAPResultProtocolError, "unsupported extended operation"
+	}
+
+	details.ResultCode = uint32(resultCode)
+	s.emit(details)
+
+	if err := s.write(messageID, newExtendedResponse(resultCode, message, name, value)); err != nil || !startTLS {
+		return err
+	}
+
+	tlsConn := tls.Server(s.conn, s.handler.tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		return err
+	}
+
+	s.conn = tlsConn
+	s.reader = bufio.NewReader(tlsConn)
+
+	return nil
+}
+
+// refuseWrite rejects all operations modifying the directory
+func (s *session) refuseWrite(messageID int64, op *ber.Packet) error {
+	details := audit.LDAP{
+		Operation:  writeOperations[op.Tag],
+		ResultCode: goldap.LDAPResultUnwillingToPerform,
+	}
+
+	// the DN of delete requests is the content of the operation itself
+	if len(op.Children) > 0 {
+		details.DN = packetString(op.Children[0])
+	} else {
+		details.DN = packetString(op)
+	}
+
+	s.emit(details)
+
+	// the tag of the response is always the one of the request incremented by one
+	return s.write(messageID, newResult(op.Tag+1, goldap.LDAPResultUnwillingToPerform, readOnlyMessage))
+}
+
+func (s *session) mayRead() bool {
+	return !s.handler.options.RequireBind || s.boundDN != ""
+}
+
+func (s *session) write(messageID int64, op *ber.Packet) error {
+	_, err := s.conn.Write(newEnvelope(messageID, op).Bytes())
+	return err
+}
+
+func (s *session) emit(details audit.LDAP) {
+	builder := s.handler.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_LDAP).
+		WithProtocolDetails(details)
+
+	if details, ok := audit.NewTLSDetailsFromConn(s.conn); ok {
+		builder = builder.WithTLSDetails(details)
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(s.conn.RemoteAddr())
+	builder, _ = builder.WithDestinationFromAddr(s.conn.LocalAddr())
+
+	builder.Emit()
+}
@@ -0,0 +1,20 @@
+package mail
+
+import (
+	"fmt"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+// ErrorLogger forwards internal errors of mail servers like SMTP or IMAP to the handler logger
+type ErrorLogger struct {
+	logging.Logger
+}
+
+func (l ErrorLogger) Printf(format string, v ...any) {
+	l.Warn(fmt.Sprintf(format, v...))
+}
+
+func (l ErrorLogger) Println(v ...any) {
+	l.Warn(fmt.Sprint(v...))
+}
@@ -0,0 +1,184 @@
+package imap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/server"
+	"github.com/emersion/go-sasl"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+var (
+	_ server.Extension  = (*auditExtension)(nil)
+	_ server.UidHandler = (*auditingUIDHandler)(nil)
+	_ server.Upgrader   = (*auditingUpgrader)(nil)
+)
+
+// auditExtension overrides the builtin commands to emit an audit event for every command a client sends.
+// The builtin handlers are taken from a reference server because the server itself would only return the
+// overridden ones.
+type auditExtension struct {
+	emitter audit.Emitter
+	builtin *server.Server
+}
+
+func (e *auditExtension) Capabilities(server.Conn) []string {
+	return nil
+}
+
+func (e *auditExtension) Command(name string) server.HandlerFactory {
+	switch name {
+	// UID only delegates to the actual command which is audited itself.
+	// The server ignores extensions overriding UNSELECT, MOVE or IDLE hence they cannot be audited.
+	// Authentication is audited by the SASL server to capture the credentials.
+	case "UID", "UNSELECT", "MOVE", "IDLE", "AUTHENTICATE":
+		return nil
+	}
+
+	factory := e.builtin.Command(name)
+	if factory == nil {
+		return nil
+	}
+
+	return func() server.Handler {
+		hdlr := &auditingHandler{
+			name:    name,
+			inner:   factory(),
+			auditor: e,
+		}
+
+		switch inner := hdlr.inner.(type) {
+		case server.UidHandler:
+			return &auditingUIDHandler{auditingHandler: hdlr, inner: inner}
+		case server.Upgrader:
+			return &auditingUpgrader{auditingHandler: hdlr, inner: inner}
+		default:
+			return hdlr
+		}
+	}
+}
+
+// plainAuth replaces the builtin PLAIN mechanism to record the credentials clients send
+func (e *auditExtension) plainAuth(be *backend) (string, server.SASLServerFactory) {
+	return sasl.Plain, func(conn server.Conn) sasl.Server {
+		return sasl.NewPlainServer(func(_, username, password string) error {
+			e.emit(conn, audit.Mailbox{
+				Command:   "AUTHENTICATE",
+				Arguments: []string{sasl.Plain},
+				User:      username,
+				Password:  password,
+			})
+
+			user, err := be.Login(conn.Info(), username, password)
+			if err != nil {
+				return err
+			}
+
+			ctx := conn.Context()
+			ctx.State = imap.AuthenticatedState
+			ctx.User = user
+			return nil
+		})
+	}
+}
+
+func (e *auditExtension) emit(conn server.Conn, details audit.Mailbox) {
+	ctx := conn.Context()
+	if details.User == "" && ctx.User != nil {
+		details.User = ctx.User.Username()
+	}
+
+	if ctx.Mailbox != nil {
+		details.Mailbox = ctx.Mailbox.Name()
+	}
+
+	info := conn.Info()
+
+	builder := e.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_IMAP).
+		WithProtocolDetails(details)
+
+	if info.TLS != nil && info.TLS.HandshakeComplete {
+		builder = builder.WithTLSDetails(audit.NewTLSDetailsFromState(*info.TLS))
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(info.RemoteAddr)
+	builder, _ = builder.WithDestinationFromAddr(info.LocalAddr)
+
+	builder.Emit()
+}
+
+type auditingHandler struct {
+	name    string
+	inner   server.Handler
+	auditor *auditExtension
+	details audit.Mailbox
+}
+
+func (h *auditingHandler) Parse(fields []any) error {
+	if login, ok := h.inner.(*server.Login); ok {
+		err := login.Parse(fields)
+		h.details = audit.Mailbox{Command: h.name, User: login.Username, Password: login.Password}
+		return err
+	}
+
+	h.details = audit.Mailbox{Command: h.name, Arguments: formatArguments(fields)}
+	return h.inner.Parse(fields)
+}
+
+func (h *auditingHandler) Handle(conn server.Conn) error {
+	h.auditor.emit(conn, h.details)
+	return h.inner.Handle(conn)
+}
+
+type auditingUIDHandler struct {
+	*auditingHandler
+	inner server.UidHandler
+}
+
+func (h *auditingUIDHandler) UidHandle(conn server.Conn) error {
+	details := h.details
+	details.Command = "UID " + details.Command
+	h.auditor.emit(conn, details)
+	return h.inner.UidHandle(conn)
+}
+
+type auditingUpgrader struct {
+	*auditingHandler
+	inner server.Upgrader
+}
+
+func (h *auditingUpgrader) Upgrade(conn server.Conn) error {
+	return h.inner.Upgrade(conn)
+}
+
+func formatArguments(fields []any) []string {
+	args := make([]string, 0, len(fields))
+	for _, field := range fields {
+		args = append(args, formatArgument(field))
+	}
+	return args
+}
+
+func formatArgument(field any) string {
+	switch f := field.(type) {
+	case nil:
+		return "NIL"
+	case string:
+		return f
+	case imap.RawString:
+		return string(f)
+	case []any:
+		return "(" + strings.Join(formatArguments(f), " ") + ")"
+	case imap.Literal:
+		return fmt.Sprintf("{%d}", f.Len())
+	default:
+		return fmt.Sprint(f)
+	}
+}
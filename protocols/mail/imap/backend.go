@@ -0,0 +1,341 @@
+package imap
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emersion/go-imap"
+	imapbackend "github.com/emersion/go-imap/backend"
+	"github.com/emersion/go-imap/backend/backendutil"
+	gomessage "github.com/emersion/go-message"
+	"github.com/emersion/go-message/textproto"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+)
+
+const (
+	inboxName      = "INBOX"
+	delimiter      = "/"
+	uidValidity    = 1
+	allowAnyFlag   = "\\*"
+	defaultMsgFlag = imap.RecentFlag
+)
+
+var (
+	_ imapbackend.Backend = (*backend)(nil)
+	_ imapbackend.User    = (*user)(nil)
+	_ imapbackend.Mailbox = (*mailbox)(nil)
+
+	errStaticHierarchy = errors.New("mailboxes cannot be created, deleted or renamed")
+)
+
+type backend struct {
+	logger logging.Logger
+	source mail.MessageSource
+}
+
+// Login accepts any credentials and returns a user with a snapshot of the configured messages in its INBOX
+func (b *backend) Login(_ *imap.ConnInfo, username, _ string) (imapbackend.User, error) {
+	messages, err := b.source()
+	if err != nil {
+		b.logger.Error("Failed to load mailbox messages", zap.Error(err))
+		return nil, err
+	}
+
+	inbox := &mailbox{
+		messages: make([]*message, 0, len(messages)),
+	}
+
+	for idx := range messages {
+		inbox.messages = append(inbox.messages, &message{
+			uid:   uint32(idx + 1),
+			date:  messages[idx].Date,
+			flags: []string{defaultMsgFlag},
+			body:  messages[idx].Body,
+		})
+	}
+
+	return &user{name: username, inbox: inbox}, nil
+}
+
+// user has only a single INBOX, all modifications are kept for the current session only
+type user struct {
+	name  string
+	inbox *mailbox
+}
+
+func (u *user) Username() string {
+	return u.name
+}
+
+func (u *user) ListMailboxes(bool) ([]imapbackend.Mailbox, error) {
+	return []imapbackend.Mailbox{u.inbox}, nil
+}
+
+func (u *user) GetMailbox(name string) (imapbackend.Mailbox, error) {
+	if !strings.EqualFold(name, inboxName) {
+		return nil, imapbackend.ErrNoSuchMailbox
+	}
+	return u.inbox, nil
+}
+
+func (u *user) CreateMailbox(string) error {
+	return errStaticHierarchy
+}
+
+func (u *user) DeleteMailbox(string) error {
+	return errStaticHierarchy
+}
+
+func (u *user) RenameMailbox(string, string) error {
+	return errStaticHierarchy
+}
+
+func (u *user) Logout() error {
+	return nil
+}
+
+type mailbox struct {
+	lock     sync.Mutex
+	messages []*message
+}
+
+func (m *mailbox) Name() string {
+	return inboxName
+}
+
+func (m *mailbox) Info() (*imap.MailboxInfo, error) {
+	return &imap.MailboxInfo{
+		Delimiter: delimiter,
+		Name:      inboxName,
+	}, nil
+}
+
+func (m *mailbox) Status(items []imap.StatusItem) (*imap.MailboxStatus, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	status := imap.NewMailboxStatus(inboxName, items)
+	status.Flags = []string{imap.SeenFlag, imap.AnsweredFlag, imap.FlaggedFlag, imap.DeletedFlag, imap.DraftFlag}
+	status.PermanentFlags = []string{allowAnyFlag}
+
+	for idx, msg := range m.messages {
+		if !msg.hasFlag(imap.SeenFlag) {
+			status.Unseen++
+			if status.UnseenSeqNum == 0 {
+				status.UnseenSeqNum = uint32(idx + 1)
+			}
+		}
+		if msg.hasFlag(imap.RecentFlag) {
+			status.Recent++
+		}
+	}
+
+	status.Messages = uint32(len(m.messages))
+	status.UidNext = m.uidNext()
+	status.UidValidity = uidValidity
+
+	return status, nil
+}
+
+func (m *mailbox) SetSubscribed(bool) error {
+	return nil
+}
+
+func (m *mailbox) Check() error {
+	return nil
+}
+
+func (m *mailbox) ListMessages(uid bool, seqSet *imap.SeqSet, items []imap.FetchItem, ch chan<- *imap.Message) error {
+	defer close(ch)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for idx, msg := range m.messages {
+		seqNum := uint32(idx + 1)
+		if !seqSet.Contains(msg.id(uid, seqNum)) {
+			continue
+		}
+
+		if fetched, err := msg.fetch(seqNum, items); err == nil {
+			ch <- fetched
+		}
+	}
+
+	return nil
+}
+
+func (m *mailbox) SearchMessages(uid bool, criteria *imap.SearchCriteria) (ids []uint32, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for idx, msg := range m.messages {
+		seqNum := uint32(idx + 1)
+		if ok, err := msg.match(seqNum, criteria); err == nil && ok {
+			ids = append(ids, msg.id(uid, seqNum))
+		}
+	}
+
+	return ids, nil
+}
+
+func (m *mailbox) CreateMessage(flags []string, date time.Time, body imap.Literal) error {
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.messages = append(m.messages, &message{
+		uid:   m.uidNext(),
+		date:  date,
+		flags: flags,
+		body:  data,
+	})
+
+	return nil
+}
+
+func (m *mailbox) UpdateMessagesFlags(uid bool, seqSet *imap.SeqSet, op imap.FlagsOp, flags []string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for idx, msg := range m.messages {
+		if seqSet.Contains(msg.id(uid, uint32(idx+1))) {
+			msg.flags = backendutil.UpdateFlags(msg.flags, op, flags)
+		}
+	}
+
+	return nil
+}
+
+func (m *mailbox) CopyMessages(uid bool, seqSet *imap.SeqSet, destName string) error {
+	if !strings.EqualFold(destName, inboxName) {
+		return imapbackend.ErrNoSuchMailbox
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for idx, msg := range m.messages {
+		if seqSet.Contains(msg.id(uid, uint32(idx+1))) {
+			msgCopy := *msg
+			msgCopy.uid = m.uidNext()
+			m.messages = append(m.messages, &msgCopy)
+		}
+	}
+
+	return nil
+}
+
+func (m *mailbox) Expunge() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	kept := m.messages[:0]
+	for _, msg := range m.messages {
+		if !msg.hasFlag(imap.DeletedFlag) {
+			kept = append(kept, msg)
+		}
+	}
+	m.messages = kept
+
+	return nil
+}
+
+func (m *mailbox) uidNext() uint32 {
+	var uid uint32
+	for _, msg := range m.messages {
+		if msg.uid > uid {
+			uid = msg.uid
+		}
+	}
+	return uid + 1
+}
+
+type message struct {
+	uid   uint32
+	date  time.Time
+	flags []string
+	body  []byte
+}
+
+func (m *message) id(uid bool, seqNum uint32) uint32 {
+	if uid {
+		return m.uid
+	}
+	return seqNum
+}
+
+func (m *message) hasFlag(flag string) bool {
+	for _, f := range m.flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *message) headerAndBody() (textproto.Header, io.Reader, error) {
+	body := bufio.NewReader(bytes.NewReader(m.body))
+	hdr, err := textproto.ReadHeader(body)
+	return hdr, body, err
+}
+
+func (m *message) fetch(seqNum uint32, items []imap.FetchItem) (*imap.Message, error) {
+	fetched := imap.NewMessage(seqNum, items)
+	for _, item := range items {
+		switch item {
+		case imap.FetchEnvelope:
+			hdr, _, _ := m.headerAndBody()
+			fetched.Envelope, _ = backendutil.FetchEnvelope(hdr)
+		case imap.FetchBody, imap.FetchBodyStructure:
+			hdr, body, _ := m.headerAndBody()
+			fetched.BodyStructure, _ = backendutil.FetchBodyStructure(hdr, body, item == imap.FetchBodyStructure)
+		case imap.FetchFlags:
+			fetched.Flags = m.flags
+		case imap.FetchInternalDate:
+			fetched.InternalDate = m.date
+		case imap.FetchRFC822Size:
+			fetched.Size = uint32(len(m.body))
+		case imap.FetchUid:
+			fetched.Uid = m.uid
+		default:
+			section, err := imap.ParseBodySectionName(item)
+			if err != nil {
+				continue
+			}
+
+			hdr, body, err := m.headerAndBody()
+			if err != nil {
+				return nil, err
+			}
+
+			fetched.Body[section], _ = backendutil.FetchBodySection(hdr, body, section)
+		}
+	}
+
+	return fetched, nil
+}
+
+func (m *message) match(seqNum uint32, criteria *imap.SearchCriteria) (bool, error) {
+	entity, err := gomessage.Read(bytes.NewReader(m.body))
+	if err != nil && entity == nil {
+		return false, err
+	}
+	return backendutil.Match(entity, seqNum, m.uid, m.date, m.flags, criteria)
+}
@@ -0,0 +1,88 @@
+package imap
+
+import (
+	"context"
+	"io/fs"
+	"net"
+
+	"github.com/emersion/go-imap/server"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+)
+
+const name = "imap_mock"
+
+type imapHandler struct {
+	logger     logging.Logger
+	emitter    audit.Emitter
+	certStore  cert.Store
+	fakeFileFS fs.FS
+	mailDir    string
+	server     *server.Server
+}
+
+func (h *imapHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) error {
+	options, err := loadFromConfig(startupSpec)
+	if err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	var source mail.MessageSource
+	if source, err = mail.NewMessageSource(options.MailboxOptions, h.fakeFileFS, h.mailDir); err != nil {
+		h.logger.Error("Failed to load mailbox messages", zap.Error(err))
+		return err
+	}
+
+	be := &backend{
+		logger: h.logger,
+		source: source,
+	}
+
+	auditor := &auditExtension{
+		emitter: h.emitter,
+		builtin: server.New(be),
+	}
+
+	h.server = server.New(be)
+	h.server.AllowInsecureAuth = true
+	h.server.ErrorLog = mail.ErrorLogger{Logger: h.logger}
+	h.server.Enable(auditor)
+	h.server.EnableAuth(auditor.plainAuth(be))
+
+	if h.certStore != nil {
+		h.server.TLSConfig = h.certStore.TLSConfig()
+	}
+
+	go h.startServer(startupSpec.Listener)
+	return nil
+}
+
+// Stop closes all open connections, the listener itself is closed by the endpoint
+func (h *imapHandler) Stop(context.Context) error {
+	if h.server == nil {
+		return nil
+	}
+
+	h.server.ForEachConn(func(conn server.Conn) {
+		_ = conn.Close()
+	})
+
+	return nil
+}
+
+func (h *imapHandler) startServer(listener net.Listener) {
+	if err := endpoint.IgnoreShutdownError(h.server.Serve(listener)); err != nil {
+		h.logger.Error("Failed to start IMAP listener", zap.Error(err))
+	}
+}
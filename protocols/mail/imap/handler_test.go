@@ -0,0 +1,246 @@
+package imap_test
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"testing"
+	"testing/fstest"
+
+	goimap "github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail/imap"
+)
+
+const fakeMessage = "From: admin@example.com\r\n" +
+	"To: ted@example.com\r\n" +
+	"Subject: Welcome\r\n" +
+	"\r\n" +
+	"Hello Ted\r\n"
+
+func Test_imapHandler_Start(t *testing.T) {
+	t.Parallel()
+	fakeFiles := fstest.MapFS{
+		"welcome.eml": &fstest.MapFile{Data: []byte(fakeMessage)},
+	}
+
+	tests := []struct {
+		name         string
+		opts         map[string]any
+		session      func(tb testing.TB, c *client.Client)
+		wantStartErr bool
+		wantEvents   any
+	}{
+		{
+			name: "Login and fetch fake message",
+			opts: map[string]any{
+				"messages": []string{"welcome.eml"},
+			},
+			session: func(tb testing.TB, c *client.Client) {
+				tb.Helper()
+				td.CmpNoError(tb, c.Login("ted", "secret"))
+
+				status, err := c.Select("INBOX", false)
+				td.CmpNoError(tb, err)
+				td.Cmp(tb, status.Messages, uint32(1))
+
+				seqSet := new(goimap.SeqSet)
+				seqSet.AddNum(1)
+				section := new(goimap.BodySectionName)
+				messages := make(chan *goimap.Message, 1)
+				td.CmpNoError(tb, c.Fetch(seqSet, []goimap.FetchItem{goimap.FetchEnvelope, section.FetchItem()}, messages))
+
+				msg := <-messages
+				if td.CmpNotNil(tb, msg) {
+					td.Cmp(tb, msg.Envelope.Subject, "Welcome")
+					body, err := io.ReadAll(msg.GetBody(section))
+					td.CmpNoError(tb, err)
+					td.Cmp(tb, string(body), fakeMessage)
+				}
+			},
+			wantEvents: td.All(
+				td.Contains(td.Struct(new(audit.Event), td.StructFields{
+					"Application": auditv1.AppProtocol_APP_PROTOCOL_IMAP,
+					"Transport":   auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP,
+					"ProtocolDetails": td.Struct(audit.Mailbox{
+						Command:  "LOGIN",
+						User:     "ted",
+						Password: "secret",
+					}, nil),
+				})),
+				td.Contains(td.Struct(new(audit.Event), td.StructFields{
+					"ProtocolDetails": td.Struct(audit.Mailbox{
+						Command:   "SELECT",
+						Arguments: []string{"INBOX"},
+						User:      "ted",
+					}, nil),
+				})),
+				td.Contains(td.Struct(new(audit.Event), td.StructFields{
+					"ProtocolDetails": td.Struct(audit.Mailbox{
+						Command: "FETCH",
+						User:    "ted",
+						Mailbox: "INBOX",
+					}, td.StructFields{
+						"Arguments": td.Len(2),
+					}),
+				})),
+			),
+		},
+		{
+			name: "UID commands are audited",
+			opts: map[string]any{
+				"messages": []string{"welcome.eml"},
+			},
+			session: func(tb testing.TB, c *client.Client) {
+				tb.Helper()
+				td.CmpNoError(tb, c.Login("ted", "secret"))
+				_, err := c.Select("INBOX", true)
+				td.CmpNoError(tb, err)
+
+				criteria := goimap.NewSearchCriteria()
+				criteria.Header.Add("Subject", "Welcome")
+				uids, err := c.UidSearch(criteria)
+				td.CmpNoError(tb, err)
+				td.Cmp(tb, uids, []uint32{1})
+			},
+			wantEvents: td.Contains(td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(audit.Mailbox{
+					Command: "UID SEARCH",
+					Mailbox: "INBOX",
+					User:    "ted",
+				}, nil),
+			})),
+		},
+		{
+			name: "Only INBOX is available",
+			session: func(tb testing.TB, c *client.Client) {
+				tb.Helper()
+				td.CmpNoError(tb, c.Login("ted", "secret"))
+				td.CmpError(tb, c.Create("Archive"))
+
+				_, err := c.Select("Archive", false)
+				td.CmpError(tb, err)
+
+				mailboxes := make(chan *goimap.MailboxInfo, 10)
+				td.CmpNoError(tb, c.List("", "*", mailboxes))
+				td.Cmp(tb, (<-mailboxes).Name, "INBOX")
+			},
+			wantEvents: td.Len(4),
+		},
+		{
+			name: "Error because of missing fake message",
+			opts: map[string]any{
+				"messages": []string{"missing.eml"},
+			},
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			listener := test.NewTCPListener(t, "127.0.0.1:0")
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := imap.New(logging.CreateTestLogger(t), emitterMock, nil, fakeFiles, t.TempDir())
+			startupSpec := endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.opts)
+
+			if err := handler.Start(ctx, startupSpec); err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			t.Cleanup(func() {
+				_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+				_ = listener.Close()
+			})
+
+			c, err := client.Dial(listener.Addr().String())
+			if err != nil {
+				t.Fatalf("client.Dial() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = c.Close()
+			})
+
+			tt.session(t, c)
+
+			emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+				events := make([]*audit.Event, 0, len(calls.Emit()))
+				for _, call := range calls.Emit() {
+					events = append(events, call.Params.Ev)
+				}
+				td.Cmp(t, events, tt.wantEvents)
+			})
+		})
+	}
+}
+
+func Test_imapHandler_StartTLS(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(test.Context(t))
+	t.Cleanup(cancel)
+
+	listener := test.NewTCPListener(t, "127.0.0.1:0")
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := imap.New(logging.CreateTestLogger(t), emitterMock, test.NewSelfSignedCertStore(t), fstest.MapFS{}, t.TempDir())
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+		_ = listener.Close()
+	})
+
+	c, err := client.Dial(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("client.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+
+	if ok, _ := c.SupportStartTLS(); !ok {
+		t.Fatal("STARTTLS not advertised")
+	}
+
+	//nolint:gosec // the test server uses a self-signed certificate
+	if err = c.StartTLS(&tls.Config{InsecureSkipVerify: true, ServerName: "mail.example.com"}); err != nil {
+		t.Fatalf("c.StartTLS() error = %v", err)
+	}
+
+	td.CmpNoError(t, c.Login("ted", "secret"))
+
+	emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+		var loginEvent *audit.Event
+		for _, call := range calls.Emit() {
+			if details, ok := call.Params.Ev.ProtocolDetails.(audit.Mailbox); ok && details.Command == "LOGIN" {
+				loginEvent = call.Params.Ev
+			}
+		}
+
+		if td.CmpNotNil(t, loginEvent) {
+			td.Cmp(t, loginEvent.TLS, td.Struct(&audit.TLSDetails{
+				ServerName: "mail.example.com",
+			}, td.StructFields{
+				"Version": td.NotZero(),
+			}))
+		}
+	})
+}
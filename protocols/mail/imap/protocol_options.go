@@ -0,0 +1,15 @@
+package imap
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+)
+
+type imapOptions struct {
+	mail.MailboxOptions `mapstructure:",squash"`
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts imapOptions, err error) {
+	err = startupSpec.UnmarshalOptions(&opts)
+	return opts, err
+}
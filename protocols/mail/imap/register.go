@@ -0,0 +1,33 @@
+package imap
+
+import (
+	"io/fs"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+func New(logger logging.Logger, emitter audit.Emitter, certStore cert.Store, fakeFileFS fs.FS, mailDir string) endpoint.ProtocolHandler {
+	return &imapHandler{
+		logger:     logger,
+		emitter:    emitter,
+		certStore:  certStore,
+		fakeFileFS: fakeFileFS,
+		mailDir:    mailDir,
+	}
+}
+
+func AddIMAPMock(
+	registry endpoint.HandlerRegistry,
+	logger logging.Logger,
+	emitter audit.Emitter,
+	certStore cert.Store,
+	fakeFileFS fs.FS,
+	mailDir string,
+) {
+	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
+		return New(logger, emitter, certStore, fakeFileFS, mailDir)
+	})
+}
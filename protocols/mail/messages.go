@@ -0,0 +1,154 @@
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	netmail "net/mail"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type (
+	// Message is a mail served by mailbox mocks like POP3 or IMAP.
+	// Name is unique within a mailbox and stable across sessions.
+	Message struct {
+		Name string
+		Date time.Time
+		Body []byte
+	}
+
+	// MailboxOptions configure which messages are served by mailbox mocks
+	MailboxOptions struct {
+		// Messages are paths of .eml files within the fake files directory
+		Messages []string
+		// IncludeReceived adds all mails stored in ReceivedDir e.g. by an SMTP mock
+		IncludeReceived bool
+		// ReceivedDir is resolved within the mail data directory if it is not absolute
+		ReceivedDir string
+	}
+
+	// MessageSource returns the messages a mailbox contains when a client logs in
+	MessageSource func() ([]Message, error)
+)
+
+// ResolveDataDir resolves dir within the mail data directory if it is not absolute.
+// An empty dir resolves to the mail data directory itself.
+func ResolveDataDir(mailDir, dir string) string {
+	switch {
+	case dir == "":
+		return mailDir
+	case filepath.IsAbs(dir):
+		return dir
+	default:
+		return filepath.Join(mailDir, dir)
+	}
+}
+
+// NewMessageSource loads the configured fake messages once and - if enabled - adds the received mails
+// currently stored in the configured directory whenever the source is called.
+func NewMessageSource(opts MailboxOptions, fakeFileFS fs.FS, mailDir string) (MessageSource, error) {
+	fixed, err := LoadMessages(fakeFileFS, opts.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.IncludeReceived {
+		return func() ([]Message, error) {
+			return fixed, nil
+		}, nil
+	}
+
+	store := DirStore{Dir: ResolveDataDir(mailDir, opts.ReceivedDir)}
+	return func() ([]Message, error) {
+		received, err := store.Messages()
+		if err != nil {
+			return nil, err
+		}
+
+		messages := make([]Message, 0, len(fixed)+len(received))
+		messages = append(messages, fixed...)
+		return append(messages, received...), nil
+	}, nil
+}
+
+// LoadMessages reads the given .eml files from fsys
+func LoadMessages(fsys fs.FS, paths []string) ([]Message, error) {
+	messages := make([]Message, 0, len(paths))
+	for _, p := range paths {
+		body, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, err
+		}
+
+		var modTime time.Time
+		if info, err := fs.Stat(fsys, p); err == nil {
+			modTime = info.ModTime()
+		}
+
+		messages = append(messages, Message{
+			Name: path.Base(p),
+			Date: messageDate(body, modTime),
+			Body: body,
+		})
+	}
+
+	return messages, nil
+}
+
+// Messages returns all messages currently in the store ordered by the time they were received
+func (s DirStore) Messages() ([]Message, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	messages := make([]Message, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), MessageFileExtension) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		body, err := os.ReadFile(filepath.Join(s.Dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, Message{
+			Name: entry.Name(),
+			Date: messageDate(body, info.ModTime()),
+			Body: body,
+		})
+	}
+
+	return messages, nil
+}
+
+func messageDate(body []byte, fallback time.Time) time.Time {
+	if msg, err := netmail.ReadMessage(bytes.NewReader(body)); err == nil {
+		if date, err := msg.Header.Date(); err == nil {
+			return date
+		}
+	}
+
+	if fallback.IsZero() {
+		return time.Now().UTC()
+	}
+
+	return fallback
+}
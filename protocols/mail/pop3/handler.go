@@ -0,0 +1,97 @@
+package pop3
+
+import (
+	"context"
+	"crypto/tls"
+	"io/fs"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+)
+
+const (
+	name               = "pop3_mock"
+	defaultIdleTimeout = 5 * time.Minute
+)
+
+type pop3Handler struct {
+	logger     logging.Logger
+	emitter    audit.Emitter
+	certStore  cert.Store
+	fakeFileFS fs.FS
+	mailDir    string
+	tlsConfig  *tls.Config
+	source     mail.MessageSource
+	conns      endpoint.ConnTracker
+}
+
+func (h *pop3Handler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) error {
+	options, err := loadFromConfig(startupSpec)
+	if err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	if h.source, err = mail.NewMessageSource(options.MailboxOptions, h.fakeFileFS, h.mailDir); err != nil {
+		h.logger.Error("Failed to load mailbox messages", zap.Error(err))
+		return err
+	}
+
+	if h.certStore != nil {
+		h.tlsConfig = h.certStore.TLSConfig()
+	}
+
+	h.conns.Open()
+
+	go h.serve(startupSpec.Listener)
+	return nil
+}
+
+// Stop terminates all POP3 sessions, deletions are never applied to the mail directory anyway
+func (h *pop3Handler) Stop(context.Context) error {
+	h.conns.CloseAll()
+	return nil
+}
+
+func (h *pop3Handler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept POP3 connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *pop3Handler) handleConn(conn net.Conn) {
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+	}()
+
+	s := newSession(h, conn)
+	if err := endpoint.IgnoreShutdownError(s.serve()); err != nil {
+		h.logger.Debug("POP3 session terminated", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,295 @@
+package pop3_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/textproto"
+	"testing"
+	"testing/fstest"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail/pop3"
+)
+
+const (
+	fakeMessage = "From: admin@example.com\r\n" +
+		"To: ted@example.com\r\n" +
+		"Subject: Welcome\r\n" +
+		"\r\n" +
+		"Hello Ted\r\n" +
+		"Have a nice day\r\n"
+	receivedMessage = "From: alice@example.com\r\n" +
+		"To: ted@example.com\r\n" +
+		"Subject: Invoice\r\n" +
+		"\r\n" +
+		"Please pay\r\n"
+)
+
+type exchange struct {
+	cmd       string
+	want      any
+	wantLines any
+}
+
+func Test_pop3Handler_Start(t *testing.T) {
+	t.Parallel()
+	fakeFiles := fstest.MapFS{
+		"welcome.eml": &fstest.MapFile{Data: []byte(fakeMessage)},
+	}
+
+	tests := []struct {
+		name         string
+		opts         map[string]any
+		received     []string
+		exchanges    []exchange
+		wantStartErr bool
+		wantEvents   any
+	}{
+		{
+			name: "Login and retrieve fake message",
+			opts: map[string]any{
+				"messages": []string{"welcome.eml"},
+			},
+			exchanges: []exchange{
+				{cmd: "USER ted", want: td.HasPrefix("+OK")},
+				{cmd: "PASS secret", want: "+OK maildrop has 1 messages"},
+				{cmd: "STAT", want: fmt.Sprintf("+OK 1 %d", len(fakeMessage))},
+				{cmd: "RETR 1", want: fmt.Sprintf("+OK %d octets", len(fakeMessage)), wantLines: td.Contains("Subject: Welcome")},
+				{cmd: "QUIT", want: td.HasPrefix("+OK")},
+			},
+			wantEvents: td.All(
+				td.Len(5),
+				td.Contains(td.Struct(new(audit.Event), td.StructFields{
+					"Application": auditv1.AppProtocol_APP_PROTOCOL_POP3,
+					"Transport":   auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP,
+					"ProtocolDetails": td.Struct(audit.Mailbox{
+						Command:  "PASS",
+						User:     "ted",
+						Password: "secret",
+					}, nil),
+				})),
+				td.Contains(td.Struct(new(audit.Event), td.StructFields{
+					"ProtocolDetails": td.Struct(audit.Mailbox{
+						Command:   "RETR",
+						Arguments: []string{"1"},
+						User:      "ted",
+					}, nil),
+				})),
+			),
+		},
+		{
+			name: "Include received messages",
+			opts: map[string]any{
+				"messages":        []string{"welcome.eml"},
+				"includeReceived": true,
+			},
+			received: []string{receivedMessage},
+			exchanges: []exchange{
+				{cmd: "USER ted", want: td.HasPrefix("+OK")},
+				{cmd: "PASS secret", want: "+OK maildrop has 2 messages"},
+				{cmd: "LIST", want: "+OK", wantLines: []string{fmt.Sprintf("1 %d", len(fakeMessage)), fmt.Sprintf("2 %d", len(receivedMessage))}},
+				{cmd: "TOP 2 0", want: "+OK", wantLines: td.All(td.Contains("Subject: Invoice"), td.Not(td.Contains("Please pay")))},
+			},
+			wantEvents: td.Len(4),
+		},
+		{
+			name: "Deleted messages are hidden until reset",
+			opts: map[string]any{
+				"messages": []string{"welcome.eml"},
+			},
+			exchanges: []exchange{
+				{cmd: "USER ted", want: td.HasPrefix("+OK")},
+				{cmd: "PASS secret", want: td.HasPrefix("+OK")},
+				{cmd: "DELE 1", want: "+OK message 1 deleted"},
+				{cmd: "RETR 1", want: td.HasPrefix("-ERR")},
+				{cmd: "RSET", want: "+OK"},
+				{cmd: "UIDL", want: "+OK", wantLines: td.Len(1)},
+			},
+			wantEvents: td.Len(6),
+		},
+		{
+			name: "Login with AUTH PLAIN",
+			exchanges: []exchange{
+				{cmd: "AUTH PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00bob\x00hunter2")), want: "+OK maildrop has 0 messages"},
+				{cmd: "STAT", want: "+OK 0 0"},
+			},
+			wantEvents: td.All(
+				td.Len(2),
+				td.Contains(td.Struct(new(audit.Event), td.StructFields{
+					"ProtocolDetails": td.Struct(audit.Mailbox{
+						Command:   "AUTH",
+						Arguments: []string{"PLAIN"},
+						User:      "bob",
+						Password:  "hunter2",
+					}, nil),
+				})),
+			),
+		},
+		{
+			name: "Reject transaction commands before login",
+			exchanges: []exchange{
+				{cmd: "STAT", want: td.HasPrefix("-ERR")},
+				{cmd: "CAPA", want: td.HasPrefix("+OK"), wantLines: td.All(td.Contains("USER"), td.Not(td.Contains("STLS")))},
+			},
+			wantEvents: td.Len(2),
+		},
+		{
+			name: "Error because of missing fake message",
+			opts: map[string]any{
+				"messages": []string{"missing.eml"},
+			},
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			mailDir := t.TempDir()
+			for _, msg := range tt.received {
+				_, _, err := mail.DirStore{Dir: mailDir}.Save(bytes.NewReader([]byte(msg)))
+				td.CmpNoError(t, err)
+			}
+
+			listener := test.NewTCPListener(t, "127.0.0.1:0")
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := pop3.New(logging.CreateTestLogger(t), emitterMock, nil, fakeFiles, mailDir)
+			startupSpec := endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.opts)
+
+			if err := handler.Start(ctx, startupSpec); err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			t.Cleanup(func() {
+				_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+				_ = listener.Close()
+			})
+
+			conn := dial(t, listener.Addr().String())
+			runExchanges(t, conn, tt.exchanges)
+			td.CmpNoError(t, conn.Close())
+
+			emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+				events := make([]*audit.Event, 0, len(calls.Emit()))
+				for _, call := range calls.Emit() {
+					events = append(events, call.Params.Ev)
+				}
+				td.Cmp(t, events, tt.wantEvents)
+			})
+		})
+	}
+}
+
+func Test_pop3Handler_STLS(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(test.Context(t))
+	t.Cleanup(cancel)
+
+	listener := test.NewTCPListener(t, "127.0.0.1:0")
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := pop3.New(logging.CreateTestLogger(t), emitterMock, test.NewSelfSignedCertStore(t), fstest.MapFS{}, t.TempDir())
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+		_ = listener.Close()
+	})
+
+	rawConn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("net.Dial() error = %v", err)
+	}
+	conn := textproto.NewConn(rawConn)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	_, err = conn.ReadLine()
+	td.CmpNoError(t, err)
+
+	runExchanges(t, conn, []exchange{
+		{cmd: "CAPA", want: td.HasPrefix("+OK"), wantLines: td.Contains("STLS")},
+		{cmd: "STLS", want: td.HasPrefix("+OK")},
+	})
+
+	//nolint:gosec // the test server uses a self-signed certificate
+	tlsConn := tls.Client(rawConn, &tls.Config{InsecureSkipVerify: true, ServerName: "mail.example.com"})
+	if err = tlsConn.Handshake(); err != nil {
+		t.Fatalf("Handshake() error = %v", err)
+	}
+
+	runExchanges(t, textproto.NewConn(tlsConn), []exchange{
+		{cmd: "USER ted", want: td.HasPrefix("+OK")},
+		{cmd: "PASS secret", want: td.HasPrefix("+OK")},
+	})
+
+	emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+		if td.Cmp(t, calls.Emit(), td.Len(4)) {
+			td.Cmp(t, calls.Emit()[3].Params.Ev.TLS, td.Struct(&audit.TLSDetails{
+				ServerName: "mail.example.com",
+			}, td.StructFields{
+				"Version": td.NotZero(),
+			}))
+		}
+	})
+}
+
+func dial(tb testing.TB, addr string) *textproto.Conn {
+	tb.Helper()
+	conn, err := textproto.Dial("tcp", addr)
+	if err != nil {
+		tb.Fatalf("textproto.Dial() error = %v", err)
+	}
+
+	if greeting, err := conn.ReadLine(); err != nil {
+		tb.Fatalf("ReadLine() error = %v", err)
+	} else {
+		td.Cmp(tb, greeting, td.HasPrefix("+OK"))
+	}
+
+	return conn
+}
+
+func runExchanges(tb testing.TB, conn *textproto.Conn, exchanges []exchange) {
+	tb.Helper()
+	for _, ex := range exchanges {
+		if err := conn.PrintfLine("%s", ex.cmd); err != nil {
+			tb.Fatalf("PrintfLine() error = %v", err)
+		}
+
+		resp, err := conn.ReadLine()
+		if err != nil {
+			tb.Fatalf("ReadLine() error = %v", err)
+		}
+		td.Cmp(tb, resp, ex.want, ex.cmd)
+
+		if ex.wantLines != nil {
+			lines, err := conn.ReadDotLines()
+			td.CmpNoError(tb, err)
+			td.Cmp(tb, lines, ex.wantLines, ex.cmd)
+		}
+	}
+}
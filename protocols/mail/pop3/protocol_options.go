@@ -0,0 +1,15 @@
+package pop3
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+)
+
+type pop3Options struct {
+	mail.MailboxOptions `mapstructure:",squash"`
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts pop3Options, err error) {
+	err = startupSpec.UnmarshalOptions(&opts)
+	return opts, err
+}
@@ -0,0 +1,432 @@
+package pop3
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+)
+
+var errQuit = errors.New("client quit")
+
+type state uint8
+
+const (
+	stateAuthorization state = iota
+	stateTransaction
+)
+
+type session struct {
+	handler  *pop3Handler
+	conn     net.Conn
+	reader   *textproto.Reader
+	writer   *textproto.Writer
+	state    state
+	user     string
+	messages []mail.Message
+	deleted  []bool
+}
+
+func newSession(handler *pop3Handler, conn net.Conn) *session {
+	s := &session{
+		handler: handler,
+	}
+	s.setConn(conn)
+	return s
+}
+
+func (s *session) setConn(conn net.Conn) {
+	s.conn = conn
+	s.reader = textproto.NewReader(bufio.NewReader(conn))
+	s.writer = textproto.NewWriter(bufio.NewWriter(conn))
+}
+
+func (s *session) serve() error {
+	if err := s.ok("InetMock POP3 server ready"); err != nil {
+		return err
+	}
+
+	for {
+		_ = s.conn.SetDeadline(time.Now().Add(defaultIdleTimeout))
+		line, err := s.reader.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		cmd, args := parseCommand(line)
+		if err = s.handle(cmd, args); errors.Is(err, errQuit) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *session) handle(cmd string, args []string) error {
+	if cmd == "" {
+		return s.err("empty command")
+	}
+
+	if cmd != "PASS" && cmd != "AUTH" {
+		s.emit(audit.Mailbox{Command: cmd, Arguments: args, User: s.user})
+	}
+
+	switch cmd {
+	case "CAPA":
+		return s.capa()
+	case "NOOP":
+		return s.ok("")
+	case "QUIT":
+		_ = s.ok("InetMock POP3 server signing off")
+		return errQuit
+	}
+
+	if s.state == stateAuthorization {
+		return s.handleAuthorization(cmd, args)
+	}
+
+	return s.handleTransaction(cmd, args)
+}
+
+func (s *session) handleAuthorization(cmd string, args []string) error {
+	switch cmd {
+	case "USER":
+		return s.userCmd(args)
+	case "PASS":
+		return s.pass(args)
+	case "AUTH":
+		return s.auth(args)
+	case "STLS":
+		return s.startTLS()
+	default:
+		return s.err("command not valid before login")
+	}
+}
+
+func (s *session) handleTransaction(cmd string, args []string) error {
+	switch cmd {
+	case "STAT":
+		return s.stat()
+	case "LIST":
+		return s.list(args)
+	case "UIDL":
+		return s.uidl(args)
+	case "RETR":
+		return s.retr(args)
+	case "TOP":
+		return s.top(args)
+	case "DELE":
+		return s.dele(args)
+	case "RSET":
+		for idx := range s.deleted {
+			s.deleted[idx] = false
+		}
+		return s.ok("")
+	default:
+		return s.err("unknown command")
+	}
+}
+
+func (s *session) capa() error {
+	capabilities := []string{"USER", "UIDL", "TOP", "SASL PLAIN", "IMPLEMENTATION InetMock"}
+	if s.canStartTLS() {
+		capabilities = append(capabilities, "STLS")
+	}
+	return s.multiline("Capability list follows", []byte(strings.Join(capabilities, "\r\n")+"\r\n"))
+}
+
+func (s *session) userCmd(args []string) error {
+	if len(args) < 1 {
+		return s.err("user name required")
+	}
+	s.user = strings.Join(args, " ")
+	return s.ok("send password")
+}
+
+func (s *session) pass(args []string) error {
+	password := strings.Join(args, " ")
+	s.emit(audit.Mailbox{Command: "PASS", User: s.user, Password: password})
+
+	if s.user == "" {
+		return s.err("USER required first")
+	}
+
+	return s.login()
+}
+
+func (s *session) auth(args []string) error {
+	if len(args) < 1 || !strings.EqualFold(args[0], "PLAIN") {
+		s.emit(audit.Mailbox{Command: "AUTH", Arguments: args})
+		return s.err("unsupported authentication mechanism")
+	}
+
+	var encoded string
+	if len(args) > 1 {
+		encoded = args[1]
+	} else {
+		if err := s.writer.PrintfLine("+ "); err != nil {
+			return err
+		}
+		line, err := s.reader.ReadLine()
+		if err != nil {
+			return err
+		}
+		encoded = strings.TrimSpace(line)
+	}
+
+	var user, password string
+	if decoded, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+		if parts := bytes.SplitN(decoded, []byte{0}, 3); len(parts) == 3 {
+			user, password = string(parts[1]), string(parts[2])
+		}
+	}
+
+	s.emit(audit.Mailbox{Command: "AUTH", Arguments: []string{"PLAIN"}, User: user, Password: password})
+
+	if user == "" {
+		return s.err("invalid authentication data")
+	}
+
+	s.user = user
+	return s.login()
+}
+
+func (s *session) login() error {
+	messages, err := s.handler.source()
+	if err != nil {
+		s.handler.logger.Error("Failed to load mailbox messages", zap.Error(err))
+		return s.err("[SYS/TEMP] failed to open maildrop")
+	}
+
+	s.messages = messages
+	s.deleted = make([]bool, len(messages))
+	s.state = stateTransaction
+
+	return s.ok(fmt.Sprintf("maildrop has %d messages", len(messages)))
+}
+
+func (s *session) canStartTLS() bool {
+	if s.handler.tlsConfig == nil || s.state != stateAuthorization {
+		return false
+	}
+	_, isTLS := s.conn.(*tls.Conn)
+	return !isTLS
+}
+
+func (s *session) startTLS() error {
+	if !s.canStartTLS() {
+		return s.err("STLS not available")
+	}
+
+	if err := s.ok("begin TLS negotiation"); err != nil {
+		return err
+	}
+
+	tlsConn := tls.Server(s.conn, s.handler.tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		return err
+	}
+
+	s.setConn(tlsConn)
+	return nil
+}
+
+func (s *session) stat() error {
+	var count, size int
+	for idx := range s.messages {
+		if !s.deleted[idx] {
+			count++
+			size += len(s.messages[idx].Body)
+		}
+	}
+	return s.ok(fmt.Sprintf("%d %d", count, size))
+}
+
+func (s *session) list(args []string) error {
+	return s.listing(args, func(idx int) string {
+		return strconv.Itoa(len(s.messages[idx].Body))
+	})
+}
+
+func (s *session) uidl(args []string) error {
+	return s.listing(args, func(idx int) string {
+		return messageUID(s.messages[idx])
+	})
+}
+
+func (s *session) listing(args []string, value func(idx int) string) error {
+	if len(args) > 0 {
+		idx, err := s.messageIndex(args[0])
+		if err != nil {
+			return s.err(err.Error())
+		}
+		return s.ok(fmt.Sprintf("%d %s", idx+1, value(idx)))
+	}
+
+	buf := new(bytes.Buffer)
+	for idx := range s.messages {
+		if !s.deleted[idx] {
+			_, _ = fmt.Fprintf(buf, "%d %s\r\n", idx+1, value(idx))
+		}
+	}
+
+	return s.multiline("", buf.Bytes())
+}
+
+func (s *session) retr(args []string) error {
+	if len(args) < 1 {
+		return s.err("message number required")
+	}
+
+	idx, err := s.messageIndex(args[0])
+	if err != nil {
+		return s.err(err.Error())
+	}
+
+	body := s.messages[idx].Body
+	return s.multiline(fmt.Sprintf("%d octets", len(body)), body)
+}
+
+func (s *session) top(args []string) error {
+	if len(args) < 2 {
+		return s.err("message number and line count required")
+	}
+
+	idx, err := s.messageIndex(args[0])
+	if err != nil {
+		return s.err(err.Error())
+	}
+
+	lines, err := strconv.Atoi(args[1])
+	if err != nil || lines < 0 {
+		return s.err("invalid line count")
+	}
+
+	return s.multiline("", topLines(s.messages[idx].Body, lines))
+}
+
+func (s *session) dele(args []string) error {
+	if len(args) < 1 {
+		return s.err("message number required")
+	}
+
+	idx, err := s.messageIndex(args[0])
+	if err != nil {
+		return s.err(err.Error())
+	}
+
+	s.deleted[idx] = true
+	return s.ok(fmt.Sprintf("message %d deleted", idx+1))
+}
+
+func (s *session) messageIndex(arg string) (int, error) {
+	num, err := strconv.Atoi(arg)
+	if err != nil || num < 1 || num > len(s.messages) {
+		return 0, fmt.Errorf("no such message")
+	}
+
+	if s.deleted[num-1] {
+		return 0, fmt.Errorf("message %d already deleted", num)
+	}
+
+	return num - 1, nil
+}
+
+func (s *session) ok(msg string) error {
+	if msg == "" {
+		return s.writer.PrintfLine("+OK")
+	}
+	return s.writer.PrintfLine("+OK %s", msg)
+}
+
+func (s *session) err(msg string) error {
+	return s.writer.PrintfLine("-ERR %s", msg)
+}
+
+func (s *session) multiline(msg string, data []byte) error {
+	if err := s.ok(msg); err != nil {
+		return err
+	}
+
+	dw := s.writer.DotWriter()
+	if _, err := dw.Write(data); err != nil {
+		return err
+	}
+
+	return dw.Close()
+}
+
+func (s *session) emit(details audit.Mailbox) {
+	builder := s.handler.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_POP3).
+		WithProtocolDetails(details)
+
+	if details, ok := audit.NewTLSDetailsFromConn(s.conn); ok {
+		builder = builder.WithTLSDetails(details)
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(s.conn.RemoteAddr())
+	builder, _ = builder.WithDestinationFromAddr(s.conn.LocalAddr())
+
+	builder.Emit()
+}
+
+func parseCommand(line string) (cmd string, args []string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return strings.ToUpper(fields[0]), fields[1:]
+}
+
+// messageUID derives a unique ID from the message name which is stable across sessions
+func messageUID(msg mail.Message) string {
+	sum := sha256.Sum256([]byte(msg.Name))
+	return hex.EncodeToString(sum[:16])
+}
+
+// topLines returns the header and the first n lines of the body of a message
+func topLines(body []byte, n int) []byte {
+	headerEnd := bytes.Index(body, []byte("\r\n\r\n"))
+	sepLen := 4
+	if lfEnd := bytes.Index(body, []byte("\n\n")); headerEnd < 0 || (lfEnd >= 0 && lfEnd < headerEnd) {
+		headerEnd, sepLen = lfEnd, 2
+	}
+
+	if headerEnd < 0 {
+		return body
+	}
+
+	end := headerEnd + sepLen
+	for i := 0; i < n && end < len(body); i++ {
+		next := bytes.IndexByte(body[end:], '\n')
+		if next < 0 {
+			end = len(body)
+			break
+		}
+		end += next + 1
+	}
+
+	return body[:end]
+}
@@ -0,0 +1,142 @@
+package smtp
+
+import (
+	"io"
+	"os"
+
+	"github.com/emersion/go-smtp"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+)
+
+var _ smtp.Backend = (*backend)(nil)
+
+type backend struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	store       *mail.DirStore
+	ruleHandler *RuleHandler
+}
+
+// Login accepts any credentials
+func (b *backend) Login(state *smtp.ConnectionState, username, _ string) (smtp.Session, error) {
+	return b.newSession(state, username), nil
+}
+
+func (b *backend) AnonymousLogin(state *smtp.ConnectionState) (smtp.Session, error) {
+	return b.newSession(state, ""), nil
+}
+
+func (b *backend) newSession(state *smtp.ConnectionState, authUser string) *session {
+	return &session{
+		backend:  b,
+		state:    *state,
+		authUser: authUser,
+	}
+}
+
+type session struct {
+	backend  *backend
+	state    smtp.ConnectionState
+	authUser string
+	from     string
+	rcptTo   []string
+}
+
+func (s *session) Reset() {
+	s.from = ""
+	s.rcptTo = nil
+}
+
+func (s *session) Logout() error {
+	return nil
+}
+
+func (s *session) Mail(from string, _ smtp.MailOptions) error {
+	if err := s.backend.ruleHandler.Evaluate(Envelope{Helo: s.state.Hostname, From: from}, s.client()); err != nil {
+		return err
+	}
+
+	s.from = from
+	return nil
+}
+
+func (s *session) Rcpt(to string) error {
+	if err := s.backend.ruleHandler.Evaluate(Envelope{Helo: s.state.Hostname, From: s.from, To: to}, s.client()); err != nil {
+		return err
+	}
+
+	s.rcptTo = append(s.rcptTo, to)
+	return nil
+}
+
+func (s *session) Data(r io.Reader) error {
+	path, size, err := s.backend.store.Save(r)
+	if err != nil {
+		s.backend.logger.Error("Failed to store received message", zap.Error(err))
+		return err
+	}
+
+	s.backend.logger.Debug("Stored received message", zap.String("path", path), zap.Int64("size", size))
+
+	details := &audit.SMTP{
+		Helo:     s.state.Hostname,
+		MailFrom: s.from,
+		RcptTo:   s.rcptTo,
+		Size:     size,
+		AuthUser: s.authUser,
+	}
+
+	if summary, err := summarizeFile(path); err != nil {
+		s.backend.logger.Warn("Failed to parse received message", zap.String("path", path), zap.Error(err))
+	} else {
+		details.Subject = summary.Subject
+		details.Attachments = summary.Attachments
+	}
+
+	s.emit(details)
+
+	return nil
+}
+
+func (s *session) emit(details *audit.SMTP) {
+	builder := s.backend.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_SMTP).
+		WithProtocolDetails(details)
+
+	if s.state.TLS.HandshakeComplete {
+		builder = builder.WithTLSDetails(audit.NewTLSDetailsFromState(s.state.TLS))
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(s.state.RemoteAddr)
+	builder, _ = builder.WithDestinationFromAddr(s.state.LocalAddr)
+
+	builder.Emit()
+}
+
+func (s *session) client() string {
+	if ip, _, err := netutils.IPPortFromAddress(s.state.RemoteAddr); err == nil {
+		return ip.String()
+	}
+	return ""
+}
+
+func summarizeFile(path string) (summary mail.Summary, err error) {
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return summary, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return mail.Summarize(f)
+}
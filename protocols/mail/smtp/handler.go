@@ -0,0 +1,142 @@
+package smtp
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+)
+
+const (
+	name               = "smtp_mock"
+	defaultIdleTimeout = 5 * time.Minute
+)
+
+var (
+	_ endpoint.FallbackHandler     = (*smtpHandler)(nil)
+	_ endpoint.StoppableHandler    = (*smtpHandler)(nil)
+	_ endpoint.RuleManagingHandler = (*smtpHandler)(nil)
+)
+
+type smtpHandler struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	certStore   cert.Store
+	mailDir     string
+	server      *smtp.Server
+	ruleHandler *RuleHandler
+}
+
+// Matchers accepts every connection because SMTP clients wait for the greeting of the server before they send anything.
+// As a fallback handler the SMTP mock only receives the connections none of the other handlers of a listener matched
+// after the read timeout of the multiplexer expired.
+func (h *smtpHandler) Matchers() []cmux.Matcher {
+	return []cmux.Matcher{cmux.Any()}
+}
+
+func (h *smtpHandler) Fallback() bool {
+	return true
+}
+
+func (h *smtpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) error {
+	options, err := loadFromConfig(startupSpec, h.mailDir)
+	if err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	var store *mail.DirStore
+	if store, err = mail.NewDirStore(options.DataDir); err != nil {
+		h.logger.Error("Failed to setup mail data directory", zap.String("data_dir", options.DataDir), zap.Error(err))
+		return err
+	}
+
+	ruleHandler := &RuleHandler{
+		HandlerName: startupSpec.Name,
+	}
+
+	for idx := range options.Rules {
+		rule := options.Rules[idx]
+		if err = ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+	h.ruleHandler = ruleHandler
+
+	be := &backend{
+		logger:      h.logger,
+		emitter:     h.emitter,
+		store:       store,
+		ruleHandler: ruleHandler,
+	}
+
+	h.server = smtp.NewServer(be)
+	h.server.Domain = options.Domain
+	h.server.MaxMessageBytes = options.MaxMessageBytes
+	h.server.MaxRecipients = options.MaxRecipients
+	h.server.AllowInsecureAuth = true
+	h.server.ReadTimeout = defaultIdleTimeout
+	h.server.WriteTimeout = defaultIdleTimeout
+	h.server.ErrorLog = mail.ErrorLogger{Logger: h.logger}
+	h.server.EnableAuth(sasl.Login, func(conn *smtp.Conn) sasl.Server {
+		return sasl.NewLoginServer(func(username, password string) error {
+			state := conn.State()
+			session, err := be.Login(&state, username, password)
+			if err != nil {
+				return err
+			}
+			conn.SetSession(session)
+			return nil
+		})
+	})
+
+	if h.certStore != nil {
+		h.server.TLSConfig = h.certStore.TLSConfig()
+	}
+
+	go h.startServer(startupSpec.Listener)
+	return nil
+}
+
+// Stop closes all open connections, the listener itself is closed by the endpoint
+func (h *smtpHandler) Stop(context.Context) error {
+	if h.server == nil {
+		return nil
+	}
+
+	h.server.ForEachConn(func(conn *smtp.Conn) {
+		_ = conn.Close()
+	})
+
+	return nil
+}
+
+func (h *smtpHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+func (h *smtpHandler) startServer(listener net.Listener) {
+	if err := endpoint.IgnoreShutdownError(h.server.Serve(listener)); err != nil {
+		h.logger.Error("Failed to start SMTP listener", zap.Error(err))
+	}
+}
@@ -0,0 +1,368 @@
+package smtp_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	httpmock "inetmock.icb4dc0.de/inetmock/protocols/http/mock"
+	smtpmock "inetmock.icb4dc0.de/inetmock/protocols/mail/smtp"
+)
+
+const testMessage = "From: ted@example.com\r\n" +
+	"To: alice@example.com\r\n" +
+	"Subject: Invoice\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"boundary\"\r\n" +
+	"\r\n" +
+	"--boundary\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"\r\n" +
+	"Please see attached\r\n" +
+	"--boundary\r\n" +
+	"Content-Type: application/octet-stream\r\n" +
+	"Content-Disposition: attachment; filename=\"invoice.exe\"\r\n" +
+	"\r\n" +
+	"MZ\r\n" +
+	"--boundary--\r\n"
+
+func Test_smtpHandler_Start(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		opts map[string]any
+		auth smtp.Auth
+		from string
+		to   []string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantStartErr  bool
+		wantSendErr   bool
+		wantEvent     any
+		wantFileCount int
+	}{
+		{
+			name: "Accept message without rules",
+			args: args{
+				from: "ted@example.com",
+				to:   []string{"alice@example.com", "bob@example.com"},
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"Application": auditv1.AppProtocol_APP_PROTOCOL_SMTP,
+				"Transport":   auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP,
+				"ProtocolDetails": td.Struct(new(audit.SMTP), td.StructFields{
+					"Helo":        "localhost",
+					"MailFrom":    "ted@example.com",
+					"RcptTo":      []string{"alice@example.com", "bob@example.com"},
+					"Subject":     "Invoice",
+					"Size":        td.Gt(int64(0)),
+					"Attachments": []string{"invoice.exe"},
+					"AuthUser":    "",
+				}),
+			}),
+			wantFileCount: 1,
+		},
+		{
+			name: "Accept any credentials",
+			args: args{
+				auth: smtp.PlainAuth("", "ted", "secret", "127.0.0.1"),
+				from: "ted@example.com",
+				to:   []string{"alice@example.com"},
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(new(audit.SMTP), td.StructFields{
+					"AuthUser": "ted",
+				}),
+			}),
+			wantFileCount: 1,
+		},
+		{
+			name: "Reject sender by rule",
+			args: args{
+				opts: map[string]any{
+					"rules": []string{
+						`From(".*@evil\\.com$") => Reject(550, "go away")`,
+					},
+				},
+				from: "mallory@evil.com",
+				to:   []string{"alice@example.com"},
+			},
+			wantSendErr: true,
+		},
+		{
+			name: "Reject recipient by rule",
+			args: args{
+				opts: map[string]any{
+					"rules": []string{
+						`To("^admin@") => Reject()`,
+						`=> Accept()`,
+					},
+				},
+				from: "ted@example.com",
+				to:   []string{"admin@example.com"},
+			},
+			wantSendErr: true,
+		},
+		{
+			name: "Accept recipient not matching reject rule",
+			args: args{
+				opts: map[string]any{
+					"rules": []string{
+						`To("^admin@") => Reject()`,
+					},
+				},
+				from: "ted@example.com",
+				to:   []string{"alice@example.com"},
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(new(audit.SMTP), td.StructFields{
+					"RcptTo": []string{"alice@example.com"},
+				}),
+			}),
+			wantFileCount: 1,
+		},
+		{
+			name: "Error because of unknown verdict",
+			args: args{
+				opts: map[string]any{
+					"rules": []string{
+						`=> Bounce()`,
+					},
+				},
+			},
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			mailDir := t.TempDir()
+			listener := test.NewTCPListener(t, "127.0.0.1:0")
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := smtpmock.New(logging.CreateTestLogger(t), emitterMock, nil, mailDir)
+			startupSpec := endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.args.opts)
+
+			if err := handler.Start(ctx, startupSpec); err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			t.Cleanup(func() {
+				_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+				_ = listener.Close()
+			})
+
+			err := smtp.SendMail(listener.Addr().String(), tt.args.auth, tt.args.from, tt.args.to, []byte(testMessage))
+			if (err != nil) != tt.wantSendErr {
+				t.Errorf("smtp.SendMail() error = %v, wantErr %v", err, tt.wantSendErr)
+				return
+			}
+
+			files, err := filepath.Glob(filepath.Join(mailDir, "*.eml"))
+			td.CmpNoError(t, err)
+			td.Cmp(t, len(files), tt.wantFileCount)
+
+			if tt.wantSendErr {
+				emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+					td.CmpEmpty(t, calls.Emit())
+				})
+				return
+			}
+
+			content, err := os.ReadFile(files[0])
+			td.CmpNoError(t, err)
+			td.Cmp(t, strings.Contains(string(content), "Subject: Invoice"), true)
+
+			emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+				if td.Cmp(t, calls.Emit(), td.Len(1)) {
+					td.Cmp(t, calls.Emit()[0].Params.Ev, tt.wantEvent)
+				}
+			})
+		})
+	}
+}
+
+func Test_smtpHandler_StartTLS(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(test.Context(t))
+	t.Cleanup(cancel)
+
+	listener := test.NewTCPListener(t, "127.0.0.1:0")
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := smtpmock.New(logging.CreateTestLogger(t), emitterMock, test.NewSelfSignedCertStore(t), t.TempDir())
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+		_ = listener.Close()
+	})
+
+	client, err := smtp.Dial(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("smtp.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if ok, _ := client.Extension("STARTTLS"); !ok {
+		t.Fatal("STARTTLS not advertised")
+	}
+
+	//nolint:gosec // the test server uses a self-signed certificate
+	if err = client.StartTLS(&tls.Config{InsecureSkipVerify: true, ServerName: "mail.example.com"}); err != nil {
+		t.Fatalf("client.StartTLS() error = %v", err)
+	}
+
+	td.CmpNoError(t, client.Mail("ted@example.com"))
+	td.CmpNoError(t, client.Rcpt("alice@example.com"))
+	writer, err := client.Data()
+	if err != nil {
+		t.Fatalf("client.Data() error = %v", err)
+	}
+	_, err = writer.Write([]byte(testMessage))
+	td.CmpNoError(t, err)
+	td.CmpNoError(t, writer.Close())
+	td.CmpNoError(t, client.Quit())
+
+	emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+		if td.Cmp(t, calls.Emit(), td.Len(1)) {
+			td.Cmp(t, calls.Emit()[0].Params.Ev.TLS, td.Struct(&audit.TLSDetails{
+				ServerName: "mail.example.com",
+			}, td.StructFields{
+				"Version": td.NotZero(),
+			}))
+		}
+	})
+}
+
+func Test_smtpHandler_Multiplexed(t *testing.T) {
+	t.Parallel()
+	var (
+		logger      = logging.CreateTestLogger(t)
+		emitterMock = new(audit_mock.EmitterMock)
+		certStore   = test.NewSelfSignedCertStore(t)
+		registry    = endpoint.NewHandlerRegistry()
+	)
+
+	httpmock.AddHTTPMock(registry, logger, emitterMock, fstest.MapFS{}, nil)
+	smtpmock.AddSMTPMock(registry, logger, emitterMock, certStore, t.TempDir())
+
+	builder := endpoint.NewServerBuilder(certStore.TLSConfig(), registry, logger)
+	spec := endpoint.ListenerSpec{
+		Name:     "mail",
+		Protocol: "tcp",
+		Address:  "127.0.0.1",
+		Endpoints: map[string]endpoint.Spec{
+			"a_smtp":     {HandlerRef: "smtp_mock"},
+			"smtps":      {HandlerRef: "smtp_mock", TLS: true, SNI: []string{"smtp.example.com"}},
+			"plain_http": {HandlerRef: "http_mock", Options: map[string]any{"rules": []string{`=> Status(204)`}}},
+		},
+	}
+
+	if err := builder.ConfigureGroup(spec); err != nil {
+		t.Fatalf("builder.ConfigureGroup() error = %v", err)
+	}
+
+	srv := builder.Server()
+	if err := srv.ServeGroups(test.Context(t)); err != nil {
+		t.Fatalf("srv.ServeGroups() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	addr := srv.ConfiguredGroups()[0].Addr.String()
+
+	resp, err := http.Get("http://" + addr + "/")
+	if td.CmpNoError(t, err) {
+		_ = resp.Body.Close()
+		td.Cmp(t, resp.StatusCode, http.StatusNoContent)
+	}
+
+	// plain SMTP clients wait for the greeting hence they are handed over to the fallback after the read timeout
+	plainConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("net.Dial() error = %v", err)
+	}
+
+	// with TCP_DEFER_ACCEPT the connection would only be accepted after about a second
+	if err = plainConn.SetReadDeadline(time.Now().Add(500 * time.Millisecond)); err != nil {
+		t.Fatalf("conn.SetReadDeadline() error = %v", err)
+	}
+
+	plainClient, err := smtp.NewClient(plainConn, "127.0.0.1")
+	if err != nil {
+		t.Fatalf("smtp.NewClient() error = %v", err)
+	}
+	sendMessage(t, plainClient)
+
+	//nolint:gosec // the server uses a self-signed certificate
+	tlsConn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true, ServerName: "smtp.example.com"})
+	if err != nil {
+		t.Fatalf("tls.Dial() error = %v", err)
+	}
+
+	tlsClient, err := smtp.NewClient(tlsConn, "smtp.example.com")
+	if err != nil {
+		t.Fatalf("smtp.NewClient() error = %v", err)
+	}
+	sendMessage(t, tlsClient)
+
+	test.AwaitEvents(t, emitterMock, td.Bag(
+		td.Smuggle("Application", auditv1.AppProtocol_APP_PROTOCOL_HTTP),
+		td.Struct(&audit.Event{Application: auditv1.AppProtocol_APP_PROTOCOL_SMTP}, td.StructFields{
+			"TLS": td.Nil(),
+		}),
+		td.Struct(&audit.Event{Application: auditv1.AppProtocol_APP_PROTOCOL_SMTP}, td.StructFields{
+			"TLS": td.Smuggle("ServerName", "smtp.example.com"),
+		}),
+	))
+}
+
+func sendMessage(tb testing.TB, client *smtp.Client) {
+	tb.Helper()
+	tb.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	td.CmpNoError(tb, client.Mail("ted@example.com"))
+	td.CmpNoError(tb, client.Rcpt("alice@example.com"))
+	writer, err := client.Data()
+	if err != nil {
+		tb.Fatalf("client.Data() error = %v", err)
+	}
+	_, err = writer.Write([]byte(testMessage))
+	td.CmpNoError(tb, err)
+	td.CmpNoError(tb, writer.Close())
+	td.CmpNoError(tb, client.Quit())
+}
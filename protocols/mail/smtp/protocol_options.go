@@ -0,0 +1,36 @@
+package smtp
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/mail"
+)
+
+const (
+	defaultDomain          = "inetmock.local"
+	defaultMaxMessageBytes = 25 * 1024 * 1024
+	defaultMaxRecipients   = 100
+)
+
+type smtpOptions struct {
+	Domain          string
+	DataDir         string
+	MaxMessageBytes int
+	MaxRecipients   int
+	Rules           []string
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec, mailDir string) (opts smtpOptions, err error) {
+	opts = smtpOptions{
+		Domain:          defaultDomain,
+		MaxMessageBytes: defaultMaxMessageBytes,
+		MaxRecipients:   defaultMaxRecipients,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts); err != nil {
+		return opts, err
+	}
+
+	opts.DataDir = mail.ResolveDataDir(mailDir, opts.DataDir)
+
+	return opts, nil
+}
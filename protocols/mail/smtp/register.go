@@ -0,0 +1,23 @@
+package smtp
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/cert"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+func New(logger logging.Logger, emitter audit.Emitter, certStore cert.Store, mailDir string) endpoint.ProtocolHandler {
+	return &smtpHandler{
+		logger:    logger,
+		emitter:   emitter,
+		certStore: certStore,
+		mailDir:   mailDir,
+	}
+}
+
+func AddSMTPMock(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter, certStore cert.Store, mailDir string) {
+	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
+		return New(logger, emitter, certStore, mailDir)
+	})
+}
@@ -0,0 +1,219 @@
+package smtp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/emersion/go-smtp"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+const (
+	defaultRejectCode    = 550
+	defaultRejectMessage = "Requested action not taken: mailbox unavailable"
+)
+
+var (
+	knownEnvelopeFilters = map[string]func(args ...rules.Param) (EnvelopeFilter, error){
+		"from": FromFilter,
+		"to":   ToFilter,
+		"helo": HeloFilter,
+	}
+	knownVerdicts = map[string]func(args ...rules.Param) (*smtp.SMTPError, error){
+		"accept": AcceptVerdict,
+		"reject": RejectVerdict,
+	}
+)
+
+type (
+	// Envelope contains the information a rule may match on.
+	// To is empty as long as no recipient was sent by the client.
+	Envelope struct {
+		Helo string
+		From string
+		To   string
+	}
+	EnvelopeFilter interface {
+		Matches(env Envelope) bool
+	}
+	EnvelopeFilterFunc func(env Envelope) bool
+	FilterChain        []EnvelopeFilter
+
+	// ConditionalVerdict decides about a transaction if all filters match,
+	// the transaction is rejected with the given error or accepted if Reject is nil
+	ConditionalVerdict struct {
+		Filters FilterChain
+		Reject  *smtp.SMTPError
+	}
+)
+
+func (f EnvelopeFilterFunc) Matches(env Envelope) bool {
+	return f(env)
+}
+
+func (c FilterChain) Matches(env Envelope) bool {
+	for idx := range c {
+		if !c[idx].Matches(env) {
+			return false
+		}
+	}
+	return true
+}
+
+type RuleHandler struct {
+	HandlerName string
+	verdicts    rules.Set[ConditionalVerdict]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	verdict, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.verdicts.Append(rawRule, verdict)
+	return nil
+}
+
+// RuleManager exposes the envelope rules, changes apply to the next MAIL or RCPT command of open sessions.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalVerdict]{
+		Set:     &h.verdicts,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the verdict of the first rule matching the given envelope.
+// If no rule matches the transaction is accepted.
+func (h *RuleHandler) Evaluate(env Envelope, client string) error {
+	verdicts := h.verdicts.Entries()
+	for idx := range verdicts {
+		entry := verdicts[idx]
+		if entry.Value.Filters.Matches(env) {
+			protocols.RecordRuleMatch(entry, name, h.HandlerName, client)
+			if entry.Value.Reject != nil {
+				return entry.Value.Reject
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func compileRule(rawRule string) (verdict ConditionalVerdict, err error) {
+	var rule *rules.SingleResponsePipeline
+	if rule, err = rules.Parse[rules.SingleResponsePipeline](rawRule); err != nil {
+		return verdict, err
+	}
+
+	if verdict.Filters, err = filtersForRule(rule); err != nil {
+		return verdict, err
+	}
+
+	if rule.Response == nil {
+		return verdict, rules.ErrNoTerminatorDefined
+	}
+
+	if constructor, ok := knownVerdicts[strings.ToLower(rule.Response.Name)]; !ok {
+		return verdict, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response.Name)
+	} else if verdict.Reject, err = constructor(rule.Response.Params...); err != nil {
+		return verdict, err
+	}
+
+	return verdict, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownEnvelopeFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+func FromFilter(args ...rules.Param) (EnvelopeFilter, error) {
+	return regexFilter(args, func(env Envelope) string {
+		return env.From
+	})
+}
+
+// ToFilter matches the current recipient - it never matches before the client sent a recipient
+func ToFilter(args ...rules.Param) (EnvelopeFilter, error) {
+	return regexFilter(args, func(env Envelope) string {
+		return env.To
+	})
+}
+
+func HeloFilter(args ...rules.Param) (EnvelopeFilter, error) {
+	return regexFilter(args, func(env Envelope) string {
+		return env.Helo
+	})
+}
+
+func regexFilter(args []rules.Param, selector func(env Envelope) string) (EnvelopeFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var exp *regexp.Regexp
+	if rawExp, err := args[0].AsString(); err != nil {
+		return nil, err
+	} else if exp, err = regexp.Compile(rawExp); err != nil {
+		return nil, err
+	}
+
+	return EnvelopeFilterFunc(func(env Envelope) bool {
+		val := selector(env)
+		return val != "" && exp.MatchString(val)
+	}), nil
+}
+
+func AcceptVerdict(...rules.Param) (*smtp.SMTPError, error) {
+	return nil, nil
+}
+
+// RejectVerdict rejects a transaction with an optional SMTP status code and message e.g. Reject(550, "unknown user")
+func RejectVerdict(args ...rules.Param) (*smtp.SMTPError, error) {
+	smtpErr := &smtp.SMTPError{
+		Code:         defaultRejectCode,
+		EnhancedCode: smtp.EnhancedCodeNotSet,
+		Message:      defaultRejectMessage,
+	}
+
+	if len(args) > 0 {
+		code, err := args[0].AsInt()
+		if err != nil {
+			return nil, err
+		}
+		if code < 400 || code > 599 {
+			return nil, fmt.Errorf("%w: reject code %d is not a 4xx or 5xx status", rules.ErrTypeMismatch, code)
+		}
+		smtpErr.Code = code
+	}
+
+	if len(args) > 1 {
+		msg, err := args[1].AsString()
+		if err != nil {
+			return nil, err
+		}
+		smtpErr.Message = msg
+	}
+
+	return smtpErr, nil
+}
@@ -0,0 +1,76 @@
+package mail
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	MessageFileExtension = ".eml"
+	defaultDirPerm       = 0o750
+	randomSuffixLength   = 4
+)
+
+// DirStore persists messages as .eml files in a directory
+// such that they can be inspected after an analysis or be served by another mock.
+type DirStore struct {
+	Dir string
+}
+
+func NewDirStore(dir string) (*DirStore, error) {
+	if err := os.MkdirAll(dir, defaultDirPerm); err != nil {
+		return nil, err
+	}
+	return &DirStore{Dir: dir}, nil
+}
+
+// Save writes the message read from r to a new file in the store.
+// The file is written to a temporary name first and renamed afterwards,
+// hence readers of the store never see incomplete messages.
+func (s DirStore) Save(r io.Reader) (path string, size int64, err error) {
+	var tmpFile *os.File
+	if tmpFile, err = os.CreateTemp(s.Dir, ".incoming-*"); err != nil {
+		return "", 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmpFile.Name())
+		}
+	}()
+
+	if size, err = io.Copy(tmpFile, r); err != nil {
+		_ = tmpFile.Close()
+		return "", 0, err
+	}
+
+	if err = tmpFile.Close(); err != nil {
+		return "", 0, err
+	}
+
+	var fileName string
+	if fileName, err = newMessageFileName(time.Now().UTC()); err != nil {
+		return "", 0, err
+	}
+
+	path = filepath.Join(s.Dir, fileName)
+	if err = os.Rename(tmpFile.Name(), path); err != nil {
+		return "", 0, err
+	}
+
+	return path, size, nil
+}
+
+func newMessageFileName(receivedAt time.Time) (string, error) {
+	suffix := make([]byte, randomSuffixLength)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%s%s", receivedAt.Format("20060102T150405.000000000Z"), hex.EncodeToString(suffix), MessageFileExtension), nil
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"errors"
+	"io"
+
+	"github.com/emersion/go-message"
+	gomail "github.com/emersion/go-message/mail"
+)
+
+// Summary contains the parts of a message that are relevant for auditing
+type Summary struct {
+	Subject     string
+	Attachments []string
+}
+
+// Summarize parses the given message and collects its subject and the file names of all attachments.
+// Messages using unknown charsets are summarized as far as possible.
+func Summarize(r io.Reader) (summary Summary, err error) {
+	var reader *gomail.Reader
+	if reader, err = gomail.CreateReader(r); err != nil && !message.IsUnknownCharset(err) {
+		return summary, err
+	}
+
+	summary.Subject, _ = reader.Header.Subject()
+
+	for {
+		part, err := reader.NextPart()
+		switch {
+		case errors.Is(err, io.EOF):
+			return summary, nil
+		case err != nil && !message.IsUnknownCharset(err):
+			return summary, err
+		}
+
+		if header, ok := part.Header.(*gomail.AttachmentHeader); ok {
+			if fileName, err := header.Filename(); err == nil && fileName != "" {
+				summary.Attachments = append(summary.Attachments, fileName)
+			}
+		}
+	}
+}
@@ -3,10 +3,14 @@ package protocols
 import (
 	"github.com/prometheus/client_golang/prometheus"
 
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
 	"inetmock.icb4dc0.de/inetmock/pkg/metrics"
 )
 
-var RequestDurationHistogram *prometheus.HistogramVec
+var (
+	RequestDurationHistogram *prometheus.HistogramVec
+	RuleMatchesCounter       *prometheus.CounterVec
+)
 
 func init() {
 	var err error
@@ -22,4 +26,24 @@ func init() {
 			panic(err)
 		}
 	}
+
+	if RuleMatchesCounter == nil {
+		if RuleMatchesCounter, err = metrics.Counter(
+			"protocols",
+			"rule_matches_total",
+			"Number of requests answered by a rule",
+			"handler",
+			"endpoint",
+			"rule_id",
+		); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RecordRuleMatch updates the statistics of the given rule and the corresponding metric.
+// The metric is labeled with the ID of the rule to keep the series of a rule when other rules are inserted or deleted.
+func RecordRuleMatch[T any](entry *rules.Entry[T], handler, endpoint string, client string) {
+	entry.Hit(client)
+	RuleMatchesCounter.WithLabelValues(handler, endpoint, entry.ID()).Inc()
 }
@@ -0,0 +1,172 @@
+package mqtt
+
+import (
+	"sort"
+	"sync"
+)
+
+// maxGrantedQoS is the highest QoS level granted to subscriptions,
+// messages are delivered at most with QoS 1 hence the broker never has to handle PUBREC packets of clients
+const maxGrantedQoS byte = 1
+
+type message struct {
+	topic   string
+	payload []byte
+	qos     byte
+	retain  bool
+}
+
+type delivery struct {
+	session *session
+	message message
+}
+
+// broker keeps track of the connected clients, their subscriptions and the retained messages.
+// Sessions are not persisted, subscriptions are dropped as soon as the client disconnects.
+type broker struct {
+	lock     sync.Mutex
+	sessions map[string]*session
+	retained map[string]message
+}
+
+func newBroker(retained map[string]string) *broker {
+	b := &broker{
+		sessions: make(map[string]*session),
+		retained: make(map[string]message, len(retained)),
+	}
+
+	for topic, payload := range retained {
+		b.retained[topic] = message{topic: topic, payload: []byte(payload), qos: maxGrantedQoS, retain: true}
+	}
+
+	return b
+}
+
+// connect registers a session and returns the session previously connected with the same client ID if any
+func (b *broker) connect(s *session) (previous *session) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	previous = b.sessions[s.clientID]
+	b.sessions[s.clientID] = s
+	return previous
+}
+
+// disconnect removes the session unless it was already replaced by another connection with the same client ID
+func (b *broker) disconnect(s *session) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.sessions[s.clientID] == s {
+		delete(b.sessions, s.clientID)
+	}
+}
+
+// subscribe adds or replaces the subscriptions of a session and returns the retained messages to send,
+// subscriptions are expected to be validated already
+func (b *broker) subscribe(s *session, subs []subscription) (retained []message) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, sub := range subs {
+		_, existed := s.subscriptions[sub.filter]
+		s.subscriptions[sub.filter] = sub
+
+		if sub.retainHandling == retainHandlingNever || (sub.retainHandling == retainHandlingNew && existed) {
+			continue
+		}
+
+		for _, msg := range b.retainedMatching(sub.filter) {
+			msg.qos = lowerQoS(msg.qos, sub.qos)
+			retained = append(retained, msg)
+		}
+	}
+
+	return retained
+}
+
+// unsubscribe removes the subscriptions of a session and reports for every filter whether a subscription existed
+func (b *broker) unsubscribe(s *session, filters []string) (existed []bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	existed = make([]bool, len(filters))
+	for idx, filter := range filters {
+		_, existed[idx] = s.subscriptions[filter]
+		delete(s.subscriptions, filter)
+	}
+
+	return existed
+}
+
+// publish stores retained messages and delivers the message to all sessions with matching subscriptions.
+// Every session receives the message at most once with the highest QoS of all matching subscriptions.
+func (b *broker) publish(msg message, from *session) (recipients int) {
+	var deliveries []delivery
+
+	b.lock.Lock()
+	if msg.retain {
+		if len(msg.payload) == 0 {
+			delete(b.retained, msg.topic)
+		} else {
+			b.retained[msg.topic] = msg
+		}
+	}
+
+	for _, s := range b.sessions {
+		if d, ok := deliveryFor(s, msg, from); ok {
+			deliveries = append(deliveries, d)
+		}
+	}
+	b.lock.Unlock()
+
+	for _, d := range deliveries {
+		d.session.deliver(d.message)
+	}
+
+	return len(deliveries)
+}
+
+func (b *broker) retainedMatching(filter string) (retained []message) {
+	for topic, msg := range b.retained {
+		if topicMatches(filter, topic) {
+			retained = append(retained, msg)
+		}
+	}
+
+	sort.Slice(retained, func(i, j int) bool {
+		return retained[i].topic < retained[j].topic
+	})
+
+	return retained
+}
+
+func deliveryFor(s *session, msg message, from *session) (d delivery, matched bool) {
+	d = delivery{session: s, message: message{topic: msg.topic, payload: msg.payload}}
+
+	for _, sub := range s.subscriptions {
+		if (sub.noLocal && s == from) || !topicMatches(sub.filter, msg.topic) {
+			continue
+		}
+
+		matched = true
+		d.message.qos = higherQoS(d.message.qos, lowerQoS(msg.qos, sub.qos))
+		d.message.retain = d.message.retain || (sub.retainAsPublished && msg.retain)
+	}
+
+	return d, matched
+}
+
+func lowerQoS(a, b byte) byte {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func higherQoS(a, b byte) byte {
+	if a > b {
+		return a
+	}
+	return b
+}
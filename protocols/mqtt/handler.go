@@ -0,0 +1,126 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/multiplexing"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const name = "mqtt_mock"
+
+var (
+	_ endpoint.MultiplexHandler        = (*mqttHandler)(nil)
+	_ endpoint.MessageInjectingHandler = (*mqttHandler)(nil)
+)
+
+type mqttHandler struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	options     mqttOptions
+	ruleHandler *RuleHandler
+	broker      *broker
+	clientIDs   atomic.Uint64
+	conns       endpoint.ConnTracker
+}
+
+func (h *mqttHandler) Matchers() []cmux.Matcher {
+	return []cmux.Matcher{multiplexing.MQTT()}
+}
+
+func (h *mqttHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	ruleHandler := &RuleHandler{
+		HandlerName: startupSpec.Name,
+	}
+
+	for idx := range h.options.Rules {
+		rule := h.options.Rules[idx]
+		if err = ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+	h.ruleHandler = ruleHandler
+	h.broker = newBroker(h.options.Retained)
+
+	h.conns.Open()
+
+	go h.serve(startupSpec.Listener)
+	return nil
+}
+
+// Stop disconnects all clients, their subscriptions and the retained messages are discarded on the next start
+func (h *mqttHandler) Stop(context.Context) error {
+	h.conns.CloseAll()
+	return nil
+}
+
+func (h *mqttHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+// InjectMessage publishes the message to all clients subscribed to the target topic,
+// the sender is ignored because MQTT messages don't carry any information about their publisher
+func (h *mqttHandler) InjectMessage(target, _, text string) (recipients int, err error) {
+	if !h.conns.IsOpen() {
+		return 0, endpoint.ErrNotServing
+	}
+
+	if !validTopicName(target) {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidTopic, target)
+	}
+
+	return h.broker.publish(message{topic: target, payload: []byte(text), qos: maxGrantedQoS}, nil), nil
+}
+
+func (h *mqttHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept MQTT connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *mqttHandler) handleConn(conn net.Conn) {
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+	}()
+
+	if err := endpoint.IgnoreShutdownError(newSession(h, conn).serve()); err != nil {
+		h.logger.Debug("MQTT session terminated", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+	}
+}
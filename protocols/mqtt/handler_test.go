@@ -0,0 +1,453 @@
+package mqtt_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/mqtt"
+)
+
+const clientTimeout = 5 * time.Second
+
+type publication struct {
+	topic    string
+	payload  string
+	qos      byte
+	retained bool
+}
+
+func Test_mqttHandler_Start(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		opts    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "Start with retained messages and rules",
+			opts: map[string]any{
+				"retained": map[string]any{
+					"bots/config": "interval=60",
+				},
+				"rules": []string{
+					`Packet("subscribe") -> Topic("^bots/") => Publish("bots/commands", "ddos 192.0.2.1")`,
+					`ClientID("^mirai") => Disconnect()`,
+				},
+			},
+		},
+		{
+			name: "Error because of retained message with wildcard topic",
+			opts: map[string]any{
+				"retained": map[string]any{
+					"bots/#": "interval=60",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error because of publish to wildcard topic",
+			opts: map[string]any{
+				"rules": []string{
+					`=> Publish("bots/+", "ddos 192.0.2.1")`,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error because of unknown packet type",
+			opts: map[string]any{
+				"rules": []string{
+					`Packet("pingreq") => Disconnect()`,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error because of unknown action",
+			opts: map[string]any{
+				"rules": []string{
+					`=> Kick()`,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := startHandler(t, new(audit_mock.EmitterMock), tt.opts); (err != nil) != tt.wantErr {
+				t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_mqttHandler_Client(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		opts         map[string]any
+		subscribe    []string
+		publish      []publication
+		wantMessages any
+		wantEvents   any
+	}{
+		{
+			name:      "Receive own message",
+			subscribe: []string{"sensors/+/temperature"},
+			publish: []publication{
+				{topic: "sensors/kitchen/temperature", payload: "21.5", qos: 1},
+				{topic: "sensors/kitchen/humidity", payload: "40"},
+			},
+			wantMessages: []publication{
+				{topic: "sensors/kitchen/temperature", payload: "21.5", qos: 1},
+			},
+			wantEvents: []any{
+				td.Struct(audit.MQTT{
+					PacketType:      auditv1.MQTTPacketType_MQTT_PACKET_TYPE_CONNECT,
+					ProtocolVersion: 4,
+					ClientID:        "bot-1",
+					Username:        "admin",
+					Password:        "secret",
+				}, nil),
+				td.Struct(audit.MQTT{
+					PacketType: auditv1.MQTTPacketType_MQTT_PACKET_TYPE_SUBSCRIBE,
+					Topics:     []string{"sensors/+/temperature"},
+				}, td.StructFields{"ClientID": "bot-1", "Username": "admin"}),
+				td.Struct(audit.MQTT{
+					PacketType:  auditv1.MQTTPacketType_MQTT_PACKET_TYPE_PUBLISH,
+					Topics:      []string{"sensors/kitchen/temperature"},
+					QoS:         1,
+					PayloadSize: 4,
+				}, td.StructFields{"ClientID": "bot-1"}),
+				td.Struct(audit.MQTT{
+					PacketType:  auditv1.MQTTPacketType_MQTT_PACKET_TYPE_PUBLISH,
+					Topics:      []string{"sensors/kitchen/humidity"},
+					PayloadSize: 2,
+				}, td.StructFields{"ClientID": "bot-1"}),
+			},
+		},
+		{
+			name: "Receive configured retained message",
+			opts: map[string]any{
+				"retained": map[string]any{
+					"bots/config":  "interval=60",
+					"other/config": "ignored",
+				},
+			},
+			subscribe: []string{"bots/#"},
+			wantMessages: []publication{
+				{topic: "bots/config", payload: "interval=60", qos: 1, retained: true},
+			},
+			wantEvents: td.Len(2),
+		},
+		{
+			name: "Receive retained message published by the client",
+			publish: []publication{
+				{topic: "bots/status", payload: "online", retained: true},
+			},
+			subscribe: []string{"bots/status"},
+			wantMessages: []publication{
+				{topic: "bots/status", payload: "online", retained: true},
+			},
+			wantEvents: td.Len(3),
+		},
+		{
+			name: "Receive messages published by rules",
+			opts: map[string]any{
+				"rules": []string{
+					`Packet("subscribe") -> Topic("^bots/") => Publish("bots/commands", "ddos 192.0.2.1")`,
+					`Packet("publish") -> Payload("^hello") => Publish("bots/commands", "sleep 60") => Retain("bots/config", "interval=5")`,
+				},
+			},
+			subscribe: []string{"bots/#"},
+			publish: []publication{
+				{topic: "bots/bot-1/status", payload: "hello from bot-1"},
+			},
+			wantMessages: td.Bag(
+				publication{topic: "bots/bot-1/status", payload: "hello from bot-1"},
+				publication{topic: "bots/commands", payload: "ddos 192.0.2.1", qos: 1},
+				publication{topic: "bots/commands", payload: "sleep 60", qos: 1},
+				publication{topic: "bots/config", payload: "interval=5", qos: 1},
+			),
+			wantEvents: td.Len(3),
+		},
+		{
+			name:      "QoS is downgraded to 1",
+			subscribe: []string{"bots/commands"},
+			publish: []publication{
+				{topic: "bots/commands", payload: "update", qos: 2},
+			},
+			wantMessages: []publication{
+				{topic: "bots/commands", payload: "update", qos: 1},
+			},
+			wantEvents: td.Len(3),
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			emitterMock := new(audit_mock.EmitterMock)
+			listener, err := startHandler(t, emitterMock, tt.opts)
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			client := connectClient(t, listener.Addr(), "bot-1")
+			received := make(chan publication, 10)
+
+			for _, p := range tt.publish {
+				if !p.retained {
+					continue
+				}
+				waitToken(t, client.Publish(p.topic, p.qos, p.retained, p.payload))
+			}
+
+			for _, topic := range tt.subscribe {
+				waitToken(t, client.Subscribe(topic, 2, func(_ pahomqtt.Client, msg pahomqtt.Message) {
+					received <- publication{topic: msg.Topic(), payload: string(msg.Payload()), qos: msg.Qos(), retained: msg.Retained()}
+				}))
+			}
+
+			for _, p := range tt.publish {
+				if p.retained {
+					continue
+				}
+				waitToken(t, client.Publish(p.topic, p.qos, p.retained, p.payload))
+			}
+
+			td.Cmp(t, collect(received, tt.wantMessages), tt.wantMessages)
+
+			client.Disconnect(0)
+			test.AwaitEventDetails(t, emitterMock, auditv1.AppProtocol_APP_PROTOCOL_MQTT, tt.wantEvents)
+		})
+	}
+}
+
+func Test_mqttHandler_Raw(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		// send contains complete packets sent one after another, want is the expected response for every packet
+		send [][]byte
+		want [][]byte
+	}{
+		{
+			name: "MQTT 5 connect without client ID",
+			send: [][]byte{
+				{0x10, 0x0d, 0x00, 0x04, 'M', 'Q', 'T', 'T', 0x05, 0x02, 0x00, 0x3c, 0x00, 0x00, 0x00},
+			},
+			want: [][]byte{
+				{0x20, 0x14, 0x00, 0x00, 0x11, 0x24, 0x01, 0x2a, 0x00, 0x12, 0x00, 0x0a, 'i', 'n', 'e', 't', 'm', 'o', 'c', 'k', '-', '1'},
+			},
+		},
+		{
+			name: "MQTT 5 subscribe",
+			send: [][]byte{
+				{0x10, 0x0e, 0x00, 0x04, 'M', 'Q', 'T', 'T', 0x05, 0x02, 0x00, 0x3c, 0x00, 0x00, 0x01, 'a'},
+				{
+					0x82, 0x21, 0x00, 0x01, 0x00,
+					0x00, 0x03, 'a', '/', 'b', 0x02,
+					0x00, 0x03, 'a', '/', '#', 0x01,
+					0x00, 0x03, 'a', '#', 'b', 0x00,
+					0x00, 0x09, '$', 's', 'h', 'a', 'r', 'e', '/', 'g', '/', 0x00,
+				},
+			},
+			want: [][]byte{
+				{0x20, 0x07, 0x00, 0x00, 0x04, 0x24, 0x01, 0x2a, 0x00},
+				{0x90, 0x07, 0x00, 0x01, 0x00, 0x01, 0x01, 0x8f, 0x9e},
+			},
+		},
+		{
+			name: "MQTT 3.1 publish QoS 2",
+			send: [][]byte{
+				{0x10, 0x11, 0x00, 0x06, 'M', 'Q', 'I', 's', 'd', 'p', 0x03, 0x02, 0x00, 0x3c, 0x00, 0x03, 'b', 'o', 't'},
+				{0x34, 0x07, 0x00, 0x01, 'a', 0x00, 0x07, 'h', 'i'},
+				{0x62, 0x02, 0x00, 0x07},
+				{0xc0, 0x00},
+			},
+			want: [][]byte{
+				{0x20, 0x02, 0x00, 0x00},
+				{0x50, 0x02, 0x00, 0x07},
+				{0x70, 0x02, 0x00, 0x07},
+				{0xd0, 0x00},
+			},
+		},
+		{
+			name: "MQTT 3.1.1 unsubscribe",
+			send: [][]byte{
+				{0x10, 0x0f, 0x00, 0x04, 'M', 'Q', 'T', 'T', 0x04, 0x02, 0x00, 0x3c, 0x00, 0x03, 'b', 'o', 't'},
+				{0xa2, 0x05, 0x00, 0x02, 0x00, 0x01, 'a'},
+			},
+			want: [][]byte{
+				{0x20, 0x02, 0x00, 0x00},
+				{0xb0, 0x02, 0x00, 0x02},
+			},
+		},
+		{
+			name: "Unsupported protocol version",
+			send: [][]byte{
+				{0x10, 0x0f, 0x00, 0x04, 'M', 'Q', 'T', 'T', 0x06, 0x02, 0x00, 0x3c, 0x00, 0x03, 'b', 'o', 't'},
+			},
+			want: [][]byte{
+				{0x20, 0x02, 0x00, 0x01},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			listener, err := startHandler(t, new(audit_mock.EmitterMock), nil)
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			conn := dial(t, listener.Addr())
+			for idx := range tt.send {
+				if _, err = conn.Write(tt.send[idx]); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+
+				got := make([]byte, len(tt.want[idx]))
+				if _, err = io.ReadFull(conn, got); err != nil {
+					t.Fatalf("ReadFull() error = %v", err)
+				}
+				td.Cmp(t, got, tt.want[idx])
+			}
+		})
+	}
+}
+
+func Test_mqttHandler_InjectMessage(t *testing.T) {
+	t.Parallel()
+	handler := mqtt.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock)).(endpoint.MessageInjectingHandler)
+
+	if _, err := handler.InjectMessage("bots/commands", "", "ddos"); !errors.Is(err, endpoint.ErrNotServing) {
+		t.Errorf("InjectMessage() error = %v, want %v", err, endpoint.ErrNotServing)
+	}
+
+	listener := test.NewTCPListener(t, "127.0.0.1:0")
+	if err := handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	received := make(chan publication, 10)
+	for _, clientID := range []string{"bot-1", "bot-2"} {
+		client := connectClient(t, listener.Addr(), clientID)
+		waitToken(t, client.Subscribe("bots/+", 1, func(_ pahomqtt.Client, msg pahomqtt.Message) {
+			received <- publication{topic: msg.Topic(), payload: string(msg.Payload()), qos: msg.Qos()}
+		}))
+	}
+
+	recipients, err := handler.InjectMessage("bots/commands", "ignored", "ddos 192.0.2.1")
+	td.CmpNoError(t, err)
+	td.Cmp(t, recipients, 2)
+
+	want := []publication{
+		{topic: "bots/commands", payload: "ddos 192.0.2.1", qos: 1},
+		{topic: "bots/commands", payload: "ddos 192.0.2.1", qos: 1},
+	}
+	td.Cmp(t, collect(received, want), want)
+
+	recipients, err = handler.InjectMessage("other/commands", "", "ddos 192.0.2.1")
+	td.CmpNoError(t, err)
+	td.Cmp(t, recipients, 0)
+
+	if _, err = handler.InjectMessage("bots/#", "", "ddos"); !errors.Is(err, mqtt.ErrInvalidTopic) {
+		t.Errorf("InjectMessage() error = %v, want %v", err, mqtt.ErrInvalidTopic)
+	}
+}
+
+func startHandler(tb testing.TB, emitter *audit_mock.EmitterMock, opts map[string]any) (net.Listener, error) {
+	tb.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+
+	listener := test.NewTCPListener(tb, "127.0.0.1:0")
+	handler := mqtt.New(logging.CreateTestLogger(tb), emitter)
+	return listener, handler.Start(ctx, endpoint.NewStartupSpec(tb.Name(), endpoint.NewUplink(listener), opts))
+}
+
+func connectClient(tb testing.TB, addr net.Addr, clientID string) pahomqtt.Client {
+	tb.Helper()
+	opts := pahomqtt.NewClientOptions().
+		AddBroker("tcp://" + addr.String()).
+		SetClientID(clientID).
+		SetUsername("admin").
+		SetPassword("secret").
+		SetAutoReconnect(false).
+		SetConnectTimeout(clientTimeout)
+
+	client := pahomqtt.NewClient(opts)
+	waitToken(tb, client.Connect())
+	tb.Cleanup(func() {
+		client.Disconnect(0)
+	})
+
+	return client
+}
+
+func waitToken(tb testing.TB, token pahomqtt.Token) {
+	tb.Helper()
+	if !token.WaitTimeout(clientTimeout) {
+		tb.Fatal("timeout while waiting for MQTT operation")
+	}
+	if err := token.Error(); err != nil {
+		tb.Fatalf("MQTT operation failed: %v", err)
+	}
+}
+
+func dial(tb testing.TB, addr net.Addr) net.Conn {
+	tb.Helper()
+	conn, err := net.DialTimeout("tcp", addr.String(), clientTimeout)
+	if err != nil {
+		tb.Fatalf("net.Dial() error = %v", err)
+	}
+	tb.Cleanup(func() {
+		_ = conn.Close()
+	})
+	_ = conn.SetDeadline(time.Now().Add(clientTimeout))
+	return conn
+}
+
+// collect waits for as many messages as expected or until no further message is received for a short time
+func collect(received <-chan publication, want any) (got []publication) {
+	expected := 0
+	if w, ok := want.([]publication); ok {
+		expected = len(w)
+	}
+
+	timeout := time.After(clientTimeout)
+	for {
+		if expected > 0 && len(got) >= expected {
+			return got
+		}
+
+		select {
+		case p := <-received:
+			got = append(got, p)
+		case <-time.After(200 * time.Millisecond):
+			if expected == 0 {
+				return got
+			}
+		case <-timeout:
+			return got
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	packetConnect     byte = 1
+	packetConnack     byte = 2
+	packetPublish     byte = 3
+	packetPuback      byte = 4
+	packetPubrec      byte = 5
+	packetPubrel      byte = 6
+	packetPubcomp     byte = 7
+	packetSubscribe   byte = 8
+	packetSuback      byte = 9
+	packetUnsubscribe byte = 10
+	packetUnsuback    byte = 11
+	packetPingreq     byte = 12
+	packetPingresp    byte = 13
+	packetDisconnect  byte = 14
+
+	protocolVersion31  byte = 3
+	protocolVersion311 byte = 4
+	protocolVersion5   byte = 5
+
+	// flags of the fixed header of PUBREL, SUBSCRIBE and UNSUBSCRIBE packets
+	reservedFlags byte = 0x02
+
+	packetTypeShift     = 4
+	flagsMask           = 0x0f
+	varIntValueMask     = 0x7f
+	varIntContinueBit   = 0x80
+	varIntShift         = 7
+	maxVarIntBytes      = 4
+	uint16Length        = 2
+	packetIDLength      = 2
+	maxFixedHeaderBytes = 1 + maxVarIntBytes
+)
+
+var (
+	errMalformedPacket = errors.New("malformed MQTT packet")
+	errPacketTooLarge  = errors.New("MQTT packet exceeds the maximum packet size")
+)
+
+type packet struct {
+	packetType byte
+	flags      byte
+	body       []byte
+}
+
+// readPacket reads a complete control packet, packets larger than maxSize are rejected before reading their body
+func readPacket(reader *bufio.Reader, maxSize int) (pkt packet, err error) {
+	var header byte
+	if header, err = reader.ReadByte(); err != nil {
+		return pkt, err
+	}
+
+	pkt.packetType = header >> packetTypeShift
+	pkt.flags = header & flagsMask
+
+	var length int
+	if length, err = readVarInt(reader); err != nil {
+		return pkt, err
+	}
+
+	if length > maxSize {
+		return pkt, fmt.Errorf("%w: %d bytes", errPacketTooLarge, length)
+	}
+
+	pkt.body = make([]byte, length)
+	if _, err = io.ReadFull(reader, pkt.body); err != nil {
+		return pkt, err
+	}
+
+	return pkt, nil
+}
+
+// encodePacket prepends the fixed header to the given body
+func encodePacket(packetType, flags byte, body []byte) []byte {
+	encoded := make([]byte, 0, maxFixedHeaderBytes+len(body))
+	encoded = append(encoded, packetType<<packetTypeShift|flags&flagsMask)
+	encoded = appendVarInt(encoded, len(body))
+	return append(encoded, body...)
+}
+
+func readVarInt(reader io.ByteReader) (value int, err error) {
+	for i := 0; i < maxVarIntBytes; i++ {
+		var b byte
+		if b, err = reader.ReadByte(); err != nil {
+			return 0, err
+		}
+
+		value |= int(b&varIntValueMask) << (varIntShift * i)
+		if b&varIntContinueBit == 0 {
+			return value, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: variable byte integer exceeds %d bytes", errMalformedPacket, maxVarIntBytes)
+}
+
+func appendVarInt(b []byte, value int) []byte {
+	for {
+		digit := byte(value & varIntValueMask)
+		value >>= varIntShift
+		if value > 0 {
+			digit |= varIntContinueBit
+		}
+		b = append(b, digit)
+		if value == 0 {
+			return b
+		}
+	}
+}
+
+func appendUint16(b []byte, value uint16) []byte {
+	return binary.BigEndian.AppendUint16(b, value)
+}
+
+func appendString(b []byte, value string) []byte {
+	b = appendUint16(b, uint16(len(value)))
+	return append(b, value...)
+}
+
+// decoder reads the fields of the variable header and the payload of a packet.
+// The first error is kept and all subsequent reads return zero values.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = errMalformedPacket
+	}
+	d.data = nil
+}
+
+func (d *decoder) remaining() int {
+	return len(d.data)
+}
+
+func (d *decoder) byte() byte {
+	if len(d.data) < 1 {
+		d.fail()
+		return 0
+	}
+
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *decoder) uint16() uint16 {
+	if len(d.data) < uint16Length {
+		d.fail()
+		return 0
+	}
+
+	value := binary.BigEndian.Uint16(d.data)
+	d.data = d.data[uint16Length:]
+	return value
+}
+
+// binary reads a length prefixed byte sequence
+func (d *decoder) binary() []byte {
+	length := int(d.uint16())
+	if len(d.data) < length {
+		d.fail()
+		return nil
+	}
+
+	value := d.data[:length]
+	d.data = d.data[length:]
+	return value
+}
+
+func (d *decoder) string() string {
+	return string(d.binary())
+}
+
+// skipProperties skips the properties of MQTT 5 packets, none of them is required to handle a packet
+func (d *decoder) skipProperties() {
+	reader := byteSliceReader{data: d.data}
+	length, err := readVarInt(&reader)
+	if err != nil || len(reader.data) < length {
+		d.fail()
+		return
+	}
+
+	d.data = reader.data[length:]
+}
+
+// rest returns all remaining bytes e.g. the payload of a PUBLISH packet
+func (d *decoder) rest() []byte {
+	value := d.data
+	d.data = nil
+	return value
+}
+
+type byteSliceReader struct {
+	data []byte
+}
+
+func (r *byteSliceReader) ReadByte() (byte, error) {
+	if len(r.data) == 0 {
+		return 0, errMalformedPacket
+	}
+
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b, nil
+}
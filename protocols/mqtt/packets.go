@@ -0,0 +1,264 @@
+package mqtt
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	connectFlagWill         = 0x04
+	connectFlagWillQoSShift = 3
+	connectFlagWillRetain   = 0x20
+	connectFlagPassword     = 0x40
+	connectFlagUsername     = 0x80
+
+	publishFlagRetain   = 0x01
+	publishFlagQoSShift = 1
+
+	subscriptionQoSMask           = 0x03
+	subscriptionNoLocal           = 0x04
+	subscriptionRetainAsPublished = 0x08
+	subscriptionRetainHandling    = 4
+
+	// retained messages are either sent on every subscribe, only for new subscriptions or never
+	retainHandlingNew   byte = 1
+	retainHandlingNever byte = 2
+
+	qosMask             = 0x03
+	qosAtLeastOnce byte = 1
+	qosExactlyOnce byte = 2
+
+	protocolNameMQTT   = "MQTT"
+	protocolNameMQIsdp = "MQIsdp"
+
+	// return and reason codes of the different protocol versions
+	connackAccepted                  byte = 0x00
+	connackUnacceptableVersion       byte = 0x01
+	subackFailure                    byte = 0x80
+	subackTopicFilterInvalid5        byte = 0x8f
+	subackSharedSubscriptionsInvalid byte = 0x9e
+	unsubackNoSubscriptionExisted5   byte = 0x11
+
+	// properties of MQTT 5 packets
+	propertyAssignedClientID        byte = 0x12
+	propertyMaximumQoS              byte = 0x24
+	propertySharedSubscriptionAvail byte = 0x2a
+)
+
+var errUnsupportedProtocolVersion = errors.New("unsupported MQTT protocol version")
+
+type connectPacket struct {
+	protocolVersion byte
+	keepAlive       uint16
+	clientID        string
+	will            *message
+	username        string
+	password        string
+}
+
+type publishPacket struct {
+	message
+	packetID uint16
+}
+
+type subscription struct {
+	filter            string
+	qos               byte
+	noLocal           bool
+	retainAsPublished bool
+	retainHandling    byte
+}
+
+type subscribePacket struct {
+	packetID      uint16
+	subscriptions []subscription
+}
+
+type unsubscribePacket struct {
+	packetID uint16
+	filters  []string
+}
+
+// decodeConnect decodes a CONNECT packet of any supported protocol version,
+// if the protocol version is unknown the returned packet contains the requested version and errUnsupportedProtocolVersion
+func decodeConnect(body []byte) (pkt connectPacket, err error) {
+	d := decoder{data: body}
+	protocolName := d.string()
+	pkt.protocolVersion = d.byte()
+	flags := d.byte()
+	pkt.keepAlive = d.uint16()
+
+	if d.err != nil {
+		return pkt, d.err
+	}
+
+	switch {
+	case protocolName == protocolNameMQIsdp && pkt.protocolVersion == protocolVersion31,
+		protocolName == protocolNameMQTT && (pkt.protocolVersion == protocolVersion311 || pkt.protocolVersion == protocolVersion5):
+	default:
+		return pkt, fmt.Errorf("%w: %s %d", errUnsupportedProtocolVersion, protocolName, pkt.protocolVersion)
+	}
+
+	if pkt.protocolVersion == protocolVersion5 {
+		d.skipProperties()
+	}
+
+	pkt.clientID = d.string()
+
+	if flags&connectFlagWill != 0 {
+		if pkt.protocolVersion == protocolVersion5 {
+			d.skipProperties()
+		}
+		pkt.will = &message{
+			topic:  d.string(),
+			qos:    (flags >> connectFlagWillQoSShift) & qosMask,
+			retain: flags&connectFlagWillRetain != 0,
+		}
+		pkt.will.payload = d.binary()
+	}
+
+	if flags&connectFlagUsername != 0 {
+		pkt.username = d.string()
+	}
+
+	if flags&connectFlagPassword != 0 {
+		pkt.password = d.string()
+	}
+
+	return pkt, d.err
+}
+
+func encodeConnack(version, reasonCode byte, assignedClientID string) []byte {
+	// the session is never present because sessions are not kept after the client disconnected
+	body := []byte{0x00, reasonCode}
+	if version == protocolVersion5 {
+		var properties []byte
+		properties = append(properties, propertyMaximumQoS, maxGrantedQoS, propertySharedSubscriptionAvail, 0)
+		if assignedClientID != "" {
+			properties = appendString(append(properties, propertyAssignedClientID), assignedClientID)
+		}
+		body = append(appendVarInt(body, len(properties)), properties...)
+	}
+
+	return encodePacket(packetConnack, 0, body)
+}
+
+func decodePublish(version, flags byte, body []byte) (pkt publishPacket, err error) {
+	d := decoder{data: body}
+	pkt.topic = d.string()
+	pkt.qos = (flags >> publishFlagQoSShift) & qosMask
+	pkt.retain = flags&publishFlagRetain != 0
+
+	if pkt.qos > qosExactlyOnce {
+		return pkt, fmt.Errorf("%w: invalid QoS %d", errMalformedPacket, pkt.qos)
+	}
+
+	if pkt.qos > 0 {
+		pkt.packetID = d.uint16()
+	}
+
+	if version == protocolVersion5 {
+		d.skipProperties()
+	}
+
+	if d.err != nil {
+		return pkt, d.err
+	}
+
+	pkt.payload = d.rest()
+	return pkt, nil
+}
+
+func encodePublish(version byte, msg message, packetID uint16) []byte {
+	var flags byte
+	if msg.retain {
+		flags |= publishFlagRetain
+	}
+	flags |= msg.qos << publishFlagQoSShift
+
+	body := make([]byte, 0, uint16Length+len(msg.topic)+packetIDLength+1+len(msg.payload))
+	body = appendString(body, msg.topic)
+	if msg.qos > 0 {
+		body = appendUint16(body, packetID)
+	}
+	if version == protocolVersion5 {
+		body = appendVarInt(body, 0)
+	}
+
+	return encodePacket(packetPublish, flags, append(body, msg.payload...))
+}
+
+// encodeAck encodes PUBACK, PUBREC, PUBREL and PUBCOMP packets,
+// MQTT 5 allows to omit the reason code and the properties if the reason code is 0
+func encodeAck(packetType byte, packetID uint16) []byte {
+	var flags byte
+	if packetType == packetPubrel {
+		flags = reservedFlags
+	}
+	return encodePacket(packetType, flags, appendUint16(nil, packetID))
+}
+
+func decodePacketID(body []byte) (uint16, error) {
+	d := decoder{data: body}
+	packetID := d.uint16()
+	return packetID, d.err
+}
+
+func decodeSubscribe(version byte, body []byte) (pkt subscribePacket, err error) {
+	d := decoder{data: body}
+	pkt.packetID = d.uint16()
+	if version == protocolVersion5 {
+		d.skipProperties()
+	}
+
+	for d.err == nil && d.remaining() > 0 {
+		sub := subscription{filter: d.string()}
+		options := d.byte()
+		sub.qos = options & subscriptionQoSMask
+		sub.noLocal = options&subscriptionNoLocal != 0
+		sub.retainAsPublished = options&subscriptionRetainAsPublished != 0
+		sub.retainHandling = (options >> subscriptionRetainHandling) & qosMask
+		pkt.subscriptions = append(pkt.subscriptions, sub)
+	}
+
+	if d.err == nil && len(pkt.subscriptions) == 0 {
+		return pkt, fmt.Errorf("%w: SUBSCRIBE without topic filters", errMalformedPacket)
+	}
+
+	return pkt, d.err
+}
+
+func encodeSuback(version byte, packetID uint16, reasonCodes []byte) []byte {
+	body := appendUint16(make([]byte, 0, packetIDLength+1+len(reasonCodes)), packetID)
+	if version == protocolVersion5 {
+		body = appendVarInt(body, 0)
+	}
+	return encodePacket(packetSuback, 0, append(body, reasonCodes...))
+}
+
+func decodeUnsubscribe(version byte, body []byte) (pkt unsubscribePacket, err error) {
+	d := decoder{data: body}
+	pkt.packetID = d.uint16()
+	if version == protocolVersion5 {
+		d.skipProperties()
+	}
+
+	for d.err == nil && d.remaining() > 0 {
+		pkt.filters = append(pkt.filters, d.string())
+	}
+
+	if d.err == nil && len(pkt.filters) == 0 {
+		return pkt, fmt.Errorf("%w: UNSUBSCRIBE without topic filters", errMalformedPacket)
+	}
+
+	return pkt, d.err
+}
+
+// encodeUnsuback encodes an UNSUBACK packet, reason codes are only sent to MQTT 5 clients
+func encodeUnsuback(version byte, packetID uint16, reasonCodes []byte) []byte {
+	body := appendUint16(nil, packetID)
+	if version == protocolVersion5 {
+		body = append(appendVarInt(body, 0), reasonCodes...)
+	}
+	return encodePacket(packetUnsuback, 0, body)
+}
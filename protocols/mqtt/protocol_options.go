@@ -0,0 +1,44 @@
+package mqtt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+const (
+	defaultConnectTimeout = 10 * time.Second
+	defaultMaxPacketSize  = 1024 * 1024
+)
+
+type mqttOptions struct {
+	// ConnectTimeout closes connections not sending a CONNECT packet within the given time
+	ConnectTimeout time.Duration
+	// MaxPacketSize limits the size of packets accepted from clients, clients sending larger packets are disconnected
+	MaxPacketSize int
+	// Retained maps topics to messages retained when the endpoint is started
+	Retained map[string]string
+	Rules    []string
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts mqttOptions, err error) {
+	opts = mqttOptions{
+		ConnectTimeout: defaultConnectTimeout,
+		MaxPacketSize:  defaultMaxPacketSize,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc())); err != nil {
+		return opts, err
+	}
+
+	for topic := range opts.Retained {
+		if !validTopicName(topic) {
+			return opts, fmt.Errorf("%w: %s", ErrInvalidTopic, topic)
+		}
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,272 @@
+package mqtt
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+const (
+	requestConnect   = "connect"
+	requestSubscribe = "subscribe"
+	requestPublish   = "publish"
+)
+
+var (
+	ErrUnknownPacketType = errors.New("unknown MQTT packet type")
+
+	knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
+		"packet":   PacketFilter,
+		"topic":    TopicFilter,
+		"clientid": ClientIDFilter,
+		"username": UsernameFilter,
+		"payload":  PayloadFilter,
+	}
+	knownActions = map[string]func(args ...rules.Param) (Action, error){
+		"publish":    PublishAction,
+		"retain":     RetainAction,
+		"disconnect": DisconnectAction,
+	}
+)
+
+type (
+	// Request is a packet sent by a client.
+	// Packet is either connect, subscribe or publish, Topic is the topic filter of a single subscription or the
+	// topic name of a published message, Payload is only set for published messages.
+	Request struct {
+		Packet   string
+		ClientID string
+		Username string
+		Topic    string
+		Payload  []byte
+	}
+	RequestFilter interface {
+		Matches(req Request) bool
+	}
+	RequestFilterFunc func(req Request) bool
+	FilterChain       []RequestFilter
+
+	// Action is executed after the client's packet was acknowledged.
+	// It either publishes a message to all subscribers of the topic or disconnects the client.
+	Action struct {
+		Disconnect bool
+		Message    message
+	}
+
+	Response []Action
+
+	ConditionalResponse struct {
+		Filters  FilterChain
+		Response Response
+	}
+)
+
+func (f RequestFilterFunc) Matches(req Request) bool {
+	return f(req)
+}
+
+func (c FilterChain) Matches(req Request) bool {
+	for idx := range c {
+		if !c[idx].Matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+type RuleHandler struct {
+	HandlerName string
+	responses   rules.Set[ConditionalResponse]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	response, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.responses.Append(rawRule, response)
+	return nil
+}
+
+// RuleManager exposes the CONNECT, SUBSCRIBE and PUBLISH rules, changes apply to the next packet of connected clients.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalResponse]{
+		Set:     &h.responses,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the response of the first rule matching the given request
+func (h *RuleHandler) Evaluate(req Request, client string) (Response, bool) {
+	responses := h.responses.Entries()
+	for idx := range responses {
+		entry := responses[idx]
+		if entry.Value.Filters.Matches(req) {
+			protocols.RecordRuleMatch(entry, name, h.HandlerName, client)
+			return entry.Value.Response, true
+		}
+	}
+
+	return nil, false
+}
+
+func compileRule(rawRule string) (response ConditionalResponse, err error) {
+	var rule *rules.ChainedResponsePipeline
+	if rule, err = rules.Parse[rules.ChainedResponsePipeline](rawRule); err != nil {
+		return response, err
+	}
+
+	if response.Filters, err = filtersForRule(rule); err != nil {
+		return response, err
+	}
+
+	if len(rule.Response) == 0 {
+		return response, rules.ErrNoTerminatorDefined
+	}
+
+	response.Response = make(Response, 0, len(rule.Response))
+	for idx := range rule.Response {
+		constructor, ok := knownActions[strings.ToLower(rule.Response[idx].Name)]
+		if !ok {
+			return response, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response[idx].Name)
+		}
+
+		var action Action
+		if action, err = constructor(rule.Response[idx].Params...); err != nil {
+			return response, err
+		}
+		response.Response = append(response.Response, action)
+	}
+
+	return response, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownRequestFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+// PacketFilter matches connect, subscribe or publish packets e.g. Packet("subscribe")
+func PacketFilter(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	packet, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	switch packet = strings.ToLower(packet); packet {
+	case requestConnect, requestSubscribe, requestPublish:
+		return RequestFilterFunc(func(req Request) bool {
+			return req.Packet == packet
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPacketType, packet)
+	}
+}
+
+// TopicFilter matches the topic filter of a subscription or the topic of a published message
+// against a regular expression e.g. Topic(`^devices/.+/commands$`)
+func TopicFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return req.Topic
+	})
+}
+
+// ClientIDFilter matches the client ID against a regular expression e.g. ClientID(`^mirai`)
+func ClientIDFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return req.ClientID
+	})
+}
+
+// UsernameFilter matches the username of the client against a regular expression e.g. Username(`^admin$`)
+func UsernameFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return req.Username
+	})
+}
+
+// PayloadFilter matches the payload of a published message against a regular expression e.g. Payload(`"status":\s*"online"`)
+func PayloadFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return string(req.Payload)
+	})
+}
+
+// PublishAction publishes a message to all subscribers of the topic e.g. Publish("bots/commands", "ddos 192.0.2.1")
+func PublishAction(args ...rules.Param) (Action, error) {
+	return publishAction(args, false)
+}
+
+// RetainAction publishes a message and retains it for future subscribers e.g. Retain("bots/config", "{}")
+func RetainAction(args ...rules.Param) (Action, error) {
+	return publishAction(args, true)
+}
+
+// DisconnectAction closes the connection of the client e.g. Disconnect()
+func DisconnectAction(...rules.Param) (Action, error) {
+	return Action{Disconnect: true}, nil
+}
+
+func publishAction(args []rules.Param, retain bool) (action Action, err error) {
+	const expectedParams = 2
+	if err = rules.ValidateParameterCount(args, expectedParams); err != nil {
+		return action, err
+	}
+
+	var topic, payload string
+	if topic, err = args[0].AsString(); err != nil {
+		return action, err
+	}
+
+	if !validTopicName(topic) {
+		return action, fmt.Errorf("%w: %s", ErrInvalidTopic, topic)
+	}
+
+	if payload, err = args[1].AsString(); err != nil {
+		return action, err
+	}
+
+	action.Message = message{topic: topic, payload: []byte(payload), qos: maxGrantedQoS, retain: retain}
+	return action, nil
+}
+
+func regexFilter(args []rules.Param, selector func(req Request) string) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var exp *regexp.Regexp
+	if rawExp, err := args[0].AsString(); err != nil {
+		return nil, err
+	} else if exp, err = regexp.Compile(rawExp); err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return exp.MatchString(selector(req))
+	}), nil
+}
@@ -0,0 +1,365 @@
+package mqtt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const (
+	writeTimeout = 10 * time.Second
+	// clients are disconnected if they didn't send anything for one and a half times the keep alive interval
+	keepAliveGraceFactor = 3
+	keepAliveGraceDivide = 2
+)
+
+var (
+	errDisconnectedByRule = errors.New("disconnected by rule")
+	errUnexpectedPacket   = errors.New("unexpected MQTT packet")
+)
+
+type session struct {
+	handler   *mqttHandler
+	conn      net.Conn
+	reader    *bufio.Reader
+	host      string
+	writeLock sync.Mutex
+	// nextPacketID is guarded by the write lock
+	nextPacketID uint16
+	version      byte
+	clientID     string
+	username     string
+	keepAlive    time.Duration
+	will         *message
+	// pendingReleases contains the IDs of QoS 2 messages the client did not release yet
+	pendingReleases map[uint16]struct{}
+	// subscriptions is guarded by the lock of the broker
+	subscriptions map[string]subscription
+}
+
+func newSession(handler *mqttHandler, conn net.Conn) *session {
+	s := &session{
+		handler:         handler,
+		conn:            conn,
+		reader:          bufio.NewReader(conn),
+		host:            conn.RemoteAddr().String(),
+		pendingReleases: make(map[uint16]struct{}),
+		subscriptions:   make(map[string]subscription),
+	}
+
+	if ip, _, err := netutils.IPPortFromAddress(conn.RemoteAddr()); err == nil {
+		s.host = ip.String()
+	}
+
+	return s
+}
+
+func (s *session) serve() (err error) {
+	_ = s.conn.SetReadDeadline(time.Now().Add(s.handler.options.ConnectTimeout))
+
+	var pkt packet
+	if pkt, err = readPacket(s.reader, s.handler.options.MaxPacketSize); err != nil {
+		return err
+	}
+
+	if pkt.packetType != packetConnect {
+		return fmt.Errorf("%w: expected CONNECT but got packet type %d", errUnexpectedPacket, pkt.packetType)
+	}
+
+	if err = s.handleConnect(pkt.body); err != nil {
+		return err
+	}
+
+	// the will message is published unless the client disconnected gracefully
+	disconnected := false
+	defer func() {
+		s.handler.broker.disconnect(s)
+		if !disconnected && s.will != nil {
+			s.handler.broker.publish(*s.will, s)
+		}
+	}()
+
+	for {
+		if s.keepAlive > 0 {
+			_ = s.conn.SetReadDeadline(time.Now().Add(s.keepAlive * keepAliveGraceFactor / keepAliveGraceDivide))
+		} else {
+			_ = s.conn.SetReadDeadline(time.Time{})
+		}
+
+		if pkt, err = readPacket(s.reader, s.handler.options.MaxPacketSize); err != nil {
+			return err
+		}
+
+		if pkt.packetType == packetDisconnect {
+			disconnected = true
+			return nil
+		}
+
+		if err = s.handle(pkt); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *session) handle(pkt packet) error {
+	switch pkt.packetType {
+	case packetPublish:
+		return s.handlePublish(pkt)
+	case packetPubrel:
+		packetID, err := decodePacketID(pkt.body)
+		if err != nil {
+			return err
+		}
+		delete(s.pendingReleases, packetID)
+		return s.write(encodeAck(packetPubcomp, packetID))
+	case packetSubscribe:
+		return s.handleSubscribe(pkt)
+	case packetUnsubscribe:
+		return s.handleUnsubscribe(pkt)
+	case packetPingreq:
+		return s.write(encodePacket(packetPingresp, 0, nil))
+	case packetPuback, packetPubrec, packetPubcomp:
+		// messages are delivered fire and forget, acknowledgements are ignored
+		return nil
+	default:
+		return fmt.Errorf("%w: packet type %d", errUnexpectedPacket, pkt.packetType)
+	}
+}
+
+func (s *session) handleConnect(body []byte) error {
+	connect, err := decodeConnect(body)
+	s.version = connect.protocolVersion
+	s.clientID = connect.clientID
+	s.username = connect.username
+
+	if errors.Is(err, errUnsupportedProtocolVersion) {
+		s.emit(audit.MQTT{PacketType: auditv1.MQTTPacketType_MQTT_PACKET_TYPE_CONNECT, Password: connect.password})
+		// the version is unknown, hence the 3.1.1 format is the best guess how the client expects the CONNACK
+		_ = s.write(encodeConnack(protocolVersion311, connackUnacceptableVersion, ""))
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	var assignedClientID string
+	if s.clientID == "" {
+		s.clientID = "inetmock-" + strconv.FormatUint(s.handler.clientIDs.Add(1), 10)
+		if s.version == protocolVersion5 {
+			assignedClientID = s.clientID
+		}
+	}
+
+	if connect.will != nil && validTopicName(connect.will.topic) {
+		s.will = connect.will
+	}
+
+	s.keepAlive = time.Duration(connect.keepAlive) * time.Second
+
+	s.emit(audit.MQTT{PacketType: auditv1.MQTTPacketType_MQTT_PACKET_TYPE_CONNECT, Password: connect.password})
+
+	// a second connection with the same client ID takes over the session
+	if previous := s.handler.broker.connect(s); previous != nil {
+		_ = previous.conn.Close()
+	}
+
+	if err = s.write(encodeConnack(s.version, connackAccepted, assignedClientID)); err != nil {
+		return err
+	}
+
+	return s.evaluate(Request{Packet: requestConnect, ClientID: s.clientID, Username: s.username})
+}
+
+func (s *session) handlePublish(pkt packet) error {
+	publish, err := decodePublish(s.version, pkt.flags, pkt.body)
+	if err != nil {
+		return err
+	}
+
+	if !validTopicName(publish.topic) {
+		return fmt.Errorf("%w: %s", ErrInvalidTopic, publish.topic)
+	}
+
+	s.emit(audit.MQTT{
+		PacketType:  auditv1.MQTTPacketType_MQTT_PACKET_TYPE_PUBLISH,
+		Topics:      []string{publish.topic},
+		QoS:         uint32(publish.qos),
+		Retain:      publish.retain,
+		PayloadSize: int64(len(publish.payload)),
+	})
+
+	// QoS 2 messages are delivered right away, a retransmission before the PUBREL must not be delivered again
+	duplicate := false
+	switch publish.qos {
+	case qosAtLeastOnce:
+		err = s.write(encodeAck(packetPuback, publish.packetID))
+	case qosExactlyOnce:
+		_, duplicate = s.pendingReleases[publish.packetID]
+		s.pendingReleases[publish.packetID] = struct{}{}
+		err = s.write(encodeAck(packetPubrec, publish.packetID))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !duplicate {
+		s.handler.broker.publish(publish.message, s)
+	}
+
+	return s.evaluate(Request{
+		Packet:   requestPublish,
+		ClientID: s.clientID,
+		Username: s.username,
+		Topic:    publish.topic,
+		Payload:  publish.payload,
+	})
+}
+
+func (s *session) handleSubscribe(pkt packet) error {
+	subscribe, err := decodeSubscribe(s.version, pkt.body)
+	if err != nil {
+		return err
+	}
+
+	var (
+		reasonCodes = make([]byte, len(subscribe.subscriptions))
+		topics      = make([]string, len(subscribe.subscriptions))
+		accepted    = make([]subscription, 0, len(subscribe.subscriptions))
+	)
+
+	for idx, sub := range subscribe.subscriptions {
+		topics[idx] = sub.filter
+		switch {
+		case strings.HasPrefix(sub.filter, sharedPrefix):
+			reasonCodes[idx] = s.failureCode(subackSharedSubscriptionsInvalid)
+		case !validTopicFilter(sub.filter):
+			reasonCodes[idx] = s.failureCode(subackTopicFilterInvalid5)
+		default:
+			sub.qos = lowerQoS(sub.qos, maxGrantedQoS)
+			reasonCodes[idx] = sub.qos
+			accepted = append(accepted, sub)
+		}
+	}
+
+	s.emit(audit.MQTT{PacketType: auditv1.MQTTPacketType_MQTT_PACKET_TYPE_SUBSCRIBE, Topics: topics})
+
+	retained := s.handler.broker.subscribe(s, accepted)
+	if err = s.write(encodeSuback(s.version, subscribe.packetID, reasonCodes)); err != nil {
+		return err
+	}
+
+	for _, msg := range retained {
+		s.deliver(msg)
+	}
+
+	for _, sub := range accepted {
+		if err = s.evaluate(Request{Packet: requestSubscribe, ClientID: s.clientID, Username: s.username, Topic: sub.filter}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *session) handleUnsubscribe(pkt packet) error {
+	unsubscribe, err := decodeUnsubscribe(s.version, pkt.body)
+	if err != nil {
+		return err
+	}
+
+	s.emit(audit.MQTT{PacketType: auditv1.MQTTPacketType_MQTT_PACKET_TYPE_UNSUBSCRIBE, Topics: unsubscribe.filters})
+
+	existed := s.handler.broker.unsubscribe(s, unsubscribe.filters)
+	reasonCodes := make([]byte, len(existed))
+	for idx := range existed {
+		if !existed[idx] {
+			reasonCodes[idx] = unsubackNoSubscriptionExisted5
+		}
+	}
+
+	return s.write(encodeUnsuback(s.version, unsubscribe.packetID, reasonCodes))
+}
+
+// evaluate executes the actions of the first matching rule,
+// a Disconnect() action stops the evaluation and terminates the session
+func (s *session) evaluate(req Request) error {
+	response, matched := s.handler.ruleHandler.Evaluate(req, s.host)
+	if !matched {
+		return nil
+	}
+
+	for _, action := range response {
+		if action.Disconnect {
+			return errDisconnectedByRule
+		}
+		s.handler.broker.publish(action.Message, nil)
+	}
+
+	return nil
+}
+
+// failureCode returns the given MQTT 5 reason code or the generic failure return code of older protocol versions
+func (s *session) failureCode(reasonCode byte) byte {
+	if s.version == protocolVersion5 {
+		return reasonCode
+	}
+	return subackFailure
+}
+
+// deliver sends a PUBLISH packet to the client, clients not reading their messages are disconnected
+func (s *session) deliver(msg message) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	var packetID uint16
+	if msg.qos > 0 {
+		if s.nextPacketID++; s.nextPacketID == 0 {
+			s.nextPacketID = 1
+		}
+		packetID = s.nextPacketID
+	}
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := s.conn.Write(encodePublish(s.version, msg, packetID)); err != nil {
+		_ = s.conn.Close()
+	}
+}
+
+func (s *session) write(data []byte) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err := s.conn.Write(data)
+	return err
+}
+
+func (s *session) emit(details audit.MQTT) {
+	details.ProtocolVersion = uint32(s.version)
+	details.ClientID = s.clientID
+	details.Username = s.username
+
+	builder := s.handler.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_MQTT).
+		WithProtocolDetails(details)
+
+	if details, ok := audit.NewTLSDetailsFromConn(s.conn); ok {
+		builder = builder.WithTLSDetails(details)
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(s.conn.RemoteAddr())
+	builder, _ = builder.WithDestinationFromAddr(s.conn.LocalAddr())
+
+	builder.Emit()
+}
@@ -0,0 +1,63 @@
+package mqtt
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	topicSeparator      = "/"
+	singleLevelWildcard = "+"
+	multiLevelWildcard  = "#"
+	sharedPrefix        = "$share/"
+)
+
+var ErrInvalidTopic = errors.New("invalid MQTT topic name")
+
+// validTopicName checks the topic name of a PUBLISH packet, topic names must not contain wildcards
+func validTopicName(topic string) bool {
+	return topic != "" && !strings.ContainsAny(topic, singleLevelWildcard+multiLevelWildcard+"\x00")
+}
+
+// validTopicFilter checks the topic filter of a SUBSCRIBE packet,
+// wildcards have to occupy a whole level and the multi level wildcard is only allowed as last level
+func validTopicFilter(filter string) bool {
+	if filter == "" || strings.Contains(filter, "\x00") {
+		return false
+	}
+
+	levels := strings.Split(filter, topicSeparator)
+	for idx, level := range levels {
+		switch {
+		case level == multiLevelWildcard && idx != len(levels)-1:
+			return false
+		case level != singleLevelWildcard && level != multiLevelWildcard && strings.ContainsAny(level, singleLevelWildcard+multiLevelWildcard):
+			return false
+		}
+	}
+
+	return true
+}
+
+// topicMatches checks whether a topic name matches a topic filter.
+// Topics starting with $ are not matched by filters starting with a wildcard.
+func topicMatches(filter, topic string) bool {
+	if strings.HasPrefix(topic, "$") && (strings.HasPrefix(filter, singleLevelWildcard) || strings.HasPrefix(filter, multiLevelWildcard)) {
+		return false
+	}
+
+	filterLevels := strings.Split(filter, topicSeparator)
+	topicLevels := strings.Split(topic, topicSeparator)
+
+	for idx, level := range filterLevels {
+		if level == multiLevelWildcard {
+			return true
+		}
+
+		if idx >= len(topicLevels) || (level != singleLevelWildcard && level != topicLevels[idx]) {
+			return false
+		}
+	}
+
+	return len(filterLevels) == len(topicLevels)
+}
@@ -0,0 +1,173 @@
+package ntp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const (
+	name = "ntp_mock"
+	// referenceInterval is the interval the reference timestamp is updated in, like a server polling every 64s
+	referenceInterval = 64 * time.Second
+	// rootDelayPerStratum and rootDispersionPerStratum let the root distance grow with the stratum like in real deployments
+	rootDelayPerStratum      = 10 * time.Millisecond
+	rootDispersionPerStratum = time.Millisecond
+)
+
+type ntpHandler struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	options     ntpOptions
+	ruleHandler *RuleHandler
+}
+
+func (h *ntpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	if startupSpec.PacketConn == nil {
+		return fmt.Errorf("%w: %s requires a UDP listener", endpoint.ErrUnsupportedProtocol, name)
+	}
+
+	ruleHandler := &RuleHandler{
+		HandlerName: startupSpec.Name,
+	}
+
+	for idx := range h.options.Rules {
+		rule := h.options.Rules[idx]
+		if err = ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+	h.ruleHandler = ruleHandler
+
+	go h.serve(startupSpec.PacketConn)
+	return nil
+}
+
+func (h *ntpHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+func (h *ntpHandler) serve(conn net.PacketConn) {
+	buf := make([]byte, maxPacketLen)
+	for {
+		n, remote, err := conn.ReadFrom(buf)
+		received := time.Now()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to read NTP packet", zap.Error(err))
+			}
+			return
+		}
+
+		h.handle(conn, remote, buf[:n], received)
+	}
+}
+
+func (h *ntpHandler) handle(conn net.PacketConn, remote net.Addr, data []byte, received time.Time) {
+	req, err := parsePacket(data)
+	if err != nil {
+		h.logger.Debug("Received malformed NTP packet", zap.String("remote", remote.String()), zap.Error(err))
+		return
+	}
+
+	// only client requests are answered, symmetric and broadcast modes as well as control messages are ignored
+	if req.mode != modeClient || req.version < minVersion || req.version > maxVersion {
+		h.logger.Debug(
+			"Ignoring NTP packet",
+			zap.String("remote", remote.String()),
+			zap.Uint8("mode", req.mode),
+			zap.Uint8("version", req.version),
+		)
+		return
+	}
+
+	offset := h.options.offset
+	clientIP, _, _ := netutils.IPPortFromAddress(remote)
+	if verdict, matched := h.ruleHandler.Evaluate(Request{Client: clientIP}); matched {
+		offset = verdict.Offset
+	}
+
+	resp := h.response(req, received.Add(offset))
+	resp.transmitTime = toNTPTime(time.Now().Add(offset))
+
+	if _, err = conn.WriteTo(resp.encode(), remote); err != nil {
+		h.logger.Debug("Failed to send NTP response", zap.String("remote", remote.String()), zap.Error(err))
+	}
+
+	h.emit(conn.LocalAddr(), remote, req, offset)
+}
+
+func (h *ntpHandler) response(req packet, received time.Time) packet {
+	poll := req.poll
+	switch {
+	case poll == 0:
+		poll = defaultPoll
+	case poll < minPoll:
+		poll = minPoll
+	case poll > maxPoll:
+		poll = maxPoll
+	}
+
+	stratum := time.Duration(h.options.Stratum)
+
+	return packet{
+		version:        req.version,
+		mode:           modeServer,
+		stratum:        uint8(h.options.Stratum),
+		poll:           poll,
+		precision:      int8(h.options.Precision),
+		rootDelay:      toNTPShort((stratum - 1) * rootDelayPerStratum),
+		rootDispersion: toNTPShort(stratum * rootDispersionPerStratum),
+		referenceID:    h.options.referenceID,
+		referenceTime:  toNTPTime(received.Truncate(referenceInterval)),
+		originTime:     req.transmitTime,
+		receiveTime:    toNTPTime(received),
+	}
+}
+
+func (h *ntpHandler) emit(local, remote net.Addr, req packet, offset time.Duration) {
+	details := audit.NTP{
+		Version: req.version,
+		Mode:    req.mode,
+		Offset:  offset,
+	}
+
+	if req.transmitTime != 0 {
+		details.ClientTransmitTime = fromNTPTime(req.transmitTime)
+	}
+
+	builder := h.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_NTP).
+		WithProtocolDetails(details)
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(remote)
+	builder, _ = builder.WithDestinationFromAddr(local)
+
+	builder.Emit()
+}
@@ -0,0 +1,195 @@
+package ntp_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/beevik/ntp"
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	ntpmock "inetmock.icb4dc0.de/inetmock/protocols/ntp"
+)
+
+const (
+	year            = 365 * 24 * time.Hour
+	offsetTolerance = time.Second
+)
+
+func Test_ntpHandler_Start(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		opts         map[string]any
+		version      int
+		wantStartErr bool
+		wantResponse any
+		wantEvent    any
+	}{
+		{
+			name:    "Default options",
+			version: 4,
+			wantResponse: td.Struct(new(ntp.Response), td.StructFields{
+				"ClockOffset": td.Between(-offsetTolerance, offsetTolerance),
+				"Stratum":     uint8(1),
+				"ReferenceID": uint32(0x47505300),
+			}),
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"Application": auditv1.AppProtocol_APP_PROTOCOL_NTP,
+				"Transport":   auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP,
+				"ProtocolDetails": td.Struct(audit.NTP{
+					Version: 4,
+					Mode:    3,
+				}, td.StructFields{
+					"ClientTransmitTime": td.Ignore(),
+				}),
+			}),
+		},
+		{
+			name: "Fixed offset",
+			opts: map[string]any{
+				"offset": "+365d",
+			},
+			version: 4,
+			wantResponse: td.Struct(new(ntp.Response), td.StructFields{
+				"ClockOffset": td.Between(year-offsetTolerance, year+offsetTolerance),
+			}),
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(audit.NTP{
+					Version: 4,
+					Mode:    3,
+					Offset:  year,
+				}, td.StructFields{
+					"ClientTransmitTime": td.Ignore(),
+				}),
+			}),
+		},
+		{
+			name: "Offset of matching rule",
+			opts: map[string]any{
+				"offset": "+365d",
+				"rules": []string{
+					`Client(10.0.0.1) => Offset("+1d")`,
+					`Client(127.0.0.0/8) => Offset("-2h")`,
+				},
+			},
+			version: 3,
+			wantResponse: td.Struct(new(ntp.Response), td.StructFields{
+				"ClockOffset": td.Between(-2*time.Hour-offsetTolerance, -2*time.Hour+offsetTolerance),
+			}),
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(audit.NTP{
+					Version: 3,
+					Mode:    3,
+					Offset:  -2 * time.Hour,
+				}, td.StructFields{
+					"ClientTransmitTime": td.Ignore(),
+				}),
+			}),
+		},
+		{
+			name: "Secondary server",
+			opts: map[string]any{
+				"stratum":     3,
+				"referenceID": "192.0.2.10",
+			},
+			version: 4,
+			wantResponse: td.Struct(new(ntp.Response), td.StructFields{
+				"Stratum":     uint8(3),
+				"ReferenceID": uint32(0xc000020a),
+			}),
+			wantEvent: td.NotNil(),
+		},
+		{
+			name: "Error because of invalid offset",
+			opts: map[string]any{
+				"offset": "one year",
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of invalid stratum",
+			opts: map[string]any{
+				"stratum": 16,
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of reference ID exceeding 4 characters",
+			opts: map[string]any{
+				"referenceID": "ATOMIC",
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because reference ID of secondary server is not an IP",
+			opts: map[string]any{
+				"stratum":     2,
+				"referenceID": "GPS",
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of unknown verdict",
+			opts: map[string]any{
+				"rules": []string{
+					`=> Time("2038-01-19")`,
+				},
+			},
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("net.ListenPacket() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := ntpmock.New(logging.CreateTestLogger(t), emitterMock)
+			if err = handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(conn), tt.opts)); err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			resp, err := ntp.QueryWithOptions("127.0.0.1", ntp.QueryOptions{
+				Port:    conn.LocalAddr().(*net.UDPAddr).Port,
+				Version: tt.version,
+				Timeout: 5 * time.Second,
+			})
+			if !td.CmpNoError(t, err) {
+				return
+			}
+
+			td.CmpNoError(t, resp.Validate())
+			td.Cmp(t, resp, tt.wantResponse)
+
+			emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+				if td.Cmp(t, calls.Emit(), td.Len(1)) {
+					td.Cmp(t, calls.Emit()[0].Params.Ev, tt.wantEvent)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package ntp
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+const (
+	packetLen = 48
+	// maxPacketLen leaves room for extension fields and MACs which are ignored
+	maxPacketLen = 1024
+
+	modeClient = 3
+	modeServer = 4
+
+	minVersion = 1
+	maxVersion = 4
+
+	minPoll     = 4
+	maxPoll     = 17
+	defaultPoll = 6
+
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the Unix epoch (1970)
+	ntpEpochOffset = 2208988800
+	// eraLength is the number of seconds after which the 32-bit seconds of an NTP timestamp roll over
+	eraLength = 1 << 32
+)
+
+var errPacketTooShort = errors.New("NTP packet too short")
+
+// packet is the fixed header of an NTP packet as defined in RFC 5905, extension fields are not supported.
+// Timestamps are kept in their wire format to be able to echo the origin timestamp unchanged.
+type packet struct {
+	leap           uint8
+	version        uint8
+	mode           uint8
+	stratum        uint8
+	poll           int8
+	precision      int8
+	rootDelay      uint32
+	rootDispersion uint32
+	referenceID    uint32
+	referenceTime  uint64
+	originTime     uint64
+	receiveTime    uint64
+	transmitTime   uint64
+}
+
+func parsePacket(data []byte) (p packet, err error) {
+	if len(data) < packetLen {
+		return p, errPacketTooShort
+	}
+
+	p.leap = data[0] >> 6
+	p.version = (data[0] >> 3) & 0x07
+	p.mode = data[0] & 0x07
+	p.stratum = data[1]
+	p.poll = int8(data[2])
+	p.precision = int8(data[3])
+	p.rootDelay = binary.BigEndian.Uint32(data[4:])
+	p.rootDispersion = binary.BigEndian.Uint32(data[8:])
+	p.referenceID = binary.BigEndian.Uint32(data[12:])
+	p.referenceTime = binary.BigEndian.Uint64(data[16:])
+	p.originTime = binary.BigEndian.Uint64(data[24:])
+	p.receiveTime = binary.BigEndian.Uint64(data[32:])
+	p.transmitTime = binary.BigEndian.Uint64(data[40:])
+
+	return p, nil
+}
+
+func (p packet) encode() []byte {
+	data := make([]byte, packetLen)
+	data[0] = p.leap<<6 | (p.version&0x07)<<3 | p.mode&0x07
+	data[1] = p.stratum
+	data[2] = uint8(p.poll)
+	data[3] = uint8(p.precision)
+	binary.BigEndian.PutUint32(data[4:], p.rootDelay)
+	binary.BigEndian.PutUint32(data[8:], p.rootDispersion)
+	binary.BigEndian.PutUint32(data[12:], p.referenceID)
+	binary.BigEndian.PutUint64(data[16:], p.referenceTime)
+	binary.BigEndian.PutUint64(data[24:], p.originTime)
+	binary.BigEndian.PutUint64(data[32:], p.receiveTime)
+	binary.BigEndian.PutUint64(data[40:], p.transmitTime)
+	return data
+}
+
+// toNTPTime converts t to the 64-bit NTP timestamp format, the seconds roll over in 2036 as intended by RFC 5905
+func toNTPTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix()+ntpEpochOffset) & (eraLength - 1)
+	fraction := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+// fromNTPTime converts an NTP timestamp assuming it is between 1968 and 2104 like RFC 4330 suggests
+func fromNTPTime(ts uint64) time.Time {
+	seconds := int64(ts >> 32)
+	if seconds&(1<<31) == 0 {
+		seconds += eraLength
+	}
+	nanos := int64(((ts & (eraLength - 1)) * uint64(time.Second)) >> 32)
+	return time.Unix(seconds-ntpEpochOffset, nanos).UTC()
+}
+
+// toNTPShort converts d to the 32-bit NTP short format used for the root delay and dispersion
+func toNTPShort(d time.Duration) uint32 {
+	return uint32((uint64(d) << 16) / uint64(time.Second))
+}
@@ -0,0 +1,81 @@
+package ntp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/timeoffset"
+)
+
+const (
+	defaultStratum     = 1
+	defaultReferenceID = "GPS"
+	defaultPrecision   = -20
+	maxStratum         = 15
+	minPrecision       = -32
+	maxReferenceIDLen  = 4
+)
+
+type ntpOptions struct {
+	// Offset is added to the server time in every response unless a rule applies a different offset e.g. "+365d"
+	Offset string
+	// Stratum is the distance to the reference clock, 1 for primary servers
+	Stratum int
+	// ReferenceID is a clock identifier like GPS for primary servers or the IPv4 address of the upstream server otherwise
+	ReferenceID string
+	// Precision of the clock as exponent of two in seconds
+	Precision   int
+	Rules       []string
+	offset      time.Duration
+	referenceID uint32
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts ntpOptions, err error) {
+	opts = ntpOptions{
+		Stratum:     defaultStratum,
+		ReferenceID: defaultReferenceID,
+		Precision:   defaultPrecision,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts); err != nil {
+		return opts, err
+	}
+
+	if opts.offset, err = timeoffset.Parse(opts.Offset); err != nil {
+		return opts, err
+	}
+
+	if opts.Stratum < 1 || opts.Stratum > maxStratum {
+		return opts, fmt.Errorf("stratum has to be between 1 and %d but was %d", maxStratum, opts.Stratum)
+	}
+
+	if opts.Precision < minPrecision || opts.Precision > 0 {
+		return opts, fmt.Errorf("precision has to be between %d and 0 but was %d", minPrecision, opts.Precision)
+	}
+
+	opts.referenceID, err = encodeReferenceID(opts.Stratum, opts.ReferenceID)
+
+	return opts, err
+}
+
+// encodeReferenceID encodes up to 4 ASCII characters for primary servers and an IPv4 address for secondary servers
+func encodeReferenceID(stratum int, referenceID string) (uint32, error) {
+	if stratum > 1 {
+		ip := net.ParseIP(referenceID).To4()
+		if ip == nil {
+			return 0, fmt.Errorf("reference ID of a stratum %d server has to be an IPv4 address but was %q", stratum, referenceID)
+		}
+		return binary.BigEndian.Uint32(ip), nil
+	}
+
+	if len(referenceID) > maxReferenceIDLen {
+		return 0, fmt.Errorf("reference ID of a primary server must not exceed 4 characters but was %q", referenceID)
+	}
+
+	var encoded [maxReferenceIDLen]byte
+	copy(encoded[:], referenceID)
+	return binary.BigEndian.Uint32(encoded[:]), nil
+}
@@ -0,0 +1,173 @@
+package ntp
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/internal/timeoffset"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+var (
+	knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
+		"client": ClientFilter,
+	}
+	knownVerdicts = map[string]func(args ...rules.Param) (Verdict, error){
+		"offset": OffsetVerdict,
+	}
+)
+
+type (
+	// Request contains the information a rule may match on
+	Request struct {
+		Client net.IP
+	}
+	RequestFilter interface {
+		Matches(req Request) bool
+	}
+	RequestFilterFunc func(req Request) bool
+	FilterChain       []RequestFilter
+
+	// Verdict overrides the configured offset for matching clients
+	Verdict struct {
+		Offset time.Duration
+	}
+
+	ConditionalVerdict struct {
+		Filters FilterChain
+		Verdict Verdict
+	}
+)
+
+func (f RequestFilterFunc) Matches(req Request) bool {
+	return f(req)
+}
+
+func (c FilterChain) Matches(req Request) bool {
+	for idx := range c {
+		if !c[idx].Matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+type RuleHandler struct {
+	HandlerName string
+	verdicts    rules.Set[ConditionalVerdict]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	verdict, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.verdicts.Append(rawRule, verdict)
+	return nil
+}
+
+// RuleManager exposes the time rules, every request is evaluated against the rules current at its arrival.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalVerdict]{
+		Set:     &h.verdicts,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the verdict of the first rule matching the given request
+func (h *RuleHandler) Evaluate(req Request) (verdict Verdict, matched bool) {
+	verdicts := h.verdicts.Entries()
+	for idx := range verdicts {
+		entry := verdicts[idx]
+		if entry.Value.Filters.Matches(req) {
+			protocols.RecordRuleMatch(entry, name, h.HandlerName, req.Client.String())
+			return entry.Value.Verdict, true
+		}
+	}
+
+	return verdict, false
+}
+
+func compileRule(rawRule string) (verdict ConditionalVerdict, err error) {
+	var rule *rules.SingleResponsePipeline
+	if rule, err = rules.Parse[rules.SingleResponsePipeline](rawRule); err != nil {
+		return verdict, err
+	}
+
+	if verdict.Filters, err = filtersForRule(rule); err != nil {
+		return verdict, err
+	}
+
+	if rule.Response == nil {
+		return verdict, rules.ErrNoTerminatorDefined
+	}
+
+	if constructor, ok := knownVerdicts[strings.ToLower(rule.Response.Name)]; !ok {
+		return verdict, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response.Name)
+	} else if verdict.Verdict, err = constructor(rule.Response.Params...); err != nil {
+		return verdict, err
+	}
+
+	return verdict, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownRequestFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+// ClientFilter matches the IP of the client either exactly or by network e.g. Client(10.10.0.0/16)
+func ClientFilter(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	if cidr, err := args[0].AsCIDR(); err == nil {
+		return RequestFilterFunc(func(req Request) bool {
+			return cidr.Contains(req.Client)
+		}), nil
+	}
+
+	ip, err := args[0].AsIP()
+	if err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return ip.Equal(req.Client)
+	}), nil
+}
+
+// OffsetVerdict adds the given offset to the server time e.g. Offset("+365d")
+func OffsetVerdict(args ...rules.Param) (verdict Verdict, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return verdict, err
+	}
+
+	var rawOffset string
+	if rawOffset, err = args[0].AsString(); err != nil {
+		return verdict, err
+	}
+
+	verdict.Offset, err = timeoffset.Parse(rawOffset)
+	return verdict, err
+}
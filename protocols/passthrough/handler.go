@@ -0,0 +1,177 @@
+package passthrough
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const name = "tls_passthrough"
+
+var _ endpoint.StoppableHandler = (*passthroughHandler)(nil)
+
+type passthroughHandler struct {
+	logger  logging.Logger
+	emitter audit.Emitter
+	options passthroughOptions
+	dialer  net.Dialer
+	conns   endpoint.ConnTracker
+}
+
+func (h *passthroughHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+		zap.String("backend", h.options.Backend),
+	)
+
+	if startupSpec.Listener == nil {
+		return fmt.Errorf("%w: %s requires a listener", endpoint.ErrUnsupportedProtocol, name)
+	}
+
+	h.dialer = net.Dialer{Timeout: h.options.DialTimeout}
+	h.conns.Open()
+	go h.serve(startupSpec.Listener)
+
+	return nil
+}
+
+// Stop closes all client connections as well as the connections to their backends
+func (h *passthroughHandler) Stop(context.Context) error {
+	h.conns.CloseAll()
+	return nil
+}
+
+func (h *passthroughHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *passthroughHandler) handleConn(conn net.Conn) {
+	var (
+		started = time.Now()
+		details = &audit.Passthrough{Backend: h.options.Backend}
+	)
+
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+		details.Duration = time.Since(started)
+		h.emit(conn, details)
+	}()
+
+	backend, err := h.dialer.Dial("tcp", h.options.Backend)
+	if err != nil {
+		h.logger.Warn("Failed to connect to backend", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+		details.Error = err.Error()
+		return
+	}
+
+	if !h.conns.Track(backend) {
+		_ = backend.Close()
+		return
+	}
+
+	defer func() {
+		h.conns.Untrack(backend)
+		_ = backend.Close()
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		received int64
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		received, _ = io.Copy(conn, backend)
+		closeWrite(conn)
+	}()
+
+	var hello []byte
+	details.ServerName, hello = h.readServerName(conn)
+
+	sent, err := io.Copy(backend, io.MultiReader(bytes.NewReader(hello), conn))
+	if err = endpoint.IgnoreShutdownError(err); err != nil {
+		h.logger.Debug("Passthrough connection terminated", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+	}
+	closeWrite(backend)
+
+	wg.Wait()
+
+	details.BytesSent = uint64(sent)
+	details.BytesReceived = uint64(received)
+}
+
+// readServerName tries to read the TLS ClientHello within the configured timeout to record the server name.
+// All data read from the client is returned to be forwarded to the backend, regardless whether it is a ClientHello.
+func (h *passthroughHandler) readServerName(conn net.Conn) (serverName string, data []byte) {
+	var buf bytes.Buffer
+	_ = conn.SetReadDeadline(time.Now().Add(h.options.HelloTimeout))
+	hello, err := endpoint.ReadClientHello(io.TeeReader(conn, &buf))
+	_ = conn.SetReadDeadline(time.Time{})
+
+	if err == nil {
+		serverName = hello.ServerName
+	}
+
+	return serverName, buf.Bytes()
+}
+
+func (h *passthroughHandler) emit(conn net.Conn, details *audit.Passthrough) {
+	builder := h.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_TLS_PASSTHROUGH).
+		WithProtocolDetails(details)
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(conn.RemoteAddr())
+	builder, _ = builder.WithDestinationFromAddr(conn.LocalAddr())
+
+	builder.Emit()
+}
+
+// closeWrite signals the peer that no more data will be sent,
+// connections not supporting half-closed states e.g. multiplexed ones are closed completely
+func closeWrite(conn net.Conn) {
+	type closeWriter interface {
+		CloseWrite() error
+	}
+
+	if cw, ok := conn.(closeWriter); ok {
+		_ = cw.CloseWrite()
+	} else {
+		_ = conn.Close()
+	}
+}
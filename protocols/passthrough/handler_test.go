@@ -0,0 +1,262 @@
+package passthrough_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/passthrough"
+)
+
+const clientTimeout = 5 * time.Second
+
+func Test_passthroughHandler_Start(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		opts    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "Valid backend",
+			opts: map[string]any{"backend": "127.0.0.1:443"},
+		},
+		{
+			name:    "Error because of missing backend",
+			opts:    map[string]any{},
+			wantErr: true,
+		},
+		{
+			name:    "Error because of backend without port",
+			opts:    map[string]any{"backend": "127.0.0.1"},
+			wantErr: true,
+		},
+		{
+			name:    "Error because of negative dialTimeout",
+			opts:    map[string]any{"backend": "127.0.0.1:443", "dialTimeout": "-1s"},
+			wantErr: true,
+		},
+		{
+			name:    "Error because of invalid helloTimeout",
+			opts:    map[string]any{"backend": "127.0.0.1:443", "helloTimeout": "soon"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			listener := test.NewTCPListener(t, "127.0.0.1:0")
+			t.Cleanup(func() {
+				_ = listener.Close()
+			})
+
+			handler := passthrough.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock))
+			err := handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.opts))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_passthroughHandler_TLS(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		serverName string
+		want       any
+	}{
+		{
+			name:       "ClientHello with server name",
+			serverName: "update.example.com",
+			want: td.SStruct(new(audit.Passthrough), td.StructFields{
+				"ServerName":    "update.example.com",
+				"BytesSent":     td.Gt(uint64(0)),
+				"BytesReceived": td.Gt(uint64(0)),
+				"Backend":       td.NotEmpty(),
+				"Duration":      td.Gt(time.Duration(0)),
+			}),
+		},
+		{
+			name:       "ClientHello without server name",
+			serverName: "127.0.0.1",
+			want: td.SStruct(new(audit.Passthrough), td.StructFields{
+				"ServerName":    "",
+				"BytesSent":     td.Gt(uint64(0)),
+				"BytesReceived": td.Gt(uint64(0)),
+				"Backend":       td.NotEmpty(),
+				"Duration":      td.Gt(time.Duration(0)),
+			}),
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			backend := tls.NewListener(test.NewTCPListener(t, "127.0.0.1:0"), test.NewSelfSignedCertStore(t).TLSConfig())
+			serveEcho(t, backend)
+
+			addr, emitterMock := setupHandler(t, backend.Addr().String())
+
+			//nolint:gosec // the backend uses a self-signed certificate
+			conn, err := tls.DialWithDialer(&net.Dialer{Timeout: clientTimeout}, "tcp", addr, &tls.Config{
+				ServerName:         tt.serverName,
+				InsecureSkipVerify: true,
+			})
+			if err != nil {
+				t.Fatalf("tls.Dial() error = %v", err)
+			}
+
+			// the certificate of the backend proves the connection was passed through untouched
+			td.Cmp(t, conn.ConnectionState().PeerCertificates[0].Subject.CommonName, "localhost")
+
+			td.Cmp(t, roundTrip(t, conn, "ping\n"), "ping\n")
+			_ = conn.Close()
+
+			test.AwaitEvent(t, emitterMock, td.Struct(new(audit.Event), td.StructFields{
+				"Application":     auditv1.AppProtocol_APP_PROTOCOL_TLS_PASSTHROUGH,
+				"Transport":       auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP,
+				"ProtocolDetails": tt.want,
+			}))
+		})
+	}
+}
+
+func Test_passthroughHandler_PlainText(t *testing.T) {
+	t.Parallel()
+	backend := test.NewTCPListener(t, "127.0.0.1:0")
+	serveEcho(t, backend)
+
+	addr, emitterMock := setupHandler(t, backend.Addr().String())
+
+	conn, err := net.DialTimeout("tcp", addr, clientTimeout)
+	if err != nil {
+		t.Fatalf("net.Dial() error = %v", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(clientTimeout))
+
+	td.Cmp(t, roundTrip(t, conn, "HELO client\n"), "HELO client\n")
+	_ = conn.(*net.TCPConn).CloseWrite()
+
+	remaining, _ := io.ReadAll(conn)
+	td.CmpEmpty(t, remaining)
+	_ = conn.Close()
+
+	test.AwaitEvent(t, emitterMock, td.Struct(new(audit.Event), td.StructFields{
+		"ProtocolDetails": td.Struct(&audit.Passthrough{
+			Backend:       backend.Addr().String(),
+			BytesSent:     12,
+			BytesReceived: 12,
+		}, td.StructFields{
+			"Duration": td.Gt(time.Duration(0)),
+		}),
+	}))
+}
+
+func Test_passthroughHandler_UnreachableBackend(t *testing.T) {
+	t.Parallel()
+	backend := test.NewTCPListener(t, "127.0.0.1:0")
+	backendAddr := backend.Addr().String()
+	_ = backend.Close()
+
+	addr, emitterMock := setupHandler(t, backendAddr)
+
+	conn, err := net.DialTimeout("tcp", addr, clientTimeout)
+	if err != nil {
+		t.Fatalf("net.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	_ = conn.SetDeadline(time.Now().Add(clientTimeout))
+
+	remaining, _ := io.ReadAll(conn)
+	td.CmpEmpty(t, remaining)
+
+	test.AwaitEvent(t, emitterMock, td.Struct(new(audit.Event), td.StructFields{
+		"ProtocolDetails": td.Struct(&audit.Passthrough{Backend: backendAddr}, td.StructFields{
+			"Error":    td.Contains("connection refused"),
+			"Duration": td.Ignore(),
+		}),
+	}))
+}
+
+func setupHandler(tb testing.TB, backend string) (string, *audit_mock.EmitterMock) {
+	tb.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+
+	listener := test.NewTCPListener(tb, "127.0.0.1:0")
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := passthrough.New(logging.CreateTestLogger(tb), emitterMock)
+
+	opts := map[string]any{"backend": backend}
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(tb.Name(), endpoint.NewUplink(listener), opts)); err != nil {
+		tb.Fatalf("Start() error = %v", err)
+	}
+
+	tb.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+		_ = listener.Close()
+	})
+
+	return listener.Addr().String(), emitterMock
+}
+
+// serveEcho echoes every line sent by clients of the given listener
+func serveEcho(tb testing.TB, listener net.Listener) {
+	tb.Helper()
+	tb.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if _, err = conn.Write([]byte(line)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+}
+
+func roundTrip(tb testing.TB, conn net.Conn, data string) string {
+	tb.Helper()
+	if _, err := conn.Write([]byte(data)); err != nil {
+		tb.Fatalf("Write() error = %v", err)
+	}
+
+	got := make([]byte, len(data))
+	if _, err := io.ReadFull(conn, got); err != nil {
+		tb.Fatalf("ReadFull() error = %v", err)
+	}
+
+	return string(got)
+}
@@ -0,0 +1,58 @@
+package passthrough
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+const (
+	defaultDialTimeout  = 5 * time.Second
+	defaultHelloTimeout = 2 * time.Second
+)
+
+var ErrMissingBackend = errors.New("backend is required")
+
+type passthroughOptions struct {
+	// Backend is the address connections are passed through to e.g. 10.10.0.5:443
+	Backend string
+	// DialTimeout limits the time to establish the connection to the backend
+	DialTimeout time.Duration
+	// HelloTimeout limits the time to wait for the TLS ClientHello to record the server name,
+	// data received so far is passed through anyway
+	HelloTimeout time.Duration
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts passthroughOptions, err error) {
+	opts = passthroughOptions{
+		DialTimeout:  defaultDialTimeout,
+		HelloTimeout: defaultHelloTimeout,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc())); err != nil {
+		return opts, err
+	}
+
+	if opts.Backend == "" {
+		return opts, ErrMissingBackend
+	}
+
+	if _, _, err = net.SplitHostPort(opts.Backend); err != nil {
+		return opts, fmt.Errorf("invalid backend %q: %w", opts.Backend, err)
+	}
+
+	if opts.DialTimeout <= 0 {
+		return opts, fmt.Errorf("dialTimeout has to be positive but was %s", opts.DialTimeout)
+	}
+
+	if opts.HelloTimeout <= 0 {
+		return opts, fmt.Errorf("helloTimeout has to be positive but was %s", opts.HelloTimeout)
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,101 @@
+package quarantine
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+const (
+	quarantineDirPerm  = 0o750
+	uploadNameRandLen  = 4
+	uploadTimestampFmt = "20060102T150405.000000000Z"
+	fallbackUploadName = "upload"
+)
+
+var ErrUploadTooLarge = errors.New("upload exceeds the maximum size")
+
+// Store saves uploaded files to a directory without ever serving them again
+type Store struct {
+	dir      string
+	maxBytes int64
+}
+
+// ResolveDir resolves dir within the quarantine data directory if it is not absolute.
+// An empty dir resolves to the quarantine data directory itself.
+func ResolveDir(quarantineDir, dir string) string {
+	switch {
+	case dir == "":
+		return quarantineDir
+	case filepath.IsAbs(dir):
+		return dir
+	default:
+		return filepath.Join(quarantineDir, dir)
+	}
+}
+
+// New creates the given directory if necessary, uploads exceeding maxBytes are discarded
+func New(dir string, maxBytes int64) (*Store, error) {
+	if err := os.MkdirAll(dir, quarantineDirPerm); err != nil {
+		return nil, err
+	}
+
+	return &Store{dir: dir, maxBytes: maxBytes}, nil
+}
+
+// Save stores the content of r prefixed with the time it was received to prevent clients from overwriting each other.
+// Uploads exceeding the maximum size are discarded.
+func (q *Store) Save(virtualPath string, r io.Reader) (stored string, size int64, err error) {
+	tmp, err := os.CreateTemp(q.dir, ".upload-*")
+	if err != nil {
+		return "", 0, err
+	}
+
+	defer func() {
+		_ = tmp.Close()
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if size, err = io.Copy(tmp, io.LimitReader(r, q.maxBytes+1)); err != nil {
+		return "", size, err
+	}
+
+	if size > q.maxBytes {
+		return "", size, ErrUploadTooLarge
+	}
+
+	randomPart := make([]byte, uploadNameRandLen)
+	if _, err = rand.Read(randomPart); err != nil {
+		return "", size, err
+	}
+
+	stored = filepath.Join(q.dir, fmt.Sprintf(
+		"%s-%s-%s",
+		time.Now().UTC().Format(uploadTimestampFmt),
+		hex.EncodeToString(randomPart),
+		uploadName(virtualPath),
+	))
+
+	if err = tmp.Close(); err != nil {
+		return "", size, err
+	}
+
+	return stored, size, os.Rename(tmp.Name(), stored)
+}
+
+func uploadName(virtualPath string) string {
+	switch base := path.Base(virtualPath); base {
+	case "/", ".", "..":
+		return fallbackUploadName
+	default:
+		return base
+	}
+}
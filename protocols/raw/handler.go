@@ -0,0 +1,199 @@
+package raw
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net"
+
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const (
+	name            = "raw_mock"
+	maxDatagramSize = 65535
+)
+
+var (
+	_ endpoint.ProtocolHandler = (*rawHandler)(nil)
+	_ endpoint.FallbackHandler = (*rawHandler)(nil)
+)
+
+type rawHandler struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	fakeFileFS  fs.FS
+	options     rawOptions
+	ruleHandler *RuleHandler
+	conns       endpoint.ConnTracker
+}
+
+// Matchers accepts every connection, as a fallback handler the raw mock is only considered
+// if none of the other handlers of a listener matched
+func (h *rawHandler) Matchers() []cmux.Matcher {
+	return []cmux.Matcher{cmux.Any()}
+}
+
+func (h *rawHandler) Fallback() bool {
+	return true
+}
+
+func (h *rawHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	ruleHandler := &RuleHandler{
+		HandlerName: startupSpec.Name,
+	}
+
+	for idx := range h.options.Rules {
+		rule := h.options.Rules[idx]
+		if err = ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+	h.ruleHandler = ruleHandler
+
+	switch {
+	case startupSpec.Listener != nil:
+		h.conns.Open()
+		go h.serve(startupSpec.Listener)
+	case startupSpec.PacketConn != nil:
+		go h.servePackets(startupSpec.PacketConn)
+	default:
+		return fmt.Errorf("%w: %s requires a listener", endpoint.ErrUnsupportedProtocol, name)
+	}
+
+	return nil
+}
+
+// Stop closes all client connections even if a rule is still sending its response
+func (h *rawHandler) Stop(context.Context) error {
+	h.conns.CloseAll()
+	return nil
+}
+
+func (h *rawHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+func (h *rawHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *rawHandler) handleConn(conn net.Conn) {
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+	}()
+
+	if err := endpoint.IgnoreShutdownError(newSession(h, conn).serve()); err != nil {
+		h.logger.Debug("Raw session terminated", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+	}
+}
+
+func (h *rawHandler) servePackets(conn net.PacketConn) {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, remote, err := conn.ReadFrom(buf)
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to read datagram", zap.Error(err))
+			}
+			return
+		}
+
+		h.handleDatagram(conn, remote, buf[:n])
+	}
+}
+
+// handleDatagram answers every action of the matching rule with a separate datagram,
+// banners are meaningless for UDP and therefore ignored, Close() only skips the remaining actions
+func (h *rawHandler) handleDatagram(conn net.PacketConn, remote net.Addr, data []byte) {
+	t := newTranscript(h.options.MaxTranscriptBytes)
+	t.record(auditv1.RawDirection_RAW_DIRECTION_INBOUND, data)
+
+	var client string
+	if ip, _, err := netutils.IPPortFromAddress(remote); err == nil {
+		client = ip.String()
+	}
+
+	if response, matched := h.ruleHandler.Evaluate(Request{Data: data}, client); matched {
+		for idx := range response {
+			if response[idx].Close {
+				break
+			}
+
+			reply, ok := h.actionData(response[idx])
+			if !ok {
+				continue
+			}
+
+			if _, err := conn.WriteTo(reply, remote); err != nil {
+				h.logger.Debug("Failed to send datagram", zap.String("remote", remote.String()), zap.Error(err))
+				break
+			}
+			t.record(auditv1.RawDirection_RAW_DIRECTION_OUTBOUND, reply)
+		}
+	}
+
+	builder := h.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_RAW).
+		WithProtocolDetails(t.details)
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(remote)
+	builder, _ = builder.WithDestinationFromAddr(conn.LocalAddr())
+
+	builder.Emit()
+}
+
+// actionData returns the data to send for the given action, false if there's nothing to send
+func (h *rawHandler) actionData(action Action) ([]byte, bool) {
+	if action.File == "" {
+		return action.Data, len(action.Data) > 0
+	}
+
+	data, err := fs.ReadFile(h.fakeFileFS, action.File)
+	if err != nil {
+		h.logger.Warn("Failed to read fake file", zap.String("file", action.File), zap.Error(err))
+		return nil, false
+	}
+
+	return data, true
+}
@@ -0,0 +1,378 @@
+package raw_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/raw"
+)
+
+const clientTimeout = 5 * time.Second
+
+var fakeFiles = fstest.MapFS{
+	"sample.txt": &fstest.MapFile{Data: []byte("Hello from the fake file")},
+}
+
+type step struct {
+	send string
+	want string
+}
+
+func inbound(data string) audit.RawTranscriptEntry {
+	return audit.RawTranscriptEntry{Direction: auditv1.RawDirection_RAW_DIRECTION_INBOUND, Data: []byte(data)}
+}
+
+func outbound(data string) audit.RawTranscriptEntry {
+	return audit.RawTranscriptEntry{Direction: auditv1.RawDirection_RAW_DIRECTION_OUTBOUND, Data: []byte(data)}
+}
+
+func Test_rawHandler_TCP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		opts         map[string]any
+		steps        []step
+		wantStartErr bool
+		wantDetails  any
+	}{
+		{
+			name: "Banner without rules",
+			opts: map[string]any{
+				"banner": "SSH-2.0-OpenSSH_8.9\r\n",
+			},
+			steps: []step{
+				{want: "SSH-2.0-OpenSSH_8.9\r\n"},
+				{send: "SSH-2.0-Go\r\n"},
+			},
+			wantDetails: audit.Raw{
+				Transcript: []audit.RawTranscriptEntry{
+					outbound("SSH-2.0-OpenSSH_8.9\r\n"),
+					inbound("SSH-2.0-Go\r\n"),
+				},
+				BytesReceived: 12,
+				BytesSent:     21,
+			},
+		},
+		{
+			name: "Hex banner",
+			opts: map[string]any{
+				"bannerHex": "de ad be ef",
+			},
+			steps: []step{
+				{want: "\xde\xad\xbe\xef"},
+			},
+			wantDetails: audit.Raw{
+				Transcript: []audit.RawTranscriptEntry{
+					outbound("\xde\xad\xbe\xef"),
+				},
+				BytesSent: 4,
+			},
+		},
+		{
+			name: "Regex rules",
+			opts: map[string]any{
+				"rules": []string{
+					`Regex("^HELO") => Reply("250 Hello\r\n")`,
+					`Regex("^QUIT") => Reply("221 Bye\r\n")`,
+				},
+			},
+			steps: []step{
+				{send: "HELO client\r\n", want: "250 Hello\r\n"},
+				{send: "QUIT\r\n", want: "221 Bye\r\n"},
+			},
+			wantDetails: audit.Raw{
+				Transcript: []audit.RawTranscriptEntry{
+					inbound("HELO client\r\n"),
+					outbound("250 Hello\r\n"),
+					inbound("QUIT\r\n"),
+					outbound("221 Bye\r\n"),
+				},
+				BytesReceived: 19,
+				BytesSent:     20,
+			},
+		},
+		{
+			name: "Hex rule closing the connection",
+			opts: map[string]any{
+				"rules": []string{
+					`Hex("16 03 01") => ReplyHex("15 03 01 00 02 02 28") => Close() => Reply("unreachable")`,
+				},
+			},
+			steps: []step{
+				{send: "\x16\x03\x01\x00\x05", want: "\x15\x03\x01\x00\x02\x02\x28"},
+			},
+			wantDetails: audit.Raw{
+				Transcript: []audit.RawTranscriptEntry{
+					inbound("\x16\x03\x01\x00\x05"),
+					outbound("\x15\x03\x01\x00\x02\x02\x28"),
+				},
+				BytesReceived: 5,
+				BytesSent:     7,
+			},
+		},
+		{
+			name: "Catch-all rule replying with fake file",
+			opts: map[string]any{
+				"rules": []string{
+					`=> File("sample.txt")`,
+				},
+			},
+			steps: []step{
+				{send: "GET /\r\n", want: "Hello from the fake file"},
+			},
+			wantDetails: audit.Raw{
+				Transcript: []audit.RawTranscriptEntry{
+					inbound("GET /\r\n"),
+					outbound("Hello from the fake file"),
+				},
+				BytesReceived: 7,
+				BytesSent:     24,
+			},
+		},
+		{
+			name: "Truncated transcript",
+			opts: map[string]any{
+				"banner":             "220 ready\r\n",
+				"maxTranscriptBytes": 16,
+			},
+			steps: []step{
+				{want: "220 ready\r\n"},
+				{send: "hello world"},
+			},
+			wantDetails: audit.Raw{
+				Transcript: []audit.RawTranscriptEntry{
+					outbound("220 ready\r\n"),
+					inbound("hello"),
+				},
+				Truncated:     true,
+				BytesReceived: 11,
+				BytesSent:     11,
+			},
+		},
+		{
+			name: "Error because of banner and hex banner",
+			opts: map[string]any{
+				"banner":    "220 ready\r\n",
+				"bannerHex": "00",
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of invalid hex banner",
+			opts: map[string]any{
+				"bannerHex": "xyz",
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of invalid regex",
+			opts: map[string]any{
+				"rules": []string{
+					`Regex("[a-") => Close()`,
+				},
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of unknown filter",
+			opts: map[string]any{
+				"rules": []string{
+					`Prefix("GET") => Close()`,
+				},
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of unknown action",
+			opts: map[string]any{
+				"rules": []string{
+					`=> Drop()`,
+				},
+			},
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			listener := test.NewTCPListener(t, "127.0.0.1:0")
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := raw.New(logging.CreateTestLogger(t), emitterMock, fakeFiles)
+
+			if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.opts)); err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			conn, err := net.DialTimeout("tcp", listener.Addr().String(), clientTimeout)
+			if err != nil {
+				t.Fatalf("net.Dial() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+			_ = conn.SetDeadline(time.Now().Add(clientTimeout))
+
+			for _, s := range tt.steps {
+				if s.send != "" {
+					if _, err = conn.Write([]byte(s.send)); err != nil {
+						t.Fatalf("Write() error = %v", err)
+					}
+				}
+
+				if s.want != "" {
+					got := make([]byte, len(s.want))
+					if _, err = io.ReadFull(conn, got); err != nil {
+						t.Fatalf("ReadFull() error = %v", err)
+					}
+					td.Cmp(t, string(got), s.want)
+				}
+			}
+
+			_ = conn.(*net.TCPConn).CloseWrite()
+
+			remaining, _ := io.ReadAll(conn)
+			td.CmpEmpty(t, remaining)
+
+			test.AwaitEvent(t, emitterMock, td.Struct(new(audit.Event), td.StructFields{
+				"Application":     auditv1.AppProtocol_APP_PROTOCOL_RAW,
+				"Transport":       auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP,
+				"ProtocolDetails": tt.wantDetails,
+			}))
+		})
+	}
+}
+
+func Test_rawHandler_UDP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		opts        map[string]any
+		send        string
+		want        []string
+		wantDetails any
+	}{
+		{
+			name: "Matching rule",
+			opts: map[string]any{
+				"rules": []string{
+					`Regex("^ping") => Reply("pong") => Close() => ReplyHex("00 01")`,
+				},
+			},
+			send: "ping",
+			want: []string{"pong"},
+			wantDetails: audit.Raw{
+				Transcript: []audit.RawTranscriptEntry{
+					inbound("ping"),
+					outbound("pong"),
+				},
+				BytesReceived: 4,
+				BytesSent:     4,
+			},
+		},
+		{
+			name: "Multiple datagrams",
+			opts: map[string]any{
+				"rules": []string{
+					`Hex("00") => ReplyHex("01") => ReplyHex("02 03")`,
+				},
+			},
+			send: "\x00",
+			want: []string{"\x01", "\x02\x03"},
+			wantDetails: audit.Raw{
+				Transcript: []audit.RawTranscriptEntry{
+					inbound("\x00"),
+					outbound("\x01\x02\x03"),
+				},
+				BytesReceived: 1,
+				BytesSent:     3,
+			},
+		},
+		{
+			name: "No matching rule",
+			opts: map[string]any{
+				"rules": []string{
+					`Regex("^ping") => Reply("pong")`,
+				},
+			},
+			send: "hello",
+			wantDetails: audit.Raw{
+				Transcript: []audit.RawTranscriptEntry{
+					inbound("hello"),
+				},
+				BytesReceived: 5,
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			srvConn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("net.ListenPacket() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = srvConn.Close()
+			})
+
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := raw.New(logging.CreateTestLogger(t), emitterMock, fakeFiles)
+			if err = handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(srvConn), tt.opts)); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			conn, err := net.Dial("udp4", srvConn.LocalAddr().String())
+			if err != nil {
+				t.Fatalf("net.Dial() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+			_ = conn.SetDeadline(time.Now().Add(clientTimeout))
+
+			if _, err = conn.Write([]byte(tt.send)); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+
+			buf := make([]byte, 1500)
+			for _, want := range tt.want {
+				n, readErr := conn.Read(buf)
+				if readErr != nil {
+					t.Fatalf("Read() error = %v", readErr)
+				}
+				td.Cmp(t, string(buf[:n]), want)
+			}
+
+			test.AwaitEvent(t, emitterMock, td.Struct(new(audit.Event), td.StructFields{
+				"Application":     auditv1.AppProtocol_APP_PROTOCOL_RAW,
+				"Transport":       auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP,
+				"ProtocolDetails": tt.wantDetails,
+			}))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package raw
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+const (
+	defaultIdleTimeout        = 30 * time.Second
+	defaultMaxTranscriptBytes = 64 * 1024
+)
+
+var ErrAmbiguousBanner = errors.New("either banner or bannerHex may be configured")
+
+type rawOptions struct {
+	// Banner is sent to TCP clients right after they connected
+	Banner string
+	// BannerHex is the hex encoded alternative to Banner for binary banners
+	BannerHex string
+	// IdleTimeout closes TCP connections if the client did not send anything for the given time
+	IdleTimeout time.Duration
+	// MaxTranscriptBytes limits the data recorded in the audit event of a session
+	MaxTranscriptBytes int
+	Rules              []string
+	banner             []byte
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts rawOptions, err error) {
+	opts = rawOptions{
+		IdleTimeout:        defaultIdleTimeout,
+		MaxTranscriptBytes: defaultMaxTranscriptBytes,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc())); err != nil {
+		return opts, err
+	}
+
+	switch {
+	case opts.Banner != "" && opts.BannerHex != "":
+		return opts, ErrAmbiguousBanner
+	case opts.BannerHex != "":
+		opts.banner, err = decodeHex(opts.BannerHex)
+	default:
+		opts.banner = []byte(opts.Banner)
+	}
+
+	return opts, err
+}
+
+// decodeHex decodes hex strings optionally separated by whitespace or colons e.g. "16 03 01" or "de:ad:be:ef"
+func decodeHex(raw string) ([]byte, error) {
+	return hex.DecodeString(strings.NewReplacer(" ", "", ":", "", "\t", "", "\n", "").Replace(raw))
+}
@@ -0,0 +1,23 @@
+package raw
+
+import (
+	"io/fs"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+func New(logger logging.Logger, emitter audit.Emitter, fakeFileFS fs.FS) endpoint.ProtocolHandler {
+	return &rawHandler{
+		logger:     logger,
+		emitter:    emitter,
+		fakeFileFS: fakeFileFS,
+	}
+}
+
+func AddRawMock(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter, fakeFileFS fs.FS) {
+	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
+		return New(logger, emitter, fakeFileFS)
+	})
+}
@@ -0,0 +1,232 @@
+package raw
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+var (
+	knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
+		"regex": RegexFilter,
+		"hex":   HexFilter,
+	}
+	knownActions = map[string]func(args ...rules.Param) (Action, error){
+		"reply":    ReplyAction,
+		"replyhex": ReplyHexAction,
+		"file":     FileAction,
+		"close":    CloseAction,
+	}
+)
+
+type (
+	// Request contains the data received since the last matching rule
+	Request struct {
+		Data []byte
+	}
+	RequestFilter interface {
+		Matches(req Request) bool
+	}
+	RequestFilterFunc func(req Request) bool
+	FilterChain       []RequestFilter
+
+	// Action is a single step of a response, it either sends data, a fake file or closes the connection
+	Action struct {
+		Data  []byte
+		File  string
+		Close bool
+	}
+
+	// Response is the sequence of actions executed if a rule matches, actions following a Close() are skipped
+	Response []Action
+
+	ConditionalResponse struct {
+		Filters  FilterChain
+		Response Response
+	}
+)
+
+func (f RequestFilterFunc) Matches(req Request) bool {
+	return f(req)
+}
+
+func (c FilterChain) Matches(req Request) bool {
+	for idx := range c {
+		if !c[idx].Matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+type RuleHandler struct {
+	HandlerName string
+	responses   rules.Set[ConditionalResponse]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	response, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.responses.Append(rawRule, response)
+	return nil
+}
+
+// RuleManager exposes the response rules, changes apply to the next data received on open connections.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalResponse]{
+		Set:     &h.responses,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the response of the first rule matching the given request
+func (h *RuleHandler) Evaluate(req Request, client string) (Response, bool) {
+	responses := h.responses.Entries()
+	for idx := range responses {
+		entry := responses[idx]
+		if entry.Value.Filters.Matches(req) {
+			protocols.RecordRuleMatch(entry, name, h.HandlerName, client)
+			return entry.Value.Response, true
+		}
+	}
+
+	return nil, false
+}
+
+func compileRule(rawRule string) (response ConditionalResponse, err error) {
+	var rule *rules.ChainedResponsePipeline
+	if rule, err = rules.Parse[rules.ChainedResponsePipeline](rawRule); err != nil {
+		return response, err
+	}
+
+	if response.Filters, err = filtersForRule(rule); err != nil {
+		return response, err
+	}
+
+	if len(rule.Response) == 0 {
+		return response, rules.ErrNoTerminatorDefined
+	}
+
+	response.Response = make(Response, 0, len(rule.Response))
+	for idx := range rule.Response {
+		constructor, ok := knownActions[strings.ToLower(rule.Response[idx].Name)]
+		if !ok {
+			return response, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response[idx].Name)
+		}
+
+		var action Action
+		if action, err = constructor(rule.Response[idx].Params...); err != nil {
+			return response, err
+		}
+		response.Response = append(response.Response, action)
+	}
+
+	return response, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownRequestFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+// RegexFilter matches the received data against a regular expression e.g. Regex(`^USER \w+`)
+func RegexFilter(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var exp *regexp.Regexp
+	if rawExp, err := args[0].AsString(); err != nil {
+		return nil, err
+	} else if exp, err = regexp.Compile(rawExp); err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return exp.Match(req.Data)
+	}), nil
+}
+
+// HexFilter matches if the received data starts with the given hex encoded bytes e.g. Hex("16 03 01")
+func HexFilter(args ...rules.Param) (RequestFilter, error) {
+	prefix, err := hexParam(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return bytes.HasPrefix(req.Data, prefix)
+	}), nil
+}
+
+// ReplyAction sends the given string, escape sequences like \r\n are supported in double quoted strings
+// e.g. Reply("220 ready\r\n")
+func ReplyAction(args ...rules.Param) (action Action, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return action, err
+	}
+
+	var data string
+	if data, err = args[0].AsString(); err != nil {
+		return action, err
+	}
+
+	action.Data = []byte(data)
+	return action, nil
+}
+
+// ReplyHexAction sends the given hex encoded bytes e.g. ReplyHex("15 03 01 00 02 02 28")
+func ReplyHexAction(args ...rules.Param) (action Action, err error) {
+	action.Data, err = hexParam(args)
+	return action, err
+}
+
+// FileAction sends the content of the given file of the fake files directory e.g. File("default.exe")
+func FileAction(args ...rules.Param) (action Action, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return action, err
+	}
+
+	action.File, err = args[0].AsString()
+	return action, err
+}
+
+// CloseAction closes the connection after the preceding actions were executed e.g. Close()
+func CloseAction(...rules.Param) (Action, error) {
+	return Action{Close: true}, nil
+}
+
+func hexParam(args []rules.Param) ([]byte, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	rawHex, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeHex(rawHex)
+}
@@ -0,0 +1,131 @@
+package raw
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const (
+	readBufferSize = 4096
+	// maxPendingBytes limits the data kept for matching if no rule matched so far
+	maxPendingBytes = 64 * 1024
+)
+
+type session struct {
+	handler    *rawHandler
+	conn       net.Conn
+	transcript *transcript
+	pending    []byte
+}
+
+func newSession(handler *rawHandler, conn net.Conn) *session {
+	return &session{
+		handler:    handler,
+		conn:       conn,
+		transcript: newTranscript(handler.options.MaxTranscriptBytes),
+	}
+}
+
+func (s *session) serve() error {
+	defer s.emit()
+
+	if len(s.handler.options.banner) > 0 {
+		if err := s.write(s.handler.options.banner); err != nil {
+			return err
+		}
+	}
+
+	buf := make([]byte, readBufferSize)
+	for {
+		_ = s.conn.SetReadDeadline(time.Now().Add(s.handler.options.IdleTimeout))
+		n, err := s.conn.Read(buf)
+		if n > 0 {
+			s.transcript.record(auditv1.RawDirection_RAW_DIRECTION_INBOUND, buf[:n])
+			s.buffer(buf[:n])
+
+			if closeConn, respErr := s.respond(); closeConn || respErr != nil {
+				return respErr
+			}
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, os.ErrDeadlineExceeded) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// buffer appends the received data to the data pending for matching,
+// if the limit is exceeded only the most recent data is kept
+func (s *session) buffer(data []byte) {
+	s.pending = append(s.pending, data...)
+	if overflow := len(s.pending) - maxPendingBytes; overflow > 0 {
+		s.pending = append(s.pending[:0], s.pending[overflow:]...)
+	}
+}
+
+// respond evaluates the pending data and executes the actions of the matching rule,
+// it returns true if the connection should be closed
+func (s *session) respond() (closeConn bool, err error) {
+	response, matched := s.handler.ruleHandler.Evaluate(Request{Data: s.pending}, s.client())
+	if !matched {
+		return false, nil
+	}
+
+	s.pending = s.pending[:0]
+
+	for idx := range response {
+		if response[idx].Close {
+			return true, nil
+		}
+
+		data, ok := s.handler.actionData(response[idx])
+		if !ok {
+			continue
+		}
+
+		if err = s.write(data); err != nil {
+			return true, err
+		}
+	}
+
+	return false, nil
+}
+
+func (s *session) write(data []byte) error {
+	n, err := s.conn.Write(data)
+	s.transcript.record(auditv1.RawDirection_RAW_DIRECTION_OUTBOUND, data[:n])
+	return err
+}
+
+func (s *session) client() string {
+	if ip, _, err := netutils.IPPortFromAddress(s.conn.RemoteAddr()); err == nil {
+		return ip.String()
+	}
+	return ""
+}
+
+func (s *session) emit() {
+	builder := s.handler.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_RAW).
+		WithProtocolDetails(s.transcript.details)
+
+	if details, ok := audit.NewTLSDetailsFromConn(s.conn); ok {
+		builder = builder.WithTLSDetails(details)
+	}
+
+	builder, _ = builder.WithSourceFromAddr(s.conn.RemoteAddr())
+	builder, _ = builder.WithDestinationFromAddr(s.conn.LocalAddr())
+
+	builder.Emit()
+}
@@ -0,0 +1,51 @@
+package raw
+
+import (
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+// transcript records the data exchanged with a client up to a fixed limit,
+// consecutive chunks of the same direction are merged into a single entry
+type transcript struct {
+	limit   int
+	size    int
+	details audit.Raw
+}
+
+func newTranscript(limit int) *transcript {
+	return &transcript{limit: limit}
+}
+
+func (t *transcript) record(direction auditv1.RawDirection, data []byte) {
+	switch direction {
+	case auditv1.RawDirection_RAW_DIRECTION_INBOUND:
+		t.details.BytesReceived += int64(len(data))
+	case auditv1.RawDirection_RAW_DIRECTION_OUTBOUND:
+		t.details.BytesSent += int64(len(data))
+	}
+
+	if remaining := t.limit - t.size; len(data) > remaining {
+		if remaining < 0 {
+			remaining = 0
+		}
+		data = data[:remaining]
+		t.details.Truncated = true
+	}
+
+	if len(data) == 0 {
+		return
+	}
+
+	t.size += len(data)
+
+	if last := len(t.details.Transcript) - 1; last >= 0 && t.details.Transcript[last].Direction == direction {
+		t.details.Transcript[last].Data = append(t.details.Transcript[last].Data, data...)
+		return
+	}
+
+	t.details.Transcript = append(t.details.Transcript, audit.RawTranscriptEntry{
+		Direction: direction,
+		Data:      append([]byte(nil), data...),
+	})
+}
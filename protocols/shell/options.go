@@ -0,0 +1,50 @@
+package shell
+
+import (
+	"time"
+)
+
+const (
+	defaultHostname         = "server"
+	defaultMaxLoginAttempts = 3
+	defaultIdleTimeout      = 5 * time.Minute
+)
+
+// Options configure the login and the fake shell of remote shell mocks like SSH or Telnet
+type Options struct {
+	// Hostname is used in the prompt and by the hostname command
+	Hostname string
+	// Banner is sent to the client before the login
+	Banner string
+	// MOTD is sent to the client after a successful login before the first prompt
+	MOTD string
+	// MaxLoginAttempts closes the connection after the given number of failed login attempts
+	MaxLoginAttempts int
+	// IdleTimeout closes connections without any input for the given time
+	IdleTimeout time.Duration
+	Rules       []string
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Hostname:         defaultHostname,
+		MaxLoginAttempts: defaultMaxLoginAttempts,
+		IdleTimeout:      defaultIdleTimeout,
+	}
+}
+
+// NewRuleHandler compiles the configured rules, handlerName is the name of the protocol handler e.g. ssh_mock
+func (o Options) NewRuleHandler(handlerName, endpointName string) (*RuleHandler, error) {
+	ruleHandler := &RuleHandler{
+		ProtocolHandler: handlerName,
+		HandlerName:     endpointName,
+	}
+
+	for idx := range o.Rules {
+		if err := ruleHandler.RegisterRule(o.Rules[idx]); err != nil {
+			return nil, err
+		}
+	}
+
+	return ruleHandler, nil
+}
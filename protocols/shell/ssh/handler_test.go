@@ -0,0 +1,303 @@
+package ssh_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+	"golang.org/x/crypto/ssh"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/state"
+	"inetmock.icb4dc0.de/inetmock/internal/state/statetest"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	sshmock "inetmock.icb4dc0.de/inetmock/protocols/shell/ssh"
+)
+
+const (
+	clientTimeout = 5 * time.Second
+	clientVersion = "SSH-2.0-Go"
+)
+
+var defaultRules = []any{
+	`Login() -> Username("^root$") -> Password("^(admin|root)$") => Accept()`,
+	`Login() -> Method("publickey") => Accept()`,
+	`Command("^uname") => Output("Linux server 5.10.0-21-amd64\n")`,
+	`Command("^reboot") => Output("bye\n") => Exit(3)`,
+}
+
+func Test_sshHandler_Login(t *testing.T) {
+	t.Parallel()
+	store := statetest.NewTestStore(t)
+
+	publicKeySigner := generateSigner(t)
+	tests := []struct {
+		name       string
+		user       string
+		auth       ssh.AuthMethod
+		wantErr    bool
+		wantEvents []any
+	}{
+		{
+			name: "Accept password",
+			user: "root",
+			auth: ssh.Password("admin"),
+			wantEvents: []any{
+				audit.Shell{
+					EventType:     auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+					ClientVersion: clientVersion,
+					Username:      "root",
+					AuthMethod:    auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD,
+					Password:      "admin",
+					Accepted:      true,
+				},
+			},
+		},
+		{
+			name:    "Reject password",
+			user:    "root",
+			auth:    ssh.RetryableAuthMethod(ssh.Password("123456"), 2),
+			wantErr: true,
+			wantEvents: []any{
+				audit.Shell{
+					EventType:     auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+					ClientVersion: clientVersion,
+					Username:      "root",
+					AuthMethod:    auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD,
+					Password:      "123456",
+				},
+				audit.Shell{
+					EventType:     auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+					ClientVersion: clientVersion,
+					Username:      "root",
+					AuthMethod:    auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD,
+					Password:      "123456",
+				},
+			},
+		},
+		{
+			name: "Accept keyboard interactive",
+			user: "root",
+			auth: ssh.KeyboardInteractive(func(string, string, []string, []bool) ([]string, error) {
+				return []string{"root"}, nil
+			}),
+			wantEvents: []any{
+				audit.Shell{
+					EventType:     auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+					ClientVersion: clientVersion,
+					Username:      "root",
+					AuthMethod:    auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_KEYBOARD_INTERACTIVE,
+					Password:      "root",
+					Accepted:      true,
+				},
+			},
+		},
+		{
+			name: "Accept public key",
+			user: "git",
+			auth: ssh.PublicKeys(publicKeySigner),
+			wantEvents: []any{
+				audit.Shell{
+					EventType:            auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+					ClientVersion:        clientVersion,
+					Username:             "git",
+					AuthMethod:           auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PUBLIC_KEY,
+					PublicKeyType:        ssh.KeyAlgoED25519,
+					PublicKeyFingerprint: ssh.FingerprintSHA256(publicKeySigner.PublicKey()),
+					Accepted:             true,
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			emitterMock := new(audit_mock.EmitterMock)
+			listener := startHandler(t, emitterMock, store, map[string]any{"rules": defaultRules})
+
+			client, err := ssh.Dial("tcp", listener.Addr().String(), clientConfig(tt.user, tt.auth))
+			if tt.wantErr {
+				td.CmpError(t, err)
+			} else if td.CmpNoError(t, err) {
+				td.CmpNoError(t, client.Close())
+			}
+
+			test.AwaitEventDetails(t, emitterMock, auditv1.AppProtocol_APP_PROTOCOL_SSH, tt.wantEvents)
+		})
+	}
+}
+
+func Test_sshHandler_Exec(t *testing.T) {
+	t.Parallel()
+	store := statetest.NewTestStore(t)
+
+	tests := []struct {
+		name         string
+		command      string
+		wantOutput   string
+		wantExitCode int
+	}{
+		{
+			name:       "Command with rule",
+			command:    "uname -a",
+			wantOutput: "Linux server 5.10.0-21-amd64\n",
+		},
+		{
+			name:       "Builtin command",
+			command:    "whoami",
+			wantOutput: "root\n",
+		},
+		{
+			name:         "Rule with exit code",
+			command:      "reboot",
+			wantOutput:   "bye\n",
+			wantExitCode: 3,
+		},
+		{
+			name:         "Unknown command",
+			command:      "curl http://evil.com",
+			wantOutput:   "-bash: curl: command not found\n",
+			wantExitCode: 127,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			emitterMock := new(audit_mock.EmitterMock)
+			listener := startHandler(t, emitterMock, store, map[string]any{"rules": defaultRules})
+
+			client, err := ssh.Dial("tcp", listener.Addr().String(), clientConfig("root", ssh.Password("root")))
+			if !td.CmpNoError(t, err) {
+				return
+			}
+			t.Cleanup(func() {
+				_ = client.Close()
+			})
+
+			session, err := client.NewSession()
+			if !td.CmpNoError(t, err) {
+				return
+			}
+
+			output, err := session.Output(tt.command)
+			td.Cmp(t, string(output), tt.wantOutput)
+
+			exitCode := 0
+			if exitErr := new(ssh.ExitError); errors.As(err, &exitErr) {
+				exitCode = exitErr.ExitStatus()
+			} else {
+				td.CmpNoError(t, err)
+			}
+			td.Cmp(t, exitCode, tt.wantExitCode)
+
+			test.AwaitEventDetails(t, emitterMock, auditv1.AppProtocol_APP_PROTOCOL_SSH, td.Contains(audit.Shell{
+				EventType:     auditv1.ShellEventType_SHELL_EVENT_TYPE_COMMAND,
+				ClientVersion: clientVersion,
+				Username:      "root",
+				Command:       tt.command,
+			}))
+		})
+	}
+}
+
+func Test_sshHandler_HostKeyPersisted(t *testing.T) {
+	t.Parallel()
+	store := statetest.NewTestStore(t)
+
+	var hostKeys []string
+	for i := 0; i < 2; i++ {
+		listener := startHandler(t, new(audit_mock.EmitterMock), store, map[string]any{"rules": defaultRules})
+
+		config := clientConfig("root", ssh.Password("root"))
+		config.HostKeyCallback = func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			hostKeys = append(hostKeys, ssh.FingerprintSHA256(key))
+			return nil
+		}
+
+		client, err := ssh.Dial("tcp", listener.Addr().String(), config)
+		if !td.CmpNoError(t, err) {
+			return
+		}
+		td.CmpNoError(t, client.Close())
+	}
+
+	if td.CmpLen(t, hostKeys, 2) {
+		td.Cmp(t, hostKeys[1], hostKeys[0])
+	}
+}
+
+func Test_sshHandler_Start_InvalidOptions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		opts map[string]any
+	}{
+		{
+			name: "Invalid server version",
+			opts: map[string]any{"serverVersion": "OpenSSH_8.4p1"},
+		},
+		{
+			name: "Invalid rule",
+			opts: map[string]any{"rules": []any{`Login() => Allow()`}},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			handler := sshmock.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock), statetest.NewTestStore(t))
+			listener := test.NewTCPListener(t, "127.0.0.1:0")
+			td.CmpError(t, handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.opts)))
+		})
+	}
+}
+
+func startHandler(tb testing.TB, emitter audit.Emitter, store state.KVStore, opts map[string]any) net.Listener {
+	tb.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+
+	listener := test.NewTCPListener(tb, "127.0.0.1:0")
+	handler := sshmock.New(logging.CreateTestLogger(tb), emitter, store)
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(tb.Name(), endpoint.NewUplink(listener), opts)); err != nil {
+		tb.Fatalf("Start() error = %v", err)
+	}
+
+	return listener
+}
+
+func clientConfig(user string, auth ssh.AuthMethod) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{auth},
+		ClientVersion:   clientVersion,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec // the host key of the mock is irrelevant
+		Timeout:         clientTimeout,
+	}
+}
+
+func generateSigner(tb testing.TB) ssh.Signer {
+	tb.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		tb.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		tb.Fatalf("NewSignerFromKey() error = %v", err)
+	}
+
+	return signer
+}
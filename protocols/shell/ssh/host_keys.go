@@ -0,0 +1,85 @@
+package ssh
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+
+	"github.com/dgraph-io/badger/v4"
+	"golang.org/x/crypto/ssh"
+
+	"inetmock.icb4dc0.de/inetmock/internal/state"
+)
+
+const (
+	hostKeysKey = "host_keys"
+	rsaKeyBits  = 3072
+)
+
+// hostKeys are the PKCS #8 encoded private keys of the server,
+// RSA keys are still required by a lot of older clients e.g. those embedded in malware
+type hostKeys struct {
+	Ed25519 []byte
+	RSA     []byte
+}
+
+// loadOrGenerateHostKeys loads the host keys from the state store or generates and stores them if they don't exist yet,
+// hence the host keys stay the same across restarts and are shared by all endpoints
+func loadOrGenerateHostKeys(store state.KVStore) (signers []ssh.Signer, err error) {
+	var keys hostKeys
+	err = store.ReadWriteTransaction(func(rw state.TxnReaderWriter) error {
+		if getErr := rw.Get(hostKeysKey, &keys); getErr == nil {
+			return nil
+		} else if !errors.Is(getErr, badger.ErrKeyNotFound) {
+			return getErr
+		}
+
+		var genErr error
+		if keys, genErr = generateHostKeys(); genErr != nil {
+			return genErr
+		}
+
+		return rw.Set(hostKeysKey, keys)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, encoded := range [][]byte{keys.Ed25519, keys.RSA} {
+		var (
+			key    any
+			signer ssh.Signer
+		)
+		if key, err = x509.ParsePKCS8PrivateKey(encoded); err != nil {
+			return nil, err
+		}
+		if signer, err = ssh.NewSignerFromKey(key); err != nil {
+			return nil, err
+		}
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
+
+func generateHostKeys() (keys hostKeys, err error) {
+	var ed25519Key, rsaKey crypto.PrivateKey
+	if _, ed25519Key, err = ed25519.GenerateKey(rand.Reader); err != nil {
+		return keys, err
+	}
+
+	if rsaKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits); err != nil {
+		return keys, err
+	}
+
+	if keys.Ed25519, err = x509.MarshalPKCS8PrivateKey(ed25519Key); err != nil {
+		return keys, err
+	}
+
+	keys.RSA, err = x509.MarshalPKCS8PrivateKey(rsaKey)
+	return keys, err
+}
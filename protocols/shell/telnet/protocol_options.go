@@ -0,0 +1,14 @@
+package telnet
+
+import (
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/shell"
+)
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts shell.Options, err error) {
+	opts = shell.DefaultOptions()
+	err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc()))
+	return opts, err
+}
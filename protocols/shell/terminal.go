@@ -0,0 +1,63 @@
+package shell
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var (
+	_ Terminal = (*lineTerminal)(nil)
+	_ Terminal = (*ttyTerminal)(nil)
+)
+
+// NewLineTerminal returns a Terminal for clients without a pseudo terminal,
+// the client is expected to echo its input and to send complete lines
+func NewLineTerminal(rw io.ReadWriter) Terminal {
+	return &lineTerminal{
+		Writer: rw,
+		reader: bufio.NewReader(rw),
+	}
+}
+
+// NewTTYTerminal returns a Terminal for clients with a pseudo terminal,
+// the input is echoed and can be edited and newlines of outputs are translated to CRLF
+func NewTTYTerminal(rw io.ReadWriter) Terminal {
+	return &ttyTerminal{
+		terminal: term.NewTerminal(rw, ""),
+	}
+}
+
+type lineTerminal struct {
+	io.Writer
+	reader *bufio.Reader
+}
+
+func (t *lineTerminal) ReadLine(prompt string) (string, error) {
+	if _, err := io.WriteString(t.Writer, prompt); err != nil {
+		return "", err
+	}
+
+	line, err := t.reader.ReadString('\n')
+	if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+type ttyTerminal struct {
+	terminal *term.Terminal
+}
+
+func (t *ttyTerminal) Write(p []byte) (int, error) {
+	return t.terminal.Write(p)
+}
+
+func (t *ttyTerminal) ReadLine(prompt string) (string, error) {
+	t.terminal.SetPrompt(prompt)
+	return t.terminal.ReadLine()
+}
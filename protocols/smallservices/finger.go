@@ -0,0 +1,72 @@
+package smallservices
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// maxQueryLength limits the length of finger and ident requests, they're usually much shorter
+	maxQueryLength = 512
+	// fingerLoginAge is subtracted from the current time to fake the login time of the listed users
+	fingerLoginAge = 3 * time.Hour
+)
+
+// serveFinger answers a single finger query and closes the connection (RFC 1288).
+// Every queried user exists, forwarding queries like user@host are refused.
+func serveFinger(s *session) error {
+	query, err := readQuery(bufio.NewReaderSize(s, maxQueryLength))
+	if err != nil {
+		return err
+	}
+
+	s.addQuery(query)
+
+	user := strings.TrimSpace(strings.TrimPrefix(query, "/W"))
+	switch {
+	case strings.Contains(user, "@"):
+		_, err = s.Write([]byte("finger: forwarding service denied\r\n"))
+	case user == "":
+		_, err = s.Write([]byte(fingerUserList(s.options().Users)))
+	default:
+		_, err = s.Write([]byte(fingerUserInfo(user)))
+	}
+
+	return err
+}
+
+func fingerUserList(users []string) string {
+	var builder strings.Builder
+	builder.WriteString("Login     Name       Tty      Idle  Login Time\r\n")
+
+	loginTime := time.Now().Add(-fingerLoginAge).Format("Jan  2 15:04")
+	for idx := range users {
+		_, _ = fmt.Fprintf(&builder, "%-9s %-10s pts/%-4d    1d  %s\r\n", users[idx], users[idx], idx, loginTime)
+	}
+
+	return builder.String()
+}
+
+func fingerUserInfo(user string) string {
+	home := "/home/" + user
+	if user == "root" {
+		home = "/root"
+	}
+
+	return fmt.Sprintf(
+		"Login: %-32sName: %s\r\nDirectory: %-28sShell: /bin/bash\r\nNever logged in.\r\nNo mail.\r\nNo Plan.\r\n",
+		user, user, home,
+	)
+}
+
+// readQuery reads a single CRLF or LF terminated line without the line ending
+func readQuery(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadSlice('\n')
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
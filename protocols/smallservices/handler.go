@@ -0,0 +1,235 @@
+package smallservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const (
+	maxDatagramSize = 65535
+	// privilegedPorts are the ports below this limit
+	privilegedPorts = 1024
+	// droppedReportInterval is the interval dropped datagrams are summarized in a single event
+	droppedReportInterval = time.Second
+)
+
+type service struct {
+	name        string
+	application auditv1.AppProtocol
+	// stream serves a TCP connection
+	stream func(s *session) error
+	// datagram returns the reply to a UDP datagram or nil if it is not answered,
+	// services without datagram func are only available via TCP
+	datagram func(opts *smallServiceOptions, req []byte) (reply []byte, query string)
+}
+
+type smallServiceHandler struct {
+	service         service
+	logger          logging.Logger
+	emitter         audit.Emitter
+	options         smallServiceOptions
+	datagramLimiter *rate.Limiter
+	dropped         droppedDatagrams
+	ctx             context.Context
+	cancel          context.CancelFunc
+	conns           endpoint.ConnTracker
+}
+
+func (h *smallServiceHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", h.service.name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	switch {
+	case startupSpec.Listener != nil:
+		h.ctx, h.cancel = context.WithCancel(context.Background())
+		h.conns.Open()
+		go h.serve(startupSpec.Listener)
+	case startupSpec.PacketConn != nil && h.service.datagram != nil:
+		burst := int(math.Ceil(h.options.DatagramsPerSecond))
+		h.datagramLimiter = rate.NewLimiter(rate.Limit(h.options.DatagramsPerSecond), burst)
+		go h.servePackets(startupSpec.PacketConn)
+	default:
+		return fmt.Errorf("%w: %s requires a TCP listener", endpoint.ErrUnsupportedProtocol, h.service.name)
+	}
+
+	return nil
+}
+
+// Stop aborts endless responses like the chargen stream and closes all client connections
+func (h *smallServiceHandler) Stop(context.Context) error {
+	if h.cancel != nil {
+		h.cancel()
+	}
+
+	h.conns.CloseAll()
+	h.dropped.Flush()
+
+	return nil
+}
+
+func (h *smallServiceHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *smallServiceHandler) handleConn(conn net.Conn) {
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+	}()
+
+	s := newSession(h, conn)
+	err := h.service.stream(s)
+
+	h.emit(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP, conn.LocalAddr(), conn.RemoteAddr(), s.details)
+
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, os.ErrDeadlineExceeded), errors.Is(err, errLimitExceeded):
+	default:
+		if err = endpoint.IgnoreShutdownError(err); err != nil {
+			h.logger.Debug("Session terminated", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+		}
+	}
+}
+
+func (h *smallServiceHandler) servePackets(conn net.PacketConn) {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, remote, err := conn.ReadFrom(buf)
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to read datagram", zap.Error(err))
+			}
+			return
+		}
+
+		h.handleDatagram(conn, remote, buf[:n])
+	}
+}
+
+// handleDatagram answers a single datagram as long as the rate limit is not exceeded,
+// this prevents the endpoint from being abused to amplify traffic towards spoofed source addresses.
+// Datagrams from privileged ports are never answered to avoid loops with other services e.g. echo and chargen.
+// Dropped datagrams are summarized in a single event per interval, otherwise a flood of datagrams would cause
+// a flood of events.
+func (h *smallServiceHandler) handleDatagram(conn net.PacketConn, remote net.Addr, req []byte) {
+	details := audit.SmallService{
+		BytesReceived: int64(len(req)),
+	}
+
+	reply, query := h.service.datagram(&h.options, req)
+	if query != "" {
+		details.Queries = []string{query}
+	}
+
+	switch {
+	case reply == nil:
+	case fromPrivilegedPort(remote), !h.datagramLimiter.Allow():
+		h.dropped.Add(len(req), func(dropped audit.SmallService) {
+			h.emit(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP, conn.LocalAddr(), remote, dropped)
+		})
+		return
+	default:
+		if n, err := conn.WriteTo(reply, remote); err != nil {
+			h.logger.Debug("Failed to send datagram", zap.String("remote", remote.String()), zap.Error(err))
+		} else {
+			details.BytesSent = int64(n)
+		}
+	}
+
+	h.emit(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP, conn.LocalAddr(), remote, details)
+}
+
+func fromPrivilegedPort(addr net.Addr) bool {
+	_, port, err := netutils.IPPortFromAddress(addr)
+	return err != nil || port < privilegedPorts
+}
+
+func (h *smallServiceHandler) emit(transport auditv1.TransportProtocol, local, remote net.Addr, details audit.SmallService) {
+	builder := h.emitter.Builder().
+		WithTransport(transport).
+		WithApplication(h.service.application).
+		WithProtocolDetails(details)
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(remote)
+	builder, _ = builder.WithDestinationFromAddr(local)
+
+	builder.Emit()
+}
+
+// droppedDatagrams summarizes the datagrams dropped within droppedReportInterval.
+// The first dropped datagram of an interval schedules the report, the report is emitted with the source address of
+// the last dropped datagram.
+type droppedDatagrams struct {
+	lock    sync.Mutex
+	details audit.SmallService
+	report  func(details audit.SmallService)
+	timer   *time.Timer
+}
+
+func (d *droppedDatagrams) Add(size int, report func(details audit.SmallService)) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.details.RateLimited = true
+	d.details.DroppedDatagrams++
+	d.details.BytesReceived += int64(size)
+	d.report = report
+
+	if d.timer == nil {
+		d.timer = time.AfterFunc(droppedReportInterval, d.Flush)
+	}
+}
+
+// Flush emits the report of the datagrams dropped so far right away
+func (d *droppedDatagrams) Flush() {
+	d.lock.Lock()
+	details, report := d.details, d.report
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.details, d.report, d.timer = audit.SmallService{}, nil, nil
+	d.lock.Unlock()
+
+	if report != nil {
+		report(details)
+	}
+}
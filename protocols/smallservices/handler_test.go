@@ -0,0 +1,413 @@
+package smallservices_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/smallservices"
+)
+
+const (
+	clientTimeout         = 5 * time.Second
+	secondsFrom1900To1970 = 2208988800
+	year                  = 365 * 24 * time.Hour
+)
+
+func Test_smallServiceHandler_TCP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		handler         endpoint.HandlerReference
+		opts            map[string]any
+		send            string
+		wantStartErr    bool
+		wantReply       any
+		wantApplication auditv1.AppProtocol
+		wantDetails     any
+		wantMinDuration time.Duration
+	}{
+		{
+			name:            "Echo",
+			handler:         "echo_mock",
+			send:            "hello\r\n",
+			wantReply:       "hello\r\n",
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_ECHO,
+			wantDetails: audit.SmallService{
+				BytesReceived: 7,
+				BytesSent:     7,
+			},
+		},
+		{
+			name:    "Echo exceeding max bytes",
+			handler: "echo_mock",
+			opts: map[string]any{
+				"maxBytes": 4,
+			},
+			send:            "hello world",
+			wantReply:       "hell",
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_ECHO,
+			wantDetails: audit.SmallService{
+				BytesReceived: 11,
+				BytesSent:     4,
+				RateLimited:   true,
+			},
+		},
+		{
+			name:            "Discard",
+			handler:         "discard_mock",
+			send:            "hello world",
+			wantReply:       "",
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_DISCARD,
+			wantDetails: audit.SmallService{
+				BytesReceived: 11,
+			},
+		},
+		{
+			name:            "Daytime",
+			handler:         "daytime_mock",
+			wantReply:       td.Re(`^\w+day, \w+ \d{1,2}, \d{4} \d{2}:\d{2}:\d{2}-\w+\r\n$`),
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_DAYTIME,
+			wantDetails:     td.Struct(audit.SmallService{}, td.StructFields{"BytesSent": td.Gt(int64(0))}),
+		},
+		{
+			name:    "Quote of the day",
+			handler: "quotd_mock",
+			opts: map[string]any{
+				"quotes": []string{"Hello, World!"},
+			},
+			wantReply:       "Hello, World!\r\n",
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_QUOTD,
+			wantDetails: audit.SmallService{
+				BytesSent: 15,
+			},
+		},
+		{
+			name:    "Chargen limited by max bytes",
+			handler: "chargen_mock",
+			opts: map[string]any{
+				"maxBytes": 148,
+			},
+			wantReply:       chargenLine(0) + chargenLine(1),
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_CHARGEN,
+			wantDetails: audit.SmallService{
+				BytesSent:   148,
+				RateLimited: true,
+			},
+		},
+		{
+			name:    "Chargen limited by bandwidth",
+			handler: "chargen_mock",
+			opts: map[string]any{
+				"maxBytes":       300,
+				"bytesPerSecond": 100,
+			},
+			wantReply:       td.Len(300),
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_CHARGEN,
+			wantDetails: audit.SmallService{
+				BytesSent:   300,
+				RateLimited: true,
+			},
+			wantMinDuration: 1500 * time.Millisecond,
+		},
+		{
+			name:    "Time with offset",
+			handler: "time_mock",
+			opts: map[string]any{
+				"offset": "+365d",
+			},
+			wantReply: td.Code(func(reply string) bool {
+				if len(reply) != 4 {
+					return false
+				}
+				expected := time.Now().Add(year).Unix() + secondsFrom1900To1970
+				return int64(binary.BigEndian.Uint32([]byte(reply)))-expected <= 1
+			}),
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_TIME,
+			wantDetails: audit.SmallService{
+				BytesSent: 4,
+			},
+		},
+		{
+			name:            "Finger user",
+			handler:         "finger_mock",
+			send:            "alice\r\n",
+			wantReply:       td.Re(`^Login: alice\s+Name: alice\r\nDirectory: /home/alice\s+Shell: /bin/bash\r\n`),
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_FINGER,
+			wantDetails: td.SStruct(audit.SmallService{
+				Queries:       []string{"alice"},
+				BytesReceived: 7,
+			}, td.StructFields{"BytesSent": td.Gt(int64(0))}),
+		},
+		{
+			name:    "Finger user list",
+			handler: "finger_mock",
+			opts: map[string]any{
+				"users": []string{"root", "alice"},
+			},
+			send:            "/W\r\n",
+			wantReply:       td.All(td.HasPrefix("Login     Name"), td.Contains("\r\nroot "), td.Contains("\r\nalice ")),
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_FINGER,
+			wantDetails: td.SStruct(audit.SmallService{
+				Queries:       []string{"/W"},
+				BytesReceived: 4,
+			}, td.StructFields{"BytesSent": td.Gt(int64(0))}),
+		},
+		{
+			name:            "Finger forwarding denied",
+			handler:         "finger_mock",
+			send:            "bob@example.com\n",
+			wantReply:       "finger: forwarding service denied\r\n",
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_FINGER,
+			wantDetails: audit.SmallService{
+				Queries:       []string{"bob@example.com"},
+				BytesReceived: 16,
+				BytesSent:     35,
+			},
+		},
+		{
+			name:    "Ident",
+			handler: "ident_mock",
+			opts: map[string]any{
+				"userID": "alice",
+			},
+			send:            "6193, 23\r\n0 , 23\r\n",
+			wantReply:       "6193 , 23 : USERID : UNIX : alice\r\n0 , 23 : ERROR : INVALID-PORT\r\n",
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_IDENT,
+			wantDetails: audit.SmallService{
+				Queries:       []string{"6193, 23", "0 , 23"},
+				BytesReceived: 18,
+				BytesSent:     66,
+			},
+		},
+		{
+			name:    "Error because of invalid limit",
+			handler: "chargen_mock",
+			opts: map[string]any{
+				"bytesPerSecond": 0,
+			},
+			wantStartErr: true,
+		},
+		{
+			name:    "Error because of invalid offset",
+			handler: "daytime_mock",
+			opts: map[string]any{
+				"offset": "tomorrow",
+			},
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			listener := test.NewTCPListener(t, "127.0.0.1:0")
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := handlerForName(t, emitterMock, tt.handler)
+
+			if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(listener), tt.opts)); err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			start := time.Now()
+			conn, err := net.DialTimeout("tcp", listener.Addr().String(), clientTimeout)
+			if err != nil {
+				t.Fatalf("net.Dial() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+			_ = conn.SetDeadline(time.Now().Add(clientTimeout))
+
+			if tt.send != "" {
+				if _, err = conn.Write([]byte(tt.send)); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+				_ = conn.(*net.TCPConn).CloseWrite()
+			}
+
+			reply, err := io.ReadAll(conn)
+			td.CmpNoError(t, err)
+			td.Cmp(t, string(reply), tt.wantReply)
+			td.Cmp(t, time.Since(start), td.Gte(tt.wantMinDuration))
+
+			test.AwaitEvents(t, emitterMock, td.Bag(td.Struct(new(audit.Event), td.StructFields{
+				"Application":     tt.wantApplication,
+				"Transport":       auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP,
+				"ProtocolDetails": tt.wantDetails,
+			})))
+		})
+	}
+}
+
+func Test_smallServiceHandler_UDP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		handler         endpoint.HandlerReference
+		opts            map[string]any
+		send            []string
+		wantStartErr    bool
+		wantReplies     []any
+		wantApplication auditv1.AppProtocol
+		wantDetails     []any
+	}{
+		{
+			name:            "Echo",
+			handler:         "echo_mock",
+			send:            []string{"hello"},
+			wantReplies:     []any{"hello"},
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_ECHO,
+			wantDetails: []any{
+				audit.SmallService{BytesReceived: 5, BytesSent: 5},
+			},
+		},
+		{
+			name:            "Discard",
+			handler:         "discard_mock",
+			send:            []string{"hello"},
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_DISCARD,
+			wantDetails: []any{
+				audit.SmallService{BytesReceived: 5},
+			},
+		},
+		{
+			name:            "Chargen",
+			handler:         "chargen_mock",
+			send:            []string{""},
+			wantReplies:     []any{strings.Join([]string{chargenLine(0), chargenLine(1), chargenLine(2), chargenLine(3), chargenLine(4), chargenLine(5)}, "")},
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_CHARGEN,
+			wantDetails: []any{
+				audit.SmallService{BytesSent: 444},
+			},
+		},
+		{
+			name:    "Rate limited echo",
+			handler: "echo_mock",
+			opts: map[string]any{
+				"datagramsPerSecond": 0.1,
+			},
+			send:            []string{"first", "second", "third"},
+			wantReplies:     []any{"first"},
+			wantApplication: auditv1.AppProtocol_APP_PROTOCOL_ECHO,
+			wantDetails: []any{
+				audit.SmallService{BytesReceived: 5, BytesSent: 5},
+				audit.SmallService{BytesReceived: 11, RateLimited: true, DroppedDatagrams: 2},
+			},
+		},
+		{
+			name:         "Error because finger requires TCP",
+			handler:      "finger_mock",
+			wantStartErr: true,
+		},
+		{
+			name:         "Error because ident requires TCP",
+			handler:      "ident_mock",
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			srvConn := listenUDP(t)
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := handlerForName(t, emitterMock, tt.handler)
+
+			if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(srvConn), tt.opts)); err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			clientConn := listenUDP(t)
+			for _, datagram := range tt.send {
+				if _, err := clientConn.WriteTo([]byte(datagram), srvConn.LocalAddr()); err != nil {
+					t.Fatalf("WriteTo() error = %v", err)
+				}
+			}
+
+			test.AwaitEvents(t, emitterMock, td.All(
+				td.ArrayEach(td.Struct(&audit.Event{
+					Application: tt.wantApplication,
+					Transport:   auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP,
+				}, nil)),
+				test.EventDetails(tt.wantDetails),
+			))
+
+			var replies []any
+			buf := make([]byte, 1500)
+			_ = clientConn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+			for {
+				n, _, err := clientConn.ReadFrom(buf)
+				if err != nil {
+					break
+				}
+				replies = append(replies, string(buf[:n]))
+			}
+
+			td.Cmp(t, replies, td.Bag(tt.wantReplies...))
+		})
+	}
+}
+
+func handlerForName(tb testing.TB, emitter audit.Emitter, handlerRef endpoint.HandlerReference) endpoint.ProtocolHandler {
+	tb.Helper()
+	registry := endpoint.NewHandlerRegistry()
+	smallservices.AddSmallServices(registry, logging.CreateTestLogger(tb), emitter)
+
+	handler, ok := registry.HandlerForName(handlerRef)
+	if !ok {
+		tb.Fatalf("handler %s not registered", handlerRef)
+	}
+	return handler
+}
+
+func listenUDP(tb testing.TB) net.PacketConn {
+	tb.Helper()
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("net.ListenPacket() error = %v", err)
+	}
+	tb.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func chargenLine(idx int) string {
+	var builder strings.Builder
+	for i := 0; i < 72; i++ {
+		builder.WriteByte(byte(' ' + (idx+i)%95))
+	}
+	builder.WriteString("\r\n")
+	return builder.String()
+}
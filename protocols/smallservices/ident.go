@@ -0,0 +1,53 @@
+package smallservices
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const maxPort = 65535
+
+// serveIdent answers ident queries until the client closes the connection (RFC 1413).
+// Every valid port pair is attributed to the configured user.
+func serveIdent(s *session) error {
+	reader := bufio.NewReaderSize(s, maxQueryLength)
+	for {
+		query, err := readQuery(reader)
+		if err != nil {
+			return err
+		}
+
+		s.addQuery(query)
+
+		if _, err = s.Write([]byte(identResponse(s.options(), query))); err != nil {
+			return err
+		}
+	}
+}
+
+func identResponse(opts *smallServiceOptions, query string) string {
+	serverPort, clientPort, ok := parsePortPair(query)
+	if !ok {
+		return fmt.Sprintf("%d , %d : ERROR : INVALID-PORT\r\n", serverPort, clientPort)
+	}
+
+	return fmt.Sprintf("%d , %d : USERID : %s : %s\r\n", serverPort, clientPort, opts.OperatingSystem, opts.UserID)
+}
+
+func parsePortPair(query string) (serverPort, clientPort int, ok bool) {
+	rawServerPort, rawClientPort, found := strings.Cut(query, ",")
+	if !found {
+		return 0, 0, false
+	}
+
+	serverPort, serverErr := strconv.Atoi(strings.TrimSpace(rawServerPort))
+	clientPort, clientErr := strconv.Atoi(strings.TrimSpace(rawClientPort))
+
+	return serverPort, clientPort, serverErr == nil && clientErr == nil && validPort(serverPort) && validPort(clientPort)
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= maxPort
+}
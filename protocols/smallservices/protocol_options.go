@@ -0,0 +1,82 @@
+package smallservices
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/timeoffset"
+)
+
+const (
+	defaultIdleTimeout        = 30 * time.Second
+	defaultMaxBytes           = 1024 * 1024
+	defaultBytesPerSecond     = 64 * 1024
+	defaultDatagramsPerSecond = 10
+)
+
+var (
+	defaultQuotes = []string{
+		"The best way to predict the future is to invent it. - Alan Kay",
+		"Talk is cheap. Show me the code. - Linus Torvalds",
+		"Simplicity is prerequisite for reliability. - Edsger W. Dijkstra",
+		"The most dangerous phrase in the language is, 'We've always done it this way.' - Grace Hopper",
+	}
+
+	ErrInvalidLimit = errors.New("limits have to be greater than zero")
+)
+
+type smallServiceOptions struct {
+	// IdleTimeout closes TCP connections if the client did not send or receive anything for the given time
+	IdleTimeout time.Duration
+	// MaxBytes limits the bytes sent per TCP connection e.g. by echo or chargen
+	MaxBytes int64
+	// BytesPerSecond limits the bandwidth per TCP connection
+	BytesPerSecond int
+	// DatagramsPerSecond limits the UDP responses of an endpoint, datagrams exceeding the limit are not answered
+	DatagramsPerSecond float64
+	// Offset shifts the time returned by daytime and time e.g. "+365d"
+	Offset string
+	// Quotes are randomly chosen by quotd
+	Quotes []string
+	// Users are listed by finger if the client did not ask for a specific user
+	Users []string
+	// UserID is returned by ident for every port pair
+	UserID string
+	// OperatingSystem is returned by ident alongside the UserID
+	OperatingSystem string
+	offset          time.Duration
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts smallServiceOptions, err error) {
+	opts = smallServiceOptions{
+		IdleTimeout:        defaultIdleTimeout,
+		MaxBytes:           defaultMaxBytes,
+		BytesPerSecond:     defaultBytesPerSecond,
+		DatagramsPerSecond: defaultDatagramsPerSecond,
+		UserID:             "root",
+		OperatingSystem:    "UNIX",
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc())); err != nil {
+		return opts, err
+	}
+
+	if opts.IdleTimeout <= 0 || opts.MaxBytes <= 0 || opts.BytesPerSecond <= 0 || opts.DatagramsPerSecond <= 0 {
+		return opts, ErrInvalidLimit
+	}
+
+	// slices are merged with their defaults by mapstructure, therefore they're only defaulted if not configured
+	if len(opts.Quotes) == 0 {
+		opts.Quotes = defaultQuotes
+	}
+
+	if len(opts.Users) == 0 {
+		opts.Users = []string{"root"}
+	}
+
+	opts.offset, err = timeoffset.Parse(opts.Offset)
+	return opts, err
+}
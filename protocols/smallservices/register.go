@@ -0,0 +1,22 @@
+package smallservices
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+// AddSmallServices registers the handlers echo_mock, discard_mock, daytime_mock, quotd_mock, chargen_mock, time_mock,
+// finger_mock and ident_mock
+func AddSmallServices(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter) {
+	for idx := range services {
+		svc := services[idx]
+		registry.RegisterHandler(endpoint.HandlerReference(svc.name), func() endpoint.ProtocolHandler {
+			return &smallServiceHandler{
+				service: svc,
+				logger:  logger.Named(svc.name),
+				emitter: emitter,
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package smallservices
+
+import (
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"time"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const (
+	readBufferSize = 4096
+	// maxQuoteLength is the maximum length of a quote including the trailing CRLF as defined in RFC 865
+	maxQuoteLength = 512
+	// maxChargenDatagram is the maximum size of a chargen datagram as defined in RFC 864
+	maxChargenDatagram = 512
+	chargenLineLength  = 72
+	chargenCharacters  = 95
+	crlf               = "\r\n"
+	timeResponseLength = 4
+	// secondsFrom1900To1970 converts UNIX time to the time protocol's epoch as defined in RFC 868
+	secondsFrom1900To1970 = 2208988800
+	daytimeLayout         = "Monday, January 2, 2006 15:04:05-MST"
+)
+
+var services = []service{
+	{name: "echo_mock", application: auditv1.AppProtocol_APP_PROTOCOL_ECHO, stream: serveEcho, datagram: answerEcho},
+	{name: "discard_mock", application: auditv1.AppProtocol_APP_PROTOCOL_DISCARD, stream: serveDiscard, datagram: answerDiscard},
+	{name: "daytime_mock", application: auditv1.AppProtocol_APP_PROTOCOL_DAYTIME, stream: serveDaytime, datagram: answerDaytime},
+	{name: "quotd_mock", application: auditv1.AppProtocol_APP_PROTOCOL_QUOTD, stream: serveQuotd, datagram: answerQuotd},
+	{name: "chargen_mock", application: auditv1.AppProtocol_APP_PROTOCOL_CHARGEN, stream: serveChargen, datagram: answerChargen},
+	{name: "time_mock", application: auditv1.AppProtocol_APP_PROTOCOL_TIME, stream: serveTime, datagram: answerTime},
+	{name: "finger_mock", application: auditv1.AppProtocol_APP_PROTOCOL_FINGER, stream: serveFinger},
+	{name: "ident_mock", application: auditv1.AppProtocol_APP_PROTOCOL_IDENT, stream: serveIdent},
+}
+
+// serveEcho sends back everything it receives until the client closes the connection (RFC 862)
+func serveEcho(s *session) error {
+	buf := make([]byte, readBufferSize)
+	for {
+		n, err := s.Read(buf)
+		if n > 0 {
+			if _, writeErr := s.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func answerEcho(_ *smallServiceOptions, req []byte) ([]byte, string) {
+	return req, ""
+}
+
+// serveDiscard throws away everything it receives until the client closes the connection (RFC 863)
+func serveDiscard(s *session) error {
+	_, err := io.Copy(io.Discard, s)
+	return err
+}
+
+func answerDiscard(*smallServiceOptions, []byte) ([]byte, string) {
+	return nil, ""
+}
+
+// serveDaytime sends the current time in a human-readable format and closes the connection (RFC 867)
+func serveDaytime(s *session) error {
+	reply, _ := answerDaytime(s.options(), nil)
+	_, err := s.Write(reply)
+	return err
+}
+
+func answerDaytime(opts *smallServiceOptions, _ []byte) ([]byte, string) {
+	return []byte(time.Now().Add(opts.offset).Format(daytimeLayout) + crlf), ""
+}
+
+// serveQuotd sends a random quote and closes the connection (RFC 865)
+func serveQuotd(s *session) error {
+	reply, _ := answerQuotd(s.options(), nil)
+	_, err := s.Write(reply)
+	return err
+}
+
+func answerQuotd(opts *smallServiceOptions, _ []byte) ([]byte, string) {
+	//nolint:gosec // quotes do not have to be chosen unpredictably
+	quote := opts.Quotes[rand.Intn(len(opts.Quotes))]
+	if len(quote) > maxQuoteLength-len(crlf) {
+		quote = quote[:maxQuoteLength-len(crlf)]
+	}
+	return []byte(quote + crlf), ""
+}
+
+// serveChargen sends lines of rotating printable characters until the client closes the connection
+// or the configured maximum number of bytes is reached (RFC 864)
+func serveChargen(s *session) error {
+	for idx := 0; ; idx++ {
+		if _, err := s.Write(chargenLine(idx)); err != nil {
+			return err
+		}
+	}
+}
+
+func answerChargen(*smallServiceOptions, []byte) ([]byte, string) {
+	reply := make([]byte, 0, maxChargenDatagram)
+	for idx := 0; len(reply)+chargenLineLength+len(crlf) <= maxChargenDatagram; idx++ {
+		reply = append(reply, chargenLine(idx)...)
+	}
+	return reply, ""
+}
+
+func chargenLine(idx int) []byte {
+	line := make([]byte, 0, chargenLineLength+len(crlf))
+	for i := 0; i < chargenLineLength; i++ {
+		line = append(line, byte(' '+(idx+i)%chargenCharacters))
+	}
+	return append(line, crlf...)
+}
+
+// serveTime sends the current time as seconds since 1900 and closes the connection (RFC 868)
+func serveTime(s *session) error {
+	reply, _ := answerTime(s.options(), nil)
+	_, err := s.Write(reply)
+	return err
+}
+
+func answerTime(opts *smallServiceOptions, _ []byte) ([]byte, string) {
+	reply := make([]byte, timeResponseLength)
+	// the conversion wraps in 2036 which is the expected behavior of the protocol
+	binary.BigEndian.PutUint32(reply, uint32(time.Now().Add(opts.offset).Unix()+secondsFrom1900To1970))
+	return reply, ""
+}
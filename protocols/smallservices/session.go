@@ -0,0 +1,78 @@
+package smallservices
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+)
+
+var errLimitExceeded = errors.New("maximum number of bytes per connection exceeded")
+
+// session wraps a TCP connection, it applies the idle timeout to reads and writes
+// and the bandwidth and size limits to writes while counting the transferred bytes
+type session struct {
+	handler *smallServiceHandler
+	conn    net.Conn
+	limiter *rate.Limiter
+	details audit.SmallService
+}
+
+func newSession(handler *smallServiceHandler, conn net.Conn) *session {
+	return &session{
+		handler: handler,
+		conn:    conn,
+		limiter: rate.NewLimiter(rate.Limit(handler.options.BytesPerSecond), handler.options.BytesPerSecond),
+	}
+}
+
+func (s *session) options() *smallServiceOptions {
+	return &s.handler.options
+}
+
+func (s *session) Read(p []byte) (n int, err error) {
+	_ = s.conn.SetReadDeadline(time.Now().Add(s.handler.options.IdleTimeout))
+	n, err = s.conn.Read(p)
+	s.details.BytesReceived += int64(n)
+	return n, err
+}
+
+// Write sends the given data in chunks not exceeding the configured bandwidth,
+// if the data exceeds the remaining bytes of the connection it's cut short and errLimitExceeded is returned
+func (s *session) Write(p []byte) (written int, err error) {
+	var limitErr error
+	if remaining := s.handler.options.MaxBytes - s.details.BytesSent; int64(len(p)) > remaining {
+		p = p[:remaining]
+		s.details.RateLimited = true
+		limitErr = errLimitExceeded
+	}
+
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > s.limiter.Burst() {
+			chunk = chunk[:s.limiter.Burst()]
+		}
+
+		if err = s.limiter.WaitN(s.handler.ctx, len(chunk)); err != nil {
+			return written, err
+		}
+
+		_ = s.conn.SetWriteDeadline(time.Now().Add(s.handler.options.IdleTimeout))
+		n, writeErr := s.conn.Write(chunk)
+		written += n
+		s.details.BytesSent += int64(n)
+		if writeErr != nil {
+			return written, writeErr
+		}
+		p = p[n:]
+	}
+
+	return written, limitErr
+}
+
+func (s *session) addQuery(query string) {
+	s.details.Queries = append(s.details.Queries, query)
+}
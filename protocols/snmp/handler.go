@@ -0,0 +1,527 @@
+package snmp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/gosnmp/gosnmp"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const (
+	name            = "snmp_mock"
+	maxDatagramSize = 65535
+	// engineBoots is reported as number of times the engine was (re-)initialized, the engine time starts at 0
+	// with every start of the endpoint hence the mock behaves like a freshly installed agent
+	engineBoots = 1
+	// minVarBindSize is the minimal size of an encoded variable binding,
+	// it limits the variables collected for GETBULK requests before the response is truncated to the message size
+	minVarBindSize = 7
+	// badCommunityReport is the counter incremented by agents for messages with unknown community strings
+	badCommunityReport = "snmpInBadCommunityNames"
+)
+
+var (
+	ErrUnsupportedRequest = errors.New("unsupported SNMP request")
+
+	operations = map[gosnmp.PDUType]auditv1.SNMPOperation{
+		gosnmp.GetRequest:     auditv1.SNMPOperation_SNMP_OPERATION_GET,
+		gosnmp.GetNextRequest: auditv1.SNMPOperation_SNMP_OPERATION_GET_NEXT,
+		gosnmp.GetBulkRequest: auditv1.SNMPOperation_SNMP_OPERATION_GET_BULK,
+		gosnmp.SetRequest:     auditv1.SNMPOperation_SNMP_OPERATION_SET,
+	}
+	versions = map[gosnmp.SnmpVersion]auditv1.SNMPVersion{
+		gosnmp.Version1:  auditv1.SNMPVersion_SNMP_VERSION_V1,
+		gosnmp.Version2c: auditv1.SNMPVersion_SNMP_VERSION_V2C,
+		gosnmp.Version3:  auditv1.SNMPVersion_SNMP_VERSION_V3,
+	}
+	securityLevels = map[gosnmp.SnmpV3MsgFlags]auditv1.SNMPSecurityLevel{
+		gosnmp.NoAuthNoPriv: auditv1.SNMPSecurityLevel_SNMP_SECURITY_LEVEL_NO_AUTH_NO_PRIV,
+		gosnmp.AuthNoPriv:   auditv1.SNMPSecurityLevel_SNMP_SECURITY_LEVEL_AUTH_NO_PRIV,
+		gosnmp.AuthPriv:     auditv1.SNMPSecurityLevel_SNMP_SECURITY_LEVEL_AUTH_PRIV,
+	}
+	// settableTypes are the types which are accepted in SET requests
+	settableTypes = map[gosnmp.Asn1BER]bool{
+		gosnmp.Integer:          true,
+		gosnmp.OctetString:      true,
+		gosnmp.ObjectIdentifier: true,
+		gosnmp.IPAddress:        true,
+		gosnmp.Counter32:        true,
+		gosnmp.Gauge32:          true,
+		gosnmp.TimeTicks:        true,
+		gosnmp.Counter64:        true,
+	}
+)
+
+type snmpHandler struct {
+	logger  logging.Logger
+	emitter audit.Emitter
+	options snmpOptions
+	mib     *mib
+	started time.Time
+	reports map[string]uint32
+}
+
+func (h *snmpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	if startupSpec.PacketConn == nil {
+		return fmt.Errorf("%w: %s requires a UDP listener", endpoint.ErrUnsupportedProtocol, name)
+	}
+
+	h.mib = newMIB(h.options.variables)
+	h.started = time.Now()
+	h.reports = make(map[string]uint32)
+
+	go h.serve(startupSpec.PacketConn)
+	return nil
+}
+
+func (h *snmpHandler) serve(conn net.PacketConn) {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, remote, err := conn.ReadFrom(buf)
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to read SNMP message", zap.Error(err))
+			}
+			return
+		}
+
+		h.handle(conn, remote, buf[:n])
+	}
+}
+
+func (h *snmpHandler) handle(conn net.PacketConn, remote net.Addr, data []byte) {
+	var (
+		resp    []byte
+		details audit.SNMP
+	)
+
+	msg, err := ber.DecodePacketErr(data)
+	if err == nil && len(msg.Children) < 2 {
+		err = ErrMalformedMessage
+	}
+
+	if err == nil {
+		version, _ := msg.Children[0].Value.(int64)
+		switch gosnmp.SnmpVersion(version) {
+		case gosnmp.Version1, gosnmp.Version2c:
+			resp, details, err = h.handleCommunity(data)
+		case gosnmp.Version3:
+			resp, details, err = h.handleUSM(msg, data)
+		default:
+			err = fmt.Errorf("%w: version %d", ErrUnsupportedRequest, version)
+		}
+	}
+
+	if err != nil {
+		h.logger.Debug("Discarding SNMP message", zap.String("remote", remote.String()), zap.Error(err))
+		return
+	}
+
+	if resp != nil {
+		if _, err = conn.WriteTo(resp, remote); err != nil {
+			h.logger.Debug("Failed to send SNMP response", zap.String("remote", remote.String()), zap.Error(err))
+		}
+	}
+
+	h.emit(conn.LocalAddr(), remote, details)
+}
+
+// handleCommunity processes SNMPv1 and SNMPv2c requests, requests with unknown communities are discarded silently
+func (h *snmpHandler) handleCommunity(data []byte) (resp []byte, details audit.SNMP, err error) {
+	req, err := new(gosnmp.GoSNMP).SnmpDecodePacket(data)
+	if err != nil {
+		return nil, details, fmt.Errorf("%w: %v", ErrMalformedMessage, err)
+	}
+
+	if err = supported(req); err != nil {
+		return nil, details, err
+	}
+
+	details = requestDetails(req)
+	details.Community = req.Community
+
+	mayWrite, granted := h.access(req.Community)
+	if !granted {
+		details.Report = badCommunityReport
+		return nil, details, nil
+	}
+
+	out := h.process(req, mayWrite)
+	out.Version = req.Version
+	out.Community = req.Community
+	details.ErrorStatus = uint32(out.Error)
+
+	resp, err = h.marshal(out, req.PDUType == gosnmp.GetBulkRequest, h.options.MaxMessageSize)
+	return resp, details, err
+}
+
+// handleUSM processes SNMPv3 requests of the user-based security model,
+// requests failing the security checks are answered with a report if the client requests one
+func (h *snmpHandler) handleUSM(msg *ber.Packet, data []byte) (resp []byte, details audit.SNMP, err error) {
+	hdr, err := parseV3Header(msg)
+	if err != nil {
+		return nil, details, err
+	}
+
+	if hdr.securityModel != int64(gosnmp.UserSecurityModel) {
+		return nil, details, fmt.Errorf("%w: security model %d", ErrUnsupportedRequest, hdr.securityModel)
+	}
+
+	details = audit.SNMP{
+		Version:       auditv1.SNMPVersion_SNMP_VERSION_V3,
+		Operation:     operations[hdr.pduType],
+		UserName:      hdr.userName,
+		SecurityLevel: securityLevels[hdr.flags&gosnmp.AuthPriv],
+		ContextName:   hdr.contextName,
+		RequestID:     hdr.requestID,
+	}
+
+	user, known := h.options.users[hdr.userName]
+	switch {
+	case hdr.engineID != h.options.engineID:
+		// this is also the case for the discovery of the engine ID
+		resp, err = h.report(hdr, reportUnknownEngineIDs, &details)
+		return resp, details, err
+	case !known:
+		resp, err = h.report(hdr, reportUnknownUserNames, &details)
+		return resp, details, err
+	case !securityLevelSupported(user, hdr.flags):
+		resp, err = h.report(hdr, reportUnsupportedSecLevels, &details)
+		return resp, details, err
+	case hdr.flags&gosnmp.AuthNoPriv != 0 && !authentic(user, data, hdr.authParams):
+		resp, err = h.report(hdr, reportWrongDigests, &details)
+		return resp, details, err
+	}
+
+	decoder := &gosnmp.GoSNMP{
+		Version:            gosnmp.Version3,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		SecurityParameters: user,
+	}
+
+	// the decoder modifies the message while checking the authentication parameters
+	req, err := decoder.UnmarshalTrap(bytes.Clone(data), true)
+	if err != nil {
+		if hdr.flags&gosnmp.AuthPriv == gosnmp.AuthPriv {
+			resp, err = h.report(hdr, reportDecryptionErrors, &details)
+			return resp, details, err
+		}
+		return nil, details, fmt.Errorf("%w: %v", ErrMalformedMessage, err)
+	}
+
+	if err = supported(req); err != nil {
+		return nil, details, err
+	}
+
+	requested := requestDetails(req)
+	details.Operation = requested.Operation
+	details.ContextName = req.ContextName
+	details.RequestID = req.RequestID
+	details.Variables = requested.Variables
+
+	out := h.process(req, true)
+	out.Version = gosnmp.Version3
+	out.MsgFlags = req.MsgFlags &^ gosnmp.Reportable
+	out.SecurityModel = gosnmp.UserSecurityModel
+	out.MsgID = req.MsgID
+	out.MsgMaxSize = uint32(h.options.MaxMessageSize)
+	out.SecurityParameters = h.engineParameters(user)
+	out.ContextEngineID = h.options.engineID
+	out.ContextName = req.ContextName
+	details.ErrorStatus = uint32(out.Error)
+
+	// every encrypted response requires a new salt
+	if err = user.InitPacket(out); err != nil {
+		return nil, details, err
+	}
+
+	maxSize := h.options.MaxMessageSize
+	if reqMaxSize := int(req.MsgMaxSize); reqMaxSize >= minMaxMessageSize && reqMaxSize < maxSize {
+		maxSize = reqMaxSize
+	}
+
+	resp, err = h.marshal(out, req.PDUType == gosnmp.GetBulkRequest, maxSize)
+	return resp, details, err
+}
+
+func (h *snmpHandler) report(hdr v3Header, report usmReport, details *audit.SNMP) ([]byte, error) {
+	h.reports[report.name]++
+	details.Report = report.name
+
+	if hdr.flags&gosnmp.Reportable == 0 {
+		return nil, nil
+	}
+
+	out := &gosnmp.SnmpPacket{
+		Version:            gosnmp.Version3,
+		MsgFlags:           gosnmp.NoAuthNoPriv,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		MsgID:              hdr.msgID,
+		MsgMaxSize:         uint32(h.options.MaxMessageSize),
+		SecurityParameters: h.engineParameters(&gosnmp.UsmSecurityParameters{UserName: hdr.userName}),
+		ContextEngineID:    h.options.engineID,
+		ContextName:        hdr.contextName,
+		PDUType:            gosnmp.Report,
+		RequestID:          hdr.requestID,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: report.oid, Type: gosnmp.Counter32, Value: h.reports[report.name]},
+		},
+	}
+
+	return out.MarshalMsg()
+}
+
+// engineParameters returns the security parameters of the user with the current state of the engine
+func (h *snmpHandler) engineParameters(user *gosnmp.UsmSecurityParameters) *gosnmp.UsmSecurityParameters {
+	return &gosnmp.UsmSecurityParameters{
+		AuthoritativeEngineID:    h.options.engineID,
+		AuthoritativeEngineBoots: engineBoots,
+		AuthoritativeEngineTime:  uint32(time.Since(h.started) / time.Second),
+		UserName:                 user.UserName,
+		AuthenticationProtocol:   user.AuthenticationProtocol,
+		PrivacyProtocol:          user.PrivacyProtocol,
+		SecretKey:                user.SecretKey,
+		PrivacyKey:               user.PrivacyKey,
+	}
+}
+
+func (h *snmpHandler) access(community string) (mayWrite, granted bool) {
+	if len(h.options.Communities) == 0 {
+		return true, true
+	}
+
+	access, granted := h.options.Communities[community]
+	return access == accessWrite, granted
+}
+
+// process answers the request from the MIB tree, the returned packet lacks the version specific fields
+func (h *snmpHandler) process(req *gosnmp.SnmpPacket, mayWrite bool) *gosnmp.SnmpPacket {
+	out := &gosnmp.SnmpPacket{
+		PDUType:   gosnmp.GetResponse,
+		RequestID: req.RequestID,
+	}
+
+	switch req.PDUType {
+	case gosnmp.GetRequest:
+		out.Variables, out.Error, out.ErrorIndex = h.get(req)
+	case gosnmp.GetNextRequest:
+		out.Variables, out.Error, out.ErrorIndex = h.getNext(req)
+	case gosnmp.GetBulkRequest:
+		out.Variables = h.getBulk(req)
+	case gosnmp.SetRequest:
+		out.Variables, out.Error, out.ErrorIndex = h.set(req, mayWrite)
+	}
+
+	return out
+}
+
+func (h *snmpHandler) get(req *gosnmp.SnmpPacket) ([]gosnmp.SnmpPDU, gosnmp.SNMPError, uint8) {
+	variables := make([]gosnmp.SnmpPDU, 0, len(req.Variables))
+	for idx, requested := range req.Variables {
+		o, err := parseOID(requested.Name)
+		if err == nil {
+			if v, found := h.mib.get(o); found && visible(req.Version, v) {
+				variables = append(variables, v.pdu(h.started))
+				continue
+			}
+		}
+
+		if req.Version == gosnmp.Version1 {
+			return req.Variables, gosnmp.NoSuchName, uint8(idx + 1)
+		}
+
+		missing := gosnmp.SnmpPDU{Name: requested.Name, Type: gosnmp.NoSuchObject}
+		if err == nil && h.mib.containsObject(o) {
+			missing.Type = gosnmp.NoSuchInstance
+		}
+		variables = append(variables, missing)
+	}
+
+	return variables, gosnmp.NoError, 0
+}
+
+func (h *snmpHandler) getNext(req *gosnmp.SnmpPacket) ([]gosnmp.SnmpPDU, gosnmp.SNMPError, uint8) {
+	variables := make([]gosnmp.SnmpPDU, 0, len(req.Variables))
+	for idx, requested := range req.Variables {
+		next := h.successor(req.Version, requested.Name)
+		if next.Type == gosnmp.EndOfMibView && req.Version == gosnmp.Version1 {
+			return req.Variables, gosnmp.NoSuchName, uint8(idx + 1)
+		}
+		variables = append(variables, next)
+	}
+
+	return variables, gosnmp.NoError, 0
+}
+
+// getBulk implements the GETBULK operation according to RFC 3416 4.2.3, the number of collected variables is limited by
+// the message size, the response is truncated further if it exceeds the size after encoding
+func (h *snmpHandler) getBulk(req *gosnmp.SnmpPacket) []gosnmp.SnmpPDU {
+	var (
+		nonRepeaters = int(req.NonRepeaters)
+		maxVariables = h.options.MaxMessageSize / minVarBindSize
+		variables    = make([]gosnmp.SnmpPDU, 0, len(req.Variables))
+	)
+
+	if nonRepeaters > len(req.Variables) {
+		nonRepeaters = len(req.Variables)
+	}
+
+	for _, requested := range req.Variables[:nonRepeaters] {
+		variables = append(variables, h.successor(req.Version, requested.Name))
+	}
+
+	last := make([]string, 0, len(req.Variables)-nonRepeaters)
+	for _, requested := range req.Variables[nonRepeaters:] {
+		last = append(last, requested.Name)
+	}
+
+	for repetition := uint32(0); repetition < req.MaxRepetitions && len(last) > 0 && len(variables) < maxVariables; repetition++ {
+		endOfMIB := true
+		for idx := range last {
+			next := h.successor(req.Version, last[idx])
+			endOfMIB = endOfMIB && next.Type == gosnmp.EndOfMibView
+			last[idx] = next.Name
+			variables = append(variables, next)
+		}
+
+		if endOfMIB {
+			break
+		}
+	}
+
+	return variables
+}
+
+// set stores all variables of the request in the MIB tree, either all variables are stored or none
+func (h *snmpHandler) set(req *gosnmp.SnmpPacket, mayWrite bool) ([]gosnmp.SnmpPDU, gosnmp.SNMPError, uint8) {
+	if !mayWrite {
+		// RFC 2576 maps noAccess to noSuchName for SNMPv1
+		if req.Version == gosnmp.Version1 {
+			return req.Variables, gosnmp.NoSuchName, 1
+		}
+		return req.Variables, gosnmp.NoAccess, 1
+	}
+
+	variables := make([]variable, 0, len(req.Variables))
+	for idx, requested := range req.Variables {
+		o, err := parseOID(requested.Name)
+		v := variable{oid: o, typ: requested.Type, value: requested.Value}
+		switch {
+		case err != nil:
+			return req.Variables, gosnmp.NotWritable, uint8(idx + 1)
+		case !settableTypes[requested.Type] || requested.Value == nil || !visible(req.Version, v):
+			if req.Version == gosnmp.Version1 {
+				return req.Variables, gosnmp.BadValue, uint8(idx + 1)
+			}
+			return req.Variables, gosnmp.WrongType, uint8(idx + 1)
+		}
+		variables = append(variables, v)
+	}
+
+	h.mib.set(variables)
+
+	return req.Variables, gosnmp.NoError, 0
+}
+
+// successor returns the variable following the given OID or endOfMibView if there's none
+func (h *snmpHandler) successor(version gosnmp.SnmpVersion, name string) gosnmp.SnmpPDU {
+	o, err := parseOID(name)
+	if err != nil {
+		// invalid OIDs are treated like the root of the tree
+		o = oid{}
+	}
+
+	if v, found := h.mib.next(o, func(v variable) bool { return visible(version, v) }); found {
+		return v.pdu(h.started)
+	}
+
+	return gosnmp.SnmpPDU{Name: name, Type: gosnmp.EndOfMibView}
+}
+
+// marshal encodes the response, responses exceeding the maximum size are truncated if possible or replaced with
+// a tooBig error
+func (h *snmpHandler) marshal(out *gosnmp.SnmpPacket, truncatable bool, maxSize int) ([]byte, error) {
+	for {
+		raw, err := out.MarshalMsg()
+		if err != nil || len(raw) <= maxSize || len(out.Variables) == 0 {
+			return raw, err
+		}
+
+		if !truncatable {
+			out.Error, out.ErrorIndex, out.Variables = gosnmp.TooBig, 0, nil
+			continue
+		}
+
+		// the variables are shrunk proportionally to avoid encoding the response for every removed variable
+		keep := len(out.Variables) * maxSize / len(raw)
+		if keep >= len(out.Variables) {
+			keep = len(out.Variables) - 1
+		}
+		out.Variables = out.Variables[:keep]
+	}
+}
+
+func (h *snmpHandler) emit(local, remote net.Addr, details audit.SNMP) {
+	builder := h.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_SNMP).
+		WithProtocolDetails(details)
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(remote)
+	builder, _ = builder.WithDestinationFromAddr(local)
+
+	builder.Emit()
+}
+
+// visible hides Counter64 variables from SNMPv1 requests because SNMPv1 doesn't support them
+func visible(version gosnmp.SnmpVersion, v variable) bool {
+	return version != gosnmp.Version1 || v.typ != gosnmp.Counter64
+}
+
+func supported(req *gosnmp.SnmpPacket) error {
+	if _, ok := operations[req.PDUType]; !ok || (req.Version == gosnmp.Version1 && req.PDUType == gosnmp.GetBulkRequest) {
+		return fmt.Errorf("%w: PDU type %s", ErrUnsupportedRequest, req.PDUType)
+	}
+	return nil
+}
+
+func requestDetails(req *gosnmp.SnmpPacket) audit.SNMP {
+	details := audit.SNMP{
+		Version:   versions[req.Version],
+		Operation: operations[req.PDUType],
+		RequestID: req.RequestID,
+		Variables: make([]audit.SNMPVariable, 0, len(req.Variables)),
+	}
+
+	for idx := range req.Variables {
+		variable := audit.SNMPVariable{OID: req.Variables[idx].Name}
+		if req.PDUType == gosnmp.SetRequest {
+			variable.Value = formatValue(req.Variables[idx])
+		}
+		details.Variables = append(details.Variables, variable)
+	}
+
+	return details
+}
@@ -0,0 +1,564 @@
+package snmp_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/snmp"
+)
+
+const (
+	sysDescr    = ".1.3.6.1.2.1.1.1.0"
+	sysName     = ".1.3.6.1.2.1.1.5.0"
+	sysLocation = ".1.3.6.1.2.1.1.6.0"
+	ifNumber    = ".1.3.6.1.2.1.2.1.0"
+	hcInOctets  = ".1.3.6.1.2.1.31.1.1.1.6.1"
+)
+
+var defaultOptions = map[string]any{
+	"communities": map[string]any{
+		"public":  "read",
+		"private": "write",
+	},
+	"system": map[string]any{
+		"name": "core-switch-01",
+	},
+	"mib": []map[string]any{
+		{"oid": ifNumber, "type": "integer", "value": 2},
+		{"oid": hcInOctets, "type": "counter64", "value": "1337"},
+	},
+	"users": []map[string]any{
+		{"name": "noauth"},
+		{"name": "authonly", "authProtocol": "SHA256", "authPassphrase": "inetmock-auth"},
+		{"name": "admin", "authProtocol": "SHA", "authPassphrase": "inetmock-auth", "privProtocol": "AES", "privPassphrase": "inetmock-priv"},
+	},
+}
+
+func Test_snmpHandler_Get(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		client    gosnmp.GoSNMP
+		oids      []string
+		want      any
+		wantErr   any
+		wantEvent any
+	}{
+		{
+			name:   "SNMPv2c system group defaults",
+			client: gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"},
+			oids:   []string{sysName, sysLocation},
+			want: td.Struct(new(gosnmp.SnmpPacket), td.StructFields{
+				"Error": gosnmp.NoError,
+				"Variables": []gosnmp.SnmpPDU{
+					{Name: sysName, Type: gosnmp.OctetString, Value: []byte("core-switch-01")},
+					{Name: sysLocation, Type: gosnmp.OctetString, Value: []byte("Sitting on the Dock of the Bay")},
+				},
+			}),
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"Application": auditv1.AppProtocol_APP_PROTOCOL_SNMP,
+				"Transport":   auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP,
+				"ProtocolDetails": td.Struct(audit.SNMP{
+					Version:   auditv1.SNMPVersion_SNMP_VERSION_V2C,
+					Operation: auditv1.SNMPOperation_SNMP_OPERATION_GET,
+					Community: "public",
+					Variables: []audit.SNMPVariable{{OID: sysName}, {OID: sysLocation}},
+				}, td.StructFields{
+					"RequestID": td.Ignore(),
+				}),
+			}),
+		},
+		{
+			name:   "SNMPv2c missing instances",
+			client: gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"},
+			oids:   []string{ifNumber, ".1.3.6.1.2.1.1.5.1", ".1.3.6.1.4.1.9.9.1.0"},
+			want: td.Struct(new(gosnmp.SnmpPacket), td.StructFields{
+				"Error": gosnmp.NoError,
+				"Variables": []gosnmp.SnmpPDU{
+					{Name: ifNumber, Type: gosnmp.Integer, Value: 2},
+					{Name: ".1.3.6.1.2.1.1.5.1", Type: gosnmp.NoSuchInstance},
+					{Name: ".1.3.6.1.4.1.9.9.1.0", Type: gosnmp.NoSuchObject},
+				},
+			}),
+			wantEvent: td.NotNil(),
+		},
+		{
+			name:   "SNMPv1 missing instance",
+			client: gosnmp.GoSNMP{Version: gosnmp.Version1, Community: "private"},
+			oids:   []string{sysName, hcInOctets},
+			want: td.Struct(new(gosnmp.SnmpPacket), td.StructFields{
+				"Error":      gosnmp.NoSuchName,
+				"ErrorIndex": uint8(2),
+			}),
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"Version": 1, "Community": "private", "ErrorStatus": 2}`),
+			}),
+		},
+		{
+			name:    "SNMPv2c unknown community",
+			client:  gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "secret", Timeout: 200 * time.Millisecond},
+			oids:    []string{sysDescr},
+			wantErr: td.NotNil(),
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"Community": "secret", "Report": "snmpInBadCommunityNames"}`),
+			}),
+		},
+		{
+			name: "SNMPv3 authPriv",
+			client: gosnmp.GoSNMP{
+				Version:       gosnmp.Version3,
+				SecurityModel: gosnmp.UserSecurityModel,
+				MsgFlags:      gosnmp.AuthPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{
+					UserName:                 "admin",
+					AuthenticationProtocol:   gosnmp.SHA,
+					AuthenticationPassphrase: "inetmock-auth",
+					PrivacyProtocol:          gosnmp.AES,
+					PrivacyPassphrase:        "inetmock-priv",
+				},
+			},
+			oids: []string{sysName, hcInOctets},
+			want: td.Struct(new(gosnmp.SnmpPacket), td.StructFields{
+				"Error": gosnmp.NoError,
+				"Variables": []gosnmp.SnmpPDU{
+					{Name: sysName, Type: gosnmp.OctetString, Value: []byte("core-switch-01")},
+					{Name: hcInOctets, Type: gosnmp.Counter64, Value: uint64(1337)},
+				},
+			}),
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(audit.SNMP{
+					Version:       auditv1.SNMPVersion_SNMP_VERSION_V3,
+					Operation:     auditv1.SNMPOperation_SNMP_OPERATION_GET,
+					UserName:      "admin",
+					SecurityLevel: auditv1.SNMPSecurityLevel_SNMP_SECURITY_LEVEL_AUTH_PRIV,
+					Variables:     []audit.SNMPVariable{{OID: sysName}, {OID: hcInOctets}},
+				}, td.StructFields{
+					"RequestID": td.Ignore(),
+				}),
+			}),
+		},
+		{
+			name: "SNMPv3 authNoPriv",
+			client: gosnmp.GoSNMP{
+				Version:       gosnmp.Version3,
+				SecurityModel: gosnmp.UserSecurityModel,
+				MsgFlags:      gosnmp.AuthNoPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{
+					UserName:                 "authonly",
+					AuthenticationProtocol:   gosnmp.SHA256,
+					AuthenticationPassphrase: "inetmock-auth",
+				},
+			},
+			oids: []string{ifNumber},
+			want: td.Struct(new(gosnmp.SnmpPacket), td.StructFields{
+				"Variables": []gosnmp.SnmpPDU{{Name: ifNumber, Type: gosnmp.Integer, Value: 2}},
+			}),
+			wantEvent: td.NotNil(),
+		},
+		{
+			name: "SNMPv3 noAuthNoPriv",
+			client: gosnmp.GoSNMP{
+				Version:            gosnmp.Version3,
+				SecurityModel:      gosnmp.UserSecurityModel,
+				MsgFlags:           gosnmp.NoAuthNoPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "noauth"},
+			},
+			oids: []string{ifNumber},
+			want: td.Struct(new(gosnmp.SnmpPacket), td.StructFields{
+				"Variables": []gosnmp.SnmpPDU{{Name: ifNumber, Type: gosnmp.Integer, Value: 2}},
+			}),
+			wantEvent: td.NotNil(),
+		},
+		{
+			name: "SNMPv3 wrong passphrase",
+			client: gosnmp.GoSNMP{
+				Version:       gosnmp.Version3,
+				SecurityModel: gosnmp.UserSecurityModel,
+				MsgFlags:      gosnmp.AuthPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{
+					UserName:                 "admin",
+					AuthenticationProtocol:   gosnmp.SHA,
+					AuthenticationPassphrase: "guessed-password",
+					PrivacyProtocol:          gosnmp.AES,
+					PrivacyPassphrase:        "inetmock-priv",
+				},
+			},
+			oids:    []string{sysName},
+			wantErr: gosnmp.ErrWrongDigest,
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"UserName": "admin", "SecurityLevel": 3, "Report": "usmStatsWrongDigests"}`),
+			}),
+		},
+		{
+			name: "SNMPv3 unknown user",
+			client: gosnmp.GoSNMP{
+				Version:       gosnmp.Version3,
+				SecurityModel: gosnmp.UserSecurityModel,
+				MsgFlags:      gosnmp.AuthNoPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{
+					UserName:                 "root",
+					AuthenticationProtocol:   gosnmp.MD5,
+					AuthenticationPassphrase: "password",
+				},
+			},
+			oids:    []string{sysName},
+			wantErr: gosnmp.ErrUnknownUsername,
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"UserName": "root", "Report": "usmStatsUnknownUserNames"}`),
+			}),
+		},
+		{
+			name: "SNMPv3 unsupported security level",
+			client: gosnmp.GoSNMP{
+				Version:       gosnmp.Version3,
+				SecurityModel: gosnmp.UserSecurityModel,
+				MsgFlags:      gosnmp.AuthNoPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{
+					UserName:                 "noauth",
+					AuthenticationProtocol:   gosnmp.MD5,
+					AuthenticationPassphrase: "password",
+				},
+			},
+			oids:    []string{sysName},
+			wantErr: gosnmp.ErrUnknownSecurityLevel,
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"UserName": "noauth", "Report": "usmStatsUnsupportedSecLevels"}`),
+			}),
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, emitterMock := setupHandler(t, defaultOptions, tt.client)
+
+			got, err := client.Get(tt.oids)
+			switch wantErr := tt.wantErr.(type) {
+			case nil:
+				if td.CmpNoError(t, err) {
+					td.Cmp(t, got, tt.want)
+				}
+			case td.TestDeep:
+				td.Cmp(t, err, wantErr)
+			case error:
+				td.Cmp(t, errors.Is(err, wantErr), true)
+			}
+
+			emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+				// the discovery of the engine ID is recorded as well
+				if td.Cmp(t, calls.Emit(), td.NotEmpty()) {
+					td.Cmp(t, calls.Emit()[len(calls.Emit())-1].Params.Ev, tt.wantEvent)
+				}
+			})
+		})
+	}
+}
+
+func Test_snmpHandler_Walk(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		client  gosnmp.GoSNMP
+		bulk    bool
+		rootOID string
+		want    any
+	}{
+		{
+			name:    "SNMPv1 walk skips Counter64 variables",
+			client:  gosnmp.GoSNMP{Version: gosnmp.Version1, Community: "public"},
+			rootOID: ".1.3.6.1.2.1",
+			want: []string{
+				sysDescr, ".1.3.6.1.2.1.1.2.0", ".1.3.6.1.2.1.1.3.0", ".1.3.6.1.2.1.1.4.0", sysName, sysLocation,
+				".1.3.6.1.2.1.1.7.0", ifNumber,
+			},
+		},
+		{
+			name:    "SNMPv2c walk",
+			client:  gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"},
+			rootOID: ".1.3.6.1.2.1.2",
+			want:    []string{ifNumber},
+		},
+		{
+			name:    "SNMPv2c bulk walk",
+			client:  gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public", MaxRepetitions: 3},
+			bulk:    true,
+			rootOID: ".1.3.6.1.2.1",
+			want: []string{
+				sysDescr, ".1.3.6.1.2.1.1.2.0", ".1.3.6.1.2.1.1.3.0", ".1.3.6.1.2.1.1.4.0", sysName, sysLocation,
+				".1.3.6.1.2.1.1.7.0", ifNumber, hcInOctets,
+			},
+		},
+		{
+			name:    "SNMPv2c bulk walk exceeding the message size",
+			client:  gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public", MaxRepetitions: 1000},
+			bulk:    true,
+			rootOID: ".1.3.6.1.4.1.2021",
+			want:    td.Len(200),
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mib := make([]map[string]any, 0, 200)
+			for idx := 0; idx < 200; idx++ {
+				mib = append(mib, map[string]any{
+					"oid":   ".1.3.6.1.4.1.2021.100." + strconv.Itoa(idx) + ".0",
+					"value": "padding to exceed the maximum message size of the agent",
+				})
+			}
+
+			opts := map[string]any{
+				"mib": append(mib, defaultOptions["mib"].([]map[string]any)...),
+				"system": map[string]any{
+					"name": "core-switch-01",
+				},
+			}
+
+			client, _ := setupHandler(t, opts, tt.client)
+
+			walk := client.WalkAll
+			if tt.bulk {
+				walk = client.BulkWalkAll
+			}
+
+			variables, err := walk(tt.rootOID)
+			if !td.CmpNoError(t, err) {
+				return
+			}
+
+			names := make([]string, 0, len(variables))
+			for idx := range variables {
+				names = append(names, variables[idx].Name)
+			}
+
+			td.Cmp(t, names, tt.want)
+		})
+	}
+}
+
+func Test_snmpHandler_Set(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		client    gosnmp.GoSNMP
+		variables []gosnmp.SnmpPDU
+		wantError gosnmp.SNMPError
+		wantValue any
+		wantEvent any
+	}{
+		{
+			name:   "SNMPv2c write community",
+			client: gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "private"},
+			variables: []gosnmp.SnmpPDU{
+				{Name: sysName, Type: gosnmp.OctetString, Value: "pwned"},
+			},
+			wantValue: []byte("pwned"),
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{
+					"Operation": 4,
+					"Community": "private",
+					"Variables": [{"OID": ".1.3.6.1.2.1.1.5.0", "Value": "pwned"}],
+					"ErrorStatus": 0
+				}`),
+			}),
+		},
+		{
+			name:   "SNMPv2c read community",
+			client: gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"},
+			variables: []gosnmp.SnmpPDU{
+				{Name: sysName, Type: gosnmp.OctetString, Value: "pwned"},
+			},
+			wantError: gosnmp.NoAccess,
+			wantValue: []byte("core-switch-01"),
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"Community": "public", "ErrorStatus": 6}`),
+			}),
+		},
+		{
+			name:   "SNMPv1 read community",
+			client: gosnmp.GoSNMP{Version: gosnmp.Version1, Community: "public"},
+			variables: []gosnmp.SnmpPDU{
+				{Name: sysName, Type: gosnmp.OctetString, Value: "pwned"},
+			},
+			wantError: gosnmp.NoSuchName,
+			wantValue: []byte("core-switch-01"),
+			wantEvent: td.NotNil(),
+		},
+		{
+			name:   "SNMPv2c new variable",
+			client: gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "private"},
+			variables: []gosnmp.SnmpPDU{
+				{Name: ".1.3.6.1.4.1.9.2.1.55.192.0.2.10", Type: gosnmp.OctetString, Value: "running-config"},
+				{Name: sysName, Type: gosnmp.Gauge32, Value: uint32(42)},
+			},
+			wantValue: uint(42),
+			wantEvent: td.NotNil(),
+		},
+		{
+			name: "SNMPv3 user",
+			client: gosnmp.GoSNMP{
+				Version:       gosnmp.Version3,
+				SecurityModel: gosnmp.UserSecurityModel,
+				MsgFlags:      gosnmp.AuthPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{
+					UserName:                 "admin",
+					AuthenticationProtocol:   gosnmp.SHA,
+					AuthenticationPassphrase: "inetmock-auth",
+					PrivacyProtocol:          gosnmp.AES,
+					PrivacyPassphrase:        "inetmock-priv",
+				},
+			},
+			variables: []gosnmp.SnmpPDU{
+				{Name: sysName, Type: gosnmp.OctetString, Value: "pwned"},
+			},
+			wantValue: []byte("pwned"),
+			wantEvent: td.NotNil(),
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, emitterMock := setupHandler(t, defaultOptions, tt.client)
+
+			got, err := client.Set(tt.variables)
+			if !td.CmpNoError(t, err) {
+				return
+			}
+			td.Cmp(t, got.Error, tt.wantError)
+
+			emitterMock.WithCalls(func(calls *audit_mock.EmitterMockCalls) {
+				if td.Cmp(t, calls.Emit(), td.NotEmpty()) {
+					td.Cmp(t, calls.Emit()[len(calls.Emit())-1].Params.Ev, tt.wantEvent)
+				}
+			})
+
+			got, err = client.Get([]string{sysName})
+			if td.CmpNoError(t, err) && td.Cmp(t, got.Variables, td.Len(1)) {
+				td.Cmp(t, got.Variables[0].Value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func Test_snmpHandler_Start(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		opts map[string]any
+	}{
+		{
+			name: "Error because of unknown access",
+			opts: map[string]any{
+				"communities": map[string]any{"public": "rw"},
+			},
+		},
+		{
+			name: "Error because of invalid OID",
+			opts: map[string]any{
+				"mib": []map[string]any{{"oid": "iso.org.dod", "value": "1"}},
+			},
+		},
+		{
+			name: "Error because of unknown type",
+			opts: map[string]any{
+				"mib": []map[string]any{{"oid": ".1.3.6.1.4.1.1.0", "type": "float", "value": "1.0"}},
+			},
+		},
+		{
+			name: "Error because of invalid value",
+			opts: map[string]any{
+				"mib": []map[string]any{{"oid": ".1.3.6.1.4.1.1.0", "type": "ipAddress", "value": "::1"}},
+			},
+		},
+		{
+			name: "Error because of invalid engine ID",
+			opts: map[string]any{
+				"engineID": "8000",
+			},
+		},
+		{
+			name: "Error because of privacy without authentication",
+			opts: map[string]any{
+				"users": []map[string]any{{"name": "admin", "privProtocol": "AES", "privPassphrase": "inetmock-priv"}},
+			},
+		},
+		{
+			name: "Error because of short passphrase",
+			opts: map[string]any{
+				"users": []map[string]any{{"name": "admin", "authProtocol": "MD5", "authPassphrase": "admin"}},
+			},
+		},
+		{
+			name: "Error because of too small maxMessageSize",
+			opts: map[string]any{
+				"maxMessageSize": 256,
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("net.ListenPacket() error = %v", err)
+			}
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+
+			handler := snmp.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock))
+			td.CmpError(t, handler.Start(test.Context(t), endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(conn), tt.opts)))
+		})
+	}
+}
+
+func setupHandler(tb *testing.T, opts map[string]any, client gosnmp.GoSNMP) (*gosnmp.GoSNMP, *audit_mock.EmitterMock) {
+	tb.Helper()
+	ctx, cancel := context.WithCancel(test.Context(tb))
+	tb.Cleanup(cancel)
+
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("net.ListenPacket() error = %v", err)
+	}
+	tb.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := snmp.New(logging.CreateTestLogger(tb), emitterMock)
+	if err = handler.Start(ctx, endpoint.NewStartupSpec(tb.Name(), endpoint.NewUplink(conn), opts)); err != nil {
+		tb.Fatalf("Start() error = %v", err)
+	}
+
+	client.Target = "127.0.0.1"
+	client.Port = uint16(conn.LocalAddr().(*net.UDPAddr).Port)
+	if client.Timeout == 0 {
+		client.Timeout = 2 * time.Second
+	}
+
+	if err = client.Connect(); err != nil {
+		tb.Fatalf("Connect() error = %v", err)
+	}
+	tb.Cleanup(func() {
+		_ = client.Conn.Close()
+	})
+
+	return &client, emitterMock
+}
@@ -0,0 +1,253 @@
+package snmp
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+const (
+	sysUpTime = ".1.3.6.1.2.1.1.3.0"
+	minOIDLen = 2
+)
+
+var (
+	ErrInvalidOID      = errors.New("invalid OID")
+	ErrInvalidVariable = errors.New("invalid variable")
+
+	variableTypes = map[string]gosnmp.Asn1BER{
+		"":          gosnmp.OctetString,
+		"string":    gosnmp.OctetString,
+		"hex":       gosnmp.OctetString,
+		"integer":   gosnmp.Integer,
+		"oid":       gosnmp.ObjectIdentifier,
+		"ipaddress": gosnmp.IPAddress,
+		"counter32": gosnmp.Counter32,
+		"gauge32":   gosnmp.Gauge32,
+		"timeticks": gosnmp.TimeTicks,
+		"counter64": gosnmp.Counter64,
+	}
+)
+
+type oid []uint32
+
+// parseOID parses an OID in dotted notation, the leading dot is optional
+func parseOID(raw string) (oid, error) {
+	arcs := strings.Split(strings.TrimPrefix(raw, "."), ".")
+	if len(arcs) < minOIDLen {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidOID, raw)
+	}
+
+	parsed := make(oid, 0, len(arcs))
+	for _, arc := range arcs {
+		value, err := strconv.ParseUint(arc, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidOID, raw)
+		}
+		parsed = append(parsed, uint32(value))
+	}
+
+	return parsed, nil
+}
+
+// compare orders OIDs lexicographically by their arcs like the MIB tree is walked
+func (o oid) compare(other oid) int {
+	for idx := 0; idx < len(o) && idx < len(other); idx++ {
+		switch {
+		case o[idx] < other[idx]:
+			return -1
+		case o[idx] > other[idx]:
+			return 1
+		}
+	}
+	return len(o) - len(other)
+}
+
+func (o oid) hasPrefix(prefix oid) bool {
+	return len(o) >= len(prefix) && o[:len(prefix)].compare(prefix) == 0
+}
+
+// String returns the dotted notation with leading dot like gosnmp does
+func (o oid) String() string {
+	var builder strings.Builder
+	for _, arc := range o {
+		builder.WriteByte('.')
+		builder.WriteString(strconv.FormatUint(uint64(arc), 10))
+	}
+	return builder.String()
+}
+
+type variable struct {
+	oid   oid
+	typ   gosnmp.Asn1BER
+	value any
+	// uptime marks sysUpTime whose value is calculated when it is read
+	uptime bool
+}
+
+func (v variable) pdu(started time.Time) gosnmp.SnmpPDU {
+	pdu := gosnmp.SnmpPDU{
+		Name:  v.oid.String(),
+		Type:  v.typ,
+		Value: v.value,
+	}
+
+	if v.uptime {
+		// sysUpTime is measured in hundredths of a second
+		pdu.Value = uint32(time.Since(started) / (10 * time.Millisecond))
+	}
+
+	return pdu
+}
+
+func parseVariable(raw Variable) (v variable, err error) {
+	if v.oid, err = parseOID(raw.OID); err != nil {
+		return v, err
+	}
+
+	var known bool
+	if v.typ, known = variableTypes[strings.ToLower(raw.Type)]; !known {
+		return v, fmt.Errorf("%w: unknown type %q of %s", ErrInvalidVariable, raw.Type, raw.OID)
+	}
+
+	switch v.typ {
+	case gosnmp.OctetString:
+		v.value = []byte(raw.Value)
+		if strings.EqualFold(raw.Type, "hex") {
+			v.value, err = hex.DecodeString(strings.ReplaceAll(raw.Value, ":", ""))
+		}
+	case gosnmp.Integer:
+		var value int64
+		value, err = strconv.ParseInt(raw.Value, 10, 32)
+		v.value = int(value)
+	case gosnmp.ObjectIdentifier:
+		var value oid
+		value, err = parseOID(raw.Value)
+		v.value = value.String()
+	case gosnmp.IPAddress:
+		if ip := net.ParseIP(raw.Value).To4(); ip == nil {
+			err = fmt.Errorf("%q is not an IPv4 address", raw.Value)
+		} else {
+			v.value = ip.String()
+		}
+	case gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks:
+		var value uint64
+		value, err = strconv.ParseUint(raw.Value, 10, 32)
+		v.value = uint32(value)
+	case gosnmp.Counter64:
+		v.value, err = strconv.ParseUint(raw.Value, 10, 64)
+	}
+
+	if err != nil {
+		return v, fmt.Errorf("%w: value of %s: %v", ErrInvalidVariable, raw.OID, err)
+	}
+
+	return v, nil
+}
+
+func systemVariables(system System) ([]variable, error) {
+	raw := []Variable{
+		{OID: ".1.3.6.1.2.1.1.1.0", Value: system.Descr},
+		{OID: ".1.3.6.1.2.1.1.2.0", Type: "oid", Value: system.ObjectID},
+		{OID: ".1.3.6.1.2.1.1.4.0", Value: system.Contact},
+		{OID: ".1.3.6.1.2.1.1.5.0", Value: system.Name},
+		{OID: ".1.3.6.1.2.1.1.6.0", Value: system.Location},
+		{OID: ".1.3.6.1.2.1.1.7.0", Type: "integer", Value: strconv.Itoa(system.Services)},
+	}
+
+	variables := make([]variable, 0, len(raw)+1)
+	for idx := range raw {
+		v, err := parseVariable(raw[idx])
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, v)
+	}
+
+	uptime, _ := parseOID(sysUpTime)
+	return append(variables, variable{oid: uptime, typ: gosnmp.TimeTicks, uptime: true}), nil
+}
+
+// formatValue returns the string representation of a value for audit events, octet strings which aren't valid
+// UTF-8 are hex encoded
+func formatValue(pdu gosnmp.SnmpPDU) string {
+	switch value := pdu.Value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		if utf8.Valid(value) {
+			return string(value)
+		}
+		return hex.EncodeToString(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// mib is the MIB tree of an endpoint, variables are sorted by their OID.
+// It is not safe for concurrent use, requests are processed sequentially.
+type mib struct {
+	variables []variable
+}
+
+func newMIB(variables []variable) *mib {
+	m := new(mib)
+	m.set(variables)
+	return m
+}
+
+func (m *mib) get(o oid) (variable, bool) {
+	idx := m.search(o)
+	if idx < len(m.variables) && m.variables[idx].oid.compare(o) == 0 {
+		return m.variables[idx], true
+	}
+
+	return variable{}, false
+}
+
+// next returns the first variable following the given OID in lexicographic order which is accepted by the filter
+func (m *mib) next(o oid, accept func(v variable) bool) (variable, bool) {
+	for idx := m.search(o); idx < len(m.variables); idx++ {
+		if m.variables[idx].oid.compare(o) > 0 && accept(m.variables[idx]) {
+			return m.variables[idx], true
+		}
+	}
+
+	return variable{}, false
+}
+
+// containsObject checks whether any instance of the object i.e. the OID without its last arc exists
+func (m *mib) containsObject(o oid) bool {
+	object := o[:len(o)-1]
+	v, found := m.next(object, func(variable) bool { return true })
+	return found && v.oid.hasPrefix(object)
+}
+
+// set adds or replaces the given variables
+func (m *mib) set(variables []variable) {
+	for _, v := range variables {
+		idx := m.search(v.oid)
+		if idx < len(m.variables) && m.variables[idx].oid.compare(v.oid) == 0 {
+			m.variables[idx] = v
+			continue
+		}
+
+		m.variables = append(m.variables, variable{})
+		copy(m.variables[idx+1:], m.variables[idx:])
+		m.variables[idx] = v
+	}
+}
+
+func (m *mib) search(o oid) int {
+	return sort.Search(len(m.variables), func(i int) bool {
+		return m.variables[i].oid.compare(o) >= 0
+	})
+}
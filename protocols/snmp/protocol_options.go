@@ -0,0 +1,211 @@
+package snmp
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gosnmp/gosnmp"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+const (
+	// defaultMaxMessageSize is the maximum message size of net-snmp agents
+	defaultMaxMessageSize = 1472
+	// minMaxMessageSize is the size every SNMP engine has to accept according to RFC 3417
+	minMaxMessageSize = 484
+	minEngineIDLen    = 5
+	maxEngineIDLen    = 32
+	minPassphraseLen  = 8
+
+	accessRead  = "read"
+	accessWrite = "write"
+)
+
+var (
+	// defaultEngineID follows RFC 3411 and consists of the enterprise number of net-snmp and the text 'inetmock'
+	defaultEngineID = string([]byte{0x80, 0x00, 0x1f, 0x88, 0x04, 'i', 'n', 'e', 't', 'm', 'o', 'c', 'k'})
+
+	authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+		"":       gosnmp.NoAuth,
+		"md5":    gosnmp.MD5,
+		"sha":    gosnmp.SHA,
+		"sha224": gosnmp.SHA224,
+		"sha256": gosnmp.SHA256,
+		"sha384": gosnmp.SHA384,
+		"sha512": gosnmp.SHA512,
+	}
+	privProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+		"":        gosnmp.NoPriv,
+		"des":     gosnmp.DES,
+		"aes":     gosnmp.AES,
+		"aes192":  gosnmp.AES192,
+		"aes256":  gosnmp.AES256,
+		"aes192c": gosnmp.AES192C,
+		"aes256c": gosnmp.AES256C,
+	}
+)
+
+// System contains the scalars of the system group (1.3.6.1.2.1.1), sysUpTime is always the uptime of the endpoint
+type System struct {
+	Descr    string
+	ObjectID string
+	Contact  string
+	Name     string
+	Location string
+	Services int
+}
+
+// Variable is a single object instance of the MIB tree e.g. {OID: ".1.3.6.1.2.1.2.1.0", Type: "integer", Value: "2"}
+type Variable struct {
+	OID string
+	// Type is one of integer, string, hex, oid, ipAddress, counter32, gauge32, timeTicks or counter64, defaults to string
+	Type  string
+	Value string
+}
+
+// User is an SNMPv3 user of the user-based security model, protocols are case-insensitive
+type User struct {
+	Name string
+	// AuthProtocol is one of MD5, SHA, SHA224, SHA256, SHA384 or SHA512, users without protocol can't authenticate
+	AuthProtocol   string
+	AuthPassphrase string
+	// PrivProtocol is one of DES, AES, AES192, AES256, AES192C or AES256C and requires an AuthProtocol
+	PrivProtocol   string
+	PrivPassphrase string
+}
+
+type snmpOptions struct {
+	// Communities maps community strings to their access which is either read or write.
+	// If no community is configured every community is granted write access.
+	Communities map[string]string
+	System      System
+	// MIB contains additional variables, variables of the system group might be overridden
+	MIB []Variable
+	// EngineID of the SNMPv3 engine in hex encoding
+	EngineID string
+	// Users of the user-based security model, SNMPv3 requests of other users are answered with a report
+	Users []User
+	// MaxMessageSize limits the size of responses, GETBULK responses are truncated and
+	// other responses exceeding the limit are answered with a tooBig error
+	MaxMessageSize int
+	engineID       string
+	variables      []variable
+	users          map[string]*gosnmp.UsmSecurityParameters
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec) (opts snmpOptions, err error) {
+	opts = snmpOptions{
+		System: System{
+			Descr:    "Linux inetmock 5.15.0-91-generic #101-Ubuntu SMP Tue Nov 14 13:30:08 UTC 2023 x86_64",
+			ObjectID: ".1.3.6.1.4.1.8072.3.2.10",
+			Contact:  "Me <me@example.org>",
+			Name:     "inetmock",
+			Location: "Sitting on the Dock of the Bay",
+			Services: 72,
+		},
+		MaxMessageSize: defaultMaxMessageSize,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithWeaklyTypedInput); err != nil {
+		return opts, err
+	}
+
+	if opts.MaxMessageSize < minMaxMessageSize {
+		return opts, fmt.Errorf("maxMessageSize has to be at least %d but was %d", minMaxMessageSize, opts.MaxMessageSize)
+	}
+
+	for community, access := range opts.Communities {
+		if access != accessRead && access != accessWrite {
+			return opts, fmt.Errorf("access of community %q has to be either %s or %s but was %q", community, accessRead, accessWrite, access)
+		}
+	}
+
+	if opts.engineID, err = parseEngineID(opts.EngineID); err != nil {
+		return opts, err
+	}
+
+	if opts.variables, err = systemVariables(opts.System); err != nil {
+		return opts, err
+	}
+
+	for idx := range opts.MIB {
+		var v variable
+		if v, err = parseVariable(opts.MIB[idx]); err != nil {
+			return opts, err
+		}
+		opts.variables = append(opts.variables, v)
+	}
+
+	opts.users = make(map[string]*gosnmp.UsmSecurityParameters, len(opts.Users))
+	for idx := range opts.Users {
+		user := opts.Users[idx]
+		if _, exists := opts.users[user.Name]; exists {
+			return opts, fmt.Errorf("user %q is defined more than once", user.Name)
+		}
+
+		if opts.users[user.Name], err = securityParameters(user, opts.engineID); err != nil {
+			return opts, err
+		}
+	}
+
+	return opts, nil
+}
+
+func parseEngineID(encoded string) (string, error) {
+	if encoded == "" {
+		return defaultEngineID, nil
+	}
+
+	engineID, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+	if err != nil {
+		return "", fmt.Errorf("engineID has to be hex encoded: %w", err)
+	}
+
+	if len(engineID) < minEngineIDLen || len(engineID) > maxEngineIDLen {
+		return "", fmt.Errorf("engineID has to be between %d and %d bytes long but was %d", minEngineIDLen, maxEngineIDLen, len(engineID))
+	}
+
+	return string(engineID), nil
+}
+
+// securityParameters validates the user and localizes its keys for the engine
+func securityParameters(user User, engineID string) (*gosnmp.UsmSecurityParameters, error) {
+	if user.Name == "" {
+		return nil, fmt.Errorf("name of SNMPv3 user is required")
+	}
+
+	authProtocol, known := authProtocols[strings.ToLower(user.AuthProtocol)]
+	if !known {
+		return nil, fmt.Errorf("unknown authProtocol %q of user %q", user.AuthProtocol, user.Name)
+	}
+
+	privProtocol, known := privProtocols[strings.ToLower(user.PrivProtocol)]
+	if !known {
+		return nil, fmt.Errorf("unknown privProtocol %q of user %q", user.PrivProtocol, user.Name)
+	}
+
+	if authProtocol == gosnmp.NoAuth && privProtocol != gosnmp.NoPriv {
+		return nil, fmt.Errorf("privProtocol of user %q requires an authProtocol", user.Name)
+	}
+
+	if authProtocol != gosnmp.NoAuth && len(user.AuthPassphrase) < minPassphraseLen {
+		return nil, fmt.Errorf("authPassphrase of user %q has to be at least %d characters long", user.Name, minPassphraseLen)
+	}
+
+	if privProtocol != gosnmp.NoPriv && len(user.PrivPassphrase) < minPassphraseLen {
+		return nil, fmt.Errorf("privPassphrase of user %q has to be at least %d characters long", user.Name, minPassphraseLen)
+	}
+
+	params := &gosnmp.UsmSecurityParameters{
+		AuthoritativeEngineID:    engineID,
+		UserName:                 user.Name,
+		AuthenticationProtocol:   authProtocol,
+		AuthenticationPassphrase: user.AuthPassphrase,
+		PrivacyProtocol:          privProtocol,
+		PrivacyPassphrase:        user.PrivPassphrase,
+	}
+
+	return params, params.InitSecurityKeys()
+}
@@ -0,0 +1,130 @@
+package snmp
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/gosnmp/gosnmp"
+)
+
+const (
+	securityParameterFields = 6
+	scopedPDUFields         = 3
+)
+
+var (
+	ErrMalformedMessage = errors.New("malformed SNMP message")
+
+	reportUnsupportedSecLevels = usmReport{name: "usmStatsUnsupportedSecLevels", oid: ".1.3.6.1.6.3.15.1.1.1.0"}
+	reportUnknownUserNames     = usmReport{name: "usmStatsUnknownUserNames", oid: ".1.3.6.1.6.3.15.1.1.3.0"}
+	reportUnknownEngineIDs     = usmReport{name: "usmStatsUnknownEngineIDs", oid: ".1.3.6.1.6.3.15.1.1.4.0"}
+	reportWrongDigests         = usmReport{name: "usmStatsWrongDigests", oid: ".1.3.6.1.6.3.15.1.1.5.0"}
+	reportDecryptionErrors     = usmReport{name: "usmStatsDecryptionErrors", oid: ".1.3.6.1.6.3.15.1.1.6.0"}
+
+	// macLengths are the lengths of the truncated HMACs according to RFC 3414 and RFC 7860
+	macLengths = map[gosnmp.SnmpV3AuthProtocol]int{
+		gosnmp.MD5:    12,
+		gosnmp.SHA:    12,
+		gosnmp.SHA224: 16,
+		gosnmp.SHA256: 24,
+		gosnmp.SHA384: 32,
+		gosnmp.SHA512: 48,
+	}
+)
+
+// usmReport is a statistics counter of the user-based security model sent as report if a request is discarded
+type usmReport struct {
+	name string
+	oid  string
+}
+
+// v3Header contains the parts of an SNMPv3 message which can be read without the keys of the user.
+// requestID, contextName and pduType are only set if the scoped PDU is not encrypted.
+type v3Header struct {
+	msgID         uint32
+	flags         gosnmp.SnmpV3MsgFlags
+	securityModel int64
+	engineID      string
+	userName      string
+	authParams    []byte
+	requestID     uint32
+	contextName   string
+	pduType       gosnmp.PDUType
+}
+
+func parseV3Header(msg *ber.Packet) (hdr v3Header, err error) {
+	if len(msg.Children) < 4 || len(msg.Children[1].Children) < 4 {
+		return hdr, ErrMalformedMessage
+	}
+
+	var (
+		header    = msg.Children[1]
+		msgID, _  = header.Children[0].Value.(int64)
+		flags     = header.Children[2].Data.Bytes()
+		secParams *ber.Packet
+	)
+
+	hdr.msgID = uint32(msgID)
+	hdr.securityModel, _ = header.Children[3].Value.(int64)
+	if len(flags) != 1 {
+		return hdr, fmt.Errorf("%w: invalid msgFlags", ErrMalformedMessage)
+	}
+	hdr.flags = gosnmp.SnmpV3MsgFlags(flags[0])
+
+	if secParams, err = ber.DecodePacketErr(msg.Children[2].Data.Bytes()); err != nil {
+		return hdr, fmt.Errorf("%w: %v", ErrMalformedMessage, err)
+	} else if len(secParams.Children) < securityParameterFields {
+		return hdr, fmt.Errorf("%w: invalid security parameters", ErrMalformedMessage)
+	}
+
+	hdr.engineID = string(secParams.Children[0].Data.Bytes())
+	hdr.userName = string(secParams.Children[3].Data.Bytes())
+	hdr.authParams = secParams.Children[4].Data.Bytes()
+
+	if scoped := msg.Children[3]; scoped.Tag == ber.TagSequence && len(scoped.Children) >= scopedPDUFields {
+		pdu := scoped.Children[2]
+		hdr.contextName = string(scoped.Children[1].Data.Bytes())
+		hdr.pduType = gosnmp.PDUType(byte(pdu.ClassType) | byte(pdu.TagType) | byte(pdu.Tag))
+		if len(pdu.Children) > 0 {
+			requestID, _ := pdu.Children[0].Value.(int64)
+			hdr.requestID = uint32(requestID)
+		}
+	}
+
+	return hdr, nil
+}
+
+// authentic verifies the HMAC of an authenticated message,
+// the authentication parameters are zeroed in a copy of the message before the HMAC is calculated
+func authentic(user *gosnmp.UsmSecurityParameters, raw []byte, authParams []byte) bool {
+	macLen := macLengths[user.AuthenticationProtocol]
+	if macLen == 0 || len(authParams) != macLen {
+		return false
+	}
+
+	// the encoded parameters are searched to locate them like gosnmp does when authenticating outgoing messages
+	idx := bytes.Index(raw, append([]byte{byte(gosnmp.OctetString), byte(macLen)}, authParams...))
+	if idx < 0 {
+		return false
+	}
+
+	msg := bytes.Clone(raw)
+	copy(msg[idx+2:idx+2+macLen], make([]byte, macLen))
+
+	mac := hmac.New(user.AuthenticationProtocol.HashType().New, user.SecretKey)
+	_, _ = mac.Write(msg)
+
+	return hmac.Equal(mac.Sum(nil)[:macLen], authParams)
+}
+
+// securityLevelSupported checks whether the user supports the security level requested by the message flags
+func securityLevelSupported(user *gosnmp.UsmSecurityParameters, flags gosnmp.SnmpV3MsgFlags) bool {
+	if flags&gosnmp.AuthNoPriv != 0 && user.AuthenticationProtocol == gosnmp.NoAuth {
+		return false
+	}
+
+	return flags&gosnmp.AuthPriv != gosnmp.AuthPriv || user.PrivacyProtocol != gosnmp.NoPriv
+}
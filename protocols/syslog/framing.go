@@ -0,0 +1,94 @@
+package syslog
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	maxOctetCountDigits = 10
+	// nonTransparentTrailer contains the characters trimmed from messages terminated by a line feed
+	nonTransparentTrailer = "\r\n\x00"
+)
+
+var (
+	ErrMessageTooLarge   = errors.New("syslog message exceeds the maximum size")
+	ErrInvalidOctetCount = errors.New("invalid octet count")
+)
+
+// frameReader splits a stream into messages as defined in RFC 6587.
+// Messages starting with a digit are prefixed with their octet count, all other messages are terminated by a line feed.
+type frameReader struct {
+	reader  *bufio.Reader
+	maxSize int
+}
+
+func newFrameReader(reader io.Reader, maxSize int) *frameReader {
+	return &frameReader{
+		reader:  bufio.NewReaderSize(reader, maxSize),
+		maxSize: maxSize,
+	}
+}
+
+// next returns the next non-empty message, the returned slice is only valid until the next call
+func (f *frameReader) next() ([]byte, error) {
+	for {
+		first, err := f.reader.Peek(1)
+		if err != nil {
+			return nil, err
+		}
+
+		var msg []byte
+		if first[0] >= '1' && first[0] <= '9' {
+			msg, err = f.nextOctetCounted()
+		} else {
+			msg, err = f.nextNonTransparent()
+		}
+
+		if err != nil || len(msg) > 0 {
+			return msg, err
+		}
+	}
+}
+
+func (f *frameReader) nextOctetCounted() ([]byte, error) {
+	rawCount, err := f.reader.ReadSlice(' ')
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOctetCount, err)
+	}
+
+	if len(rawCount) > maxOctetCountDigits+1 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidOctetCount, rawCount)
+	}
+
+	count, err := strconv.Atoi(string(rawCount[:len(rawCount)-1]))
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOctetCount, err)
+	case count > f.maxSize:
+		return nil, fmt.Errorf("%w: %d bytes", ErrMessageTooLarge, count)
+	}
+
+	msg := make([]byte, count)
+	_, err = io.ReadFull(f.reader, msg)
+	return msg, err
+}
+
+func (f *frameReader) nextNonTransparent() ([]byte, error) {
+	msg, err := f.reader.ReadSlice('\n')
+	switch {
+	case errors.Is(err, bufio.ErrBufferFull):
+		return nil, fmt.Errorf("%w: more than %d bytes without line feed", ErrMessageTooLarge, f.maxSize)
+	case errors.Is(err, io.EOF) && len(msg) > 0:
+		// the last message is not necessarily terminated if the client closes the connection
+		err = nil
+	case err != nil:
+		return nil, err
+	}
+
+	return bytes.TrimRight(msg, nonTransparentTrailer), err
+}
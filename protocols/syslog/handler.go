@@ -0,0 +1,184 @@
+package syslog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/multiplexing"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+const (
+	name = "syslog_mock"
+
+	transportTCP = "tcp"
+	transportUDP = "udp"
+)
+
+var (
+	_ endpoint.MultiplexHandler = (*syslogHandler)(nil)
+	_ endpoint.StoppableHandler = (*syslogHandler)(nil)
+)
+
+type syslogHandler struct {
+	logger    logging.Logger
+	emitter   audit.Emitter
+	syslogDir string
+	options   syslogOptions
+	store     *store
+	conns     endpoint.ConnTracker
+}
+
+func (h *syslogHandler) Matchers() []cmux.Matcher {
+	return []cmux.Matcher{multiplexing.Syslog()}
+}
+
+func (h *syslogHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec, h.syslogDir); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	if startupSpec.Listener == nil && startupSpec.PacketConn == nil {
+		return fmt.Errorf("%w: %s requires a TCP or UDP listener", endpoint.ErrUnsupportedProtocol, name)
+	}
+
+	if h.store, err = openStore(h.options.File); err != nil {
+		h.logger.Error("Failed to open syslog file", zap.String("file", h.options.File), zap.Error(err))
+		return err
+	}
+
+	if startupSpec.Listener != nil {
+		h.conns.Open()
+
+		go h.serve(startupSpec.Listener)
+	} else {
+		go h.servePackets(startupSpec.PacketConn)
+	}
+
+	return nil
+}
+
+// Stop disconnects all TCP senders and closes the syslog file, messages received so far remain in the file
+func (h *syslogHandler) Stop(context.Context) error {
+	h.conns.CloseAll()
+
+	if h.store != nil {
+		return h.store.Close()
+	}
+
+	return nil
+}
+
+func (h *syslogHandler) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to accept syslog connection", zap.Error(err))
+			}
+			return
+		}
+
+		if !h.conns.Track(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		go h.handleConn(conn)
+	}
+}
+
+func (h *syslogHandler) handleConn(conn net.Conn) {
+	defer func() {
+		h.conns.Untrack(conn)
+		_ = conn.Close()
+	}()
+
+	frames := newFrameReader(conn, h.options.MaxMessageSize)
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(h.options.IdleTimeout))
+		raw, err := frames.next()
+		if err != nil {
+			switch {
+			case errors.Is(err, io.EOF), errors.Is(err, os.ErrDeadlineExceeded):
+			default:
+				if err = endpoint.IgnoreShutdownError(err); err != nil {
+					h.logger.Debug("Syslog connection terminated", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+				}
+			}
+			return
+		}
+
+		h.handleMessage(transportTCP, conn.LocalAddr(), conn.RemoteAddr(), conn, raw)
+	}
+}
+
+func (h *syslogHandler) servePackets(conn net.PacketConn) {
+	buf := make([]byte, h.options.MaxMessageSize)
+	for {
+		n, remote, err := conn.ReadFrom(buf)
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil {
+				h.logger.Error("Failed to read syslog datagram", zap.Error(err))
+			}
+			return
+		}
+
+		h.handleMessage(transportUDP, conn.LocalAddr(), remote, nil, buf[:n])
+	}
+}
+
+// handleMessage stores and emits a single message, conn is only set for TCP connections to record TLS details
+func (h *syslogHandler) handleMessage(transport string, local, remote net.Addr, conn net.Conn, raw []byte) {
+	received := time.Now().UTC()
+	msg := Parse(raw, received)
+
+	err := h.store.append(storedMessage{
+		Received:  received,
+		Transport: transport,
+		Source:    remote.String(),
+		Message:   string(raw),
+	})
+	if err != nil {
+		h.logger.Warn("Failed to store syslog message", zap.Error(err))
+	}
+
+	builder := h.emitter.Builder().
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_SYSLOG).
+		WithProtocolDetails(msg.details())
+
+	if transport == transportUDP {
+		builder = builder.WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP)
+	} else {
+		builder = builder.WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP)
+	}
+
+	if conn != nil {
+		if details, ok := audit.NewTLSDetailsFromConn(conn); ok {
+			builder = builder.WithTLSDetails(details)
+		}
+	}
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(remote)
+	builder, _ = builder.WithDestinationFromAddr(local)
+
+	builder.Emit()
+}
@@ -0,0 +1,282 @@
+package syslog_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/syslog"
+)
+
+type storedMessage struct {
+	Received  time.Time `json:"received"`
+	Transport string    `json:"transport"`
+	Source    string    `json:"source"`
+	Message   string    `json:"message"`
+}
+
+func Test_syslogHandler_TCP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		opts       map[string]any
+		send       []string
+		wantStored []string
+		wantEvents any
+	}{
+		{
+			name: "Non-transparent framing",
+			send: []string{
+				"<34>Oct 11 22:14:15 mymachine su: 'su root' failed\n",
+				"\n<165>1 2003-10-11T22:14:15.003Z host app - - - first\r\n<13>second",
+			},
+			wantStored: []string{
+				"<34>Oct 11 22:14:15 mymachine su: 'su root' failed",
+				"<165>1 2003-10-11T22:14:15.003Z host app - - - first",
+				"<13>second",
+			},
+			wantEvents: []any{
+				td.Struct(audit.Syslog{
+					Format:   auditv1.SyslogFormat_SYSLOG_FORMAT_RFC3164,
+					Facility: 4,
+					Severity: 2,
+					Hostname: "mymachine",
+					AppName:  "su",
+					Message:  "'su root' failed",
+				}, td.StructFields{"Timestamp": td.Ignore()}),
+				audit.Syslog{
+					Format:    auditv1.SyslogFormat_SYSLOG_FORMAT_RFC5424,
+					Facility:  20,
+					Severity:  5,
+					Timestamp: time.Date(2003, time.October, 11, 22, 14, 15, 3000000, time.UTC),
+					Hostname:  "host",
+					AppName:   "app",
+					Message:   "first",
+				},
+				audit.Syslog{
+					Format:   auditv1.SyslogFormat_SYSLOG_FORMAT_RFC3164,
+					Facility: 1,
+					Severity: 5,
+					Message:  "second",
+				},
+			},
+		},
+		{
+			name: "Octet counting framing",
+			send: []string{
+				"32 <0>1 - host app - - - line\nbreak",
+				"28 <0>1 - host app - - - second",
+			},
+			wantStored: []string{
+				"<0>1 - host app - - - line\nbreak",
+				"<0>1 - host app - - - second",
+			},
+			wantEvents: []any{
+				audit.Syslog{
+					Format:   auditv1.SyslogFormat_SYSLOG_FORMAT_RFC5424,
+					Hostname: "host",
+					AppName:  "app",
+					Message:  "line\nbreak",
+				},
+				audit.Syslog{
+					Format:   auditv1.SyslogFormat_SYSLOG_FORMAT_RFC5424,
+					Hostname: "host",
+					AppName:  "app",
+					Message:  "second",
+				},
+			},
+		},
+		{
+			name: "Connection closed because of too large message",
+			opts: map[string]any{
+				"maxMessageSize": 480,
+			},
+			send: []string{
+				"27 <0>1 - host app - - - first",
+				"481 <0>1 - host app - - - too large",
+			},
+			wantStored: []string{
+				"<0>1 - host app - - - first",
+			},
+			wantEvents: td.Len(1),
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			syslogDir := t.TempDir()
+			listener := test.NewInMemoryListener(t)
+			emitterMock := startHandler(t, syslogDir, endpoint.NewUplink(listener), tt.opts)
+
+			conn, err := listener.Dial("tcp", "")
+			if err != nil {
+				t.Fatalf("Dial() error = %v", err)
+			}
+
+			for _, msg := range tt.send {
+				if _, err := conn.Write([]byte(msg)); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			_ = conn.Close()
+
+			test.AwaitEvents(t, emitterMock, syslogEvents(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_TCP, tt.wantEvents))
+			td.Cmp(t, storedMessages(t, filepath.Join(syslogDir, "514_tcp_syslog.jsonl")), tt.wantStored)
+		})
+	}
+}
+
+func Test_syslogHandler_UDP(t *testing.T) {
+	t.Parallel()
+	syslogDir := t.TempDir()
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.ListenPacket() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	emitterMock := startHandler(t, syslogDir, endpoint.NewUplink(conn), map[string]any{
+		"file": "udp/messages.jsonl",
+	})
+
+	client, err := net.Dial("udp4", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("net.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err = client.Write([]byte("<86>Feb  5 17:32:18 fw01 sshd[42]: Accepted password for root\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	test.AwaitEvents(t, emitterMock, syslogEvents(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP, []any{
+		td.Struct(audit.Syslog{
+			Format:   auditv1.SyslogFormat_SYSLOG_FORMAT_RFC3164,
+			Facility: 10,
+			Severity: 6,
+			Hostname: "fw01",
+			AppName:  "sshd",
+			ProcID:   "42",
+			Message:  "Accepted password for root",
+		}, td.StructFields{"Timestamp": td.Ignore()}),
+	}))
+
+	td.Cmp(t, storedMessages(t, filepath.Join(syslogDir, "udp", "messages.jsonl")), []string{
+		"<86>Feb  5 17:32:18 fw01 sshd[42]: Accepted password for root\n",
+	})
+}
+
+func Test_syslogHandler_Start(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		opts    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "Start with defaults",
+		},
+		{
+			name: "Start with options",
+			opts: map[string]any{
+				"file":           "lab.jsonl",
+				"maxMessageSize": 8192,
+				"idleTimeout":    "1m",
+			},
+		},
+		{
+			name: "Error because of too small max message size",
+			opts: map[string]any{
+				"maxMessageSize": 100,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error because of negative idle timeout",
+			opts: map[string]any{
+				"idleTimeout": "-1s",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			handler := syslog.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock), t.TempDir())
+			spec := endpoint.NewStartupSpec("514/tcp:syslog", endpoint.NewUplink(test.NewInMemoryListener(t)), tt.opts)
+
+			err := handler.Start(test.Context(t), spec)
+			t.Cleanup(func() {
+				_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+			})
+
+			td.Cmp(t, err != nil, tt.wantErr)
+		})
+	}
+}
+
+func startHandler(t *testing.T, syslogDir string, uplink endpoint.Uplink, opts map[string]any) *audit_mock.EmitterMock {
+	t.Helper()
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := syslog.New(logging.CreateTestLogger(t), emitterMock, syslogDir)
+
+	if err := handler.Start(test.Context(t), endpoint.NewStartupSpec("514/tcp:syslog", uplink, opts)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+	})
+
+	return emitterMock
+}
+
+// syslogEvents compares the protocol details of the events after checking all of them were received via the given transport
+func syslogEvents(transport auditv1.TransportProtocol, wantDetails any) td.TestDeep {
+	return td.All(
+		td.ArrayEach(td.All(
+			td.Smuggle("Application", auditv1.AppProtocol_APP_PROTOCOL_SYSLOG),
+			td.Smuggle("Transport", transport),
+		)),
+		test.EventDetails(wantDetails),
+	)
+}
+
+func storedMessages(tb testing.TB, path string) (messages []string) {
+	tb.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		tb.Fatalf("os.Open() error = %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var stored storedMessage
+		if err := json.Unmarshal(scanner.Bytes(), &stored); err != nil {
+			tb.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		messages = append(messages, stored.Message)
+	}
+
+	return messages
+}
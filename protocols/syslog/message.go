@@ -0,0 +1,223 @@
+package syslog
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+)
+
+const (
+	// defaultPri is assumed for messages without valid PRI part: facility user, severity notice
+	defaultPri     = 13
+	maxPri         = 191
+	maxPriDigits   = 3
+	severities     = 8
+	rfc3164TSLen   = len(time.Stamp)
+	rfc5424Version = "1 "
+	nilValue       = "-"
+	utf8BOM        = "\xef\xbb\xbf"
+	maxTagLength   = 48
+	// futureTolerance is the time a RFC 3164 timestamp may lie in the future before it is assigned to the previous year
+	futureTolerance = 24 * time.Hour
+)
+
+// Message is a parsed syslog message.
+// Parsing never fails, parts of a message not following RFC 3164 or RFC 5424 are kept as content.
+type Message struct {
+	Format         auditv1.SyslogFormat
+	Facility       uint32
+	Severity       uint32
+	Timestamp      time.Time
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData string
+	Content        string
+}
+
+func (m Message) details() audit.Syslog {
+	return audit.Syslog{
+		Format:         m.Format,
+		Facility:       m.Facility,
+		Severity:       m.Severity,
+		Timestamp:      m.Timestamp,
+		Hostname:       m.Hostname,
+		AppName:        m.AppName,
+		ProcID:         m.ProcID,
+		MsgID:          m.MsgID,
+		StructuredData: m.StructuredData,
+		Message:        m.Content,
+	}
+}
+
+// Parse parses a single message in either RFC 5424 or RFC 3164 format,
+// received is used to complete RFC 3164 timestamps which don't contain a year
+func Parse(raw []byte, received time.Time) (msg Message) {
+	data := strings.TrimRight(string(raw), "\r\n\x00")
+
+	pri, rest, ok := parsePri(data)
+	if !ok {
+		pri, rest = defaultPri, data
+	}
+
+	msg.Facility = uint32(pri / severities)
+	msg.Severity = uint32(pri % severities)
+
+	if ok && strings.HasPrefix(rest, rfc5424Version) {
+		parseRFC5424(&msg, rest[len(rfc5424Version):])
+	} else {
+		parseRFC3164(&msg, rest, received)
+	}
+
+	return msg
+}
+
+func parsePri(data string) (pri int, rest string, ok bool) {
+	if !strings.HasPrefix(data, "<") {
+		return 0, data, false
+	}
+
+	end := strings.IndexByte(data, '>')
+	if end < 2 || end > maxPriDigits+1 {
+		return 0, data, false
+	}
+
+	if pri, err := strconv.Atoi(data[1:end]); err != nil || pri < 0 || pri > maxPri {
+		return 0, data, false
+	} else {
+		return pri, data[end+1:], true
+	}
+}
+
+// parseRFC5424 parses TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]
+func parseRFC5424(msg *Message, rest string) {
+	const headerFields = 5
+	msg.Format = auditv1.SyslogFormat_SYSLOG_FORMAT_RFC5424
+
+	fields := strings.SplitN(rest, " ", headerFields+1)
+	if len(fields) <= headerFields {
+		msg.Content = rest
+		return
+	}
+
+	if ts, err := time.Parse(time.RFC3339Nano, fields[0]); err == nil {
+		msg.Timestamp = ts
+	}
+
+	msg.Hostname = nilToEmpty(fields[1])
+	msg.AppName = nilToEmpty(fields[2])
+	msg.ProcID = nilToEmpty(fields[3])
+	msg.MsgID = nilToEmpty(fields[4])
+
+	sd, content := splitStructuredData(fields[5])
+	msg.StructuredData = nilToEmpty(sd)
+	msg.Content = strings.TrimPrefix(strings.TrimPrefix(content, " "), utf8BOM)
+}
+
+// splitStructuredData splits the structured data elements e.g. [exampleSDID@32473 iut="3"] from the message,
+// closing brackets and quotes within quoted values are escaped with a backslash
+func splitStructuredData(data string) (sd, content string) {
+	if !strings.HasPrefix(data, "[") {
+		sd, content, _ = strings.Cut(data, " ")
+		return sd, content
+	}
+
+	var inElement, quoted, escaped bool
+	for idx := 0; idx < len(data); idx++ {
+		switch c := data[idx]; {
+		case escaped:
+			escaped = false
+		case c == '\\' && quoted:
+			escaped = true
+		case c == '"' && inElement:
+			quoted = !quoted
+		case c == '[' && !inElement:
+			inElement = true
+		case c == ']' && inElement && !quoted:
+			inElement = false
+		case !inElement:
+			return data[:idx], data[idx:]
+		}
+	}
+
+	return data, ""
+}
+
+// parseRFC3164 parses TIMESTAMP HOSTNAME TAG[PID]: CONTENT,
+// like syslog daemons it also accepts RFC 3339 timestamps
+func parseRFC3164(msg *Message, rest string, received time.Time) {
+	msg.Format = auditv1.SyslogFormat_SYSLOG_FORMAT_RFC3164
+	msg.Content = rest
+
+	var ok bool
+	if msg.Timestamp, rest, ok = parseRFC3164Timestamp(rest, received); !ok {
+		return
+	}
+
+	hostname, tagged, found := strings.Cut(rest, " ")
+	if !found || hostname == "" {
+		msg.Timestamp = time.Time{}
+		return
+	}
+
+	msg.Hostname = hostname
+	msg.AppName, msg.ProcID, msg.Content = splitTag(tagged)
+}
+
+func parseRFC3164Timestamp(data string, received time.Time) (ts time.Time, rest string, ok bool) {
+	if len(data) > rfc3164TSLen && data[rfc3164TSLen] == ' ' {
+		if parsed, err := time.Parse(time.Stamp, data[:rfc3164TSLen]); err == nil {
+			ts = rfc3164Date(received.Year(), parsed, received.Location())
+			if ts.Sub(received) > futureTolerance {
+				ts = rfc3164Date(received.Year()-1, parsed, received.Location())
+			}
+			return ts, data[rfc3164TSLen+1:], true
+		}
+	}
+
+	if rawTS, rest, found := strings.Cut(data, " "); found {
+		if ts, err := time.Parse(time.RFC3339Nano, rawTS); err == nil {
+			return ts, rest, true
+		}
+	}
+
+	return time.Time{}, data, false
+}
+
+func rfc3164Date(year int, parsed time.Time, loc *time.Location) time.Time {
+	return time.Date(year, parsed.Month(), parsed.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, loc)
+}
+
+// splitTag splits a tag e.g. sshd[4711]: from the content, the content is kept as it is if it doesn't start with a tag
+func splitTag(data string) (tag, pid, content string) {
+	end := strings.IndexAny(data, ":[ ")
+	if end <= 0 || end > maxTagLength {
+		return "", "", data
+	}
+
+	tag, rest := data[:end], data[end:]
+	if strings.HasPrefix(rest, "[") {
+		closing := strings.IndexByte(rest, ']')
+		if closing < 0 {
+			return "", "", data
+		}
+		pid, rest = rest[1:closing], rest[closing+1:]
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		return "", "", data
+	}
+
+	return tag, pid, strings.TrimPrefix(rest[1:], " ")
+}
+
+func nilToEmpty(value string) string {
+	if value == nilValue {
+		return ""
+	}
+	return value
+}
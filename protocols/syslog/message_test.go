@@ -0,0 +1,164 @@
+package syslog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/protocols/syslog"
+)
+
+const (
+	rfc3164 = auditv1.SyslogFormat_SYSLOG_FORMAT_RFC3164
+	rfc5424 = auditv1.SyslogFormat_SYSLOG_FORMAT_RFC5424
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	received := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		raw  string
+		want syslog.Message
+	}{
+		{
+			name: "RFC 3164 message with tag and PID",
+			raw:  "<34>Oct 11 22:14:15 mymachine su[4711]: 'su root' failed for lonvick on /dev/pts/8\n",
+			want: syslog.Message{
+				Format:    rfc3164,
+				Facility:  4,
+				Severity:  2,
+				Timestamp: time.Date(2022, time.October, 11, 22, 14, 15, 0, time.UTC),
+				Hostname:  "mymachine",
+				AppName:   "su",
+				ProcID:    "4711",
+				Content:   "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			name: "RFC 3164 message with space padded day of the current year",
+			raw:  "<13>Feb  5 17:32:18 10.0.0.99 myapp: started",
+			want: syslog.Message{
+				Format:    rfc3164,
+				Facility:  1,
+				Severity:  5,
+				Timestamp: time.Date(2023, time.February, 5, 17, 32, 18, 0, time.UTC),
+				Hostname:  "10.0.0.99",
+				AppName:   "myapp",
+				Content:   "started",
+			},
+		},
+		{
+			name: "RFC 3164 message with RFC 3339 timestamp",
+			raw:  "<86>2023-02-28T10:00:00+01:00 fw01 sshd[42]: Accepted password for root",
+			want: syslog.Message{
+				Format:    rfc3164,
+				Facility:  10,
+				Severity:  6,
+				Timestamp: time.Date(2023, time.February, 28, 10, 0, 0, 0, time.FixedZone("", 3600)),
+				Hostname:  "fw01",
+				AppName:   "sshd",
+				ProcID:    "42",
+				Content:   "Accepted password for root",
+			},
+		},
+		{
+			name: "RFC 3164 message without tag",
+			raw:  "<13>Feb  5 17:32:18 router link down on eth0",
+			want: syslog.Message{
+				Format:    rfc3164,
+				Facility:  1,
+				Severity:  5,
+				Timestamp: time.Date(2023, time.February, 5, 17, 32, 18, 0, time.UTC),
+				Hostname:  "router",
+				Content:   "link down on eth0",
+			},
+		},
+		{
+			name: "Message without timestamp",
+			raw:  "<14>link down",
+			want: syslog.Message{
+				Format:   rfc3164,
+				Facility: 1,
+				Severity: 6,
+				Content:  "link down",
+			},
+		},
+		{
+			name: "Message without PRI",
+			raw:  "hello world",
+			want: syslog.Message{
+				Format:   rfc3164,
+				Facility: 1,
+				Severity: 5,
+				Content:  "hello world",
+			},
+		},
+		{
+			name: "Message with invalid PRI",
+			raw:  "<200>hello world",
+			want: syslog.Message{
+				Format:   rfc3164,
+				Facility: 1,
+				Severity: 5,
+				Content:  "<200>hello world",
+			},
+		},
+		{
+			name: "RFC 5424 message without structured data",
+			raw:  "<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - \xef\xbb\xbf'su root' failed",
+			want: syslog.Message{
+				Format:    rfc5424,
+				Facility:  4,
+				Severity:  2,
+				Timestamp: time.Date(2003, time.October, 11, 22, 14, 15, 3000000, time.UTC),
+				Hostname:  "mymachine.example.com",
+				AppName:   "su",
+				MsgID:     "ID47",
+				Content:   "'su root' failed",
+			},
+		},
+		{
+			name: "RFC 5424 message with structured data",
+			raw: `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 ` +
+				`[exampleSDID@32473 iut="3" eventSource="App\"lication\]"][examplePriority@32473 class="high"] An application event`,
+			want: syslog.Message{
+				Format:         rfc5424,
+				Facility:       20,
+				Severity:       5,
+				Timestamp:      time.Date(2003, time.October, 11, 22, 14, 15, 3000000, time.UTC),
+				Hostname:       "mymachine.example.com",
+				AppName:        "evntslog",
+				ProcID:         "1234",
+				MsgID:          "ID47",
+				StructuredData: `[exampleSDID@32473 iut="3" eventSource="App\"lication\]"][examplePriority@32473 class="high"]`,
+				Content:        "An application event",
+			},
+		},
+		{
+			name: "RFC 5424 message with nil values and without message",
+			raw:  "<0>1 - - - - - [origin ip=\"192.0.2.1\"]",
+			want: syslog.Message{
+				Format:         rfc5424,
+				StructuredData: `[origin ip="192.0.2.1"]`,
+			},
+		},
+		{
+			name: "Truncated RFC 5424 message",
+			raw:  "<0>1 - host app",
+			want: syslog.Message{
+				Format:  rfc5424,
+				Content: "- host app",
+			},
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			td.Cmp(t, syslog.Parse([]byte(tt.raw), received), tt.want)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package syslog
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+)
+
+const (
+	defaultMaxMessageSize = 64 * 1024
+	minMaxMessageSize     = 480
+	defaultIdleTimeout    = 5 * time.Minute
+	storeFileExtension    = ".jsonl"
+)
+
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+type syslogOptions struct {
+	// File the received messages are appended to, relative paths are resolved within the syslog data directory.
+	// Defaults to a file named after the endpoint e.g. 514_udp_syslog.jsonl
+	File string
+	// MaxMessageSize limits the size of a single message, TCP connections sending larger messages are closed and
+	// larger UDP datagrams are truncated
+	MaxMessageSize int
+	// IdleTimeout closes TCP connections not sending any message within the given time
+	IdleTimeout time.Duration
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec, syslogDir string) (opts syslogOptions, err error) {
+	opts = syslogOptions{
+		MaxMessageSize: defaultMaxMessageSize,
+		IdleTimeout:    defaultIdleTimeout,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc())); err != nil {
+		return opts, err
+	}
+
+	// RFC 5424 requires receivers to accept at least 480 octets
+	if opts.MaxMessageSize < minMaxMessageSize {
+		return opts, fmt.Errorf("maxMessageSize has to be at least %d but was %d", minMaxMessageSize, opts.MaxMessageSize)
+	}
+
+	if opts.IdleTimeout <= 0 {
+		return opts, fmt.Errorf("idleTimeout has to be positive but was %s", opts.IdleTimeout)
+	}
+
+	switch {
+	case opts.File == "":
+		opts.File = filepath.Join(syslogDir, unsafeFileNameChars.ReplaceAllString(startupSpec.Name, "_")+storeFileExtension)
+	case !filepath.IsAbs(opts.File):
+		opts.File = filepath.Join(syslogDir, opts.File)
+	}
+
+	return opts, nil
+}
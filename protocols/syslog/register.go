@@ -0,0 +1,21 @@
+package syslog
+
+import (
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+func New(logger logging.Logger, emitter audit.Emitter, syslogDir string) endpoint.ProtocolHandler {
+	return &syslogHandler{
+		logger:    logger,
+		emitter:   emitter,
+		syslogDir: syslogDir,
+	}
+}
+
+func AddSyslogMock(registry endpoint.HandlerRegistry, logger logging.Logger, emitter audit.Emitter, syslogDir string) {
+	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
+		return New(logger, emitter, syslogDir)
+	})
+}
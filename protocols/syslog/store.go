@@ -0,0 +1,59 @@
+package syslog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	storeDirPerm  = 0o750
+	storeFilePerm = 0o640
+)
+
+type storedMessage struct {
+	Received  time.Time `json:"received"`
+	Transport string    `json:"transport"`
+	Source    string    `json:"source"`
+	Message   string    `json:"message"`
+}
+
+// store appends the raw messages as JSON lines to a file because messages with octet counting framing might contain
+// line feeds
+type store struct {
+	lock    sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func openStore(path string) (*store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), storeDirPerm); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, storeFilePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &store{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (s *store) append(msg storedMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.encoder.Encode(msg)
+}
+
+func (s *store) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.file.Close()
+}
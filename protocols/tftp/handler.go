@@ -0,0 +1,209 @@
+package tftp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"net"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/quarantine"
+)
+
+const (
+	name = "tftp_mock"
+	// incomingBacklog is the number of packets buffered per transfer, more packets are dropped like on a busy socket
+	incomingBacklog = 8
+)
+
+// tftpHandler serves all transfers from the socket the request was received on instead of choosing a new TID per transfer.
+// Replies originating from the well-known port keep working with clients behind NAT or if the traffic was redirected to InetMock.
+type tftpHandler struct {
+	logger        logging.Logger
+	emitter       audit.Emitter
+	fakeFileFS    fs.FS
+	quarantineDir string
+	options       tftpOptions
+	quarantine    *quarantine.Store
+	ruleHandler   *RuleHandler
+	conn          net.PacketConn
+	cancel        context.CancelFunc
+	lock          sync.Mutex
+	transfers     map[string]*transfer
+}
+
+func (h *tftpHandler) Start(_ context.Context, startupSpec *endpoint.StartupSpec) (err error) {
+	if h.options, err = loadFromConfig(startupSpec, h.quarantineDir); err != nil {
+		return err
+	}
+
+	h.logger = h.logger.With(
+		zap.String("protocol_handler", name),
+		zap.String("handler_name", startupSpec.Name),
+		zap.String("address", startupSpec.Addr.String()),
+	)
+
+	if startupSpec.PacketConn == nil {
+		return fmt.Errorf("%w: %s requires a UDP listener", endpoint.ErrUnsupportedProtocol, name)
+	}
+
+	if h.quarantine, err = quarantine.New(h.options.QuarantineDir, h.options.MaxUploadBytes); err != nil {
+		h.logger.Error("Failed to setup quarantine directory", zap.String("quarantine_dir", h.options.QuarantineDir), zap.Error(err))
+		return err
+	}
+
+	ruleHandler := &RuleHandler{
+		HandlerName: startupSpec.Name,
+	}
+
+	for idx := range h.options.Rules {
+		rule := h.options.Rules[idx]
+		if err = ruleHandler.RegisterRule(rule); err != nil {
+			h.logger.Error("failed to setup rule", zap.String("raw_rule", rule), zap.Error(err))
+			return err
+		}
+	}
+	h.ruleHandler = ruleHandler
+
+	// transfers must not be bound to the startup context
+	var ctx context.Context
+	ctx, h.cancel = context.WithCancel(context.Background())
+	h.conn = startupSpec.PacketConn
+	h.transfers = make(map[string]*transfer)
+
+	go h.serve(ctx)
+	return nil
+}
+
+// Stop aborts all running transfers, the socket itself is closed by the endpoint
+func (h *tftpHandler) Stop(context.Context) error {
+	h.cancel()
+	return nil
+}
+
+func (h *tftpHandler) RuleManager() rules.Manager {
+	if h.ruleHandler == nil {
+		return nil
+	}
+	return h.ruleHandler.RuleManager()
+}
+
+func (h *tftpHandler) serve(ctx context.Context) {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, remote, err := h.conn.ReadFrom(buf)
+		if err != nil {
+			if err = endpoint.IgnoreShutdownError(err); err != nil && ctx.Err() == nil {
+				h.logger.Error("Failed to read TFTP packet", zap.Error(err))
+			}
+			h.cancel()
+			return
+		}
+
+		packet := make([]byte, n)
+		copy(packet, buf[:n])
+		h.dispatch(ctx, remote, packet)
+	}
+}
+
+// dispatch starts a new transfer for every read or write request and passes all other packets to the transfer of the client
+func (h *tftpHandler) dispatch(ctx context.Context, remote net.Addr, packet []byte) {
+	op, _, _ := parseHeader(packet)
+	switch op {
+	case opRRQ, opWRQ:
+		h.startTransfer(ctx, remote, packet)
+	default:
+		h.lock.Lock()
+		t, ok := h.transfers[remote.String()]
+		h.lock.Unlock()
+
+		switch {
+		case ok:
+			t.deliver(packet)
+		case op != opError:
+			h.send(remote, encodeError(errCodeUnknownTID, "Unknown transfer ID"))
+		}
+	}
+}
+
+func (h *tftpHandler) startTransfer(ctx context.Context, remote net.Addr, packet []byte) {
+	req, err := parseRequest(packet)
+	if err != nil {
+		h.logger.Debug("Received malformed request", zap.String("remote", remote.String()), zap.Error(err))
+		h.send(remote, encodeError(errCodeIllegalOperation, "Malformed request"))
+		return
+	}
+
+	key := remote.String()
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	running, replaces := h.transfers[key]
+	// clients retransmit their request if the first reply got lost, the running transfer retransmits on its own
+	if replaces && bytes.Equal(running.raw, packet) {
+		return
+	}
+
+	if !replaces && h.exceedsTransferLimits(remote) {
+		h.logger.Debug("Rejected request because of too many transfers", zap.String("remote", key))
+		h.send(remote, encodeError(errCodeNotDefined, "Too many transfers"))
+		return
+	}
+
+	if replaces {
+		running.cancel()
+	}
+
+	t := newTransfer(ctx, h, remote, req, packet)
+	h.transfers[key] = t
+
+	go func() {
+		t.run()
+		h.finishTransfer(key, t)
+	}()
+}
+
+// exceedsTransferLimits reports whether another transfer for the given client would exceed the configured limits,
+// this prevents clients from exhausting the handler with requests they never acknowledge.
+// The caller has to hold the lock.
+func (h *tftpHandler) exceedsTransferLimits(remote net.Addr) bool {
+	if len(h.transfers) >= h.options.MaxTransfers {
+		return true
+	}
+
+	var (
+		client    = clientIP(remote)
+		perClient int
+	)
+
+	for _, t := range h.transfers {
+		if t.client() == client {
+			perClient++
+		}
+	}
+
+	return perClient >= h.options.MaxTransfersPerClient
+}
+
+func (h *tftpHandler) finishTransfer(key string, t *transfer) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.transfers[key] == t {
+		delete(h.transfers, key)
+	}
+}
+
+func (h *tftpHandler) send(remote net.Addr, packet []byte) {
+	if _, err := h.conn.WriteTo(packet, remote); err != nil {
+		h.logger.Debug("Failed to send TFTP packet", zap.String("remote", remote.String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,577 @@
+package tftp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	audit_mock "inetmock.icb4dc0.de/inetmock/internal/mock/audit"
+	"inetmock.icb4dc0.de/inetmock/internal/test"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+	"inetmock.icb4dc0.de/inetmock/protocols/tftp"
+)
+
+const (
+	opRRQ   = 1
+	opWRQ   = 2
+	opData  = 3
+	opAck   = 4
+	opError = 5
+	opOAck  = 6
+
+	bootLoaderContent = "fake boot loader"
+	clientTimeout     = 5 * time.Second
+)
+
+var (
+	// larger than a single default block to test multi block transfers
+	pxeConfigContent = bytes.Repeat([]byte("DEFAULT inetmock\n"), 80)
+	fakeFiles        = fstest.MapFS{
+		"pxelinux.cfg/default": &fstest.MapFile{Data: pxeConfigContent},
+		"boot.bin":             &fstest.MapFile{Data: []byte(bootLoaderContent)},
+	}
+)
+
+type tftpError struct {
+	Code    uint16
+	Message string
+}
+
+func (e *tftpError) Error() string {
+	return fmt.Sprintf("TFTP error %d: %s", e.Code, e.Message)
+}
+
+func Test_tftpHandler_Start(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		opts          map[string]any
+		session       func(tb testing.TB, c *tftpClient)
+		wantStartErr  bool
+		wantEvent     any
+		wantFileCount int
+	}{
+		{
+			name: "Read file with default block size",
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				content, oack, err := c.read("pxelinux.cfg/default", "octet")
+				td.CmpNoError(tb, err)
+				td.CmpNil(tb, oack)
+				td.Cmp(tb, content, pxeConfigContent)
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"Application": auditv1.AppProtocol_APP_PROTOCOL_TFTP,
+				"Transport":   auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP,
+				"ProtocolDetails": audit.TFTP{
+					Operation: auditv1.TFTPOperation_TFTP_OPERATION_READ,
+					Filename:  "pxelinux.cfg/default",
+					Mode:      "octet",
+					BlockSize: 512,
+					Bytes:     int64(len(pxeConfigContent)),
+					Completed: true,
+				},
+			}),
+		},
+		{
+			name: "Read file with negotiated options",
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				content, oack, err := c.read("/pxelinux.cfg/default", "octet", "blksize", "100", "tsize", "0", "windowsize", "4")
+				td.CmpNoError(tb, err)
+				td.Cmp(tb, oack, map[string]string{
+					"blksize": "100",
+					"tsize":   strconv.Itoa(len(pxeConfigContent)),
+				})
+				td.Cmp(tb, content, pxeConfigContent)
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": audit.TFTP{
+					Operation: auditv1.TFTPOperation_TFTP_OPERATION_READ,
+					Filename:  "/pxelinux.cfg/default",
+					Mode:      "octet",
+					BlockSize: 100,
+					Bytes:     int64(len(pxeConfigContent)),
+					Completed: true,
+				},
+			}),
+		},
+		{
+			name: "Read file served by rule",
+			opts: map[string]any{
+				"rules": []string{
+					`Operation("read") -> Path("\\.(efi|0)$") => File("boot.bin")`,
+				},
+			},
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				content, _, err := c.read(`boot\x64\wdsnbp.0`, "octet")
+				td.CmpNoError(tb, err)
+				td.Cmp(tb, string(content), bootLoaderContent)
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(audit.TFTP{
+					Filename:  `boot\x64\wdsnbp.0`,
+					Bytes:     int64(len(bootLoaderContent)),
+					Completed: true,
+				}, td.StructFields{
+					"Operation": td.Ignore(),
+					"Mode":      td.Ignore(),
+					"BlockSize": td.Ignore(),
+				}),
+			}),
+		},
+		{
+			name: "Read rejected by rule",
+			opts: map[string]any{
+				"rules": []string{
+					`Path("^/boot") => Reject(2, "not for you")`,
+				},
+			},
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				_, _, err := c.read("boot.bin", "octet")
+				td.Cmp(tb, err, &tftpError{Code: 2, Message: "not for you"})
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.Struct(audit.TFTP{ErrorCode: 2}, td.StructFields{
+					"Operation": td.Ignore(),
+					"Filename":  td.Ignore(),
+					"Mode":      td.Ignore(),
+					"BlockSize": td.Ignore(),
+				}),
+			}),
+		},
+		{
+			name: "Missing file",
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				_, _, err := c.read("missing.bin", "octet")
+				td.Cmp(tb, err, td.Struct(&tftpError{Code: 1}, td.StructFields{"Message": td.NotEmpty()}))
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"ErrorCode": 1, "Completed": false}`),
+			}),
+		},
+		{
+			name: "Unsupported mode",
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				_, _, err := c.read("boot.bin", "mail")
+				td.Cmp(tb, err, td.Struct(&tftpError{Code: 4}, td.StructFields{"Message": td.NotEmpty()}))
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"ErrorCode": 4, "Mode": "mail"}`),
+			}),
+		},
+		{
+			name: "Data is retransmitted until retries are exhausted",
+			opts: map[string]any{
+				"timeout": "20ms",
+				"retries": 2,
+			},
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				c.send(request(opRRQ, "boot.bin", "octet"))
+				for i := 0; i < 3; i++ {
+					packet := c.receive()
+					td.Cmp(tb, packet[:4], []byte{0, opData, 0, 1})
+				}
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"Bytes": 0, "Completed": false}`),
+			}),
+		},
+		{
+			name: "Write is captured",
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				oack, err := c.write("firmware.bin", pxeConfigContent, "blksize", "1024", "tsize", strconv.Itoa(len(pxeConfigContent)))
+				td.CmpNoError(tb, err)
+				td.Cmp(tb, oack, map[string]string{
+					"blksize": "1024",
+					"tsize":   strconv.Itoa(len(pxeConfigContent)),
+				})
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": audit.TFTP{
+					Operation: auditv1.TFTPOperation_TFTP_OPERATION_WRITE,
+					Filename:  "firmware.bin",
+					Mode:      "octet",
+					BlockSize: 1024,
+					Bytes:     int64(len(pxeConfigContent)),
+					Completed: true,
+				},
+			}),
+			wantFileCount: 1,
+		},
+		{
+			name: "Write of an empty file is captured",
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				oack, err := c.write("empty.txt", nil)
+				td.CmpNoError(tb, err)
+				td.CmpNil(tb, oack)
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"Bytes": 0, "Completed": true}`),
+			}),
+			wantFileCount: 1,
+		},
+		{
+			name: "Write exceeding maximum size is discarded",
+			opts: map[string]any{
+				"maxUploadBytes": 600,
+			},
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				_, err := c.write("firmware.bin", pxeConfigContent)
+				td.Cmp(tb, err, td.Struct(&tftpError{Code: 3}, td.StructFields{"Message": td.NotEmpty()}))
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"ErrorCode": 3, "Completed": false}`),
+			}),
+		},
+		{
+			name: "Write with announced size exceeding maximum size is rejected",
+			opts: map[string]any{
+				"maxUploadBytes": 600,
+			},
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				_, err := c.write("firmware.bin", pxeConfigContent, "tsize", strconv.Itoa(len(pxeConfigContent)))
+				td.Cmp(tb, err, td.Struct(&tftpError{Code: 3}, td.StructFields{"Message": td.NotEmpty()}))
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"ErrorCode": 3, "Bytes": 0}`),
+			}),
+		},
+		{
+			name: "Write rejected by rule",
+			opts: map[string]any{
+				"rules": []string{
+					`Operation("write") => Reject()`,
+				},
+			},
+			session: func(tb testing.TB, c *tftpClient) {
+				tb.Helper()
+				_, err := c.write("firmware.bin", pxeConfigContent)
+				td.Cmp(tb, err, &tftpError{Code: 2, Message: "Access violation"})
+			},
+			wantEvent: td.Struct(new(audit.Event), td.StructFields{
+				"ProtocolDetails": td.SuperJSONOf(`{"ErrorCode": 2}`),
+			}),
+		},
+		{
+			name: "Error because of unknown verdict",
+			opts: map[string]any{
+				"rules": []string{
+					`=> Serve()`,
+				},
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of unknown operation",
+			opts: map[string]any{
+				"rules": []string{
+					`Operation("delete") => Reject()`,
+				},
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of invalid timeout",
+			opts: map[string]any{
+				"timeout": "-1s",
+			},
+			wantStartErr: true,
+		},
+		{
+			name: "Error because of invalid transfer limit",
+			opts: map[string]any{
+				"maxTransfersPerClient": 0,
+			},
+			wantStartErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			quarantineDir := t.TempDir()
+			conn := newUDPConn(t)
+			emitterMock := new(audit_mock.EmitterMock)
+			handler := tftp.New(logging.CreateTestLogger(t), emitterMock, fakeFiles, quarantineDir)
+			startupSpec := endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(conn), tt.opts)
+
+			if err := handler.Start(ctx, startupSpec); err != nil {
+				if !tt.wantStartErr {
+					t.Errorf("Start() error = %v", err)
+				}
+				return
+			} else if tt.wantStartErr {
+				t.Error("Start() expected error but got none")
+				return
+			}
+
+			t.Cleanup(func() {
+				_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+				_ = conn.Close()
+			})
+
+			tt.session(t, &tftpClient{tb: t, conn: newUDPConn(t), server: conn.LocalAddr()})
+
+			test.AwaitEvent(t, emitterMock, tt.wantEvent)
+
+			files, err := os.ReadDir(quarantineDir)
+			td.CmpNoError(t, err)
+			td.Cmp(t, len(files), tt.wantFileCount)
+		})
+	}
+}
+
+func Test_tftpHandler_Stop(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(test.Context(t))
+	t.Cleanup(cancel)
+
+	conn := newUDPConn(t)
+	emitterMock := new(audit_mock.EmitterMock)
+	handler := tftp.New(logging.CreateTestLogger(t), emitterMock, fakeFiles, t.TempDir())
+	if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(conn), nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	c := &tftpClient{tb: t, conn: newUDPConn(t), server: conn.LocalAddr()}
+	c.send(request(opRRQ, "pxelinux.cfg/default", "octet"))
+	c.receive()
+
+	td.CmpNoError(t, handler.(endpoint.StoppableHandler).Stop(context.Background()))
+	td.CmpNoError(t, conn.Close())
+
+	test.AwaitEvent(t, emitterMock, td.Struct(new(audit.Event), td.StructFields{
+		"ProtocolDetails": td.SuperJSONOf(`{"Bytes": 0, "Completed": false}`),
+	}))
+}
+
+func Test_tftpHandler_TransferLimits(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		opts         map[string]any
+		secondClient string
+		wantErr      bool
+	}{
+		{
+			name:         "Reject second transfer of the same client",
+			opts:         map[string]any{"maxTransfersPerClient": 1},
+			secondClient: "127.0.0.1:0",
+			wantErr:      true,
+		},
+		{
+			name:         "Accept transfer of another client",
+			opts:         map[string]any{"maxTransfersPerClient": 1},
+			secondClient: "127.0.0.2:0",
+		},
+		{
+			name:         "Reject transfer of another client if the handler is busy",
+			opts:         map[string]any{"maxTransfers": 1},
+			secondClient: "127.0.0.2:0",
+			wantErr:      true,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(test.Context(t))
+			t.Cleanup(cancel)
+
+			conn := newUDPConn(t)
+			handler := tftp.New(logging.CreateTestLogger(t), new(audit_mock.EmitterMock), fakeFiles, t.TempDir())
+			if err := handler.Start(ctx, endpoint.NewStartupSpec(t.Name(), endpoint.NewUplink(conn), tt.opts)); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			t.Cleanup(func() {
+				_ = handler.(endpoint.StoppableHandler).Stop(context.Background())
+				_ = conn.Close()
+			})
+
+			// the first transfer is never acknowledged and keeps running until it times out
+			first := &tftpClient{tb: t, conn: newUDPConn(t), server: conn.LocalAddr()}
+			first.send(request(opRRQ, "pxelinux.cfg/default", "octet"))
+			td.Cmp(t, binary.BigEndian.Uint16(first.receive()), uint16(opData))
+
+			second := &tftpClient{tb: t, conn: listenUDP(t, tt.secondClient), server: conn.LocalAddr()}
+			content, _, err := second.read("boot.bin", "octet")
+			if tt.wantErr {
+				td.Cmp(t, err, &tftpError{Code: 0, Message: "Too many transfers"})
+				return
+			}
+
+			td.CmpNoError(t, err)
+			td.Cmp(t, string(content), bootLoaderContent)
+		})
+	}
+}
+
+func newUDPConn(tb testing.TB) net.PacketConn {
+	tb.Helper()
+	return listenUDP(tb, "127.0.0.1:0")
+}
+
+func listenUDP(tb testing.TB, addr string) net.PacketConn {
+	tb.Helper()
+	conn, err := net.ListenPacket("udp4", addr)
+	if err != nil {
+		tb.Fatalf("net.ListenPacket() error = %v", err)
+	}
+	tb.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+type tftpClient struct {
+	tb     testing.TB
+	conn   net.PacketConn
+	server net.Addr
+}
+
+func (c *tftpClient) send(packet []byte) {
+	c.tb.Helper()
+	if _, err := c.conn.WriteTo(packet, c.server); err != nil {
+		c.tb.Fatalf("WriteTo() error = %v", err)
+	}
+}
+
+func (c *tftpClient) receive() []byte {
+	c.tb.Helper()
+	buf := make([]byte, 65536)
+	_ = c.conn.SetReadDeadline(time.Now().Add(clientTimeout))
+	n, _, err := c.conn.ReadFrom(buf)
+	if err != nil {
+		c.tb.Fatalf("ReadFrom() error = %v", err)
+	}
+	if n < 4 {
+		c.tb.Fatalf("received packet of %d bytes", n)
+	}
+	return buf[:n]
+}
+
+func (c *tftpClient) read(filename, mode string, options ...string) (content []byte, oack map[string]string, err error) {
+	c.tb.Helper()
+	c.send(request(opRRQ, filename, append([]string{mode}, options...)...))
+
+	blockSize := 512
+	for expected := uint16(1); ; {
+		packet := c.receive()
+		switch binary.BigEndian.Uint16(packet) {
+		case opOAck:
+			oack = parseOAck(packet)
+			if size, ok := oack["blksize"]; ok {
+				blockSize, _ = strconv.Atoi(size)
+			}
+			c.send(ack(0))
+		case opData:
+			if block := binary.BigEndian.Uint16(packet[2:]); block != expected {
+				continue
+			}
+			content = append(content, packet[4:]...)
+			c.send(ack(expected))
+			if len(packet)-4 < blockSize {
+				return content, oack, nil
+			}
+			expected++
+		case opError:
+			return content, oack, parseError(packet)
+		default:
+			c.tb.Fatalf("unexpected packet %v", packet)
+		}
+	}
+}
+
+func (c *tftpClient) write(filename string, content []byte, options ...string) (oack map[string]string, err error) {
+	c.tb.Helper()
+	c.send(request(opWRQ, filename, append([]string{"octet"}, options...)...))
+
+	blockSize := 512
+	switch reply := c.receive(); binary.BigEndian.Uint16(reply) {
+	case opOAck:
+		oack = parseOAck(reply)
+		if size, ok := oack["blksize"]; ok {
+			blockSize, _ = strconv.Atoi(size)
+		}
+	case opAck:
+	default:
+		return nil, parseError(reply)
+	}
+
+	for block := uint16(1); ; block++ {
+		chunk := content
+		if len(chunk) > blockSize {
+			chunk = chunk[:blockSize]
+		}
+		content = content[len(chunk):]
+
+		packet := make([]byte, 4, 4+len(chunk))
+		binary.BigEndian.PutUint16(packet, opData)
+		binary.BigEndian.PutUint16(packet[2:], block)
+		c.send(append(packet, chunk...))
+
+		reply := c.receive()
+		if binary.BigEndian.Uint16(reply) != opAck {
+			return oack, parseError(reply)
+		}
+
+		if len(chunk) < blockSize {
+			return oack, nil
+		}
+	}
+}
+
+func request(op uint16, filename string, fields ...string) []byte {
+	packet := binary.BigEndian.AppendUint16(nil, op)
+	for _, field := range append([]string{filename}, fields...) {
+		packet = append(packet, field...)
+		packet = append(packet, 0)
+	}
+	return packet
+}
+
+func ack(block uint16) []byte {
+	return binary.BigEndian.AppendUint16([]byte{0, opAck}, block)
+}
+
+func parseOAck(packet []byte) map[string]string {
+	fields := bytes.Split(bytes.TrimSuffix(packet[2:], []byte{0}), []byte{0})
+	options := make(map[string]string, len(fields)/2)
+	for idx := 0; idx+1 < len(fields); idx += 2 {
+		options[string(fields[idx])] = string(fields[idx+1])
+	}
+	return options
+}
+
+func parseError(packet []byte) *tftpError {
+	return &tftpError{
+		Code:    binary.BigEndian.Uint16(packet[2:]),
+		Message: string(bytes.TrimSuffix(packet[4:], []byte{0})),
+	}
+}
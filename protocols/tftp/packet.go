@@ -0,0 +1,170 @@
+package tftp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type opcode uint16
+
+const (
+	opRRQ opcode = iota + 1
+	opWRQ
+	opData
+	opAck
+	opError
+	opOAck
+)
+
+type errorCode uint16
+
+const (
+	errCodeNotDefined        errorCode = 0
+	errCodeFileNotFound      errorCode = 1
+	errCodeAccessViolation   errorCode = 2
+	errCodeDiskFull          errorCode = 3
+	errCodeIllegalOperation  errorCode = 4
+	errCodeUnknownTID        errorCode = 5
+	errCodeOptionNegotiation errorCode = 8
+	maxErrorCode             errorCode = errCodeOptionNegotiation
+)
+
+const (
+	headerLen        = 4
+	defaultBlockSize = 512
+	minBlockSize     = 8
+	maxBlockSize     = 65464
+	maxPacketSize    = headerLen + maxBlockSize
+	maxTimeoutOption = 255
+
+	modeNetASCII = "netascii"
+	modeOctet    = "octet"
+
+	optionBlockSize    = "blksize"
+	optionTransferSize = "tsize"
+	optionTimeout      = "timeout"
+)
+
+var errMalformedPacket = errors.New("malformed TFTP packet")
+
+type (
+	// request is a parsed RRQ or WRQ including the options of RFC 2347, option names are lower case
+	request struct {
+		op       opcode
+		filename string
+		mode     string
+		options  map[string]string
+	}
+
+	// option is a negotiated option acknowledged in an OACK
+	option struct {
+		name, value string
+	}
+
+	// remoteError is an ERROR packet received from the client
+	remoteError struct {
+		code    errorCode
+		message string
+	}
+)
+
+func (e remoteError) Error() string {
+	return fmt.Sprintf("client aborted transfer with error %d: %s", e.code, e.message)
+}
+
+func parseRequest(packet []byte) (req request, err error) {
+	if len(packet) < 2 {
+		return req, errMalformedPacket
+	}
+
+	req.op = opcode(binary.BigEndian.Uint16(packet))
+	fields := bytes.Split(packet[2:], []byte{0})
+
+	// every field is terminated by a zero byte hence the last element is always empty
+	if len(fields) < 3 || len(fields)%2 == 0 || len(fields[len(fields)-1]) != 0 {
+		return req, errMalformedPacket
+	}
+
+	req.filename = string(fields[0])
+	req.mode = strings.ToLower(string(fields[1]))
+	if req.filename == "" {
+		return req, errMalformedPacket
+	}
+
+	req.options = make(map[string]string)
+	for idx := 2; idx < len(fields)-1; idx += 2 {
+		req.options[strings.ToLower(string(fields[idx]))] = string(fields[idx+1])
+	}
+
+	return req, nil
+}
+
+// parseHeader returns the opcode and the block number of a DATA or ACK packet
+func parseHeader(packet []byte) (op opcode, block uint16, ok bool) {
+	if len(packet) < headerLen {
+		return 0, 0, false
+	}
+	return opcode(binary.BigEndian.Uint16(packet)), binary.BigEndian.Uint16(packet[2:]), true
+}
+
+func parseError(packet []byte) remoteError {
+	_, code, _ := parseHeader(packet)
+	var msg string
+	if len(packet) > headerLen {
+		msg = string(bytes.TrimRight(packet[headerLen:], "\x00"))
+	}
+	return remoteError{code: errorCode(code), message: msg}
+}
+
+func encodeData(block uint16, data []byte) []byte {
+	packet := make([]byte, headerLen, headerLen+len(data))
+	binary.BigEndian.PutUint16(packet, uint16(opData))
+	binary.BigEndian.PutUint16(packet[2:], block)
+	return append(packet, data...)
+}
+
+func encodeAck(block uint16) []byte {
+	packet := make([]byte, headerLen)
+	binary.BigEndian.PutUint16(packet, uint16(opAck))
+	binary.BigEndian.PutUint16(packet[2:], block)
+	return packet
+}
+
+func encodeError(code errorCode, msg string) []byte {
+	packet := make([]byte, headerLen, headerLen+len(msg)+1)
+	binary.BigEndian.PutUint16(packet, uint16(opError))
+	binary.BigEndian.PutUint16(packet[2:], uint16(code))
+	packet = append(packet, msg...)
+	return append(packet, 0)
+}
+
+func encodeOAck(options []option) []byte {
+	packet := make([]byte, 2)
+	binary.BigEndian.PutUint16(packet, uint16(opOAck))
+	for idx := range options {
+		packet = append(packet, options[idx].name...)
+		packet = append(packet, 0)
+		packet = append(packet, options[idx].value...)
+		packet = append(packet, 0)
+	}
+	return packet
+}
+
+// parseIntOption returns the value of the given option if it was requested and is a number within [lower, upper]
+func (r request) parseIntOption(name string, lower, upper int64) (int64, bool) {
+	raw, ok := r.options[name]
+	if !ok {
+		return 0, false
+	}
+
+	val, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || val < lower || val > upper {
+		return 0, false
+	}
+
+	return val, true
+}
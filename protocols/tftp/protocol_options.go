@@ -0,0 +1,67 @@
+package tftp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/quarantine"
+)
+
+const (
+	defaultTimeout               = 5 * time.Second
+	defaultRetries               = 5
+	defaultMaxUploadBytes        = 100 * 1024 * 1024
+	defaultMaxTransfers          = 64
+	defaultMaxTransfersPerClient = 4
+)
+
+type tftpOptions struct {
+	// Timeout until a packet is retransmitted, clients may negotiate a different one with the timeout option
+	Timeout time.Duration
+	// Retries is the number of retransmissions before a transfer is aborted
+	Retries        int
+	QuarantineDir  string
+	MaxUploadBytes int64
+	// MaxTransfers is the number of concurrent transfers of the handler, further requests are rejected
+	MaxTransfers int
+	// MaxTransfersPerClient is the number of concurrent transfers of a single IP address
+	MaxTransfersPerClient int
+	Rules                 []string
+}
+
+func loadFromConfig(startupSpec *endpoint.StartupSpec, quarantineDir string) (opts tftpOptions, err error) {
+	opts = tftpOptions{
+		Timeout:               defaultTimeout,
+		Retries:               defaultRetries,
+		MaxUploadBytes:        defaultMaxUploadBytes,
+		MaxTransfers:          defaultMaxTransfers,
+		MaxTransfersPerClient: defaultMaxTransfersPerClient,
+	}
+
+	if err = startupSpec.UnmarshalOptions(&opts, endpoint.WithDecodeHook(mapstructure.StringToTimeDurationHookFunc())); err != nil {
+		return opts, err
+	}
+
+	if opts.Timeout <= 0 {
+		return opts, fmt.Errorf("timeout has to be positive but was %s", opts.Timeout)
+	}
+
+	if opts.Retries < 0 {
+		return opts, fmt.Errorf("retries must not be negative but was %d", opts.Retries)
+	}
+
+	if opts.MaxTransfers <= 0 {
+		return opts, fmt.Errorf("maxTransfers has to be positive but was %d", opts.MaxTransfers)
+	}
+
+	if opts.MaxTransfersPerClient <= 0 {
+		return opts, fmt.Errorf("maxTransfersPerClient has to be positive but was %d", opts.MaxTransfersPerClient)
+	}
+
+	opts.QuarantineDir = quarantine.ResolveDir(quarantineDir, opts.QuarantineDir)
+
+	return opts, nil
+}
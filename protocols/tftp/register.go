@@ -0,0 +1,30 @@
+package tftp
+
+import (
+	"io/fs"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	"inetmock.icb4dc0.de/inetmock/pkg/logging"
+)
+
+func New(logger logging.Logger, emitter audit.Emitter, fakeFileFS fs.FS, quarantineDir string) endpoint.ProtocolHandler {
+	return &tftpHandler{
+		logger:        logger,
+		emitter:       emitter,
+		fakeFileFS:    fakeFileFS,
+		quarantineDir: quarantineDir,
+	}
+}
+
+func AddTFTPMock(
+	registry endpoint.HandlerRegistry,
+	logger logging.Logger,
+	emitter audit.Emitter,
+	fakeFileFS fs.FS,
+	quarantineDir string,
+) {
+	registry.RegisterHandler(name, func() endpoint.ProtocolHandler {
+		return New(logger, emitter, fakeFileFS, quarantineDir)
+	})
+}
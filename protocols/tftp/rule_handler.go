@@ -0,0 +1,229 @@
+package tftp
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"inetmock.icb4dc0.de/inetmock/internal/rules"
+	"inetmock.icb4dc0.de/inetmock/protocols"
+)
+
+const (
+	operationRead  = "read"
+	operationWrite = "write"
+
+	defaultRejectMessage = "Access violation"
+)
+
+var (
+	ErrUnknownOperation = errors.New("unknown TFTP operation")
+
+	knownRequestFilters = map[string]func(args ...rules.Param) (RequestFilter, error){
+		"path":      PathFilter,
+		"operation": OperationFilter,
+	}
+	knownVerdicts = map[string]func(args ...rules.Param) (Verdict, error){
+		"file":   FileVerdict,
+		"reject": RejectVerdict,
+	}
+)
+
+type (
+	// Request contains the information a rule may match on.
+	// Path is the requested filename as absolute slash separated path, Operation is either read or write.
+	Request struct {
+		Operation string
+		Path      string
+	}
+	RequestFilter interface {
+		Matches(req Request) bool
+	}
+	RequestFilterFunc func(req Request) bool
+	FilterChain       []RequestFilter
+
+	// Verdict either serves a fake file for a read request or rejects the transfer with a TFTP error.
+	// Write requests are only affected by rejecting verdicts.
+	Verdict struct {
+		File    string
+		Reject  bool
+		Code    uint16
+		Message string
+	}
+
+	ConditionalVerdict struct {
+		Filters FilterChain
+		Verdict Verdict
+	}
+)
+
+func (f RequestFilterFunc) Matches(req Request) bool {
+	return f(req)
+}
+
+func (c FilterChain) Matches(req Request) bool {
+	for idx := range c {
+		if !c[idx].Matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+type RuleHandler struct {
+	HandlerName string
+	verdicts    rules.Set[ConditionalVerdict]
+}
+
+func (h *RuleHandler) RegisterRule(rawRule string) error {
+	verdict, err := compileRule(rawRule)
+	if err != nil {
+		return err
+	}
+
+	h.verdicts.Append(rawRule, verdict)
+	return nil
+}
+
+// RuleManager exposes the read and write rules, transfers in progress are not affected.
+func (h *RuleHandler) RuleManager() rules.Manager {
+	return rules.CompilingManager[ConditionalVerdict]{
+		Set:     &h.verdicts,
+		Compile: compileRule,
+	}
+}
+
+// Evaluate returns the verdict of the first rule matching the given request
+func (h *RuleHandler) Evaluate(req Request, client string) (verdict Verdict, matched bool) {
+	verdicts := h.verdicts.Entries()
+	for idx := range verdicts {
+		entry := verdicts[idx]
+		if entry.Value.Filters.Matches(req) {
+			protocols.RecordRuleMatch(entry, name, h.HandlerName, client)
+			return entry.Value.Verdict, true
+		}
+	}
+
+	return verdict, false
+}
+
+func compileRule(rawRule string) (verdict ConditionalVerdict, err error) {
+	var rule *rules.SingleResponsePipeline
+	if rule, err = rules.Parse[rules.SingleResponsePipeline](rawRule); err != nil {
+		return verdict, err
+	}
+
+	if verdict.Filters, err = filtersForRule(rule); err != nil {
+		return verdict, err
+	}
+
+	if rule.Response == nil {
+		return verdict, rules.ErrNoTerminatorDefined
+	}
+
+	if constructor, ok := knownVerdicts[strings.ToLower(rule.Response.Name)]; !ok {
+		return verdict, fmt.Errorf("%w %s", rules.ErrUnknownTerminator, rule.Response.Name)
+	} else if verdict.Verdict, err = constructor(rule.Response.Params...); err != nil {
+		return verdict, err
+	}
+
+	return verdict, nil
+}
+
+func filtersForRule(rule rules.FilteredPipeline) (filters FilterChain, err error) {
+	chain := rule.Filters()
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	filters = make(FilterChain, len(chain))
+	for idx := range chain {
+		constructor, ok := knownRequestFilters[strings.ToLower(chain[idx].Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", rules.ErrUnknownFilterMethod, chain[idx].Name)
+		}
+		if filters[idx], err = constructor(chain[idx].Params...); err != nil {
+			return nil, err
+		}
+	}
+
+	return filters, nil
+}
+
+// PathFilter matches the requested filename as absolute path e.g. Path(`^/pxelinux\.cfg/`)
+func PathFilter(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	var exp *regexp.Regexp
+	if rawExp, err := args[0].AsString(); err != nil {
+		return nil, err
+	} else if exp, err = regexp.Compile(rawExp); err != nil {
+		return nil, err
+	}
+
+	return RequestFilterFunc(func(req Request) bool {
+		return exp.MatchString(req.Path)
+	}), nil
+}
+
+// OperationFilter matches either read or write requests e.g. Operation("write")
+func OperationFilter(args ...rules.Param) (RequestFilter, error) {
+	if err := rules.ValidateParameterCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	operation, err := args[0].AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation = strings.ToLower(operation); operation {
+	case operationRead, operationWrite:
+		return RequestFilterFunc(func(req Request) bool {
+			return req.Operation == operation
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOperation, operation)
+	}
+}
+
+// FileVerdict serves the given file of the fake files directory e.g. File("pxelinux.0")
+func FileVerdict(args ...rules.Param) (verdict Verdict, err error) {
+	if err = rules.ValidateParameterCount(args, 1); err != nil {
+		return verdict, err
+	}
+
+	verdict.File, err = args[0].AsString()
+	return verdict, err
+}
+
+// RejectVerdict aborts a transfer with an optional TFTP error code and message e.g. Reject(1, "File not found")
+func RejectVerdict(args ...rules.Param) (verdict Verdict, err error) {
+	verdict = Verdict{
+		Reject:  true,
+		Code:    uint16(errCodeAccessViolation),
+		Message: defaultRejectMessage,
+	}
+
+	if len(args) > 0 {
+		var code int
+		if code, err = args[0].AsInt(); err != nil {
+			return verdict, err
+		}
+		if code < 0 || code > int(maxErrorCode) {
+			return verdict, fmt.Errorf("%w: error code %d is not a TFTP error code", rules.ErrTypeMismatch, code)
+		}
+		verdict.Code = uint16(code)
+	}
+
+	if len(args) > 1 {
+		if verdict.Message, err = args[1].AsString(); err != nil {
+			return verdict, err
+		}
+	}
+
+	return verdict, nil
+}
@@ -0,0 +1,361 @@
+package tftp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"inetmock.icb4dc0.de/inetmock/internal/netutils"
+	"inetmock.icb4dc0.de/inetmock/pkg/audit"
+	auditv1 "inetmock.icb4dc0.de/inetmock/pkg/audit/v1"
+	"inetmock.icb4dc0.de/inetmock/protocols/quarantine"
+)
+
+var (
+	errTransferTimeout = errors.New("transfer timed out")
+	errTransferAborted = errors.New("transfer aborted")
+)
+
+// transfer is a single read or write request, all packets of the client are passed to it by the handler.
+// Only the transfer's own goroutine touches its state except for the incoming channel.
+type transfer struct {
+	handler   *tftpHandler
+	ctx       context.Context
+	cancel    context.CancelFunc
+	remote    net.Addr
+	req       request
+	raw       []byte
+	path      string
+	incoming  chan []byte
+	blockSize int
+	timeout   time.Duration
+	bytes     int64
+	errCode   errorCode
+	completed bool
+	// finalAck is retransmitted if the client repeats the last block of a write because the ACK got lost
+	finalAck   []byte
+	finalBlock uint16
+}
+
+func newTransfer(parent context.Context, h *tftpHandler, remote net.Addr, req request, raw []byte) *transfer {
+	ctx, cancel := context.WithCancel(parent)
+	return &transfer{
+		handler:   h,
+		ctx:       ctx,
+		cancel:    cancel,
+		remote:    remote,
+		req:       req,
+		raw:       raw,
+		path:      requestPath(req.filename),
+		incoming:  make(chan []byte, incomingBacklog),
+		blockSize: defaultBlockSize,
+		timeout:   h.options.Timeout,
+	}
+}
+
+// requestPath converts the requested filename to an absolute slash separated path, some clients send backslashes
+func requestPath(filename string) string {
+	return path.Clean("/" + strings.ReplaceAll(filename, `\`, "/"))
+}
+
+// fsPath converts an absolute path to a path within the fake files directory
+func fsPath(p string) string {
+	if p = strings.TrimPrefix(p, "/"); p != "" {
+		return p
+	}
+	return "."
+}
+
+func (t *transfer) deliver(packet []byte) {
+	select {
+	case t.incoming <- packet:
+	default:
+	}
+}
+
+func (t *transfer) run() {
+	defer t.cancel()
+
+	var err error
+	switch {
+	case t.req.mode != modeOctet && t.req.mode != modeNetASCII:
+		err = t.fail(errCodeIllegalOperation, fmt.Sprintf("Unsupported mode %s", t.req.mode))
+	case t.req.op == opRRQ:
+		err = t.read()
+	default:
+		err = t.write()
+	}
+
+	if err != nil {
+		t.handler.logger.Debug("TFTP transfer failed", zap.String("remote", t.remote.String()), zap.String("filename", t.req.filename), zap.Error(err))
+	}
+
+	t.emit()
+	t.dally()
+}
+
+// read serves a file, netascii transfers are served unchanged
+func (t *transfer) read() error {
+	file, info, err := t.open()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if options := t.negotiate(info.Size()); len(options) > 0 {
+		if _, err = t.exchange(encodeOAck(options), opAck, 0); err != nil {
+			return err
+		}
+	}
+
+	buf := make([]byte, t.blockSize)
+	for block := uint16(1); ; block++ {
+		n, readErr := io.ReadFull(file, buf)
+		if readErr != nil && !errors.Is(readErr, io.EOF) && !errors.Is(readErr, io.ErrUnexpectedEOF) {
+			return errors.Join(readErr, t.fail(errCodeNotDefined, "Failed to read file"))
+		}
+
+		// block numbers roll over to 0 after 65535 like most clients expect it
+		if _, err = t.exchange(encodeData(block, buf[:n]), opAck, block); err != nil {
+			return err
+		}
+
+		t.bytes += int64(n)
+		if n < t.blockSize {
+			t.completed = true
+			return nil
+		}
+	}
+}
+
+// open returns the file the first matching rule refers to or the file with the requested path if no rule matches
+func (t *transfer) open() (fs.File, fs.FileInfo, error) {
+	filePath := fsPath(t.path)
+	if verdict, matched := t.handler.ruleHandler.Evaluate(Request{Operation: operationRead, Path: t.path}, t.client()); matched {
+		if verdict.Reject {
+			return nil, nil, t.fail(errorCode(verdict.Code), verdict.Message)
+		}
+		filePath = verdict.File
+	}
+
+	file, err := t.handler.fakeFileFS.Open(filePath)
+	if err != nil {
+		return nil, nil, errors.Join(err, t.fail(errCodeFileNotFound, "File not found"))
+	}
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		_ = file.Close()
+		return nil, nil, errors.Join(err, t.fail(errCodeFileNotFound, "File not found"))
+	}
+
+	return file, info, nil
+}
+
+// write stores the received file in the quarantine directory
+func (t *transfer) write() error {
+	if verdict, matched := t.handler.ruleHandler.Evaluate(Request{Operation: operationWrite, Path: t.path}, t.client()); matched && verdict.Reject {
+		return t.fail(errorCode(verdict.Code), verdict.Message)
+	}
+
+	announced, ok := t.req.parseIntOption(optionTransferSize, 0, math.MaxInt64)
+	switch {
+	case !ok:
+		announced = -1
+	case announced > t.handler.options.MaxUploadBytes:
+		return t.fail(errCodeDiskFull, "File exceeds the maximum size")
+	}
+
+	reply := encodeAck(0)
+	if options := t.negotiate(announced); len(options) > 0 {
+		reply = encodeOAck(options)
+	}
+
+	upload, uploadWriter := io.Pipe()
+	saved := make(chan error, 1)
+	go func() {
+		stored, size, err := t.handler.quarantine.Save(t.path, upload)
+		_ = upload.CloseWithError(err)
+		if err == nil {
+			t.handler.logger.Info("Stored uploaded file", zap.String("path", t.path), zap.String("stored", stored), zap.Int64("size", size))
+		}
+		saved <- err
+	}()
+
+	for block := uint16(1); ; block++ {
+		packet, err := t.exchange(reply, opData, block)
+		if err != nil {
+			_ = uploadWriter.CloseWithError(err)
+			<-saved
+			return err
+		}
+
+		data := packet[headerLen:]
+		if len(data) > t.blockSize {
+			_ = uploadWriter.CloseWithError(errMalformedPacket)
+			<-saved
+			return t.fail(errCodeIllegalOperation, "Block exceeds the negotiated block size")
+		}
+
+		if _, err = uploadWriter.Write(data); err != nil {
+			return t.failUpload(<-saved)
+		}
+
+		t.bytes += int64(len(data))
+		reply = encodeAck(block)
+
+		if len(data) < t.blockSize {
+			_ = uploadWriter.Close()
+			if err = <-saved; err != nil {
+				return t.failUpload(err)
+			}
+
+			t.handler.send(t.remote, reply)
+			t.finalAck, t.finalBlock = reply, block
+			t.completed = true
+			return nil
+		}
+	}
+}
+
+func (t *transfer) failUpload(err error) error {
+	if errors.Is(err, quarantine.ErrUploadTooLarge) {
+		return errors.Join(err, t.fail(errCodeDiskFull, "File exceeds the maximum size"))
+	}
+
+	t.handler.logger.Error("Failed to store uploaded file", zap.String("path", t.path), zap.Error(err))
+	return errors.Join(err, t.fail(errCodeNotDefined, "Failed to store file"))
+}
+
+// negotiate applies the options of RFC 2348 and RFC 2349 the client requested and returns the options to acknowledge.
+// A negative transfer size is never acknowledged.
+func (t *transfer) negotiate(transferSize int64) (acknowledged []option) {
+	if blockSize, ok := t.req.parseIntOption(optionBlockSize, minBlockSize, math.MaxInt32); ok {
+		// the server may choose a smaller block size than requested
+		t.blockSize = maxBlockSize
+		if blockSize < maxBlockSize {
+			t.blockSize = int(blockSize)
+		}
+		acknowledged = append(acknowledged, option{name: optionBlockSize, value: strconv.Itoa(t.blockSize)})
+	}
+
+	if timeout, ok := t.req.parseIntOption(optionTimeout, 1, maxTimeoutOption); ok {
+		t.timeout = time.Duration(timeout) * time.Second
+		acknowledged = append(acknowledged, option{name: optionTimeout, value: strconv.FormatInt(timeout, 10)})
+	}
+
+	if _, ok := t.req.options[optionTransferSize]; ok && transferSize >= 0 {
+		acknowledged = append(acknowledged, option{name: optionTransferSize, value: strconv.FormatInt(transferSize, 10)})
+	}
+
+	return acknowledged
+}
+
+// exchange sends the given packet until the expected reply is received or all retries are exhausted
+func (t *transfer) exchange(packet []byte, expected opcode, block uint16) ([]byte, error) {
+	for attempt := 0; attempt <= t.handler.options.Retries; attempt++ {
+		t.handler.send(t.remote, packet)
+		if reply, err := t.await(expected, block); !errors.Is(err, errTransferTimeout) {
+			return reply, err
+		}
+	}
+
+	return nil, errTransferTimeout
+}
+
+func (t *transfer) await(expected opcode, block uint16) ([]byte, error) {
+	timer := time.NewTimer(t.timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return nil, t.ctx.Err()
+		case <-timer.C:
+			return nil, errTransferTimeout
+		case packet := <-t.incoming:
+			op, received, ok := parseHeader(packet)
+			switch {
+			case ok && op == opError:
+				remoteErr := parseError(packet)
+				t.errCode = remoteErr.code
+				return nil, remoteErr
+			case ok && op == expected && received == block:
+				return packet, nil
+			}
+			// duplicates of previous packets are ignored to avoid the Sorcerer's Apprentice Syndrome
+		}
+	}
+}
+
+// dally keeps the transfer alive for another timeout period to acknowledge retransmissions of the last block
+func (t *transfer) dally() {
+	if t.finalAck == nil {
+		return
+	}
+
+	for {
+		if _, err := t.await(opData, t.finalBlock); err != nil {
+			return
+		}
+		t.handler.send(t.remote, t.finalAck)
+	}
+}
+
+// fail sends an ERROR packet to the client which terminates the transfer
+func (t *transfer) fail(code errorCode, msg string) error {
+	t.errCode = code
+	t.handler.send(t.remote, encodeError(code, msg))
+	return fmt.Errorf("%w: %s", errTransferAborted, msg)
+}
+
+func (t *transfer) client() string {
+	return clientIP(t.remote)
+}
+
+func clientIP(addr net.Addr) string {
+	if ip, _, err := netutils.IPPortFromAddress(addr); err == nil {
+		return ip.String()
+	}
+	return ""
+}
+
+func (t *transfer) emit() {
+	details := audit.TFTP{
+		Operation: auditv1.TFTPOperation_TFTP_OPERATION_READ,
+		Filename:  t.req.filename,
+		Mode:      t.req.mode,
+		BlockSize: uint32(t.blockSize),
+		Bytes:     t.bytes,
+		ErrorCode: uint32(t.errCode),
+		Completed: t.completed,
+	}
+
+	if t.req.op == opWRQ {
+		details.Operation = auditv1.TFTPOperation_TFTP_OPERATION_WRITE
+	}
+
+	builder := t.handler.emitter.Builder().
+		WithTransport(auditv1.TransportProtocol_TRANSPORT_PROTOCOL_UDP).
+		WithApplication(auditv1.AppProtocol_APP_PROTOCOL_TFTP).
+		WithProtocolDetails(details)
+
+	// it's considered to be okay if these details are missing
+	builder, _ = builder.WithSourceFromAddr(t.remote)
+	builder, _ = builder.WithDestinationFromAddr(t.handler.conn.LocalAddr())
+
+	builder.Emit()
+}
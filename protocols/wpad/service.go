@@ -0,0 +1,173 @@
+package wpad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	mdns "github.com/miekg/dns"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/dns"
+)
+
+const (
+	pacContentType = "application/x-ns-proxy-autoconfig"
+	wpadLabel      = "wpad"
+)
+
+var (
+	ErrMissingIP             = errors.New("WPAD requires the IP address clients use to reach the server")
+	ErrUnknownProxyListener  = errors.New("unknown proxy listener")
+	ErrUnsupportedProxyProto = errors.New("proxy listener has to use TCP")
+
+	// pacPaths are the well known paths clients request the PAC file from
+	pacPaths = []string{"/wpad.dat", "/proxy.pac"}
+)
+
+// Config is the single source of truth for the WPAD setup of all handlers
+type Config struct {
+	// IP is the address of INetMock as seen by the clients, WPAD names are resolved to it and the proxy is expected there
+	IP netip.Addr
+	// ProxyListener is the name of the listener running the http_proxy endpoint the PAC file points to
+	ProxyListener string
+	// Bypass contains shell expressions of hosts that are accessed directly e.g. *.local
+	Bypass []string
+	// URL of the PAC file announced via DHCP, defaults to http://<IP>/wpad.dat
+	URL string
+}
+
+// Enabled reports whether WPAD is configured at all
+func (c Config) Enabled() bool {
+	return c.IP.IsValid() || c.ProxyListener != ""
+}
+
+// Service serves the PAC file and answers WPAD names, a nil Service is valid and disables WPAD
+type Service struct {
+	ip  netip.Addr
+	url string
+	pac []byte
+}
+
+// New resolves the configured proxy listener and generates the PAC file
+func New(cfg Config, listeners map[string]endpoint.ListenerSpec) (*Service, error) {
+	if !cfg.IP.IsValid() {
+		return nil, ErrMissingIP
+	}
+
+	proxy, ok := listeners[cfg.ProxyListener]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProxyListener, cfg.ProxyListener)
+	}
+
+	// the address type covers tcp4 and tcp6 listeners as well
+	if addr, err := proxy.Addr(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrUnsupportedProxyProto, cfg.ProxyListener, err)
+	} else if _, isTCP := addr.(*net.TCPAddr); !isTCP {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProxyProto, cfg.ProxyListener)
+	}
+
+	ip := cfg.IP.Unmap()
+	s := &Service{
+		ip:  ip,
+		url: cfg.URL,
+		pac: generatePAC(net.JoinHostPort(ip.String(), strconv.Itoa(int(proxy.Port))), cfg.Bypass),
+	}
+
+	if s.url == "" {
+		host := ip.String()
+		if ip.Is6() {
+			host = "[" + host + "]"
+		}
+		s.url = "http://" + host + pacPaths[0]
+	}
+
+	return s, nil
+}
+
+// URL is the location of the PAC file as announced via DHCP option 252
+func (s *Service) URL() string {
+	return s.url
+}
+
+// PAC returns the generated proxy auto-config script
+func (s *Service) PAC() []byte {
+	return s.pac
+}
+
+// IsWPADName checks whether the first label of the given name is wpad e.g. wpad. or wpad.corp.local.
+func IsWPADName(name string) bool {
+	label, _, _ := strings.Cut(name, ".")
+	return strings.EqualFold(label, wpadLabel)
+}
+
+// HTTPHandler serves the PAC file on its well known paths and passes all other requests to next
+func (s *Service) HTTPHandler(next http.Handler) http.Handler {
+	if s == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if !isPACPath(request.URL.Path) {
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		writer.Header().Set("Content-Type", pacContentType)
+		writer.Header().Set("Content-Length", strconv.Itoa(len(s.pac)))
+		writer.WriteHeader(http.StatusOK)
+		if request.Method != http.MethodHead {
+			_, _ = writer.Write(s.pac)
+		}
+	})
+}
+
+// DNSHandler resolves WPAD names to the configured IP if the previous handler has no answer,
+// hence rules still take precedence
+func (s *Service) DNSHandler(previous dns.Handler, ttl time.Duration) dns.Handler {
+	if s == nil {
+		return previous
+	}
+
+	return dns.HandlerFunc(func(ctx context.Context, q dns.Question) (dns.ResourceRecord, error) {
+		rr, err := previous.AnswerDNSQuestion(ctx, q)
+		if err == nil || !IsWPADName(q.Name) {
+			return rr, err
+		}
+
+		switch {
+		case q.Qtype == mdns.TypeA && s.ip.Is4():
+			return &mdns.A{Hdr: dns.RRHeader(ttl, q), A: s.ip.AsSlice()}, nil
+		case q.Qtype == mdns.TypeAAAA && s.ip.Is6():
+			return &mdns.AAAA{Hdr: dns.RRHeader(ttl, q), AAAA: s.ip.AsSlice()}, nil
+		default:
+			return rr, err
+		}
+	})
+}
+
+func isPACPath(path string) bool {
+	for _, p := range pacPaths {
+		if strings.EqualFold(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func generatePAC(proxyAddr string, bypass []string) []byte {
+	var builder strings.Builder
+	builder.WriteString("function FindProxyForURL(url, host) {\n")
+	builder.WriteString("  if (isPlainHostName(host) || host === \"localhost\") {\n    return \"DIRECT\";\n  }\n")
+	for _, pattern := range bypass {
+		fmt.Fprintf(&builder, "  if (shExpMatch(host, %q)) {\n    return \"DIRECT\";\n  }\n", pattern)
+	}
+	fmt.Fprintf(&builder, "  return %q;\n}\n", "PROXY "+proxyAddr)
+	return []byte(builder.String())
+}
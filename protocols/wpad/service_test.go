@@ -0,0 +1,318 @@
+package wpad_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/td"
+	mdns "github.com/miekg/dns"
+
+	"inetmock.icb4dc0.de/inetmock/internal/endpoint"
+	"inetmock.icb4dc0.de/inetmock/protocols/dns"
+	"inetmock.icb4dc0.de/inetmock/protocols/wpad"
+)
+
+var listeners = map[string]endpoint.ListenerSpec{
+	"tcp_3128":  {Protocol: "tcp", Port: 3128},
+	"tcp6_3128": {Protocol: "tcp6", Address: "fd00::1", Port: 3128},
+	"udp_53":    {Protocol: "udp", Port: 53},
+	"sctp_3128": {Protocol: "sctp", Port: 3128},
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     wpad.Config
+		wantURL string
+		wantPAC string
+		wantErr error
+	}{
+		{
+			name: "Default URL",
+			cfg: wpad.Config{
+				IP:            netip.MustParseAddr("10.10.1.1"),
+				ProxyListener: "tcp_3128",
+			},
+			wantURL: "http://10.10.1.1/wpad.dat",
+			wantPAC: `function FindProxyForURL(url, host) {
+  if (isPlainHostName(host) || host === "localhost") {
+    return "DIRECT";
+  }
+  return "PROXY 10.10.1.1:3128";
+}
+`,
+		},
+		{
+			name: "Explicit URL and bypass",
+			cfg: wpad.Config{
+				IP:            netip.MustParseAddr("10.10.1.1"),
+				ProxyListener: "tcp_3128",
+				Bypass:        []string{"*.local"},
+				URL:           "http://wpad.corp.local/proxy.pac",
+			},
+			wantURL: "http://wpad.corp.local/proxy.pac",
+			wantPAC: `function FindProxyForURL(url, host) {
+  if (isPlainHostName(host) || host === "localhost") {
+    return "DIRECT";
+  }
+  if (shExpMatch(host, "*.local")) {
+    return "DIRECT";
+  }
+  return "PROXY 10.10.1.1:3128";
+}
+`,
+		},
+		{
+			name: "IPv6",
+			cfg: wpad.Config{
+				IP:            netip.MustParseAddr("fd00::1"),
+				ProxyListener: "tcp_3128",
+			},
+			wantURL: "http://[fd00::1]/wpad.dat",
+			wantPAC: `function FindProxyForURL(url, host) {
+  if (isPlainHostName(host) || host === "localhost") {
+    return "DIRECT";
+  }
+  return "PROXY [fd00::1]:3128";
+}
+`,
+		},
+		{
+			name: "IPv6 only proxy listener",
+			cfg: wpad.Config{
+				IP:            netip.MustParseAddr("fd00::1"),
+				ProxyListener: "tcp6_3128",
+			},
+			wantURL: "http://[fd00::1]/wpad.dat",
+			wantPAC: `function FindProxyForURL(url, host) {
+  if (isPlainHostName(host) || host === "localhost") {
+    return "DIRECT";
+  }
+  return "PROXY [fd00::1]:3128";
+}
+`,
+		},
+		{
+			name:    "Missing IP",
+			cfg:     wpad.Config{ProxyListener: "tcp_3128"},
+			wantErr: wpad.ErrMissingIP,
+		},
+		{
+			name: "Unknown proxy listener",
+			cfg: wpad.Config{
+				IP:            netip.MustParseAddr("10.10.1.1"),
+				ProxyListener: "tcp_8080",
+			},
+			wantErr: wpad.ErrUnknownProxyListener,
+		},
+		{
+			name: "UDP proxy listener",
+			cfg: wpad.Config{
+				IP:            netip.MustParseAddr("10.10.1.1"),
+				ProxyListener: "udp_53",
+			},
+			wantErr: wpad.ErrUnsupportedProxyProto,
+		},
+		{
+			name: "Proxy listener with unknown protocol",
+			cfg: wpad.Config{
+				IP:            netip.MustParseAddr("10.10.1.1"),
+				ProxyListener: "sctp_3128",
+			},
+			wantErr: wpad.ErrUnsupportedProxyProto,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc, err := wpad.New(tt.cfg, listeners)
+			if tt.wantErr != nil {
+				td.Cmp(t, errors.Is(err, tt.wantErr), true)
+				return
+			}
+
+			td.CmpNoError(t, err)
+			td.Cmp(t, svc.URL(), tt.wantURL)
+			td.Cmp(t, string(svc.PAC()), tt.wantPAC)
+		})
+	}
+}
+
+func TestService_HTTPHandler(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		service     *wpad.Service
+		method      string
+		path        string
+		wantStatus  int
+		wantHeaders http.Header
+		wantBody    any
+	}{
+		{
+			name:       "Serve wpad.dat",
+			service:    newService(t),
+			method:     http.MethodGet,
+			path:       "/wpad.dat",
+			wantStatus: http.StatusOK,
+			wantHeaders: http.Header{
+				"Content-Type": []string{"application/x-ns-proxy-autoconfig"},
+			},
+			wantBody: td.Contains(`return "PROXY 10.10.1.1:3128";`),
+		},
+		{
+			name:       "Serve proxy.pac",
+			service:    newService(t),
+			method:     http.MethodGet,
+			path:       "/proxy.pac",
+			wantStatus: http.StatusOK,
+			wantHeaders: http.Header{
+				"Content-Type": []string{"application/x-ns-proxy-autoconfig"},
+			},
+			wantBody: td.Contains("FindProxyForURL"),
+		},
+		{
+			name:        "HEAD request without body",
+			service:     newService(t),
+			method:      http.MethodHead,
+			path:        "/wpad.dat",
+			wantStatus:  http.StatusOK,
+			wantHeaders: http.Header{},
+			wantBody:    "",
+		},
+		{
+			name:        "Pass other paths to next handler",
+			service:     newService(t),
+			method:      http.MethodGet,
+			path:        "/index.html",
+			wantStatus:  http.StatusTeapot,
+			wantHeaders: http.Header{},
+			wantBody:    "",
+		},
+		{
+			name:        "Disabled service",
+			method:      http.MethodGet,
+			path:        "/wpad.dat",
+			wantStatus:  http.StatusTeapot,
+			wantHeaders: http.Header{},
+			wantBody:    "",
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			next := http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				writer.WriteHeader(http.StatusTeapot)
+			})
+
+			recorder := httptest.NewRecorder()
+			tt.service.HTTPHandler(next).ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+
+			td.Cmp(t, recorder.Code, tt.wantStatus)
+			td.Cmp(t, recorder.Header(), td.SuperMapOf(tt.wantHeaders, nil))
+			td.Cmp(t, recorder.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestService_DNSHandler(t *testing.T) {
+	t.Parallel()
+	ruleAnswer := &mdns.A{A: net.IPv4(1, 1, 1, 1)}
+	tests := []struct {
+		name     string
+		service  *wpad.Service
+		previous dns.Handler
+		question dns.Question
+		want     any
+		wantErr  error
+	}{
+		{
+			name:     "Answer wpad name",
+			service:  newService(t),
+			previous: noAnswer(),
+			question: dns.Question{Name: "wpad.", Qtype: mdns.TypeA, Qclass: mdns.ClassINET},
+			want: td.Struct(new(mdns.A), td.StructFields{
+				"A": net.IPv4(10, 10, 1, 1).To4(),
+			}),
+		},
+		{
+			name:     "Answer wpad name in search domain",
+			service:  newService(t),
+			previous: noAnswer(),
+			question: dns.Question{Name: "WPAD.corp.local.", Qtype: mdns.TypeA, Qclass: mdns.ClassINET},
+			want: td.Struct(new(mdns.A), td.StructFields{
+				"A": net.IPv4(10, 10, 1, 1).To4(),
+			}),
+		},
+		{
+			name:    "Rules take precedence",
+			service: newService(t),
+			previous: dns.HandlerFunc(func(context.Context, dns.Question) (dns.ResourceRecord, error) {
+				return ruleAnswer, nil
+			}),
+			question: dns.Question{Name: "wpad.", Qtype: mdns.TypeA, Qclass: mdns.ClassINET},
+			want:     td.Shallow(ruleAnswer),
+		},
+		{
+			name:     "Ignore other names",
+			service:  newService(t),
+			previous: noAnswer(),
+			question: dns.Question{Name: "wpadding.corp.local.", Qtype: mdns.TypeA, Qclass: mdns.ClassINET},
+			wantErr:  dns.ErrNoAnswerForQuestion,
+		},
+		{
+			name:     "No AAAA answer for IPv4 address",
+			service:  newService(t),
+			previous: noAnswer(),
+			question: dns.Question{Name: "wpad.", Qtype: mdns.TypeAAAA, Qclass: mdns.ClassINET},
+			wantErr:  dns.ErrNoAnswerForQuestion,
+		},
+		{
+			name:     "Disabled service",
+			previous: noAnswer(),
+			question: dns.Question{Name: "wpad.", Qtype: mdns.TypeA, Qclass: mdns.ClassINET},
+			wantErr:  dns.ErrNoAnswerForQuestion,
+		},
+	}
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr, err := tt.service.DNSHandler(tt.previous, 30*time.Second).AnswerDNSQuestion(context.Background(), tt.question)
+			if tt.wantErr != nil {
+				td.Cmp(t, errors.Is(err, tt.wantErr), true)
+				return
+			}
+
+			td.CmpNoError(t, err)
+			td.Cmp(t, rr, tt.want)
+		})
+	}
+}
+
+func newService(tb testing.TB) *wpad.Service {
+	tb.Helper()
+	svc, err := wpad.New(wpad.Config{
+		IP:            netip.MustParseAddr("10.10.1.1"),
+		ProxyListener: "tcp_3128",
+	}, listeners)
+	if err != nil {
+		tb.Fatalf("wpad.New() error = %v", err)
+	}
+	return svc
+}
+
+func noAnswer() dns.Handler {
+	return dns.HandlerFunc(func(context.Context, dns.Question) (dns.ResourceRecord, error) {
+		return nil, dns.ErrNoAnswerForQuestion
+	})
+}